@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel/auto"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/east-eden/server/services/game/hero"
+	"github.com/east-eden/server/services/game/item"
+)
+
+// 阵容配置:
+// 英雄之间用逗号分隔, 每个英雄为 typeId[:level[:promote[:star]]], 后面用+连接装备 equipTypeId[:level[:promote]]
+// 例如: 1:30:2:1+20001:20:1+20002,2:30
+
+type EquipSpec struct {
+	TypeId  int32
+	Level   int32
+	Promote int32
+}
+
+type HeroSpec struct {
+	TypeId  int32
+	Level   int32
+	Promote int32
+	Star    int32
+	Equips  []EquipSpec
+}
+
+func parseFields(s string, fields ...*int32) error {
+	values := strings.Split(s, ":")
+	if len(values) > len(fields) {
+		return fmt.Errorf("too many fields in <%s>", s)
+	}
+
+	for n, v := range values {
+		value, err := strconv.ParseInt(strings.TrimSpace(v), 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid field <%s> in <%s>: %w", v, s, err)
+		}
+
+		*fields[n] = int32(value)
+	}
+
+	return nil
+}
+
+func ParseLineup(s string) ([]*HeroSpec, error) {
+	specs := make([]*HeroSpec, 0, 5)
+	if len(strings.TrimSpace(s)) == 0 {
+		return specs, nil
+	}
+
+	for _, heroStr := range strings.Split(s, ",") {
+		parts := strings.Split(heroStr, "+")
+		spec := &HeroSpec{Level: 1, Equips: make([]EquipSpec, 0, len(parts)-1)}
+		if err := parseFields(parts[0], &spec.TypeId, &spec.Level, &spec.Promote, &spec.Star); err != nil {
+			return nil, err
+		}
+
+		for _, equipStr := range parts[1:] {
+			equip := EquipSpec{Level: 1}
+			if err := parseFields(equipStr, &equip.TypeId, &equip.Level, &equip.Promote); err != nil {
+				return nil, err
+			}
+
+			spec.Equips = append(spec.Equips, equip)
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
+// 按game的英雄属性计算规则生成战斗单位, 需要在读取静态表之后调用
+func BuildLineup(specs []*HeroSpec) ([]*pbGlobal.EntityInfo, error) {
+	globalConfig, ok := auto.GetGlobalConfig()
+	if !ok {
+		return nil, auto.ErrGlobalConfigInvalid
+	}
+
+	list := make([]*pbGlobal.EntityInfo, 0, len(specs))
+	for idx, spec := range specs {
+		heroEntry, ok := auto.GetHeroEntry(spec.TypeId)
+		if !ok {
+			return nil, fmt.Errorf("invalid hero type_id<%d>", spec.TypeId)
+		}
+
+		if spec.Promote < 0 || int(spec.Promote) >= len(globalConfig.HeroPromoteIntensityRatio) {
+			return nil, fmt.Errorf("invalid hero<%d> promote<%d>", spec.TypeId, spec.Promote)
+		}
+
+		h := hero.NewHero()
+		h.Init(
+			hero.Id(int64(idx+1)),
+			hero.TypeId(spec.TypeId),
+			hero.Level(int16(spec.Level)),
+			hero.PromoteLevel(int8(spec.Promote)),
+			hero.Star(int8(spec.Star)),
+			hero.Entry(heroEntry),
+		)
+		h.GetAttManager().SetBaseAttId(heroEntry.AttId)
+
+		for n, equipSpec := range spec.Equips {
+			e, err := buildEquip(int64(idx*int(define.Equip_Pos_End)+n+1), equipSpec)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := h.GetEquipBar().PutonEquip(e); err != nil {
+				return nil, fmt.Errorf("hero<%d> equip<%d>: %w", spec.TypeId, equipSpec.TypeId, err)
+			}
+		}
+
+		list = append(list, h.GenEntityInfoPB())
+		hero.GetHeroPool().Put(h)
+	}
+
+	return list, nil
+}
+
+func buildEquip(id int64, spec EquipSpec) (*item.Equip, error) {
+	globalConfig, _ := auto.GetGlobalConfig()
+
+	itemEntry, ok := auto.GetItemEntry(spec.TypeId)
+	if !ok || itemEntry.Type != define.Item_TypeEquip {
+		return nil, fmt.Errorf("invalid equip type_id<%d>", spec.TypeId)
+	}
+
+	enchantEntry, ok := auto.GetEquipEnchantEntry(spec.TypeId)
+	if !ok {
+		return nil, fmt.Errorf("can not find EquipEnchantEntry<%d>", spec.TypeId)
+	}
+
+	if spec.Promote < 0 || int(spec.Promote) >= len(globalConfig.EquipPromoteIntensityRatio) {
+		return nil, fmt.Errorf("invalid equip<%d> promote<%d>", spec.TypeId, spec.Promote)
+	}
+
+	e := item.NewItem(define.Item_TypeEquip).(*item.Equip)
+	e.InitItem(
+		item.Id(id),
+		item.TypeId(spec.TypeId),
+		item.ItemEntry(itemEntry),
+	)
+
+	e.InitEquip(
+		item.EquipEnchantEntry(enchantEntry),
+		item.EquipLevel(int8(spec.Level)),
+		item.EquipPromote(int8(spec.Promote)),
+	)
+
+	e.GetAttManager().SetBaseAttId(enchantEntry.AttId)
+	return e, nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/east-eden/server/excel"
+	"github.com/east-eden/server/utils"
+	"github.com/east-eden/server/version"
+	"github.com/rs/zerolog"
+)
+
+// 离线模拟战斗, 用于数值平衡测试:
+// combat_sim -attack 1:30:2+20001:20,2:30 -defence 3:30,4:30 -times 10000
+// combat_sim -attack 1:30,2:30,3:30 -stage 101 -times 5000 -format json -output stage_101.json
+// combat_sim -attack 1:30,2:30 -stage 101 -excel_dir config/csv/ -excel_dir_b ../balance/csv/
+
+var (
+	excelDir  string // 静态表目录
+	excelDirB string // 对比用的静态表目录, 不为空时输出A/B对比
+	attack    string // 进攻方阵容
+	defence   string // 防守方阵容
+	sceneId   int    // 场景id
+	stageId   int    // 关卡id
+	times     int    // 模拟次数
+	seed      int64  // 起始随机种子
+	parallel  int    // 并行协程数
+	format    string // 输出格式: csv, json
+	output    string // 输出文件, 为空时输出到标准输出
+	logLevel  string // 日志等级
+)
+
+func init() {
+	flag.StringVar(&excelDir, "excel_dir", "config/csv/", "静态表目录")
+	flag.StringVar(&excelDirB, "excel_dir_b", "", "对比用的静态表目录, 不为空时输出A/B对比")
+	flag.StringVar(&attack, "attack", "", "进攻方阵容: typeId[:level[:promote[:star]]][+equipTypeId[:level[:promote]]], 逗号分隔")
+	flag.StringVar(&defence, "defence", "", "防守方阵容, 格式同attack, 指定关卡时可为空")
+	flag.IntVar(&sceneId, "scene", 1, "场景id")
+	flag.IntVar(&stageId, "stage", 0, "关卡id, 按关卡波次生成防守方怪物")
+	flag.IntVar(&times, "times", 1000, "模拟次数")
+	flag.Int64Var(&seed, "seed", 1, "起始随机种子, 第n次模拟使用seed+n")
+	flag.IntVar(&parallel, "parallel", runtime.NumCPU(), "并行协程数")
+	flag.StringVar(&format, "format", "csv", "输出格式: csv, json")
+	flag.StringVar(&output, "output", "", "输出文件, 为空时输出到标准输出")
+	flag.StringVar(&logLevel, "log_level", "fatal", "日志等级, 静态表和战斗中的错误日志较多, 默认不输出")
+}
+
+func main() {
+	utils.LDFlagsCheck(os.Args, version.Version, version.Help)
+
+	flag.Parse()
+
+	level, err := zerolog.ParseLevel(logLevel)
+	if err != nil {
+		exit("invalid log level: ", logLevel)
+	}
+	zerolog.SetGlobalLevel(level)
+
+	if format != "csv" && format != "json" {
+		exit("invalid format: ", format)
+	}
+
+	attackSpecs, err := ParseLineup(attack)
+	if err != nil {
+		exit("parse attack lineup failed: ", err)
+	}
+
+	defenceSpecs, err := ParseLineup(defence)
+	if err != nil {
+		exit("parse defence lineup failed: ", err)
+	}
+
+	opts := &SimOptions{
+		SceneId:  int32(sceneId),
+		StageId:  int32(stageId),
+		Attack:   attackSpecs,
+		Defence:  defenceSpecs,
+		Times:    times,
+		Seed:     seed,
+		Parallel: parallel,
+	}
+
+	// 静态表为全局数据, A/B两组依次读取和模拟
+	reportA, err := run(excelDir, opts)
+	if err != nil {
+		exit("simulate failed: ", err, excelDir)
+	}
+
+	var reportB *Report
+	if len(excelDirB) > 0 {
+		reportB, err = run(excelDirB, opts)
+		if err != nil {
+			exit("simulate failed: ", err, excelDirB)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if len(output) > 0 {
+		f, err := os.Create(output)
+		if err != nil {
+			exit("create output file failed: ", err, output)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch {
+	case reportB != nil && format == "json":
+		err = WriteJSON(w, Diff(reportA, reportB))
+	case reportB != nil:
+		err = WriteDiffCSV(w, Diff(reportA, reportB))
+	case format == "json":
+		err = WriteJSON(w, reportA)
+	default:
+		err = WriteCSV(w, reportA)
+	}
+
+	if err != nil {
+		exit("write report failed: ", err)
+	}
+}
+
+func run(dir string, opts *SimOptions) (*Report, error) {
+	if !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+
+	excel.ReadAllEntries(dir)
+
+	report, err := Simulate(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	report.ExcelDir = dir
+	return report, nil
+}
+
+func exit(args ...any) {
+	fmt.Fprintln(os.Stderr, args...)
+	os.Exit(1)
+}
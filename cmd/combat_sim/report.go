@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/east-eden/server/define"
+)
+
+// 模拟报告, 单位和技能数据均为场均值
+type Report struct {
+	ExcelDir  string         `json:"excel_dir"`
+	Times     int            `json:"times"`
+	Wins      int            `json:"wins"`
+	WinRate   float64        `json:"win_rate"`
+	AvgRounds float64        `json:"avg_rounds"`
+	Units     []*UnitReport  `json:"units"`
+	Skills    []*SkillReport `json:"skills"`
+}
+
+type UnitReport struct {
+	Camp       string  `json:"camp"`
+	TypeId     int32   `json:"type_id"`
+	IsMonster  bool    `json:"is_monster"`
+	AvgNum     float64 `json:"avg_num"`      // 场均出场数量
+	AvgDmgDone float64 `json:"avg_dmg_done"` // 场均造成伤害
+	AvgDmgRecv float64 `json:"avg_dmg_recv"` // 场均受到伤害
+	AvgHeal    float64 `json:"avg_heal"`     // 场均治疗
+	DeadRate   float64 `json:"dead_rate"`    // 死亡率
+}
+
+type SkillReport struct {
+	Camp      string  `json:"camp"`
+	TypeId    int32   `json:"type_id"`
+	IsMonster bool    `json:"is_monster"`
+	SkillId   int32   `json:"skill_id"`
+	AvgCast   float64 `json:"avg_cast"` // 场均施放次数
+}
+
+// A/B对比报告
+type DiffReport struct {
+	A    *Report    `json:"a"`
+	B    *Report    `json:"b"`
+	Rows []*DiffRow `json:"rows"`
+}
+
+// Row.Value为A的数值
+type DiffRow struct {
+	Row
+	B    float64 `json:"b"`
+	Diff float64 `json:"diff"`
+}
+
+// 报告中的一项数据
+type Row struct {
+	Metric  string  `json:"metric"`
+	Camp    string  `json:"camp,omitempty"`
+	Unit    string  `json:"unit,omitempty"`
+	SkillId int32   `json:"skill_id,omitempty"`
+	Value   float64 `json:"value"`
+}
+
+func (r *Row) key() string {
+	return fmt.Sprintf("%s|%s|%s|%d", r.Metric, r.Camp, r.Unit, r.SkillId)
+}
+
+func campName(camp int32) string {
+	if camp == define.Scene_Camp_Attack {
+		return "attack"
+	}
+
+	return "defence"
+}
+
+func unitName(typeId int32, isMonster bool) string {
+	if isMonster {
+		return fmt.Sprintf("monster_%d", typeId)
+	}
+
+	return fmt.Sprintf("hero_%d", typeId)
+}
+
+func (r *Report) Rows() []*Row {
+	rows := make([]*Row, 0, 3+len(r.Units)*5+len(r.Skills))
+	rows = append(rows,
+		&Row{Metric: "times", Value: float64(r.Times)},
+		&Row{Metric: "win_rate", Value: r.WinRate},
+		&Row{Metric: "avg_rounds", Value: r.AvgRounds},
+	)
+
+	for _, u := range r.Units {
+		name := unitName(u.TypeId, u.IsMonster)
+		rows = append(rows,
+			&Row{Metric: "avg_num", Camp: u.Camp, Unit: name, Value: u.AvgNum},
+			&Row{Metric: "avg_dmg_done", Camp: u.Camp, Unit: name, Value: u.AvgDmgDone},
+			&Row{Metric: "avg_dmg_recv", Camp: u.Camp, Unit: name, Value: u.AvgDmgRecv},
+			&Row{Metric: "avg_heal", Camp: u.Camp, Unit: name, Value: u.AvgHeal},
+			&Row{Metric: "dead_rate", Camp: u.Camp, Unit: name, Value: u.DeadRate},
+		)
+	}
+
+	for _, s := range r.Skills {
+		rows = append(rows, &Row{Metric: "avg_cast", Camp: s.Camp, Unit: unitName(s.TypeId, s.IsMonster), SkillId: s.SkillId, Value: s.AvgCast})
+	}
+
+	return rows
+}
+
+// 按A报告的顺序对比, 只在B中出现的数据排在最后
+func Diff(a, b *Report) *DiffReport {
+	d := &DiffReport{A: a, B: b}
+
+	rowsA, rowsB := a.Rows(), b.Rows()
+	indexB := make(map[string]*Row, len(rowsB))
+	for _, row := range rowsB {
+		indexB[row.key()] = row
+	}
+
+	d.Rows = make([]*DiffRow, 0, len(rowsA))
+	for _, row := range rowsA {
+		diff := &DiffRow{Row: *row}
+		if rb, ok := indexB[row.key()]; ok {
+			diff.B = rb.Value
+			delete(indexB, row.key())
+		}
+
+		diff.Diff = diff.B - diff.Value
+		d.Rows = append(d.Rows, diff)
+	}
+
+	for _, row := range rowsB {
+		if _, ok := indexB[row.key()]; !ok {
+			continue
+		}
+
+		diff := &DiffRow{Row: *row, B: row.Value, Diff: row.Value}
+		diff.Value = 0
+		d.Rows = append(d.Rows, diff)
+	}
+
+	return d
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 4, 64)
+}
+
+func rowFields(r *Row) []string {
+	skillId := ""
+	if r.SkillId != 0 {
+		skillId = strconv.Itoa(int(r.SkillId))
+	}
+
+	return []string{r.Metric, r.Camp, r.Unit, skillId}
+}
+
+func WriteCSV(w io.Writer, r *Report) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"metric", "camp", "unit", "skill_id", "value"})
+	for _, row := range r.Rows() {
+		_ = cw.Write(append(rowFields(row), formatFloat(row.Value)))
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func WriteDiffCSV(w io.Writer, d *DiffReport) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"metric", "camp", "unit", "skill_id", "a", "b", "diff"})
+	for _, row := range d.Rows {
+		_ = cw.Write(append(rowFields(&row.Row), formatFloat(row.Value), formatFloat(row.B), formatFloat(row.Diff)))
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func WriteJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/east-eden/server/excel/auto"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/east-eden/server/services/combat/scene"
+)
+
+var (
+	ErrInvalidScene   = errors.New("invalid scene id")
+	ErrInvalidStage   = errors.New("invalid stage id")
+	ErrEmptyLineup    = errors.New("empty lineup")
+	ErrInvalidRunTime = errors.New("invalid simulate times")
+)
+
+// 模拟参数
+type SimOptions struct {
+	SceneId  int32
+	StageId  int32 // 关卡id, 不为0时按关卡波次生成防守方
+	Attack   []*HeroSpec
+	Defence  []*HeroSpec
+	Times    int   // 模拟次数
+	Seed     int64 // 第n次模拟使用Seed+n作为随机种子
+	Parallel int   // 并行协程数
+}
+
+type unitKey struct {
+	Camp      int32
+	TypeId    int32
+	IsMonster bool
+}
+
+type skillKey struct {
+	unitKey
+	SkillId int32
+}
+
+type unitStat struct {
+	appear  int64
+	dmgDone int64
+	dmgRecv int64
+	heal    int64
+	dead    int64
+}
+
+// 模拟结果累计
+type accumulator struct {
+	times  int
+	wins   int
+	rounds int64
+	units  map[unitKey]*unitStat
+	skills map[skillKey]int64
+}
+
+func newAccumulator() *accumulator {
+	return &accumulator{
+		units:  make(map[unitKey]*unitStat),
+		skills: make(map[skillKey]int64),
+	}
+}
+
+func (a *accumulator) getUnit(k unitKey) *unitStat {
+	u, ok := a.units[k]
+	if !ok {
+		u = &unitStat{}
+		a.units[k] = u
+	}
+
+	return u
+}
+
+func (a *accumulator) add(result *scene.SceneResult) {
+	a.times++
+	if result.Win {
+		a.wins++
+	}
+	a.rounds += int64(result.Statistics.GetRounds())
+
+	keys := make(map[int64]unitKey, len(result.Units))
+	for _, unit := range result.Units {
+		k := unitKey{Camp: unit.Camp, TypeId: unit.TypeId, IsMonster: unit.IsMonster}
+		keys[unit.Id] = k
+
+		u := a.getUnit(k)
+		u.appear++
+		u.dmgDone += unit.DmgDone
+		u.dmgRecv += unit.DmgRecv
+		if unit.Dead {
+			u.dead++
+		}
+	}
+
+	for _, e := range result.Record.GetEvents() {
+		k, ok := keys[e.GetCasterId()]
+		if !ok {
+			continue
+		}
+
+		switch e.GetType() {
+		case pbGlobal.CombatEventType_CombatEvent_SkillCast:
+			a.skills[skillKey{unitKey: k, SkillId: e.GetSkillId()}]++
+		case pbGlobal.CombatEventType_CombatEvent_Heal:
+			a.getUnit(k).heal += e.GetValue()
+		}
+	}
+}
+
+func (a *accumulator) merge(other *accumulator) {
+	a.times += other.times
+	a.wins += other.wins
+	a.rounds += other.rounds
+
+	for k, o := range other.units {
+		u := a.getUnit(k)
+		u.appear += o.appear
+		u.dmgDone += o.dmgDone
+		u.dmgRecv += o.dmgRecv
+		u.heal += o.heal
+		u.dead += o.dead
+	}
+
+	for k, n := range other.skills {
+		a.skills[k] += n
+	}
+}
+
+// 生成场景参数, 需要在读取静态表之后调用
+func buildSceneOptions(opts *SimOptions) ([]scene.SceneOption, error) {
+	sceneEntry, ok := auto.GetSceneEntry(opts.SceneId)
+	if !ok {
+		return nil, fmt.Errorf("scene_id<%d>: %w", opts.SceneId, ErrInvalidScene)
+	}
+
+	attackList, err := BuildLineup(opts.Attack)
+	if err != nil {
+		return nil, err
+	}
+
+	defenceList, err := BuildLineup(opts.Defence)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attackList) == 0 {
+		return nil, fmt.Errorf("attack: %w", ErrEmptyLineup)
+	}
+
+	sceneOpts := []scene.SceneOption{
+		scene.WithSceneEntry(sceneEntry),
+		scene.WithSceneAttackUnitList(attackList),
+		scene.WithSceneDefenceUnitList(defenceList),
+	}
+
+	if opts.StageId == 0 {
+		if len(defenceList) == 0 {
+			return nil, fmt.Errorf("defence: %w", ErrEmptyLineup)
+		}
+
+		return sceneOpts, nil
+	}
+
+	stageEntry, ok := auto.GetStageEntry(opts.StageId)
+	if !ok {
+		return nil, fmt.Errorf("stage_id<%d>: %w", opts.StageId, ErrInvalidStage)
+	}
+
+	battleWaveEntries := make([]*auto.BattleWaveEntry, 0, len(stageEntry.WaveID))
+	for _, id := range stageEntry.WaveID {
+		entry, ok := auto.GetBattleWaveEntry(id)
+		if !ok {
+			return nil, fmt.Errorf("stage_id<%d> wave_id<%d>: %w", opts.StageId, id, ErrInvalidStage)
+		}
+
+		battleWaveEntries = append(battleWaveEntries, entry)
+	}
+
+	layoutEntry, _ := auto.GetBattleLayoutEntry(stageEntry.LayoutID)
+	sceneOpts = append(sceneOpts,
+		scene.WithSceneBattleWaveEntries(battleWaveEntries...),
+		scene.WithSceneBattleLayoutEntry(layoutEntry),
+	)
+
+	return sceneOpts, nil
+}
+
+// 并行模拟战斗, 每个协程单独累计结果后合并
+func Simulate(opts *SimOptions) (*Report, error) {
+	if opts.Times <= 0 {
+		return nil, ErrInvalidRunTime
+	}
+
+	sceneOpts, err := buildSceneOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	parallel := opts.Parallel
+	if parallel <= 0 {
+		parallel = 1
+	}
+
+	jobs := make(chan int, parallel)
+	results := make(chan *accumulator, parallel)
+	wg := sync.WaitGroup{}
+	for n := 0; n < parallel; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			acc := newAccumulator()
+			for idx := range jobs {
+				o := append([]scene.SceneOption{scene.WithSceneSeed(opts.Seed + int64(idx))}, sceneOpts...)
+				acc.add(scene.Simulate(int64(idx+1), o...))
+			}
+			results <- acc
+		}()
+	}
+
+	for n := 0; n < opts.Times; n++ {
+		jobs <- n
+	}
+	close(jobs)
+
+	wg.Wait()
+	close(results)
+
+	total := newAccumulator()
+	for acc := range results {
+		total.merge(acc)
+	}
+
+	return total.report(), nil
+}
+
+// 按单位场均数据生成报告
+func (a *accumulator) report() *Report {
+	times := float64(a.times)
+	r := &Report{
+		Times:     a.times,
+		Wins:      a.wins,
+		WinRate:   float64(a.wins) / times,
+		AvgRounds: float64(a.rounds) / times,
+		Units:     make([]*UnitReport, 0, len(a.units)),
+		Skills:    make([]*SkillReport, 0, len(a.skills)),
+	}
+
+	for k, u := range a.units {
+		r.Units = append(r.Units, &UnitReport{
+			Camp:       campName(k.Camp),
+			TypeId:     k.TypeId,
+			IsMonster:  k.IsMonster,
+			AvgNum:     float64(u.appear) / times,
+			AvgDmgDone: float64(u.dmgDone) / times,
+			AvgDmgRecv: float64(u.dmgRecv) / times,
+			AvgHeal:    float64(u.heal) / times,
+			DeadRate:   float64(u.dead) / float64(u.appear),
+		})
+	}
+
+	for k, n := range a.skills {
+		r.Skills = append(r.Skills, &SkillReport{
+			Camp:      campName(k.Camp),
+			TypeId:    k.TypeId,
+			IsMonster: k.IsMonster,
+			SkillId:   k.SkillId,
+			AvgCast:   float64(n) / times,
+		})
+	}
+
+	sort.Slice(r.Units, func(i, j int) bool {
+		return lessUnit(r.Units[i].Camp, r.Units[i].IsMonster, r.Units[i].TypeId, r.Units[j].Camp, r.Units[j].IsMonster, r.Units[j].TypeId)
+	})
+
+	sort.Slice(r.Skills, func(i, j int) bool {
+		si, sj := r.Skills[i], r.Skills[j]
+		if si.Camp != sj.Camp || si.IsMonster != sj.IsMonster || si.TypeId != sj.TypeId {
+			return lessUnit(si.Camp, si.IsMonster, si.TypeId, sj.Camp, sj.IsMonster, sj.TypeId)
+		}
+		return si.SkillId < sj.SkillId
+	})
+
+	return r
+}
+
+// 按阵营, 英雄在前怪物在后, type_id排序
+func lessUnit(campA string, monsterA bool, typeA int32, campB string, monsterB bool, typeB int32) bool {
+	if campA != campB {
+		return campA < campB
+	}
+
+	if monsterA != monsterB {
+		return !monsterA
+	}
+
+	return typeA < typeB
+}
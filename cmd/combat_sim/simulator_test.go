@@ -0,0 +1,100 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/east-eden/server/excel"
+	"github.com/rs/zerolog"
+)
+
+func loadTestEntries(t *testing.T) {
+	t.Helper()
+
+	dir := "../../config/csv/"
+	if _, err := os.Stat(dir + "Hero.csv"); err != nil {
+		t.Skip("config/csv not found")
+	}
+
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+	excel.ReadAllEntries(dir)
+}
+
+func TestParseLineup(t *testing.T) {
+	specs, err := ParseLineup("1:30:2:1+2000:10:1+2001, 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []*HeroSpec{
+		{TypeId: 1, Level: 30, Promote: 2, Star: 1, Equips: []EquipSpec{{TypeId: 2000, Level: 10, Promote: 1}, {TypeId: 2001, Level: 1}}},
+		{TypeId: 2, Level: 1, Equips: []EquipSpec{}},
+	}
+
+	if !reflect.DeepEqual(specs, expect) {
+		t.Fatalf("parse lineup got %+v", specs)
+	}
+
+	for _, s := range []string{"a", "1:2:3:4:5", "1+2000:1:1:1"} {
+		if _, err := ParseLineup(s); err == nil {
+			t.Fatalf("lineup <%s> should be invalid", s)
+		}
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	loadTestEntries(t)
+
+	attack, _ := ParseLineup("1:30+2000:10")
+	defence, _ := ParseLineup("3:30")
+	opts := &SimOptions{SceneId: 1, Attack: attack, Defence: defence, Times: 4, Seed: 1, Parallel: 1}
+
+	report, err := Simulate(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Times != 4 || len(report.Units) != 2 || len(report.Skills) == 0 {
+		t.Fatalf("report times %d units %d skills %d", report.Times, len(report.Units), len(report.Skills))
+	}
+
+	// 相同种子的结果与并行数无关
+	opts.Parallel = 3
+	parallelReport, err := Simulate(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(report, parallelReport) {
+		t.Fatalf("parallel report %+v, expect %+v", parallelReport, report)
+	}
+
+	// 没有防守方也没有关卡
+	opts.Defence = nil
+	if _, err := Simulate(opts); err == nil {
+		t.Fatal("simulate without defence should fail")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := &Report{Times: 10, WinRate: 0.5, Units: []*UnitReport{{Camp: "attack", TypeId: 1, AvgDmgDone: 100}}}
+	b := &Report{Times: 10, WinRate: 0.8, Skills: []*SkillReport{{Camp: "attack", TypeId: 1, SkillId: 101, AvgCast: 2}}}
+
+	rows := make(map[string]*DiffRow)
+	for _, row := range Diff(a, b).Rows {
+		rows[row.key()] = row
+	}
+
+	if r := rows[(&Row{Metric: "win_rate"}).key()]; r == nil || r.Value != 0.5 || r.B != 0.8 || r.Diff < 0.29 || r.Diff > 0.31 {
+		t.Fatalf("win rate diff %+v", r)
+	}
+
+	if r := rows[(&Row{Metric: "avg_dmg_done", Camp: "attack", Unit: "hero_1"}).key()]; r == nil || r.B != 0 || r.Diff != -100 {
+		t.Fatalf("damage diff %+v", r)
+	}
+
+	if r := rows[(&Row{Metric: "avg_cast", Camp: "attack", Unit: "hero_1", SkillId: 101}).key()]; r == nil || r.Value != 0 || r.Diff != 2 {
+		t.Fatalf("skill diff %+v", r)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+
+	_ "github.com/east-eden/server/proto/global"
+	_ "github.com/east-eden/server/proto/server/chat"
+	_ "github.com/east-eden/server/proto/server/combat"
+	_ "github.com/east-eden/server/proto/server/comment"
+	_ "github.com/east-eden/server/proto/server/game"
+	_ "github.com/east-eden/server/proto/server/gate"
+	_ "github.com/east-eden/server/proto/server/mail"
+	_ "github.com/east-eden/server/proto/server/pubsub"
+	_ "github.com/east-eden/server/proto/server/rank"
+	"github.com/east-eden/server/transport"
+	"github.com/east-eden/server/utils"
+	"github.com/east-eden/server/version"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	relocatePath string // 重定位路径
+	outputPath   string // 输出manifest文件路径
+
+	// 检测消息id冲突的所有proto package
+	checkPackages = []protoreflect.FullName{"proto", "chat", "combat", "comment", "game", "gate", "mail", "pubsub", "rank"}
+
+	// 生成manifest的package: proto/global
+	manifestPackage protoreflect.FullName = "proto"
+)
+
+func init() {
+	flag.StringVar(&relocatePath, "relocatePath", "/server", "重定位到east_eden/server/目录下")
+	flag.StringVar(&outputPath, "outputPath", "proto/global/manifest.go", "输出manifest文件路径")
+}
+
+func main() {
+	utils.LDFlagsCheck(os.Args, version.Version, version.Help)
+
+	flag.Parse()
+
+	if err := utils.RelocatePath(relocatePath); err != nil {
+		fmt.Println("relocate failed: ", err, relocatePath)
+		os.Exit(1)
+	}
+
+	// build time verification: message ids must be unique across all packages
+	ids, err := transport.MessageIDs(checkPackages...)
+	if err != nil {
+		fmt.Println("check message ids failed: ", err)
+		os.Exit(1)
+	}
+
+	manifest := make(map[string]uint32)
+	for fullName, id := range ids {
+		if fullName.Parent() == manifestPackage {
+			manifest[string(fullName.Name())] = id
+		}
+	}
+
+	data, err := generate(manifest)
+	if err != nil {
+		fmt.Println("generate manifest failed: ", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		fmt.Println("write manifest failed: ", err, outputPath)
+		os.Exit(1)
+	}
+
+	fmt.Printf("generate manifest with %d messages to %s success!\n", len(manifest), outputPath)
+}
+
+func generate(manifest map[string]uint32) ([]byte, error) {
+	names := make([]string, 0, len(manifest))
+	for name := range manifest {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by proto_manifest. DO NOT EDIT.\n\n")
+	b.WriteString("package global\n\n")
+	b.WriteString("// ManifestVersion is exchanged in Handshake, clients with different version will be rejected\n")
+	fmt.Fprintf(&b, "const ManifestVersion uint32 = %d\n\n", transport.ManifestVersion(manifest))
+	b.WriteString("// Manifest maps every message name to its transport id\n")
+	b.WriteString("var Manifest = map[string]uint32{\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\t%q: %d,\n", name, manifest[name])
+	}
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
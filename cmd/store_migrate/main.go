@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	_ "github.com/east-eden/server/services/game/player"
+	"github.com/east-eden/server/store"
+	"github.com/east-eden/server/store/db"
+	"github.com/east-eden/server/utils"
+	"github.com/east-eden/server/version"
+	"github.com/urfave/cli/v2"
+)
+
+// 离线迁移玩家数据:
+// store_migrate -db_dsn mongodb://localhost:27017 -database game -dry_run
+// store_migrate -db_dsn mongodb://localhost:27017 -database game -tables player_token -rollback -to 0
+
+var (
+	tables   string // 迁移的表, 逗号分隔, 为空时迁移所有注册了迁移的表
+	dryRun   bool   // 只输出迁移结果, 不写入数据库
+	rollback bool   // 从快照回滚
+	to       int    // 回滚到的版本
+	list     bool   // 列出所有迁移
+)
+
+func init() {
+	flag.String("db_driver", db.Driver_MongoDB, "db driver: mongodb, mysql, sqlite3")
+	flag.String("db_dsn", "mongodb://localhost:27017", "db data source name")
+	flag.String("database", "game", "database name")
+	flag.StringVar(&tables, "tables", "", "迁移的表, 逗号分隔, 为空时迁移所有注册了迁移的表")
+	flag.BoolVar(&dryRun, "dry_run", false, "只输出迁移结果, 不写入数据库")
+	flag.BoolVar(&rollback, "rollback", false, "从快照回滚迁移")
+	flag.IntVar(&to, "to", 0, "回滚到的版本")
+	flag.BoolVar(&list, "list", false, "列出所有迁移")
+}
+
+func main() {
+	utils.LDFlagsCheck(os.Args, version.Version, version.Help)
+
+	flag.Parse()
+
+	tbls := store.MigrationTables()
+	if len(tables) > 0 {
+		tbls = strings.Split(tables, ",")
+	}
+
+	if list {
+		for _, tbl := range tbls {
+			for _, m := range store.Migrations(tbl) {
+				fmt.Printf("table=%s version=%d name=%s\n", tbl, m.Version, m.Name)
+			}
+		}
+		return
+	}
+
+	d := db.NewDB(cli.NewContext(nil, flag.CommandLine, nil))
+	defer d.Exit()
+
+	for _, tbl := range append([]string{store.SnapshotTable}, tbls...) {
+		indexNames := []string{}
+		if tbl == store.SnapshotTable {
+			indexNames = append(indexNames, "table")
+		}
+
+		if err := d.MigrateTable(tbl, indexNames...); err != nil {
+			fmt.Println("migrate table failed: ", err, tbl)
+			os.Exit(1)
+		}
+	}
+
+	m := &store.Migrator{
+		DB:     d,
+		DryRun: dryRun,
+		Output: func(format string, args ...any) { fmt.Printf(format, args...) },
+	}
+
+	ctx := context.Background()
+	for _, tbl := range tbls {
+		var n int
+		var err error
+		if rollback {
+			n, err = m.Rollback(ctx, tbl, int32(to))
+		} else {
+			n, err = m.Migrate(ctx, tbl)
+		}
+
+		if err != nil {
+			fmt.Println("migrate failed: ", err, tbl)
+			os.Exit(1)
+		}
+
+		fmt.Printf("table %s: %d documents processed, dry_run=%v rollback=%v\n", tbl, n, dryRun, rollback)
+	}
+}
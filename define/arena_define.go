@@ -0,0 +1,109 @@
+package define
+
+import (
+	"math"
+	"time"
+
+	pbGlobal "github.com/east-eden/server/proto/global"
+)
+
+const (
+	ArenaReward_Type_Begin  int32 = iota
+	ArenaReward_Type_Daily  int32 = iota - 1 // 0 每日排名奖励
+	ArenaReward_Type_Season                  // 1 赛季排名奖励
+	ArenaReward_Type_End
+)
+
+const (
+	Arena_MatchNum          = 3  // 每次匹配的对手数量
+	Arena_MatchCandidateNum = 30 // 每次从积分区间中取出的候选对手数量
+	Arena_MatchTimes        = 3  // 候选对手不足时扩大积分区间的次数
+	Arena_LogMaxNum         = 20 // 防守记录最多保留条数
+	Arena_SettleMaxDays     = 3  // 每日排名奖励最多累计天数
+	Arena_SettleHour        = 5  // 每日5点结算排名奖励, rank服务在同一时间点生成排行快照
+)
+
+// 竞技场赛季分榜id
+func ArenaRankId(season int32) int32 {
+	return RankId_Arena*RankSubIdScale + season
+}
+
+// 竞技场赛季, 从1开始, 第一赛季开始前为0
+func ArenaSeason(now int64, beginTime int64, seasonDays int32) int32 {
+	if now < beginTime || seasonDays <= 0 {
+		return 0
+	}
+
+	return int32((now-beginTime)/(int64(seasonDays)*86400)) + 1
+}
+
+// 竞技场赛季结束时间
+func ArenaSeasonEndTime(season int32, beginTime int64, seasonDays int32) int64 {
+	return beginTime + int64(season)*int64(seasonDays)*86400
+}
+
+// 每日结算时间点: now之前(含)最近一次Arena_SettleHour的时间
+func ArenaDailySettleTime(now int64) int64 {
+	t := time.Unix(now, 0)
+	settle := time.Date(t.Year(), t.Month(), t.Day(), Arena_SettleHour, 0, 0, 0, t.Location())
+	if t.Before(settle) {
+		settle = settle.AddDate(0, 0, -1)
+	}
+
+	return settle.Unix()
+}
+
+// 竞技场分榜快照时间点: 最近一次每日结算时间点, 赛季结束后固定为赛季结束时间
+func ArenaSnapshotTime(now int64, season int32, beginTime int64, seasonDays int32) int64 {
+	end := ArenaSeasonEndTime(season, beginTime, seasonDays)
+	if now >= end {
+		return end
+	}
+
+	return ArenaDailySettleTime(now)
+}
+
+// elo积分变化, 防守方积分变化为进攻方的相反数
+func ArenaEloDelta(attackScore float64, defenceScore float64, k int32, win bool) float64 {
+	expect := 1 / (1 + math.Pow(10, (defenceScore-attackScore)/400))
+
+	var result float64
+	if win {
+		result = 1
+	}
+
+	return math.Round(float64(k) * (result - expect))
+}
+
+// 竞技场防守阵容快照
+type ArenaDefence struct {
+	PlayerId   int64  `bson:"_id" json:"_id"`
+	Name       string `bson:"name" json:"name"`
+	Level      int32  `bson:"level" json:"level"`
+	UpdateTime int64  `bson:"update_time" json:"update_time"`
+	Data       []byte `bson:"data" json:"data"` // pbGlobal.ArenaDefenceSnapshot序列化数据
+}
+
+// 竞技场防守记录
+type ArenaLog struct {
+	Id          int64   `bson:"_id" json:"_id"`
+	DefenceId   int64   `bson:"defence_id" json:"defence_id"`
+	AttackId    int64   `bson:"attack_id" json:"attack_id"`
+	AttackName  string  `bson:"attack_name" json:"attack_name"`
+	Win         bool    `bson:"win" json:"win"`                   // 是否防守成功
+	ScoreChange float64 `bson:"score_change" json:"score_change"` // 防守方积分变化
+	RecordId    int64   `bson:"record_id" json:"record_id"`       // 战斗录像id
+	Time        int64   `bson:"time" json:"time"`
+}
+
+func (l *ArenaLog) ToPB() *pbGlobal.ArenaLog {
+	return &pbGlobal.ArenaLog{
+		Id:          l.Id,
+		AttackId:    l.AttackId,
+		AttackName:  l.AttackName,
+		Win:         l.Win,
+		ScoreChange: l.ScoreChange,
+		RecordId:    l.RecordId,
+		Time:        l.Time,
+	}
+}
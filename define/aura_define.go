@@ -110,6 +110,7 @@ const (
 	AuraEffectType_PerioHeal                                     // 16 周期治疗
 	AuraEffectType_ModAttByAlive                                 // 17 根据当前友方存活人数,计算属性改变
 	AuraEffectType_ModAttByEnemyAlive                            // 18 根据当前敌方存活人数,计算属性改变
+	AuraEffectType_ModAtb                                        // 19 改变com条
 
 	AuraEffectType_End
 )
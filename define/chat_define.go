@@ -0,0 +1,83 @@
+package define
+
+import (
+	"fmt"
+
+	pbGlobal "github.com/east-eden/server/proto/global"
+)
+
+const (
+	ChatChannel_Begin      int32 = iota
+	ChatChannel_World      int32 = iota - 1 // 0 本服世界频道, 按game节点区分
+	ChatChannel_CrossWorld                  // 1 跨服世界频道
+	ChatChannel_Private                     // 2 私聊, 按接收者区分
+	ChatChannel_System                      // 3 系统广播
+	ChatChannel_Guild                       // 4 公会频道(预留)
+	ChatChannel_End
+)
+
+const (
+	Chat_ContentMaxLen    = 200       // 聊天内容最大字符数
+	Chat_HistoryMaxSize   = 32 * 1024 // 每个频道保留的历史消息最大字节数
+	Chat_ReportContextNum = 10        // 举报时保存的上下文消息条数
+)
+
+// 聊天频道
+type ChatChannel struct {
+	Type   int32 `json:"type" bson:"type"`       // 频道类型
+	TypeId int64 `json:"type_id" bson:"type_id"` // 本服世界频道为game节点id, 私聊为接收者玩家id, 公会频道为公会id
+}
+
+func (c *ChatChannel) Valid() bool {
+	if c.Type < ChatChannel_Begin || c.Type >= ChatChannel_End {
+		return false
+	}
+
+	switch c.Type {
+	case ChatChannel_CrossWorld, ChatChannel_System:
+		return c.TypeId == 0
+	default:
+		return c.TypeId > 0
+	}
+}
+
+// 频道唯一key, 用于缓存, 存储和一致性哈希
+func (c *ChatChannel) Key() string {
+	return fmt.Sprintf("%d_%d", c.Type, c.TypeId)
+}
+
+func (c *ChatChannel) FromPB(pb *pbGlobal.ChatChannel) {
+	c.Type = pb.GetType()
+	c.TypeId = pb.GetTypeId()
+}
+
+func (c *ChatChannel) ToPB() *pbGlobal.ChatChannel {
+	return &pbGlobal.ChatChannel{
+		Type:   c.Type,
+		TypeId: c.TypeId,
+	}
+}
+
+// 禁言
+type ChatMute struct {
+	PlayerId int64  `json:"_id" bson:"_id"`
+	EndTime  int64  `json:"end_time" bson:"end_time"` // 禁言结束时间
+	Reason   string `json:"reason" bson:"reason"`     // 禁言原因
+	Operator string `json:"operator" bson:"operator"` // 操作者
+}
+
+func (m *ChatMute) IsMuted(now int64) bool {
+	return m.EndTime > now
+}
+
+// 聊天举报
+type ChatReport struct {
+	Id         int64       `json:"_id" bson:"_id"`
+	ReporterId int64       `json:"reporter_id" bson:"reporter_id"` // 举报者玩家id
+	ReportedId int64       `json:"reported_id" bson:"reported_id"` // 被举报玩家id
+	Channel    ChatChannel `json:"channel" bson:"channel"`
+	Seq        uint64      `json:"seq" bson:"seq"`         // 被举报的消息序号
+	Reason     string      `json:"reason" bson:"reason"`   // 举报原因
+	Context    [][]byte    `json:"context" bson:"context"` // 被举报消息及之前的消息, pbGlobal.ChatMessage序列化数据
+	Time       int64       `json:"time" bson:"time"`
+}
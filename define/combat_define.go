@@ -48,9 +48,25 @@ type EImmunityType int32
 
 const (
 	ImmunityType_Begin    EImmunityType = iota
-	ImmunityType_Damage                 = iota - 1 // 0 伤害免疫
-	ImmunityType_Mechanic                          // 1 状态免疫
-	ImmunityType_Dispel                            // 2 免疫驱散
+	ImmunityType_Damage                 = iota - 1 // 0 伤害免疫 掩码位为伤害类型(ESchoolType)
+	ImmunityType_Mechanic                          // 1 状态免疫 掩码位为状态(EHeroState)
+	ImmunityType_Dispel                            // 2 免疫驱散 掩码位为buff驱散类型
+	ImmunityType_Effect                            // 3 技能效果免疫 掩码位为技能效果分类(效果类型/100)
 
 	ImmunityType_End
 )
+
+//-------------------------------------------------------------------------------
+// 伤害加成类型
+//-------------------------------------------------------------------------------
+type EDmgModType int32
+
+const (
+	DmgMod_Begin       EDmgModType = iota
+	DmgMod_DamageDone  EDmgModType = iota - 1 // 0 造成伤害百分比加成
+	DmgMod_DamageTaken                        // 1 受到伤害百分比加成
+	DmgMod_HealDone                           // 2 造成治疗百分比加成
+	DmgMod_HealTaken                          // 3 受到治疗百分比加成
+
+	DmgMod_End
+)
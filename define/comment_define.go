@@ -1,6 +1,8 @@
 package define
 
 import (
+	"fmt"
+
 	pbGlobal "github.com/east-eden/server/proto/global"
 )
 
@@ -11,6 +13,28 @@ const (
 	TopicType_End
 )
 
+// 评论状态
+const (
+	CommentStatus_Begin   int32 = iota
+	CommentStatus_Normal  int32 = iota - 1 // 0 正常
+	CommentStatus_Deleted                  // 1 发表者或管理员删除, 仅管理员可见
+	CommentStatus_Hidden                   // 2 管理员隐藏, 仅管理员可见
+	CommentStatus_End
+)
+
+const (
+	Comment_ContentMaxLen = 200  // 评论内容最大字数
+	Comment_ReplyMaxNum   = 100  // 每条评论最多回复数
+	Comment_HotTimeFactor = 3600 // 热度时间因子, 晚发表1小时的评论热度相当于多1个赞
+	Comment_QueryMaxNum   = 50   // 每次查询最多返回的评论数
+
+	Comment_ThumbsFilterBits   = 8192 // 点赞玩家布隆过滤器位数
+	Comment_ThumbsFilterHashes = 4    // 点赞玩家布隆过滤器哈希函数个数
+	Comment_ThumbsRecentNum    = 64   // 最近点赞记录数量
+	Comment_ThumbsSpikeWindow  = 300  // 点赞异常增长统计时间(秒)
+	Comment_ThumbsSpikeNum     = 30   // 统计时间内点赞数达到此数量时标记给管理员
+)
+
 // 发表元数据
 type PublisherMetadata struct {
 	PublisherId   int64  `json:"publisher_id" bson:"publisher_id"`     // 发表者玩家id
@@ -26,6 +50,7 @@ type PublisherMetadata struct {
 type ReplyerMetadata struct {
 	CommentId         int64 `json:"_id" bson:"_id"` // 评论唯一id
 	PublisherMetadata `json:",inline" bson:"inline"`
+	Status            int32 `json:"status" bson:"status"` // 回复状态
 }
 
 // 评论话题
@@ -42,6 +67,11 @@ func (c *CommentTopic) Equal(cc *CommentTopic) bool {
 	return c.Type == cc.Type && c.TypeId == cc.TypeId
 }
 
+// 一致性哈希key
+func (c *CommentTopic) Key() string {
+	return fmt.Sprintf("%d_%d", c.Type, c.TypeId)
+}
+
 func (c *CommentTopic) FromPB(pb *pbGlobal.CommentTopic) {
 	c.Type = pb.GetTopicType()
 	c.TypeId = pb.GetTopicTypeId()
@@ -55,6 +85,25 @@ func (c *CommentTopic) ToPB() *pbGlobal.CommentTopic {
 	return pb
 }
 
+// 点赞记录key
+type CommentThumbsKey struct {
+	CommentId int64 `json:"comment_id" bson:"comment_id"` // 评论唯一id
+	PlayerId  int64 `json:"player_id" bson:"player_id"`   // 点赞玩家id
+}
+
+// 点赞记录, 每个玩家对每条评论的点赞单独保存
+type CommentThumbs struct {
+	CommentThumbsKey `json:"_id" bson:"_id"`
+	Date             int32 `json:"date" bson:"date"` // 点赞时间
+}
+
+// 最近点赞和取消点赞记录
+type CommentThumbsRecord struct {
+	PlayerId int64 `json:"player_id" bson:"player_id"` // 玩家id
+	Liked    bool  `json:"liked" bson:"liked"`         // 是否点赞
+	Date     int32 `json:"date" bson:"date"`           // 操作时间
+}
+
 // 评论元数据
 type CommentMetadata struct {
 	CommentId int64        `json:"_id" bson:"_id"`     // 评论唯一id
@@ -62,6 +111,52 @@ type CommentMetadata struct {
 
 	PublisherMetadata *PublisherMetadata `json:"publisher_metadata" bson:"publisher_metadata"` // 发表者元数据
 	ReplyerMetadatas  []*ReplyerMetadata `json:"replyer_metadatas" bson:"replyer_metadatas"`   // 此条评论回复列表
+	Status            int32              `json:"status" bson:"status"`                         // 评论状态
+
+	ThumbsFilter  []int64                `json:"thumbs_filter" bson:"thumbs_filter"`     // 点赞玩家布隆过滤器
+	RecentThumbs  []*CommentThumbsRecord `json:"recent_thumbs" bson:"recent_thumbs"`     // 最近点赞记录
+	SpikeFlagTime int32                  `json:"spike_flag_time" bson:"spike_flag_time"` // 点赞数异常增长标记时间
+	SpikeThumbs   int32                  `json:"spike_thumbs" bson:"spike_thumbs"`       // 标记时统计时间内的点赞数
+	Liked         bool                   `json:"-" bson:"-"`                             // 查询者是否点过赞
+}
+
+// 热度排序分数, 综合点赞数和发表时间
+func (c *CommentMetadata) HotScore() float64 {
+	return float64(c.PublisherMetadata.Thumbs) + float64(c.PublisherMetadata.Date)/Comment_HotTimeFactor
+}
+
+// 获取回复
+func (c *CommentMetadata) GetReply(replyId int64) *ReplyerMetadata {
+	for _, r := range c.ReplyerMetadatas {
+		if r.CommentId == replyId {
+			return r
+		}
+	}
+	return nil
+}
+
+// 复制一份评论数据, 不包括点赞记录. 非管理员只保留正常状态的回复, 并且看不到点赞异常标记
+func (c *CommentMetadata) Clone(moderator bool) *CommentMetadata {
+	cm := *c
+	pm := *c.PublisherMetadata
+	cm.PublisherMetadata = &pm
+	cm.ThumbsFilter = nil
+	cm.RecentThumbs = nil
+	if !moderator {
+		cm.SpikeFlagTime = 0
+		cm.SpikeThumbs = 0
+	}
+
+	cm.ReplyerMetadatas = make([]*ReplyerMetadata, 0, len(c.ReplyerMetadatas))
+	for _, r := range c.ReplyerMetadatas {
+		if !moderator && r.Status != CommentStatus_Normal {
+			continue
+		}
+
+		rr := *r
+		cm.ReplyerMetadatas = append(cm.ReplyerMetadatas, &rr)
+	}
+	return &cm
 }
 
 func (c *CommentMetadata) FromPB(pb *pbGlobal.CommentMetadata) {
@@ -77,12 +172,17 @@ func (c *CommentMetadata) FromPB(pb *pbGlobal.CommentMetadata) {
 		ReplyToName:   pb.GetPublisherMetadata().GetReplyToName(),
 		Content:       pb.GetPublisherMetadata().GetContent(),
 		Thumbs:        pb.GetPublisherMetadata().GetThumbs(),
+		Date:          pb.GetPublisherMetadata().GetDate(),
 	}
+	c.Status = pb.GetStatus()
+	c.Liked = pb.GetLiked()
+	c.SpikeFlagTime = pb.GetSpikeFlagTime()
+	c.SpikeThumbs = pb.GetSpikeThumbs()
 
 	c.ReplyerMetadatas = make([]*ReplyerMetadata, 0, len(pb.GetReplyMetadatas()))
 	for n := 0; n < len(pb.GetReplyMetadatas()); n++ {
 		c.ReplyerMetadatas = append(c.ReplyerMetadatas, &ReplyerMetadata{
-			CommentId: c.CommentId,
+			CommentId: pb.GetReplyMetadatas()[n].GetCommentId(),
 			PublisherMetadata: PublisherMetadata{
 				PublisherId:   pb.GetReplyMetadatas()[n].GetPublisherMetadata().GetPublisherId(),
 				PublisherName: pb.GetReplyMetadatas()[n].GetPublisherMetadata().GetPublisherName(),
@@ -90,7 +190,9 @@ func (c *CommentMetadata) FromPB(pb *pbGlobal.CommentMetadata) {
 				ReplyToName:   pb.GetReplyMetadatas()[n].GetPublisherMetadata().GetReplyToName(),
 				Content:       pb.GetReplyMetadatas()[n].GetPublisherMetadata().GetContent(),
 				Thumbs:        pb.GetReplyMetadatas()[n].GetPublisherMetadata().GetThumbs(),
+				Date:          pb.GetReplyMetadatas()[n].GetPublisherMetadata().GetDate(),
 			},
+			Status: pb.GetReplyMetadatas()[n].GetStatus(),
 		})
 	}
 }
@@ -115,21 +217,30 @@ func (c *CommentMetadata) ToPB() *pbGlobal.CommentMetadata {
 		},
 
 		ReplyMetadatas: make([]*pbGlobal.ReplyerMetadata, 0, len(c.ReplyerMetadatas)),
+		Status:         c.Status,
+		Liked:          c.Liked,
+		SpikeFlagTime:  c.SpikeFlagTime,
+		SpikeThumbs:    c.SpikeThumbs,
 	}
 
 	for n := 0; n < len(c.ReplyerMetadatas); n++ {
-		pb.ReplyMetadatas = append(pb.ReplyMetadatas, &pbGlobal.ReplyerMetadata{
-			CommentId: c.CommentId,
-			PublisherMetadata: &pbGlobal.PublisherMetadata{
-				PublisherId:   c.ReplyerMetadatas[n].PublisherId,
-				PublisherName: c.ReplyerMetadatas[n].PublisherName,
-				ReplyToId:     c.ReplyerMetadatas[n].ReplyToId,
-				ReplyToName:   c.ReplyerMetadatas[n].ReplyToName,
-				Content:       c.ReplyerMetadatas[n].Content,
-				Thumbs:        c.ReplyerMetadatas[n].Thumbs,
-				Date:          c.ReplyerMetadatas[n].Date,
-			},
-		})
+		pb.ReplyMetadatas = append(pb.ReplyMetadatas, c.ReplyerMetadatas[n].ToPB())
 	}
 	return pb
 }
+
+func (r *ReplyerMetadata) ToPB() *pbGlobal.ReplyerMetadata {
+	return &pbGlobal.ReplyerMetadata{
+		CommentId: r.CommentId,
+		PublisherMetadata: &pbGlobal.PublisherMetadata{
+			PublisherId:   r.PublisherId,
+			PublisherName: r.PublisherName,
+			ReplyToId:     r.ReplyToId,
+			ReplyToName:   r.ReplyToName,
+			Content:       r.Content,
+			Thumbs:        r.Thumbs,
+			Date:          r.Date,
+		},
+		Status: r.Status,
+	}
+}
@@ -18,6 +18,8 @@ const (
 	Condition_SubType_OurUnitCastUltimateSkill                  // 5 己方单位成功使用*次奥义技能
 	Condition_SubType_CombatPassTimeLessThan                    // 6 通关时间小于*秒
 	Condition_SubType_KillEnemyTypeIdFirst                      // 7 优先击杀id为*的敌方单位
+	Condition_SubType_CombatRoundLessThan                       // 8 *回合内清除所有波次
+	Condition_SubType_CombatWaveReached                         // 9 到达第*波
 
 	Condition_SubType_End
 )
@@ -10,3 +10,39 @@ type PlayerLevelupEntry struct {
 	ID  int32 `json:"_id"`
 	Exp int64 `json:"exp"`
 }
+
+// 布阵类型
+const (
+	BattleArray_Type_Begin         int32 = iota
+	BattleArray_Type_Stage         int32 = iota - 1 // 0 关卡
+	BattleArray_Type_TowerMelody                    // 1 韵律之塔
+	BattleArray_Type_TowerNature                    // 2 自然之塔
+	BattleArray_Type_TowerCivilize                  // 3 文明之塔
+	BattleArray_Type_TowerDestroy                   // 4 破灭之塔
+	BattleArray_Type_TowerGeneral                   // 5 综合试炼
+	BattleArray_Type_ArenaDefence                   // 6 竞技场防守
+	BattleArray_Type_ArenaAttack                    // 7 竞技场进攻
+	BattleArray_Type_End
+)
+
+const (
+	BattleArray_MaxNameLength = 32 // 布阵名称最大长度
+)
+
+// 爬塔类型对应的布阵类型
+func TowerBattleArrayType(towerType int32) int32 {
+	return BattleArray_Type_TowerMelody + towerType - Tower_Type_Melody
+}
+
+// 布阵英雄
+type BattleArrayHero struct {
+	HeroId int64 `bson:"hero_id" json:"hero_id"`
+	Pos    int32 `bson:"pos" json:"pos"` // 布阵位置, 从1开始
+}
+
+// 布阵
+type BattleArray struct {
+	Type   int32              `bson:"type" json:"type"`
+	Name   string             `bson:"name" json:"name"`
+	Heroes []*BattleArrayHero `bson:"heroes" json:"heroes"`
+}
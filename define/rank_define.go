@@ -8,9 +8,36 @@ const (
 	RankId_Begin             = iota
 	RankId_LocalPlayerLevel  // 本服玩家等级榜
 	RankId_GlobalPlayerLevel // 全服玩家等级榜
+	RankId_Tower             // 爬塔最快通关榜, 按塔类型和层数分榜
+	RankId_Arena             // 竞技场积分榜, 按赛季分榜
 	RankId_End
 )
 
+const (
+	RankSubIdScale       = 100000 // 分榜id = 排行榜id * RankSubIdScale + 分榜序号
+	TowerRankRoundsMax   = 1000   // 爬塔排行分数中回合数的进位
+	RankSnapshotKeepDays = 7      // 排行快照保留天数, 最近一次快照一直保留
+)
+
+// 分榜对应Rank.csv中的排行榜id
+func RankEntryId(rankId int32) int32 {
+	if rankId >= RankSubIdScale {
+		return rankId / RankSubIdScale
+	}
+
+	return rankId
+}
+
+// 爬塔分榜id, 每种塔的每一层各一个分榜
+func TowerRankId(towerType int32, floor int32) int32 {
+	return RankId_Tower*RankSubIdScale + towerType*TowerMaxFloor + floor
+}
+
+// 爬塔排行分数, 通关时间少的在前, 时间相同时回合数少的在前
+func TowerRankScore(passTime int32, rounds int32) float64 {
+	return float64(passTime)*TowerRankRoundsMax + float64(rounds)
+}
+
 // 排行榜复合键值
 type RankKey struct {
 	ObjId  int64 `json:"obj_id" bson:"obj_id"`   // 排行榜对象id -- 玩家id或工会id
@@ -19,10 +46,11 @@ type RankKey struct {
 
 // 排行榜元数据
 type RankMetadata struct {
-	RankKey `json:"_id" bson:"_id"` // 排行榜key
-	ObjName string                  `json:"name" bson:"name"`   // 排行数据名字 -- 玩家名字
-	Score   float64                 `json:"score" bson:"score"` // 排行榜得分
-	Date    int64                   `json:"date" bson:"date"`   // 分数更新时间
+	RankKey  `json:"_id" bson:"_id"` // 排行榜key
+	ObjName  string                  `json:"name" bson:"name"`           // 排行数据名字 -- 玩家名字
+	Score    float64                 `json:"score" bson:"score"`         // 排行榜得分
+	Date     int64                   `json:"date" bson:"date"`           // 分数更新时间
+	RecordId int64                   `json:"record_id" bson:"record_id"` // 战斗录像id
 }
 
 func (r *RankMetadata) FromPB(pb *pbGlobal.RankMetadata) {
@@ -30,14 +58,45 @@ func (r *RankMetadata) FromPB(pb *pbGlobal.RankMetadata) {
 	r.ObjName = pb.GetObjName()
 	r.Score = pb.GetScore()
 	r.Date = pb.GetDate()
+	r.RecordId = pb.GetRecordId()
 }
 
 func (r *RankMetadata) ToPB() *pbGlobal.RankMetadata {
 	pb := &pbGlobal.RankMetadata{
-		ObjId:   r.ObjId,
-		ObjName: r.ObjName,
-		Score:   r.Score,
-		Date:    r.Date,
+		ObjId:    r.ObjId,
+		ObjName:  r.ObjName,
+		Score:    r.Score,
+		Date:     r.Date,
+		RecordId: r.RecordId,
 	}
 	return pb
 }
+
+// 排行快照复合键值
+type RankSnapshotKey struct {
+	RankId       int32 `json:"rank_id" bson:"rank_id"`             // 排行榜id
+	SnapshotTime int64 `json:"snapshot_time" bson:"snapshot_time"` // 快照对应的结算时间点
+}
+
+// 排行快照, 结算时间点之后排行第一次变化之前生成, 按快照发放排名奖励
+type RankSnapshot struct {
+	RankSnapshotKey `json:"_id" bson:"_id"`
+	ObjIds          []int64         `json:"obj_ids" bson:"obj_ids"` // 按排行顺序
+	ranks           map[int64]int32 `json:"-" bson:"-"`
+}
+
+// 快照中的位置, 从0开始, 不在快照中时返回-1
+func (s *RankSnapshot) GetRank(objId int64) int32 {
+	if s.ranks == nil {
+		s.ranks = make(map[int64]int32, len(s.ObjIds))
+		for idx, id := range s.ObjIds {
+			s.ranks[id] = int32(idx)
+		}
+	}
+
+	if rank, ok := s.ranks[objId]; ok {
+		return rank
+	}
+
+	return -1
+}
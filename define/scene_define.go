@@ -18,6 +18,8 @@ const (
 const (
 	Scene_MaxNumPerCombat = 5000 // 每台combat最多跑5000个场景
 	Scene_MaxUnitPerScene = 1000 // 每个scene最多跑1000个unit
+	Scene_MaxRound        = 1800 // 每场战斗最多1800回合
+	Scene_RoundTime       = 100  // 每回合战斗时间(毫秒)
 )
 
 // 朝向
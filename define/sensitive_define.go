@@ -0,0 +1,10 @@
+package define
+
+// 屏蔽字处理方式, 数值越大越严重, 同时命中多个屏蔽字时取最严重的处理方式
+const (
+	SensitiveAction_Begin     int32 = iota
+	SensitiveAction_Mask      int32 = iota - 1 // 0 替换为*
+	SensitiveAction_ShadowBan                  // 1 仅发送者可见
+	SensitiveAction_Reject                     // 2 拒绝发送
+	SensitiveAction_End
+)
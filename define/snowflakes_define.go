@@ -12,5 +12,14 @@ const (
 
 	SnowFlake_Pubsub
 
+	SnowFlake_CombatRecord
+
+	SnowFlake_ArenaLog
+
+	SnowFlake_Chat
+	SnowFlake_ChatReport
+
+	SnowFlake_Comment
+
 	SnowFlake_End
 )
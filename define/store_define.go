@@ -13,8 +13,17 @@ const (
 	StoreType_Fragment
 	StoreType_Mail
 	StoreType_Rank
+	StoreType_RankSnapshot
 	StoreType_Comment
+	StoreType_CommentMetadata
+	StoreType_CommentThumbs
 	StoreType_GlobalMess
+	StoreType_CombatRecord
+	StoreType_ArenaDefence
+	StoreType_ArenaLog
+	StoreType_Chat
+	StoreType_ChatMute
+	StoreType_ChatReport
 
 	StoreType_End
 )
@@ -0,0 +1,62 @@
+package auto
+
+import (
+	"github.com/east-eden/server/excel"
+	"github.com/east-eden/server/utils"
+	"github.com/mitchellh/mapstructure"
+	"github.com/rs/zerolog/log"
+)
+
+var arenaRewardEntries *ArenaRewardEntries //ArenaReward.csv全局变量
+
+// ArenaReward.csv属性表
+type ArenaRewardEntry struct {
+	Id       int32 `json:"Id,omitempty"`       // 主键
+	Type     int32 `json:"Type,omitempty"`     //奖励类型
+	RankMin  int32 `json:"RankMin,omitempty"`  //排名下限
+	RankMax  int32 `json:"RankMax,omitempty"`  //排名上限
+	RewardId int32 `json:"RewardId,omitempty"` //奖励掉落id
+}
+
+// ArenaReward.csv属性表集合
+type ArenaRewardEntries struct {
+	Rows map[int32]*ArenaRewardEntry `json:"Rows,omitempty"` //
+}
+
+func init() {
+	excel.AddEntryLoader("ArenaReward.csv", (*ArenaRewardEntries)(nil))
+}
+
+func (e *ArenaRewardEntries) Load(excelFileRaw *excel.ExcelFileRaw) error {
+
+	arenaRewardEntries = &ArenaRewardEntries{
+		Rows: make(map[int32]*ArenaRewardEntry, 100),
+	}
+
+	for _, v := range excelFileRaw.CellData {
+		entry := &ArenaRewardEntry{}
+		err := mapstructure.Decode(v, entry)
+		if !utils.ErrCheck(err, "decode excel data to struct failed", v) {
+			return err
+		}
+
+		arenaRewardEntries.Rows[entry.Id] = entry
+	}
+
+	log.Info().Str("excel_file", excelFileRaw.Filename).Msg("excel load success")
+	return nil
+
+}
+
+func GetArenaRewardEntry(id int32) (*ArenaRewardEntry, bool) {
+	entry, ok := arenaRewardEntries.Rows[id]
+	return entry, ok
+}
+
+func GetArenaRewardSize() int32 {
+	return int32(len(arenaRewardEntries.Rows))
+}
+
+func GetArenaRewardRows() map[int32]*ArenaRewardEntry {
+	return arenaRewardEntries.Rows
+}
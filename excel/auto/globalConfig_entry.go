@@ -69,6 +69,13 @@ type GlobalConfigEntry struct {
 	CollectionQualityScoreFactor   []decimal.Decimal `json:"CollectionQualityScoreFactor,omitempty"`   //收集品评分品质系数(绿、蓝、紫、黄)
 	CollectionStarScoreFactor      []decimal.Decimal `json:"CollectionStarScoreFactor,omitempty"`      //收集品评分星级系数(0星开始，最多6星)
 	CollectionWakeupScoreFactor    decimal.Decimal   `json:"CollectionWakeupScoreFactor,omitempty"`    //收集品评分觉醒系数
+	ArenaSceneId                   int32             `json:"ArenaSceneId,omitempty"`                   //竞技场战斗场景id
+	ArenaInitScore                 int32             `json:"ArenaInitScore,omitempty"`                 //竞技场初始积分
+	ArenaEloK                      int32             `json:"ArenaEloK,omitempty"`                      //竞技场积分变化K值
+	ArenaMatchScoreRange           int32             `json:"ArenaMatchScoreRange,omitempty"`           //竞技场匹配对手的积分范围
+	ArenaDailyChallengeTimes       int32             `json:"ArenaDailyChallengeTimes,omitempty"`       //竞技场每日挑战次数
+	ArenaSeasonBeginTime           int32             `json:"ArenaSeasonBeginTime,omitempty"`           //竞技场第一赛季开始时间(秒)
+	ArenaSeasonDays                int32             `json:"ArenaSeasonDays,omitempty"`                //竞技场赛季天数
 }
 
 // GlobalConfig.csv属性表集合
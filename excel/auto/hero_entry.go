@@ -25,6 +25,8 @@ type HeroEntry struct {
 	Skill2            int32   `json:"Skill2,omitempty"`            //技能2
 	Skill3            int32   `json:"Skill3,omitempty"`            //技能3
 	Skill4            int32   `json:"Skill4,omitempty"`            //技能4
+	StateMask         int32   `json:"StateMask,omitempty"`         //初始状态掩码
+	Immunity          []int32 `json:"Immunity,omitempty"`          //免疫掩码
 }
 
 // Hero.csv属性表集合
@@ -27,6 +27,8 @@ type MonsterEntry struct {
 	ProtectionBreakFx string          `json:"ProtectionBreakFx,omitempty"` //
 	BreakTime         decimal.Decimal `json:"BreakTime,omitempty"`         //break,持续时间(秒)
 	NormalTime        decimal.Decimal `json:"NormalTime,omitempty"`        //normal,持续时间(秒)
+	StateMask         int32           `json:"StateMask,omitempty"`         //初始状态掩码
+	Immunity          []int32         `json:"Immunity,omitempty"`          //免疫掩码
 }
 
 // Monster.csv属性表集合
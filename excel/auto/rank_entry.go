@@ -15,6 +15,8 @@ type RankEntry struct {
 	RefreshType int32 `json:"RefreshType,omitempty"` //刷新方式
 	Local       bool  `json:"Local,omitempty"`       //是否仅为本服排行榜
 	Desc        bool  `json:"Desc,omitempty"`        //是否降序排序
+	MaxSize     int32 `json:"MaxSize,omitempty"`     //排行榜最大数量
+	KeepBest    bool  `json:"KeepBest,omitempty"`    //是否只保留最好成绩
 }
 
 // Rank.csv属性表集合
@@ -0,0 +1,60 @@
+package auto
+
+import (
+	"github.com/east-eden/server/excel"
+	"github.com/east-eden/server/utils"
+	"github.com/mitchellh/mapstructure"
+	"github.com/rs/zerolog/log"
+)
+
+var sensitiveWordEntries *SensitiveWordEntries //SensitiveWord.csv全局变量
+
+// SensitiveWord.csv属性表
+type SensitiveWordEntry struct {
+	Id     int32  `json:"Id,omitempty"`     // 主键
+	Word   string `json:"Word,omitempty"`   //屏蔽字
+	Action int32  `json:"Action,omitempty"` //处理方式
+}
+
+// SensitiveWord.csv属性表集合
+type SensitiveWordEntries struct {
+	Rows map[int32]*SensitiveWordEntry `json:"Rows,omitempty"` //
+}
+
+func init() {
+	excel.AddEntryLoader("SensitiveWord.csv", (*SensitiveWordEntries)(nil))
+}
+
+func (e *SensitiveWordEntries) Load(excelFileRaw *excel.ExcelFileRaw) error {
+
+	sensitiveWordEntries = &SensitiveWordEntries{
+		Rows: make(map[int32]*SensitiveWordEntry, 100),
+	}
+
+	for _, v := range excelFileRaw.CellData {
+		entry := &SensitiveWordEntry{}
+		err := mapstructure.Decode(v, entry)
+		if !utils.ErrCheck(err, "decode excel data to struct failed", v) {
+			return err
+		}
+
+		sensitiveWordEntries.Rows[entry.Id] = entry
+	}
+
+	log.Info().Str("excel_file", excelFileRaw.Filename).Msg("excel load success")
+	return nil
+
+}
+
+func GetSensitiveWordEntry(id int32) (*SensitiveWordEntry, bool) {
+	entry, ok := sensitiveWordEntries.Rows[id]
+	return entry, ok
+}
+
+func GetSensitiveWordSize() int32 {
+	return int32(len(sensitiveWordEntries.Rows))
+}
+
+func GetSensitiveWordRows() map[int32]*SensitiveWordEntry {
+	return sensitiveWordEntries.Rows
+}
@@ -0,0 +1,28 @@
+package auto
+
+import (
+	"github.com/east-eden/server/excel"
+	"github.com/east-eden/server/utils/sensitive"
+)
+
+func init() {
+	excel.AddEntryManualLoader("SensitiveWord.csv", (*SensitiveWordEntries)(nil))
+}
+
+// ManualLoader 屏蔽字表加载或热更后重建屏蔽字过滤器
+func (e *SensitiveWordEntries) ManualLoad(*excel.ExcelFileRaw) error {
+	if sensitiveWordEntries == nil {
+		return nil
+	}
+
+	words := make([]*sensitive.Word, 0, len(sensitiveWordEntries.Rows))
+	for _, entry := range sensitiveWordEntries.Rows {
+		words = append(words, &sensitive.Word{
+			Text:   entry.Word,
+			Action: entry.Action,
+		})
+	}
+
+	sensitive.Reset(words)
+	return nil
+}
@@ -15,12 +15,14 @@ var towerEntries *TowerEntries //Tower.csv全局变量
 
 // Tower.csv属性表
 type TowerEntry struct {
-	Id            int32 `json:"Id,omitempty"`            // 主键
-	Floor         int32 `json:"Floor,omitempty"`         // 多主键之一
-	LevelLimit    int32 `json:"LevelLimit,omitempty"`    //队伍等级限制
-	FirstRewardId int32 `json:"FirstRewardId,omitempty"` //首通奖励Id
-	DailyRewardId int32 `json:"DailyRewardId,omitempty"` //每日结算奖励id
-	SceneId       int32 `json:"SceneId,omitempty"`       //场景id
+	Id            int32   `json:"Id,omitempty"`            // 主键
+	Floor         int32   `json:"Floor,omitempty"`         // 多主键之一
+	LevelLimit    int32   `json:"LevelLimit,omitempty"`    //队伍等级限制
+	FirstRewardId int32   `json:"FirstRewardId,omitempty"` //首通奖励Id
+	DailyRewardId int32   `json:"DailyRewardId,omitempty"` //每日结算奖励id
+	SceneId       int32   `json:"SceneId,omitempty"`       //场景id
+	LayoutID      int32   `json:"LayoutID,omitempty"`      //布阵点ID
+	WaveID        []int32 `json:"WaveID,omitempty"`        //波数ID
 }
 
 // Tower.csv属性表集合
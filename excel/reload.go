@@ -0,0 +1,73 @@
+package excel
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/east-eden/server/utils"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	WatchInterval = 10 * time.Second // 配置表热更检查间隔
+)
+
+// 重新读取指定的csv文件, 并重新执行对应的EntryLoader和EntryManualLoader
+func ReloadEntries(dirPath string, fileNames ...string) {
+	readCSV(dirPath, fileNames)
+
+	for _, name := range fileNames {
+		if v, ok := entryLoaders.Load(name); ok {
+			err := v.(EntryLoader).Load(excelFileRaws[name])
+			utils.ErrPrint(err, "EntryLoader Load failed when ReloadEntries", name)
+		}
+
+		if v, ok := entryManualLoaders.Load(name); ok {
+			err := v.(EntryManualLoader).ManualLoad(excelFileRaws[name])
+			utils.ErrPrint(err, "EntryManualLoader Load failed when ReloadEntries", name)
+		}
+
+		log.Info().Str("file_name", name).Msg("excel entries reloaded")
+	}
+}
+
+// 定时检查指定csv文件的修改时间, 有修改时热更配置, 直到ctx结束
+func WatchEntries(ctx context.Context, dirPath string, fileNames ...string) error {
+	modTimes := make(map[string]time.Time, len(fileNames))
+	modTime := func(name string) time.Time {
+		fi, err := os.Stat(fmt.Sprintf("%s%s", dirPath, name))
+		if err != nil {
+			return time.Time{}
+		}
+		return fi.ModTime()
+	}
+
+	for _, name := range fileNames {
+		modTimes[name] = modTime(name)
+	}
+
+	ticker := time.NewTicker(WatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			changed := make([]string, 0, len(fileNames))
+			for _, name := range fileNames {
+				if t := modTime(name); !t.Equal(modTimes[name]) {
+					modTimes[name] = t
+					changed = append(changed, name)
+				}
+			}
+
+			if len(changed) > 0 {
+				ReloadEntries(dirPath, changed...)
+			}
+		}
+	}
+}
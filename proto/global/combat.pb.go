@@ -20,34 +20,756 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 战斗事件类型
+type CombatEventType int32
+
+const (
+	CombatEventType_CombatEvent_Begin       CombatEventType = 0
+	CombatEventType_CombatEvent_Action      CombatEventType = 0  // 0 行动 Value:行动类型
+	CombatEventType_CombatEvent_SkillCast   CombatEventType = 1  // 1 技能施放
+	CombatEventType_CombatEvent_Damage      CombatEventType = 2  // 2 伤害 Value:伤害值
+	CombatEventType_CombatEvent_BuffAdd     CombatEventType = 3  // 3 添加buff
+	CombatEventType_CombatEvent_BuffRemove  CombatEventType = 4  // 4 移除buff
+	CombatEventType_CombatEvent_Interrupt   CombatEventType = 5  // 5 打断
+	CombatEventType_CombatEvent_Dead        CombatEventType = 6  // 6 死亡
+	CombatEventType_CombatEvent_WaveStart   CombatEventType = 7  // 7 波次开始 Value:波次(从1开始)
+	CombatEventType_CombatEvent_SkillHit    CombatEventType = 8  // 8 技能作用目标 Crit:暴击 Miss:未命中
+	CombatEventType_CombatEvent_Heal        CombatEventType = 9  // 9 治疗 Value:治疗值
+	CombatEventType_CombatEvent_BuffTick    CombatEventType = 10 // 10 buff生效 Value:效果索引(-1为全部效果)
+	CombatEventType_CombatEvent_StateAdd    CombatEventType = 11 // 11 进入状态 Value:状态
+	CombatEventType_CombatEvent_StateRemove CombatEventType = 12 // 12 脱离状态 Value:状态
+	CombatEventType_CombatEvent_WaveEnd     CombatEventType = 13 // 13 波次结束 Value:波次(从1开始)
+	CombatEventType_CombatEvent_SkillEnd    CombatEventType = 14 // 14 技能施放结束 Value:阵营能量
+	CombatEventType_CombatEvent_End         CombatEventType = 15
+)
+
+// Enum value maps for CombatEventType.
+var (
+	CombatEventType_name = map[int32]string{
+		0: "CombatEvent_Begin",
+		// Duplicate value: 0: "CombatEvent_Action",
+		1:  "CombatEvent_SkillCast",
+		2:  "CombatEvent_Damage",
+		3:  "CombatEvent_BuffAdd",
+		4:  "CombatEvent_BuffRemove",
+		5:  "CombatEvent_Interrupt",
+		6:  "CombatEvent_Dead",
+		7:  "CombatEvent_WaveStart",
+		8:  "CombatEvent_SkillHit",
+		9:  "CombatEvent_Heal",
+		10: "CombatEvent_BuffTick",
+		11: "CombatEvent_StateAdd",
+		12: "CombatEvent_StateRemove",
+		13: "CombatEvent_WaveEnd",
+		14: "CombatEvent_SkillEnd",
+		15: "CombatEvent_End",
+	}
+	CombatEventType_value = map[string]int32{
+		"CombatEvent_Begin":       0,
+		"CombatEvent_Action":      0,
+		"CombatEvent_SkillCast":   1,
+		"CombatEvent_Damage":      2,
+		"CombatEvent_BuffAdd":     3,
+		"CombatEvent_BuffRemove":  4,
+		"CombatEvent_Interrupt":   5,
+		"CombatEvent_Dead":        6,
+		"CombatEvent_WaveStart":   7,
+		"CombatEvent_SkillHit":    8,
+		"CombatEvent_Heal":        9,
+		"CombatEvent_BuffTick":    10,
+		"CombatEvent_StateAdd":    11,
+		"CombatEvent_StateRemove": 12,
+		"CombatEvent_WaveEnd":     13,
+		"CombatEvent_SkillEnd":    14,
+		"CombatEvent_End":         15,
+	}
+)
+
+func (x CombatEventType) Enum() *CombatEventType {
+	p := new(CombatEventType)
+	*p = x
+	return p
+}
+
+func (x CombatEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CombatEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_combat_proto_enumTypes[0].Descriptor()
+}
+
+func (CombatEventType) Type() protoreflect.EnumType {
+	return &file_combat_proto_enumTypes[0]
+}
+
+func (x CombatEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CombatEventType.Descriptor instead.
+func (CombatEventType) EnumDescriptor() ([]byte, []int) {
+	return file_combat_proto_rawDescGZIP(), []int{0}
+}
+
 // 战斗单位信息
 type EntityInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	HeroTypeId    int32     `protobuf:"varint,1,opt,name=HeroTypeId,proto3" json:"HeroTypeId,omitempty"`              // 英雄type_id
-	CrystalSkills []int32   `protobuf:"varint,2,rep,packed,name=CrystalSkills,proto3" json:"CrystalSkills,omitempty"` // 残响技能
-	AttValue      []float32 `protobuf:"fixed32,3,rep,packed,name=AttValue,proto3" json:"AttValue,omitempty"`          // 英雄属性
+	HeroTypeId    int32     `protobuf:"varint,1,opt,name=HeroTypeId,proto3" json:"HeroTypeId,omitempty"`              // 英雄type_id
+	CrystalSkills []int32   `protobuf:"varint,2,rep,packed,name=CrystalSkills,proto3" json:"CrystalSkills,omitempty"` // 残响技能
+	AttValue      []float32 `protobuf:"fixed32,3,rep,packed,name=AttValue,proto3" json:"AttValue,omitempty"`          // 英雄属性
+	Pos           int32     `protobuf:"varint,4,opt,name=Pos,proto3" json:"Pos,omitempty"`                            // 布阵位置, 从1开始, 为0时按出场顺序站位
+}
+
+func (x *EntityInfo) Reset() {
+	*x = EntityInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_combat_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EntityInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityInfo) ProtoMessage() {}
+
+func (x *EntityInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_combat_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityInfo.ProtoReflect.Descriptor instead.
+func (*EntityInfo) Descriptor() ([]byte, []int) {
+	return file_combat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EntityInfo) GetHeroTypeId() int32 {
+	if x != nil {
+		return x.HeroTypeId
+	}
+	return 0
+}
+
+func (x *EntityInfo) GetCrystalSkills() []int32 {
+	if x != nil {
+		return x.CrystalSkills
+	}
+	return nil
+}
+
+func (x *EntityInfo) GetAttValue() []float32 {
+	if x != nil {
+		return x.AttValue
+	}
+	return nil
+}
+
+func (x *EntityInfo) GetPos() int32 {
+	if x != nil {
+		return x.Pos
+	}
+	return 0
+}
+
+// 竞技场防守阵容快照
+type ArenaDefenceSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EntityList []*EntityInfo `protobuf:"bytes,1,rep,name=EntityList,proto3" json:"EntityList,omitempty"` // 保存防守布阵时的英雄信息
+}
+
+func (x *ArenaDefenceSnapshot) Reset() {
+	*x = ArenaDefenceSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_combat_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArenaDefenceSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArenaDefenceSnapshot) ProtoMessage() {}
+
+func (x *ArenaDefenceSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_combat_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArenaDefenceSnapshot.ProtoReflect.Descriptor instead.
+func (*ArenaDefenceSnapshot) Descriptor() ([]byte, []int) {
+	return file_combat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ArenaDefenceSnapshot) GetEntityList() []*EntityInfo {
+	if x != nil {
+		return x.EntityList
+	}
+	return nil
+}
+
+// 战斗统计
+type CombatStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AttackUnitNum    int32             `protobuf:"varint,1,opt,name=AttackUnitNum,proto3" json:"AttackUnitNum,omitempty"`       // 进攻方单位数
+	AttackDeadNum    int32             `protobuf:"varint,2,opt,name=AttackDeadNum,proto3" json:"AttackDeadNum,omitempty"`       // 进攻方死亡单位数
+	DefenceUnitNum   int32             `protobuf:"varint,3,opt,name=DefenceUnitNum,proto3" json:"DefenceUnitNum,omitempty"`     // 防守方单位数
+	DefenceDeadNum   int32             `protobuf:"varint,4,opt,name=DefenceDeadNum,proto3" json:"DefenceDeadNum,omitempty"`     // 防守方死亡单位数
+	InterruptNum     int32             `protobuf:"varint,5,opt,name=InterruptNum,proto3" json:"InterruptNum,omitempty"`         // 进攻方打断敌方技能次数
+	UltimateSkillNum int32             `protobuf:"varint,6,opt,name=UltimateSkillNum,proto3" json:"UltimateSkillNum,omitempty"` // 进攻方释放奥义技能次数
+	PassTime         int32             `protobuf:"varint,7,opt,name=PassTime,proto3" json:"PassTime,omitempty"`                 // 战斗用时(秒)
+	Rounds           int32             `protobuf:"varint,8,opt,name=Rounds,proto3" json:"Rounds,omitempty"`                     // 战斗回合数
+	KillOrder        []int32           `protobuf:"varint,9,rep,packed,name=KillOrder,proto3" json:"KillOrder,omitempty"`        // 防守方单位死亡顺序(type_id)
+	WaveReached      int32             `protobuf:"varint,10,opt,name=WaveReached,proto3" json:"WaveReached,omitempty"`          // 到达的波次(从1开始)
+	Waves            []*WaveStatistics `protobuf:"bytes,11,rep,name=Waves,proto3" json:"Waves,omitempty"`                       // 波次统计
+}
+
+func (x *CombatStatistics) Reset() {
+	*x = CombatStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_combat_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CombatStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CombatStatistics) ProtoMessage() {}
+
+func (x *CombatStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_combat_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CombatStatistics.ProtoReflect.Descriptor instead.
+func (*CombatStatistics) Descriptor() ([]byte, []int) {
+	return file_combat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CombatStatistics) GetAttackUnitNum() int32 {
+	if x != nil {
+		return x.AttackUnitNum
+	}
+	return 0
+}
+
+func (x *CombatStatistics) GetAttackDeadNum() int32 {
+	if x != nil {
+		return x.AttackDeadNum
+	}
+	return 0
+}
+
+func (x *CombatStatistics) GetDefenceUnitNum() int32 {
+	if x != nil {
+		return x.DefenceUnitNum
+	}
+	return 0
+}
+
+func (x *CombatStatistics) GetDefenceDeadNum() int32 {
+	if x != nil {
+		return x.DefenceDeadNum
+	}
+	return 0
+}
+
+func (x *CombatStatistics) GetInterruptNum() int32 {
+	if x != nil {
+		return x.InterruptNum
+	}
+	return 0
+}
+
+func (x *CombatStatistics) GetUltimateSkillNum() int32 {
+	if x != nil {
+		return x.UltimateSkillNum
+	}
+	return 0
+}
+
+func (x *CombatStatistics) GetPassTime() int32 {
+	if x != nil {
+		return x.PassTime
+	}
+	return 0
+}
+
+func (x *CombatStatistics) GetRounds() int32 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *CombatStatistics) GetKillOrder() []int32 {
+	if x != nil {
+		return x.KillOrder
+	}
+	return nil
+}
+
+func (x *CombatStatistics) GetWaveReached() int32 {
+	if x != nil {
+		return x.WaveReached
+	}
+	return 0
+}
+
+func (x *CombatStatistics) GetWaves() []*WaveStatistics {
+	if x != nil {
+		return x.Waves
+	}
+	return nil
+}
+
+// 波次统计
+type WaveStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WaveId         int32 `protobuf:"varint,1,opt,name=WaveId,proto3" json:"WaveId,omitempty"`                 // 波次id
+	StartRound     int32 `protobuf:"varint,2,opt,name=StartRound,proto3" json:"StartRound,omitempty"`         // 开始回合
+	Rounds         int32 `protobuf:"varint,3,opt,name=Rounds,proto3" json:"Rounds,omitempty"`                 // 持续回合数
+	DefenceUnitNum int32 `protobuf:"varint,4,opt,name=DefenceUnitNum,proto3" json:"DefenceUnitNum,omitempty"` // 本波防守方单位数
+	DefenceDeadNum int32 `protobuf:"varint,5,opt,name=DefenceDeadNum,proto3" json:"DefenceDeadNum,omitempty"` // 本波防守方死亡单位数
+	AttackDeadNum  int32 `protobuf:"varint,6,opt,name=AttackDeadNum,proto3" json:"AttackDeadNum,omitempty"`   // 本波进攻方死亡单位数
+	Cleared        bool  `protobuf:"varint,7,opt,name=Cleared,proto3" json:"Cleared,omitempty"`               // 是否清除本波
+}
+
+func (x *WaveStatistics) Reset() {
+	*x = WaveStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_combat_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WaveStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaveStatistics) ProtoMessage() {}
+
+func (x *WaveStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_combat_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaveStatistics.ProtoReflect.Descriptor instead.
+func (*WaveStatistics) Descriptor() ([]byte, []int) {
+	return file_combat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WaveStatistics) GetWaveId() int32 {
+	if x != nil {
+		return x.WaveId
+	}
+	return 0
+}
+
+func (x *WaveStatistics) GetStartRound() int32 {
+	if x != nil {
+		return x.StartRound
+	}
+	return 0
+}
+
+func (x *WaveStatistics) GetRounds() int32 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *WaveStatistics) GetDefenceUnitNum() int32 {
+	if x != nil {
+		return x.DefenceUnitNum
+	}
+	return 0
+}
+
+func (x *WaveStatistics) GetDefenceDeadNum() int32 {
+	if x != nil {
+		return x.DefenceDeadNum
+	}
+	return 0
+}
+
+func (x *WaveStatistics) GetAttackDeadNum() int32 {
+	if x != nil {
+		return x.AttackDeadNum
+	}
+	return 0
+}
+
+func (x *WaveStatistics) GetCleared() bool {
+	if x != nil {
+		return x.Cleared
+	}
+	return false
+}
+
+// 战斗事件
+type CombatEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Round    int32           `protobuf:"varint,1,opt,name=Round,proto3" json:"Round,omitempty"`                          // 回合数
+	Type     CombatEventType `protobuf:"varint,2,opt,name=Type,proto3,enum=proto.CombatEventType" json:"Type,omitempty"` // 事件类型
+	CasterId int64           `protobuf:"varint,3,opt,name=CasterId,proto3" json:"CasterId,omitempty"`                    // 发起者id
+	TargetId int64           `protobuf:"varint,4,opt,name=TargetId,proto3" json:"TargetId,omitempty"`                    // 目标id
+	SkillId  int32           `protobuf:"varint,5,opt,name=SkillId,proto3" json:"SkillId,omitempty"`                      // 技能id
+	BuffId   int32           `protobuf:"varint,6,opt,name=BuffId,proto3" json:"BuffId,omitempty"`                        // buff id
+	Value    int64           `protobuf:"varint,7,opt,name=Value,proto3" json:"Value,omitempty"`                          // 事件数值
+	Time     int32           `protobuf:"varint,8,opt,name=Time,proto3" json:"Time,omitempty"`                            // 事件时间(毫秒, 从战斗开始计算)
+	Crit     bool            `protobuf:"varint,9,opt,name=Crit,proto3" json:"Crit,omitempty"`                            // 是否暴击
+	Miss     bool            `protobuf:"varint,10,opt,name=Miss,proto3" json:"Miss,omitempty"`                           // 是否未命中
+}
+
+func (x *CombatEvent) Reset() {
+	*x = CombatEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_combat_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CombatEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CombatEvent) ProtoMessage() {}
+
+func (x *CombatEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_combat_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CombatEvent.ProtoReflect.Descriptor instead.
+func (*CombatEvent) Descriptor() ([]byte, []int) {
+	return file_combat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CombatEvent) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *CombatEvent) GetType() CombatEventType {
+	if x != nil {
+		return x.Type
+	}
+	return CombatEventType_CombatEvent_Begin
+}
+
+func (x *CombatEvent) GetCasterId() int64 {
+	if x != nil {
+		return x.CasterId
+	}
+	return 0
+}
+
+func (x *CombatEvent) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *CombatEvent) GetSkillId() int32 {
+	if x != nil {
+		return x.SkillId
+	}
+	return 0
+}
+
+func (x *CombatEvent) GetBuffId() int32 {
+	if x != nil {
+		return x.BuffId
+	}
+	return 0
+}
+
+func (x *CombatEvent) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CombatEvent) GetTime() int32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *CombatEvent) GetCrit() bool {
+	if x != nil {
+		return x.Crit
+	}
+	return false
+}
+
+func (x *CombatEvent) GetMiss() bool {
+	if x != nil {
+		return x.Miss
+	}
+	return false
+}
+
+// 战斗录像
+type CombatRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                int64             `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`                              // 录像id
+	Seed              int64             `protobuf:"varint,2,opt,name=Seed,proto3" json:"Seed,omitempty"`                          // 场景随机种子
+	SceneTypeId       int32             `protobuf:"varint,3,opt,name=SceneTypeId,proto3" json:"SceneTypeId,omitempty"`            // 场景type_id
+	StageId           int32             `protobuf:"varint,4,opt,name=StageId,proto3" json:"StageId,omitempty"`                    // 关卡id
+	AttackId          int64             `protobuf:"varint,5,opt,name=AttackId,proto3" json:"AttackId,omitempty"`                  // 进攻方id
+	DefenceId         int64             `protobuf:"varint,6,opt,name=DefenceId,proto3" json:"DefenceId,omitempty"`                // 防守方id
+	AttackEntityList  []*EntityInfo     `protobuf:"bytes,7,rep,name=AttackEntityList,proto3" json:"AttackEntityList,omitempty"`   // 进攻方初始单位信息
+	DefenceEntityList []*EntityInfo     `protobuf:"bytes,8,rep,name=DefenceEntityList,proto3" json:"DefenceEntityList,omitempty"` // 防守方初始单位信息
+	WaveIds           []int32           `protobuf:"varint,9,rep,packed,name=WaveIds,proto3" json:"WaveIds,omitempty"`             // 怪物波次id
+	Events            []*CombatEvent    `protobuf:"bytes,10,rep,name=Events,proto3" json:"Events,omitempty"`                      // 战斗事件
+	Win               bool              `protobuf:"varint,11,opt,name=Win,proto3" json:"Win,omitempty"`                           // 战斗结果
+	Statistics        *CombatStatistics `protobuf:"bytes,12,opt,name=Statistics,proto3" json:"Statistics,omitempty"`              // 战斗统计
+	CreateTime        int64             `protobuf:"varint,13,opt,name=CreateTime,proto3" json:"CreateTime,omitempty"`             // 录像时间
+	WaveCarryHP       bool              `protobuf:"varint,14,opt,name=WaveCarryHP,proto3" json:"WaveCarryHP,omitempty"`           // 进攻方血量带入下一波
+	WaveCarryBuff     bool              `protobuf:"varint,15,opt,name=WaveCarryBuff,proto3" json:"WaveCarryBuff,omitempty"`       // 进攻方buff带入下一波
+	LayoutId          int32             `protobuf:"varint,16,opt,name=LayoutId,proto3" json:"LayoutId,omitempty"`                 // 进攻方布阵点id
+}
+
+func (x *CombatRecord) Reset() {
+	*x = CombatRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_combat_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CombatRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CombatRecord) ProtoMessage() {}
+
+func (x *CombatRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_combat_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CombatRecord.ProtoReflect.Descriptor instead.
+func (*CombatRecord) Descriptor() ([]byte, []int) {
+	return file_combat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CombatRecord) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CombatRecord) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *CombatRecord) GetSceneTypeId() int32 {
+	if x != nil {
+		return x.SceneTypeId
+	}
+	return 0
+}
+
+func (x *CombatRecord) GetStageId() int32 {
+	if x != nil {
+		return x.StageId
+	}
+	return 0
+}
+
+func (x *CombatRecord) GetAttackId() int64 {
+	if x != nil {
+		return x.AttackId
+	}
+	return 0
+}
+
+func (x *CombatRecord) GetDefenceId() int64 {
+	if x != nil {
+		return x.DefenceId
+	}
+	return 0
+}
+
+func (x *CombatRecord) GetAttackEntityList() []*EntityInfo {
+	if x != nil {
+		return x.AttackEntityList
+	}
+	return nil
+}
+
+func (x *CombatRecord) GetDefenceEntityList() []*EntityInfo {
+	if x != nil {
+		return x.DefenceEntityList
+	}
+	return nil
+}
+
+func (x *CombatRecord) GetWaveIds() []int32 {
+	if x != nil {
+		return x.WaveIds
+	}
+	return nil
+}
+
+func (x *CombatRecord) GetEvents() []*CombatEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *CombatRecord) GetWin() bool {
+	if x != nil {
+		return x.Win
+	}
+	return false
+}
+
+func (x *CombatRecord) GetStatistics() *CombatStatistics {
+	if x != nil {
+		return x.Statistics
+	}
+	return nil
+}
+
+func (x *CombatRecord) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+func (x *CombatRecord) GetWaveCarryHP() bool {
+	if x != nil {
+		return x.WaveCarryHP
+	}
+	return false
+}
+
+func (x *CombatRecord) GetWaveCarryBuff() bool {
+	if x != nil {
+		return x.WaveCarryBuff
+	}
+	return false
+}
+
+func (x *CombatRecord) GetLayoutId() int32 {
+	if x != nil {
+		return x.LayoutId
+	}
+	return 0
+}
+
+// 查询战斗录像
+type C2S_QueryCombatRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecordId int64 `protobuf:"varint,1,opt,name=RecordId,proto3" json:"RecordId,omitempty"`
 }
 
-func (x *EntityInfo) Reset() {
-	*x = EntityInfo{}
+func (x *C2S_QueryCombatRecord) Reset() {
+	*x = C2S_QueryCombatRecord{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_combat_proto_msgTypes[0]
+		mi := &file_combat_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EntityInfo) String() string {
+func (x *C2S_QueryCombatRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EntityInfo) ProtoMessage() {}
+func (*C2S_QueryCombatRecord) ProtoMessage() {}
 
-func (x *EntityInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_combat_proto_msgTypes[0]
+func (x *C2S_QueryCombatRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_combat_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,28 +780,141 @@ func (x *EntityInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EntityInfo.ProtoReflect.Descriptor instead.
-func (*EntityInfo) Descriptor() ([]byte, []int) {
-	return file_combat_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use C2S_QueryCombatRecord.ProtoReflect.Descriptor instead.
+func (*C2S_QueryCombatRecord) Descriptor() ([]byte, []int) {
+	return file_combat_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *EntityInfo) GetHeroTypeId() int32 {
+func (x *C2S_QueryCombatRecord) GetRecordId() int64 {
 	if x != nil {
-		return x.HeroTypeId
+		return x.RecordId
 	}
 	return 0
 }
 
-func (x *EntityInfo) GetCrystalSkills() []int32 {
+type S2C_CombatRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Record *CombatRecord `protobuf:"bytes,1,opt,name=Record,proto3" json:"Record,omitempty"`
+}
+
+func (x *S2C_CombatRecord) Reset() {
+	*x = S2C_CombatRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_combat_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *S2C_CombatRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_CombatRecord) ProtoMessage() {}
+
+func (x *S2C_CombatRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_combat_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_CombatRecord.ProtoReflect.Descriptor instead.
+func (*S2C_CombatRecord) Descriptor() ([]byte, []int) {
+	return file_combat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *S2C_CombatRecord) GetRecord() *CombatRecord {
 	if x != nil {
-		return x.CrystalSkills
+		return x.Record
 	}
 	return nil
 }
 
-func (x *EntityInfo) GetAttValue() []float32 {
+// 关卡战斗结果, 客户端按事件流播放服务器模拟的战斗
+type S2C_StageCombat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StageId    int32             `protobuf:"varint,1,opt,name=StageId,proto3" json:"StageId,omitempty"`      // 关卡id
+	Win        bool              `protobuf:"varint,2,opt,name=Win,proto3" json:"Win,omitempty"`              // 战斗结果
+	Statistics *CombatStatistics `protobuf:"bytes,3,opt,name=Statistics,proto3" json:"Statistics,omitempty"` // 战斗统计
+	RecordId   int64             `protobuf:"varint,4,opt,name=RecordId,proto3" json:"RecordId,omitempty"`    // 战斗录像id
+	Events     []*CombatEvent    `protobuf:"bytes,5,rep,name=Events,proto3" json:"Events,omitempty"`         // 战斗事件
+}
+
+func (x *S2C_StageCombat) Reset() {
+	*x = S2C_StageCombat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_combat_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *S2C_StageCombat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_StageCombat) ProtoMessage() {}
+
+func (x *S2C_StageCombat) ProtoReflect() protoreflect.Message {
+	mi := &file_combat_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_StageCombat.ProtoReflect.Descriptor instead.
+func (*S2C_StageCombat) Descriptor() ([]byte, []int) {
+	return file_combat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *S2C_StageCombat) GetStageId() int32 {
 	if x != nil {
-		return x.AttValue
+		return x.StageId
+	}
+	return 0
+}
+
+func (x *S2C_StageCombat) GetWin() bool {
+	if x != nil {
+		return x.Win
+	}
+	return false
+}
+
+func (x *S2C_StageCombat) GetStatistics() *CombatStatistics {
+	if x != nil {
+		return x.Statistics
+	}
+	return nil
+}
+
+func (x *S2C_StageCombat) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+func (x *S2C_StageCombat) GetEvents() []*CombatEvent {
+	if x != nil {
+		return x.Events
 	}
 	return nil
 }
@@ -88,18 +923,165 @@ var File_combat_proto protoreflect.FileDescriptor
 
 var file_combat_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x6e, 0x0a, 0x0a, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49,
-	0x6e, 0x66, 0x6f, 0x12, 0x1e, 0x0a, 0x0a, 0x48, 0x65, 0x72, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x48, 0x65, 0x72, 0x6f, 0x54, 0x79, 0x70,
-	0x65, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x43, 0x72, 0x79, 0x73, 0x74, 0x61, 0x6c, 0x53, 0x6b,
-	0x69, 0x6c, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0d, 0x43, 0x72, 0x79, 0x73,
-	0x74, 0x61, 0x6c, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x74, 0x74,
-	0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x02, 0x52, 0x08, 0x41, 0x74, 0x74,
-	0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x32, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65, 0x6e, 0x2f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6c, 0x6f, 0x62, 0x61,
-	0x6c, 0xaa, 0x02, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x80, 0x01, 0x0a, 0x0a, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1e, 0x0a, 0x0a, 0x48, 0x65, 0x72, 0x6f, 0x54, 0x79, 0x70, 0x65,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x48, 0x65, 0x72, 0x6f, 0x54, 0x79,
+	0x70, 0x65, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x43, 0x72, 0x79, 0x73, 0x74, 0x61, 0x6c, 0x53,
+	0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0d, 0x43, 0x72, 0x79,
+	0x73, 0x74, 0x61, 0x6c, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x74,
+	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x02, 0x52, 0x08, 0x41, 0x74,
+	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x50, 0x6f, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x03, 0x50, 0x6f, 0x73, 0x22, 0x49, 0x0a, 0x14, 0x41, 0x72, 0x65, 0x6e,
+	0x61, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x12, 0x31, 0x0a, 0x0a, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c,
+	0x69, 0x73, 0x74, 0x22, 0x9f, 0x03, 0x0a, 0x10, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x41, 0x74, 0x74, 0x61,
+	0x63, 0x6b, 0x55, 0x6e, 0x69, 0x74, 0x4e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x55, 0x6e, 0x69, 0x74, 0x4e, 0x75, 0x6d, 0x12, 0x24,
+	0x0a, 0x0d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x44, 0x65, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x44, 0x65, 0x61,
+	0x64, 0x4e, 0x75, 0x6d, 0x12, 0x26, 0x0a, 0x0e, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x55,
+	0x6e, 0x69, 0x74, 0x4e, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x44, 0x65,
+	0x66, 0x65, 0x6e, 0x63, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x4e, 0x75, 0x6d, 0x12, 0x26, 0x0a, 0x0e,
+	0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x44, 0x65, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x44, 0x65, 0x61,
+	0x64, 0x4e, 0x75, 0x6d, 0x12, 0x22, 0x0a, 0x0c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75, 0x70,
+	0x74, 0x4e, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x72, 0x75, 0x70, 0x74, 0x4e, 0x75, 0x6d, 0x12, 0x2a, 0x0a, 0x10, 0x55, 0x6c, 0x74, 0x69,
+	0x6d, 0x61, 0x74, 0x65, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x4e, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x10, 0x55, 0x6c, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x6b, 0x69, 0x6c,
+	0x6c, 0x4e, 0x75, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x61, 0x73, 0x73, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x50, 0x61, 0x73, 0x73, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x4b, 0x69, 0x6c, 0x6c,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x09, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x4b, 0x69, 0x6c,
+	0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x57, 0x61, 0x76, 0x65, 0x52, 0x65,
+	0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x57, 0x61, 0x76,
+	0x65, 0x52, 0x65, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x57, 0x61, 0x76, 0x65,
+	0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x57, 0x61, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x05,
+	0x57, 0x61, 0x76, 0x65, 0x73, 0x22, 0xf0, 0x01, 0x0a, 0x0e, 0x57, 0x61, 0x76, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x61, 0x76, 0x65,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x57, 0x61, 0x76, 0x65, 0x49, 0x64,
+	0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x44, 0x65, 0x66, 0x65,
+	0x6e, 0x63, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x4e, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0e, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x4e, 0x75, 0x6d,
+	0x12, 0x26, 0x0a, 0x0e, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x44, 0x65, 0x61, 0x64, 0x4e,
+	0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63,
+	0x65, 0x44, 0x65, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x12, 0x24, 0x0a, 0x0d, 0x41, 0x74, 0x74, 0x61,
+	0x63, 0x6b, 0x44, 0x65, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x44, 0x65, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x12, 0x18,
+	0x0a, 0x07, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x65, 0x64, 0x22, 0x8b, 0x02, 0x0a, 0x0b, 0x43, 0x6f, 0x6d,
+	0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2a,
+	0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x61,
+	0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x43, 0x61,
+	0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x42, 0x75, 0x66, 0x66, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x42, 0x75,
+	0x66, 0x66, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x43, 0x72, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x43, 0x72,
+	0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x69, 0x73, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x4d, 0x69, 0x73, 0x73, 0x22, 0xbd, 0x04, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x62, 0x61,
+	0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x65, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x53, 0x65, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x53,
+	0x63, 0x65, 0x6e, 0x65, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x53, 0x63, 0x65, 0x6e, 0x65, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x74, 0x74, 0x61, 0x63,
+	0x6b, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x41, 0x74, 0x74, 0x61, 0x63,
+	0x6b, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x49,
+	0x64, 0x12, 0x3d, 0x0a, 0x10, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x10,
+	0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x3f, 0x0a, 0x11, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x11,
+	0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x57, 0x61, 0x76, 0x65, 0x49, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x07, 0x57, 0x61, 0x76, 0x65, 0x49, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x06, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x57, 0x69, 0x6e, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x57, 0x69, 0x6e, 0x12, 0x37, 0x0a, 0x0a, 0x53, 0x74, 0x61,
+	0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x57, 0x61, 0x76, 0x65, 0x43, 0x61, 0x72, 0x72, 0x79, 0x48,
+	0x50, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x57, 0x61, 0x76, 0x65, 0x43, 0x61, 0x72,
+	0x72, 0x79, 0x48, 0x50, 0x12, 0x24, 0x0a, 0x0d, 0x57, 0x61, 0x76, 0x65, 0x43, 0x61, 0x72, 0x72,
+	0x79, 0x42, 0x75, 0x66, 0x66, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x57, 0x61, 0x76,
+	0x65, 0x43, 0x61, 0x72, 0x72, 0x79, 0x42, 0x75, 0x66, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x61,
+	0x79, 0x6f, 0x75, 0x74, 0x49, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x4c, 0x61,
+	0x79, 0x6f, 0x75, 0x74, 0x49, 0x64, 0x22, 0x33, 0x0a, 0x15, 0x43, 0x32, 0x53, 0x5f, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x22, 0x3f, 0x0a, 0x10, 0x53,
+	0x32, 0x43, 0x5f, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x2b, 0x0a, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0xbe, 0x01, 0x0a,
+	0x0f, 0x53, 0x32, 0x43, 0x5f, 0x53, 0x74, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x57, 0x69,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x57, 0x69, 0x6e, 0x12, 0x37, 0x0a, 0x0a,
+	0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49,
+	0x64, 0x12, 0x2a, 0x0a, 0x06, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2a, 0xc1, 0x03,
+	0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x5f, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x62,
+	0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x00,
+	0x12, 0x19, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f,
+	0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x43, 0x61, 0x73, 0x74, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x43,
+	0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x44, 0x61, 0x6d, 0x61, 0x67,
+	0x65, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x42, 0x75, 0x66, 0x66, 0x41, 0x64, 0x64, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16,
+	0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x42, 0x75, 0x66, 0x66,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x10, 0x04, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x62,
+	0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75, 0x70,
+	0x74, 0x10, 0x05, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x44, 0x65, 0x61, 0x64, 0x10, 0x06, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x6f, 0x6d,
+	0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x57, 0x61, 0x76, 0x65, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x10, 0x07, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x5f, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x48, 0x69, 0x74, 0x10, 0x08, 0x12, 0x14,
+	0x0a, 0x10, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x48, 0x65,
+	0x61, 0x6c, 0x10, 0x09, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x5f, 0x42, 0x75, 0x66, 0x66, 0x54, 0x69, 0x63, 0x6b, 0x10, 0x0a, 0x12, 0x18,
+	0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x41, 0x64, 0x64, 0x10, 0x0b, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x6f, 0x6d, 0x62,
+	0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x10, 0x0c, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x5f, 0x57, 0x61, 0x76, 0x65, 0x45, 0x6e, 0x64, 0x10, 0x0d, 0x12, 0x18,
+	0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x53, 0x6b,
+	0x69, 0x6c, 0x6c, 0x45, 0x6e, 0x64, 0x10, 0x0e, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x62,
+	0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x45, 0x6e, 0x64, 0x10, 0x0f, 0x1a, 0x02, 0x10,
+	0x01, 0x42, 0x32, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65, 0x6e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0xaa, 0x02, 0x05,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -114,16 +1096,36 @@ func file_combat_proto_rawDescGZIP() []byte {
 	return file_combat_proto_rawDescData
 }
 
-var file_combat_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_combat_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_combat_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_combat_proto_goTypes = []interface{}{
-	(*EntityInfo)(nil), // 0: proto.EntityInfo
+	(CombatEventType)(0),          // 0: proto.CombatEventType
+	(*EntityInfo)(nil),            // 1: proto.EntityInfo
+	(*ArenaDefenceSnapshot)(nil),  // 2: proto.ArenaDefenceSnapshot
+	(*CombatStatistics)(nil),      // 3: proto.CombatStatistics
+	(*WaveStatistics)(nil),        // 4: proto.WaveStatistics
+	(*CombatEvent)(nil),           // 5: proto.CombatEvent
+	(*CombatRecord)(nil),          // 6: proto.CombatRecord
+	(*C2S_QueryCombatRecord)(nil), // 7: proto.C2S_QueryCombatRecord
+	(*S2C_CombatRecord)(nil),      // 8: proto.S2C_CombatRecord
+	(*S2C_StageCombat)(nil),       // 9: proto.S2C_StageCombat
 }
 var file_combat_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1,  // 0: proto.ArenaDefenceSnapshot.EntityList:type_name -> proto.EntityInfo
+	4,  // 1: proto.CombatStatistics.Waves:type_name -> proto.WaveStatistics
+	0,  // 2: proto.CombatEvent.Type:type_name -> proto.CombatEventType
+	1,  // 3: proto.CombatRecord.AttackEntityList:type_name -> proto.EntityInfo
+	1,  // 4: proto.CombatRecord.DefenceEntityList:type_name -> proto.EntityInfo
+	5,  // 5: proto.CombatRecord.Events:type_name -> proto.CombatEvent
+	3,  // 6: proto.CombatRecord.Statistics:type_name -> proto.CombatStatistics
+	6,  // 7: proto.S2C_CombatRecord.Record:type_name -> proto.CombatRecord
+	3,  // 8: proto.S2C_StageCombat.Statistics:type_name -> proto.CombatStatistics
+	5,  // 9: proto.S2C_StageCombat.Events:type_name -> proto.CombatEvent
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_combat_proto_init() }
@@ -144,19 +1146,116 @@ func file_combat_proto_init() {
 				return nil
 			}
 		}
+		file_combat_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArenaDefenceSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_combat_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CombatStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_combat_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WaveStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_combat_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CombatEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_combat_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CombatRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_combat_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*C2S_QueryCombatRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_combat_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*S2C_CombatRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_combat_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*S2C_StageCombat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_combat_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_combat_proto_goTypes,
 		DependencyIndexes: file_combat_proto_depIdxs,
+		EnumInfos:         file_combat_proto_enumTypes,
 		MessageInfos:      file_combat_proto_msgTypes,
 	}.Build()
 	File_combat_proto = out.File
@@ -20,6 +20,124 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type CommentStatus int32
+
+const (
+	CommentStatus_CommentStatus_Begin   CommentStatus = 0
+	CommentStatus_CommentStatus_Normal  CommentStatus = 0 // 0 正常
+	CommentStatus_CommentStatus_Deleted CommentStatus = 1 // 1 发表者或管理员删除, 仅管理员可见
+	CommentStatus_CommentStatus_Hidden  CommentStatus = 2 // 2 管理员隐藏, 仅管理员可见
+	CommentStatus_CommentStatus_End     CommentStatus = 3
+)
+
+// Enum value maps for CommentStatus.
+var (
+	CommentStatus_name = map[int32]string{
+		0: "CommentStatus_Begin",
+		// Duplicate value: 0: "CommentStatus_Normal",
+		1: "CommentStatus_Deleted",
+		2: "CommentStatus_Hidden",
+		3: "CommentStatus_End",
+	}
+	CommentStatus_value = map[string]int32{
+		"CommentStatus_Begin":   0,
+		"CommentStatus_Normal":  0,
+		"CommentStatus_Deleted": 1,
+		"CommentStatus_Hidden":  2,
+		"CommentStatus_End":     3,
+	}
+)
+
+func (x CommentStatus) Enum() *CommentStatus {
+	p := new(CommentStatus)
+	*p = x
+	return p
+}
+
+func (x CommentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CommentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_define_proto_enumTypes[0].Descriptor()
+}
+
+func (CommentStatus) Type() protoreflect.EnumType {
+	return &file_define_proto_enumTypes[0]
+}
+
+func (x CommentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CommentStatus.Descriptor instead.
+func (CommentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_define_proto_rawDescGZIP(), []int{0}
+}
+
+////////////////////////////////////////////////
+// 聊天
+type ChatChannelType int32
+
+const (
+	ChatChannelType_ChatChannel_Begin      ChatChannelType = 0
+	ChatChannelType_ChatChannel_World      ChatChannelType = 0 // 0 本服世界频道
+	ChatChannelType_ChatChannel_CrossWorld ChatChannelType = 1 // 1 跨服世界频道
+	ChatChannelType_ChatChannel_Private    ChatChannelType = 2 // 2 私聊
+	ChatChannelType_ChatChannel_System     ChatChannelType = 3 // 3 系统广播
+	ChatChannelType_ChatChannel_Guild      ChatChannelType = 4 // 4 公会频道(预留)
+	ChatChannelType_ChatChannel_End        ChatChannelType = 5
+)
+
+// Enum value maps for ChatChannelType.
+var (
+	ChatChannelType_name = map[int32]string{
+		0: "ChatChannel_Begin",
+		// Duplicate value: 0: "ChatChannel_World",
+		1: "ChatChannel_CrossWorld",
+		2: "ChatChannel_Private",
+		3: "ChatChannel_System",
+		4: "ChatChannel_Guild",
+		5: "ChatChannel_End",
+	}
+	ChatChannelType_value = map[string]int32{
+		"ChatChannel_Begin":      0,
+		"ChatChannel_World":      0,
+		"ChatChannel_CrossWorld": 1,
+		"ChatChannel_Private":    2,
+		"ChatChannel_System":     3,
+		"ChatChannel_Guild":      4,
+		"ChatChannel_End":        5,
+	}
+)
+
+func (x ChatChannelType) Enum() *ChatChannelType {
+	p := new(ChatChannelType)
+	*p = x
+	return p
+}
+
+func (x ChatChannelType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChatChannelType) Descriptor() protoreflect.EnumDescriptor {
+	return file_define_proto_enumTypes[1].Descriptor()
+}
+
+func (ChatChannelType) Type() protoreflect.EnumType {
+	return &file_define_proto_enumTypes[1]
+}
+
+func (x ChatChannelType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChatChannelType.Descriptor instead.
+func (ChatChannelType) EnumDescriptor() ([]byte, []int) {
+	return file_define_proto_rawDescGZIP(), []int{1}
+}
+
 ////////////////////////////////////////////////
 // token
 type TokenType int32
@@ -124,11 +242,11 @@ func (x TokenType) String() string {
 }
 
 func (TokenType) Descriptor() protoreflect.EnumDescriptor {
-	return file_define_proto_enumTypes[0].Descriptor()
+	return file_define_proto_enumTypes[2].Descriptor()
 }
 
 func (TokenType) Type() protoreflect.EnumType {
-	return &file_define_proto_enumTypes[0]
+	return &file_define_proto_enumTypes[2]
 }
 
 func (x TokenType) Number() protoreflect.EnumNumber {
@@ -137,7 +255,7 @@ func (x TokenType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TokenType.Descriptor instead.
 func (TokenType) EnumDescriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{0}
+	return file_define_proto_rawDescGZIP(), []int{2}
 }
 
 ////////////////////////////////////////////////
@@ -181,11 +299,11 @@ func (x LootType) String() string {
 }
 
 func (LootType) Descriptor() protoreflect.EnumDescriptor {
-	return file_define_proto_enumTypes[1].Descriptor()
+	return file_define_proto_enumTypes[3].Descriptor()
 }
 
 func (LootType) Type() protoreflect.EnumType {
-	return &file_define_proto_enumTypes[1]
+	return &file_define_proto_enumTypes[3]
 }
 
 func (x LootType) Number() protoreflect.EnumNumber {
@@ -194,7 +312,7 @@ func (x LootType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LootType.Descriptor instead.
 func (LootType) EnumDescriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{1}
+	return file_define_proto_rawDescGZIP(), []int{3}
 }
 
 // 邮件状态
@@ -231,11 +349,11 @@ func (x MailStatus) String() string {
 }
 
 func (MailStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_define_proto_enumTypes[2].Descriptor()
+	return file_define_proto_enumTypes[4].Descriptor()
 }
 
 func (MailStatus) Type() protoreflect.EnumType {
-	return &file_define_proto_enumTypes[2]
+	return &file_define_proto_enumTypes[4]
 }
 
 func (x MailStatus) Number() protoreflect.EnumNumber {
@@ -244,7 +362,7 @@ func (x MailStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use MailStatus.Descriptor instead.
 func (MailStatus) EnumDescriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{2}
+	return file_define_proto_rawDescGZIP(), []int{4}
 }
 
 // 邮件类型
@@ -278,11 +396,11 @@ func (x MailType) String() string {
 }
 
 func (MailType) Descriptor() protoreflect.EnumDescriptor {
-	return file_define_proto_enumTypes[3].Descriptor()
+	return file_define_proto_enumTypes[5].Descriptor()
 }
 
 func (MailType) Type() protoreflect.EnumType {
-	return &file_define_proto_enumTypes[3]
+	return &file_define_proto_enumTypes[5]
 }
 
 func (x MailType) Number() protoreflect.EnumNumber {
@@ -291,7 +409,7 @@ func (x MailType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use MailType.Descriptor instead.
 func (MailType) EnumDescriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{3}
+	return file_define_proto_rawDescGZIP(), []int{5}
 }
 
 ////////////////////////////////////////////////
@@ -332,11 +450,11 @@ func (x TopicType) String() string {
 }
 
 func (TopicType) Descriptor() protoreflect.EnumDescriptor {
-	return file_define_proto_enumTypes[4].Descriptor()
+	return file_define_proto_enumTypes[6].Descriptor()
 }
 
 func (TopicType) Type() protoreflect.EnumType {
-	return &file_define_proto_enumTypes[4]
+	return &file_define_proto_enumTypes[6]
 }
 
 func (x TopicType) Number() protoreflect.EnumNumber {
@@ -345,7 +463,7 @@ func (x TopicType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TopicType.Descriptor instead.
 func (TopicType) EnumDescriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{4}
+	return file_define_proto_rawDescGZIP(), []int{6}
 }
 
 ////////////////////////////////////////////////
@@ -1351,13 +1469,13 @@ type PlayerInfo struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id                 int64   `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
-	AccountId          int64   `protobuf:"varint,2,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
-	Name               string  `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`
-	Exp                int32   `protobuf:"varint,4,opt,name=Exp,proto3" json:"Exp,omitempty"`
-	Level              int32   `protobuf:"varint,5,opt,name=Level,proto3" json:"Level,omitempty"`
-	BuyStrengthenTimes int32   `protobuf:"varint,6,opt,name=BuyStrengthenTimes,proto3" json:"BuyStrengthenTimes,omitempty"` // 已购买体力次数
-	BattleArray        []int64 `protobuf:"varint,7,rep,packed,name=BattleArray,proto3" json:"BattleArray,omitempty"`        // 战斗布阵英雄id
+	Id                 int64          `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	AccountId          int64          `protobuf:"varint,2,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
+	Name               string         `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`
+	Exp                int32          `protobuf:"varint,4,opt,name=Exp,proto3" json:"Exp,omitempty"`
+	Level              int32          `protobuf:"varint,5,opt,name=Level,proto3" json:"Level,omitempty"`
+	BuyStrengthenTimes int32          `protobuf:"varint,6,opt,name=BuyStrengthenTimes,proto3" json:"BuyStrengthenTimes,omitempty"` // 已购买体力次数
+	BattleArrays       []*BattleArray `protobuf:"bytes,8,rep,name=BattleArrays,proto3" json:"BattleArrays,omitempty"`              // 各玩法布阵
 }
 
 func (x *PlayerInfo) Reset() {
@@ -1434,9 +1552,129 @@ func (x *PlayerInfo) GetBuyStrengthenTimes() int32 {
 	return 0
 }
 
-func (x *PlayerInfo) GetBattleArray() []int64 {
+func (x *PlayerInfo) GetBattleArrays() []*BattleArray {
+	if x != nil {
+		return x.BattleArrays
+	}
+	return nil
+}
+
+// 布阵英雄
+type BattleArrayHero struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HeroId int64 `protobuf:"varint,1,opt,name=HeroId,proto3" json:"HeroId,omitempty"`
+	Pos    int32 `protobuf:"varint,2,opt,name=Pos,proto3" json:"Pos,omitempty"` // 布阵位置, 从1开始
+}
+
+func (x *BattleArrayHero) Reset() {
+	*x = BattleArrayHero{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_define_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BattleArrayHero) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleArrayHero) ProtoMessage() {}
+
+func (x *BattleArrayHero) ProtoReflect() protoreflect.Message {
+	mi := &file_define_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleArrayHero.ProtoReflect.Descriptor instead.
+func (*BattleArrayHero) Descriptor() ([]byte, []int) {
+	return file_define_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BattleArrayHero) GetHeroId() int64 {
+	if x != nil {
+		return x.HeroId
+	}
+	return 0
+}
+
+func (x *BattleArrayHero) GetPos() int32 {
+	if x != nil {
+		return x.Pos
+	}
+	return 0
+}
+
+// 布阵
+type BattleArray struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type   int32              `protobuf:"varint,1,opt,name=Type,proto3" json:"Type,omitempty"` // 布阵类型
+	Name   string             `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`  // 布阵名称
+	Heroes []*BattleArrayHero `protobuf:"bytes,3,rep,name=Heroes,proto3" json:"Heroes,omitempty"`
+}
+
+func (x *BattleArray) Reset() {
+	*x = BattleArray{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_define_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BattleArray) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleArray) ProtoMessage() {}
+
+func (x *BattleArray) ProtoReflect() protoreflect.Message {
+	mi := &file_define_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleArray.ProtoReflect.Descriptor instead.
+func (*BattleArray) Descriptor() ([]byte, []int) {
+	return file_define_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BattleArray) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *BattleArray) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BattleArray) GetHeroes() []*BattleArrayHero {
 	if x != nil {
-		return x.BattleArray
+		return x.Heroes
 	}
 	return nil
 }
@@ -1459,7 +1697,7 @@ type Collection struct {
 func (x *Collection) Reset() {
 	*x = Collection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[16]
+		mi := &file_define_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1472,7 +1710,7 @@ func (x *Collection) String() string {
 func (*Collection) ProtoMessage() {}
 
 func (x *Collection) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[16]
+	mi := &file_define_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1485,7 +1723,7 @@ func (x *Collection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Collection.ProtoReflect.Descriptor instead.
 func (*Collection) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{16}
+	return file_define_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Collection) GetTypeId() int32 {
@@ -1545,7 +1783,7 @@ type Chapter struct {
 func (x *Chapter) Reset() {
 	*x = Chapter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[17]
+		mi := &file_define_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1558,7 +1796,7 @@ func (x *Chapter) String() string {
 func (*Chapter) ProtoMessage() {}
 
 func (x *Chapter) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[17]
+	mi := &file_define_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1571,7 +1809,7 @@ func (x *Chapter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Chapter.ProtoReflect.Descriptor instead.
 func (*Chapter) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{17}
+	return file_define_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *Chapter) GetId() int32 {
@@ -1610,7 +1848,7 @@ type Stage struct {
 func (x *Stage) Reset() {
 	*x = Stage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[18]
+		mi := &file_define_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1623,7 +1861,7 @@ func (x *Stage) String() string {
 func (*Stage) ProtoMessage() {}
 
 func (x *Stage) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[18]
+	mi := &file_define_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1636,7 +1874,7 @@ func (x *Stage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Stage.ProtoReflect.Descriptor instead.
 func (*Stage) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{18}
+	return file_define_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *Stage) GetId() int32 {
@@ -1689,7 +1927,7 @@ type Tower struct {
 func (x *Tower) Reset() {
 	*x = Tower{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[19]
+		mi := &file_define_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1702,7 +1940,7 @@ func (x *Tower) String() string {
 func (*Tower) ProtoMessage() {}
 
 func (x *Tower) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[19]
+	mi := &file_define_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1715,7 +1953,7 @@ func (x *Tower) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tower.ProtoReflect.Descriptor instead.
 func (*Tower) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{19}
+	return file_define_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Tower) GetType() int32 {
@@ -1732,40 +1970,37 @@ func (x *Tower) GetFloor() int32 {
 	return 0
 }
 
-// 邮件上下文
-type MailContext struct {
+////////////////////////////////////////////////
+// 竞技场
+type ArenaOpponent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id         int64      `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`                               // 邮件id
-	SenderId   int64      `protobuf:"varint,2,opt,name=SenderId,proto3" json:"SenderId,omitempty"`                   // 发件人id
-	Status     MailStatus `protobuf:"varint,3,opt,name=Status,proto3,enum=proto.MailStatus" json:"Status,omitempty"` // 邮件状态
-	Type       MailType   `protobuf:"varint,4,opt,name=Type,proto3,enum=proto.MailType" json:"Type,omitempty"`       // 邮件类型
-	Date       int32      `protobuf:"varint,5,opt,name=Date,proto3" json:"Date,omitempty"`                           // 寄件日期
-	ExpireDate int32      `protobuf:"varint,6,opt,name=ExpireDate,proto3" json:"ExpireDate,omitempty"`               // 过期日期
-	SenderName string     `protobuf:"bytes,7,opt,name=SenderName,proto3" json:"SenderName,omitempty"`                // 寄件人姓名
-	Title      string     `protobuf:"bytes,8,opt,name=Title,proto3" json:"Title,omitempty"`                          // 邮件标题
-	Content    string     `protobuf:"bytes,9,opt,name=Content,proto3" json:"Content,omitempty"`                      // 邮件内容
+	PlayerId    int64   `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`              // 对手玩家id
+	Name        string  `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`                       // 对手名字
+	Level       int32   `protobuf:"varint,3,opt,name=Level,proto3" json:"Level,omitempty"`                    // 对手等级
+	Score       float64 `protobuf:"fixed64,4,opt,name=Score,proto3" json:"Score,omitempty"`                   // 对手积分
+	HeroTypeIds []int32 `protobuf:"varint,5,rep,packed,name=HeroTypeIds,proto3" json:"HeroTypeIds,omitempty"` // 防守阵容英雄type_id
 }
 
-func (x *MailContext) Reset() {
-	*x = MailContext{}
+func (x *ArenaOpponent) Reset() {
+	*x = ArenaOpponent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[20]
+		mi := &file_define_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MailContext) String() string {
+func (x *ArenaOpponent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MailContext) ProtoMessage() {}
+func (*ArenaOpponent) ProtoMessage() {}
 
-func (x *MailContext) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[20]
+func (x *ArenaOpponent) ProtoReflect() protoreflect.Message {
+	mi := &file_define_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1776,62 +2011,242 @@ func (x *MailContext) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MailContext.ProtoReflect.Descriptor instead.
-func (*MailContext) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{20}
-}
-
-func (x *MailContext) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
+// Deprecated: Use ArenaOpponent.ProtoReflect.Descriptor instead.
+func (*ArenaOpponent) Descriptor() ([]byte, []int) {
+	return file_define_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *MailContext) GetSenderId() int64 {
+func (x *ArenaOpponent) GetPlayerId() int64 {
 	if x != nil {
-		return x.SenderId
+		return x.PlayerId
 	}
 	return 0
 }
 
-func (x *MailContext) GetStatus() MailStatus {
+func (x *ArenaOpponent) GetName() string {
 	if x != nil {
-		return x.Status
+		return x.Name
 	}
-	return MailStatus_Unread
+	return ""
 }
 
-func (x *MailContext) GetType() MailType {
+func (x *ArenaOpponent) GetLevel() int32 {
 	if x != nil {
-		return x.Type
+		return x.Level
 	}
-	return MailType_System
+	return 0
 }
 
-func (x *MailContext) GetDate() int32 {
+func (x *ArenaOpponent) GetScore() float64 {
 	if x != nil {
-		return x.Date
+		return x.Score
 	}
 	return 0
 }
 
-func (x *MailContext) GetExpireDate() int32 {
+func (x *ArenaOpponent) GetHeroTypeIds() []int32 {
 	if x != nil {
-		return x.ExpireDate
+		return x.HeroTypeIds
 	}
-	return 0
+	return nil
 }
 
-func (x *MailContext) GetSenderName() string {
-	if x != nil {
-		return x.SenderName
-	}
-	return ""
+// 竞技场防守记录
+type ArenaLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          int64   `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	AttackId    int64   `protobuf:"varint,2,opt,name=AttackId,proto3" json:"AttackId,omitempty"`        // 进攻方玩家id
+	AttackName  string  `protobuf:"bytes,3,opt,name=AttackName,proto3" json:"AttackName,omitempty"`     // 进攻方名字
+	Win         bool    `protobuf:"varint,4,opt,name=Win,proto3" json:"Win,omitempty"`                  // 是否防守成功
+	ScoreChange float64 `protobuf:"fixed64,5,opt,name=ScoreChange,proto3" json:"ScoreChange,omitempty"` // 防守方积分变化
+	RecordId    int64   `protobuf:"varint,6,opt,name=RecordId,proto3" json:"RecordId,omitempty"`        // 战斗录像id
+	Time        int64   `protobuf:"varint,7,opt,name=Time,proto3" json:"Time,omitempty"`                // 战斗时间
 }
 
-func (x *MailContext) GetTitle() string {
-	if x != nil {
+func (x *ArenaLog) Reset() {
+	*x = ArenaLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_define_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArenaLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArenaLog) ProtoMessage() {}
+
+func (x *ArenaLog) ProtoReflect() protoreflect.Message {
+	mi := &file_define_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArenaLog.ProtoReflect.Descriptor instead.
+func (*ArenaLog) Descriptor() ([]byte, []int) {
+	return file_define_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ArenaLog) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ArenaLog) GetAttackId() int64 {
+	if x != nil {
+		return x.AttackId
+	}
+	return 0
+}
+
+func (x *ArenaLog) GetAttackName() string {
+	if x != nil {
+		return x.AttackName
+	}
+	return ""
+}
+
+func (x *ArenaLog) GetWin() bool {
+	if x != nil {
+		return x.Win
+	}
+	return false
+}
+
+func (x *ArenaLog) GetScoreChange() float64 {
+	if x != nil {
+		return x.ScoreChange
+	}
+	return 0
+}
+
+func (x *ArenaLog) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+func (x *ArenaLog) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+// 邮件上下文
+type MailContext struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         int64      `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`                               // 邮件id
+	SenderId   int64      `protobuf:"varint,2,opt,name=SenderId,proto3" json:"SenderId,omitempty"`                   // 发件人id
+	Status     MailStatus `protobuf:"varint,3,opt,name=Status,proto3,enum=proto.MailStatus" json:"Status,omitempty"` // 邮件状态
+	Type       MailType   `protobuf:"varint,4,opt,name=Type,proto3,enum=proto.MailType" json:"Type,omitempty"`       // 邮件类型
+	Date       int32      `protobuf:"varint,5,opt,name=Date,proto3" json:"Date,omitempty"`                           // 寄件日期
+	ExpireDate int32      `protobuf:"varint,6,opt,name=ExpireDate,proto3" json:"ExpireDate,omitempty"`               // 过期日期
+	SenderName string     `protobuf:"bytes,7,opt,name=SenderName,proto3" json:"SenderName,omitempty"`                // 寄件人姓名
+	Title      string     `protobuf:"bytes,8,opt,name=Title,proto3" json:"Title,omitempty"`                          // 邮件标题
+	Content    string     `protobuf:"bytes,9,opt,name=Content,proto3" json:"Content,omitempty"`                      // 邮件内容
+}
+
+func (x *MailContext) Reset() {
+	*x = MailContext{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_define_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MailContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailContext) ProtoMessage() {}
+
+func (x *MailContext) ProtoReflect() protoreflect.Message {
+	mi := &file_define_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailContext.ProtoReflect.Descriptor instead.
+func (*MailContext) Descriptor() ([]byte, []int) {
+	return file_define_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MailContext) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MailContext) GetSenderId() int64 {
+	if x != nil {
+		return x.SenderId
+	}
+	return 0
+}
+
+func (x *MailContext) GetStatus() MailStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MailStatus_Unread
+}
+
+func (x *MailContext) GetType() MailType {
+	if x != nil {
+		return x.Type
+	}
+	return MailType_System
+}
+
+func (x *MailContext) GetDate() int32 {
+	if x != nil {
+		return x.Date
+	}
+	return 0
+}
+
+func (x *MailContext) GetExpireDate() int32 {
+	if x != nil {
+		return x.ExpireDate
+	}
+	return 0
+}
+
+func (x *MailContext) GetSenderName() string {
+	if x != nil {
+		return x.SenderName
+	}
+	return ""
+}
+
+func (x *MailContext) GetTitle() string {
+	if x != nil {
 		return x.Title
 	}
 	return ""
@@ -1857,7 +2272,7 @@ type Mail struct {
 func (x *Mail) Reset() {
 	*x = Mail{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[21]
+		mi := &file_define_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1870,7 +2285,7 @@ func (x *Mail) String() string {
 func (*Mail) ProtoMessage() {}
 
 func (x *Mail) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[21]
+	mi := &file_define_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1883,7 +2298,7 @@ func (x *Mail) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Mail.ProtoReflect.Descriptor instead.
 func (*Mail) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{21}
+	return file_define_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *Mail) GetContext() *MailContext {
@@ -1914,7 +2329,7 @@ type QuestObj struct {
 func (x *QuestObj) Reset() {
 	*x = QuestObj{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[22]
+		mi := &file_define_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1927,7 +2342,7 @@ func (x *QuestObj) String() string {
 func (*QuestObj) ProtoMessage() {}
 
 func (x *QuestObj) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[22]
+	mi := &file_define_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1940,7 +2355,7 @@ func (x *QuestObj) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QuestObj.ProtoReflect.Descriptor instead.
 func (*QuestObj) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{22}
+	return file_define_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *QuestObj) GetType() int32 {
@@ -1978,7 +2393,7 @@ type Quest struct {
 func (x *Quest) Reset() {
 	*x = Quest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[23]
+		mi := &file_define_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1991,7 +2406,7 @@ func (x *Quest) String() string {
 func (*Quest) ProtoMessage() {}
 
 func (x *Quest) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[23]
+	mi := &file_define_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2004,7 +2419,7 @@ func (x *Quest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Quest.ProtoReflect.Descriptor instead.
 func (*Quest) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{23}
+	return file_define_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *Quest) GetId() int32 {
@@ -2035,16 +2450,17 @@ type RankMetadata struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ObjId   int64   `protobuf:"varint,1,opt,name=ObjId,proto3" json:"ObjId,omitempty"`    // 排行榜对象id
-	ObjName string  `protobuf:"bytes,2,opt,name=ObjName,proto3" json:"ObjName,omitempty"` // 名字
-	Score   float64 `protobuf:"fixed64,3,opt,name=Score,proto3" json:"Score,omitempty"`   // 排行榜分数
-	Date    int64   `protobuf:"varint,4,opt,name=Date,proto3" json:"Date,omitempty"`      // 分数更新时间
+	ObjId    int64   `protobuf:"varint,1,opt,name=ObjId,proto3" json:"ObjId,omitempty"`       // 排行榜对象id
+	ObjName  string  `protobuf:"bytes,2,opt,name=ObjName,proto3" json:"ObjName,omitempty"`    // 名字
+	Score    float64 `protobuf:"fixed64,3,opt,name=Score,proto3" json:"Score,omitempty"`      // 排行榜分数
+	Date     int64   `protobuf:"varint,4,opt,name=Date,proto3" json:"Date,omitempty"`         // 分数更新时间
+	RecordId int64   `protobuf:"varint,5,opt,name=RecordId,proto3" json:"RecordId,omitempty"` // 战斗录像id
 }
 
 func (x *RankMetadata) Reset() {
 	*x = RankMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[24]
+		mi := &file_define_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2057,7 +2473,7 @@ func (x *RankMetadata) String() string {
 func (*RankMetadata) ProtoMessage() {}
 
 func (x *RankMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[24]
+	mi := &file_define_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2070,7 +2486,7 @@ func (x *RankMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RankMetadata.ProtoReflect.Descriptor instead.
 func (*RankMetadata) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{24}
+	return file_define_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *RankMetadata) GetObjId() int64 {
@@ -2101,6 +2517,13 @@ func (x *RankMetadata) GetDate() int64 {
 	return 0
 }
 
+func (x *RankMetadata) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
 type PublisherMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2118,7 +2541,7 @@ type PublisherMetadata struct {
 func (x *PublisherMetadata) Reset() {
 	*x = PublisherMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[25]
+		mi := &file_define_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2131,7 +2554,7 @@ func (x *PublisherMetadata) String() string {
 func (*PublisherMetadata) ProtoMessage() {}
 
 func (x *PublisherMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[25]
+	mi := &file_define_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2144,7 +2567,7 @@ func (x *PublisherMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublisherMetadata.ProtoReflect.Descriptor instead.
 func (*PublisherMetadata) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{25}
+	return file_define_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *PublisherMetadata) GetPublisherId() int64 {
@@ -2203,12 +2626,13 @@ type ReplyerMetadata struct {
 
 	CommentId         int64              `protobuf:"varint,1,opt,name=CommentId,proto3" json:"CommentId,omitempty"`                // 评论唯一id
 	PublisherMetadata *PublisherMetadata `protobuf:"bytes,2,opt,name=PublisherMetadata,proto3" json:"PublisherMetadata,omitempty"` // 发表元数据
+	Status            int32              `protobuf:"varint,3,opt,name=Status,proto3" json:"Status,omitempty"`                      // 回复状态
 }
 
 func (x *ReplyerMetadata) Reset() {
 	*x = ReplyerMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[26]
+		mi := &file_define_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2221,7 +2645,7 @@ func (x *ReplyerMetadata) String() string {
 func (*ReplyerMetadata) ProtoMessage() {}
 
 func (x *ReplyerMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[26]
+	mi := &file_define_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2234,7 +2658,7 @@ func (x *ReplyerMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReplyerMetadata.ProtoReflect.Descriptor instead.
 func (*ReplyerMetadata) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{26}
+	return file_define_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ReplyerMetadata) GetCommentId() int64 {
@@ -2251,6 +2675,13 @@ func (x *ReplyerMetadata) GetPublisherMetadata() *PublisherMetadata {
 	return nil
 }
 
+func (x *ReplyerMetadata) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
 type CommentTopic struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2263,7 +2694,7 @@ type CommentTopic struct {
 func (x *CommentTopic) Reset() {
 	*x = CommentTopic{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[27]
+		mi := &file_define_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2276,7 +2707,7 @@ func (x *CommentTopic) String() string {
 func (*CommentTopic) ProtoMessage() {}
 
 func (x *CommentTopic) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[27]
+	mi := &file_define_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2289,7 +2720,7 @@ func (x *CommentTopic) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommentTopic.ProtoReflect.Descriptor instead.
 func (*CommentTopic) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{27}
+	return file_define_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *CommentTopic) GetTopicType() int32 {
@@ -2315,12 +2746,16 @@ type CommentMetadata struct {
 	Topic             *CommentTopic      `protobuf:"bytes,2,opt,name=Topic,proto3" json:"Topic,omitempty"`                         // 评论话题
 	PublisherMetadata *PublisherMetadata `protobuf:"bytes,3,opt,name=PublisherMetadata,proto3" json:"PublisherMetadata,omitempty"` // 发表者元数据
 	ReplyMetadatas    []*ReplyerMetadata `protobuf:"bytes,4,rep,name=ReplyMetadatas,proto3" json:"ReplyMetadatas,omitempty"`       // 此条评论回复列表
+	Status            int32              `protobuf:"varint,5,opt,name=Status,proto3" json:"Status,omitempty"`                      // 评论状态
+	Liked             bool               `protobuf:"varint,6,opt,name=Liked,proto3" json:"Liked,omitempty"`                        // 查询者是否点过赞
+	SpikeFlagTime     int32              `protobuf:"varint,7,opt,name=SpikeFlagTime,proto3" json:"SpikeFlagTime,omitempty"`        // 点赞数异常增长标记时间, 仅管理员可见
+	SpikeThumbs       int32              `protobuf:"varint,8,opt,name=SpikeThumbs,proto3" json:"SpikeThumbs,omitempty"`            // 标记时统计时间内的点赞数, 仅管理员可见
 }
 
 func (x *CommentMetadata) Reset() {
 	*x = CommentMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_define_proto_msgTypes[28]
+		mi := &file_define_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2333,7 +2768,7 @@ func (x *CommentMetadata) String() string {
 func (*CommentMetadata) ProtoMessage() {}
 
 func (x *CommentMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_define_proto_msgTypes[28]
+	mi := &file_define_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2346,7 +2781,7 @@ func (x *CommentMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommentMetadata.ProtoReflect.Descriptor instead.
 func (*CommentMetadata) Descriptor() ([]byte, []int) {
-	return file_define_proto_rawDescGZIP(), []int{28}
+	return file_define_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *CommentMetadata) GetCommentId() int64 {
@@ -2377,6 +2812,200 @@ func (x *CommentMetadata) GetReplyMetadatas() []*ReplyerMetadata {
 	return nil
 }
 
+func (x *CommentMetadata) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *CommentMetadata) GetLiked() bool {
+	if x != nil {
+		return x.Liked
+	}
+	return false
+}
+
+func (x *CommentMetadata) GetSpikeFlagTime() int32 {
+	if x != nil {
+		return x.SpikeFlagTime
+	}
+	return 0
+}
+
+func (x *CommentMetadata) GetSpikeThumbs() int32 {
+	if x != nil {
+		return x.SpikeThumbs
+	}
+	return 0
+}
+
+type ChatChannel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type   int32 `protobuf:"varint,1,opt,name=Type,proto3" json:"Type,omitempty"`     // 频道类型
+	TypeId int64 `protobuf:"varint,2,opt,name=TypeId,proto3" json:"TypeId,omitempty"` // 本服世界频道为game节点id, 私聊为接收者玩家id, 公会频道为公会id
+}
+
+func (x *ChatChannel) Reset() {
+	*x = ChatChannel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_define_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChatChannel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatChannel) ProtoMessage() {}
+
+func (x *ChatChannel) ProtoReflect() protoreflect.Message {
+	mi := &file_define_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatChannel.ProtoReflect.Descriptor instead.
+func (*ChatChannel) Descriptor() ([]byte, []int) {
+	return file_define_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ChatChannel) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *ChatChannel) GetTypeId() int64 {
+	if x != nil {
+		return x.TypeId
+	}
+	return 0
+}
+
+type ChatMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          int64        `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`                   // 消息唯一id
+	Channel     *ChatChannel `protobuf:"bytes,2,opt,name=Channel,proto3" json:"Channel,omitempty"`          // 所属频道
+	Seq         uint64       `protobuf:"varint,3,opt,name=Seq,proto3" json:"Seq,omitempty"`                 // 频道内消息序号
+	SenderId    int64        `protobuf:"varint,4,opt,name=SenderId,proto3" json:"SenderId,omitempty"`       // 发送者玩家id, 系统消息为0
+	SenderName  string       `protobuf:"bytes,5,opt,name=SenderName,proto3" json:"SenderName,omitempty"`    // 发送者名字
+	SenderLevel int32        `protobuf:"varint,6,opt,name=SenderLevel,proto3" json:"SenderLevel,omitempty"` // 发送者等级
+	ReceiverId  int64        `protobuf:"varint,7,opt,name=ReceiverId,proto3" json:"ReceiverId,omitempty"`   // 私聊接收者玩家id
+	Content     string       `protobuf:"bytes,8,opt,name=Content,proto3" json:"Content,omitempty"`          // 内容
+	Time        int32        `protobuf:"varint,9,opt,name=Time,proto3" json:"Time,omitempty"`               // 发送时间
+}
+
+func (x *ChatMessage) Reset() {
+	*x = ChatMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_define_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessage) ProtoMessage() {}
+
+func (x *ChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_define_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
+func (*ChatMessage) Descriptor() ([]byte, []int) {
+	return file_define_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ChatMessage) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetChannel() *ChatChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetSenderId() int64 {
+	if x != nil {
+		return x.SenderId
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetSenderName() string {
+	if x != nil {
+		return x.SenderName
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetSenderLevel() int32 {
+	if x != nil {
+		return x.SenderLevel
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetReceiverId() int64 {
+	if x != nil {
+		return x.ReceiverId
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetTime() int32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
 var File_define_proto protoreflect.FileDescriptor
 
 var file_define_proto_rawDesc = []byte{
@@ -2479,7 +3108,7 @@ var file_define_proto_rawDesc = []byte{
 	0x72, 0x79, 0x73, 0x74, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x79, 0x73, 0x74, 0x61, 0x6c,
 	0x44, 0x61, 0x74, 0x61, 0x52, 0x0b, 0x43, 0x72, 0x79, 0x73, 0x74, 0x61, 0x6c, 0x44, 0x61, 0x74,
-	0x61, 0x22, 0xc8, 0x01, 0x0a, 0x0a, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
+	0x61, 0x22, 0xe4, 0x01, 0x0a, 0x0a, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
 	0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x64,
 	0x12, 0x1c, 0x0a, 0x09, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20,
 	0x01, 0x28, 0x03, 0x52, 0x09, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12,
@@ -2489,9 +3118,21 @@ var file_define_proto_rawDesc = []byte{
 	0x01, 0x28, 0x05, 0x52, 0x05, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x2e, 0x0a, 0x12, 0x42, 0x75,
 	0x79, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x73,
 	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x42, 0x75, 0x79, 0x53, 0x74, 0x72, 0x65, 0x6e,
-	0x67, 0x74, 0x68, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x42, 0x61,
-	0x74, 0x74, 0x6c, 0x65, 0x41, 0x72, 0x72, 0x61, 0x79, 0x18, 0x07, 0x20, 0x03, 0x28, 0x03, 0x52,
-	0x0b, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x41, 0x72, 0x72, 0x61, 0x79, 0x22, 0x94, 0x01, 0x0a,
+	0x67, 0x74, 0x68, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x0c, 0x42, 0x61,
+	0x74, 0x74, 0x6c, 0x65, 0x41, 0x72, 0x72, 0x61, 0x79, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x41,
+	0x72, 0x72, 0x61, 0x79, 0x52, 0x0c, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x41, 0x72, 0x72, 0x61,
+	0x79, 0x73, 0x4a, 0x04, 0x08, 0x07, 0x10, 0x08, 0x22, 0x3b, 0x0a, 0x0f, 0x42, 0x61, 0x74, 0x74,
+	0x6c, 0x65, 0x41, 0x72, 0x72, 0x61, 0x79, 0x48, 0x65, 0x72, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x48,
+	0x65, 0x72, 0x6f, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x48, 0x65, 0x72,
+	0x6f, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x50, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x50, 0x6f, 0x73, 0x22, 0x65, 0x0a, 0x0b, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x41,
+	0x72, 0x72, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x06,
+	0x48, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x41, 0x72, 0x72, 0x61, 0x79,
+	0x48, 0x65, 0x72, 0x6f, 0x52, 0x06, 0x48, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x22, 0x94, 0x01, 0x0a,
 	0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x54,
 	0x79, 0x70, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x54, 0x79, 0x70,
 	0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20,
@@ -2518,149 +3159,223 @@ var file_define_proto_rawDesc = []byte{
 	0x65, 0x73, 0x22, 0x31, 0x0a, 0x05, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x54,
 	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12,
 	0x14, 0x0a, 0x05, 0x46, 0x6c, 0x6f, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
-	0x46, 0x6c, 0x6f, 0x6f, 0x72, 0x22, 0x8d, 0x02, 0x0a, 0x0b, 0x4d, 0x61, 0x69, 0x6c, 0x43, 0x6f,
-	0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x02, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49,
-	0x64, 0x12, 0x29, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x04,
-	0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x04, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x44,
-	0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x45, 0x78, 0x70, 0x69, 0x72,
-	0x65, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4e,
-	0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65,
-	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f,
-	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x67, 0x0a, 0x04, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x2c, 0x0a,
-	0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x43, 0x6f, 0x6e, 0x74, 0x65,
-	0x78, 0x74, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x31, 0x0a, 0x0b, 0x41,
-	0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x6f, 0x74, 0x44, 0x61, 0x74,
-	0x61, 0x52, 0x0b, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x52,
-	0x0a, 0x08, 0x51, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79,
-	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
-	0x65, 0x64, 0x22, 0x52, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x53, 0x74, 0x61, 0x74,
-	0x65, 0x12, 0x23, 0x0a, 0x04, 0x4f, 0x62, 0x6a, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x62, 0x6a,
-	0x52, 0x04, 0x4f, 0x62, 0x6a, 0x73, 0x22, 0x68, 0x0a, 0x0c, 0x52, 0x61, 0x6e, 0x6b, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x4f, 0x62, 0x6a, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x4f, 0x62, 0x6a, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
-	0x4f, 0x62, 0x6a, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4f,
-	0x62, 0x6a, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x44, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x44, 0x61, 0x74, 0x65,
-	0x22, 0xe1, 0x01, 0x0a, 0x11, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73,
-	0x68, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x50, 0x75, 0x62,
-	0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x50, 0x75, 0x62, 0x6c,
-	0x69, 0x73, 0x68, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0d, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c,
-	0x0a, 0x09, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x09, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x68, 0x75, 0x6d,
-	0x62, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73,
-	0x12, 0x12, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
-	0x44, 0x61, 0x74, 0x65, 0x22, 0x77, 0x0a, 0x0f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x65, 0x72, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x6f, 0x6d, 0x6d,
-	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x46, 0x0a, 0x11, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
-	0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
-	0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x11, 0x50, 0x75, 0x62, 0x6c,
-	0x69, 0x73, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x4e, 0x0a,
-	0x0c, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c, 0x0a,
-	0x09, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x09, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x54,
-	0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0b, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x22, 0xe2, 0x01,
-	0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
-	0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f,
-	0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x46, 0x0a, 0x11, 0x50, 0x75,
-	0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75,
-	0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x46, 0x6c, 0x6f, 0x6f, 0x72, 0x22, 0x8d, 0x01, 0x0a, 0x0d, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x4f,
+	0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a,
+	0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x48, 0x65, 0x72, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x49,
+	0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x48, 0x65, 0x72, 0x6f, 0x54, 0x79,
+	0x70, 0x65, 0x49, 0x64, 0x73, 0x22, 0xba, 0x01, 0x0a, 0x08, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x4c,
+	0x6f, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x1e,
+	0x0a, 0x0a, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x57, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x57, 0x69, 0x6e,
+	0x12, 0x20, 0x0a, 0x0b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69,
+	0x6d, 0x65, 0x22, 0x8d, 0x02, 0x0a, 0x0b, 0x4d, 0x61, 0x69, 0x6c, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x29,
+	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x04, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x4d, 0x61, 0x69, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x44, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x44, 0x61,
+	0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x44, 0x61, 0x74, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x44, 0x61,
+	0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x22, 0x67, 0x0a, 0x04, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x2c, 0x0a, 0x07, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52,
+	0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x31, 0x0a, 0x0b, 0x41, 0x74, 0x74, 0x61,
+	0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x6f, 0x74, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0b,
+	0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x52, 0x0a, 0x08, 0x51,
+	0x75, 0x65, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22,
+	0x52, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x23,
+	0x0a, 0x04, 0x4f, 0x62, 0x6a, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x52, 0x04, 0x4f,
+	0x62, 0x6a, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x0c, 0x52, 0x61, 0x6e, 0x6b, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x4f, 0x62, 0x6a, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x4f, 0x62, 0x6a, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x4f, 0x62,
+	0x6a, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4f, 0x62, 0x6a,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x61,
+	0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x22, 0xe1, 0x01, 0x0a, 0x11, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x20, 0x0a, 0x0b, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x54, 0x6f, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x54, 0x6f, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x54,
+	0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x54, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x61,
+	0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x44, 0x61, 0x74, 0x65, 0x22, 0x8f,
+	0x01, 0x0a, 0x0f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x46, 0x0a, 0x11, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x11, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x22, 0x4e, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63,
+	0x12, 0x1c, 0x0a, 0x09, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12, 0x20,
+	0x0a, 0x0b, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64,
+	0x22, 0xd8, 0x02, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x46, 0x0a,
 	0x11, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x12, 0x3e, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x52, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x73, 0x2a, 0xf0, 0x04, 0x0a, 0x09, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x10,
-	0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x47, 0x6f, 0x6c, 0x64, 0x10,
-	0x00, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x44, 0x69, 0x61, 0x6d, 0x6f,
-	0x6e, 0x64, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x4d, 0x75,
-	0x73, 0x69, 0x63, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x46,
-	0x72, 0x69, 0x65, 0x6e, 0x64, 0x73, 0x68, 0x69, 0x70, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x4d, 0x61, 0x7a, 0x65, 0x10, 0x04, 0x12, 0x0f, 0x0a, 0x0b, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x10, 0x05, 0x12, 0x14, 0x0a, 0x10,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x45, 0x78, 0x70, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x10, 0x06, 0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x48, 0x6f, 0x6d, 0x65,
-	0x10, 0x07, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x41, 0x63, 0x74, 0x69,
-	0x76, 0x69, 0x74, 0x79, 0x51, 0x75, 0x65, 0x73, 0x74, 0x10, 0x08, 0x12, 0x1b, 0x0a, 0x17, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x47, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x75,
-	0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x09, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x6f, 0x6b, 0x65,
-	0x6e, 0x5f, 0x43, 0x72, 0x79, 0x73, 0x74, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x10, 0x0a, 0x12, 0x1c,
+	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x52, 0x11, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3e, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x4c, 0x69,
+	0x6b, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x70, 0x69, 0x6b, 0x65, 0x46, 0x6c, 0x61, 0x67,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x53, 0x70, 0x69, 0x6b,
+	0x65, 0x46, 0x6c, 0x61, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x53, 0x70, 0x69,
+	0x6b, 0x65, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x53, 0x70, 0x69, 0x6b, 0x65, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73, 0x22, 0x39, 0x0a, 0x0b, 0x43,
+	0x68, 0x61, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x22, 0x89, 0x02, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x07, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x43, 0x68, 0x61, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x07, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x53, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
+	0x49, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x54, 0x69,
+	0x6d, 0x65, 0x2a, 0x92, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x10, 0x00, 0x12, 0x18, 0x0a,
+	0x14, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x4e,
+	0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x5f, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x45, 0x6e,
+	0x64, 0x10, 0x03, 0x1a, 0x02, 0x10, 0x01, 0x2a, 0xbc, 0x01, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x74,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x43,
+	0x68, 0x61, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x42, 0x65, 0x67, 0x69, 0x6e,
+	0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x5f, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x43, 0x68, 0x61,
+	0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x57, 0x6f,
+	0x72, 0x6c, 0x64, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x68, 0x61, 0x74, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x10, 0x02, 0x12, 0x16,
+	0x0a, 0x12, 0x43, 0x68, 0x61, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x74, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x47, 0x75, 0x69, 0x6c, 0x64, 0x10, 0x04, 0x12, 0x13, 0x0a,
+	0x0f, 0x43, 0x68, 0x61, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x45, 0x6e, 0x64,
+	0x10, 0x05, 0x1a, 0x02, 0x10, 0x01, 0x2a, 0xf0, 0x04, 0x0a, 0x09, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x42, 0x65,
+	0x67, 0x69, 0x6e, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x47,
+	0x6f, 0x6c, 0x64, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x44,
+	0x69, 0x61, 0x6d, 0x6f, 0x6e, 0x64, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x5f, 0x4d, 0x75, 0x73, 0x69, 0x63, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x5f, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x73, 0x68, 0x69, 0x70, 0x10, 0x03, 0x12,
+	0x0e, 0x0a, 0x0a, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x4d, 0x61, 0x7a, 0x65, 0x10, 0x04, 0x12,
+	0x0f, 0x0a, 0x0b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x10, 0x05,
+	0x12, 0x14, 0x0a, 0x10, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x45, 0x78, 0x70, 0x65, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x10, 0x06, 0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f,
+	0x48, 0x6f, 0x6d, 0x65, 0x10, 0x07, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x51, 0x75, 0x65, 0x73, 0x74, 0x10, 0x08, 0x12,
+	0x1b, 0x0a, 0x17, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x47, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x75, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x09, 0x12, 0x14, 0x0a, 0x10,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x43, 0x72, 0x79, 0x73, 0x74, 0x61, 0x6c, 0x45, 0x78, 0x70,
+	0x10, 0x0a, 0x12, 0x1c, 0x0a, 0x18, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x45, 0x78, 0x70, 0x6c,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x10, 0x0b,
+	0x12, 0x1c, 0x0a, 0x18, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72,
+	0x65, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x10, 0x0c, 0x12, 0x1c,
 	0x0a, 0x18, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x52,
-	0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x31, 0x10, 0x0b, 0x12, 0x1c, 0x0a, 0x18,
+	0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x33, 0x10, 0x0d, 0x12, 0x1c, 0x0a, 0x18,
 	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x70,
-	0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x10, 0x0c, 0x12, 0x1c, 0x0a, 0x18, 0x54, 0x6f,
+	0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x34, 0x10, 0x0e, 0x12, 0x1c, 0x0a, 0x18, 0x54, 0x6f,
 	0x6b, 0x65, 0x6e, 0x5f, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x70, 0x75, 0x74,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x33, 0x10, 0x0d, 0x12, 0x1c, 0x0a, 0x18, 0x54, 0x6f, 0x6b, 0x65,
-	0x6e, 0x5f, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x34, 0x10, 0x0e, 0x12, 0x1c, 0x0a, 0x18, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f,
-	0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x35, 0x10, 0x0f, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x53, 0x74,
-	0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x10, 0x10, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x6f, 0x6b, 0x65,
-	0x6e, 0x5f, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x10,
-	0x11, 0x12, 0x19, 0x0a, 0x15, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x10, 0x12, 0x12, 0x19, 0x0a, 0x15,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x47, 0x72, 0x65, 0x65, 0x6e, 0x10, 0x12, 0x12, 0x18, 0x0a, 0x14, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x5f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x6c, 0x75, 0x65, 0x10,
-	0x13, 0x12, 0x1a, 0x0a, 0x16, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x70, 0x6c, 0x65, 0x10, 0x14, 0x12, 0x1a, 0x0a,
-	0x16, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x59, 0x65, 0x6c, 0x6c, 0x6f, 0x77, 0x10, 0x15, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x5f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x64,
-	0x10, 0x16, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x45, 0x6e, 0x64, 0x10,
-	0x16, 0x1a, 0x02, 0x10, 0x01, 0x2a, 0x5a, 0x0a, 0x08, 0x4c, 0x6f, 0x6f, 0x74, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x6f, 0x6f, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x10, 0x00, 0x12,
-	0x0d, 0x0a, 0x09, 0x4c, 0x6f, 0x6f, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x10, 0x01, 0x12, 0x0c,
-	0x0a, 0x08, 0x4c, 0x6f, 0x6f, 0x74, 0x48, 0x65, 0x72, 0x6f, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d,
-	0x4c, 0x6f, 0x6f, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x45, 0x78, 0x70, 0x10, 0x03, 0x12,
-	0x10, 0x0a, 0x0c, 0x4c, 0x6f, 0x6f, 0x74, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x10,
-	0x04, 0x2a, 0x3b, 0x0a, 0x0a, 0x4d, 0x61, 0x69, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x0a, 0x0a, 0x06, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x52,
-	0x65, 0x61, 0x64, 0x65, 0x64, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x47, 0x61, 0x69, 0x6e, 0x65,
-	0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x10, 0x02, 0x2a, 0x22,
-	0x0a, 0x08, 0x4d, 0x61, 0x69, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x79,
-	0x73, 0x74, 0x65, 0x6d, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x10, 0x01, 0x2a, 0x5f, 0x0a, 0x09, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x13, 0x0a, 0x0f, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x5f, 0x42, 0x65, 0x67,
-	0x69, 0x6e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70,
-	0x65, 0x5f, 0x48, 0x65, 0x72, 0x6f, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x6f, 0x70, 0x69,
-	0x63, 0x54, 0x79, 0x70, 0x65, 0x5f, 0x49, 0x74, 0x65, 0x6d, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d,
-	0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x5f, 0x45, 0x6e, 0x64, 0x10, 0x02, 0x1a,
-	0x02, 0x10, 0x01, 0x42, 0x32, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65, 0x6e, 0x2f, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0xaa,
-	0x02, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x35, 0x10, 0x0f, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x5f, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x10, 0x10, 0x12, 0x17, 0x0a, 0x13,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x10, 0x11, 0x12, 0x19, 0x0a, 0x15, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x10, 0x12,
+	0x12, 0x19, 0x0a, 0x15, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x65, 0x65, 0x6e, 0x10, 0x12, 0x12, 0x18, 0x0a, 0x14, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42,
+	0x6c, 0x75, 0x65, 0x10, 0x13, 0x12, 0x1a, 0x0a, 0x16, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x70, 0x6c, 0x65, 0x10,
+	0x14, 0x12, 0x1a, 0x0a, 0x16, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x59, 0x65, 0x6c, 0x6c, 0x6f, 0x77, 0x10, 0x15, 0x12, 0x17, 0x0a,
+	0x13, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x6e, 0x64, 0x10, 0x16, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x5f,
+	0x45, 0x6e, 0x64, 0x10, 0x16, 0x1a, 0x02, 0x10, 0x01, 0x2a, 0x5a, 0x0a, 0x08, 0x4c, 0x6f, 0x6f,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x6f, 0x6f, 0x74, 0x49, 0x74, 0x65,
+	0x6d, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4c, 0x6f, 0x6f, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x6f, 0x6f, 0x74, 0x48, 0x65, 0x72, 0x6f, 0x10, 0x02,
+	0x12, 0x11, 0x0a, 0x0d, 0x4c, 0x6f, 0x6f, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x45, 0x78,
+	0x70, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x6f, 0x6f, 0x74, 0x46, 0x72, 0x61, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x10, 0x04, 0x2a, 0x3b, 0x0a, 0x0a, 0x4d, 0x61, 0x69, 0x6c, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x10, 0x00, 0x12,
+	0x0a, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x64, 0x65, 0x64, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x47,
+	0x61, 0x69, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x10, 0x02, 0x2a, 0x22, 0x0a, 0x08, 0x4d, 0x61, 0x69, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a,
+	0x0a, 0x06, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x10, 0x01, 0x2a, 0x5f, 0x0a, 0x09, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65,
+	0x5f, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x6f, 0x70, 0x69,
+	0x63, 0x54, 0x79, 0x70, 0x65, 0x5f, 0x48, 0x65, 0x72, 0x6f, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e,
+	0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x5f, 0x49, 0x74, 0x65, 0x6d, 0x10, 0x01,
+	0x12, 0x11, 0x0a, 0x0d, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x5f, 0x45, 0x6e,
+	0x64, 0x10, 0x02, 0x1a, 0x02, 0x10, 0x01, 0x42, 0x32, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65, 0x6e, 0x2f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6c, 0x6f,
+	0x62, 0x61, 0x6c, 0xaa, 0x02, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2675,68 +3390,79 @@ func file_define_proto_rawDescGZIP() []byte {
 	return file_define_proto_rawDescData
 }
 
-var file_define_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_define_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_define_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_define_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_define_proto_goTypes = []interface{}{
-	(TokenType)(0),                  // 0: proto.TokenType
-	(LootType)(0),                   // 1: proto.LootType
-	(MailStatus)(0),                 // 2: proto.MailStatus
-	(MailType)(0),                   // 3: proto.MailType
-	(TopicType)(0),                  // 4: proto.TopicType
-	(*C2S_WaitResponseMessage)(nil), // 5: proto.C2S_WaitResponseMessage
-	(*S2C_WaitResponseMessage)(nil), // 6: proto.S2C_WaitResponseMessage
-	(*S2C_ServerConsole)(nil),       // 7: proto.S2C_ServerConsole
-	(*AccountInfo)(nil),             // 8: proto.AccountInfo
-	(*Token)(nil),                   // 9: proto.Token
-	(*Talent)(nil),                  // 10: proto.Talent
-	(*Hero)(nil),                    // 11: proto.Hero
-	(*Fragment)(nil),                // 12: proto.Fragment
-	(*LootData)(nil),                // 13: proto.LootData
-	(*Item)(nil),                    // 14: proto.Item
-	(*EquipData)(nil),               // 15: proto.EquipData
-	(*Equip)(nil),                   // 16: proto.Equip
-	(*CrystalAtt)(nil),              // 17: proto.CrystalAtt
-	(*CrystalData)(nil),             // 18: proto.CrystalData
-	(*Crystal)(nil),                 // 19: proto.Crystal
-	(*PlayerInfo)(nil),              // 20: proto.PlayerInfo
-	(*Collection)(nil),              // 21: proto.Collection
-	(*Chapter)(nil),                 // 22: proto.Chapter
-	(*Stage)(nil),                   // 23: proto.Stage
-	(*Tower)(nil),                   // 24: proto.Tower
-	(*MailContext)(nil),             // 25: proto.MailContext
-	(*Mail)(nil),                    // 26: proto.Mail
-	(*QuestObj)(nil),                // 27: proto.QuestObj
-	(*Quest)(nil),                   // 28: proto.Quest
-	(*RankMetadata)(nil),            // 29: proto.RankMetadata
-	(*PublisherMetadata)(nil),       // 30: proto.PublisherMetadata
-	(*ReplyerMetadata)(nil),         // 31: proto.ReplyerMetadata
-	(*CommentTopic)(nil),            // 32: proto.CommentTopic
-	(*CommentMetadata)(nil),         // 33: proto.CommentMetadata
+	(CommentStatus)(0),              // 0: proto.CommentStatus
+	(ChatChannelType)(0),            // 1: proto.ChatChannelType
+	(TokenType)(0),                  // 2: proto.TokenType
+	(LootType)(0),                   // 3: proto.LootType
+	(MailStatus)(0),                 // 4: proto.MailStatus
+	(MailType)(0),                   // 5: proto.MailType
+	(TopicType)(0),                  // 6: proto.TopicType
+	(*C2S_WaitResponseMessage)(nil), // 7: proto.C2S_WaitResponseMessage
+	(*S2C_WaitResponseMessage)(nil), // 8: proto.S2C_WaitResponseMessage
+	(*S2C_ServerConsole)(nil),       // 9: proto.S2C_ServerConsole
+	(*AccountInfo)(nil),             // 10: proto.AccountInfo
+	(*Token)(nil),                   // 11: proto.Token
+	(*Talent)(nil),                  // 12: proto.Talent
+	(*Hero)(nil),                    // 13: proto.Hero
+	(*Fragment)(nil),                // 14: proto.Fragment
+	(*LootData)(nil),                // 15: proto.LootData
+	(*Item)(nil),                    // 16: proto.Item
+	(*EquipData)(nil),               // 17: proto.EquipData
+	(*Equip)(nil),                   // 18: proto.Equip
+	(*CrystalAtt)(nil),              // 19: proto.CrystalAtt
+	(*CrystalData)(nil),             // 20: proto.CrystalData
+	(*Crystal)(nil),                 // 21: proto.Crystal
+	(*PlayerInfo)(nil),              // 22: proto.PlayerInfo
+	(*BattleArrayHero)(nil),         // 23: proto.BattleArrayHero
+	(*BattleArray)(nil),             // 24: proto.BattleArray
+	(*Collection)(nil),              // 25: proto.Collection
+	(*Chapter)(nil),                 // 26: proto.Chapter
+	(*Stage)(nil),                   // 27: proto.Stage
+	(*Tower)(nil),                   // 28: proto.Tower
+	(*ArenaOpponent)(nil),           // 29: proto.ArenaOpponent
+	(*ArenaLog)(nil),                // 30: proto.ArenaLog
+	(*MailContext)(nil),             // 31: proto.MailContext
+	(*Mail)(nil),                    // 32: proto.Mail
+	(*QuestObj)(nil),                // 33: proto.QuestObj
+	(*Quest)(nil),                   // 34: proto.Quest
+	(*RankMetadata)(nil),            // 35: proto.RankMetadata
+	(*PublisherMetadata)(nil),       // 36: proto.PublisherMetadata
+	(*ReplyerMetadata)(nil),         // 37: proto.ReplyerMetadata
+	(*CommentTopic)(nil),            // 38: proto.CommentTopic
+	(*CommentMetadata)(nil),         // 39: proto.CommentMetadata
+	(*ChatChannel)(nil),             // 40: proto.ChatChannel
+	(*ChatMessage)(nil),             // 41: proto.ChatMessage
 }
 var file_define_proto_depIdxs = []int32{
-	0,  // 0: proto.Token.Type:type_name -> proto.TokenType
-	10, // 1: proto.Hero.TalentList:type_name -> proto.Talent
-	1,  // 2: proto.LootData.Type:type_name -> proto.LootType
-	14, // 3: proto.Equip.Item:type_name -> proto.Item
-	15, // 4: proto.Equip.EquipData:type_name -> proto.EquipData
-	17, // 5: proto.CrystalData.MainAtt:type_name -> proto.CrystalAtt
-	17, // 6: proto.CrystalData.ViceAtts:type_name -> proto.CrystalAtt
-	14, // 7: proto.Crystal.Item:type_name -> proto.Item
-	18, // 8: proto.Crystal.CrystalData:type_name -> proto.CrystalData
-	2,  // 9: proto.MailContext.Status:type_name -> proto.MailStatus
-	3,  // 10: proto.MailContext.Type:type_name -> proto.MailType
-	25, // 11: proto.Mail.Context:type_name -> proto.MailContext
-	13, // 12: proto.Mail.Attachments:type_name -> proto.LootData
-	27, // 13: proto.Quest.Objs:type_name -> proto.QuestObj
-	30, // 14: proto.ReplyerMetadata.PublisherMetadata:type_name -> proto.PublisherMetadata
-	32, // 15: proto.CommentMetadata.Topic:type_name -> proto.CommentTopic
-	30, // 16: proto.CommentMetadata.PublisherMetadata:type_name -> proto.PublisherMetadata
-	31, // 17: proto.CommentMetadata.ReplyMetadatas:type_name -> proto.ReplyerMetadata
-	18, // [18:18] is the sub-list for method output_type
-	18, // [18:18] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	2,  // 0: proto.Token.Type:type_name -> proto.TokenType
+	12, // 1: proto.Hero.TalentList:type_name -> proto.Talent
+	3,  // 2: proto.LootData.Type:type_name -> proto.LootType
+	16, // 3: proto.Equip.Item:type_name -> proto.Item
+	17, // 4: proto.Equip.EquipData:type_name -> proto.EquipData
+	19, // 5: proto.CrystalData.MainAtt:type_name -> proto.CrystalAtt
+	19, // 6: proto.CrystalData.ViceAtts:type_name -> proto.CrystalAtt
+	16, // 7: proto.Crystal.Item:type_name -> proto.Item
+	20, // 8: proto.Crystal.CrystalData:type_name -> proto.CrystalData
+	24, // 9: proto.PlayerInfo.BattleArrays:type_name -> proto.BattleArray
+	23, // 10: proto.BattleArray.Heroes:type_name -> proto.BattleArrayHero
+	4,  // 11: proto.MailContext.Status:type_name -> proto.MailStatus
+	5,  // 12: proto.MailContext.Type:type_name -> proto.MailType
+	31, // 13: proto.Mail.Context:type_name -> proto.MailContext
+	15, // 14: proto.Mail.Attachments:type_name -> proto.LootData
+	33, // 15: proto.Quest.Objs:type_name -> proto.QuestObj
+	36, // 16: proto.ReplyerMetadata.PublisherMetadata:type_name -> proto.PublisherMetadata
+	38, // 17: proto.CommentMetadata.Topic:type_name -> proto.CommentTopic
+	36, // 18: proto.CommentMetadata.PublisherMetadata:type_name -> proto.PublisherMetadata
+	37, // 19: proto.CommentMetadata.ReplyMetadatas:type_name -> proto.ReplyerMetadata
+	40, // 20: proto.ChatMessage.Channel:type_name -> proto.ChatChannel
+	21, // [21:21] is the sub-list for method output_type
+	21, // [21:21] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_define_proto_init() }
@@ -2938,7 +3664,7 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Collection); i {
+			switch v := v.(*BattleArrayHero); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2950,7 +3676,7 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Chapter); i {
+			switch v := v.(*BattleArray); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2962,7 +3688,7 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Stage); i {
+			switch v := v.(*Collection); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2974,7 +3700,7 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Tower); i {
+			switch v := v.(*Chapter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2986,7 +3712,7 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MailContext); i {
+			switch v := v.(*Stage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2998,7 +3724,7 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Mail); i {
+			switch v := v.(*Tower); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3010,7 +3736,7 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QuestObj); i {
+			switch v := v.(*ArenaOpponent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3022,7 +3748,7 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Quest); i {
+			switch v := v.(*ArenaLog); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3034,7 +3760,7 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RankMetadata); i {
+			switch v := v.(*MailContext); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3046,7 +3772,7 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PublisherMetadata); i {
+			switch v := v.(*Mail); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3058,7 +3784,7 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReplyerMetadata); i {
+			switch v := v.(*QuestObj); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3070,7 +3796,7 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommentTopic); i {
+			switch v := v.(*Quest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3082,6 +3808,54 @@ func file_define_proto_init() {
 			}
 		}
 		file_define_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RankMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_define_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublisherMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_define_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplyerMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_define_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommentTopic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_define_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CommentMetadata); i {
 			case 0:
 				return &v.state
@@ -3093,14 +3867,38 @@ func file_define_proto_init() {
 				return nil
 			}
 		}
+		file_define_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChatChannel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_define_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChatMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_define_proto_rawDesc,
-			NumEnums:      5,
-			NumMessages:   29,
+			NumEnums:      7,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -20,15 +20,64 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 会话功能, 登陆时客户端声明支持的功能, 服务器在S2C_AccountLogon中返回启用的功能
+type SessionCapability int32
+
+const (
+	SessionCapability_SessionCapability_None      SessionCapability = 0
+	SessionCapability_SessionCapability_Sequenced SessionCapability = 1 // 下发消息包装为S2C_SequencedMessage, 支持断线重连补发
+)
+
+// Enum value maps for SessionCapability.
+var (
+	SessionCapability_name = map[int32]string{
+		0: "SessionCapability_None",
+		1: "SessionCapability_Sequenced",
+	}
+	SessionCapability_value = map[string]int32{
+		"SessionCapability_None":      0,
+		"SessionCapability_Sequenced": 1,
+	}
+)
+
+func (x SessionCapability) Enum() *SessionCapability {
+	p := new(SessionCapability)
+	*p = x
+	return p
+}
+
+func (x SessionCapability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SessionCapability) Descriptor() protoreflect.EnumDescriptor {
+	return file_logon_proto_enumTypes[0].Descriptor()
+}
+
+func (SessionCapability) Type() protoreflect.EnumType {
+	return &file_logon_proto_enumTypes[0]
+}
+
+func (x SessionCapability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SessionCapability.Descriptor instead.
+func (SessionCapability) EnumDescriptor() ([]byte, []int) {
+	return file_logon_proto_rawDescGZIP(), []int{0}
+}
+
 // 客户端账号登陆
 type C2S_AccountLogon struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId      string `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
-	AccountId   int64  `protobuf:"varint,2,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
-	AccountName string `protobuf:"bytes,3,opt,name=AccountName,proto3" json:"AccountName,omitempty"`
+	UserId       string `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	AccountId    int64  `protobuf:"varint,2,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
+	AccountName  string `protobuf:"bytes,3,opt,name=AccountName,proto3" json:"AccountName,omitempty"`
+	SessionToken string `protobuf:"bytes,4,opt,name=SessionToken,proto3" json:"SessionToken,omitempty"`  // signed session token issued by gate's select_game_addr
+	Capabilities uint32 `protobuf:"varint,5,opt,name=Capabilities,proto3" json:"Capabilities,omitempty"` // 客户端支持的会话功能, 按位组合SessionCapability
 }
 
 func (x *C2S_AccountLogon) Reset() {
@@ -84,16 +133,31 @@ func (x *C2S_AccountLogon) GetAccountName() string {
 	return ""
 }
 
+func (x *C2S_AccountLogon) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+func (x *C2S_AccountLogon) GetCapabilities() uint32 {
+	if x != nil {
+		return x.Capabilities
+	}
+	return 0
+}
+
 type S2C_AccountLogon struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId      int64  `protobuf:"varint,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
-	AccountId   int64  `protobuf:"varint,2,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
-	PlayerId    int64  `protobuf:"varint,3,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
-	PlayerName  string `protobuf:"bytes,4,opt,name=PlayerName,proto3" json:"PlayerName,omitempty"`
-	PlayerLevel int32  `protobuf:"varint,5,opt,name=PlayerLevel,proto3" json:"PlayerLevel,omitempty"`
+	UserId       int64  `protobuf:"varint,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	AccountId    int64  `protobuf:"varint,2,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
+	PlayerId     int64  `protobuf:"varint,3,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	PlayerName   string `protobuf:"bytes,4,opt,name=PlayerName,proto3" json:"PlayerName,omitempty"`
+	PlayerLevel  int32  `protobuf:"varint,5,opt,name=PlayerLevel,proto3" json:"PlayerLevel,omitempty"`
+	Capabilities uint32 `protobuf:"varint,6,opt,name=Capabilities,proto3" json:"Capabilities,omitempty"` // 服务器启用的会话功能, 按位组合SessionCapability
 }
 
 func (x *S2C_AccountLogon) Reset() {
@@ -163,6 +227,268 @@ func (x *S2C_AccountLogon) GetPlayerLevel() int32 {
 	return 0
 }
 
+func (x *S2C_AccountLogon) GetCapabilities() uint32 {
+	if x != nil {
+		return x.Capabilities
+	}
+	return 0
+}
+
+// 断线重连: 携带session token和最后收到的消息序号, 恢复仍在线的账号
+type C2S_AccountResume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId       string `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	SessionToken string `protobuf:"bytes,2,opt,name=SessionToken,proto3" json:"SessionToken,omitempty"`
+	LastSeq      uint64 `protobuf:"varint,3,opt,name=LastSeq,proto3" json:"LastSeq,omitempty"` // 客户端最后收到的S2C_SequencedMessage序号
+}
+
+func (x *C2S_AccountResume) Reset() {
+	*x = C2S_AccountResume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logon_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *C2S_AccountResume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_AccountResume) ProtoMessage() {}
+
+func (x *C2S_AccountResume) ProtoReflect() protoreflect.Message {
+	mi := &file_logon_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_AccountResume.ProtoReflect.Descriptor instead.
+func (*C2S_AccountResume) Descriptor() ([]byte, []int) {
+	return file_logon_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *C2S_AccountResume) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *C2S_AccountResume) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+func (x *C2S_AccountResume) GetLastSeq() uint64 {
+	if x != nil {
+		return x.LastSeq
+	}
+	return 0
+}
+
+type S2C_AccountResume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Resumed bool   `protobuf:"varint,1,opt,name=Resumed,proto3" json:"Resumed,omitempty"` // false表示无法恢复, 客户端需重新登陆
+	LastSeq uint64 `protobuf:"varint,2,opt,name=LastSeq,proto3" json:"LastSeq,omitempty"` // 服务器最后发送的消息序号
+}
+
+func (x *S2C_AccountResume) Reset() {
+	*x = S2C_AccountResume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logon_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *S2C_AccountResume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_AccountResume) ProtoMessage() {}
+
+func (x *S2C_AccountResume) ProtoReflect() protoreflect.Message {
+	mi := &file_logon_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_AccountResume.ProtoReflect.Descriptor instead.
+func (*S2C_AccountResume) Descriptor() ([]byte, []int) {
+	return file_logon_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *S2C_AccountResume) GetResumed() bool {
+	if x != nil {
+		return x.Resumed
+	}
+	return false
+}
+
+func (x *S2C_AccountResume) GetLastSeq() uint64 {
+	if x != nil {
+		return x.LastSeq
+	}
+	return 0
+}
+
+// 服务器下发的带序号消息, 断线重连时用于补发
+type S2C_SequencedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq          uint64 `protobuf:"varint,1,opt,name=Seq,proto3" json:"Seq,omitempty"`
+	InnerMsgCrc  uint32 `protobuf:"varint,2,opt,name=InnerMsgCrc,proto3" json:"InnerMsgCrc,omitempty"`  // 实际发送的消息id
+	InnerMsgData []byte `protobuf:"bytes,3,opt,name=InnerMsgData,proto3" json:"InnerMsgData,omitempty"` // 实际消息二进制数据
+}
+
+func (x *S2C_SequencedMessage) Reset() {
+	*x = S2C_SequencedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logon_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *S2C_SequencedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_SequencedMessage) ProtoMessage() {}
+
+func (x *S2C_SequencedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_logon_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_SequencedMessage.ProtoReflect.Descriptor instead.
+func (*S2C_SequencedMessage) Descriptor() ([]byte, []int) {
+	return file_logon_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *S2C_SequencedMessage) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *S2C_SequencedMessage) GetInnerMsgCrc() uint32 {
+	if x != nil {
+		return x.InnerMsgCrc
+	}
+	return 0
+}
+
+func (x *S2C_SequencedMessage) GetInnerMsgData() []byte {
+	if x != nil {
+		return x.InnerMsgData
+	}
+	return nil
+}
+
+// game节点下线迁移: 客户端使用新的session token重新连接到新节点
+type S2C_AccountRedirect struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId        int32  `protobuf:"varint,1,opt,name=GameId,proto3" json:"GameId,omitempty"`
+	PublicTcpAddr string `protobuf:"bytes,2,opt,name=PublicTcpAddr,proto3" json:"PublicTcpAddr,omitempty"`
+	PublicWsAddr  string `protobuf:"bytes,3,opt,name=PublicWsAddr,proto3" json:"PublicWsAddr,omitempty"`
+	SessionToken  string `protobuf:"bytes,4,opt,name=SessionToken,proto3" json:"SessionToken,omitempty"` // signed session token for new game node
+}
+
+func (x *S2C_AccountRedirect) Reset() {
+	*x = S2C_AccountRedirect{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logon_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *S2C_AccountRedirect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_AccountRedirect) ProtoMessage() {}
+
+func (x *S2C_AccountRedirect) ProtoReflect() protoreflect.Message {
+	mi := &file_logon_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_AccountRedirect.ProtoReflect.Descriptor instead.
+func (*S2C_AccountRedirect) Descriptor() ([]byte, []int) {
+	return file_logon_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *S2C_AccountRedirect) GetGameId() int32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *S2C_AccountRedirect) GetPublicTcpAddr() string {
+	if x != nil {
+		return x.PublicTcpAddr
+	}
+	return ""
+}
+
+func (x *S2C_AccountRedirect) GetPublicWsAddr() string {
+	if x != nil {
+		return x.PublicWsAddr
+	}
+	return ""
+}
+
+func (x *S2C_AccountRedirect) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
 // 客户端心跳包
 type C2S_HeartBeat struct {
 	state         protoimpl.MessageState
@@ -173,7 +499,7 @@ type C2S_HeartBeat struct {
 func (x *C2S_HeartBeat) Reset() {
 	*x = C2S_HeartBeat{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logon_proto_msgTypes[2]
+		mi := &file_logon_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -186,7 +512,7 @@ func (x *C2S_HeartBeat) String() string {
 func (*C2S_HeartBeat) ProtoMessage() {}
 
 func (x *C2S_HeartBeat) ProtoReflect() protoreflect.Message {
-	mi := &file_logon_proto_msgTypes[2]
+	mi := &file_logon_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -199,7 +525,7 @@ func (x *C2S_HeartBeat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use C2S_HeartBeat.ProtoReflect.Descriptor instead.
 func (*C2S_HeartBeat) Descriptor() ([]byte, []int) {
-	return file_logon_proto_rawDescGZIP(), []int{2}
+	return file_logon_proto_rawDescGZIP(), []int{6}
 }
 
 type S2C_HeartBeat struct {
@@ -211,7 +537,7 @@ type S2C_HeartBeat struct {
 func (x *S2C_HeartBeat) Reset() {
 	*x = S2C_HeartBeat{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logon_proto_msgTypes[3]
+		mi := &file_logon_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -224,7 +550,7 @@ func (x *S2C_HeartBeat) String() string {
 func (*S2C_HeartBeat) ProtoMessage() {}
 
 func (x *S2C_HeartBeat) ProtoReflect() protoreflect.Message {
-	mi := &file_logon_proto_msgTypes[3]
+	mi := &file_logon_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -237,7 +563,7 @@ func (x *S2C_HeartBeat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S2C_HeartBeat.ProtoReflect.Descriptor instead.
 func (*S2C_HeartBeat) Descriptor() ([]byte, []int) {
-	return file_logon_proto_rawDescGZIP(), []int{3}
+	return file_logon_proto_rawDescGZIP(), []int{7}
 }
 
 type S2C_ServerTime struct {
@@ -251,7 +577,7 @@ type S2C_ServerTime struct {
 func (x *S2C_ServerTime) Reset() {
 	*x = S2C_ServerTime{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logon_proto_msgTypes[4]
+		mi := &file_logon_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -264,7 +590,7 @@ func (x *S2C_ServerTime) String() string {
 func (*S2C_ServerTime) ProtoMessage() {}
 
 func (x *S2C_ServerTime) ProtoReflect() protoreflect.Message {
-	mi := &file_logon_proto_msgTypes[4]
+	mi := &file_logon_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -277,7 +603,7 @@ func (x *S2C_ServerTime) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S2C_ServerTime.ProtoReflect.Descriptor instead.
 func (*S2C_ServerTime) Descriptor() ([]byte, []int) {
-	return file_logon_proto_rawDescGZIP(), []int{4}
+	return file_logon_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *S2C_ServerTime) GetTimestamp() uint32 {
@@ -297,7 +623,7 @@ type C2S_AccountDisconnect struct {
 func (x *C2S_AccountDisconnect) Reset() {
 	*x = C2S_AccountDisconnect{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logon_proto_msgTypes[5]
+		mi := &file_logon_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -310,7 +636,7 @@ func (x *C2S_AccountDisconnect) String() string {
 func (*C2S_AccountDisconnect) ProtoMessage() {}
 
 func (x *C2S_AccountDisconnect) ProtoReflect() protoreflect.Message {
-	mi := &file_logon_proto_msgTypes[5]
+	mi := &file_logon_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -323,7 +649,7 @@ func (x *C2S_AccountDisconnect) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use C2S_AccountDisconnect.ProtoReflect.Descriptor instead.
 func (*C2S_AccountDisconnect) Descriptor() ([]byte, []int) {
-	return file_logon_proto_rawDescGZIP(), []int{5}
+	return file_logon_proto_rawDescGZIP(), []int{9}
 }
 
 // ping
@@ -338,7 +664,7 @@ type C2S_Ping struct {
 func (x *C2S_Ping) Reset() {
 	*x = C2S_Ping{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logon_proto_msgTypes[6]
+		mi := &file_logon_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -351,7 +677,7 @@ func (x *C2S_Ping) String() string {
 func (*C2S_Ping) ProtoMessage() {}
 
 func (x *C2S_Ping) ProtoReflect() protoreflect.Message {
-	mi := &file_logon_proto_msgTypes[6]
+	mi := &file_logon_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -364,7 +690,7 @@ func (x *C2S_Ping) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use C2S_Ping.ProtoReflect.Descriptor instead.
 func (*C2S_Ping) Descriptor() ([]byte, []int) {
-	return file_logon_proto_rawDescGZIP(), []int{6}
+	return file_logon_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *C2S_Ping) GetPing() int32 {
@@ -385,7 +711,7 @@ type S2C_Pong struct {
 func (x *S2C_Pong) Reset() {
 	*x = S2C_Pong{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logon_proto_msgTypes[7]
+		mi := &file_logon_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -398,7 +724,7 @@ func (x *S2C_Pong) String() string {
 func (*S2C_Pong) ProtoMessage() {}
 
 func (x *S2C_Pong) ProtoReflect() protoreflect.Message {
-	mi := &file_logon_proto_msgTypes[7]
+	mi := &file_logon_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -411,7 +737,7 @@ func (x *S2C_Pong) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S2C_Pong.ProtoReflect.Descriptor instead.
 func (*S2C_Pong) Descriptor() ([]byte, []int) {
-	return file_logon_proto_rawDescGZIP(), []int{7}
+	return file_logon_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *S2C_Pong) GetPong() int32 {
@@ -425,38 +751,79 @@ var File_logon_proto protoreflect.FileDescriptor
 
 var file_logon_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x6a, 0x0a, 0x10, 0x43, 0x32, 0x53, 0x5f, 0x41, 0x63, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72,
-	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64,
-	0x12, 0x1c, 0x0a, 0x09, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x09, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x20,
-	0x0a, 0x0b, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65,
-	0x22, 0xa6, 0x01, 0x0a, 0x10, 0x53, 0x32, 0x43, 0x5f, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x4c, 0x6f, 0x67, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1c, 0x0a,
-	0x09, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x09, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x50,
-	0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x50,
-	0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x50, 0x6c, 0x61,
-	0x79, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x50, 0x6c,
-	0x61, 0x79, 0x65, 0x72, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x0f, 0x0a, 0x0d, 0x43, 0x32, 0x53,
-	0x5f, 0x48, 0x65, 0x61, 0x72, 0x74, 0x42, 0x65, 0x61, 0x74, 0x22, 0x0f, 0x0a, 0x0d, 0x53, 0x32,
-	0x43, 0x5f, 0x48, 0x65, 0x61, 0x72, 0x74, 0x42, 0x65, 0x61, 0x74, 0x22, 0x2e, 0x0a, 0x0e, 0x53,
-	0x32, 0x43, 0x5f, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a,
-	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x17, 0x0a, 0x15, 0x43,
-	0x32, 0x53, 0x5f, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x22, 0x1e, 0x0a, 0x08, 0x43, 0x32, 0x53, 0x5f, 0x50, 0x69, 0x6e, 0x67,
-	0x12, 0x12, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
-	0x50, 0x69, 0x6e, 0x67, 0x22, 0x1e, 0x0a, 0x08, 0x53, 0x32, 0x43, 0x5f, 0x50, 0x6f, 0x6e, 0x67,
-	0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
-	0x50, 0x6f, 0x6e, 0x67, 0x42, 0x32, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65, 0x6e, 0x2f, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c,
-	0xaa, 0x02, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb2, 0x01, 0x0a, 0x10, 0x43, 0x32, 0x53, 0x5f, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0xca, 0x01, 0x0a, 0x10, 0x53, 0x32,
+	0x43, 0x5f, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x6f, 0x67, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x69, 0x0a, 0x11, 0x43, 0x32, 0x53, 0x5f, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x4c, 0x61, 0x73, 0x74, 0x53,
+	0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x65,
+	0x71, 0x22, 0x47, 0x0a, 0x11, 0x53, 0x32, 0x43, 0x5f, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x07, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x71, 0x22, 0x6e, 0x0a, 0x14, 0x53, 0x32,
+	0x43, 0x5f, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x03, 0x53, 0x65, 0x71, 0x12, 0x20, 0x0a, 0x0b, 0x49, 0x6e, 0x6e, 0x65, 0x72, 0x4d, 0x73, 0x67,
+	0x43, 0x72, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x49, 0x6e, 0x6e, 0x65, 0x72,
+	0x4d, 0x73, 0x67, 0x43, 0x72, 0x63, 0x12, 0x22, 0x0a, 0x0c, 0x49, 0x6e, 0x6e, 0x65, 0x72, 0x4d,
+	0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x49, 0x6e,
+	0x6e, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x22, 0x9b, 0x01, 0x0a, 0x13, 0x53,
+	0x32, 0x43, 0x5f, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x54, 0x63, 0x70, 0x41, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x54, 0x63, 0x70, 0x41, 0x64, 0x64, 0x72,
+	0x12, 0x22, 0x0a, 0x0c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x57, 0x73, 0x41, 0x64, 0x64, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x57, 0x73,
+	0x41, 0x64, 0x64, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x0f, 0x0a, 0x0d, 0x43, 0x32, 0x53, 0x5f,
+	0x48, 0x65, 0x61, 0x72, 0x74, 0x42, 0x65, 0x61, 0x74, 0x22, 0x0f, 0x0a, 0x0d, 0x53, 0x32, 0x43,
+	0x5f, 0x48, 0x65, 0x61, 0x72, 0x74, 0x42, 0x65, 0x61, 0x74, 0x22, 0x2e, 0x0a, 0x0e, 0x53, 0x32,
+	0x43, 0x5f, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x17, 0x0a, 0x15, 0x43, 0x32,
+	0x53, 0x5f, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x22, 0x1e, 0x0a, 0x08, 0x43, 0x32, 0x53, 0x5f, 0x50, 0x69, 0x6e, 0x67, 0x12,
+	0x12, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50,
+	0x69, 0x6e, 0x67, 0x22, 0x1e, 0x0a, 0x08, 0x53, 0x32, 0x43, 0x5f, 0x50, 0x6f, 0x6e, 0x67, 0x12,
+	0x12, 0x0a, 0x04, 0x50, 0x6f, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50,
+	0x6f, 0x6e, 0x67, 0x2a, 0x50, 0x0a, 0x11, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x4e, 0x6f,
+	0x6e, 0x65, 0x10, 0x00, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x64, 0x10, 0x01, 0x42, 0x32, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65, 0x6e, 0x2f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6c, 0x6f, 0x62, 0x61,
+	0x6c, 0xaa, 0x02, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -471,16 +838,22 @@ func file_logon_proto_rawDescGZIP() []byte {
 	return file_logon_proto_rawDescData
 }
 
-var file_logon_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_logon_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_logon_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_logon_proto_goTypes = []interface{}{
-	(*C2S_AccountLogon)(nil),      // 0: proto.C2S_AccountLogon
-	(*S2C_AccountLogon)(nil),      // 1: proto.S2C_AccountLogon
-	(*C2S_HeartBeat)(nil),         // 2: proto.C2S_HeartBeat
-	(*S2C_HeartBeat)(nil),         // 3: proto.S2C_HeartBeat
-	(*S2C_ServerTime)(nil),        // 4: proto.S2C_ServerTime
-	(*C2S_AccountDisconnect)(nil), // 5: proto.C2S_AccountDisconnect
-	(*C2S_Ping)(nil),              // 6: proto.C2S_Ping
-	(*S2C_Pong)(nil),              // 7: proto.S2C_Pong
+	(SessionCapability)(0),        // 0: proto.SessionCapability
+	(*C2S_AccountLogon)(nil),      // 1: proto.C2S_AccountLogon
+	(*S2C_AccountLogon)(nil),      // 2: proto.S2C_AccountLogon
+	(*C2S_AccountResume)(nil),     // 3: proto.C2S_AccountResume
+	(*S2C_AccountResume)(nil),     // 4: proto.S2C_AccountResume
+	(*S2C_SequencedMessage)(nil),  // 5: proto.S2C_SequencedMessage
+	(*S2C_AccountRedirect)(nil),   // 6: proto.S2C_AccountRedirect
+	(*C2S_HeartBeat)(nil),         // 7: proto.C2S_HeartBeat
+	(*S2C_HeartBeat)(nil),         // 8: proto.S2C_HeartBeat
+	(*S2C_ServerTime)(nil),        // 9: proto.S2C_ServerTime
+	(*C2S_AccountDisconnect)(nil), // 10: proto.C2S_AccountDisconnect
+	(*C2S_Ping)(nil),              // 11: proto.C2S_Ping
+	(*S2C_Pong)(nil),              // 12: proto.S2C_Pong
 }
 var file_logon_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -521,7 +894,7 @@ func file_logon_proto_init() {
 			}
 		}
 		file_logon_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*C2S_HeartBeat); i {
+			switch v := v.(*C2S_AccountResume); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -533,7 +906,7 @@ func file_logon_proto_init() {
 			}
 		}
 		file_logon_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*S2C_HeartBeat); i {
+			switch v := v.(*S2C_AccountResume); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -545,7 +918,7 @@ func file_logon_proto_init() {
 			}
 		}
 		file_logon_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*S2C_ServerTime); i {
+			switch v := v.(*S2C_SequencedMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -557,7 +930,7 @@ func file_logon_proto_init() {
 			}
 		}
 		file_logon_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*C2S_AccountDisconnect); i {
+			switch v := v.(*S2C_AccountRedirect); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -569,7 +942,7 @@ func file_logon_proto_init() {
 			}
 		}
 		file_logon_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*C2S_Ping); i {
+			switch v := v.(*C2S_HeartBeat); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -581,6 +954,54 @@ func file_logon_proto_init() {
 			}
 		}
 		file_logon_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*S2C_HeartBeat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logon_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*S2C_ServerTime); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logon_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*C2S_AccountDisconnect); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logon_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*C2S_Ping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logon_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*S2C_Pong); i {
 			case 0:
 				return &v.state
@@ -598,13 +1019,14 @@ func file_logon_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_logon_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      1,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_logon_proto_goTypes,
 		DependencyIndexes: file_logon_proto_depIdxs,
+		EnumInfos:         file_logon_proto_enumTypes,
 		MessageInfos:      file_logon_proto_msgTypes,
 	}.Build()
 	File_logon_proto = out.File
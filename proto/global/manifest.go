@@ -0,0 +1,158 @@
+// Code generated by proto_manifest. DO NOT EDIT.
+
+package global
+
+// ManifestVersion is exchanged in Handshake, clients with different version will be rejected
+const ManifestVersion uint32 = 3140209526
+
+// Manifest maps every message name to its transport id
+var Manifest = map[string]uint32{
+	"AccountInfo":                    3770110234,
+	"ArenaDefenceSnapshot":           1030238798,
+	"ArenaLog":                       1316023436,
+	"ArenaOpponent":                  936189273,
+	"Att":                            2451715890,
+	"BattleArray":                    3960733161,
+	"BattleArrayHero":                801423432,
+	"C2S_AccountDisconnect":          2794433760,
+	"C2S_AccountLogon":               1971174571,
+	"C2S_AccountResume":              941667169,
+	"C2S_ArenaChallenge":             3636923210,
+	"C2S_ArenaInfo":                  2082727637,
+	"C2S_ArenaLogs":                  1193611742,
+	"C2S_ArenaMatch":                 2035977694,
+	"C2S_BuyStrengthen":              2059731387,
+	"C2S_ChapterReward":              2700500572,
+	"C2S_ChatHistory":                665955190,
+	"C2S_ChatReport":                 3488893572,
+	"C2S_ChatSend":                   1163064781,
+	"C2S_CollectionActive":           1547542059,
+	"C2S_CollectionFragmentsCompose": 1977853342,
+	"C2S_CollectionStarup":           4054264381,
+	"C2S_CollectionWakeup":           3034719560,
+	"C2S_CommentDelete":              4259733190,
+	"C2S_CommentPublish":             3112716645,
+	"C2S_CommentQuery":               121061520,
+	"C2S_CommentReply":               3726783387,
+	"C2S_CommentThumbs":              3454980551,
+	"C2S_CreatePlayer":               3090866982,
+	"C2S_CrystalLevelup":             160157380,
+	"C2S_DelHero":                    3958721461,
+	"C2S_DelItem":                    2770337837,
+	"C2S_EquipLevelup":               3277384974,
+	"C2S_EquipPromote":               2737938296,
+	"C2S_EquipStarup":                3418642067,
+	"C2S_GmCmd":                      3805552337,
+	"C2S_GuidePass":                  2478742759,
+	"C2S_HeartBeat":                  2429919606,
+	"C2S_HeroFragmentsCompose":       2216164668,
+	"C2S_HeroLevelup":                3537977235,
+	"C2S_HeroPromote":                2995277285,
+	"C2S_HeroStarup":                 1931319112,
+	"C2S_HeroTalentChoose":           3210295149,
+	"C2S_Ping":                       1765196160,
+	"C2S_PlayerQuestReward":          2894357520,
+	"C2S_PutonCrystal":               636522823,
+	"C2S_PutonEquip":                 3125773472,
+	"C2S_QueryCombatRecord":          3044324236,
+	"C2S_QueryRank":                  3176295084,
+	"C2S_QueryTowerRank":             1039941755,
+	"C2S_SaveBattleArray":            3189452870,
+	"C2S_StageChallenge":             2388560342,
+	"C2S_StageSweep":                 1361607561,
+	"C2S_TakeoffCrystal":             3096788995,
+	"C2S_TakeoffEquip":               2717399774,
+	"C2S_TestCrystalRandom":          4073554738,
+	"C2S_TowerChallenge":             3328594211,
+	"C2S_UseItem":                    3155563680,
+	"C2S_WaitResponseMessage":        3535423748,
+	"C2S_WithdrawStrengthen":         257147456,
+	"Chapter":                        909937842,
+	"ChatChannel":                    1966598318,
+	"ChatMessage":                    1634948758,
+	"Collection":                     3004196578,
+	"CombatEvent":                    3122432754,
+	"CombatRecord":                   2159315050,
+	"CombatStatistics":               456916694,
+	"CommentMetadata":                3114068913,
+	"CommentTopic":                   1067710480,
+	"Crystal":                        1211573893,
+	"CrystalAtt":                     247449658,
+	"CrystalData":                    59132304,
+	"EntityInfo":                     386065636,
+	"Equip":                          867364020,
+	"EquipData":                      1443496312,
+	"Fragment":                       853243834,
+	"Handshake":                      1707781183,
+	"HandshakeResp":                  783068183,
+	"Hero":                           4059873720,
+	"Item":                           3207170592,
+	"LootData":                       3214625798,
+	"Mail":                           4044620662,
+	"MailContext":                    2406902749,
+	"PlayerInfo":                     346867040,
+	"PublisherMetadata":              1613353166,
+	"Quest":                          2195117843,
+	"QuestObj":                       3246566174,
+	"RankMetadata":                   4170705301,
+	"ReplyerMetadata":                3517590327,
+	"S2C_AccountLogon":               4125671001,
+	"S2C_AccountRedirect":            3242570320,
+	"S2C_AccountResume":              1796453715,
+	"S2C_ArenaChallenge":             272853452,
+	"S2C_ArenaInfo":                  1725072580,
+	"S2C_ArenaLogs":                  1574204367,
+	"S2C_ArenaMatch":                 334519374,
+	"S2C_ChapterUpdate":              623976261,
+	"S2C_ChatHistory":                3621032675,
+	"S2C_ChatMessage":                1188287191,
+	"S2C_ChatReport":                 2774440724,
+	"S2C_CollectionFragmentsList":    2625031210,
+	"S2C_CollectionFragmentsUpdate":  1424051458,
+	"S2C_CollectionInfo":             3143387480,
+	"S2C_CombatRecord":               2169002472,
+	"S2C_CommentDelete":              2933217524,
+	"S2C_CommentPublish":             1896623075,
+	"S2C_CommentQuery":               2275557986,
+	"S2C_CommentReply":               1589064041,
+	"S2C_CommentThumbs":              2665338869,
+	"S2C_CreatePlayer":               951050708,
+	"S2C_CrystalAttUpdate":           2828499913,
+	"S2C_CrystalUpdate":              2411162940,
+	"S2C_DelHero":                    3921923343,
+	"S2C_DelItem":                    2803271319,
+	"S2C_EquipUpdate":                1157632618,
+	"S2C_ExpUpdate":                  1828756006,
+	"S2C_HeartBeat":                  2317665127,
+	"S2C_HeroAttUpdate":              176249013,
+	"S2C_HeroAtts":                   337714086,
+	"S2C_HeroFragmentsList":          3348166869,
+	"S2C_HeroFragmentsUpdate":        3866173219,
+	"S2C_HeroInfo":                   2899392883,
+	"S2C_HeroLevelup":                579077638,
+	"S2C_HeroPromote":                1122858096,
+	"S2C_HeroTalentChoose":           1899806800,
+	"S2C_ItemAdd":                    1852621551,
+	"S2C_ItemUpdate":                 3532533667,
+	"S2C_PlayerInitInfo":             3247839080,
+	"S2C_Pong":                       316051074,
+	"S2C_QueryRank":                  2812479677,
+	"S2C_QueryTowerRank":             4118291197,
+	"S2C_QuestUpdate":                3878702808,
+	"S2C_SaveBattleArray":            3121123889,
+	"S2C_SequencedMessage":           2257013757,
+	"S2C_ServerConsole":              3375922503,
+	"S2C_ServerTime":                 1369230458,
+	"S2C_StageCombat":                559854432,
+	"S2C_StageUpdate":                875193728,
+	"S2C_TestCrystalRandomReport":    1215496968,
+	"S2C_TokenUpdate":                391369477,
+	"S2C_TowerUpdate":                4258895066,
+	"S2C_VipUpdate":                  1214043431,
+	"S2C_WaitResponseMessage":        2553077156,
+	"Stage":                          62766189,
+	"Talent":                         292882371,
+	"Token":                          2666958399,
+	"Tower":                          2680916068,
+	"WaveStatistics":                 3840468395,
+}
@@ -384,7 +384,9 @@ type C2S_SaveBattleArray struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BattleHeroId []int64 `protobuf:"varint,1,rep,packed,name=BattleHeroId,proto3" json:"BattleHeroId,omitempty"`
+	Type   int32              `protobuf:"varint,2,opt,name=Type,proto3" json:"Type,omitempty"`    // 布阵类型
+	Name   string             `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`     // 布阵名称
+	Heroes []*BattleArrayHero `protobuf:"bytes,4,rep,name=Heroes,proto3" json:"Heroes,omitempty"` // 布阵英雄, 为空时清除布阵
 }
 
 func (x *C2S_SaveBattleArray) Reset() {
@@ -419,9 +421,23 @@ func (*C2S_SaveBattleArray) Descriptor() ([]byte, []int) {
 	return file_player_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *C2S_SaveBattleArray) GetBattleHeroId() []int64 {
+func (x *C2S_SaveBattleArray) GetType() int32 {
 	if x != nil {
-		return x.BattleHeroId
+		return x.Type
+	}
+	return 0
+}
+
+func (x *C2S_SaveBattleArray) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *C2S_SaveBattleArray) GetHeroes() []*BattleArrayHero {
+	if x != nil {
+		return x.Heroes
 	}
 	return nil
 }
@@ -431,7 +447,8 @@ type S2C_SaveBattleArray struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Success bool         `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Array   *BattleArray `protobuf:"bytes,2,opt,name=Array,proto3" json:"Array,omitempty"`
 }
 
 func (x *S2C_SaveBattleArray) Reset() {
@@ -473,6 +490,13 @@ func (x *S2C_SaveBattleArray) GetSuccess() bool {
 	return false
 }
 
+func (x *S2C_SaveBattleArray) GetArray() *BattleArray {
+	if x != nil {
+		return x.Array
+	}
+	return nil
+}
+
 ////////////////////////////////////////////////
 // stage
 type C2S_StageChallenge struct {
@@ -899,9 +923,8 @@ type C2S_TowerChallenge struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TowerType   int32   `protobuf:"varint,1,opt,name=TowerType,proto3" json:"TowerType,omitempty"`            // 塔类型
-	TowerFloor  int32   `protobuf:"varint,2,opt,name=TowerFloor,proto3" json:"TowerFloor,omitempty"`          // 挑战层数
-	BattleArray []int64 `protobuf:"varint,3,rep,packed,name=BattleArray,proto3" json:"BattleArray,omitempty"` // 挑战阵容
+	TowerType  int32 `protobuf:"varint,1,opt,name=TowerType,proto3" json:"TowerType,omitempty"`   // 塔类型
+	TowerFloor int32 `protobuf:"varint,2,opt,name=TowerFloor,proto3" json:"TowerFloor,omitempty"` // 挑战层数
 }
 
 func (x *C2S_TowerChallenge) Reset() {
@@ -950,13 +973,6 @@ func (x *C2S_TowerChallenge) GetTowerFloor() int32 {
 	return 0
 }
 
-func (x *C2S_TowerChallenge) GetBattleArray() []int64 {
-	if x != nil {
-		return x.BattleArray
-	}
-	return nil
-}
-
 type S2C_TowerUpdate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1004,6 +1020,535 @@ func (x *S2C_TowerUpdate) GetTower() *Tower {
 	return nil
 }
 
+// 查询爬塔某层的最快通关记录
+type C2S_QueryTowerRank struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TowerType  int32 `protobuf:"varint,1,opt,name=TowerType,proto3" json:"TowerType,omitempty"`   // 塔类型
+	TowerFloor int32 `protobuf:"varint,2,opt,name=TowerFloor,proto3" json:"TowerFloor,omitempty"` // 层数
+}
+
+func (x *C2S_QueryTowerRank) Reset() {
+	*x = C2S_QueryTowerRank{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_player_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *C2S_QueryTowerRank) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_QueryTowerRank) ProtoMessage() {}
+
+func (x *C2S_QueryTowerRank) ProtoReflect() protoreflect.Message {
+	mi := &file_player_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_QueryTowerRank.ProtoReflect.Descriptor instead.
+func (*C2S_QueryTowerRank) Descriptor() ([]byte, []int) {
+	return file_player_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *C2S_QueryTowerRank) GetTowerType() int32 {
+	if x != nil {
+		return x.TowerType
+	}
+	return 0
+}
+
+func (x *C2S_QueryTowerRank) GetTowerFloor() int32 {
+	if x != nil {
+		return x.TowerFloor
+	}
+	return 0
+}
+
+type S2C_QueryTowerRank struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TowerType  int32           `protobuf:"varint,1,opt,name=TowerType,proto3" json:"TowerType,omitempty"`
+	TowerFloor int32           `protobuf:"varint,2,opt,name=TowerFloor,proto3" json:"TowerFloor,omitempty"`
+	Metadatas  []*RankMetadata `protobuf:"bytes,3,rep,name=Metadatas,proto3" json:"Metadatas,omitempty"` // 按通关时间排序, 通过RecordId查询战斗录像
+}
+
+func (x *S2C_QueryTowerRank) Reset() {
+	*x = S2C_QueryTowerRank{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_player_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *S2C_QueryTowerRank) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_QueryTowerRank) ProtoMessage() {}
+
+func (x *S2C_QueryTowerRank) ProtoReflect() protoreflect.Message {
+	mi := &file_player_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_QueryTowerRank.ProtoReflect.Descriptor instead.
+func (*S2C_QueryTowerRank) Descriptor() ([]byte, []int) {
+	return file_player_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *S2C_QueryTowerRank) GetTowerType() int32 {
+	if x != nil {
+		return x.TowerType
+	}
+	return 0
+}
+
+func (x *S2C_QueryTowerRank) GetTowerFloor() int32 {
+	if x != nil {
+		return x.TowerFloor
+	}
+	return 0
+}
+
+func (x *S2C_QueryTowerRank) GetMetadatas() []*RankMetadata {
+	if x != nil {
+		return x.Metadatas
+	}
+	return nil
+}
+
+////////////////////////////////////////////////
+// 竞技场
+type C2S_ArenaInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *C2S_ArenaInfo) Reset() {
+	*x = C2S_ArenaInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_player_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *C2S_ArenaInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_ArenaInfo) ProtoMessage() {}
+
+func (x *C2S_ArenaInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_player_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_ArenaInfo.ProtoReflect.Descriptor instead.
+func (*C2S_ArenaInfo) Descriptor() ([]byte, []int) {
+	return file_player_proto_rawDescGZIP(), []int{19}
+}
+
+type S2C_ArenaInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Season        int32   `protobuf:"varint,1,opt,name=Season,proto3" json:"Season,omitempty"`               // 当前赛季, 0为未开启
+	Score         float64 `protobuf:"fixed64,2,opt,name=Score,proto3" json:"Score,omitempty"`                // 当前积分
+	RankIndex     int32   `protobuf:"varint,3,opt,name=RankIndex,proto3" json:"RankIndex,omitempty"`         // 排行榜中位置：从0开始, -1为未上榜
+	SeasonEndTime int64   `protobuf:"varint,4,opt,name=SeasonEndTime,proto3" json:"SeasonEndTime,omitempty"` // 赛季结束时间
+}
+
+func (x *S2C_ArenaInfo) Reset() {
+	*x = S2C_ArenaInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_player_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *S2C_ArenaInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_ArenaInfo) ProtoMessage() {}
+
+func (x *S2C_ArenaInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_player_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_ArenaInfo.ProtoReflect.Descriptor instead.
+func (*S2C_ArenaInfo) Descriptor() ([]byte, []int) {
+	return file_player_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *S2C_ArenaInfo) GetSeason() int32 {
+	if x != nil {
+		return x.Season
+	}
+	return 0
+}
+
+func (x *S2C_ArenaInfo) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *S2C_ArenaInfo) GetRankIndex() int32 {
+	if x != nil {
+		return x.RankIndex
+	}
+	return 0
+}
+
+func (x *S2C_ArenaInfo) GetSeasonEndTime() int64 {
+	if x != nil {
+		return x.SeasonEndTime
+	}
+	return 0
+}
+
+// 匹配对手, 需要先保存竞技场防守布阵
+type C2S_ArenaMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *C2S_ArenaMatch) Reset() {
+	*x = C2S_ArenaMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_player_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *C2S_ArenaMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_ArenaMatch) ProtoMessage() {}
+
+func (x *C2S_ArenaMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_player_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_ArenaMatch.ProtoReflect.Descriptor instead.
+func (*C2S_ArenaMatch) Descriptor() ([]byte, []int) {
+	return file_player_proto_rawDescGZIP(), []int{21}
+}
+
+type S2C_ArenaMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Opponents []*ArenaOpponent `protobuf:"bytes,1,rep,name=Opponents,proto3" json:"Opponents,omitempty"`
+}
+
+func (x *S2C_ArenaMatch) Reset() {
+	*x = S2C_ArenaMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_player_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *S2C_ArenaMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_ArenaMatch) ProtoMessage() {}
+
+func (x *S2C_ArenaMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_player_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_ArenaMatch.ProtoReflect.Descriptor instead.
+func (*S2C_ArenaMatch) Descriptor() ([]byte, []int) {
+	return file_player_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *S2C_ArenaMatch) GetOpponents() []*ArenaOpponent {
+	if x != nil {
+		return x.Opponents
+	}
+	return nil
+}
+
+// 使用竞技场进攻布阵挑战匹配到的对手
+type C2S_ArenaChallenge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetId int64 `protobuf:"varint,1,opt,name=TargetId,proto3" json:"TargetId,omitempty"` // 对手玩家id
+}
+
+func (x *C2S_ArenaChallenge) Reset() {
+	*x = C2S_ArenaChallenge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_player_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *C2S_ArenaChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_ArenaChallenge) ProtoMessage() {}
+
+func (x *C2S_ArenaChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_player_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_ArenaChallenge.ProtoReflect.Descriptor instead.
+func (*C2S_ArenaChallenge) Descriptor() ([]byte, []int) {
+	return file_player_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *C2S_ArenaChallenge) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+type S2C_ArenaChallenge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetId    int64   `protobuf:"varint,1,opt,name=TargetId,proto3" json:"TargetId,omitempty"`
+	Win         bool    `protobuf:"varint,2,opt,name=Win,proto3" json:"Win,omitempty"`                  // 战斗结果
+	ScoreChange float64 `protobuf:"fixed64,3,opt,name=ScoreChange,proto3" json:"ScoreChange,omitempty"` // 积分变化
+	Score       float64 `protobuf:"fixed64,4,opt,name=Score,proto3" json:"Score,omitempty"`             // 变化后积分
+	RecordId    int64   `protobuf:"varint,5,opt,name=RecordId,proto3" json:"RecordId,omitempty"`        // 战斗录像id
+}
+
+func (x *S2C_ArenaChallenge) Reset() {
+	*x = S2C_ArenaChallenge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_player_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *S2C_ArenaChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_ArenaChallenge) ProtoMessage() {}
+
+func (x *S2C_ArenaChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_player_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_ArenaChallenge.ProtoReflect.Descriptor instead.
+func (*S2C_ArenaChallenge) Descriptor() ([]byte, []int) {
+	return file_player_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *S2C_ArenaChallenge) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *S2C_ArenaChallenge) GetWin() bool {
+	if x != nil {
+		return x.Win
+	}
+	return false
+}
+
+func (x *S2C_ArenaChallenge) GetScoreChange() float64 {
+	if x != nil {
+		return x.ScoreChange
+	}
+	return 0
+}
+
+func (x *S2C_ArenaChallenge) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *S2C_ArenaChallenge) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+// 查询防守记录
+type C2S_ArenaLogs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *C2S_ArenaLogs) Reset() {
+	*x = C2S_ArenaLogs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_player_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *C2S_ArenaLogs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*C2S_ArenaLogs) ProtoMessage() {}
+
+func (x *C2S_ArenaLogs) ProtoReflect() protoreflect.Message {
+	mi := &file_player_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use C2S_ArenaLogs.ProtoReflect.Descriptor instead.
+func (*C2S_ArenaLogs) Descriptor() ([]byte, []int) {
+	return file_player_proto_rawDescGZIP(), []int{25}
+}
+
+type S2C_ArenaLogs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Logs []*ArenaLog `protobuf:"bytes,1,rep,name=Logs,proto3" json:"Logs,omitempty"` // 按时间倒序
+}
+
+func (x *S2C_ArenaLogs) Reset() {
+	*x = S2C_ArenaLogs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_player_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *S2C_ArenaLogs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S2C_ArenaLogs) ProtoMessage() {}
+
+func (x *S2C_ArenaLogs) ProtoReflect() protoreflect.Message {
+	mi := &file_player_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S2C_ArenaLogs.ProtoReflect.Descriptor instead.
+func (*S2C_ArenaLogs) Descriptor() ([]byte, []int) {
+	return file_player_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *S2C_ArenaLogs) GetLogs() []*ArenaLog {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
 // gm 命令:
 // gm player level(exp、vip) 10
 // gm hero add 1
@@ -1019,7 +1564,7 @@ type C2S_GmCmd struct {
 func (x *C2S_GmCmd) Reset() {
 	*x = C2S_GmCmd{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_player_proto_msgTypes[17]
+		mi := &file_player_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1032,7 +1577,7 @@ func (x *C2S_GmCmd) String() string {
 func (*C2S_GmCmd) ProtoMessage() {}
 
 func (x *C2S_GmCmd) ProtoReflect() protoreflect.Message {
-	mi := &file_player_proto_msgTypes[17]
+	mi := &file_player_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1045,7 +1590,7 @@ func (x *C2S_GmCmd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use C2S_GmCmd.ProtoReflect.Descriptor instead.
 func (*C2S_GmCmd) Descriptor() ([]byte, []int) {
-	return file_player_proto_rawDescGZIP(), []int{17}
+	return file_player_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *C2S_GmCmd) GetCmd() string {
@@ -1111,63 +1656,113 @@ var file_player_proto_rawDesc = []byte{
 	0x70, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x56, 0x69, 0x70, 0x45, 0x78,
 	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x56, 0x69, 0x70, 0x45, 0x78, 0x70, 0x12,
 	0x1a, 0x0a, 0x08, 0x56, 0x69, 0x70, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x08, 0x56, 0x69, 0x70, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x39, 0x0a, 0x13, 0x43,
+	0x05, 0x52, 0x08, 0x56, 0x69, 0x70, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x73, 0x0a, 0x13, 0x43,
 	0x32, 0x53, 0x5f, 0x53, 0x61, 0x76, 0x65, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x41, 0x72, 0x72,
-	0x61, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x48, 0x65, 0x72, 0x6f,
-	0x49, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0c, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65,
-	0x48, 0x65, 0x72, 0x6f, 0x49, 0x64, 0x22, 0x2f, 0x0a, 0x13, 0x53, 0x32, 0x43, 0x5f, 0x53, 0x61,
-	0x76, 0x65, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x41, 0x72, 0x72, 0x61, 0x79, 0x12, 0x18, 0x0a,
-	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
-	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x92, 0x01, 0x0a, 0x12, 0x43, 0x32, 0x53, 0x5f,
-	0x53, 0x74, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x07, 0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x57, 0x69, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x57, 0x69, 0x6e, 0x12, 0x2a, 0x0a, 0x10, 0x41, 0x63,
-	0x68, 0x69, 0x65, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x41, 0x63, 0x68, 0x69, 0x65, 0x76, 0x65, 0x43, 0x6f, 0x6e,
-	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x72, 0x43, 0x6f,
-	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x08, 0x52, 0x0d, 0x53,
-	0x74, 0x61, 0x72, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x40, 0x0a, 0x0e,
-	0x43, 0x32, 0x53, 0x5f, 0x53, 0x74, 0x61, 0x67, 0x65, 0x53, 0x77, 0x65, 0x65, 0x70, 0x12, 0x18,
-	0x0a, 0x07, 0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x07, 0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x22, 0x47,
-	0x0a, 0x11, 0x43, 0x32, 0x53, 0x5f, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x52, 0x65, 0x77,
-	0x61, 0x72, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x49, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x49,
-	0x64, 0x12, 0x14, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x3d, 0x0a, 0x11, 0x53, 0x32, 0x43, 0x5f, 0x43,
-	0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x07,
-	0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x52, 0x07, 0x43,
-	0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x22, 0x35, 0x0a, 0x0f, 0x53, 0x32, 0x43, 0x5f, 0x53, 0x74,
-	0x61, 0x67, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x53, 0x74, 0x61,
-	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x22, 0x2e, 0x0a,
-	0x16, 0x43, 0x32, 0x53, 0x5f, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x53, 0x74, 0x72,
-	0x65, 0x6e, 0x67, 0x74, 0x68, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x13, 0x0a,
-	0x11, 0x43, 0x32, 0x53, 0x5f, 0x42, 0x75, 0x79, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68,
-	0x65, 0x6e, 0x22, 0x25, 0x0a, 0x0d, 0x43, 0x32, 0x53, 0x5f, 0x47, 0x75, 0x69, 0x64, 0x65, 0x50,
-	0x61, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x74, 0x0a, 0x12, 0x43, 0x32, 0x53,
-	0x5f, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12,
-	0x1c, 0x0a, 0x09, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x09, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a,
-	0x0a, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x46, 0x6c, 0x6f, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x0a, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x46, 0x6c, 0x6f, 0x6f, 0x72, 0x12, 0x20, 0x0a,
-	0x0b, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x41, 0x72, 0x72, 0x61, 0x79, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x03, 0x52, 0x0b, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x41, 0x72, 0x72, 0x61, 0x79, 0x22,
-	0x35, 0x0a, 0x0f, 0x53, 0x32, 0x43, 0x5f, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x52,
-	0x05, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x22, 0x1d, 0x0a, 0x09, 0x43, 0x32, 0x53, 0x5f, 0x47, 0x6d,
-	0x43, 0x6d, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x63, 0x6d, 0x64, 0x42, 0x32, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65, 0x6e, 0x2f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6c, 0x6f, 0x62, 0x61,
-	0x6c, 0xaa, 0x02, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x48, 0x65,
+	0x72, 0x6f, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x41, 0x72, 0x72, 0x61, 0x79, 0x48, 0x65,
+	0x72, 0x6f, 0x52, 0x06, 0x48, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02,
+	0x22, 0x59, 0x0a, 0x13, 0x53, 0x32, 0x43, 0x5f, 0x53, 0x61, 0x76, 0x65, 0x42, 0x61, 0x74, 0x74,
+	0x6c, 0x65, 0x41, 0x72, 0x72, 0x61, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x28, 0x0a, 0x05, 0x41, 0x72, 0x72, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x61, 0x74, 0x74, 0x6c, 0x65, 0x41,
+	0x72, 0x72, 0x61, 0x79, 0x52, 0x05, 0x41, 0x72, 0x72, 0x61, 0x79, 0x22, 0x92, 0x01, 0x0a, 0x12,
+	0x43, 0x32, 0x53, 0x5f, 0x53, 0x74, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x57, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x57, 0x69, 0x6e, 0x12, 0x2a,
+	0x0a, 0x10, 0x41, 0x63, 0x68, 0x69, 0x65, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x41, 0x63, 0x68, 0x69, 0x65, 0x76,
+	0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x74,
+	0x61, 0x72, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x08, 0x52, 0x0d, 0x53, 0x74, 0x61, 0x72, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x40, 0x0a, 0x0e, 0x43, 0x32, 0x53, 0x5f, 0x53, 0x74, 0x61, 0x67, 0x65, 0x53, 0x77, 0x65,
+	0x65, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x53, 0x74, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x22, 0x47, 0x0a, 0x11, 0x43, 0x32, 0x53, 0x5f, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65,
+	0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x68, 0x61, 0x70, 0x74,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x43, 0x68, 0x61, 0x70,
+	0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x3d, 0x0a, 0x11, 0x53,
+	0x32, 0x43, 0x5f, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x28, 0x0a, 0x07, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65,
+	0x72, 0x52, 0x07, 0x43, 0x68, 0x61, 0x70, 0x74, 0x65, 0x72, 0x22, 0x35, 0x0a, 0x0f, 0x53, 0x32,
+	0x43, 0x5f, 0x53, 0x74, 0x61, 0x67, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a,
+	0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x53, 0x74, 0x61, 0x67,
+	0x65, 0x22, 0x2e, 0x0a, 0x16, 0x43, 0x32, 0x53, 0x5f, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61,
+	0x77, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0x13, 0x0a, 0x11, 0x43, 0x32, 0x53, 0x5f, 0x42, 0x75, 0x79, 0x53, 0x74, 0x72, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x65, 0x6e, 0x22, 0x25, 0x0a, 0x0d, 0x43, 0x32, 0x53, 0x5f, 0x47, 0x75,
+	0x69, 0x64, 0x65, 0x50, 0x61, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x58, 0x0a,
+	0x12, 0x43, 0x32, 0x53, 0x5f, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x46, 0x6c, 0x6f, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x46, 0x6c, 0x6f, 0x6f,
+	0x72, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0x35, 0x0a, 0x0f, 0x53, 0x32, 0x43, 0x5f, 0x54,
+	0x6f, 0x77, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x54, 0x6f,
+	0x77, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x05, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x22, 0x52,
+	0x0a, 0x12, 0x43, 0x32, 0x53, 0x5f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x6f, 0x77, 0x65, 0x72,
+	0x52, 0x61, 0x6e, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x46, 0x6c, 0x6f, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x46, 0x6c, 0x6f,
+	0x6f, 0x72, 0x22, 0x85, 0x01, 0x0a, 0x12, 0x53, 0x32, 0x43, 0x5f, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x54, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x6f, 0x77,
+	0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x54, 0x6f,
+	0x77, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x6f, 0x77, 0x65, 0x72,
+	0x46, 0x6c, 0x6f, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x54, 0x6f, 0x77,
+	0x65, 0x72, 0x46, 0x6c, 0x6f, 0x6f, 0x72, 0x12, 0x31, 0x0a, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x09, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x22, 0x0f, 0x0a, 0x0d, 0x43, 0x32,
+	0x53, 0x5f, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x81, 0x01, 0x0a, 0x0d,
+	0x53, 0x32, 0x43, 0x5f, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a,
+	0x06, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x52,
+	0x61, 0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x52, 0x61, 0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x45, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x45, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22,
+	0x10, 0x0a, 0x0e, 0x43, 0x32, 0x53, 0x5f, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x22, 0x44, 0x0a, 0x0e, 0x53, 0x32, 0x43, 0x5f, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x32, 0x0a, 0x09, 0x4f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41,
+	0x72, 0x65, 0x6e, 0x61, 0x4f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x4f, 0x70,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x30, 0x0a, 0x12, 0x43, 0x32, 0x53, 0x5f, 0x41,
+	0x72, 0x65, 0x6e, 0x61, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x22, 0x96, 0x01, 0x0a, 0x12, 0x53, 0x32,
+	0x43, 0x5f, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x57, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x57, 0x69, 0x6e, 0x12, 0x20,
+	0x0a, 0x0b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x49, 0x64, 0x22, 0x0f, 0x0a, 0x0d, 0x43, 0x32, 0x53, 0x5f, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x4c,
+	0x6f, 0x67, 0x73, 0x22, 0x34, 0x0a, 0x0d, 0x53, 0x32, 0x43, 0x5f, 0x41, 0x72, 0x65, 0x6e, 0x61,
+	0x4c, 0x6f, 0x67, 0x73, 0x12, 0x23, 0x0a, 0x04, 0x4c, 0x6f, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x72, 0x65, 0x6e, 0x61,
+	0x4c, 0x6f, 0x67, 0x52, 0x04, 0x4c, 0x6f, 0x67, 0x73, 0x22, 0x1d, 0x0a, 0x09, 0x43, 0x32, 0x53,
+	0x5f, 0x47, 0x6d, 0x43, 0x6d, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x42, 0x32, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65, 0x6e,
+	0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6c,
+	0x6f, 0x62, 0x61, 0x6c, 0xaa, 0x02, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1182,7 +1777,7 @@ func file_player_proto_rawDescGZIP() []byte {
 	return file_player_proto_rawDescData
 }
 
-var file_player_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_player_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_player_proto_goTypes = []interface{}{
 	(*C2S_CreatePlayer)(nil),       // 0: proto.C2S_CreatePlayer
 	(*S2C_CreatePlayer)(nil),       // 1: proto.S2C_CreatePlayer
@@ -1201,43 +1796,63 @@ var file_player_proto_goTypes = []interface{}{
 	(*C2S_GuidePass)(nil),          // 14: proto.C2S_GuidePass
 	(*C2S_TowerChallenge)(nil),     // 15: proto.C2S_TowerChallenge
 	(*S2C_TowerUpdate)(nil),        // 16: proto.S2C_TowerUpdate
-	(*C2S_GmCmd)(nil),              // 17: proto.C2S_GmCmd
-	(*PlayerInfo)(nil),             // 18: proto.PlayerInfo
-	(*Hero)(nil),                   // 19: proto.Hero
-	(*Item)(nil),                   // 20: proto.Item
-	(*Equip)(nil),                  // 21: proto.Equip
-	(*Crystal)(nil),                // 22: proto.Crystal
-	(*Collection)(nil),             // 23: proto.Collection
-	(*Fragment)(nil),               // 24: proto.Fragment
-	(*Chapter)(nil),                // 25: proto.Chapter
-	(*Stage)(nil),                  // 26: proto.Stage
-	(*Quest)(nil),                  // 27: proto.Quest
-	(*Token)(nil),                  // 28: proto.Token
-	(*Tower)(nil),                  // 29: proto.Tower
+	(*C2S_QueryTowerRank)(nil),     // 17: proto.C2S_QueryTowerRank
+	(*S2C_QueryTowerRank)(nil),     // 18: proto.S2C_QueryTowerRank
+	(*C2S_ArenaInfo)(nil),          // 19: proto.C2S_ArenaInfo
+	(*S2C_ArenaInfo)(nil),          // 20: proto.S2C_ArenaInfo
+	(*C2S_ArenaMatch)(nil),         // 21: proto.C2S_ArenaMatch
+	(*S2C_ArenaMatch)(nil),         // 22: proto.S2C_ArenaMatch
+	(*C2S_ArenaChallenge)(nil),     // 23: proto.C2S_ArenaChallenge
+	(*S2C_ArenaChallenge)(nil),     // 24: proto.S2C_ArenaChallenge
+	(*C2S_ArenaLogs)(nil),          // 25: proto.C2S_ArenaLogs
+	(*S2C_ArenaLogs)(nil),          // 26: proto.S2C_ArenaLogs
+	(*C2S_GmCmd)(nil),              // 27: proto.C2S_GmCmd
+	(*PlayerInfo)(nil),             // 28: proto.PlayerInfo
+	(*Hero)(nil),                   // 29: proto.Hero
+	(*Item)(nil),                   // 30: proto.Item
+	(*Equip)(nil),                  // 31: proto.Equip
+	(*Crystal)(nil),                // 32: proto.Crystal
+	(*Collection)(nil),             // 33: proto.Collection
+	(*Fragment)(nil),               // 34: proto.Fragment
+	(*Chapter)(nil),                // 35: proto.Chapter
+	(*Stage)(nil),                  // 36: proto.Stage
+	(*Quest)(nil),                  // 37: proto.Quest
+	(*Token)(nil),                  // 38: proto.Token
+	(*Tower)(nil),                  // 39: proto.Tower
+	(*BattleArrayHero)(nil),        // 40: proto.BattleArrayHero
+	(*BattleArray)(nil),            // 41: proto.BattleArray
+	(*RankMetadata)(nil),           // 42: proto.RankMetadata
+	(*ArenaOpponent)(nil),          // 43: proto.ArenaOpponent
+	(*ArenaLog)(nil),               // 44: proto.ArenaLog
 }
 var file_player_proto_depIdxs = []int32{
-	18, // 0: proto.S2C_CreatePlayer.info:type_name -> proto.PlayerInfo
-	18, // 1: proto.S2C_PlayerInitInfo.Info:type_name -> proto.PlayerInfo
-	19, // 2: proto.S2C_PlayerInitInfo.Heros:type_name -> proto.Hero
-	20, // 3: proto.S2C_PlayerInitInfo.Items:type_name -> proto.Item
-	21, // 4: proto.S2C_PlayerInitInfo.Equips:type_name -> proto.Equip
-	22, // 5: proto.S2C_PlayerInitInfo.Crystals:type_name -> proto.Crystal
-	23, // 6: proto.S2C_PlayerInitInfo.Collections:type_name -> proto.Collection
-	24, // 7: proto.S2C_PlayerInitInfo.HeroFrags:type_name -> proto.Fragment
-	24, // 8: proto.S2C_PlayerInitInfo.CollectionFrags:type_name -> proto.Fragment
-	25, // 9: proto.S2C_PlayerInitInfo.Chapters:type_name -> proto.Chapter
-	26, // 10: proto.S2C_PlayerInitInfo.Stages:type_name -> proto.Stage
-	27, // 11: proto.S2C_PlayerInitInfo.Quests:type_name -> proto.Quest
-	28, // 12: proto.S2C_PlayerInitInfo.Tokens:type_name -> proto.Token
-	29, // 13: proto.S2C_PlayerInitInfo.Towers:type_name -> proto.Tower
-	25, // 14: proto.S2C_ChapterUpdate.Chapter:type_name -> proto.Chapter
-	26, // 15: proto.S2C_StageUpdate.Stage:type_name -> proto.Stage
-	29, // 16: proto.S2C_TowerUpdate.Tower:type_name -> proto.Tower
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	28, // 0: proto.S2C_CreatePlayer.info:type_name -> proto.PlayerInfo
+	28, // 1: proto.S2C_PlayerInitInfo.Info:type_name -> proto.PlayerInfo
+	29, // 2: proto.S2C_PlayerInitInfo.Heros:type_name -> proto.Hero
+	30, // 3: proto.S2C_PlayerInitInfo.Items:type_name -> proto.Item
+	31, // 4: proto.S2C_PlayerInitInfo.Equips:type_name -> proto.Equip
+	32, // 5: proto.S2C_PlayerInitInfo.Crystals:type_name -> proto.Crystal
+	33, // 6: proto.S2C_PlayerInitInfo.Collections:type_name -> proto.Collection
+	34, // 7: proto.S2C_PlayerInitInfo.HeroFrags:type_name -> proto.Fragment
+	34, // 8: proto.S2C_PlayerInitInfo.CollectionFrags:type_name -> proto.Fragment
+	35, // 9: proto.S2C_PlayerInitInfo.Chapters:type_name -> proto.Chapter
+	36, // 10: proto.S2C_PlayerInitInfo.Stages:type_name -> proto.Stage
+	37, // 11: proto.S2C_PlayerInitInfo.Quests:type_name -> proto.Quest
+	38, // 12: proto.S2C_PlayerInitInfo.Tokens:type_name -> proto.Token
+	39, // 13: proto.S2C_PlayerInitInfo.Towers:type_name -> proto.Tower
+	40, // 14: proto.C2S_SaveBattleArray.Heroes:type_name -> proto.BattleArrayHero
+	41, // 15: proto.S2C_SaveBattleArray.Array:type_name -> proto.BattleArray
+	35, // 16: proto.S2C_ChapterUpdate.Chapter:type_name -> proto.Chapter
+	36, // 17: proto.S2C_StageUpdate.Stage:type_name -> proto.Stage
+	39, // 18: proto.S2C_TowerUpdate.Tower:type_name -> proto.Tower
+	42, // 19: proto.S2C_QueryTowerRank.Metadatas:type_name -> proto.RankMetadata
+	43, // 20: proto.S2C_ArenaMatch.Opponents:type_name -> proto.ArenaOpponent
+	44, // 21: proto.S2C_ArenaLogs.Logs:type_name -> proto.ArenaLog
+	22, // [22:22] is the sub-list for method output_type
+	22, // [22:22] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_player_proto_init() }
@@ -1452,6 +2067,126 @@ func file_player_proto_init() {
 			}
 		}
 		file_player_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*C2S_QueryTowerRank); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_player_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*S2C_QueryTowerRank); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_player_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*C2S_ArenaInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_player_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*S2C_ArenaInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_player_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*C2S_ArenaMatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_player_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*S2C_ArenaMatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_player_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*C2S_ArenaChallenge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_player_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*S2C_ArenaChallenge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_player_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*C2S_ArenaLogs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_player_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*S2C_ArenaLogs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_player_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*C2S_GmCmd); i {
 			case 0:
 				return &v.state
@@ -1470,7 +2205,7 @@ func file_player_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_player_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
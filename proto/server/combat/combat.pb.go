@@ -90,8 +90,11 @@ type StageCombatRs struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Win       bool   `protobuf:"varint,1,opt,name=Win,proto3" json:"Win,omitempty"`                    // 战斗结果
-	Objective []bool `protobuf:"varint,2,rep,packed,name=Objective,proto3" json:"Objective,omitempty"` // 关卡条件达成
+	Win        bool                     `protobuf:"varint,1,opt,name=Win,proto3" json:"Win,omitempty"`                    // 战斗结果
+	Objective  []bool                   `protobuf:"varint,2,rep,packed,name=Objective,proto3" json:"Objective,omitempty"` // 关卡条件达成
+	Statistics *global.CombatStatistics `protobuf:"bytes,3,opt,name=Statistics,proto3" json:"Statistics,omitempty"`       // 战斗统计
+	RecordId   int64                    `protobuf:"varint,4,opt,name=RecordId,proto3" json:"RecordId,omitempty"`          // 战斗录像id
+	Events     []*global.CombatEvent    `protobuf:"bytes,5,rep,name=Events,proto3" json:"Events,omitempty"`               // 战斗事件
 }
 
 func (x *StageCombatRs) Reset() {
@@ -140,6 +143,392 @@ func (x *StageCombatRs) GetObjective() []bool {
 	return nil
 }
 
+func (x *StageCombatRs) GetStatistics() *global.CombatStatistics {
+	if x != nil {
+		return x.Statistics
+	}
+	return nil
+}
+
+func (x *StageCombatRs) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+func (x *StageCombatRs) GetEvents() []*global.CombatEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// 爬塔战斗
+type TowerCombatRq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TowerType        int32                `protobuf:"varint,1,opt,name=TowerType,proto3" json:"TowerType,omitempty"`              // 塔类型
+	TowerFloor       int32                `protobuf:"varint,2,opt,name=TowerFloor,proto3" json:"TowerFloor,omitempty"`            // 层数
+	AttackId         int64                `protobuf:"varint,3,opt,name=AttackId,proto3" json:"AttackId,omitempty"`                // 进攻方id -- 玩家id
+	AttackEntityList []*global.EntityInfo `protobuf:"bytes,4,rep,name=AttackEntityList,proto3" json:"AttackEntityList,omitempty"` // 进攻方英雄信息
+}
+
+func (x *TowerCombatRq) Reset() {
+	*x = TowerCombatRq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_combat_combat_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TowerCombatRq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TowerCombatRq) ProtoMessage() {}
+
+func (x *TowerCombatRq) ProtoReflect() protoreflect.Message {
+	mi := &file_server_combat_combat_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TowerCombatRq.ProtoReflect.Descriptor instead.
+func (*TowerCombatRq) Descriptor() ([]byte, []int) {
+	return file_server_combat_combat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TowerCombatRq) GetTowerType() int32 {
+	if x != nil {
+		return x.TowerType
+	}
+	return 0
+}
+
+func (x *TowerCombatRq) GetTowerFloor() int32 {
+	if x != nil {
+		return x.TowerFloor
+	}
+	return 0
+}
+
+func (x *TowerCombatRq) GetAttackId() int64 {
+	if x != nil {
+		return x.AttackId
+	}
+	return 0
+}
+
+func (x *TowerCombatRq) GetAttackEntityList() []*global.EntityInfo {
+	if x != nil {
+		return x.AttackEntityList
+	}
+	return nil
+}
+
+type TowerCombatRs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Win        bool                     `protobuf:"varint,1,opt,name=Win,proto3" json:"Win,omitempty"`              // 战斗结果
+	Statistics *global.CombatStatistics `protobuf:"bytes,2,opt,name=Statistics,proto3" json:"Statistics,omitempty"` // 战斗统计
+	RecordId   int64                    `protobuf:"varint,3,opt,name=RecordId,proto3" json:"RecordId,omitempty"`    // 战斗录像id
+}
+
+func (x *TowerCombatRs) Reset() {
+	*x = TowerCombatRs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_combat_combat_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TowerCombatRs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TowerCombatRs) ProtoMessage() {}
+
+func (x *TowerCombatRs) ProtoReflect() protoreflect.Message {
+	mi := &file_server_combat_combat_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TowerCombatRs.ProtoReflect.Descriptor instead.
+func (*TowerCombatRs) Descriptor() ([]byte, []int) {
+	return file_server_combat_combat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TowerCombatRs) GetWin() bool {
+	if x != nil {
+		return x.Win
+	}
+	return false
+}
+
+func (x *TowerCombatRs) GetStatistics() *global.CombatStatistics {
+	if x != nil {
+		return x.Statistics
+	}
+	return nil
+}
+
+func (x *TowerCombatRs) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+// 竞技场战斗, 防守方为防守阵容快照
+type ArenaCombatRq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AttackId          int64                `protobuf:"varint,1,opt,name=AttackId,proto3" json:"AttackId,omitempty"`                  // 进攻方id -- 玩家id
+	AttackEntityList  []*global.EntityInfo `protobuf:"bytes,2,rep,name=AttackEntityList,proto3" json:"AttackEntityList,omitempty"`   // 进攻方英雄信息
+	DefenceId         int64                `protobuf:"varint,3,opt,name=DefenceId,proto3" json:"DefenceId,omitempty"`                // 防守方id -- 玩家id
+	DefenceEntityList []*global.EntityInfo `protobuf:"bytes,4,rep,name=DefenceEntityList,proto3" json:"DefenceEntityList,omitempty"` // 防守方英雄信息
+}
+
+func (x *ArenaCombatRq) Reset() {
+	*x = ArenaCombatRq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_combat_combat_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArenaCombatRq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArenaCombatRq) ProtoMessage() {}
+
+func (x *ArenaCombatRq) ProtoReflect() protoreflect.Message {
+	mi := &file_server_combat_combat_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArenaCombatRq.ProtoReflect.Descriptor instead.
+func (*ArenaCombatRq) Descriptor() ([]byte, []int) {
+	return file_server_combat_combat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ArenaCombatRq) GetAttackId() int64 {
+	if x != nil {
+		return x.AttackId
+	}
+	return 0
+}
+
+func (x *ArenaCombatRq) GetAttackEntityList() []*global.EntityInfo {
+	if x != nil {
+		return x.AttackEntityList
+	}
+	return nil
+}
+
+func (x *ArenaCombatRq) GetDefenceId() int64 {
+	if x != nil {
+		return x.DefenceId
+	}
+	return 0
+}
+
+func (x *ArenaCombatRq) GetDefenceEntityList() []*global.EntityInfo {
+	if x != nil {
+		return x.DefenceEntityList
+	}
+	return nil
+}
+
+type ArenaCombatRs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Win        bool                     `protobuf:"varint,1,opt,name=Win,proto3" json:"Win,omitempty"`              // 战斗结果
+	Statistics *global.CombatStatistics `protobuf:"bytes,2,opt,name=Statistics,proto3" json:"Statistics,omitempty"` // 战斗统计
+	RecordId   int64                    `protobuf:"varint,3,opt,name=RecordId,proto3" json:"RecordId,omitempty"`    // 战斗录像id
+}
+
+func (x *ArenaCombatRs) Reset() {
+	*x = ArenaCombatRs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_combat_combat_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArenaCombatRs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArenaCombatRs) ProtoMessage() {}
+
+func (x *ArenaCombatRs) ProtoReflect() protoreflect.Message {
+	mi := &file_server_combat_combat_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArenaCombatRs.ProtoReflect.Descriptor instead.
+func (*ArenaCombatRs) Descriptor() ([]byte, []int) {
+	return file_server_combat_combat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ArenaCombatRs) GetWin() bool {
+	if x != nil {
+		return x.Win
+	}
+	return false
+}
+
+func (x *ArenaCombatRs) GetStatistics() *global.CombatStatistics {
+	if x != nil {
+		return x.Statistics
+	}
+	return nil
+}
+
+func (x *ArenaCombatRs) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+// 查询战斗录像
+type QueryCombatRecordRq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecordId int64 `protobuf:"varint,1,opt,name=RecordId,proto3" json:"RecordId,omitempty"`
+}
+
+func (x *QueryCombatRecordRq) Reset() {
+	*x = QueryCombatRecordRq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_combat_combat_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryCombatRecordRq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCombatRecordRq) ProtoMessage() {}
+
+func (x *QueryCombatRecordRq) ProtoReflect() protoreflect.Message {
+	mi := &file_server_combat_combat_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCombatRecordRq.ProtoReflect.Descriptor instead.
+func (*QueryCombatRecordRq) Descriptor() ([]byte, []int) {
+	return file_server_combat_combat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *QueryCombatRecordRq) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+type QueryCombatRecordRs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Record *global.CombatRecord `protobuf:"bytes,1,opt,name=Record,proto3" json:"Record,omitempty"`
+}
+
+func (x *QueryCombatRecordRs) Reset() {
+	*x = QueryCombatRecordRs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_combat_combat_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryCombatRecordRs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCombatRecordRs) ProtoMessage() {}
+
+func (x *QueryCombatRecordRs) ProtoReflect() protoreflect.Message {
+	mi := &file_server_combat_combat_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCombatRecordRs.ProtoReflect.Descriptor instead.
+func (*QueryCombatRecordRs) Descriptor() ([]byte, []int) {
+	return file_server_combat_combat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *QueryCombatRecordRs) GetRecord() *global.CombatRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
 var File_server_combat_combat_proto protoreflect.FileDescriptor
 
 var file_server_combat_combat_proto_rawDesc = []byte{
@@ -155,15 +544,82 @@ var file_server_combat_combat_proto_rawDesc = []byte{
 	0x79, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x10,
 	0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73, 0x74,
-	0x22, 0x3f, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52,
-	0x73, 0x12, 0x10, 0x0a, 0x03, 0x57, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03,
-	0x57, 0x69, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x08, 0x52, 0x09, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76,
-	0x65, 0x32, 0x4e, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x62, 0x61,
-	0x74, 0x12, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65,
+	0x22, 0xc0, 0x01, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74,
+	0x52, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x57, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x03, 0x57, 0x69, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x08, 0x52, 0x09, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x12, 0x37, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x6f, 0x6d, 0x62, 0x61, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52,
+	0x0a, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x06, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x22, 0xa8, 0x01, 0x0a, 0x0d, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x43, 0x6f, 0x6d,
+	0x62, 0x61, 0x74, 0x52, 0x71, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x46, 0x6c, 0x6f, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x46, 0x6c,
+	0x6f, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x49, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x49, 0x64, 0x12,
+	0x3d, 0x0a, 0x10, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c,
+	0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x10, 0x41, 0x74,
+	0x74, 0x61, 0x63, 0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x76,
+	0x0a, 0x0d, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x57, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x57, 0x69,
+	0x6e, 0x12, 0x37, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
+	0x6d, 0x62, 0x61, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x0a,
+	0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x22, 0xc9, 0x01, 0x0a, 0x0d, 0x41, 0x72, 0x65, 0x6e, 0x61,
+	0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x74, 0x74, 0x61,
+	0x63, 0x6b, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x41, 0x74, 0x74, 0x61,
+	0x63, 0x6b, 0x49, 0x64, 0x12, 0x3d, 0x0a, 0x10, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x45, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x10, 0x41, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x49,
+	0x64, 0x12, 0x3f, 0x0a, 0x11, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x11, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c, 0x69,
+	0x73, 0x74, 0x22, 0x76, 0x0a, 0x0d, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x43, 0x6f, 0x6d, 0x62, 0x61,
+	0x74, 0x52, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x57, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x03, 0x57, 0x69, 0x6e, 0x12, 0x37, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x52, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x22, 0x31, 0x0a, 0x13, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x71, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x22, 0x42, 0x0a,
+	0x13, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x52, 0x73, 0x12, 0x2b, 0x0a, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d,
+	0x62, 0x61, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x32, 0x9d, 0x02, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x62,
+	0x61, 0x74, 0x12, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x67,
+	0x65, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x71, 0x1a, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x62,
+	0x61, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x73,
+	0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0b, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x62, 0x61,
+	0x74, 0x12, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x2e, 0x54, 0x6f, 0x77, 0x65, 0x72,
 	0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x71, 0x1a, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x62, 0x61,
-	0x74, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x73, 0x22,
+	0x74, 0x2e, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x73, 0x22,
+	0x00, 0x12, 0x3d, 0x0a, 0x0b, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74,
+	0x12, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x2e, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x43,
+	0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x71, 0x1a, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74,
+	0x2e, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x73, 0x22, 0x00,
+	0x12, 0x4f, 0x0a, 0x11, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x52, 0x71, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x43, 0x6f, 0x6d, 0x62, 0x61, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x73, 0x22,
 	0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
 	0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65, 0x6e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x63, 0x6f,
@@ -182,21 +638,44 @@ func file_server_combat_combat_proto_rawDescGZIP() []byte {
 	return file_server_combat_combat_proto_rawDescData
 }
 
-var file_server_combat_combat_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_server_combat_combat_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_server_combat_combat_proto_goTypes = []interface{}{
-	(*StageCombatRq)(nil),     // 0: combat.StageCombatRq
-	(*StageCombatRs)(nil),     // 1: combat.StageCombatRs
-	(*global.EntityInfo)(nil), // 2: proto.EntityInfo
+	(*StageCombatRq)(nil),           // 0: combat.StageCombatRq
+	(*StageCombatRs)(nil),           // 1: combat.StageCombatRs
+	(*TowerCombatRq)(nil),           // 2: combat.TowerCombatRq
+	(*TowerCombatRs)(nil),           // 3: combat.TowerCombatRs
+	(*ArenaCombatRq)(nil),           // 4: combat.ArenaCombatRq
+	(*ArenaCombatRs)(nil),           // 5: combat.ArenaCombatRs
+	(*QueryCombatRecordRq)(nil),     // 6: combat.QueryCombatRecordRq
+	(*QueryCombatRecordRs)(nil),     // 7: combat.QueryCombatRecordRs
+	(*global.EntityInfo)(nil),       // 8: proto.EntityInfo
+	(*global.CombatStatistics)(nil), // 9: proto.CombatStatistics
+	(*global.CombatEvent)(nil),      // 10: proto.CombatEvent
+	(*global.CombatRecord)(nil),     // 11: proto.CombatRecord
 }
 var file_server_combat_combat_proto_depIdxs = []int32{
-	2, // 0: combat.StageCombatRq.AttackEntityList:type_name -> proto.EntityInfo
-	0, // 1: combat.CombatService.StageCombat:input_type -> combat.StageCombatRq
-	1, // 2: combat.CombatService.StageCombat:output_type -> combat.StageCombatRs
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	8,  // 0: combat.StageCombatRq.AttackEntityList:type_name -> proto.EntityInfo
+	9,  // 1: combat.StageCombatRs.Statistics:type_name -> proto.CombatStatistics
+	10, // 2: combat.StageCombatRs.Events:type_name -> proto.CombatEvent
+	8,  // 3: combat.TowerCombatRq.AttackEntityList:type_name -> proto.EntityInfo
+	9,  // 4: combat.TowerCombatRs.Statistics:type_name -> proto.CombatStatistics
+	8,  // 5: combat.ArenaCombatRq.AttackEntityList:type_name -> proto.EntityInfo
+	8,  // 6: combat.ArenaCombatRq.DefenceEntityList:type_name -> proto.EntityInfo
+	9,  // 7: combat.ArenaCombatRs.Statistics:type_name -> proto.CombatStatistics
+	11, // 8: combat.QueryCombatRecordRs.Record:type_name -> proto.CombatRecord
+	0,  // 9: combat.CombatService.StageCombat:input_type -> combat.StageCombatRq
+	2,  // 10: combat.CombatService.TowerCombat:input_type -> combat.TowerCombatRq
+	4,  // 11: combat.CombatService.ArenaCombat:input_type -> combat.ArenaCombatRq
+	6,  // 12: combat.CombatService.QueryCombatRecord:input_type -> combat.QueryCombatRecordRq
+	1,  // 13: combat.CombatService.StageCombat:output_type -> combat.StageCombatRs
+	3,  // 14: combat.CombatService.TowerCombat:output_type -> combat.TowerCombatRs
+	5,  // 15: combat.CombatService.ArenaCombat:output_type -> combat.ArenaCombatRs
+	7,  // 16: combat.CombatService.QueryCombatRecord:output_type -> combat.QueryCombatRecordRs
+	13, // [13:17] is the sub-list for method output_type
+	9,  // [9:13] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_server_combat_combat_proto_init() }
@@ -229,6 +708,78 @@ func file_server_combat_combat_proto_init() {
 				return nil
 			}
 		}
+		file_server_combat_combat_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TowerCombatRq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_combat_combat_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TowerCombatRs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_combat_combat_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArenaCombatRq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_combat_combat_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArenaCombatRs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_combat_combat_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryCombatRecordRq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_combat_combat_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryCombatRecordRs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -236,7 +787,7 @@ func file_server_combat_combat_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_server_combat_combat_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -38,6 +38,9 @@ func NewCombatServiceEndpoints() []*api.Endpoint {
 
 type CombatService interface {
 	StageCombat(ctx context.Context, in *StageCombatRq, opts ...client.CallOption) (*StageCombatRs, error)
+	TowerCombat(ctx context.Context, in *TowerCombatRq, opts ...client.CallOption) (*TowerCombatRs, error)
+	ArenaCombat(ctx context.Context, in *ArenaCombatRq, opts ...client.CallOption) (*ArenaCombatRs, error)
+	QueryCombatRecord(ctx context.Context, in *QueryCombatRecordRq, opts ...client.CallOption) (*QueryCombatRecordRs, error)
 }
 
 type combatService struct {
@@ -62,15 +65,51 @@ func (c *combatService) StageCombat(ctx context.Context, in *StageCombatRq, opts
 	return out, nil
 }
 
+func (c *combatService) TowerCombat(ctx context.Context, in *TowerCombatRq, opts ...client.CallOption) (*TowerCombatRs, error) {
+	req := c.c.NewRequest(c.name, "CombatService.TowerCombat", in)
+	out := new(TowerCombatRs)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *combatService) ArenaCombat(ctx context.Context, in *ArenaCombatRq, opts ...client.CallOption) (*ArenaCombatRs, error) {
+	req := c.c.NewRequest(c.name, "CombatService.ArenaCombat", in)
+	out := new(ArenaCombatRs)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *combatService) QueryCombatRecord(ctx context.Context, in *QueryCombatRecordRq, opts ...client.CallOption) (*QueryCombatRecordRs, error) {
+	req := c.c.NewRequest(c.name, "CombatService.QueryCombatRecord", in)
+	out := new(QueryCombatRecordRs)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for CombatService service
 
 type CombatServiceHandler interface {
 	StageCombat(context.Context, *StageCombatRq, *StageCombatRs) error
+	TowerCombat(context.Context, *TowerCombatRq, *TowerCombatRs) error
+	ArenaCombat(context.Context, *ArenaCombatRq, *ArenaCombatRs) error
+	QueryCombatRecord(context.Context, *QueryCombatRecordRq, *QueryCombatRecordRs) error
 }
 
 func RegisterCombatServiceHandler(s server.Server, hdlr CombatServiceHandler, opts ...server.HandlerOption) error {
 	type combatService interface {
 		StageCombat(ctx context.Context, in *StageCombatRq, out *StageCombatRs) error
+		TowerCombat(ctx context.Context, in *TowerCombatRq, out *TowerCombatRs) error
+		ArenaCombat(ctx context.Context, in *ArenaCombatRq, out *ArenaCombatRs) error
+		QueryCombatRecord(ctx context.Context, in *QueryCombatRecordRq, out *QueryCombatRecordRs) error
 	}
 	type CombatService struct {
 		combatService
@@ -86,3 +125,15 @@ type combatServiceHandler struct {
 func (h *combatServiceHandler) StageCombat(ctx context.Context, in *StageCombatRq, out *StageCombatRs) error {
 	return h.CombatServiceHandler.StageCombat(ctx, in, out)
 }
+
+func (h *combatServiceHandler) TowerCombat(ctx context.Context, in *TowerCombatRq, out *TowerCombatRs) error {
+	return h.CombatServiceHandler.TowerCombat(ctx, in, out)
+}
+
+func (h *combatServiceHandler) ArenaCombat(ctx context.Context, in *ArenaCombatRq, out *ArenaCombatRs) error {
+	return h.CombatServiceHandler.ArenaCombat(ctx, in, out)
+}
+
+func (h *combatServiceHandler) QueryCombatRecord(ctx context.Context, in *QueryCombatRecordRq, out *QueryCombatRecordRs) error {
+	return h.CombatServiceHandler.QueryCombatRecord(ctx, in, out)
+}
@@ -27,7 +27,9 @@ type QueryCommentTopicRq struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Topic *global.CommentTopic `protobuf:"bytes,1,opt,name=Topic,proto3" json:"Topic,omitempty"`
+	Topic     *global.CommentTopic `protobuf:"bytes,1,opt,name=Topic,proto3" json:"Topic,omitempty"`
+	Moderator bool                 `protobuf:"varint,2,opt,name=Moderator,proto3" json:"Moderator,omitempty"` // 管理员可以查看已删除和隐藏的评论
+	ViewerId  int64                `protobuf:"varint,3,opt,name=ViewerId,proto3" json:"ViewerId,omitempty"`   // 查询者玩家id, 用于返回是否点过赞
 }
 
 func (x *QueryCommentTopicRq) Reset() {
@@ -69,6 +71,20 @@ func (x *QueryCommentTopicRq) GetTopic() *global.CommentTopic {
 	return nil
 }
 
+func (x *QueryCommentTopicRq) GetModerator() bool {
+	if x != nil {
+		return x.Moderator
+	}
+	return false
+}
+
+func (x *QueryCommentTopicRq) GetViewerId() int64 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
 type QueryCommentTopicRs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -122,9 +138,11 @@ type QueryCommentTopicRangeRq struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Topic *global.CommentTopic `protobuf:"bytes,1,opt,name=Topic,proto3" json:"Topic,omitempty"`
-	Start int64                `protobuf:"varint,2,opt,name=Start,proto3" json:"Start,omitempty"`
-	End   int64                `protobuf:"varint,3,opt,name=End,proto3" json:"End,omitempty"` // End == -1时代表查询所有数据
+	Topic     *global.CommentTopic `protobuf:"bytes,1,opt,name=Topic,proto3" json:"Topic,omitempty"`
+	Start     int64                `protobuf:"varint,2,opt,name=Start,proto3" json:"Start,omitempty"`
+	End       int64                `protobuf:"varint,3,opt,name=End,proto3" json:"End,omitempty"`             // End == -1时代表查询所有数据
+	Moderator bool                 `protobuf:"varint,4,opt,name=Moderator,proto3" json:"Moderator,omitempty"` // 管理员可以查看已删除和隐藏的评论
+	ViewerId  int64                `protobuf:"varint,5,opt,name=ViewerId,proto3" json:"ViewerId,omitempty"`   // 查询者玩家id, 用于返回是否点过赞
 }
 
 func (x *QueryCommentTopicRangeRq) Reset() {
@@ -180,6 +198,20 @@ func (x *QueryCommentTopicRangeRq) GetEnd() int64 {
 	return 0
 }
 
+func (x *QueryCommentTopicRangeRq) GetModerator() bool {
+	if x != nil {
+		return x.Moderator
+	}
+	return false
+}
+
+func (x *QueryCommentTopicRangeRq) GetViewerId() int64 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
 type QueryCommentTopicRangeRs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -251,19 +283,20 @@ func (x *QueryCommentTopicRangeRs) GetMetadatas() []*global.CommentMetadata {
 	return nil
 }
 
-// 修改按赞数
-type ModCommentThumbsRq struct {
+// 点赞或取消点赞, 每个玩家对每条评论只能点赞一次
+type ThumbsCommentRq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Topic     *global.CommentTopic `protobuf:"bytes,1,opt,name=Topic,proto3" json:"Topic,omitempty"`
 	CommentId int64                `protobuf:"varint,2,opt,name=CommentId,proto3" json:"CommentId,omitempty"` // 评论唯一id
-	ModThumbs int32                `protobuf:"varint,3,opt,name=ModThumbs,proto3" json:"ModThumbs,omitempty"`
+	PlayerId  int64                `protobuf:"varint,3,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`   // 点赞玩家id
+	Like      bool                 `protobuf:"varint,4,opt,name=Like,proto3" json:"Like,omitempty"`           // true点赞, false取消点赞
 }
 
-func (x *ModCommentThumbsRq) Reset() {
-	*x = ModCommentThumbsRq{}
+func (x *ThumbsCommentRq) Reset() {
+	*x = ThumbsCommentRq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_server_comment_comment_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -271,13 +304,13 @@ func (x *ModCommentThumbsRq) Reset() {
 	}
 }
 
-func (x *ModCommentThumbsRq) String() string {
+func (x *ThumbsCommentRq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModCommentThumbsRq) ProtoMessage() {}
+func (*ThumbsCommentRq) ProtoMessage() {}
 
-func (x *ModCommentThumbsRq) ProtoReflect() protoreflect.Message {
+func (x *ThumbsCommentRq) ProtoReflect() protoreflect.Message {
 	mi := &file_server_comment_comment_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -289,42 +322,51 @@ func (x *ModCommentThumbsRq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModCommentThumbsRq.ProtoReflect.Descriptor instead.
-func (*ModCommentThumbsRq) Descriptor() ([]byte, []int) {
+// Deprecated: Use ThumbsCommentRq.ProtoReflect.Descriptor instead.
+func (*ThumbsCommentRq) Descriptor() ([]byte, []int) {
 	return file_server_comment_comment_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ModCommentThumbsRq) GetTopic() *global.CommentTopic {
+func (x *ThumbsCommentRq) GetTopic() *global.CommentTopic {
 	if x != nil {
 		return x.Topic
 	}
 	return nil
 }
 
-func (x *ModCommentThumbsRq) GetCommentId() int64 {
+func (x *ThumbsCommentRq) GetCommentId() int64 {
 	if x != nil {
 		return x.CommentId
 	}
 	return 0
 }
 
-func (x *ModCommentThumbsRq) GetModThumbs() int32 {
+func (x *ThumbsCommentRq) GetPlayerId() int64 {
 	if x != nil {
-		return x.ModThumbs
+		return x.PlayerId
 	}
 	return 0
 }
 
-type ModCommentThumbsRs struct {
+func (x *ThumbsCommentRq) GetLike() bool {
+	if x != nil {
+		return x.Like
+	}
+	return false
+}
+
+type ThumbsCommentRs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Error string `protobuf:"bytes,1,opt,name=Error,proto3" json:"Error,omitempty"`
+	CommentId int64 `protobuf:"varint,1,opt,name=CommentId,proto3" json:"CommentId,omitempty"`
+	Thumbs    int32 `protobuf:"varint,2,opt,name=Thumbs,proto3" json:"Thumbs,omitempty"` // 当前点赞数
+	Liked     bool  `protobuf:"varint,3,opt,name=Liked,proto3" json:"Liked,omitempty"`   // 操作后是否点过赞
 }
 
-func (x *ModCommentThumbsRs) Reset() {
-	*x = ModCommentThumbsRs{}
+func (x *ThumbsCommentRs) Reset() {
+	*x = ThumbsCommentRs{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_server_comment_comment_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -332,13 +374,13 @@ func (x *ModCommentThumbsRs) Reset() {
 	}
 }
 
-func (x *ModCommentThumbsRs) String() string {
+func (x *ThumbsCommentRs) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModCommentThumbsRs) ProtoMessage() {}
+func (*ThumbsCommentRs) ProtoMessage() {}
 
-func (x *ModCommentThumbsRs) ProtoReflect() protoreflect.Message {
+func (x *ThumbsCommentRs) ProtoReflect() protoreflect.Message {
 	mi := &file_server_comment_comment_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -350,16 +392,522 @@ func (x *ModCommentThumbsRs) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModCommentThumbsRs.ProtoReflect.Descriptor instead.
-func (*ModCommentThumbsRs) Descriptor() ([]byte, []int) {
+// Deprecated: Use ThumbsCommentRs.ProtoReflect.Descriptor instead.
+func (*ThumbsCommentRs) Descriptor() ([]byte, []int) {
 	return file_server_comment_comment_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ModCommentThumbsRs) GetError() string {
+func (x *ThumbsCommentRs) GetCommentId() int64 {
 	if x != nil {
-		return x.Error
+		return x.CommentId
 	}
-	return ""
+	return 0
+}
+
+func (x *ThumbsCommentRs) GetThumbs() int32 {
+	if x != nil {
+		return x.Thumbs
+	}
+	return 0
+}
+
+func (x *ThumbsCommentRs) GetLiked() bool {
+	if x != nil {
+		return x.Liked
+	}
+	return false
+}
+
+// 发表评论
+type PublishCommentRq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topic     *global.CommentTopic      `protobuf:"bytes,1,opt,name=Topic,proto3" json:"Topic,omitempty"`
+	Publisher *global.PublisherMetadata `protobuf:"bytes,2,opt,name=Publisher,proto3" json:"Publisher,omitempty"` // 发表者元数据
+}
+
+func (x *PublishCommentRq) Reset() {
+	*x = PublishCommentRq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_comment_comment_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublishCommentRq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishCommentRq) ProtoMessage() {}
+
+func (x *PublishCommentRq) ProtoReflect() protoreflect.Message {
+	mi := &file_server_comment_comment_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishCommentRq.ProtoReflect.Descriptor instead.
+func (*PublishCommentRq) Descriptor() ([]byte, []int) {
+	return file_server_comment_comment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PublishCommentRq) GetTopic() *global.CommentTopic {
+	if x != nil {
+		return x.Topic
+	}
+	return nil
+}
+
+func (x *PublishCommentRq) GetPublisher() *global.PublisherMetadata {
+	if x != nil {
+		return x.Publisher
+	}
+	return nil
+}
+
+type PublishCommentRs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *global.CommentMetadata `protobuf:"bytes,1,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+}
+
+func (x *PublishCommentRs) Reset() {
+	*x = PublishCommentRs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_comment_comment_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublishCommentRs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishCommentRs) ProtoMessage() {}
+
+func (x *PublishCommentRs) ProtoReflect() protoreflect.Message {
+	mi := &file_server_comment_comment_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishCommentRs.ProtoReflect.Descriptor instead.
+func (*PublishCommentRs) Descriptor() ([]byte, []int) {
+	return file_server_comment_comment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PublishCommentRs) GetMetadata() *global.CommentMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// 回复评论, 回复保存在被回复的评论下
+type ReplyCommentRq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topic     *global.CommentTopic      `protobuf:"bytes,1,opt,name=Topic,proto3" json:"Topic,omitempty"`
+	CommentId int64                     `protobuf:"varint,2,opt,name=CommentId,proto3" json:"CommentId,omitempty"` // 回复的评论id
+	Publisher *global.PublisherMetadata `protobuf:"bytes,3,opt,name=Publisher,proto3" json:"Publisher,omitempty"`  // 回复者元数据, ReplyToId为0时回复评论发表者
+}
+
+func (x *ReplyCommentRq) Reset() {
+	*x = ReplyCommentRq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_comment_comment_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplyCommentRq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplyCommentRq) ProtoMessage() {}
+
+func (x *ReplyCommentRq) ProtoReflect() protoreflect.Message {
+	mi := &file_server_comment_comment_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplyCommentRq.ProtoReflect.Descriptor instead.
+func (*ReplyCommentRq) Descriptor() ([]byte, []int) {
+	return file_server_comment_comment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReplyCommentRq) GetTopic() *global.CommentTopic {
+	if x != nil {
+		return x.Topic
+	}
+	return nil
+}
+
+func (x *ReplyCommentRq) GetCommentId() int64 {
+	if x != nil {
+		return x.CommentId
+	}
+	return 0
+}
+
+func (x *ReplyCommentRq) GetPublisher() *global.PublisherMetadata {
+	if x != nil {
+		return x.Publisher
+	}
+	return nil
+}
+
+type ReplyCommentRs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommentId int64                   `protobuf:"varint,1,opt,name=CommentId,proto3" json:"CommentId,omitempty"`
+	Reply     *global.ReplyerMetadata `protobuf:"bytes,2,opt,name=Reply,proto3" json:"Reply,omitempty"`
+}
+
+func (x *ReplyCommentRs) Reset() {
+	*x = ReplyCommentRs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_comment_comment_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplyCommentRs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplyCommentRs) ProtoMessage() {}
+
+func (x *ReplyCommentRs) ProtoReflect() protoreflect.Message {
+	mi := &file_server_comment_comment_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplyCommentRs.ProtoReflect.Descriptor instead.
+func (*ReplyCommentRs) Descriptor() ([]byte, []int) {
+	return file_server_comment_comment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReplyCommentRs) GetCommentId() int64 {
+	if x != nil {
+		return x.CommentId
+	}
+	return 0
+}
+
+func (x *ReplyCommentRs) GetReply() *global.ReplyerMetadata {
+	if x != nil {
+		return x.Reply
+	}
+	return nil
+}
+
+// 删除评论或回复
+type DeleteCommentRq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topic      *global.CommentTopic `protobuf:"bytes,1,opt,name=Topic,proto3" json:"Topic,omitempty"`
+	CommentId  int64                `protobuf:"varint,2,opt,name=CommentId,proto3" json:"CommentId,omitempty"`   // 评论id
+	ReplyId    int64                `protobuf:"varint,3,opt,name=ReplyId,proto3" json:"ReplyId,omitempty"`       // 回复id, 为0时删除评论
+	OperatorId int64                `protobuf:"varint,4,opt,name=OperatorId,proto3" json:"OperatorId,omitempty"` // 操作者玩家id, 非管理员只能删除自己发表的评论
+	Moderator  bool                 `protobuf:"varint,5,opt,name=Moderator,proto3" json:"Moderator,omitempty"`   // 是否管理员操作
+}
+
+func (x *DeleteCommentRq) Reset() {
+	*x = DeleteCommentRq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_comment_comment_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCommentRq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentRq) ProtoMessage() {}
+
+func (x *DeleteCommentRq) ProtoReflect() protoreflect.Message {
+	mi := &file_server_comment_comment_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentRq.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRq) Descriptor() ([]byte, []int) {
+	return file_server_comment_comment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteCommentRq) GetTopic() *global.CommentTopic {
+	if x != nil {
+		return x.Topic
+	}
+	return nil
+}
+
+func (x *DeleteCommentRq) GetCommentId() int64 {
+	if x != nil {
+		return x.CommentId
+	}
+	return 0
+}
+
+func (x *DeleteCommentRq) GetReplyId() int64 {
+	if x != nil {
+		return x.ReplyId
+	}
+	return 0
+}
+
+func (x *DeleteCommentRq) GetOperatorId() int64 {
+	if x != nil {
+		return x.OperatorId
+	}
+	return 0
+}
+
+func (x *DeleteCommentRq) GetModerator() bool {
+	if x != nil {
+		return x.Moderator
+	}
+	return false
+}
+
+type DeleteCommentRs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommentId int64 `protobuf:"varint,1,opt,name=CommentId,proto3" json:"CommentId,omitempty"`
+	ReplyId   int64 `protobuf:"varint,2,opt,name=ReplyId,proto3" json:"ReplyId,omitempty"`
+}
+
+func (x *DeleteCommentRs) Reset() {
+	*x = DeleteCommentRs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_comment_comment_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCommentRs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentRs) ProtoMessage() {}
+
+func (x *DeleteCommentRs) ProtoReflect() protoreflect.Message {
+	mi := &file_server_comment_comment_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentRs.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRs) Descriptor() ([]byte, []int) {
+	return file_server_comment_comment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteCommentRs) GetCommentId() int64 {
+	if x != nil {
+		return x.CommentId
+	}
+	return 0
+}
+
+func (x *DeleteCommentRs) GetReplyId() int64 {
+	if x != nil {
+		return x.ReplyId
+	}
+	return 0
+}
+
+// 管理员隐藏或恢复评论
+type HideCommentRq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topic     *global.CommentTopic `protobuf:"bytes,1,opt,name=Topic,proto3" json:"Topic,omitempty"`
+	CommentId int64                `protobuf:"varint,2,opt,name=CommentId,proto3" json:"CommentId,omitempty"` // 评论id
+	ReplyId   int64                `protobuf:"varint,3,opt,name=ReplyId,proto3" json:"ReplyId,omitempty"`     // 回复id, 为0时隐藏评论
+	Hide      bool                 `protobuf:"varint,4,opt,name=Hide,proto3" json:"Hide,omitempty"`           // true隐藏, false恢复正常
+}
+
+func (x *HideCommentRq) Reset() {
+	*x = HideCommentRq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_comment_comment_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HideCommentRq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HideCommentRq) ProtoMessage() {}
+
+func (x *HideCommentRq) ProtoReflect() protoreflect.Message {
+	mi := &file_server_comment_comment_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HideCommentRq.ProtoReflect.Descriptor instead.
+func (*HideCommentRq) Descriptor() ([]byte, []int) {
+	return file_server_comment_comment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HideCommentRq) GetTopic() *global.CommentTopic {
+	if x != nil {
+		return x.Topic
+	}
+	return nil
+}
+
+func (x *HideCommentRq) GetCommentId() int64 {
+	if x != nil {
+		return x.CommentId
+	}
+	return 0
+}
+
+func (x *HideCommentRq) GetReplyId() int64 {
+	if x != nil {
+		return x.ReplyId
+	}
+	return 0
+}
+
+func (x *HideCommentRq) GetHide() bool {
+	if x != nil {
+		return x.Hide
+	}
+	return false
+}
+
+type HideCommentRs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommentId int64 `protobuf:"varint,1,opt,name=CommentId,proto3" json:"CommentId,omitempty"`
+	ReplyId   int64 `protobuf:"varint,2,opt,name=ReplyId,proto3" json:"ReplyId,omitempty"`
+	Status    int32 `protobuf:"varint,3,opt,name=Status,proto3" json:"Status,omitempty"` // 操作后的状态
+}
+
+func (x *HideCommentRs) Reset() {
+	*x = HideCommentRs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_comment_comment_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HideCommentRs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HideCommentRs) ProtoMessage() {}
+
+func (x *HideCommentRs) ProtoReflect() protoreflect.Message {
+	mi := &file_server_comment_comment_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HideCommentRs.ProtoReflect.Descriptor instead.
+func (*HideCommentRs) Descriptor() ([]byte, []int) {
+	return file_server_comment_comment_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *HideCommentRs) GetCommentId() int64 {
+	if x != nil {
+		return x.CommentId
+	}
+	return 0
+}
+
+func (x *HideCommentRs) GetReplyId() int64 {
+	if x != nil {
+		return x.ReplyId
+	}
+	return 0
+}
+
+func (x *HideCommentRs) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
 }
 
 // 踢掉其他节点评论缓存
@@ -375,7 +923,7 @@ type KickCommentTopicDataRq struct {
 func (x *KickCommentTopicDataRq) Reset() {
 	*x = KickCommentTopicDataRq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_comment_comment_proto_msgTypes[6]
+		mi := &file_server_comment_comment_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -388,7 +936,7 @@ func (x *KickCommentTopicDataRq) String() string {
 func (*KickCommentTopicDataRq) ProtoMessage() {}
 
 func (x *KickCommentTopicDataRq) ProtoReflect() protoreflect.Message {
-	mi := &file_server_comment_comment_proto_msgTypes[6]
+	mi := &file_server_comment_comment_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -401,7 +949,7 @@ func (x *KickCommentTopicDataRq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KickCommentTopicDataRq.ProtoReflect.Descriptor instead.
 func (*KickCommentTopicDataRq) Descriptor() ([]byte, []int) {
-	return file_server_comment_comment_proto_rawDescGZIP(), []int{6}
+	return file_server_comment_comment_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *KickCommentTopicDataRq) GetTopic() *global.CommentTopic {
@@ -430,7 +978,7 @@ type KickCommentTopicDataRs struct {
 func (x *KickCommentTopicDataRs) Reset() {
 	*x = KickCommentTopicDataRs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_comment_comment_proto_msgTypes[7]
+		mi := &file_server_comment_comment_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -443,7 +991,7 @@ func (x *KickCommentTopicDataRs) String() string {
 func (*KickCommentTopicDataRs) ProtoMessage() {}
 
 func (x *KickCommentTopicDataRs) ProtoReflect() protoreflect.Message {
-	mi := &file_server_comment_comment_proto_msgTypes[7]
+	mi := &file_server_comment_comment_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -456,7 +1004,7 @@ func (x *KickCommentTopicDataRs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KickCommentTopicDataRs.ProtoReflect.Descriptor instead.
 func (*KickCommentTopicDataRs) Descriptor() ([]byte, []int) {
-	return file_server_comment_comment_proto_rawDescGZIP(), []int{7}
+	return file_server_comment_comment_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *KickCommentTopicDataRs) GetTopic() *global.CommentTopic {
@@ -479,74 +1027,160 @@ var file_server_comment_comment_proto_rawDesc = []byte{
 	0x0a, 0x1c, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
 	0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07,
 	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x13, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x2f,
-	0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x40, 0x0a, 0x13,
+	0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x7a, 0x0a, 0x13,
 	0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69,
 	0x63, 0x52, 0x71, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x22, 0x4b,
-	0x0a, 0x13, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f,
-	0x70, 0x69, 0x63, 0x52, 0x73, 0x12, 0x34, 0x0a, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x52, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x22, 0x6d, 0x0a, 0x18, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63,
-	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x71, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
-	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70,
-	0x69, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x45, 0x6e, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x45, 0x6e, 0x64, 0x22, 0xa3, 0x01, 0x0a, 0x18, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63,
-	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x73, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
-	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70,
-	0x69, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x45, 0x6e, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x45, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x09, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73,
-	0x22, 0x7b, 0x0a, 0x12, 0x4d, 0x6f, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x68,
-	0x75, 0x6d, 0x62, 0x73, 0x52, 0x71, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x18,
+	0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c,
+	0x0a, 0x09, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08,
+	0x56, 0x69, 0x65, 0x77, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x56, 0x69, 0x65, 0x77, 0x65, 0x72, 0x49, 0x64, 0x22, 0x4b, 0x0a, 0x13, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x73, 0x12,
+	0x34, 0x0a, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x09, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x22, 0xa7, 0x01, 0x0a, 0x18, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x71, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x14, 0x0a,
+	0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x45, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x03, 0x45, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x69, 0x65, 0x77, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x56, 0x69, 0x65, 0x77, 0x65, 0x72, 0x49, 0x64, 0x22,
+	0xa3, 0x01, 0x0a, 0x18, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x73, 0x12, 0x29, 0x0a, 0x05,
+	0x54, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63,
+	0x52, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x45, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x45, 0x6e, 0x64, 0x12,
+	0x34, 0x0a, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x09, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x0f, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x71, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70,
+	0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54,
+	0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x4c, 0x69, 0x6b, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x4c, 0x69,
+	0x6b, 0x65, 0x22, 0x5d, 0x0a, 0x0f, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73, 0x43, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x4c,
+	0x69, 0x6b, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x4c, 0x69, 0x6b, 0x65,
+	0x64, 0x22, 0x75, 0x0a, 0x10, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x71, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63,
+	0x12, 0x36, 0x0a, 0x09, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x73, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x09, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x22, 0x46, 0x0a, 0x10, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x73, 0x68, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x73, 0x12, 0x32, 0x0a, 0x08,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x91, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x71, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c,
+	0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x09,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65,
+	0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x09, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x72, 0x22, 0x5c, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x05, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x05, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0xb2, 0x01, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x71, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
 	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70, 0x69,
 	0x63, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
-	0x1c, 0x0a, 0x09, 0x4d, 0x6f, 0x64, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x09, 0x4d, 0x6f, 0x64, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73, 0x22, 0x2a, 0x0a,
-	0x12, 0x4d, 0x6f, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x68, 0x75, 0x6d, 0x62,
-	0x73, 0x52, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x73, 0x0a, 0x16, 0x4b, 0x69, 0x63,
-	0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x44, 0x61, 0x74,
-	0x61, 0x52, 0x71, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x2e,
-	0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x4e, 0x6f,
-	0x64, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x43, 0x6f, 0x6d, 0x6d,
-	0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x22, 0x59,
-	0x0a, 0x16, 0x4b, 0x69, 0x63, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70,
-	0x69, 0x63, 0x44, 0x61, 0x74, 0x61, 0x52, 0x73, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69,
-	0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f,
-	0x70, 0x69, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x32, 0xf1, 0x02, 0x0a, 0x0e, 0x43, 0x6f,
-	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a, 0x11,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69,
-	0x63, 0x12, 0x1c, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x71, 0x1a,
-	0x1c, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43,
-	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x73, 0x22, 0x00, 0x12,
-	0x60, 0x0a, 0x16, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54,
-	0x6f, 0x70, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x21, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
-	0x65, 0x6e, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
-	0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x71, 0x1a, 0x21, 0x2e, 0x63,
+	0x18, 0x0a, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x4d, 0x6f, 0x64,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x4d, 0x6f,
+	0x64, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x49, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x49, 0x64, 0x22, 0x86, 0x01, 0x0a, 0x0d, 0x48, 0x69, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x71, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12,
+	0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x69, 0x64, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x48, 0x69, 0x64, 0x65, 0x22, 0x5f, 0x0a, 0x0d, 0x48,
+	0x69, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x73, 0x0a, 0x16,
+	0x4b, 0x69, 0x63, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x71, 0x12, 0x29, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x05, 0x54, 0x6f, 0x70, 0x69,
+	0x63, 0x12, 0x2e, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69,
+	0x63, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x4e, 0x6f, 0x64, 0x65, 0x49,
+	0x64, 0x22, 0x59, 0x0a, 0x16, 0x4b, 0x69, 0x63, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x54, 0x6f, 0x70, 0x69, 0x63, 0x44, 0x61, 0x74, 0x61, 0x52, 0x73, 0x12, 0x29, 0x0a, 0x05, 0x54,
+	0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52,
+	0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x32, 0xfe, 0x04, 0x0a,
+	0x0e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x51, 0x0a, 0x11, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54,
+	0x6f, 0x70, 0x69, 0x63, 0x12, 0x1c, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63,
+	0x52, 0x71, 0x1a, 0x1c, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x73,
+	0x22, 0x00, 0x12, 0x60, 0x0a, 0x16, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x21, 0x2e, 0x63,
 	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x6d,
-	0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x73, 0x22,
-	0x00, 0x12, 0x4e, 0x0a, 0x10, 0x4d, 0x6f, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54,
-	0x68, 0x75, 0x6d, 0x62, 0x73, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
-	0x4d, 0x6f, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73,
-	0x52, 0x71, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x6f, 0x64,
-	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73, 0x52, 0x73, 0x22,
+	0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x71, 0x1a,
+	0x21, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x73, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0d, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x54, 0x68, 0x75, 0x6d, 0x62, 0x73, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x71, 0x1a,
+	0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x73,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x73, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0e, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x71, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x73, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x71, 0x1a, 0x17,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x73, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0d, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x71, 0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x73, 0x22, 0x00,
+	0x12, 0x3f, 0x0a, 0x0b, 0x48, 0x69, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x16, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x48, 0x69, 0x64, 0x65, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x71, 0x1a, 0x16, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x2e, 0x48, 0x69, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x73, 0x22,
 	0x00, 0x12, 0x5a, 0x0a, 0x14, 0x4b, 0x69, 0x63, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
 	0x54, 0x6f, 0x70, 0x69, 0x63, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
 	0x65, 0x6e, 0x74, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54,
@@ -571,41 +1205,67 @@ func file_server_comment_comment_proto_rawDescGZIP() []byte {
 	return file_server_comment_comment_proto_rawDescData
 }
 
-var file_server_comment_comment_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_server_comment_comment_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_server_comment_comment_proto_goTypes = []interface{}{
 	(*QueryCommentTopicRq)(nil),      // 0: comment.QueryCommentTopicRq
 	(*QueryCommentTopicRs)(nil),      // 1: comment.QueryCommentTopicRs
 	(*QueryCommentTopicRangeRq)(nil), // 2: comment.QueryCommentTopicRangeRq
 	(*QueryCommentTopicRangeRs)(nil), // 3: comment.QueryCommentTopicRangeRs
-	(*ModCommentThumbsRq)(nil),       // 4: comment.ModCommentThumbsRq
-	(*ModCommentThumbsRs)(nil),       // 5: comment.ModCommentThumbsRs
-	(*KickCommentTopicDataRq)(nil),   // 6: comment.KickCommentTopicDataRq
-	(*KickCommentTopicDataRs)(nil),   // 7: comment.KickCommentTopicDataRs
-	(*global.CommentTopic)(nil),      // 8: proto.CommentTopic
-	(*global.CommentMetadata)(nil),   // 9: proto.CommentMetadata
+	(*ThumbsCommentRq)(nil),          // 4: comment.ThumbsCommentRq
+	(*ThumbsCommentRs)(nil),          // 5: comment.ThumbsCommentRs
+	(*PublishCommentRq)(nil),         // 6: comment.PublishCommentRq
+	(*PublishCommentRs)(nil),         // 7: comment.PublishCommentRs
+	(*ReplyCommentRq)(nil),           // 8: comment.ReplyCommentRq
+	(*ReplyCommentRs)(nil),           // 9: comment.ReplyCommentRs
+	(*DeleteCommentRq)(nil),          // 10: comment.DeleteCommentRq
+	(*DeleteCommentRs)(nil),          // 11: comment.DeleteCommentRs
+	(*HideCommentRq)(nil),            // 12: comment.HideCommentRq
+	(*HideCommentRs)(nil),            // 13: comment.HideCommentRs
+	(*KickCommentTopicDataRq)(nil),   // 14: comment.KickCommentTopicDataRq
+	(*KickCommentTopicDataRs)(nil),   // 15: comment.KickCommentTopicDataRs
+	(*global.CommentTopic)(nil),      // 16: proto.CommentTopic
+	(*global.CommentMetadata)(nil),   // 17: proto.CommentMetadata
+	(*global.PublisherMetadata)(nil), // 18: proto.PublisherMetadata
+	(*global.ReplyerMetadata)(nil),   // 19: proto.ReplyerMetadata
 }
 var file_server_comment_comment_proto_depIdxs = []int32{
-	8,  // 0: comment.QueryCommentTopicRq.Topic:type_name -> proto.CommentTopic
-	9,  // 1: comment.QueryCommentTopicRs.Metadatas:type_name -> proto.CommentMetadata
-	8,  // 2: comment.QueryCommentTopicRangeRq.Topic:type_name -> proto.CommentTopic
-	8,  // 3: comment.QueryCommentTopicRangeRs.Topic:type_name -> proto.CommentTopic
-	9,  // 4: comment.QueryCommentTopicRangeRs.Metadatas:type_name -> proto.CommentMetadata
-	8,  // 5: comment.ModCommentThumbsRq.Topic:type_name -> proto.CommentTopic
-	8,  // 6: comment.KickCommentTopicDataRq.Topic:type_name -> proto.CommentTopic
-	8,  // 7: comment.KickCommentTopicDataRs.Topic:type_name -> proto.CommentTopic
-	0,  // 8: comment.CommentService.QueryCommentTopic:input_type -> comment.QueryCommentTopicRq
-	2,  // 9: comment.CommentService.QueryCommentTopicRange:input_type -> comment.QueryCommentTopicRangeRq
-	4,  // 10: comment.CommentService.ModCommentThumbs:input_type -> comment.ModCommentThumbsRq
-	6,  // 11: comment.CommentService.KickCommentTopicData:input_type -> comment.KickCommentTopicDataRq
-	1,  // 12: comment.CommentService.QueryCommentTopic:output_type -> comment.QueryCommentTopicRs
-	3,  // 13: comment.CommentService.QueryCommentTopicRange:output_type -> comment.QueryCommentTopicRangeRs
-	5,  // 14: comment.CommentService.ModCommentThumbs:output_type -> comment.ModCommentThumbsRs
-	7,  // 15: comment.CommentService.KickCommentTopicData:output_type -> comment.KickCommentTopicDataRs
-	12, // [12:16] is the sub-list for method output_type
-	8,  // [8:12] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	16, // 0: comment.QueryCommentTopicRq.Topic:type_name -> proto.CommentTopic
+	17, // 1: comment.QueryCommentTopicRs.Metadatas:type_name -> proto.CommentMetadata
+	16, // 2: comment.QueryCommentTopicRangeRq.Topic:type_name -> proto.CommentTopic
+	16, // 3: comment.QueryCommentTopicRangeRs.Topic:type_name -> proto.CommentTopic
+	17, // 4: comment.QueryCommentTopicRangeRs.Metadatas:type_name -> proto.CommentMetadata
+	16, // 5: comment.ThumbsCommentRq.Topic:type_name -> proto.CommentTopic
+	16, // 6: comment.PublishCommentRq.Topic:type_name -> proto.CommentTopic
+	18, // 7: comment.PublishCommentRq.Publisher:type_name -> proto.PublisherMetadata
+	17, // 8: comment.PublishCommentRs.Metadata:type_name -> proto.CommentMetadata
+	16, // 9: comment.ReplyCommentRq.Topic:type_name -> proto.CommentTopic
+	18, // 10: comment.ReplyCommentRq.Publisher:type_name -> proto.PublisherMetadata
+	19, // 11: comment.ReplyCommentRs.Reply:type_name -> proto.ReplyerMetadata
+	16, // 12: comment.DeleteCommentRq.Topic:type_name -> proto.CommentTopic
+	16, // 13: comment.HideCommentRq.Topic:type_name -> proto.CommentTopic
+	16, // 14: comment.KickCommentTopicDataRq.Topic:type_name -> proto.CommentTopic
+	16, // 15: comment.KickCommentTopicDataRs.Topic:type_name -> proto.CommentTopic
+	0,  // 16: comment.CommentService.QueryCommentTopic:input_type -> comment.QueryCommentTopicRq
+	2,  // 17: comment.CommentService.QueryCommentTopicRange:input_type -> comment.QueryCommentTopicRangeRq
+	4,  // 18: comment.CommentService.ThumbsComment:input_type -> comment.ThumbsCommentRq
+	6,  // 19: comment.CommentService.PublishComment:input_type -> comment.PublishCommentRq
+	8,  // 20: comment.CommentService.ReplyComment:input_type -> comment.ReplyCommentRq
+	10, // 21: comment.CommentService.DeleteComment:input_type -> comment.DeleteCommentRq
+	12, // 22: comment.CommentService.HideComment:input_type -> comment.HideCommentRq
+	14, // 23: comment.CommentService.KickCommentTopicData:input_type -> comment.KickCommentTopicDataRq
+	1,  // 24: comment.CommentService.QueryCommentTopic:output_type -> comment.QueryCommentTopicRs
+	3,  // 25: comment.CommentService.QueryCommentTopicRange:output_type -> comment.QueryCommentTopicRangeRs
+	5,  // 26: comment.CommentService.ThumbsComment:output_type -> comment.ThumbsCommentRs
+	7,  // 27: comment.CommentService.PublishComment:output_type -> comment.PublishCommentRs
+	9,  // 28: comment.CommentService.ReplyComment:output_type -> comment.ReplyCommentRs
+	11, // 29: comment.CommentService.DeleteComment:output_type -> comment.DeleteCommentRs
+	13, // 30: comment.CommentService.HideComment:output_type -> comment.HideCommentRs
+	15, // 31: comment.CommentService.KickCommentTopicData:output_type -> comment.KickCommentTopicDataRs
+	24, // [24:32] is the sub-list for method output_type
+	16, // [16:24] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_server_comment_comment_proto_init() }
@@ -663,7 +1323,7 @@ func file_server_comment_comment_proto_init() {
 			}
 		}
 		file_server_comment_comment_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ModCommentThumbsRq); i {
+			switch v := v.(*ThumbsCommentRq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -675,7 +1335,7 @@ func file_server_comment_comment_proto_init() {
 			}
 		}
 		file_server_comment_comment_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ModCommentThumbsRs); i {
+			switch v := v.(*ThumbsCommentRs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -687,7 +1347,7 @@ func file_server_comment_comment_proto_init() {
 			}
 		}
 		file_server_comment_comment_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KickCommentTopicDataRq); i {
+			switch v := v.(*PublishCommentRq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -699,6 +1359,102 @@ func file_server_comment_comment_proto_init() {
 			}
 		}
 		file_server_comment_comment_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublishCommentRs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_comment_comment_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplyCommentRq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_comment_comment_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplyCommentRs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_comment_comment_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCommentRq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_comment_comment_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCommentRs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_comment_comment_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HideCommentRq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_comment_comment_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HideCommentRs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_comment_comment_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KickCommentTopicDataRq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_comment_comment_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*KickCommentTopicDataRs); i {
 			case 0:
 				return &v.state
@@ -717,7 +1473,7 @@ func file_server_comment_comment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_server_comment_comment_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -39,7 +39,11 @@ func NewCommentServiceEndpoints() []*api.Endpoint {
 type CommentService interface {
 	QueryCommentTopic(ctx context.Context, in *QueryCommentTopicRq, opts ...client.CallOption) (*QueryCommentTopicRs, error)
 	QueryCommentTopicRange(ctx context.Context, in *QueryCommentTopicRangeRq, opts ...client.CallOption) (*QueryCommentTopicRangeRs, error)
-	ModCommentThumbs(ctx context.Context, in *ModCommentThumbsRq, opts ...client.CallOption) (*ModCommentThumbsRs, error)
+	ThumbsComment(ctx context.Context, in *ThumbsCommentRq, opts ...client.CallOption) (*ThumbsCommentRs, error)
+	PublishComment(ctx context.Context, in *PublishCommentRq, opts ...client.CallOption) (*PublishCommentRs, error)
+	ReplyComment(ctx context.Context, in *ReplyCommentRq, opts ...client.CallOption) (*ReplyCommentRs, error)
+	DeleteComment(ctx context.Context, in *DeleteCommentRq, opts ...client.CallOption) (*DeleteCommentRs, error)
+	HideComment(ctx context.Context, in *HideCommentRq, opts ...client.CallOption) (*HideCommentRs, error)
 	KickCommentTopicData(ctx context.Context, in *KickCommentTopicDataRq, opts ...client.CallOption) (*KickCommentTopicDataRs, error)
 }
 
@@ -75,9 +79,49 @@ func (c *commentService) QueryCommentTopicRange(ctx context.Context, in *QueryCo
 	return out, nil
 }
 
-func (c *commentService) ModCommentThumbs(ctx context.Context, in *ModCommentThumbsRq, opts ...client.CallOption) (*ModCommentThumbsRs, error) {
-	req := c.c.NewRequest(c.name, "CommentService.ModCommentThumbs", in)
-	out := new(ModCommentThumbsRs)
+func (c *commentService) ThumbsComment(ctx context.Context, in *ThumbsCommentRq, opts ...client.CallOption) (*ThumbsCommentRs, error) {
+	req := c.c.NewRequest(c.name, "CommentService.ThumbsComment", in)
+	out := new(ThumbsCommentRs)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentService) PublishComment(ctx context.Context, in *PublishCommentRq, opts ...client.CallOption) (*PublishCommentRs, error) {
+	req := c.c.NewRequest(c.name, "CommentService.PublishComment", in)
+	out := new(PublishCommentRs)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentService) ReplyComment(ctx context.Context, in *ReplyCommentRq, opts ...client.CallOption) (*ReplyCommentRs, error) {
+	req := c.c.NewRequest(c.name, "CommentService.ReplyComment", in)
+	out := new(ReplyCommentRs)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentService) DeleteComment(ctx context.Context, in *DeleteCommentRq, opts ...client.CallOption) (*DeleteCommentRs, error) {
+	req := c.c.NewRequest(c.name, "CommentService.DeleteComment", in)
+	out := new(DeleteCommentRs)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentService) HideComment(ctx context.Context, in *HideCommentRq, opts ...client.CallOption) (*HideCommentRs, error) {
+	req := c.c.NewRequest(c.name, "CommentService.HideComment", in)
+	out := new(HideCommentRs)
 	err := c.c.Call(ctx, req, out, opts...)
 	if err != nil {
 		return nil, err
@@ -100,7 +144,11 @@ func (c *commentService) KickCommentTopicData(ctx context.Context, in *KickComme
 type CommentServiceHandler interface {
 	QueryCommentTopic(context.Context, *QueryCommentTopicRq, *QueryCommentTopicRs) error
 	QueryCommentTopicRange(context.Context, *QueryCommentTopicRangeRq, *QueryCommentTopicRangeRs) error
-	ModCommentThumbs(context.Context, *ModCommentThumbsRq, *ModCommentThumbsRs) error
+	ThumbsComment(context.Context, *ThumbsCommentRq, *ThumbsCommentRs) error
+	PublishComment(context.Context, *PublishCommentRq, *PublishCommentRs) error
+	ReplyComment(context.Context, *ReplyCommentRq, *ReplyCommentRs) error
+	DeleteComment(context.Context, *DeleteCommentRq, *DeleteCommentRs) error
+	HideComment(context.Context, *HideCommentRq, *HideCommentRs) error
 	KickCommentTopicData(context.Context, *KickCommentTopicDataRq, *KickCommentTopicDataRs) error
 }
 
@@ -108,7 +156,11 @@ func RegisterCommentServiceHandler(s server.Server, hdlr CommentServiceHandler,
 	type commentService interface {
 		QueryCommentTopic(ctx context.Context, in *QueryCommentTopicRq, out *QueryCommentTopicRs) error
 		QueryCommentTopicRange(ctx context.Context, in *QueryCommentTopicRangeRq, out *QueryCommentTopicRangeRs) error
-		ModCommentThumbs(ctx context.Context, in *ModCommentThumbsRq, out *ModCommentThumbsRs) error
+		ThumbsComment(ctx context.Context, in *ThumbsCommentRq, out *ThumbsCommentRs) error
+		PublishComment(ctx context.Context, in *PublishCommentRq, out *PublishCommentRs) error
+		ReplyComment(ctx context.Context, in *ReplyCommentRq, out *ReplyCommentRs) error
+		DeleteComment(ctx context.Context, in *DeleteCommentRq, out *DeleteCommentRs) error
+		HideComment(ctx context.Context, in *HideCommentRq, out *HideCommentRs) error
 		KickCommentTopicData(ctx context.Context, in *KickCommentTopicDataRq, out *KickCommentTopicDataRs) error
 	}
 	type CommentService struct {
@@ -130,8 +182,24 @@ func (h *commentServiceHandler) QueryCommentTopicRange(ctx context.Context, in *
 	return h.CommentServiceHandler.QueryCommentTopicRange(ctx, in, out)
 }
 
-func (h *commentServiceHandler) ModCommentThumbs(ctx context.Context, in *ModCommentThumbsRq, out *ModCommentThumbsRs) error {
-	return h.CommentServiceHandler.ModCommentThumbs(ctx, in, out)
+func (h *commentServiceHandler) ThumbsComment(ctx context.Context, in *ThumbsCommentRq, out *ThumbsCommentRs) error {
+	return h.CommentServiceHandler.ThumbsComment(ctx, in, out)
+}
+
+func (h *commentServiceHandler) PublishComment(ctx context.Context, in *PublishCommentRq, out *PublishCommentRs) error {
+	return h.CommentServiceHandler.PublishComment(ctx, in, out)
+}
+
+func (h *commentServiceHandler) ReplyComment(ctx context.Context, in *ReplyCommentRq, out *ReplyCommentRs) error {
+	return h.CommentServiceHandler.ReplyComment(ctx, in, out)
+}
+
+func (h *commentServiceHandler) DeleteComment(ctx context.Context, in *DeleteCommentRq, out *DeleteCommentRs) error {
+	return h.CommentServiceHandler.DeleteComment(ctx, in, out)
+}
+
+func (h *commentServiceHandler) HideComment(ctx context.Context, in *HideCommentRq, out *HideCommentRs) error {
+	return h.CommentServiceHandler.HideComment(ctx, in, out)
 }
 
 func (h *commentServiceHandler) KickCommentTopicData(ctx context.Context, in *KickCommentTopicDataRq, out *KickCommentTopicDataRs) error {
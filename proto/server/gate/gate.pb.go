@@ -350,6 +350,133 @@ func (x *SyncPlayerInfoReply) GetInfo() *UserInfo {
 	return nil
 }
 
+// game节点下线迁移时为玩家重新选择game节点
+type SelectGameNodeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId        string `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	ExcludeGameId int32  `protobuf:"varint,2,opt,name=ExcludeGameId,proto3" json:"ExcludeGameId,omitempty"` // 正在下线的game节点
+}
+
+func (x *SelectGameNodeRequest) Reset() {
+	*x = SelectGameNodeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_gate_gate_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SelectGameNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectGameNodeRequest) ProtoMessage() {}
+
+func (x *SelectGameNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_gate_gate_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectGameNodeRequest.ProtoReflect.Descriptor instead.
+func (*SelectGameNodeRequest) Descriptor() ([]byte, []int) {
+	return file_server_gate_gate_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SelectGameNodeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SelectGameNodeRequest) GetExcludeGameId() int32 {
+	if x != nil {
+		return x.ExcludeGameId
+	}
+	return 0
+}
+
+type SelectGameNodeReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId        int32  `protobuf:"varint,1,opt,name=GameId,proto3" json:"GameId,omitempty"`
+	PublicTcpAddr string `protobuf:"bytes,2,opt,name=PublicTcpAddr,proto3" json:"PublicTcpAddr,omitempty"`
+	PublicWsAddr  string `protobuf:"bytes,3,opt,name=PublicWsAddr,proto3" json:"PublicWsAddr,omitempty"`
+	SessionToken  string `protobuf:"bytes,4,opt,name=SessionToken,proto3" json:"SessionToken,omitempty"`
+}
+
+func (x *SelectGameNodeReply) Reset() {
+	*x = SelectGameNodeReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_gate_gate_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SelectGameNodeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectGameNodeReply) ProtoMessage() {}
+
+func (x *SelectGameNodeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_server_gate_gate_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectGameNodeReply.ProtoReflect.Descriptor instead.
+func (*SelectGameNodeReply) Descriptor() ([]byte, []int) {
+	return file_server_gate_gate_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SelectGameNodeReply) GetGameId() int32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *SelectGameNodeReply) GetPublicTcpAddr() string {
+	if x != nil {
+		return x.PublicTcpAddr
+	}
+	return ""
+}
+
+func (x *SelectGameNodeReply) GetPublicWsAddr() string {
+	if x != nil {
+		return x.PublicWsAddr
+	}
+	return ""
+}
+
+func (x *SelectGameNodeReply) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
 var File_server_gate_gate_proto protoreflect.FileDescriptor
 
 var file_server_gate_gate_proto_rawDesc = []byte{
@@ -386,21 +513,41 @@ var file_server_gate_gate_proto_rawDesc = []byte{
 	0x13, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52,
 	0x65, 0x70, 0x6c, 0x79, 0x12, 0x22, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e,
-	0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x32, 0x9e, 0x01, 0x0a, 0x0b, 0x47, 0x61, 0x74,
-	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x47,
-	0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x61, 0x74, 0x65,
-	0x2e, 0x47, 0x61, 0x74, 0x65, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x1a, 0x18, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x61, 0x74, 0x65,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4a, 0x0a,
-	0x0e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12,
-	0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67,
-	0x61, 0x74, 0x65, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e,
-	0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65,
-	0x6e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x55, 0x0a, 0x15, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x45, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0d, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x22,
+	0x9b, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x47, 0x61, 0x6d, 0x65, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12,
+	0x24, 0x0a, 0x0d, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x54, 0x63, 0x70, 0x41, 0x64, 0x64, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x54, 0x63,
+	0x70, 0x41, 0x64, 0x64, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x57,
+	0x73, 0x41, 0x64, 0x64, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x57, 0x73, 0x41, 0x64, 0x64, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x32, 0xea, 0x01,
+	0x0a, 0x0b, 0x47, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a,
+	0x0d, 0x47, 0x65, 0x74, 0x47, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16,
+	0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x18, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x47, 0x65,
+	0x74, 0x47, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x53, 0x79, 0x6e, 0x63,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4a,
+	0x0a, 0x0e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x4e, 0x6f, 0x64, 0x65,
+	0x12, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x47, 0x61,
+	0x6d, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x67, 0x61, 0x74, 0x65, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x4e,
+	0x6f, 0x64, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64,
+	0x65, 0x6e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -415,7 +562,7 @@ func file_server_gate_gate_proto_rawDescGZIP() []byte {
 	return file_server_gate_gate_proto_rawDescData
 }
 
-var file_server_gate_gate_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_server_gate_gate_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_server_gate_gate_proto_goTypes = []interface{}{
 	(*GateStatus)(nil),            // 0: gate.GateStatus
 	(*UserInfo)(nil),              // 1: gate.UserInfo
@@ -423,18 +570,22 @@ var file_server_gate_gate_proto_goTypes = []interface{}{
 	(*GetGateStatusReply)(nil),    // 3: gate.GetGateStatusReply
 	(*SyncPlayerInfoRequest)(nil), // 4: gate.SyncPlayerInfoRequest
 	(*SyncPlayerInfoReply)(nil),   // 5: gate.SyncPlayerInfoReply
-	(*global.PlayerInfo)(nil),     // 6: proto.PlayerInfo
+	(*SelectGameNodeRequest)(nil), // 6: gate.SelectGameNodeRequest
+	(*SelectGameNodeReply)(nil),   // 7: gate.SelectGameNodeReply
+	(*global.PlayerInfo)(nil),     // 8: proto.PlayerInfo
 }
 var file_server_gate_gate_proto_depIdxs = []int32{
 	0, // 0: gate.GetGateStatusReply.status:type_name -> gate.GateStatus
-	6, // 1: gate.SyncPlayerInfoRequest.info:type_name -> proto.PlayerInfo
+	8, // 1: gate.SyncPlayerInfoRequest.info:type_name -> proto.PlayerInfo
 	1, // 2: gate.SyncPlayerInfoReply.info:type_name -> gate.UserInfo
 	2, // 3: gate.GateService.GetGateStatus:input_type -> gate.GateEmptyMessage
 	4, // 4: gate.GateService.SyncPlayerInfo:input_type -> gate.SyncPlayerInfoRequest
-	3, // 5: gate.GateService.GetGateStatus:output_type -> gate.GetGateStatusReply
-	5, // 6: gate.GateService.SyncPlayerInfo:output_type -> gate.SyncPlayerInfoReply
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
+	6, // 5: gate.GateService.SelectGameNode:input_type -> gate.SelectGameNodeRequest
+	3, // 6: gate.GateService.GetGateStatus:output_type -> gate.GetGateStatusReply
+	5, // 7: gate.GateService.SyncPlayerInfo:output_type -> gate.SyncPlayerInfoReply
+	7, // 8: gate.GateService.SelectGameNode:output_type -> gate.SelectGameNodeReply
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
 	3, // [3:3] is the sub-list for extension type_name
 	3, // [3:3] is the sub-list for extension extendee
 	0, // [0:3] is the sub-list for field type_name
@@ -518,6 +669,30 @@ func file_server_gate_gate_proto_init() {
 				return nil
 			}
 		}
+		file_server_gate_gate_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SelectGameNodeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_gate_gate_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SelectGameNodeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -525,7 +700,7 @@ func file_server_gate_gate_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_server_gate_gate_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
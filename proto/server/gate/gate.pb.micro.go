@@ -39,6 +39,7 @@ func NewGateServiceEndpoints() []*api.Endpoint {
 type GateService interface {
 	GetGateStatus(ctx context.Context, in *GateEmptyMessage, opts ...client.CallOption) (*GetGateStatusReply, error)
 	SyncPlayerInfo(ctx context.Context, in *SyncPlayerInfoRequest, opts ...client.CallOption) (*SyncPlayerInfoReply, error)
+	SelectGameNode(ctx context.Context, in *SelectGameNodeRequest, opts ...client.CallOption) (*SelectGameNodeReply, error)
 }
 
 type gateService struct {
@@ -73,17 +74,29 @@ func (c *gateService) SyncPlayerInfo(ctx context.Context, in *SyncPlayerInfoRequ
 	return out, nil
 }
 
+func (c *gateService) SelectGameNode(ctx context.Context, in *SelectGameNodeRequest, opts ...client.CallOption) (*SelectGameNodeReply, error) {
+	req := c.c.NewRequest(c.name, "GateService.SelectGameNode", in)
+	out := new(SelectGameNodeReply)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for GateService service
 
 type GateServiceHandler interface {
 	GetGateStatus(context.Context, *GateEmptyMessage, *GetGateStatusReply) error
 	SyncPlayerInfo(context.Context, *SyncPlayerInfoRequest, *SyncPlayerInfoReply) error
+	SelectGameNode(context.Context, *SelectGameNodeRequest, *SelectGameNodeReply) error
 }
 
 func RegisterGateServiceHandler(s server.Server, hdlr GateServiceHandler, opts ...server.HandlerOption) error {
 	type gateService interface {
 		GetGateStatus(ctx context.Context, in *GateEmptyMessage, out *GetGateStatusReply) error
 		SyncPlayerInfo(ctx context.Context, in *SyncPlayerInfoRequest, out *SyncPlayerInfoReply) error
+		SelectGameNode(ctx context.Context, in *SelectGameNodeRequest, out *SelectGameNodeReply) error
 	}
 	type GateService struct {
 		gateService
@@ -103,3 +116,7 @@ func (h *gateServiceHandler) GetGateStatus(ctx context.Context, in *GateEmptyMes
 func (h *gateServiceHandler) SyncPlayerInfo(ctx context.Context, in *SyncPlayerInfoRequest, out *SyncPlayerInfoReply) error {
 	return h.GateServiceHandler.SyncPlayerInfo(ctx, in, out)
 }
+
+func (h *gateServiceHandler) SelectGameNode(ctx context.Context, in *SelectGameNodeRequest, out *SelectGameNodeReply) error {
+	return h.GateServiceHandler.SelectGameNode(ctx, in, out)
+}
@@ -197,6 +197,70 @@ func (x *PubSyncPlayerInfo) GetInfo() *global.PlayerInfo {
 	return nil
 }
 
+// game节点进入下线迁移状态, gate需要将其从一致性哈希中移除
+type PubGameDrain struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MsgId    int64  `protobuf:"varint,1,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	GameId   int32  `protobuf:"varint,2,opt,name=gameId,proto3" json:"gameId,omitempty"`
+	BootTime string `protobuf:"bytes,3,opt,name=bootTime,proto3" json:"bootTime,omitempty"` // game节点启动时间, 重新部署后的节点不受影响
+}
+
+func (x *PubGameDrain) Reset() {
+	*x = PubGameDrain{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_pubsub_pubsub_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PubGameDrain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubGameDrain) ProtoMessage() {}
+
+func (x *PubGameDrain) ProtoReflect() protoreflect.Message {
+	mi := &file_server_pubsub_pubsub_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubGameDrain.ProtoReflect.Descriptor instead.
+func (*PubGameDrain) Descriptor() ([]byte, []int) {
+	return file_server_pubsub_pubsub_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PubGameDrain) GetMsgId() int64 {
+	if x != nil {
+		return x.MsgId
+	}
+	return 0
+}
+
+func (x *PubGameDrain) GetGameId() int32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *PubGameDrain) GetBootTime() string {
+	if x != nil {
+		return x.BootTime
+	}
+	return ""
+}
+
 type MultiPublishTest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -210,7 +274,7 @@ type MultiPublishTest struct {
 func (x *MultiPublishTest) Reset() {
 	*x = MultiPublishTest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_pubsub_pubsub_proto_msgTypes[3]
+		mi := &file_server_pubsub_pubsub_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -223,7 +287,7 @@ func (x *MultiPublishTest) String() string {
 func (*MultiPublishTest) ProtoMessage() {}
 
 func (x *MultiPublishTest) ProtoReflect() protoreflect.Message {
-	mi := &file_server_pubsub_pubsub_proto_msgTypes[3]
+	mi := &file_server_pubsub_pubsub_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -236,7 +300,7 @@ func (x *MultiPublishTest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MultiPublishTest.ProtoReflect.Descriptor instead.
 func (*MultiPublishTest) Descriptor() ([]byte, []int) {
-	return file_server_pubsub_pubsub_proto_rawDescGZIP(), []int{3}
+	return file_server_pubsub_pubsub_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *MultiPublishTest) GetMsgId() int64 {
@@ -260,6 +324,78 @@ func (x *MultiPublishTest) GetName() string {
 	return ""
 }
 
+// 聊天消息, 由game节点推送给在线玩家
+type PubChatMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MsgId       int64               `protobuf:"varint,1,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	Message     *global.ChatMessage `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	GameId      int32               `protobuf:"varint,3,opt,name=gameId,proto3" json:"gameId,omitempty"`                  // 只推送给此game节点的玩家, 为0时所有game节点都推送
+	ReceiverIds []int64             `protobuf:"varint,4,rep,packed,name=receiverIds,proto3" json:"receiverIds,omitempty"` // 只推送给这些玩家, 为空时推送给所有在线玩家
+}
+
+func (x *PubChatMessage) Reset() {
+	*x = PubChatMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_pubsub_pubsub_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PubChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubChatMessage) ProtoMessage() {}
+
+func (x *PubChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_server_pubsub_pubsub_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubChatMessage.ProtoReflect.Descriptor instead.
+func (*PubChatMessage) Descriptor() ([]byte, []int) {
+	return file_server_pubsub_pubsub_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PubChatMessage) GetMsgId() int64 {
+	if x != nil {
+		return x.MsgId
+	}
+	return 0
+}
+
+func (x *PubChatMessage) GetMessage() *global.ChatMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *PubChatMessage) GetGameId() int32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *PubChatMessage) GetReceiverIds() []int64 {
+	if x != nil {
+		return x.ReceiverIds
+	}
+	return nil
+}
+
 var File_server_pubsub_pubsub_proto protoreflect.FileDescriptor
 
 var file_server_pubsub_pubsub_proto_rawDesc = []byte{
@@ -282,16 +418,30 @@ var file_server_pubsub_pubsub_proto_rawDesc = []byte{
 	0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d, 0x73,
 	0x67, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x4c, 0x0a, 0x10, 0x4d, 0x75,
-	0x6c, 0x74, 0x69, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x54, 0x65, 0x73, 0x74, 0x12, 0x14,
-	0x0a, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d,
-	0x73, 0x67, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65, 0x6e,
-	0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x58, 0x0a, 0x0c, 0x50, 0x75,
+	0x62, 0x47, 0x61, 0x6d, 0x65, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73,
+	0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6f, 0x6f, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x6f, 0x6f, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x22, 0x4c, 0x0a, 0x10, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x54, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x8e, 0x01, 0x0a, 0x0e, 0x50, 0x75, 0x62, 0x43, 0x68, 0x61, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x61, 0x6d,
+	0x65, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49,
+	0x64, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
+	0x49, 0x64, 0x73, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65, 0x6e, 0x2f, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f,
+	0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -306,24 +456,28 @@ func file_server_pubsub_pubsub_proto_rawDescGZIP() []byte {
 	return file_server_pubsub_pubsub_proto_rawDescData
 }
 
-var file_server_pubsub_pubsub_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_server_pubsub_pubsub_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_server_pubsub_pubsub_proto_goTypes = []interface{}{
 	(*PubStartGate)(nil),       // 0: pubsub.PubStartGate
 	(*PubGateResult)(nil),      // 1: pubsub.PubGateResult
 	(*PubSyncPlayerInfo)(nil),  // 2: pubsub.PubSyncPlayerInfo
-	(*MultiPublishTest)(nil),   // 3: pubsub.MultiPublishTest
-	(*global.AccountInfo)(nil), // 4: proto.AccountInfo
-	(*global.PlayerInfo)(nil),  // 5: proto.PlayerInfo
+	(*PubGameDrain)(nil),       // 3: pubsub.PubGameDrain
+	(*MultiPublishTest)(nil),   // 4: pubsub.MultiPublishTest
+	(*PubChatMessage)(nil),     // 5: pubsub.PubChatMessage
+	(*global.AccountInfo)(nil), // 6: proto.AccountInfo
+	(*global.PlayerInfo)(nil),  // 7: proto.PlayerInfo
+	(*global.ChatMessage)(nil), // 8: proto.ChatMessage
 }
 var file_server_pubsub_pubsub_proto_depIdxs = []int32{
-	4, // 0: pubsub.PubStartGate.info:type_name -> proto.AccountInfo
-	4, // 1: pubsub.PubGateResult.info:type_name -> proto.AccountInfo
-	5, // 2: pubsub.PubSyncPlayerInfo.info:type_name -> proto.PlayerInfo
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	6, // 0: pubsub.PubStartGate.info:type_name -> proto.AccountInfo
+	6, // 1: pubsub.PubGateResult.info:type_name -> proto.AccountInfo
+	7, // 2: pubsub.PubSyncPlayerInfo.info:type_name -> proto.PlayerInfo
+	8, // 3: pubsub.PubChatMessage.message:type_name -> proto.ChatMessage
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_server_pubsub_pubsub_proto_init() }
@@ -369,6 +523,18 @@ func file_server_pubsub_pubsub_proto_init() {
 			}
 		}
 		file_server_pubsub_pubsub_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PubGameDrain); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_pubsub_pubsub_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MultiPublishTest); i {
 			case 0:
 				return &v.state
@@ -380,6 +546,18 @@ func file_server_pubsub_pubsub_proto_init() {
 				return nil
 			}
 		}
+		file_server_pubsub_pubsub_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PubChatMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -387,7 +565,7 @@ func file_server_pubsub_pubsub_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_server_pubsub_pubsub_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
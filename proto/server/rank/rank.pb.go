@@ -282,6 +282,133 @@ func (x *QueryRankByRangeRs) GetMetadatas() []*global.RankMetadata {
 	return nil
 }
 
+// 通过分数区间查询排行
+type QueryRankByScoreRq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RankId int32   `protobuf:"varint,1,opt,name=RankId,proto3" json:"RankId,omitempty"`
+	Min    float64 `protobuf:"fixed64,2,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max    float64 `protobuf:"fixed64,3,opt,name=Max,proto3" json:"Max,omitempty"`
+	Limit  int64   `protobuf:"varint,4,opt,name=Limit,proto3" json:"Limit,omitempty"` // 最多返回数量, <= 0时返回区间内所有数据
+}
+
+func (x *QueryRankByScoreRq) Reset() {
+	*x = QueryRankByScoreRq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_rank_rank_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryRankByScoreRq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRankByScoreRq) ProtoMessage() {}
+
+func (x *QueryRankByScoreRq) ProtoReflect() protoreflect.Message {
+	mi := &file_server_rank_rank_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRankByScoreRq.ProtoReflect.Descriptor instead.
+func (*QueryRankByScoreRq) Descriptor() ([]byte, []int) {
+	return file_server_rank_rank_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *QueryRankByScoreRq) GetRankId() int32 {
+	if x != nil {
+		return x.RankId
+	}
+	return 0
+}
+
+func (x *QueryRankByScoreRq) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *QueryRankByScoreRq) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *QueryRankByScoreRq) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type QueryRankByScoreRs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RankId    int32                  `protobuf:"varint,1,opt,name=RankId,proto3" json:"RankId,omitempty"`
+	Metadatas []*global.RankMetadata `protobuf:"bytes,2,rep,name=Metadatas,proto3" json:"Metadatas,omitempty"` // 按照排行顺序
+}
+
+func (x *QueryRankByScoreRs) Reset() {
+	*x = QueryRankByScoreRs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_rank_rank_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryRankByScoreRs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRankByScoreRs) ProtoMessage() {}
+
+func (x *QueryRankByScoreRs) ProtoReflect() protoreflect.Message {
+	mi := &file_server_rank_rank_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRankByScoreRs.ProtoReflect.Descriptor instead.
+func (*QueryRankByScoreRs) Descriptor() ([]byte, []int) {
+	return file_server_rank_rank_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *QueryRankByScoreRs) GetRankId() int32 {
+	if x != nil {
+		return x.RankId
+	}
+	return 0
+}
+
+func (x *QueryRankByScoreRs) GetMetadatas() []*global.RankMetadata {
+	if x != nil {
+		return x.Metadatas
+	}
+	return nil
+}
+
 // 设置排行榜分数
 type SetRankScoreRq struct {
 	state         protoimpl.MessageState
@@ -295,7 +422,7 @@ type SetRankScoreRq struct {
 func (x *SetRankScoreRq) Reset() {
 	*x = SetRankScoreRq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_rank_rank_proto_msgTypes[4]
+		mi := &file_server_rank_rank_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -308,7 +435,7 @@ func (x *SetRankScoreRq) String() string {
 func (*SetRankScoreRq) ProtoMessage() {}
 
 func (x *SetRankScoreRq) ProtoReflect() protoreflect.Message {
-	mi := &file_server_rank_rank_proto_msgTypes[4]
+	mi := &file_server_rank_rank_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -321,7 +448,7 @@ func (x *SetRankScoreRq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetRankScoreRq.ProtoReflect.Descriptor instead.
 func (*SetRankScoreRq) Descriptor() ([]byte, []int) {
-	return file_server_rank_rank_proto_rawDescGZIP(), []int{4}
+	return file_server_rank_rank_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SetRankScoreRq) GetRankId() int32 {
@@ -347,7 +474,7 @@ type SetRankScoreRs struct {
 func (x *SetRankScoreRs) Reset() {
 	*x = SetRankScoreRs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_rank_rank_proto_msgTypes[5]
+		mi := &file_server_rank_rank_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -360,7 +487,7 @@ func (x *SetRankScoreRs) String() string {
 func (*SetRankScoreRs) ProtoMessage() {}
 
 func (x *SetRankScoreRs) ProtoReflect() protoreflect.Message {
-	mi := &file_server_rank_rank_proto_msgTypes[5]
+	mi := &file_server_rank_rank_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +500,253 @@ func (x *SetRankScoreRs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetRankScoreRs.ProtoReflect.Descriptor instead.
 func (*SetRankScoreRs) Descriptor() ([]byte, []int) {
-	return file_server_rank_rank_proto_rawDescGZIP(), []int{5}
+	return file_server_rank_rank_proto_rawDescGZIP(), []int{7}
+}
+
+// 增减排行榜分数, 不在排行榜中时以Metadata.Score为初始分数
+type IncrRankScoreRq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RankId   int32                `protobuf:"varint,1,opt,name=RankId,proto3" json:"RankId,omitempty"`    // 排行榜id
+	Metadata *global.RankMetadata `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"` // 元数据
+	Delta    float64              `protobuf:"fixed64,3,opt,name=Delta,proto3" json:"Delta,omitempty"`     // 分数变化
+}
+
+func (x *IncrRankScoreRq) Reset() {
+	*x = IncrRankScoreRq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_rank_rank_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IncrRankScoreRq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrRankScoreRq) ProtoMessage() {}
+
+func (x *IncrRankScoreRq) ProtoReflect() protoreflect.Message {
+	mi := &file_server_rank_rank_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrRankScoreRq.ProtoReflect.Descriptor instead.
+func (*IncrRankScoreRq) Descriptor() ([]byte, []int) {
+	return file_server_rank_rank_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *IncrRankScoreRq) GetRankId() int32 {
+	if x != nil {
+		return x.RankId
+	}
+	return 0
+}
+
+func (x *IncrRankScoreRq) GetMetadata() *global.RankMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *IncrRankScoreRq) GetDelta() float64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type IncrRankScoreRs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *global.RankMetadata `protobuf:"bytes,1,opt,name=Metadata,proto3" json:"Metadata,omitempty"` // 变化后的元数据
+}
+
+func (x *IncrRankScoreRs) Reset() {
+	*x = IncrRankScoreRs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_rank_rank_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IncrRankScoreRs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrRankScoreRs) ProtoMessage() {}
+
+func (x *IncrRankScoreRs) ProtoReflect() protoreflect.Message {
+	mi := &file_server_rank_rank_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrRankScoreRs.ProtoReflect.Descriptor instead.
+func (*IncrRankScoreRs) Descriptor() ([]byte, []int) {
+	return file_server_rank_rank_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *IncrRankScoreRs) GetMetadata() *global.RankMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// 查询排行快照中的排名, 用于按结算时间点发放排名奖励
+type QueryRankSnapshotRq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RankId       int32 `protobuf:"varint,1,opt,name=RankId,proto3" json:"RankId,omitempty"`             // 排行榜id
+	SnapshotTime int64 `protobuf:"varint,2,opt,name=SnapshotTime,proto3" json:"SnapshotTime,omitempty"` // 结算时间点
+	ObjId        int64 `protobuf:"varint,3,opt,name=ObjId,proto3" json:"ObjId,omitempty"`
+}
+
+func (x *QueryRankSnapshotRq) Reset() {
+	*x = QueryRankSnapshotRq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_rank_rank_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryRankSnapshotRq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRankSnapshotRq) ProtoMessage() {}
+
+func (x *QueryRankSnapshotRq) ProtoReflect() protoreflect.Message {
+	mi := &file_server_rank_rank_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRankSnapshotRq.ProtoReflect.Descriptor instead.
+func (*QueryRankSnapshotRq) Descriptor() ([]byte, []int) {
+	return file_server_rank_rank_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *QueryRankSnapshotRq) GetRankId() int32 {
+	if x != nil {
+		return x.RankId
+	}
+	return 0
+}
+
+func (x *QueryRankSnapshotRq) GetSnapshotTime() int64 {
+	if x != nil {
+		return x.SnapshotTime
+	}
+	return 0
+}
+
+func (x *QueryRankSnapshotRq) GetObjId() int64 {
+	if x != nil {
+		return x.ObjId
+	}
+	return 0
+}
+
+type QueryRankSnapshotRs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RankId       int32 `protobuf:"varint,1,opt,name=RankId,proto3" json:"RankId,omitempty"`
+	SnapshotTime int64 `protobuf:"varint,2,opt,name=SnapshotTime,proto3" json:"SnapshotTime,omitempty"` // 实际使用的快照时间点
+	ObjId        int64 `protobuf:"varint,3,opt,name=ObjId,proto3" json:"ObjId,omitempty"`
+	RankIndex    int32 `protobuf:"varint,4,opt,name=RankIndex,proto3" json:"RankIndex,omitempty"` // 快照中的位置：从0开始, 不在快照中时为-1
+}
+
+func (x *QueryRankSnapshotRs) Reset() {
+	*x = QueryRankSnapshotRs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_rank_rank_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryRankSnapshotRs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRankSnapshotRs) ProtoMessage() {}
+
+func (x *QueryRankSnapshotRs) ProtoReflect() protoreflect.Message {
+	mi := &file_server_rank_rank_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRankSnapshotRs.ProtoReflect.Descriptor instead.
+func (*QueryRankSnapshotRs) Descriptor() ([]byte, []int) {
+	return file_server_rank_rank_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QueryRankSnapshotRs) GetRankId() int32 {
+	if x != nil {
+		return x.RankId
+	}
+	return 0
+}
+
+func (x *QueryRankSnapshotRs) GetSnapshotTime() int64 {
+	if x != nil {
+		return x.SnapshotTime
+	}
+	return 0
+}
+
+func (x *QueryRankSnapshotRs) GetObjId() int64 {
+	if x != nil {
+		return x.ObjId
+	}
+	return 0
+}
+
+func (x *QueryRankSnapshotRs) GetRankIndex() int32 {
+	if x != nil {
+		return x.RankIndex
+	}
+	return 0
 }
 
 // 踢掉其他节点排行榜缓存
@@ -389,7 +762,7 @@ type KickRankDataRq struct {
 func (x *KickRankDataRq) Reset() {
 	*x = KickRankDataRq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_rank_rank_proto_msgTypes[6]
+		mi := &file_server_rank_rank_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -402,7 +775,7 @@ func (x *KickRankDataRq) String() string {
 func (*KickRankDataRq) ProtoMessage() {}
 
 func (x *KickRankDataRq) ProtoReflect() protoreflect.Message {
-	mi := &file_server_rank_rank_proto_msgTypes[6]
+	mi := &file_server_rank_rank_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,7 +788,7 @@ func (x *KickRankDataRq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KickRankDataRq.ProtoReflect.Descriptor instead.
 func (*KickRankDataRq) Descriptor() ([]byte, []int) {
-	return file_server_rank_rank_proto_rawDescGZIP(), []int{6}
+	return file_server_rank_rank_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *KickRankDataRq) GetRankId() int32 {
@@ -444,7 +817,7 @@ type KickRankDataRs struct {
 func (x *KickRankDataRs) Reset() {
 	*x = KickRankDataRs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_rank_rank_proto_msgTypes[7]
+		mi := &file_server_rank_rank_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -457,7 +830,7 @@ func (x *KickRankDataRs) String() string {
 func (*KickRankDataRs) ProtoMessage() {}
 
 func (x *KickRankDataRs) ProtoReflect() protoreflect.Message {
-	mi := &file_server_rank_rank_proto_msgTypes[7]
+	mi := &file_server_rank_rank_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -470,7 +843,7 @@ func (x *KickRankDataRs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KickRankDataRs.ProtoReflect.Descriptor instead.
 func (*KickRankDataRs) Descriptor() ([]byte, []int) {
-	return file_server_rank_rank_proto_rawDescGZIP(), []int{7}
+	return file_server_rank_rank_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *KickRankDataRs) GetRankId() int32 {
@@ -520,14 +893,53 @@ var file_server_rank_rank_proto_rawDesc = []byte{
 	0x01, 0x28, 0x03, 0x52, 0x03, 0x45, 0x6e, 0x64, 0x12, 0x31, 0x0a, 0x09, 0x4d, 0x65, 0x74, 0x61,
 	0x64, 0x61, 0x74, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x52, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x22, 0x59, 0x0a, 0x0e, 0x53,
-	0x65, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x71, 0x12, 0x16, 0x0a,
-	0x06, 0x52, 0x61, 0x6e, 0x6b, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x52,
-	0x61, 0x6e, 0x6b, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x52, 0x61, 0x6e, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x10, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x52, 0x61, 0x6e,
-	0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x73, 0x22, 0x48, 0x0a, 0x0e, 0x4b, 0x69, 0x63, 0x6b,
+	0x52, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x22, 0x66, 0x0a, 0x12, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x61, 0x6e, 0x6b, 0x42, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52,
+	0x71, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x61, 0x6e, 0x6b, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x52, 0x61, 0x6e, 0x6b, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x4d, 0x69, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x4d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x4d,
+	0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x4d, 0x61, 0x78, 0x12, 0x14, 0x0a,
+	0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x22, 0x5f, 0x0a, 0x12, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x61, 0x6e, 0x6b,
+	0x42, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x61, 0x6e,
+	0x6b, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x52, 0x61, 0x6e, 0x6b, 0x49,
+	0x64, 0x12, 0x31, 0x0a, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x61, 0x6e,
+	0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x22, 0x59, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x52, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x61, 0x6e, 0x6b, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x52, 0x61, 0x6e, 0x6b, 0x49, 0x64, 0x12, 0x2f,
+	0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0x10, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52,
+	0x73, 0x22, 0x70, 0x0a, 0x0f, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x52, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x61, 0x6e, 0x6b, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x52, 0x61, 0x6e, 0x6b, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x08,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a,
+	0x05, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x44, 0x65,
+	0x6c, 0x74, 0x61, 0x22, 0x42, 0x0a, 0x0f, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x52, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x67, 0x0a, 0x13, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x61, 0x6e, 0x6b, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x71, 0x12, 0x16,
+	0x0a, 0x06, 0x52, 0x61, 0x6e, 0x6b, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x52, 0x61, 0x6e, 0x6b, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x4f, 0x62,
+	0x6a, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x4f, 0x62, 0x6a, 0x49, 0x64,
+	0x22, 0x85, 0x01, 0x0a, 0x13, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x61, 0x6e, 0x6b,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x52, 0x61, 0x6e, 0x6b, 0x49, 0x64,
+	0x12, 0x22, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x4f, 0x62, 0x6a, 0x49, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x4f, 0x62, 0x6a, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x52, 0x61,
+	0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x52,
+	0x61, 0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x48, 0x0a, 0x0e, 0x4b, 0x69, 0x63, 0x6b,
 	0x52, 0x61, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x61,
 	0x6e, 0x6b, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x52, 0x61, 0x6e, 0x6b,
 	0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x61, 0x6e, 0x6b, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64,
@@ -536,7 +948,7 @@ var file_server_rank_rank_proto_rawDesc = []byte{
 	0x74, 0x61, 0x52, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x61, 0x6e, 0x6b, 0x49, 0x64, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x52, 0x61, 0x6e, 0x6b, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
 	0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x32, 0x9d, 0x02, 0x0a, 0x0b, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x6f, 0x72, 0x32, 0xf5, 0x03, 0x0a, 0x0b, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69,
 	0x63, 0x65, 0x12, 0x48, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x61, 0x6e, 0x6b, 0x42,
 	0x79, 0x4f, 0x62, 0x6a, 0x49, 0x64, 0x12, 0x18, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x2e, 0x51, 0x75,
 	0x65, 0x72, 0x79, 0x52, 0x61, 0x6e, 0x6b, 0x42, 0x79, 0x4f, 0x62, 0x6a, 0x49, 0x64, 0x52, 0x71,
@@ -546,18 +958,32 @@ var file_server_rank_rank_proto_rawDesc = []byte{
 	0x12, 0x18, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x61, 0x6e,
 	0x6b, 0x42, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x71, 0x1a, 0x18, 0x2e, 0x72, 0x61, 0x6e,
 	0x6b, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x61, 0x6e, 0x6b, 0x42, 0x79, 0x52, 0x61, 0x6e,
-	0x67, 0x65, 0x52, 0x73, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x52, 0x61, 0x6e,
-	0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x65,
-	0x74, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x71, 0x1a, 0x14, 0x2e, 0x72,
-	0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65,
-	0x52, 0x73, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0c, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x6b,
-	0x44, 0x61, 0x74, 0x61, 0x12, 0x14, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x2e, 0x4b, 0x69, 0x63, 0x6b,
-	0x52, 0x61, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x71, 0x1a, 0x14, 0x2e, 0x72, 0x61, 0x6e,
-	0x6b, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x73,
-	0x22, 0x00, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64, 0x65, 0x6e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x72,
-	0x61, 0x6e, 0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x67, 0x65, 0x52, 0x73, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
+	0x61, 0x6e, 0x6b, 0x42, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x2e, 0x72, 0x61, 0x6e,
+	0x6b, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x61, 0x6e, 0x6b, 0x42, 0x79, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x52, 0x71, 0x1a, 0x18, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x61, 0x6e, 0x6b, 0x42, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x73, 0x22, 0x00,
+	0x12, 0x3c, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x12, 0x14, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x52, 0x71, 0x1a, 0x14, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x2e, 0x53, 0x65,
+	0x74, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x73, 0x22, 0x00, 0x12, 0x3f,
+	0x0a, 0x0d, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x15, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x52, 0x71, 0x1a, 0x15, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x2e, 0x49, 0x6e,
+	0x63, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x73, 0x22, 0x00, 0x12,
+	0x4b, 0x0a, 0x11, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x12, 0x19, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x61, 0x6e, 0x6b, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x71, 0x1a,
+	0x19, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x61, 0x6e, 0x6b,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x73, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0c,
+	0x4b, 0x69, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x14, 0x2e, 0x72,
+	0x61, 0x6e, 0x6b, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x71, 0x1a, 0x14, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x61,
+	0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x73, 0x22, 0x00, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x61, 0x73, 0x74, 0x2d, 0x65, 0x64,
+	0x65, 0x6e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x72, 0x61, 0x6e, 0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -572,35 +998,50 @@ func file_server_rank_rank_proto_rawDescGZIP() []byte {
 	return file_server_rank_rank_proto_rawDescData
 }
 
-var file_server_rank_rank_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_server_rank_rank_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_server_rank_rank_proto_goTypes = []interface{}{
 	(*QueryRankByObjIdRq)(nil),  // 0: rank.QueryRankByObjIdRq
 	(*QueryRankByObjIdRs)(nil),  // 1: rank.QueryRankByObjIdRs
 	(*QueryRankByRangeRq)(nil),  // 2: rank.QueryRankByRangeRq
 	(*QueryRankByRangeRs)(nil),  // 3: rank.QueryRankByRangeRs
-	(*SetRankScoreRq)(nil),      // 4: rank.SetRankScoreRq
-	(*SetRankScoreRs)(nil),      // 5: rank.SetRankScoreRs
-	(*KickRankDataRq)(nil),      // 6: rank.KickRankDataRq
-	(*KickRankDataRs)(nil),      // 7: rank.KickRankDataRs
-	(*global.RankMetadata)(nil), // 8: proto.RankMetadata
+	(*QueryRankByScoreRq)(nil),  // 4: rank.QueryRankByScoreRq
+	(*QueryRankByScoreRs)(nil),  // 5: rank.QueryRankByScoreRs
+	(*SetRankScoreRq)(nil),      // 6: rank.SetRankScoreRq
+	(*SetRankScoreRs)(nil),      // 7: rank.SetRankScoreRs
+	(*IncrRankScoreRq)(nil),     // 8: rank.IncrRankScoreRq
+	(*IncrRankScoreRs)(nil),     // 9: rank.IncrRankScoreRs
+	(*QueryRankSnapshotRq)(nil), // 10: rank.QueryRankSnapshotRq
+	(*QueryRankSnapshotRs)(nil), // 11: rank.QueryRankSnapshotRs
+	(*KickRankDataRq)(nil),      // 12: rank.KickRankDataRq
+	(*KickRankDataRs)(nil),      // 13: rank.KickRankDataRs
+	(*global.RankMetadata)(nil), // 14: proto.RankMetadata
 }
 var file_server_rank_rank_proto_depIdxs = []int32{
-	8, // 0: rank.QueryRankByObjIdRs.Metadata:type_name -> proto.RankMetadata
-	8, // 1: rank.QueryRankByRangeRs.Metadatas:type_name -> proto.RankMetadata
-	8, // 2: rank.SetRankScoreRq.Metadata:type_name -> proto.RankMetadata
-	0, // 3: rank.RankService.QueryRankByObjId:input_type -> rank.QueryRankByObjIdRq
-	2, // 4: rank.RankService.QueryRankByRange:input_type -> rank.QueryRankByRangeRq
-	4, // 5: rank.RankService.SetRankScore:input_type -> rank.SetRankScoreRq
-	6, // 6: rank.RankService.KickRankData:input_type -> rank.KickRankDataRq
-	1, // 7: rank.RankService.QueryRankByObjId:output_type -> rank.QueryRankByObjIdRs
-	3, // 8: rank.RankService.QueryRankByRange:output_type -> rank.QueryRankByRangeRs
-	5, // 9: rank.RankService.SetRankScore:output_type -> rank.SetRankScoreRs
-	7, // 10: rank.RankService.KickRankData:output_type -> rank.KickRankDataRs
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	14, // 0: rank.QueryRankByObjIdRs.Metadata:type_name -> proto.RankMetadata
+	14, // 1: rank.QueryRankByRangeRs.Metadatas:type_name -> proto.RankMetadata
+	14, // 2: rank.QueryRankByScoreRs.Metadatas:type_name -> proto.RankMetadata
+	14, // 3: rank.SetRankScoreRq.Metadata:type_name -> proto.RankMetadata
+	14, // 4: rank.IncrRankScoreRq.Metadata:type_name -> proto.RankMetadata
+	14, // 5: rank.IncrRankScoreRs.Metadata:type_name -> proto.RankMetadata
+	0,  // 6: rank.RankService.QueryRankByObjId:input_type -> rank.QueryRankByObjIdRq
+	2,  // 7: rank.RankService.QueryRankByRange:input_type -> rank.QueryRankByRangeRq
+	4,  // 8: rank.RankService.QueryRankByScore:input_type -> rank.QueryRankByScoreRq
+	6,  // 9: rank.RankService.SetRankScore:input_type -> rank.SetRankScoreRq
+	8,  // 10: rank.RankService.IncrRankScore:input_type -> rank.IncrRankScoreRq
+	10, // 11: rank.RankService.QueryRankSnapshot:input_type -> rank.QueryRankSnapshotRq
+	12, // 12: rank.RankService.KickRankData:input_type -> rank.KickRankDataRq
+	1,  // 13: rank.RankService.QueryRankByObjId:output_type -> rank.QueryRankByObjIdRs
+	3,  // 14: rank.RankService.QueryRankByRange:output_type -> rank.QueryRankByRangeRs
+	5,  // 15: rank.RankService.QueryRankByScore:output_type -> rank.QueryRankByScoreRs
+	7,  // 16: rank.RankService.SetRankScore:output_type -> rank.SetRankScoreRs
+	9,  // 17: rank.RankService.IncrRankScore:output_type -> rank.IncrRankScoreRs
+	11, // 18: rank.RankService.QueryRankSnapshot:output_type -> rank.QueryRankSnapshotRs
+	13, // 19: rank.RankService.KickRankData:output_type -> rank.KickRankDataRs
+	13, // [13:20] is the sub-list for method output_type
+	6,  // [6:13] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_server_rank_rank_proto_init() }
@@ -658,7 +1099,7 @@ func file_server_rank_rank_proto_init() {
 			}
 		}
 		file_server_rank_rank_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetRankScoreRq); i {
+			switch v := v.(*QueryRankByScoreRq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -670,7 +1111,7 @@ func file_server_rank_rank_proto_init() {
 			}
 		}
 		file_server_rank_rank_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetRankScoreRs); i {
+			switch v := v.(*QueryRankByScoreRs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -682,7 +1123,7 @@ func file_server_rank_rank_proto_init() {
 			}
 		}
 		file_server_rank_rank_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KickRankDataRq); i {
+			switch v := v.(*SetRankScoreRq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -694,6 +1135,78 @@ func file_server_rank_rank_proto_init() {
 			}
 		}
 		file_server_rank_rank_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetRankScoreRs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_rank_rank_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IncrRankScoreRq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_rank_rank_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IncrRankScoreRs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_rank_rank_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryRankSnapshotRq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_rank_rank_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryRankSnapshotRs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_rank_rank_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KickRankDataRq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_rank_rank_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*KickRankDataRs); i {
 			case 0:
 				return &v.state
@@ -712,7 +1225,7 @@ func file_server_rank_rank_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_server_rank_rank_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -39,7 +39,10 @@ func NewRankServiceEndpoints() []*api.Endpoint {
 type RankService interface {
 	QueryRankByObjId(ctx context.Context, in *QueryRankByObjIdRq, opts ...client.CallOption) (*QueryRankByObjIdRs, error)
 	QueryRankByRange(ctx context.Context, in *QueryRankByRangeRq, opts ...client.CallOption) (*QueryRankByRangeRs, error)
+	QueryRankByScore(ctx context.Context, in *QueryRankByScoreRq, opts ...client.CallOption) (*QueryRankByScoreRs, error)
 	SetRankScore(ctx context.Context, in *SetRankScoreRq, opts ...client.CallOption) (*SetRankScoreRs, error)
+	IncrRankScore(ctx context.Context, in *IncrRankScoreRq, opts ...client.CallOption) (*IncrRankScoreRs, error)
+	QueryRankSnapshot(ctx context.Context, in *QueryRankSnapshotRq, opts ...client.CallOption) (*QueryRankSnapshotRs, error)
 	KickRankData(ctx context.Context, in *KickRankDataRq, opts ...client.CallOption) (*KickRankDataRs, error)
 }
 
@@ -75,6 +78,16 @@ func (c *rankService) QueryRankByRange(ctx context.Context, in *QueryRankByRange
 	return out, nil
 }
 
+func (c *rankService) QueryRankByScore(ctx context.Context, in *QueryRankByScoreRq, opts ...client.CallOption) (*QueryRankByScoreRs, error) {
+	req := c.c.NewRequest(c.name, "RankService.QueryRankByScore", in)
+	out := new(QueryRankByScoreRs)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rankService) SetRankScore(ctx context.Context, in *SetRankScoreRq, opts ...client.CallOption) (*SetRankScoreRs, error) {
 	req := c.c.NewRequest(c.name, "RankService.SetRankScore", in)
 	out := new(SetRankScoreRs)
@@ -85,6 +98,26 @@ func (c *rankService) SetRankScore(ctx context.Context, in *SetRankScoreRq, opts
 	return out, nil
 }
 
+func (c *rankService) IncrRankScore(ctx context.Context, in *IncrRankScoreRq, opts ...client.CallOption) (*IncrRankScoreRs, error) {
+	req := c.c.NewRequest(c.name, "RankService.IncrRankScore", in)
+	out := new(IncrRankScoreRs)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rankService) QueryRankSnapshot(ctx context.Context, in *QueryRankSnapshotRq, opts ...client.CallOption) (*QueryRankSnapshotRs, error) {
+	req := c.c.NewRequest(c.name, "RankService.QueryRankSnapshot", in)
+	out := new(QueryRankSnapshotRs)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rankService) KickRankData(ctx context.Context, in *KickRankDataRq, opts ...client.CallOption) (*KickRankDataRs, error) {
 	req := c.c.NewRequest(c.name, "RankService.KickRankData", in)
 	out := new(KickRankDataRs)
@@ -100,7 +133,10 @@ func (c *rankService) KickRankData(ctx context.Context, in *KickRankDataRq, opts
 type RankServiceHandler interface {
 	QueryRankByObjId(context.Context, *QueryRankByObjIdRq, *QueryRankByObjIdRs) error
 	QueryRankByRange(context.Context, *QueryRankByRangeRq, *QueryRankByRangeRs) error
+	QueryRankByScore(context.Context, *QueryRankByScoreRq, *QueryRankByScoreRs) error
 	SetRankScore(context.Context, *SetRankScoreRq, *SetRankScoreRs) error
+	IncrRankScore(context.Context, *IncrRankScoreRq, *IncrRankScoreRs) error
+	QueryRankSnapshot(context.Context, *QueryRankSnapshotRq, *QueryRankSnapshotRs) error
 	KickRankData(context.Context, *KickRankDataRq, *KickRankDataRs) error
 }
 
@@ -108,7 +144,10 @@ func RegisterRankServiceHandler(s server.Server, hdlr RankServiceHandler, opts .
 	type rankService interface {
 		QueryRankByObjId(ctx context.Context, in *QueryRankByObjIdRq, out *QueryRankByObjIdRs) error
 		QueryRankByRange(ctx context.Context, in *QueryRankByRangeRq, out *QueryRankByRangeRs) error
+		QueryRankByScore(ctx context.Context, in *QueryRankByScoreRq, out *QueryRankByScoreRs) error
 		SetRankScore(ctx context.Context, in *SetRankScoreRq, out *SetRankScoreRs) error
+		IncrRankScore(ctx context.Context, in *IncrRankScoreRq, out *IncrRankScoreRs) error
+		QueryRankSnapshot(ctx context.Context, in *QueryRankSnapshotRq, out *QueryRankSnapshotRs) error
 		KickRankData(ctx context.Context, in *KickRankDataRq, out *KickRankDataRs) error
 	}
 	type RankService struct {
@@ -130,10 +169,22 @@ func (h *rankServiceHandler) QueryRankByRange(ctx context.Context, in *QueryRank
 	return h.RankServiceHandler.QueryRankByRange(ctx, in, out)
 }
 
+func (h *rankServiceHandler) QueryRankByScore(ctx context.Context, in *QueryRankByScoreRq, out *QueryRankByScoreRs) error {
+	return h.RankServiceHandler.QueryRankByScore(ctx, in, out)
+}
+
 func (h *rankServiceHandler) SetRankScore(ctx context.Context, in *SetRankScoreRq, out *SetRankScoreRs) error {
 	return h.RankServiceHandler.SetRankScore(ctx, in, out)
 }
 
+func (h *rankServiceHandler) IncrRankScore(ctx context.Context, in *IncrRankScoreRq, out *IncrRankScoreRs) error {
+	return h.RankServiceHandler.IncrRankScore(ctx, in, out)
+}
+
+func (h *rankServiceHandler) QueryRankSnapshot(ctx context.Context, in *QueryRankSnapshotRq, out *QueryRankSnapshotRs) error {
+	return h.RankServiceHandler.QueryRankSnapshot(ctx, in, out)
+}
+
 func (h *rankServiceHandler) KickRankData(ctx context.Context, in *KickRankDataRq, out *KickRankDataRs) error {
 	return h.RankServiceHandler.KickRankData(ctx, in, out)
 }
@@ -14,9 +14,11 @@ import (
 	"github.com/east-eden/server/logger"
 	"github.com/east-eden/server/store"
 	"github.com/east-eden/server/utils"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v2"
 	"github.com/urfave/cli/v2/altsrc"
+	"stathat.com/c/consistent"
 )
 
 type Chat struct {
@@ -25,6 +27,13 @@ type Chat struct {
 	SnowflakeStartTime int64    `bson:"snowflake_starttime" json:"snowflake_starttime"`
 	sync.RWMutex       `bson:"-" json:"-"`
 	waitGroup          utils.WaitGroupWrapper `bson:"-" json:"-"`
+
+	gin        *GinServer             `bson:"-" json:"-"`
+	manager    *ChatManager           `bson:"-" json:"-"`
+	mi         *MicroService          `bson:"-" json:"-"`
+	rpcHandler *RpcHandler            `bson:"-" json:"-"`
+	pubSub     *PubSub                `bson:"-" json:"-"`
+	cons       *consistent.Consistent `bson:"-" json:"-"`
 }
 
 func NewChat() (*Chat, error) {
@@ -73,17 +82,74 @@ func (c *Chat) Before(ctx *cli.Context) error {
 	// load excel entries
 	excel.ReadAllEntries("config/csv/")
 
-	ctx.Set("config_file", "config/chat/config.toml")
+	_ = ctx.Set("config_file", "config/chat/config.toml")
 	return altsrc.InitInputSourceWithContext(c.app.Flags, altsrc.NewTomlSourceFromFlagFunc("config_file"))(ctx)
 }
 
 func (c *Chat) Action(ctx *cli.Context) error {
+	// log settings
+	logLevel, err := zerolog.ParseLevel(ctx.String("log_level"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+
+	log.Logger = log.Level(logLevel)
+
+	exitCh := make(chan error)
+	var once sync.Once
+	exitFunc := func(err error) {
+		once.Do(func() {
+			if err != nil {
+				log.Fatal().Err(err).Msg("Chat Action() failed")
+			}
+			exitCh <- err
+		})
+	}
+
 	c.ID = int16(ctx.Int("chat_id"))
 
+	store.NewStore(ctx, store.Node(c.ID))
+
 	// init snowflakes
 	c.initSnowflake()
 
-	return nil
+	c.manager = NewChatManager(ctx, c)
+	c.gin = NewGinServer(ctx, c)
+	c.mi = NewMicroService(ctx, c)
+	c.rpcHandler = NewRpcHandler(ctx, c)
+	c.pubSub = NewPubSub(c)
+	c.cons = consistent.New()
+	c.cons.NumberOfReplicas = define.ConsistentNodeReplicas
+
+	// micro run
+	c.waitGroup.Wrap(func() {
+		defer utils.CaptureException()
+		exitFunc(c.mi.Run(ctx.Context))
+	})
+
+	// chat manager run
+	c.waitGroup.Wrap(func() {
+		defer utils.CaptureException()
+		err := c.manager.Run(ctx)
+		_ = utils.ErrCheck(err, "ChatManager.Run failed")
+		c.manager.Exit(ctx)
+	})
+
+	// sensitive words hot reload
+	c.waitGroup.Wrap(func() {
+		defer utils.CaptureException()
+		err := excel.WatchEntries(ctx.Context, "config/csv/", "SensitiveWord.csv")
+		_ = utils.ErrCheck(err, "excel.WatchEntries failed")
+	})
+
+	// gin server
+	c.waitGroup.Wrap(func() {
+		defer utils.CaptureException()
+		exitFunc(c.gin.Main(ctx))
+		c.gin.Exit(ctx.Context)
+	})
+
+	return <-exitCh
 }
 
 func (c *Chat) Run(arguments []string) error {
@@ -100,4 +166,5 @@ func (c *Chat) Run(arguments []string) error {
 
 func (c *Chat) Stop() {
 	c.waitGroup.Wait()
+	store.GetStore().Exit()
 }
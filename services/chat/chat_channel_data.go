@@ -0,0 +1,216 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/east-eden/server/define"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/east-eden/server/store"
+	"github.com/east-eden/server/utils"
+	"github.com/east-eden/server/utils/ringbuffer"
+	"github.com/hellodudu/task"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrInvalidChatChannel  = errors.New("invalid chat channel")
+	ErrInvalidChatMessage  = errors.New("invalid chat message")
+	ErrChatMessageNotFound = errors.New("chat message not found")
+
+	ChatChannelDataTaskTimeout = time.Hour // 频道任务超时
+)
+
+// 持久化的历史消息
+type ChatRecord struct {
+	Seq  uint64 `json:"seq" bson:"seq"`
+	Data []byte `json:"data" bson:"data"` // pbGlobal.ChatMessage序列化数据
+}
+
+// 聊天频道
+type ChatChannelData struct {
+	Id                 string `json:"_id" bson:"_id"` // 频道key
+	define.ChatChannel `json:"channel" bson:"channel"`
+	LastSaveNodeId     int32                 `json:"last_save_node_id" bson:"last_save_node_id"`
+	LastSeq            uint64                `json:"last_seq" bson:"last_seq"`         // 最后一条消息序号
+	History            []*ChatRecord         `json:"history" bson:"history,omitempty"` // 历史消息, 只在读写db时使用, 为空时不保存null, 否则无法$push/$pull
+	NodeId             int16                 `json:"-" bson:"-"`                       // 当前节点id
+	buffer             *ringbuffer.SeqBuffer `json:"-" bson:"-"`                       // 历史消息缓存
+	tasker             *task.Tasker          `json:"-" bson:"-"`
+	rpcHandler         *RpcHandler           `json:"-" bson:"-"`
+}
+
+func NewChatChannelData() any {
+	return &ChatChannelData{}
+}
+
+func (c *ChatChannelData) Init(nodeId int16, rpcHandler *RpcHandler) {
+	c.Id = ""
+	c.LastSaveNodeId = -1
+	c.LastSeq = 0
+	c.History = nil
+	c.NodeId = nodeId
+	c.rpcHandler = rpcHandler
+	c.buffer = ringbuffer.NewSeqBuffer(define.Chat_HistoryMaxSize)
+}
+
+func (c *ChatChannelData) InitTask() {
+	c.tasker = task.NewTasker()
+	c.tasker.Init(
+		task.WithStopFns(c.onTaskStop),
+		task.WithTimeout(ChatChannelDataTaskTimeout),
+	)
+}
+
+func (c *ChatChannelData) IsTaskRunning() bool {
+	return c.tasker.IsRunning()
+}
+
+func (c *ChatChannelData) Load(channel define.ChatChannel) error {
+	err := store.GetStore().FindOne(context.Background(), define.StoreType_Chat, channel.Key(), c)
+
+	// 创建新频道数据
+	if errors.Is(err, store.ErrNoResult) {
+		c.Id = channel.Key()
+		c.ChatChannel = channel
+		c.LastSaveNodeId = int32(c.NodeId)
+		errSave := store.GetStore().UpdateOne(context.Background(), define.StoreType_Chat, c.Id, c, true)
+		utils.ErrPrint(errSave, "UpdateOne failed when ChatChannelData.Load", channel)
+		return errSave
+	}
+
+	if !utils.ErrCheck(err, "FindOne failed when ChatChannelData.Load", channel) {
+		return err
+	}
+
+	// 历史消息放入缓存
+	for _, r := range c.History {
+		c.buffer.Push(r.Seq, 0, r.Data)
+	}
+	c.History = nil
+
+	return nil
+}
+
+func (c *ChatChannelData) onTaskStop() {
+	log.Info().Caller().Interface("channel", c.ChatChannel).Msg("ChatChannelData task stopped...")
+}
+
+func (c *ChatChannelData) TaskRun(ctx context.Context) error {
+	return c.tasker.Run(ctx)
+}
+
+func (c *ChatChannelData) Stop() {
+	c.tasker.Stop()
+}
+
+func (c *ChatChannelData) AddTask(ctx context.Context, fn task.TaskHandler, p ...any) error {
+	return c.tasker.AddWait(ctx, fn, p...)
+}
+
+// 保存新消息, 只追加这一条记录, 并按缓存中的消息数量裁剪db中的历史消息
+func (c *ChatChannelData) save(ctx context.Context, seq uint64, data []byte) error {
+	c.LastSaveNodeId = int32(c.NodeId)
+	fields := map[string]any{
+		"last_seq":          c.LastSeq,
+		"last_save_node_id": c.LastSaveNodeId,
+	}
+	err := store.GetStore().UpdateFields(ctx, define.StoreType_Chat, c.Id, fields)
+	if !utils.ErrCheck(err, "UpdateFields failed when ChatChannelData.save", c.ChatChannel) {
+		return err
+	}
+
+	// 缓存按字节数丢弃旧消息, db中只保留和缓存相同的最后Len()条
+	record := bson.M{
+		"$each":  []*ChatRecord{{Seq: seq, Data: data}},
+		"$slice": -c.buffer.Len(),
+	}
+	err = store.GetStore().PushArray(ctx, define.StoreType_Chat, c.Id, "history", record)
+	_ = utils.ErrCheck(err, "PushArray failed when ChatChannelData.save", c.ChatChannel)
+	return err
+}
+
+// 添加消息, 填充消息id, 序号和发送时间
+func (c *ChatChannelData) Push(ctx context.Context, msg *pbGlobal.ChatMessage) error {
+	if msg == nil {
+		return ErrInvalidChatMessage
+	}
+
+	id, err := utils.NextID(define.SnowFlake_Chat)
+	if !utils.ErrCheck(err, "NextID failed when ChatChannelData.Push", c.ChatChannel) {
+		return err
+	}
+
+	c.LastSeq++
+	msg.Id = id
+	msg.Channel = c.ChatChannel.ToPB()
+	msg.Seq = c.LastSeq
+	msg.Time = int32(time.Now().Unix())
+
+	data, err := proto.Marshal(msg)
+	if !utils.ErrCheck(err, "proto.Marshal failed when ChatChannelData.Push", c.ChatChannel) {
+		return err
+	}
+
+	c.buffer.Push(msg.Seq, 0, data)
+	return c.save(ctx, msg.Seq, data)
+}
+
+// 获取lastSeq之后的历史消息, 部分消息已被丢弃时返回缓存中所有消息
+func (c *ChatChannelData) GetHistory(ctx context.Context, lastSeq uint64) ([]*pbGlobal.ChatMessage, error) {
+	records, ok := c.buffer.Since(lastSeq)
+	if !ok {
+		records = c.buffer.All()
+	}
+
+	msgs := make([]*pbGlobal.ChatMessage, 0, len(records))
+	for _, r := range records {
+		msg := &pbGlobal.ChatMessage{}
+		err := proto.Unmarshal(r.Data, msg)
+		if !utils.ErrCheck(err, "proto.Unmarshal failed when ChatChannelData.GetHistory", c.ChatChannel, r.Seq) {
+			continue
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+// 获取被举报的消息及之前最多num条消息的序列化数据
+func (c *ChatChannelData) GetReportContext(seq uint64, num int) (*pbGlobal.ChatMessage, [][]byte, error) {
+	records := c.buffer.All()
+
+	end := -1
+	for n, r := range records {
+		if r.Seq == seq {
+			end = n
+			break
+		}
+	}
+
+	if end == -1 {
+		return nil, nil, ErrChatMessageNotFound
+	}
+
+	reported := &pbGlobal.ChatMessage{}
+	err := proto.Unmarshal(records[end].Data, reported)
+	if !utils.ErrCheck(err, "proto.Unmarshal failed when ChatChannelData.GetReportContext", c.ChatChannel, seq) {
+		return nil, nil, err
+	}
+
+	start := end + 1 - num
+	if start < 0 {
+		start = 0
+	}
+
+	msgs := make([][]byte, 0, end+1-start)
+	for _, r := range records[start : end+1] {
+		msgs = append(msgs, append([]byte(nil), r.Data...))
+	}
+
+	return reported, msgs, nil
+}
@@ -0,0 +1,169 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/east-eden/server/define"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/east-eden/server/store"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	chatId      int16 = 501
+	chatManager *ChatManager
+)
+
+func init() {
+	ctx := store.NewTestMemStore("chat_test", chatId)
+	chatManager = NewChatManager(ctx, &Chat{ID: chatId})
+}
+
+func newTestChannelData(t *testing.T, channel define.ChatChannel) *ChatChannelData {
+	cd := NewChatChannelData().(*ChatChannelData)
+	cd.Init(chatId, nil)
+	if err := cd.Load(channel); err != nil {
+		t.Fatalf("load channel %v failed: %v", channel, err)
+	}
+	return cd
+}
+
+func TestChatChannelValid(t *testing.T) {
+	cases := []struct {
+		channel define.ChatChannel
+		valid   bool
+	}{
+		{define.ChatChannel{Type: define.ChatChannel_World, TypeId: 201}, true},
+		{define.ChatChannel{Type: define.ChatChannel_World, TypeId: 0}, false},
+		{define.ChatChannel{Type: define.ChatChannel_CrossWorld, TypeId: 0}, true},
+		{define.ChatChannel{Type: define.ChatChannel_System, TypeId: 1}, false},
+		{define.ChatChannel{Type: define.ChatChannel_Private, TypeId: 10001}, true},
+		{define.ChatChannel{Type: define.ChatChannel_End, TypeId: 1}, false},
+	}
+
+	for _, c := range cases {
+		if c.channel.Valid() != c.valid {
+			t.Errorf("channel %v valid should be %v", c.channel, c.valid)
+		}
+	}
+}
+
+func TestChatChannelHistory(t *testing.T) {
+	ctx := context.Background()
+	channel := define.ChatChannel{Type: define.ChatChannel_World, TypeId: 201}
+	cd := newTestChannelData(t, channel)
+
+	for n := 0; n < 3; n++ {
+		msg := &pbGlobal.ChatMessage{SenderId: 10001, SenderName: "tester", Content: "hello"}
+		if err := cd.Push(ctx, msg); err != nil {
+			t.Fatalf("push failed: %v", err)
+		}
+
+		if msg.Id == 0 || msg.Seq != uint64(n+1) || msg.GetChannel().GetTypeId() != channel.TypeId {
+			t.Fatalf("pushed message not filled: %v", msg)
+		}
+	}
+
+	msgs, _ := cd.GetHistory(ctx, 1)
+	if len(msgs) != 2 || msgs[0].Seq != 2 || msgs[1].Seq != 3 {
+		t.Fatalf("history since 1 mismatch: %v", msgs)
+	}
+
+	// 从db重新加载, 历史消息和序号保持不变
+	store.GetStore().Flush()
+	reload := newTestChannelData(t, channel)
+	if reload.LastSeq != 3 {
+		t.Fatalf("reload last seq should be 3, got %d", reload.LastSeq)
+	}
+
+	msgs, _ = reload.GetHistory(ctx, 0)
+	if len(msgs) != 3 || msgs[2].GetContent() != "hello" {
+		t.Fatalf("reload history mismatch: %v", msgs)
+	}
+
+	msg := &pbGlobal.ChatMessage{SenderId: 10001, Content: "again"}
+	if err := reload.Push(ctx, msg); err != nil || msg.Seq != 4 {
+		t.Fatalf("push after reload failed: err=%v, seq=%d", err, msg.Seq)
+	}
+}
+
+func TestChatChannelHistoryBounded(t *testing.T) {
+	ctx := context.Background()
+	channel := define.ChatChannel{Type: define.ChatChannel_Private, TypeId: 10002}
+	cd := newTestChannelData(t, channel)
+
+	content := strings.Repeat("聊", define.Chat_ContentMaxLen)
+	total := 100
+	for n := 0; n < total; n++ {
+		if err := cd.Push(ctx, &pbGlobal.ChatMessage{SenderId: 10001, ReceiverId: 10002, Content: content}); err != nil {
+			t.Fatalf("push failed: %v", err)
+		}
+	}
+
+	msgs, _ := cd.GetHistory(ctx, 0)
+	if len(msgs) == 0 || len(msgs) >= total {
+		t.Fatalf("history should be bounded, got %d", len(msgs))
+	}
+
+	if msgs[len(msgs)-1].Seq != uint64(total) {
+		t.Fatalf("latest message should be kept, got seq %d", msgs[len(msgs)-1].Seq)
+	}
+
+	// 客户端序号已被丢弃时返回缓存中所有消息
+	since, _ := cd.GetHistory(ctx, 1)
+	if len(since) != len(msgs) {
+		t.Fatalf("history since discarded seq should return all, got %d", len(since))
+	}
+
+	// db中的历史消息只追加并裁剪到和缓存一致
+	store.GetStore().Flush()
+	var saved ChatChannelData
+	if err := store.GetStore().GetDB().FindOne(ctx, "chat", bson.M{"_id": channel.Key()}, &saved); err != nil {
+		t.Fatalf("find saved channel failed: %v", err)
+	}
+
+	if saved.LastSeq != uint64(total) || len(saved.History) != len(msgs) {
+		t.Fatalf("saved history should keep %d records with last seq %d, got %d records, last seq %d",
+			len(msgs), total, len(saved.History), saved.LastSeq)
+	}
+
+	for n, r := range saved.History {
+		if r.Seq != msgs[n].Seq {
+			t.Fatalf("saved history seq mismatch at %d: %d != %d", n, r.Seq, msgs[n].Seq)
+		}
+	}
+}
+
+func TestChatReportContext(t *testing.T) {
+	ctx := context.Background()
+	channel := define.ChatChannel{Type: define.ChatChannel_CrossWorld}
+	cd := newTestChannelData(t, channel)
+
+	for n := 1; n <= 15; n++ {
+		if err := cd.Push(ctx, &pbGlobal.ChatMessage{SenderId: int64(10000 + n), Content: "msg"}); err != nil {
+			t.Fatalf("push failed: %v", err)
+		}
+	}
+
+	reported, msgs, err := cd.GetReportContext(12, define.Chat_ReportContextNum)
+	if err != nil || reported.GetSenderId() != 10012 || len(msgs) != define.Chat_ReportContextNum {
+		t.Fatalf("report context mismatch: err=%v, reported=%v, len=%d", err, reported, len(msgs))
+	}
+
+	first := &pbGlobal.ChatMessage{}
+	if err := proto.Unmarshal(msgs[0], first); err != nil || first.Seq != 3 {
+		t.Fatalf("report context should start from seq 3: err=%v, msg=%v", err, first)
+	}
+
+	if _, msgs, _ := cd.GetReportContext(2, define.Chat_ReportContextNum); len(msgs) != 2 {
+		t.Fatalf("report context of seq 2 should have 2 messages, got %d", len(msgs))
+	}
+
+	if _, _, err := cd.GetReportContext(100, define.Chat_ReportContextNum); !errors.Is(err, ErrChatMessageNotFound) {
+		t.Fatalf("report unknown seq should fail, got %v", err)
+	}
+}
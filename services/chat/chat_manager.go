@@ -0,0 +1,420 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/east-eden/server/define"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/east-eden/server/store"
+	"github.com/east-eden/server/utils"
+	"github.com/east-eden/server/utils/cache"
+	"github.com/east-eden/server/utils/sensitive"
+	"github.com/hellodudu/task"
+	log "github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	chatCleanupInterval = 1 * time.Minute // cache cleanup interval
+	chatCacheExpire     = 1 * time.Hour   // cache缓存1小时
+
+	ErrChatChannelNotSupported = errors.New("chat channel not supported")
+	ErrInvalidChatContent      = errors.New("invalid chat content")
+	ErrChatSensitiveContent    = errors.New("chat content contains sensitive words")
+	ErrChatMuted               = errors.New("chat player muted")
+	ErrInvalidChatMuteDuration = errors.New("invalid chat mute duration")
+)
+
+type ChatManager struct {
+	c             *Chat
+	cacheChannels *cache.Cache
+	channelPool   sync.Pool
+	wg            utils.WaitGroupWrapper
+	mu            sync.Mutex
+}
+
+func NewChatManager(ctx *cli.Context, c *Chat) *ChatManager {
+	manager := &ChatManager{
+		c:             c,
+		cacheChannels: cache.New(chatCacheExpire, chatCleanupInterval),
+	}
+
+	// 频道池
+	manager.channelPool.New = NewChatChannelData
+
+	// 频道缓存删除时处理
+	manager.cacheChannels.OnEvicted(func(k, v any) {
+		v.(*ChatChannelData).Stop()
+		manager.channelPool.Put(v)
+	})
+
+	// 初始化db
+	store.GetStore().AddStoreInfo(define.StoreType_Chat, "chat", "_id")
+	if err := store.GetStore().MigrateDbTable("chat"); err != nil {
+		log.Fatal().Err(err).Msg("migrate collection chat failed")
+	}
+
+	store.GetStore().AddStoreInfo(define.StoreType_ChatMute, "chat_mute", "_id")
+	if err := store.GetStore().MigrateDbTable("chat_mute"); err != nil {
+		log.Fatal().Err(err).Msg("migrate collection chat_mute failed")
+	}
+
+	store.GetStore().AddStoreInfo(define.StoreType_ChatReport, "chat_report", "_id")
+	if err := store.GetStore().MigrateDbTable("chat_report", "reported_id"); err != nil {
+		log.Fatal().Err(err).Msg("migrate collection chat_report failed")
+	}
+
+	log.Info().Msg("ChatManager init ok ...")
+	return manager
+}
+
+func (m *ChatManager) Run(ctx *cli.Context) error {
+	<-ctx.Done()
+	log.Info().Msg("ChatManager context done...")
+	return nil
+}
+
+func (m *ChatManager) Exit(ctx *cli.Context) {
+	m.wg.Wait()
+	log.Info().Msg("ChatManager exit...")
+}
+
+func (m *ChatManager) KickAllChatChannelData() {
+	m.cacheChannels.DeleteAll()
+}
+
+// 踢掉频道缓存
+func (m *ChatManager) KickChatChannelData(channel define.ChatChannel, chatNodeId int32) error {
+	if !channel.Valid() {
+		return nil
+	}
+
+	// 踢掉本服ChatChannelData
+	if chatNodeId == int32(m.c.ID) {
+		cd, ok := m.cacheChannels.Get(channel.Key())
+		if !ok {
+			return nil
+		}
+
+		cd.(*ChatChannelData).Stop()
+		store.GetStore().Flush()
+		return nil
+
+	} else {
+		// chat节点不存在的话不用发送rpc
+		nodeId := fmt.Sprintf("chat-%d", chatNodeId)
+		srvs, err := m.c.mi.srv.Options().Registry.GetService("chat")
+		if err != nil {
+			return nil
+		}
+
+		hit := false
+		for _, srv := range srvs {
+			for _, node := range srv.Nodes {
+				if node.Id == nodeId {
+					hit = true
+					break
+				}
+			}
+		}
+
+		if !hit {
+			return nil
+		}
+
+		// 发送rpc踢掉其他服ChatChannelData
+		rs, err := m.c.rpcHandler.CallKickChatChannelData(channel, chatNodeId)
+		if !utils.ErrCheck(err, "kick chat channel data failed", channel, chatNodeId, rs) {
+			return err
+		}
+
+		// rpc调用成功
+		if rs.GetChannel().GetType() == channel.Type && rs.GetChannel().GetTypeId() == channel.TypeId {
+			return nil
+		}
+
+		return errors.New("kick chat channel data invalid error")
+	}
+}
+
+// 获取频道数据
+func (m *ChatManager) getChannelData(channel define.ChatChannel) (*ChatChannelData, error) {
+	if !channel.Valid() {
+		return nil, ErrInvalidChatChannel
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := channel.Key()
+	cache, ok := m.cacheChannels.Get(key)
+
+	if ok {
+		cd := cache.(*ChatChannelData)
+		if cd.IsTaskRunning() {
+			return cd, nil
+		}
+
+	} else {
+
+		// 缓存没有，从db加载
+		cache = m.channelPool.Get()
+		cd := cache.(*ChatChannelData)
+		cd.Init(m.c.ID, m.c.rpcHandler)
+		err := cd.Load(channel)
+		if !utils.ErrCheck(err, "ChatChannelData Load failed when ChatManager.getChannelData", channel) {
+			m.channelPool.Put(cache)
+			return nil, err
+		}
+
+		// 踢掉上一个节点的缓存
+		if cd.LastSaveNodeId != -1 && cd.LastSaveNodeId != int32(m.c.ID) {
+			err := m.KickChatChannelData(channel, cd.LastSaveNodeId)
+			if !utils.ErrCheck(err, "kick ChatChannelData failed", channel, cd.LastSaveNodeId, m.c.ID) {
+				return nil, err
+			}
+		}
+
+		m.cacheChannels.Set(key, cache, chatCacheExpire)
+	}
+
+	cd := cache.(*ChatChannelData)
+	cd.InitTask()
+	m.wg.Wrap(func() {
+		defer func() {
+			if err := recover(); err != nil {
+				stack := string(debug.Stack())
+				log.Error().Msgf("catch exception:%v, panic recovered with stack:%s", err, stack)
+			}
+
+			// 立即删除缓存
+			m.cacheChannels.Delete(key)
+		}()
+
+		ctx, _ := signal.NotifyContext(context.Background(), os.Interrupt)
+		for {
+			err := cd.TaskRun(ctx)
+			utils.ErrPrint(err, "ChatChannelData run failed", cd.ChatChannel)
+
+			// pull up goroutine when task panic
+			if errors.Is(err, task.ErrTaskPanic) {
+				continue
+			} else {
+				break
+			}
+		}
+	})
+
+	return cd, nil
+}
+
+func (m *ChatManager) AddTask(ctx context.Context, channel define.ChatChannel, fn task.TaskHandler) error {
+	cd, err := m.getChannelData(channel)
+	if err != nil {
+		return err
+	}
+
+	return cd.AddTask(ctx, fn, cd)
+}
+
+// 发送聊天消息, 保存到频道历史后推送给频道内的在线玩家
+func (m *ChatManager) SendChatMessage(ctx context.Context, msg *pbGlobal.ChatMessage) (*pbGlobal.ChatMessage, error) {
+	var channel define.ChatChannel
+	channel.FromPB(msg.GetChannel())
+
+	if channel.Type == define.ChatChannel_Guild {
+		return nil, ErrChatChannelNotSupported
+	}
+
+	if n := utf8.RuneCountInString(msg.GetContent()); n == 0 || n > define.Chat_ContentMaxLen {
+		return nil, ErrInvalidChatContent
+	}
+
+	// 禁言检查, 系统消息不受限制
+	if msg.GetSenderId() > 0 {
+		mute, err := m.getMute(ctx, msg.GetSenderId())
+		if err != nil {
+			return nil, err
+		}
+
+		if mute != nil && mute.IsMuted(time.Now().Unix()) {
+			return nil, ErrChatMuted
+		}
+	}
+
+	// 屏蔽字过滤
+	content, action, hit := sensitive.Filter(msg.GetContent())
+	if hit {
+		switch action {
+		case define.SensitiveAction_Reject:
+			return nil, ErrChatSensitiveContent
+
+		case define.SensitiveAction_ShadowBan:
+			return m.shadowBan(ctx, channel, msg)
+
+		default:
+			msg.Content = content
+		}
+	}
+
+	err := m.AddTask(
+		ctx,
+		channel,
+		func(c context.Context, p ...any) error {
+			cd := p[0].(*ChatChannelData)
+			return cd.Push(c, msg)
+		},
+	)
+
+	if !utils.ErrCheck(err, "AddTask failed when ChatManager.SendChatMessage", channel) {
+		return nil, err
+	}
+
+	// 推送范围
+	var gameId int32
+	var receiverIds []int64
+	switch channel.Type {
+	case define.ChatChannel_World:
+		gameId = int32(channel.TypeId)
+	case define.ChatChannel_Private:
+		receiverIds = []int64{channel.TypeId, msg.GetSenderId()}
+	}
+
+	err = m.c.pubSub.PubChatMessage(ctx, msg, gameId, receiverIds)
+	_ = utils.ErrCheck(err, "PubChatMessage failed when ChatManager.SendChatMessage", channel, msg.GetId())
+	return msg, nil
+}
+
+// 仅发送者可见, 消息不保存到频道历史
+func (m *ChatManager) shadowBan(ctx context.Context, channel define.ChatChannel, msg *pbGlobal.ChatMessage) (*pbGlobal.ChatMessage, error) {
+	id, err := utils.NextID(define.SnowFlake_Chat)
+	if !utils.ErrCheck(err, "NextID failed when ChatManager.shadowBan", channel) {
+		return nil, err
+	}
+
+	msg.Id = id
+	msg.Channel = channel.ToPB()
+	msg.Time = int32(time.Now().Unix())
+
+	log.Info().
+		Int64("sender_id", msg.GetSenderId()).
+		Interface("channel", channel).
+		Str("content", msg.GetContent()).
+		Msg("chat message shadow banned")
+
+	err = m.c.pubSub.PubChatMessage(ctx, msg, 0, []int64{msg.GetSenderId()})
+	_ = utils.ErrCheck(err, "PubChatMessage failed when ChatManager.shadowBan", channel, msg.GetId())
+	return msg, nil
+}
+
+// 查询频道历史消息
+func (m *ChatManager) QueryChatHistory(ctx context.Context, channel define.ChatChannel, lastSeq uint64) (msgs []*pbGlobal.ChatMessage, err error) {
+	err = m.AddTask(
+		ctx,
+		channel,
+		func(c context.Context, p ...any) error {
+			var e error
+			cd := p[0].(*ChatChannelData)
+			msgs, e = cd.GetHistory(c, lastSeq)
+			return e
+		},
+	)
+
+	_ = utils.ErrCheck(err, "AddTask failed when ChatManager.QueryChatHistory", channel, lastSeq)
+	return
+}
+
+// 查询玩家禁言信息, 没有禁言时返回nil
+func (m *ChatManager) getMute(ctx context.Context, playerId int64) (*define.ChatMute, error) {
+	mute := &define.ChatMute{}
+	err := store.GetStore().FindOne(ctx, define.StoreType_ChatMute, playerId, mute)
+	if errors.Is(err, store.ErrNoResult) {
+		return nil, nil
+	}
+
+	if !utils.ErrCheck(err, "FindOne failed when ChatManager.getMute", playerId) {
+		return nil, err
+	}
+
+	return mute, nil
+}
+
+// 禁言
+func (m *ChatManager) MutePlayer(ctx context.Context, playerId int64, duration int64, reason string, operator string) (*define.ChatMute, error) {
+	if duration <= 0 {
+		return nil, ErrInvalidChatMuteDuration
+	}
+
+	mute := &define.ChatMute{
+		PlayerId: playerId,
+		EndTime:  time.Now().Unix() + duration,
+		Reason:   reason,
+		Operator: operator,
+	}
+
+	err := store.GetStore().UpdateOne(ctx, define.StoreType_ChatMute, playerId, mute)
+	if !utils.ErrCheck(err, "UpdateOne failed when ChatManager.MutePlayer", playerId, duration) {
+		return nil, err
+	}
+
+	return mute, nil
+}
+
+// 解除禁言
+func (m *ChatManager) UnmutePlayer(ctx context.Context, playerId int64) error {
+	err := store.GetStore().DeleteOne(ctx, define.StoreType_ChatMute, playerId)
+	_ = utils.ErrCheck(err, "DeleteOne failed when ChatManager.UnmutePlayer", playerId)
+	return err
+}
+
+// 举报聊天消息, 保存被举报消息及之前的消息作为上下文
+func (m *ChatManager) ReportChatMessage(ctx context.Context, channel define.ChatChannel, seq uint64, reporterId int64, reason string) (*define.ChatReport, error) {
+	id, err := utils.NextID(define.SnowFlake_ChatReport)
+	if !utils.ErrCheck(err, "NextID failed when ChatManager.ReportChatMessage", channel, seq) {
+		return nil, err
+	}
+
+	report := &define.ChatReport{
+		Id:         id,
+		ReporterId: reporterId,
+		Channel:    channel,
+		Seq:        seq,
+		Reason:     reason,
+		Time:       time.Now().Unix(),
+	}
+
+	err = m.AddTask(
+		ctx,
+		channel,
+		func(c context.Context, p ...any) error {
+			cd := p[0].(*ChatChannelData)
+			reported, msgs, e := cd.GetReportContext(seq, define.Chat_ReportContextNum)
+			if e != nil {
+				return e
+			}
+
+			report.ReportedId = reported.GetSenderId()
+			report.Context = msgs
+			return nil
+		},
+	)
+
+	if !utils.ErrCheck(err, "AddTask failed when ChatManager.ReportChatMessage", channel, seq) {
+		return nil, err
+	}
+
+	err = store.GetStore().UpdateOne(ctx, define.StoreType_ChatReport, report.Id, report)
+	if !utils.ErrCheck(err, "UpdateOne failed when ChatManager.ReportChatMessage", report.Id) {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,74 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel"
+	_ "github.com/east-eden/server/excel/auto"
+	"github.com/east-eden/server/store"
+	"github.com/east-eden/server/utils/sensitive"
+)
+
+func TestChatMute(t *testing.T) {
+	ctx := context.Background()
+	var playerId int64 = 10003
+
+	if _, err := chatManager.MutePlayer(ctx, playerId, 0, "spam", "gm"); !errors.Is(err, ErrInvalidChatMuteDuration) {
+		t.Fatalf("mute with invalid duration should fail, got %v", err)
+	}
+
+	if _, err := chatManager.MutePlayer(ctx, playerId, 3600, "spam", "gm"); err != nil {
+		t.Fatalf("mute failed: %v", err)
+	}
+
+	store.GetStore().Flush()
+
+	mute, err := chatManager.getMute(ctx, playerId)
+	if err != nil || mute == nil || !mute.IsMuted(time.Now().Unix()) || mute.Reason != "spam" {
+		t.Fatalf("player should be muted: err=%v, mute=%v", err, mute)
+	}
+
+	if mute.IsMuted(mute.EndTime) {
+		t.Fatal("mute should end at end time")
+	}
+
+	if err := chatManager.UnmutePlayer(ctx, playerId); err != nil {
+		t.Fatalf("unmute failed: %v", err)
+	}
+
+	store.GetStore().Flush()
+
+	if mute, err := chatManager.getMute(ctx, playerId); err != nil || mute != nil {
+		t.Fatalf("player should be unmuted: err=%v, mute=%v", err, mute)
+	}
+}
+
+func TestSensitiveWordReload(t *testing.T) {
+	dir := "../../config/csv/"
+	data, err := os.ReadFile(dir + "SensitiveWord.csv")
+	if err != nil {
+		t.Skip("config/csv not found")
+	}
+
+	excel.ReadAllEntries(dir)
+	if _, action, hit := sensitive.Filter("加微信领福利"); !hit || action != define.SensitiveAction_ShadowBan {
+		t.Fatalf("filter should shadow ban: hit=%v, action=%d", hit, action)
+	}
+
+	// 热更后新增的屏蔽字生效
+	tmp := t.TempDir() + "/"
+	data = append(data, []byte(",,100,测试屏蔽,2\n")...)
+	if err := os.WriteFile(tmp+"SensitiveWord.csv", data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	excel.ReloadEntries(tmp, "SensitiveWord.csv")
+	if result, action, hit := sensitive.Filter("这是测试屏蔽字"); !hit || action != define.SensitiveAction_Reject || result != "这是****字" {
+		t.Fatalf("filter after reload mismatch: result=%q, hit=%v, action=%d", result, hit, action)
+	}
+}
@@ -0,0 +1,48 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/asim/go-micro/v3"
+	"github.com/east-eden/server/define"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	pbPubSub "github.com/east-eden/server/proto/server/pubsub"
+	"github.com/east-eden/server/utils"
+)
+
+type PubSub struct {
+	pubChatMessage micro.Publisher
+	c              *Chat
+}
+
+func NewPubSub(c *Chat) *PubSub {
+	ps := &PubSub{
+		c: c,
+	}
+
+	// create publisher
+	ps.pubChatMessage = micro.NewEvent("chat.ChatMessage", c.mi.srv.Client())
+
+	return ps
+}
+
+/////////////////////////////////////
+// publish handle
+/////////////////////////////////////
+func (ps *PubSub) PubChatMessage(ctx context.Context, msg *pbGlobal.ChatMessage, gameId int32, receiverIds []int64) error {
+	nextId, err := utils.NextID(define.SnowFlake_Pubsub)
+	if !utils.ErrCheck(err, "NextID failed") {
+		return err
+	}
+
+	return ps.pubChatMessage.Publish(ctx, &pbPubSub.PubChatMessage{
+		MsgId:       nextId,
+		Message:     msg,
+		GameId:      gameId,
+		ReceiverIds: receiverIds,
+	})
+}
+
+/////////////////////////////////////
+// subscribe handle
+/////////////////////////////////////
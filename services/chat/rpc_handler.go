@@ -0,0 +1,171 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/asim/go-micro/v3/client"
+	"github.com/east-eden/server/define"
+	pbChat "github.com/east-eden/server/proto/server/chat"
+	"github.com/east-eden/server/utils"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	DefaultRpcTimeout = 5 * time.Second // 默认rpc超时时间
+)
+
+type RpcHandler struct {
+	c       *Chat
+	chatSrv pbChat.ChatService
+}
+
+func NewRpcHandler(cli *cli.Context, c *Chat) *RpcHandler {
+	h := &RpcHandler{
+		c: c,
+		chatSrv: pbChat.NewChatService(
+			"chat",
+			c.mi.srv.Client(),
+		),
+	}
+
+	err := pbChat.RegisterChatServiceHandler(c.mi.srv.Server(), h)
+	if err != nil {
+		log.Fatal().Err(err).Msg("RegisterChatServiceHandler failed")
+	}
+
+	return h
+}
+
+// 一致性哈希
+func (h *RpcHandler) consistentHashCallOption(key string) client.CallOption {
+	return client.WithSelectOption(
+		utils.ConsistentHashSelector(h.c.cons, key),
+	)
+}
+
+// 重试次数
+func (h *RpcHandler) retries(times int) client.CallOption {
+	return client.WithRetries(times)
+}
+
+/////////////////////////////////////////////
+// rpc call
+/////////////////////////////////////////////
+func (h *RpcHandler) CallKickChatChannelData(channel define.ChatChannel, nodeId int32) (*pbChat.KickChatChannelDataRs, error) {
+	if !channel.Valid() {
+		return nil, ErrInvalidChatChannel
+	}
+
+	if nodeId == int32(h.c.ID) {
+		return nil, errors.New("same chat node id")
+	}
+
+	req := &pbChat.KickChatChannelDataRq{
+		Channel:    channel.ToPB(),
+		ChatNodeId: nodeId,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+
+	return h.chatSrv.KickChatChannelData(
+		ctx,
+		req,
+		client.WithSelectOption(
+			utils.SpecificIDSelector(
+				fmt.Sprintf("chat-%d", nodeId),
+			),
+		),
+	)
+}
+
+/////////////////////////////////////////////
+// rpc receive
+/////////////////////////////////////////////
+// 发送聊天消息
+func (h *RpcHandler) SendChatMessage(
+	ctx context.Context,
+	req *pbChat.SendChatMessageRq,
+	rsp *pbChat.SendChatMessageRs,
+) error {
+	msg, err := h.c.manager.SendChatMessage(ctx, req.GetMessage())
+	rsp.Message = msg
+	return err
+}
+
+// 查询频道历史消息
+func (h *RpcHandler) QueryChatHistory(
+	ctx context.Context,
+	req *pbChat.QueryChatHistoryRq,
+	rsp *pbChat.QueryChatHistoryRs,
+) error {
+	var channel define.ChatChannel
+	channel.FromPB(req.GetChannel())
+	msgs, err := h.c.manager.QueryChatHistory(ctx, channel, req.GetLastSeq())
+	rsp.Channel = req.GetChannel()
+	rsp.Messages = msgs
+	return err
+}
+
+// 踢出频道cache
+func (h *RpcHandler) KickChatChannelData(
+	ctx context.Context,
+	req *pbChat.KickChatChannelDataRq,
+	rsp *pbChat.KickChatChannelDataRs,
+) error {
+	var channel define.ChatChannel
+	channel.FromPB(req.GetChannel())
+	err := h.c.manager.KickChatChannelData(channel, req.GetChatNodeId())
+	rsp.Channel = req.GetChannel()
+	if err != nil {
+		rsp.Error = err.Error()
+	}
+	return err
+}
+
+// 禁言
+func (h *RpcHandler) MutePlayer(
+	ctx context.Context,
+	req *pbChat.MutePlayerRq,
+	rsp *pbChat.MutePlayerRs,
+) error {
+	mute, err := h.c.manager.MutePlayer(ctx, req.GetPlayerId(), req.GetDuration(), req.GetReason(), req.GetOperator())
+	if err != nil {
+		return err
+	}
+
+	rsp.PlayerId = mute.PlayerId
+	rsp.EndTime = mute.EndTime
+	return nil
+}
+
+// 解除禁言
+func (h *RpcHandler) UnmutePlayer(
+	ctx context.Context,
+	req *pbChat.UnmutePlayerRq,
+	rsp *pbChat.UnmutePlayerRs,
+) error {
+	rsp.PlayerId = req.GetPlayerId()
+	return h.c.manager.UnmutePlayer(ctx, req.GetPlayerId())
+}
+
+// 举报聊天消息
+func (h *RpcHandler) ReportChatMessage(
+	ctx context.Context,
+	req *pbChat.ReportChatMessageRq,
+	rsp *pbChat.ReportChatMessageRs,
+) error {
+	var channel define.ChatChannel
+	channel.FromPB(req.GetChannel())
+	report, err := h.c.manager.ReportChatMessage(ctx, channel, req.GetSeq(), req.GetReporterId(), req.GetReason())
+	if err != nil {
+		return err
+	}
+
+	rsp.ReportId = report.Id
+	return nil
+}
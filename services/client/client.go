@@ -36,8 +36,9 @@ type Client struct {
 	prompt     *PromptUI
 	chExec     chan ExecuteFunc
 
-	GateAddr string
-	wg       utils.WaitGroupWrapper
+	GateAddr     string
+	GateHttpAddr string
+	wg           utils.WaitGroupWrapper
 }
 
 func NewClient(ch chan ExecuteFunc) *Client {
@@ -99,6 +100,7 @@ func (c *Client) Action(ctx *cli.Context) error {
 
 	c.Id = ctx.Int64("client_id")
 	c.GateAddr = ctx.String("gate_addr")
+	c.GateHttpAddr = ctx.String("gate_http_addr")
 
 	c.cmder = NewCommander(c)
 	c.prompt = NewPromptUI(ctx, c)
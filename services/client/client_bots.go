@@ -129,6 +129,10 @@ func (c *ClientBots) Action(ctx *cli.Context) error {
 		var httpListenAddr int64 = int64(8090 + n)
 		set.String("http_listen_addr", ":"+strconv.FormatInt(httpListenAddr, 10), "http listen address")
 		set.String("gate_addr", ctx.String("gate_addr"), "gate address")
+		set.String("gate_http_addr", ctx.String("gate_http_addr"), "gate http address")
+		set.String("transport_cipher", ctx.String("transport_cipher"), "transport cipher")
+		set.String("transport_compress", ctx.String("transport_compress"), "transport compress")
+		set.Int("transport_compress_threshold", ctx.Int("transport_compress_threshold"), "transport compress threshold")
 		set.String("cert_path_debug", ctx.String("cert_path_debug"), "cert path debug")
 		set.String("key_path_debug", ctx.String("key_path_debug"), "key path debug")
 		set.String("cert_path_release", ctx.String("cert_path_release"), "cert path release")
@@ -262,15 +266,19 @@ func PingExecution(ctx context.Context, c *Client) error {
 func LogonExecution(ctx context.Context, c *Client) error {
 	log.Info().Int64("client_id", c.Id).Msg("client execute LogonExecution")
 
-	var gateInfo GateInfo
-	gateInfo.UserID = cast.ToString(c.Id)
+	gateInfo, err := selectGameAddr(c.GateHttpAddr, cast.ToString(c.Id))
+	if err != nil {
+		return fmt.Errorf("LogonExecution select game addr failed: %w", err)
+	}
+
+	// connect with transfer gate
 	gateInfo.PublicTcpAddr = c.GateAddr
 
 	if len(gateInfo.PublicTcpAddr) == 0 {
 		return errors.New("LogonExecution get invalid game public address")
 	}
 
-	c.transport.SetGateInfo(&gateInfo)
+	c.transport.SetGateInfo(gateInfo)
 	c.transport.SetProtocol("tcp")
 	if err := c.transport.StartConnect(ctx); err != nil {
 		return fmt.Errorf("LogonExecution connect failed: %w", err)
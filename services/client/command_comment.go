@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+
+	pbGlobal "github.com/east-eden/server/proto/global"
+	log "github.com/rs/zerolog/log"
+)
+
+func (cmd *Commander) initCommentCommands() {
+	cmd.registerCommandPage(&CommandPage{PageID: Cmd_Page_Comment, ParentPageID: Cmd_Page_Main, Cmds: make([]*Command, 0)})
+
+	// 返回上页
+	cmd.registerCommand(&Command{Text: "返回上页", PageID: Cmd_Page_Comment, GotoPageID: Cmd_Page_Main, Cb: nil})
+
+	// 2查询评论
+	cmd.registerCommand(&Command{Text: "查询评论", PageID: Cmd_Page_Comment, GotoPageID: -1, InputText: "请输入话题类型,话题type_id,起始排名,结束排名:", DefaultInput: "0,1,0,9", Cb: cmd.CmdCommentQuery})
+
+	// 3发表评论
+	cmd.registerCommand(&Command{Text: "发表评论", PageID: Cmd_Page_Comment, GotoPageID: -1, InputText: "请输入话题类型,话题type_id,评论内容:", DefaultInput: "0,1,评论内容", Cb: cmd.CmdCommentPublish})
+
+	// 4回复评论
+	cmd.registerCommand(&Command{Text: "回复评论", PageID: Cmd_Page_Comment, GotoPageID: -1, InputText: "请输入话题类型,话题type_id,评论id,回复玩家id(0为回复评论发表者),回复内容:", DefaultInput: "0,1,1,0,回复内容", Cb: cmd.CmdCommentReply})
+
+	// 5删除评论
+	cmd.registerCommand(&Command{Text: "删除评论", PageID: Cmd_Page_Comment, GotoPageID: -1, InputText: "请输入话题类型,话题type_id,评论id,回复id(0为删除评论):", DefaultInput: "0,1,1,0", Cb: cmd.CmdCommentDelete})
+
+	// 6点赞评论
+	cmd.registerCommand(&Command{Text: "点赞评论", PageID: Cmd_Page_Comment, GotoPageID: -1, InputText: "请输入话题类型,话题type_id,评论id,是否点赞(true点赞,false取消):", DefaultInput: "0,1,1,true", Cb: cmd.CmdCommentThumbs})
+}
+
+func (cmd *Commander) CmdCommentQuery(ctx context.Context, result []string) (bool, string) {
+	msg := &pbGlobal.C2S_CommentQuery{}
+
+	err := reflectIntoMsg(msg, result)
+	if err != nil {
+		log.Error().Err(err).Msg("CmdCommentQuery command failed")
+		return false, ""
+	}
+
+	cmd.c.transport.SendMessage(msg)
+	return true, "S2C_CommentQuery"
+}
+
+func (cmd *Commander) CmdCommentPublish(ctx context.Context, result []string) (bool, string) {
+	msg := &pbGlobal.C2S_CommentPublish{}
+
+	err := reflectIntoMsg(msg, result)
+	if err != nil {
+		log.Error().Err(err).Msg("CmdCommentPublish command failed")
+		return false, ""
+	}
+
+	cmd.c.transport.SendMessage(msg)
+	return true, "S2C_CommentPublish"
+}
+
+func (cmd *Commander) CmdCommentReply(ctx context.Context, result []string) (bool, string) {
+	msg := &pbGlobal.C2S_CommentReply{}
+
+	err := reflectIntoMsg(msg, result)
+	if err != nil {
+		log.Error().Err(err).Msg("CmdCommentReply command failed")
+		return false, ""
+	}
+
+	cmd.c.transport.SendMessage(msg)
+	return true, "S2C_CommentReply"
+}
+
+func (cmd *Commander) CmdCommentDelete(ctx context.Context, result []string) (bool, string) {
+	msg := &pbGlobal.C2S_CommentDelete{}
+
+	err := reflectIntoMsg(msg, result)
+	if err != nil {
+		log.Error().Err(err).Msg("CmdCommentDelete command failed")
+		return false, ""
+	}
+
+	cmd.c.transport.SendMessage(msg)
+	return true, "S2C_CommentDelete"
+}
+
+func (cmd *Commander) CmdCommentThumbs(ctx context.Context, result []string) (bool, string) {
+	msg := &pbGlobal.C2S_CommentThumbs{}
+
+	err := reflectIntoMsg(msg, result)
+	if err != nil {
+		log.Error().Err(err).Msg("CmdCommentThumbs command failed")
+		return false, ""
+	}
+
+	cmd.c.transport.SendMessage(msg)
+	return true, "S2C_CommentThumbs"
+}
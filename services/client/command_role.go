@@ -22,6 +22,9 @@ func (cmd *Commander) initRoleCommands() {
 	// 请求排行榜
 	cmd.registerCommand(&Command{Text: "请求排行榜", PageID: Cmd_Page_Role, GotoPageID: -1, InputText: "请输入排行榜id:", DefaultInput: "1", Cb: cmd.CmdQueryRank})
 
+	// 请求战斗录像
+	cmd.registerCommand(&Command{Text: "请求战斗录像", PageID: Cmd_Page_Role, GotoPageID: -1, InputText: "请输入录像id:", DefaultInput: "1", Cb: cmd.CmdQueryCombatRecord})
+
 	// gm命令
 	cmd.registerCommand(&Command{Text: "gm命令", PageID: Cmd_Page_Role, GotoPageID: -1, InputText: "请输入gm命令", DefaultInput: "gm player exp 100", Cb: cmd.CmdGmCmd})
 }
@@ -65,6 +68,19 @@ func (cmd *Commander) CmdQueryRank(ctx context.Context, result []string) (bool,
 	return true, "S2C_QueryRank"
 }
 
+func (cmd *Commander) CmdQueryCombatRecord(ctx context.Context, result []string) (bool, string) {
+	msg := &pbGlobal.C2S_QueryCombatRecord{}
+
+	err := reflectIntoMsg(msg, result)
+	if err != nil {
+		log.Error().Err(err).Msg("CmdQueryCombatRecord command failed")
+		return false, ""
+	}
+
+	cmd.c.transport.SendMessage(msg)
+	return true, "S2C_CombatRecord"
+}
+
 func (cmd *Commander) CmdGmCmd(ctx context.Context, result []string) (bool, string) {
 	msg := &pbGlobal.C2S_GmCmd{}
 
@@ -2,9 +2,9 @@ package client
 
 import (
 	"context"
-	"hash/crc32"
 
 	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/east-eden/server/transport"
 	"github.com/east-eden/server/utils"
 	log "github.com/rs/zerolog/log"
 	"google.golang.org/protobuf/proto"
@@ -40,9 +40,13 @@ func (cmd *Commander) initServerCommands() {
 }
 
 func (cmd *Commander) CmdAccountLogon(ctx context.Context, result []string) (bool, string) {
+	gateInfo, err := selectGameAddr(cmd.c.GateHttpAddr, result[0])
+	if err != nil {
+		log.Warn().Err(err).Msg("select game addr failed")
+		return false, ""
+	}
+
 	// transfer gate
-	var gateInfo GateInfo
-	gateInfo.UserID = result[0]
 	gateInfo.PublicTcpAddr = cmd.c.GateAddr
 
 	log.Info().Interface("info", gateInfo).Msg("metadata unmarshaled result")
@@ -52,7 +56,7 @@ func (cmd *Commander) CmdAccountLogon(ctx context.Context, result []string) (boo
 		return false, ""
 	}
 
-	cmd.c.transport.SetGateInfo(&gateInfo)
+	cmd.c.transport.SetGateInfo(gateInfo)
 	cmd.c.transport.SetProtocol("tcp")
 	if err := cmd.c.transport.StartConnect(ctx); err != nil {
 		log.Warn().Err(err).Msg("tcp connect failed")
@@ -62,9 +66,13 @@ func (cmd *Commander) CmdAccountLogon(ctx context.Context, result []string) (boo
 }
 
 func (cmd *Commander) CmdWebSocketAccountLogon(ctx context.Context, result []string) (bool, string) {
+	gateInfo, err := selectGameAddr(cmd.c.GateHttpAddr, result[0])
+	if err != nil {
+		log.Warn().Err(err).Msg("select game addr failed")
+		return false, ""
+	}
+
 	// transfer gate
-	var gateInfo GateInfo
-	gateInfo.UserID = result[0]
 	gateInfo.PublicTcpAddr = cmd.c.GateAddr
 
 	log.Info().Interface("info", gateInfo).Msg("metadata unmarshaled result")
@@ -74,7 +82,7 @@ func (cmd *Commander) CmdWebSocketAccountLogon(ctx context.Context, result []str
 		return false, ""
 	}
 
-	cmd.c.transport.SetGateInfo(&gateInfo)
+	cmd.c.transport.SetGateInfo(gateInfo)
 	cmd.c.transport.SetProtocol("ws")
 	if err := cmd.c.transport.StartConnect(ctx); err != nil {
 		log.Warn().Err(err).Msg("ws connect failed")
@@ -99,7 +107,7 @@ func (cmd *Commander) CmdWaitResponseMessage(ctx context.Context, result []strin
 	// send wait response message
 	msg := &pbGlobal.C2S_WaitResponseMessage{
 		MsgId:        1001,
-		InnerMsgCrc:  crc32.ChecksumIEEE([]byte("C2S_Ping")),
+		InnerMsgCrc:  transport.MessageID(&innerMsg),
 		InnerMsgData: data,
 	}
 
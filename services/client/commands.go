@@ -22,6 +22,7 @@ const (
 	Cmd_Page_Combat   = 8  // 战斗选项
 	Cmd_Page_Fragment = 9  // 英雄碎片选项
 	Cmd_Page_Crystal  = 10 // 晶石选项
+	Cmd_Page_Comment  = 11 // 评论选项
 )
 
 type Command struct {
@@ -86,6 +87,15 @@ func reflectIntoMsg(msg proto.Message, result []string) error {
 		if ft.Kind() == reflect.String {
 			fv.Set(reflect.ValueOf(result[n]))
 		}
+
+		if ft.Kind() == reflect.Bool {
+			inputValue, err := strconv.ParseBool(result[n])
+			if err != nil {
+				return fmt.Errorf("input value<%s> cannot assert to type<%s>\r\n", result[n], ft.Name())
+			}
+
+			fv.SetBool(inputValue)
+		}
 	}
 
 	return nil
@@ -122,6 +132,7 @@ func (c *Commander) initCommandPages() {
 	c.initCombatCommands()
 	c.initFragmentCommands()
 	c.initCrystalCommands()
+	c.initCommentCommands()
 }
 
 func (cmd *Commander) initMainCommands() {
@@ -155,6 +166,9 @@ func (cmd *Commander) initMainCommands() {
 	// 9晶石
 	cmd.registerCommand(&Command{Text: "晶石", PageID: Cmd_Page_Main, GotoPageID: Cmd_Page_Crystal, Cb: nil})
 
-	// 10退出
+	// 10评论
+	cmd.registerCommand(&Command{Text: "评论", PageID: Cmd_Page_Main, GotoPageID: Cmd_Page_Comment, Cb: nil})
+
+	// 11退出
 	cmd.registerCommand(&Command{Text: "退出", PageID: Cmd_Page_Main, GotoPageID: -1, Cb: cmd.CmdQuit})
 }
@@ -9,9 +9,41 @@ import (
 	"time"
 
 	"github.com/asim/go-micro/v3/errors"
+	json "github.com/json-iterator/go"
 	log "github.com/rs/zerolog/log"
 )
 
+// request gate's select_game_addr to get game node and session token
+func selectGameAddr(gateHttpAddr string, userId string) (*GateInfo, error) {
+	body, err := json.Marshal(map[string]string{"userId": userId})
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: time.Second * 3}
+	resp, err := client.Post(gateHttpAddr+"/select_game_addr", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("selectGameAddr failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("selectGameAddr read body failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("selectGameAddr failed with status<%d>: %s", resp.StatusCode, string(data))
+	}
+
+	var info GateInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("selectGameAddr unmarshal failed: %w", err)
+	}
+
+	return &info, nil
+}
+
 // deprecated
 func httpPost(endPoints []string, header map[string]string, body []byte) ([]byte, error) {
 	if len(endPoints) == 0 {
@@ -40,8 +40,11 @@ func (h *MsgHandler) registerMessage() {
 
 	registerFn(&pbGlobal.S2C_Pong{}, h.OnS2C_Pong)
 	registerFn(&pbGlobal.S2C_HeartBeat{}, h.OnS2C_HeartBeat)
-	// registerFn(&msg.HandshakeResp{}, h.OnHandshakeResp)
+	registerFn(&pbGlobal.HandshakeResp{}, h.OnHandshakeResp)
 	registerFn(&pbGlobal.S2C_AccountLogon{}, h.OnS2C_AccountLogon)
+	registerFn(&pbGlobal.S2C_AccountResume{}, h.OnS2C_AccountResume)
+	registerFn(&pbGlobal.S2C_SequencedMessage{}, h.OnS2C_SequencedMessage)
+	registerFn(&pbGlobal.S2C_AccountRedirect{}, h.OnS2C_AccountRedirect)
 	registerFn(&pbGlobal.S2C_ServerTime{}, h.OnS2C_ServerTime)
 	registerFn(&pbGlobal.S2C_WaitResponseMessage{}, h.OnS2C_WaitResponseMessage)
 	registerFn(&pbGlobal.S2C_ServerConsole{}, h.OnS2C_ServerConsole)
@@ -76,6 +79,14 @@ func (h *MsgHandler) registerMessage() {
 	registerFn(&pbGlobal.S2C_QuestUpdate{}, h.OnS2C_QuestUpdate)
 
 	registerFn(&pbGlobal.S2C_QueryRank{}, h.OnS2C_QueryRank)
+
+	registerFn(&pbGlobal.S2C_CombatRecord{}, h.OnS2C_CombatRecord)
+
+	registerFn(&pbGlobal.S2C_CommentQuery{}, h.OnS2C_CommentQuery)
+	registerFn(&pbGlobal.S2C_CommentPublish{}, h.OnS2C_CommentPublish)
+	registerFn(&pbGlobal.S2C_CommentReply{}, h.OnS2C_CommentReply)
+	registerFn(&pbGlobal.S2C_CommentDelete{}, h.OnS2C_CommentDelete)
+	registerFn(&pbGlobal.S2C_CommentThumbs{}, h.OnS2C_CommentThumbs)
 }
 
 func (h *MsgHandler) OnS2C_Pong(ctx context.Context, sock transport.Socket, msg proto.Message) error {
@@ -86,11 +97,19 @@ func (h *MsgHandler) OnS2C_HeartBeat(ctx context.Context, sock transport.Socket,
 	return nil
 }
 
-// func (h *MsgHandler) OnHandshakeResp(ctx context.Context, sock transport.Socket, m proto.Message) error {
-// 	resp := m.(*msg.HandshakeResp)
-// 	log.Info().Interface("handshake resp", resp).Msg("握手成功")
-// 	return nil
-// }
+func (h *MsgHandler) OnHandshakeResp(ctx context.Context, sock transport.Socket, msg proto.Message) error {
+	m := msg.(*pbGlobal.HandshakeResp)
+	if m.GetCode() != pbGlobal.ErrorCode_Success {
+		log.Warn().
+			Str("code", m.GetCode().String()).
+			Str("desc", m.GetDesc()).
+			Msg("握手失败")
+		return nil
+	}
+
+	log.Info().Interface("handshake resp", m).Msg("握手成功")
+	return nil
+}
 
 func (h *MsgHandler) OnS2C_AccountLogon(ctx context.Context, sock transport.Socket, msg proto.Message) error {
 	m := msg.(*pbGlobal.S2C_AccountLogon)
@@ -103,6 +122,37 @@ func (h *MsgHandler) OnS2C_AccountLogon(ctx context.Context, sock transport.Sock
 		Str("player_name", m.PlayerName).
 		Int32("player_level", m.PlayerLevel).Msg("账号登录成功")
 
+	h.c.transport.onLogon(m.GetCapabilities())
+	return nil
+}
+
+func (h *MsgHandler) OnS2C_AccountResume(ctx context.Context, sock transport.Socket, msg proto.Message) error {
+	m := msg.(*pbGlobal.S2C_AccountResume)
+	if !m.GetResumed() {
+		log.Warn().Str("local", sock.Local()).Msg("断线重连失败, 重新登陆")
+		h.c.transport.onResumeFailed()
+		return nil
+	}
+
+	log.Info().Str("local", sock.Local()).Uint64("last_seq", m.GetLastSeq()).Msg("断线重连成功")
+	return nil
+}
+
+// game节点下线, 使用新的session token重新登陆到新节点
+func (h *MsgHandler) OnS2C_AccountRedirect(ctx context.Context, sock transport.Socket, msg proto.Message) error {
+	m := msg.(*pbGlobal.S2C_AccountRedirect)
+	log.Info().
+		Str("local", sock.Local()).
+		Int32("game_id", m.GetGameId()).
+		Str("public_tcp_addr", m.GetPublicTcpAddr()).
+		Msg("game节点迁移, 重新登陆到新节点")
+
+	h.c.transport.onRedirect(m.GetSessionToken())
+	return nil
+}
+
+// 带序号的消息在TransportClient.onRecv中解包处理
+func (h *MsgHandler) OnS2C_SequencedMessage(ctx context.Context, sock transport.Socket, msg proto.Message) error {
 	return nil
 }
 
@@ -331,3 +381,44 @@ func (h *MsgHandler) OnS2C_QueryRank(ctx context.Context, sock transport.Socket,
 	log.Info().Int32("排名", m.GetRankIndex()).Interface("排行榜数据", m.Metadata).Msg("请求排行榜结果")
 	return nil
 }
+
+func (h *MsgHandler) OnS2C_CombatRecord(ctx context.Context, sock transport.Socket, msg proto.Message) error {
+	m := msg.(*pbGlobal.S2C_CombatRecord)
+	log.Info().
+		Int64("录像id", m.GetRecord().GetId()).
+		Bool("战斗结果", m.GetRecord().GetWin()).
+		Int("事件数量", len(m.GetRecord().GetEvents())).
+		Interface("战斗统计", m.GetRecord().GetStatistics()).
+		Msg("请求战斗录像结果")
+	return nil
+}
+
+func (h *MsgHandler) OnS2C_CommentQuery(ctx context.Context, sock transport.Socket, msg proto.Message) error {
+	m := msg.(*pbGlobal.S2C_CommentQuery)
+	log.Info().Interface("话题", m.GetTopic()).Interface("评论列表", m.GetMetadatas()).Msg("查询评论结果")
+	return nil
+}
+
+func (h *MsgHandler) OnS2C_CommentPublish(ctx context.Context, sock transport.Socket, msg proto.Message) error {
+	m := msg.(*pbGlobal.S2C_CommentPublish)
+	log.Info().Interface("评论", m.GetMetadata()).Msg("发表评论成功")
+	return nil
+}
+
+func (h *MsgHandler) OnS2C_CommentReply(ctx context.Context, sock transport.Socket, msg proto.Message) error {
+	m := msg.(*pbGlobal.S2C_CommentReply)
+	log.Info().Int64("评论id", m.GetCommentId()).Interface("回复", m.GetReply()).Msg("回复评论成功")
+	return nil
+}
+
+func (h *MsgHandler) OnS2C_CommentDelete(ctx context.Context, sock transport.Socket, msg proto.Message) error {
+	m := msg.(*pbGlobal.S2C_CommentDelete)
+	log.Info().Int64("评论id", m.GetCommentId()).Int64("回复id", m.GetReplyId()).Msg("删除评论成功")
+	return nil
+}
+
+func (h *MsgHandler) OnS2C_CommentThumbs(ctx context.Context, sock transport.Socket, msg proto.Message) error {
+	m := msg.(*pbGlobal.S2C_CommentThumbs)
+	log.Info().Int64("评论id", m.GetCommentId()).Int32("点赞数", m.GetThumbs()).Bool("是否点赞", m.GetLiked()).Msg("点赞评论结果")
+	return nil
+}
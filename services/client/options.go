@@ -21,6 +21,10 @@ func NewFlags() []cli.Flag {
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "key_path_release", Usage: "release tls server_key path"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "http_listen_addr", Usage: "http listen address"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "gate_addr", Usage: "gate address"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "gate_http_addr", Usage: "gate http address for select_game_addr"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "transport_cipher", Usage: "gate transport cipher: none, aes-gcm, chacha20"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "transport_compress", Usage: "gate transport compress: none, snappy, zstd"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "transport_compress_threshold", Usage: "compress message body greater than threshold"}),
 
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "config_file", Usage: "client config path"}),
 	}
@@ -40,6 +44,10 @@ func NewClientBotsFlags() []cli.Flag {
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "key_path_release", Usage: "release tls server_key path"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "http_listen_addr", Usage: "http listen address"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "gate_addr", Usage: "gate address"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "gate_http_addr", Usage: "gate http address for select_game_addr"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "transport_cipher", Usage: "gate transport cipher: none, aes-gcm, chacha20"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "transport_compress", Usage: "gate transport compress: none, snappy, zstd"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "transport_compress_threshold", Usage: "compress message body greater than threshold"}),
 		&cli.StringFlag{
 			Name:  "config_file",
 			Value: "config/client_bots/config.toml",
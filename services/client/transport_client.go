@@ -11,6 +11,7 @@ import (
 
 	pbGlobal "github.com/east-eden/server/proto/global"
 	"github.com/east-eden/server/transport"
+	"github.com/east-eden/server/transport/codec"
 	"github.com/east-eden/server/utils"
 	log "github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v2"
@@ -24,6 +25,7 @@ type GateInfo struct {
 	GateID        string `json:"gateId"`
 	PublicTcpAddr string `json:"publicTcpAddr"`
 	PublicWsAddr  string `json:"publicWsAddr"`
+	SessionToken  string `json:"sessionToken"`
 }
 
 type TransportClient struct {
@@ -35,6 +37,7 @@ type TransportClient struct {
 
 	gateInfo *GateInfo
 	tlsConf  *tls.Config
+	session  transport.SessionOptions
 
 	protocol       string
 	connected      int32
@@ -43,6 +46,8 @@ type TransportClient struct {
 	chDisconnect   chan int
 	returnMsgName  chan string
 	unProcedMsg    int32
+	logoned        int32  // 登陆成功后断线重连使用resume
+	lastSeq        uint64 // 最后收到的S2C_SequencedMessage序号
 
 	ticker *time.Ticker
 	chSend chan proto.Message
@@ -62,6 +67,19 @@ func NewTransportClient(ctx *cli.Context, c *Client) *TransportClient {
 		chSend:         make(chan proto.Message, 64),
 	}
 
+	// transport session layer with gate
+	var err error
+	t.session.Cipher, err = transport.ParseCipherType(ctx.String("transport_cipher"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("parse transport_cipher failed")
+	}
+
+	t.session.Compress, err = transport.ParseCompressType(ctx.String("transport_compress"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("parse transport_compress failed")
+	}
+	t.session.CompressThreshold = ctx.Int("transport_compress_threshold")
+
 	// // tls
 	// var certFile, keyFile string
 	// if ctx.Bool("debug") {
@@ -104,6 +122,14 @@ func NewTransportClient(ctx *cli.Context, c *Client) *TransportClient {
 }
 
 func (t *TransportClient) connect(ctx context.Context) error {
+	// session token有效期较短, 断线重连前重新获取
+	resume := atomic.LoadInt32(&t.logoned) > 0
+	if resume {
+		if err := t.refreshSessionToken(); err != nil {
+			return fmt.Errorf("TransportClient.Connect failed: %w", err)
+		}
+	}
+
 	// dial to server
 	var err error
 	addr := t.gateInfo.PublicTcpAddr
@@ -125,10 +151,14 @@ func (t *TransportClient) connect(ctx context.Context) error {
 	t.chSend = make(chan proto.Message, 100)
 
 	// handshake
-	t.sendHandshake()
+	t.sendHandshake(resume)
 
-	// logon
-	t.sendLogon()
+	// resume or logon
+	if resume {
+		t.sendResume()
+	} else {
+		t.sendLogon()
+	}
 
 	// goroutine to send and recv messages
 	t.wg.Wrap(func() {
@@ -160,29 +190,84 @@ func (t *TransportClient) connect(ctx context.Context) error {
 	return nil
 }
 
-func (t *TransportClient) sendHandshake() {
+func (t *TransportClient) sendHandshake(resume bool) {
+	connType := pbGlobal.ConnType_New
+	if resume {
+		connType = pbGlobal.ConnType_Recon
+	}
+
 	p := &pbGlobal.Handshake{
-		ConnType:     pbGlobal.ConnType_New,
-		MsgType:      pbGlobal.MsgType_Direct,
-		ClientAddr:   t.ts.Local(),
-		UserId:       t.gateInfo.UserID,
-		ClientVer:    "0.0.1",
-		ClientResVer: "0.0.1",
-		Metadata:     make(map[string]string),
+		ConnType:        connType,
+		MsgType:         pbGlobal.MsgType_Direct,
+		ClientAddr:      t.ts.Local(),
+		UserId:          t.gateInfo.UserID,
+		ClientVer:       "0.0.1",
+		ClientResVer:    "0.0.1",
+		Metadata:        make(map[string]string),
+		SessionToken:    t.gateInfo.SessionToken,
+		ManifestVersion: pbGlobal.ManifestVersion,
 	}
 	t.chSend <- p
 }
 
 func (t *TransportClient) sendLogon() {
 	msg := &pbGlobal.C2S_AccountLogon{
-		UserId:      t.gateInfo.UserID,
-		AccountId:   t.gateInfo.AccountID,
-		AccountName: t.gateInfo.UserName,
+		UserId:       t.gateInfo.UserID,
+		AccountId:    t.gateInfo.AccountID,
+		AccountName:  t.gateInfo.UserName,
+		SessionToken: t.gateInfo.SessionToken,
+		Capabilities: uint32(pbGlobal.SessionCapability_SessionCapability_Sequenced),
+	}
+	log.Info().Interface("msg", msg).Send()
+	t.chSend <- msg
+}
+
+// 重新请求gate签发session token, 保留原来的连接地址
+func (t *TransportClient) refreshSessionToken() error {
+	info, err := selectGameAddr(t.c.GateHttpAddr, t.gateInfo.UserID)
+	if err != nil {
+		return err
+	}
+
+	t.gateInfo.SessionToken = info.SessionToken
+	return nil
+}
+
+func (t *TransportClient) sendResume() {
+	msg := &pbGlobal.C2S_AccountResume{
+		UserId:       t.gateInfo.UserID,
+		SessionToken: t.gateInfo.SessionToken,
+		LastSeq:      atomic.LoadUint64(&t.lastSeq),
 	}
 	log.Info().Interface("msg", msg).Send()
 	t.chSend <- msg
 }
 
+// 登陆成功, 服务器启用了带序号的消息时断线重连才能使用resume
+func (t *TransportClient) onLogon(caps uint32) {
+	if caps&uint32(pbGlobal.SessionCapability_SessionCapability_Sequenced) == 0 {
+		atomic.StoreInt32(&t.logoned, 0)
+		return
+	}
+
+	atomic.StoreInt32(&t.logoned, 1)
+}
+
+// 断线重连失败, 重新登陆
+func (t *TransportClient) onResumeFailed() {
+	atomic.StoreInt32(&t.logoned, 0)
+	atomic.StoreUint64(&t.lastSeq, 0)
+	t.sendLogon()
+}
+
+// game节点迁移, 经由gate重新登陆到session token指定的新节点
+func (t *TransportClient) onRedirect(token string) {
+	t.gateInfo.SessionToken = token
+	atomic.StoreInt32(&t.logoned, 0)
+	atomic.StoreUint64(&t.lastSeq, 0)
+	atomic.StoreInt32(&t.needReconnect, 1)
+}
+
 func (t *TransportClient) sendHeartBeat() {
 	msg := &pbGlobal.C2S_HeartBeat{}
 	t.chSend <- msg
@@ -197,6 +282,8 @@ func (t *TransportClient) StartConnect(ctx context.Context) error {
 		t.tr = transport.NewTransport("tcp")
 		t.tr.Init(
 			transport.Timeout(transport.DefaultDialTimeout),
+			transport.Encrypt(t.session.Cipher),
+			transport.Compress(t.session.Compress, t.session.CompressThreshold),
 		)
 	} else {
 		t.tr = transport.NewTransport("ws")
@@ -295,34 +382,59 @@ func (t *TransportClient) onRecv(ctx context.Context) error {
 				continue
 			}
 
-			if msg, h, err := t.ts.Recv(t.c.msgHandler.r); err != nil {
+			msg, h, err := t.ts.Recv(t.c.msgHandler.r)
+			if err != nil {
 				if errors.Is(err, transport.ErrUnregistedMessage) {
 					log.Warn().Err(err).Msg("TransportSocket Recv failed")
 					continue
 				}
 
 				return fmt.Errorf("TransportClient.onRecv failed: %w", err)
+			}
 
-			} else {
-				err := h.Fn(ctx, t.ts, msg)
+			// 带序号的消息, 记录序号后处理实际消息
+			if m, ok := msg.(*pbGlobal.S2C_SequencedMessage); ok {
+				msg, h, err = t.unwrapSequenced(m)
 				if err != nil {
-					return fmt.Errorf("TransportClient.onRecv failed: %w", err)
+					log.Warn().Err(err).Uint64("seq", m.GetSeq()).Msg("TransportClient unwrap sequenced message failed")
+					continue
 				}
+			}
 
-				name := msg.ProtoReflect().Descriptor().Name()
-				if name != "S2C_HeartBeat" {
-					t.returnMsgName <- string(name)
-					atomic.AddInt32(&t.unProcedMsg, 1)
-					num := atomic.LoadInt32(&t.unProcedMsg)
-					if num >= 90 {
-						log.Warn().Int64("client_id", t.c.Id).Int32("unproc", num).Msg("return msg name ")
-					}
+			if err := h.Fn(ctx, t.ts, msg); err != nil {
+				return fmt.Errorf("TransportClient.onRecv failed: %w", err)
+			}
+
+			name := msg.ProtoReflect().Descriptor().Name()
+			if name != "S2C_HeartBeat" {
+				t.returnMsgName <- string(name)
+				atomic.AddInt32(&t.unProcedMsg, 1)
+				num := atomic.LoadInt32(&t.unProcedMsg)
+				if num >= 90 {
+					log.Warn().Int64("client_id", t.c.Id).Int32("unproc", num).Msg("return msg name ")
 				}
 			}
 		}
 	}
 }
 
+func (t *TransportClient) unwrapSequenced(m *pbGlobal.S2C_SequencedMessage) (proto.Message, *transport.MessageHandler, error) {
+	atomic.StoreUint64(&t.lastSeq, m.GetSeq())
+
+	h, err := t.c.msgHandler.r.GetHandler(m.GetInnerMsgCrc())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codec := &codec.ProtoBufMarshaler{}
+	msg, err := codec.Unmarshal(m.GetInnerMsgData(), h.RType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return msg.(proto.Message), h, nil
+}
+
 func (t *TransportClient) onReconnect(ctx context.Context) {
 	for {
 		select {
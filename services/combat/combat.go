@@ -26,11 +26,14 @@ type Combat struct {
 	app                *cli.App               `bson:"-" json:"-"`
 	ID                 int16                  `bson:"_id" json:"_id"`
 	SnowflakeStartTime int64                  `bson:"snowflake_starttime" json:"snowflake_starttime"`
+	WaveCarryHP        bool                   `bson:"-" json:"-"`
+	WaveCarryBuff      bool                   `bson:"-" json:"-"`
 	wg                 utils.WaitGroupWrapper `bson:"-" json:"-"`
 
 	gin        *GinServer             `bson:"-" json:"-"`
 	mi         *MicroService          `bson:"-" json:"-"`
 	sm         *scene.SceneManager    `bson:"-" json:"-"`
+	rm         *RecordManager         `bson:"-" json:"-"`
 	rpcHandler *RpcHandler            `bson:"-" json:"-"`
 	pubSub     *PubSub                `bson:"-" json:"-"`
 	cons       *consistent.Consistent `bson:"-" json:"-"`
@@ -107,8 +110,10 @@ func (c *Combat) Action(ctx *cli.Context) error {
 	}
 
 	c.ID = int16(ctx.Int("combat_id"))
+	c.WaveCarryHP = ctx.Bool("wave_carry_hp")
+	c.WaveCarryBuff = ctx.Bool("wave_carry_buff")
 
-	store.NewStore(ctx)
+	store.NewStore(ctx, store.Node(c.ID))
 
 	// init snowflakes
 	c.initSnowflake()
@@ -116,6 +121,7 @@ func (c *Combat) Action(ctx *cli.Context) error {
 	c.gin = NewGinServer(ctx, c)
 	c.mi = NewMicroService(ctx, c)
 	c.sm = scene.NewSceneManager()
+	c.rm = NewRecordManager(c)
 	c.rpcHandler = NewRpcHandler(c)
 	c.pubSub = NewPubSub(c)
 	c.cons = consistent.New()
@@ -13,6 +13,10 @@ func NewFlags() []cli.Flag {
 		altsrc.NewIntFlag(&cli.IntFlag{Name: "combat_id", Usage: "combat server unique id(0 - 1024)"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "https_listen_addr", Usage: "https listen address"}),
 
+		// scene
+		altsrc.NewBoolFlag(&cli.BoolFlag{Name: "wave_carry_hp", Usage: "attacker hp carries over to next battle wave", Value: true}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{Name: "wave_carry_buff", Usage: "attacker buffs carry over to next battle wave", Value: true}),
+
 		// rate limit
 		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "rate_limit_interval", Usage: "rpc server rate limit interval"}),
 		altsrc.NewIntFlag(&cli.IntFlag{Name: "rate_limit_capacity", Usage: "rpc server rate limit capacity"}),
@@ -0,0 +1,93 @@
+package combat
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/east-eden/server/define"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/east-eden/server/store"
+	"github.com/east-eden/server/utils"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrCombatRecordNotFound = errors.New("combat record not found")
+)
+
+// 战斗录像存储结构, 录像内容以protobuf序列化保存
+type CombatRecord struct {
+	Id         int64  `bson:"_id" json:"_id"`
+	AttackId   int64  `bson:"attack_id" json:"attack_id"`
+	DefenceId  int64  `bson:"defence_id" json:"defence_id"`
+	CreateTime int64  `bson:"create_time" json:"create_time"`
+	Data       []byte `bson:"data" json:"data"`
+}
+
+type RecordManager struct {
+	c *Combat
+}
+
+func NewRecordManager(c *Combat) *RecordManager {
+	m := &RecordManager{
+		c: c,
+	}
+
+	store.GetStore().AddStoreInfo(define.StoreType_CombatRecord, "combat_record", "_id")
+	if err := store.GetStore().MigrateDbTable("combat_record", "attack_id", "defence_id"); err != nil {
+		log.Fatal().Err(err).Msg("migrate collection combat_record failed")
+	}
+
+	return m
+}
+
+// 保存战斗录像, 返回录像id
+func (m *RecordManager) SaveRecord(ctx context.Context, record *pbGlobal.CombatRecord) (int64, error) {
+	id, err := utils.NextID(define.SnowFlake_CombatRecord)
+	if err != nil {
+		return -1, err
+	}
+
+	record.Id = id
+	data, err := proto.Marshal(record)
+	if err != nil {
+		return -1, err
+	}
+
+	r := &CombatRecord{
+		Id:         id,
+		AttackId:   record.GetAttackId(),
+		DefenceId:  record.GetDefenceId(),
+		CreateTime: time.Now().Unix(),
+		Data:       data,
+	}
+
+	err = store.GetStore().UpdateOne(ctx, define.StoreType_CombatRecord, id, r)
+	if !utils.ErrCheck(err, "UpdateOne failed when RecordManager.SaveRecord", id, r.AttackId, r.DefenceId) {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+// 读取战斗录像
+func (m *RecordManager) LoadRecord(ctx context.Context, id int64) (*pbGlobal.CombatRecord, error) {
+	r := &CombatRecord{}
+	err := store.GetStore().FindOne(ctx, define.StoreType_CombatRecord, id, r)
+	if errors.Is(err, store.ErrNoResult) {
+		return nil, ErrCombatRecordNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	record := &pbGlobal.CombatRecord{}
+	if err := proto.Unmarshal(r.Data, record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
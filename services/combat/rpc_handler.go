@@ -3,6 +3,7 @@ package combat
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/east-eden/server/excel/auto"
 	pbCombat "github.com/east-eden/server/proto/server/combat"
@@ -16,6 +17,8 @@ var (
 	ErrInvalidStage      = errors.New("invalid stage id")
 	ErrInvalidScene      = errors.New("invalid scene id")
 	ErrInvalidBattleWave = errors.New("invalid battle wave entry")
+	ErrInvalidTower      = errors.New("invalid tower entry")
+	ErrInvalidConfig     = errors.New("invalid global config")
 )
 
 type RpcHandler struct {
@@ -63,34 +66,166 @@ func (h *RpcHandler) StageCombat(ctx context.Context, req *pbCombat.StageCombatR
 		return ErrInvalidScene
 	}
 
-	battleWaveEntries := make([]*auto.BattleWaveEntry, 0, len(stageEntry.WaveID))
-	for _, id := range stageEntry.WaveID {
-		entry, ok := auto.GetBattleWaveEntry(id)
-		if !ok {
-			log.Error().Caller().
-				Int32("stage_id", req.GetStageId()).
-				Int32("wave_id", id).
-				Msg("can not find BattleWaveEntry")
-			return ErrInvalidBattleWave
-		}
-
-		battleWaveEntries = append(battleWaveEntries, entry)
+	battleWaveEntries, err := getBattleWaveEntries(stageEntry.WaveID)
+	if !utils.ErrCheck(err, "getBattleWaveEntries failed when RpcHandler.StageCombat", req.GetStageId()) {
+		return err
 	}
 
-	sc, err := h.c.sm.CreateScene(
+	// 没有配置布阵点时按默认坐标站位
+	layoutEntry, _ := auto.GetBattleLayoutEntry(stageEntry.LayoutID)
+
+	result, err := h.combat(
 		ctx,
+		req.GetStageId(),
 		scene.WithSceneAttackId(req.AttackId),
 		scene.WithSceneAttackUnitList(req.AttackEntityList),
 		scene.WithSceneEntry(sceneEntry),
 		scene.WithSceneBattleWaveEntries(battleWaveEntries...),
+		scene.WithSceneBattleLayoutEntry(layoutEntry),
+		scene.WithSceneWaveCarryOver(h.c.WaveCarryHP, h.c.WaveCarryBuff),
 	)
 
-	if !utils.ErrCheck(err, "CreateScene failed when RpcHandler.StageCombat", req.GetStageId(), req.GetAttackId()) {
+	if !utils.ErrCheck(err, "combat failed when RpcHandler.StageCombat", req.GetStageId(), req.GetAttackId()) {
+		return err
+	}
+
+	// 关卡条件由game根据战斗统计判断
+	rsp.Win = result.Win
+	rsp.Statistics = result.Statistics
+	rsp.RecordId = result.Record.GetId()
+	rsp.Events = result.Record.GetEvents()
+	return nil
+}
+
+func (h *RpcHandler) TowerCombat(ctx context.Context, req *pbCombat.TowerCombatRq, rsp *pbCombat.TowerCombatRs) error {
+	log.Info().Interface("request", req).Msg("recv rpc call TowerCombat")
+
+	opts, err := towerSceneOptions(req)
+	if err != nil {
 		return err
 	}
 
-	rsp.Win = sc.GetResult()
-	// todo 关卡条件
+	opts = append(opts, scene.WithSceneWaveCarryOver(h.c.WaveCarryHP, h.c.WaveCarryBuff))
+	result, err := h.combat(ctx, -1, opts...)
+	if !utils.ErrCheck(err, "combat failed when RpcHandler.TowerCombat", req.GetTowerType(), req.GetTowerFloor(), req.GetAttackId()) {
+		return err
+	}
+
+	rsp.Win = result.Win
+	rsp.Statistics = result.Statistics
+	rsp.RecordId = result.Record.GetId()
+	return nil
+}
+
+func (h *RpcHandler) ArenaCombat(ctx context.Context, req *pbCombat.ArenaCombatRq, rsp *pbCombat.ArenaCombatRs) error {
+	log.Info().Int64("attack_id", req.GetAttackId()).Int64("defence_id", req.GetDefenceId()).Msg("recv rpc call ArenaCombat")
+
+	globalConfig, ok := auto.GetGlobalConfig()
+	if !ok {
+		return ErrInvalidConfig
+	}
+
+	sceneEntry, ok := auto.GetSceneEntry(globalConfig.ArenaSceneId)
+	if !ok {
+		return ErrInvalidScene
+	}
+
+	result, err := h.combat(
+		ctx,
+		-1,
+		scene.WithSceneAttackId(req.GetAttackId()),
+		scene.WithSceneAttackUnitList(req.GetAttackEntityList()),
+		scene.WithSceneDefenceId(req.GetDefenceId()),
+		scene.WithSceneDefenceUnitList(req.GetDefenceEntityList()),
+		scene.WithSceneEntry(sceneEntry),
+	)
+
+	if !utils.ErrCheck(err, "combat failed when RpcHandler.ArenaCombat", req.GetAttackId(), req.GetDefenceId()) {
+		return err
+	}
+
+	rsp.Win = result.Win
+	rsp.Statistics = result.Statistics
+	rsp.RecordId = result.Record.GetId()
+	return nil
+}
+
+func (h *RpcHandler) QueryCombatRecord(ctx context.Context, req *pbCombat.QueryCombatRecordRq, rsp *pbCombat.QueryCombatRecordRs) error {
+	record, err := h.c.rm.LoadRecord(ctx, req.GetRecordId())
+	if err != nil {
+		return err
+	}
 
+	rsp.Record = record
 	return nil
 }
+
+// 塔层战斗场景, 防守方为塔层配置的怪物波次
+func towerSceneOptions(req *pbCombat.TowerCombatRq) ([]scene.SceneOption, error) {
+	towerEntry, ok := auto.GetTowerEntry(req.GetTowerType(), req.GetTowerFloor())
+	if !ok {
+		return nil, ErrInvalidTower
+	}
+
+	sceneEntry, ok := auto.GetSceneEntry(towerEntry.SceneId)
+	if !ok {
+		return nil, ErrInvalidScene
+	}
+
+	// 塔层必须配置怪物波次, 否则防守方为空会直接判定胜利
+	if len(towerEntry.WaveID) == 0 {
+		return nil, ErrInvalidBattleWave
+	}
+
+	battleWaveEntries, err := getBattleWaveEntries(towerEntry.WaveID)
+	if !utils.ErrCheck(err, "getBattleWaveEntries failed when towerSceneOptions", req.GetTowerType(), req.GetTowerFloor()) {
+		return nil, err
+	}
+
+	layoutEntry, _ := auto.GetBattleLayoutEntry(towerEntry.LayoutID)
+
+	return []scene.SceneOption{
+		scene.WithSceneAttackId(req.GetAttackId()),
+		scene.WithSceneAttackUnitList(req.GetAttackEntityList()),
+		scene.WithSceneEntry(sceneEntry),
+		scene.WithSceneBattleWaveEntries(battleWaveEntries...),
+		scene.WithSceneBattleLayoutEntry(layoutEntry),
+	}, nil
+}
+
+// 根据波次id获取怪物波次配置
+func getBattleWaveEntries(waveIds []int32) ([]*auto.BattleWaveEntry, error) {
+	entries := make([]*auto.BattleWaveEntry, 0, len(waveIds))
+	for _, id := range waveIds {
+		entry, ok := auto.GetBattleWaveEntry(id)
+		if !ok {
+			return nil, fmt.Errorf("wave_id<%d>: %w", id, ErrInvalidBattleWave)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// 创建场景战斗并保存录像
+func (h *RpcHandler) combat(ctx context.Context, stageId int32, opts ...scene.SceneOption) (*scene.SceneResult, error) {
+	sc, err := h.c.sm.CreateScene(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := sc.GetResult(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Record.StageId = stageId
+	_, err = h.c.rm.SaveRecord(ctx, result.Record)
+	if !utils.ErrCheck(err, "SaveRecord failed when RpcHandler.combat", sc.GetId(), stageId) {
+		// 录像保存失败不影响战斗结果
+		result.Record.Id = -1
+	}
+
+	return result, nil
+}
@@ -0,0 +1,115 @@
+package combat
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel"
+	"github.com/east-eden/server/excel/auto"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	pbCombat "github.com/east-eden/server/proto/server/combat"
+	"github.com/east-eden/server/services/combat/scene"
+)
+
+func loadTestEntries(t *testing.T) {
+	t.Helper()
+
+	dir := "../../config/csv/"
+	if _, err := os.Stat(dir + "Tower.csv"); err != nil {
+		t.Skip("config/csv not found")
+	}
+
+	excel.ReadAllEntries(dir)
+}
+
+// 按id顺序取前num个英雄作为进攻方
+func testAttackList(num int) []*pbGlobal.EntityInfo {
+	heroIds := make([]int32, 0, auto.GetHeroSize())
+	for id := range auto.GetHeroRows() {
+		heroIds = append(heroIds, id)
+	}
+	sort.Slice(heroIds, func(i, j int) bool { return heroIds[i] < heroIds[j] })
+	if len(heroIds) > num {
+		heroIds = heroIds[:num]
+	}
+
+	attackList := make([]*pbGlobal.EntityInfo, 0, len(heroIds))
+	for _, id := range heroIds {
+		attackList = append(attackList, &pbGlobal.EntityInfo{HeroTypeId: id})
+	}
+
+	return attackList
+}
+
+func simulateTower(t *testing.T, towerType, floor int32, attackList []*pbGlobal.EntityInfo) *scene.SceneResult {
+	t.Helper()
+
+	opts, err := towerSceneOptions(&pbCombat.TowerCombatRq{
+		TowerType:        towerType,
+		TowerFloor:       floor,
+		AttackId:         1,
+		AttackEntityList: attackList,
+	})
+	if err != nil {
+		t.Fatalf("towerSceneOptions failed: %v", err)
+	}
+
+	return scene.Simulate(1, append(opts, scene.WithSceneSeed(12345))...)
+}
+
+func TestTowerCombat(t *testing.T) {
+	loadTestEntries(t)
+
+	// 每层都配置了怪物
+	for _, entry := range auto.GetTowerRows() {
+		if len(entry.WaveID) == 0 {
+			t.Fatalf("tower<%d> floor<%d> without battle wave", entry.Id, entry.Floor)
+		}
+	}
+
+	// 只有1点攻击和生命的英雄无法通关
+	weak := testAttackList(1)
+	weak[0].AttValue = make([]float32, define.AttFinalNum)
+	weak[0].AttValue[define.Att_Atk] = 1
+	weak[0].AttValue[define.Att_MaxHP] = 1
+	weak[0].AttValue[define.Att_CurHP] = 1
+	weak[0].AttValue[define.Att_AtbSpeed] = 0.1
+
+	if result := simulateTower(t, define.Tower_Type_General, 1, weak); result.Win {
+		t.Fatalf("weak lineup should lose tower floor: %v", result.Statistics)
+	}
+
+	// 通关时间和回合数来自实际战斗, 阵容越强排行得分越低
+	scores := make([]float64, 0, 3)
+	for num := 1; num <= 3; num++ {
+		result := simulateTower(t, define.Tower_Type_General, 1, testAttackList(num))
+		if !result.Win {
+			t.Fatalf("lineup with %d heroes should pass tower floor: %v", num, result.Statistics)
+		}
+
+		stats := result.Statistics
+		if stats.GetRounds() <= 0 || stats.GetDefenceDeadNum() != stats.GetDefenceUnitNum() {
+			t.Fatalf("tower combat statistics mismatch: %v", stats)
+		}
+
+		scores = append(scores, define.TowerRankScore(stats.GetPassTime(), stats.GetRounds()))
+	}
+
+	if !(scores[0] > scores[1] && scores[1] > scores[2]) {
+		t.Fatalf("tower rank score should depend on combat rounds, got %v", scores)
+	}
+
+	// 没有配置怪物的塔层不能挑战
+	entry, _ := auto.GetTowerEntry(define.Tower_Type_General, 1)
+	waveIds := entry.WaveID
+	entry.WaveID = nil
+	defer func() { entry.WaveID = waveIds }()
+
+	_, err := towerSceneOptions(&pbCombat.TowerCombatRq{TowerType: define.Tower_Type_General, TowerFloor: 1})
+	if !errors.Is(err, ErrInvalidBattleWave) {
+		t.Fatalf("tower floor without battle wave should return ErrInvalidBattleWave, got %v", err)
+	}
+}
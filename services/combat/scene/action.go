@@ -18,6 +18,7 @@ type Action struct {
 	opts      *ActionOptions // 行动参数
 	handler   ActionHandle   // 行动对应的处理
 	completed bool           // 行动是否结束
+	failed    bool           // 行动是否失败
 	count     int32          // 执行次数
 }
 
@@ -25,6 +26,7 @@ func (a *Action) Init(owner *SceneEntity, opts ...ActionOption) {
 	a.owner = owner
 	a.opts = DefaultActionOptions()
 	a.completed = false
+	a.failed = false
 	a.count = 0
 
 	for _, o := range opts {
@@ -40,6 +42,16 @@ func (a *Action) IsCompleted() bool {
 	return a.completed
 }
 
+// 行动失败也会结束行动
+func (a *Action) Fail() {
+	a.completed = true
+	a.failed = true
+}
+
+func (a *Action) IsFailed() bool {
+	return a.failed
+}
+
 // helper functions
 func (a *Action) GetScene() *Scene {
 	return a.owner.GetScene()
@@ -69,12 +81,9 @@ func (a *Action) Handle() error {
 	return errors.New("invalid action type")
 }
 
-// 空闲行动处理
+// 空闲行动处理, 没有目标时直接结束本次行动
 func (a *Action) handleIdle() error {
-	// 空闲执行10次后结束 100ms一次handle？
-	if a.count >= 10 {
-		a.Complete()
-	}
+	a.Complete()
 	return nil
 }
 
@@ -82,15 +91,48 @@ func (a *Action) handleIdle() error {
 func (a *Action) handleAttack() error {
 	target, ok := a.GetScene().GetEntity(a.opts.TargetId)
 	if !ok {
+		a.Fail()
 		return ErrAction_TargetNotFound
 	}
 
-	err := a.owner.CombatCtrl.CastSkill(a.owner.NormalSkill, target, false)
+	// 目标已死亡, 重新寻找目标
+	if target.HasState(define.HeroState_Dead) {
+		target, ok = a.owner.ActionCtrl.findTarget()
+		if !ok {
+			a.Complete()
+			return nil
+		}
+
+		a.opts.TargetId = target.id
+	}
+
+	// 技能都在cd中, 行动失败
+	skill := a.owner.CombatCtrl.SelectSkill(target)
+	if skill == nil {
+		a.Fail()
+		return nil
+	}
+
+	// 不在技能范围内, 向目标移动
+	if !a.owner.IsInSkillRange(skill, target) {
+		if !a.owner.MoveCtrl.CanMove() {
+			a.Fail()
+			return nil
+		}
+
+		a.owner.MoveCtrl.MoveTo(target, a.owner.GetSkillRange(skill, target))
+		return nil
+	}
+
+	a.owner.MoveCtrl.Stop()
+	a.owner.GetPosition().FaceTo(&target.GetPosition().Pos)
+
+	a.Complete()
+	err := a.owner.CombatCtrl.CastSkill(skill, target, false)
 	if !utils.ErrCheck(err, "Action CastSpell failed", a.owner.id, a.opts.TargetId) {
 		return err
 	}
 
-	a.Complete()
 	return nil
 }
 
@@ -8,6 +8,9 @@ import (
 	log "github.com/rs/zerolog/log"
 )
 
+// 无法行动的状态
+var actionFailMask uint32 = 1<<define.HeroState_Freeze | 1<<define.HeroState_Solid | 1<<define.HeroState_Stun | 1<<define.HeroState_Paralyzed
+
 type ActionCtrl struct {
 	owner      *SceneEntity // 拥有者
 	actionList *list.List   // 行动列表
@@ -22,31 +25,51 @@ func NewActionCtrl(owner *SceneEntity) *ActionCtrl {
 	return c
 }
 
+// com条满后轮到行动时更新, 行动结束后重置com条
 func (c *ActionCtrl) Update() {
-	log.Info().Int64("owner_id", c.owner.id).Msg("ActionCtrl update")
+	log.Trace().Int64("owner_id", c.owner.id).Msg("ActionCtrl update")
+
+	// 处于无法行动状态, 行动失败
+	if c.owner.HasStateAny(actionFailMask) {
+		c.owner.OnActionFinish(false)
+		return
+	}
 
 	c.updateActionList()
 }
 
 func (c *ActionCtrl) updateActionList() {
-	// 删除完成的行动
-	var next *list.Element
-	for e := c.actionList.Front(); e != nil; e = next {
-		next = e.Next()
-		if e.Value.(*Action).IsCompleted() {
-			c.actionList.Remove(e)
-		}
-	}
-
 	// 需要产生新行动
 	if c.actionList.Len() == 0 {
 		c.createNewAction()
 	}
 
 	// 执行当前行动
-	curAction := c.actionList.Front().Value.(*Action)
+	e := c.actionList.Front()
+	curAction := e.Value.(*Action)
 	err := curAction.Handle()
 	utils.ErrPrint(err, "action handle failed", curAction.opts.Type, c.owner.id)
+
+	// 行动结束
+	if curAction.IsCompleted() {
+		c.actionList.Remove(e)
+		c.owner.OnActionFinish(!curAction.IsFailed())
+	}
+}
+
+// 打断等待行动或正在进行的行动, com条按行动失败重置
+func (c *ActionCtrl) Interrupt() bool {
+	if !c.owner.AtbCtrl.IsReady() {
+		return false
+	}
+
+	c.owner.OnActionFinish(false)
+	return true
+}
+
+// 清空行动列表
+func (c *ActionCtrl) Clear() {
+	c.actionList.Init()
 }
 
 // 创建新行动
@@ -61,6 +84,7 @@ func (c *ActionCtrl) createNewAction() {
 		)
 
 		c.actionList.PushBack(action)
+		c.owner.GetScene().OnActionStart(c.owner, define.CombatAction_Attack, target.id)
 		return
 	}
 
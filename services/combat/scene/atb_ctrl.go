@@ -1,29 +1,119 @@
 package scene
 
-import log "github.com/rs/zerolog/log"
+import (
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel/auto"
+	log "github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// com条:
+// 每回合按时间槽速度增长, 满值后进入场景行动队列等待行动, 行动结束后重置.
+// 行动失败时按全局配置重置到指定位置, buff可以提前或延后com条
+
+var (
+	atbFullValue         = decimal.NewFromInt(1)                                  // com条满值
+	atbRoundTime         = decimal.New(define.Scene_RoundTime, -3)                // 每回合时间(秒)
+	atbFrozenMask uint32 = 1<<define.HeroState_Solid | 1<<define.HeroState_Freeze // 石化和冻结时com条停止增长
+)
 
 type AtbCtrl struct {
-	*AtbOption
-	owner *SceneEntity // 拥有者
+	opts  *AtbOptions
+	owner *SceneEntity    // 拥有者
+	value decimal.Decimal // 当前com条
+	ready bool            // com条已满, 在行动队列中
 }
 
 type AtbOption func(*AtbOptions)
 type AtbOptions struct {
+	InitValue decimal.Decimal // 初始com条
 }
 
 func DefaultAtbOptions() *AtbOptions {
-	o := &AtbOptions{}
+	o := &AtbOptions{
+		InitValue: decimal.Zero,
+	}
 	return o
 }
 
+func WithAtbInitValue(value decimal.Decimal) AtbOption {
+	return func(o *AtbOptions) {
+		o.InitValue = value
+	}
+}
+
 func NewAtbCtrl(owner *SceneEntity, opts ...AtbOption) *AtbCtrl {
 	c := &AtbCtrl{
+		opts:  DefaultAtbOptions(),
 		owner: owner,
 	}
 
+	for _, o := range opts {
+		o(c.opts)
+	}
+
+	c.value = c.opts.InitValue
 	return c
 }
 
 func (c *AtbCtrl) Update() {
-	log.Info().Int64("owner_id", c.owner.id).Msg("AtbCtrl update")
+	log.Trace().Int64("owner_id", c.owner.id).Str("value", c.value.String()).Msg("AtbCtrl update")
+
+	if c.ready || c.owner.HasStateAny(atbFrozenMask) {
+		return
+	}
+
+	speed := c.owner.GetAttManager().GetFinalAttValue(define.Att_AtbSpeed)
+	c.Mod(speed.Mul(atbRoundTime))
+}
+
+func (c *AtbCtrl) GetValue() decimal.Decimal {
+	return c.value
+}
+
+func (c *AtbCtrl) IsReady() bool {
+	return c.ready
+}
+
+// 改变com条, 正值提前负值延后
+func (c *AtbCtrl) Mod(value decimal.Decimal) {
+	c.set(c.value.Add(value))
+}
+
+// 行动结束后重置com条
+func (c *AtbCtrl) OnActionFinish(success bool) {
+	value := decimal.Zero
+	if !success {
+		if globalConfig, ok := auto.GetGlobalConfig(); ok {
+			value = globalConfig.ActFailComReset
+		}
+	}
+
+	c.set(value)
+}
+
+func (c *AtbCtrl) set(value decimal.Decimal) {
+	switch {
+	case value.LessThan(decimal.Zero):
+		value = decimal.Zero
+	case value.GreaterThan(atbFullValue):
+		value = atbFullValue
+	}
+	c.value = value
+
+	ready := c.value.Equal(atbFullValue)
+	if ready == c.ready {
+		return
+	}
+
+	c.ready = ready
+	if ready {
+		c.owner.GetScene().OnComFinish(c.owner)
+		return
+	}
+
+	// 离开行动队列, 放弃未完成的行动
+	c.owner.GetScene().OnComReset(c.owner)
+	c.owner.ActionCtrl.Clear()
+	c.owner.MoveCtrl.Reset()
 }
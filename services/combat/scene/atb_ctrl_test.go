@@ -0,0 +1,201 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel/auto"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/shopspring/decimal"
+)
+
+// 进攻方一个英雄站在(-5, 0), 防守方一个怪物站在(5, 0)
+func newAtbTestScene(t *testing.T) (*Scene, *SceneEntity, *SceneEntity) {
+	t.Helper()
+
+	sceneEntry, ok := auto.GetSceneEntry(1)
+	if !ok {
+		t.Fatal("scene entry not found")
+	}
+
+	s := NewScene()
+	s.init(1, WithSceneSeed(1), WithSceneEntry(sceneEntry))
+
+	err := s.AddEntityByPB(
+		s.camps[define.Scene_Camp_Attack],
+		testAttackList()[0],
+		WithEntityPosition(decimal.NewFromInt(-5), decimal.Zero, decimal.NewFromInt(90)),
+	)
+	if err != nil {
+		t.Fatalf("add attack entity failed: %v", err)
+	}
+
+	waveEntry, ok := auto.GetBattleWaveEntry(testStageEntry(t).WaveID[0])
+	if !ok {
+		t.Fatal("battle wave entry not found")
+	}
+	s.spawnWaveMonster(waveEntry.MonsterID[0], decimal.NewFromInt(5), decimal.Zero, decimal.NewFromInt(-90), decimal.Zero)
+
+	attacker, _ := s.GetEntity(1)
+	defender, ok := s.GetEntity(2)
+	if !ok {
+		t.Fatal("spawn monster failed")
+	}
+
+	s.onTaskStart()
+	return s, attacker, defender
+}
+
+func comFinishEntities(s *Scene) []*SceneEntity {
+	entities := make([]*SceneEntity, 0, s.comFinishList.Len())
+	for e := s.comFinishList.Front(); e != nil; e = e.Next() {
+		entities = append(entities, e.Value.(*SceneEntity))
+	}
+	return entities
+}
+
+func TestAtbFill(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	_, attacker, _ := newAtbTestScene(t)
+	attacker.AttManager.SetFinalAttValue(define.Att_AtbSpeed, decimal.NewFromFloat(0.25))
+
+	// 每回合增长 0.25 * 0.1
+	for n := 0; n < 39; n++ {
+		attacker.AtbCtrl.Update()
+	}
+
+	if !attacker.AtbCtrl.GetValue().Equal(decimal.NewFromFloat(0.975)) || attacker.AtbCtrl.IsReady() {
+		t.Fatalf("atb value %s ready %v, expect 0.975 not ready", attacker.AtbCtrl.GetValue(), attacker.AtbCtrl.IsReady())
+	}
+
+	attacker.AtbCtrl.Update()
+	if !attacker.AtbCtrl.IsReady() {
+		t.Fatalf("atb value %s should be ready", attacker.AtbCtrl.GetValue())
+	}
+
+	// 满值后不再增长
+	attacker.AtbCtrl.Update()
+	if !attacker.AtbCtrl.GetValue().Equal(atbFullValue) {
+		t.Fatalf("atb value %s should stay full", attacker.AtbCtrl.GetValue())
+	}
+
+	// 冻结时com条停止增长
+	attacker.AtbCtrl.OnActionFinish(true)
+	attacker.AddState(define.HeroState_Freeze, 1)
+	attacker.AtbCtrl.Update()
+	if !attacker.AtbCtrl.GetValue().IsZero() {
+		t.Fatalf("frozen atb value %s should not grow", attacker.AtbCtrl.GetValue())
+	}
+}
+
+func TestAtbTurnQueue(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	s, attacker, defender := newAtbTestScene(t)
+
+	defender.AtbCtrl.Mod(atbFullValue)
+	attacker.AtbCtrl.Mod(atbFullValue)
+	if queue := comFinishEntities(s); len(queue) != 2 || queue[0] != defender || queue[1] != attacker {
+		t.Fatalf("com finish list %v, expect defender then attacker", queue)
+	}
+
+	// 延后com条离开行动队列, 提前不超过满值
+	defender.AtbCtrl.Mod(decimal.NewFromFloat(-0.3))
+	if queue := comFinishEntities(s); len(queue) != 1 || queue[0] != attacker {
+		t.Fatalf("delayed entity should leave com finish list, got %v", queue)
+	}
+
+	defender.AtbCtrl.Mod(decimal.NewFromInt(2))
+	if !defender.AtbCtrl.GetValue().Equal(atbFullValue) {
+		t.Fatalf("atb value %s should be clamped to full", defender.AtbCtrl.GetValue())
+	}
+
+	// 队首单位无法行动, 行动失败com条重置到配置位置, 后面的单位在同一回合行动
+	attacker.AddState(define.HeroState_Stun, 1)
+	s.updateComFinish()
+
+	globalConfig, _ := auto.GetGlobalConfig()
+	if attacker.AtbCtrl.IsReady() || !attacker.AtbCtrl.GetValue().Equal(globalConfig.ActFailComReset) {
+		t.Fatalf("stunned attacker atb value %s, expect %s", attacker.AtbCtrl.GetValue(), globalConfig.ActFailComReset)
+	}
+
+	var defenderActed bool
+	for _, e := range s.record.Events {
+		if e.Type == pbGlobal.CombatEventType_CombatEvent_Action && e.CasterId == defender.id {
+			defenderActed = true
+		}
+	}
+
+	if !defenderActed {
+		t.Fatal("defender should act after stunned attacker")
+	}
+}
+
+func TestActionMoveToTarget(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	s, attacker, defender := newAtbTestScene(t)
+	attacker.AtbCtrl.Mod(atbFullValue)
+
+	start := Distance(&attacker.GetPosition().Pos, &defender.GetPosition().Pos)
+	for n := 0; n < 100 && attacker.AtbCtrl.IsReady(); n++ {
+		s.updateRound()
+	}
+
+	if attacker.AtbCtrl.IsReady() {
+		t.Fatal("attacker action should finish")
+	}
+
+	dist := Distance(&attacker.GetPosition().Pos, &defender.GetPosition().Pos)
+	moved := start.Sub(dist)
+	if !moved.IsPositive() || moved.GreaterThan(attacker.AttManager.GetFinalAttValue(define.Att_MoveScope)) {
+		t.Fatalf("attacker moved %s, move scope %s", moved, attacker.AttManager.GetFinalAttValue(define.Att_MoveScope))
+	}
+
+	var cast bool
+	for _, e := range s.record.Events {
+		if e.Type == pbGlobal.CombatEventType_CombatEvent_SkillCast && e.CasterId == attacker.id {
+			cast = true
+			entry, _ := auto.GetSkillBaseEntry(e.SkillId)
+			if !attacker.IsInSkillRange(entry, defender) {
+				t.Fatalf("skill<%d> cast out of range, distance %s", e.SkillId, dist)
+			}
+		}
+	}
+
+	// 移动范围内无法到达时行动失败
+	if !cast && !attacker.AtbCtrl.GetValue().IsPositive() {
+		t.Fatalf("attacker failed to reach target, atb value %s", attacker.AtbCtrl.GetValue())
+	}
+}
+
+func TestAuraEffectModAtb(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	_, attacker, _ := newAtbTestScene(t)
+	attacker.AtbCtrl.Mod(decimal.NewFromFloat(0.5))
+
+	aura := &Buff{opts: DefaultBuffOptions()}
+	aura.opts.Owner = attacker
+
+	// 延后30%
+	aura.CurPoint[0] = -3000
+	AuraEffectModAtb(aura, define.AuraEffectStep_Apply, 0, nil, nil)
+	if !attacker.AtbCtrl.GetValue().Equal(decimal.NewFromFloat(0.2)) {
+		t.Fatalf("atb value %s, expect 0.2", attacker.AtbCtrl.GetValue())
+	}
+
+	// 移除时不恢复
+	AuraEffectModAtb(aura, define.AuraEffectStep_Remove, 0, nil, nil)
+	if !attacker.AtbCtrl.GetValue().Equal(decimal.NewFromFloat(0.2)) {
+		t.Fatalf("atb value %s, expect 0.2 after remove", attacker.AtbCtrl.GetValue())
+	}
+
+	// 提前到满值进入行动队列
+	aura.CurPoint[0] = 10000
+	AuraEffectModAtb(aura, define.AuraEffectStep_Effect, 0, nil, nil)
+	if !attacker.AtbCtrl.IsReady() {
+		t.Fatalf("atb value %s should be ready", attacker.AtbCtrl.GetValue())
+	}
+}
@@ -148,6 +148,10 @@ func (a *Buff) CalAuraEffect(step define.EAuraEffectStep, effIndex int32, param1
 		if a.TriggerCount[effIndex] != 0 && a.isNoCd(effIndex) {
 			eff := a.opts.Entry.Effects[effIndex]
 			result = auraEffectsHandlers[eff](a, step, effIndex, param1, param2)
+			if step == define.AuraEffectStep_Effect {
+				a.opts.Owner.GetScene().OnBuffTick(a, effIndex)
+			}
+
 			a.TriggerCount[effIndex]--
 			a.TriggerCd[effIndex] = a.opts.Entry.TriggerCd[effIndex]
 
@@ -157,14 +161,17 @@ func (a *Buff) CalAuraEffect(step define.EAuraEffectStep, effIndex int32, param1
 			}
 		}
 	} else {
+		var ticked bool
 		for index := 0; index < define.SpellEffectNum; index++ {
 			if step == define.AuraEffectStep_Effect && a.opts.Entry.TriggerId[index] > 0 {
 				continue
 			}
 
-			removeMode := *param1.(*define.EAuraRemoveMode)
-			if step == define.AuraEffectStep_Remove && (removeMode&define.EAuraRemoveMode(a.opts.Entry.RemoveEffect[index]) == 0) {
-				continue
+			if step == define.AuraEffectStep_Remove {
+				removeMode := *param1.(*define.EAuraRemoveMode)
+				if removeMode&define.EAuraRemoveMode(a.opts.Entry.RemoveEffect[index]) == 0 {
+					continue
+				}
 			}
 
 			eff := a.opts.Entry.Effects[index]
@@ -172,7 +179,12 @@ func (a *Buff) CalAuraEffect(step define.EAuraEffectStep, effIndex int32, param1
 				continue
 			}
 
-			result = auraEffectsHandlers[eff](a, step, effIndex, param1, param2)
+			result = auraEffectsHandlers[eff](a, step, int32(index), param1, param2)
+			ticked = true
+		}
+
+		if step == define.AuraEffectStep_Effect && ticked {
+			a.opts.Owner.GetScene().OnBuffTick(a, effIndex)
 		}
 	}
 
@@ -191,9 +203,9 @@ func (a *Buff) SyncAuraToClient(step define.EAuraSyncStep) {
 		}
 
 		switch step {
+		// buff添加和移除事件由CombatCtrl记录到战斗事件流
 		case define.AuraSyncStep_Add:
 			// if !scene.IsOnlyRecord() {
-			// todo send
 			//CreateSceneProtoMsg(msg, MS_AddAura,);
 			//*msg << (UINT32)(VALID(m_pCaster) ? m_pCaster->GetLocation() : INVALID);
 			//*msg << (UINT32)m_pOwner->GetLocation();
@@ -1,30 +1,39 @@
 package scene
 
-import "github.com/east-eden/server/define"
+import (
+	"github.com/east-eden/server/define"
+	"github.com/shopspring/decimal"
+)
 
 // 技能效果处理函数
 type AuraEffectsHandler func(*Buff, define.EAuraEffectStep, int32, any, any) define.EAuraAddResult
 
-var auraEffectsHandlers []AuraEffectsHandler = []AuraEffectsHandler{
-	AuraEffectNull,               // 0
-	AuraEffectPeriodDamage,       // 1周期伤害
-	AuraEffectModAtt,             // 2属性改变
-	AuraEffectSpell,              // 3施放技能
-	AuraEffectState,              // 4状态变更
-	AuraEffectImmunity,           // 5免疫变更
-	AuraEffectDmgMod,             // 6伤害转换(总量一定)
-	AuraEffectNewDmg,             // 7生成伤害(原伤害不变)
-	AuraEffectDmgFix,             // 8限量伤害(改变原伤害)
-	AuraEffectChgMelee,           // 9替换普通攻击
-	AuraEffectShield,             // 10血盾
-	AuraEffectDmgAttMod,          // 11改变伤害属性
-	AuraEffectAbsorbAllDmg,       // 12全伤害吸收
-	AuraEffectDmgAccumulate,      // 13累计伤害
-	AuraEffectMeleeSpell,         // 14释放普通攻击
-	AuraEffectLimitAttack,        // 15限制攻击力
-	AuraEffectPeriodHeal,         // 16周期治疗
-	AuraEffectModAttByAlive,      // 15根据当前友方存活人数,计算属性改变
-	AuraEffectModAttByEnemyAlive, // 16根据当前敌方存活人数,计算属性改变
+var auraEffectsHandlers []AuraEffectsHandler
+
+// 效果处理函数间接引用了auraEffectsHandlers, 需要在init中初始化
+func init() {
+	auraEffectsHandlers = []AuraEffectsHandler{
+		AuraEffectNull,               // 0
+		AuraEffectPeriodDamage,       // 1周期伤害
+		AuraEffectModAtt,             // 2属性改变
+		AuraEffectSpell,              // 3施放技能
+		AuraEffectState,              // 4状态变更
+		AuraEffectImmunity,           // 5免疫变更
+		AuraEffectDmgMod,             // 6伤害转换(总量一定)
+		AuraEffectNewDmg,             // 7生成伤害(原伤害不变)
+		AuraEffectDmgFix,             // 8限量伤害(改变原伤害)
+		AuraEffectChgMelee,           // 9替换普通攻击
+		AuraEffectShield,             // 10血盾
+		AuraEffectDmgAttMod,          // 11改变伤害属性
+		AuraEffectAbsorbAllDmg,       // 12全伤害吸收
+		AuraEffectDmgAccumulate,      // 13累计伤害
+		AuraEffectMeleeSpell,         // 14释放普通攻击
+		AuraEffectLimitAttack,        // 15限制攻击力
+		AuraEffectPeriodHeal,         // 16周期治疗
+		AuraEffectModAttByAlive,      // 15根据当前友方存活人数,计算属性改变
+		AuraEffectModAttByEnemyAlive, // 16根据当前敌方存活人数,计算属性改变
+		AuraEffectModAtb,             // 19改变com条
+	}
 }
 
 func AuraEffectNull(aura *Buff, step define.EAuraEffectStep, index int32, param1 any, param2 any) define.EAuraAddResult {
@@ -283,29 +292,32 @@ func AuraEffectSpell(aura *Buff, step define.EAuraEffectStep, index int32, param
 // 改变状态
 //-------------------------------------------------------------------------------
 func AuraEffectState(aura *Buff, step define.EAuraEffectStep, index int32, param1 any, param2 any) define.EAuraAddResult {
-	//DWORD dwServentState = m_pAuraEntry->nMiscValue1[nIndex];
-
-	//switch (eStep)
-	//{
-	//case EAES_Apply:
-	//// 设置状态
-	//m_pOwner->AddState(dwServentState);
-
-	//if( dwServentState & EHSF_Taunt )
-	//{
-	//m_pOwner->SetTauntTarget(m_pCaster->GetPos());
-	//}
-
-	//break;
-
-	//case EAES_Remove:
-	//// 去除状态
-	//m_pOwner->DecState(dwServentState);
-	//break;
-
-	//default:
-	//break;
-	//}
+	owner := aura.opts.Owner
+	state := define.EHeroState(aura.opts.Entry.MiscValue1[index])
+	if state < define.HeroState_Begin || state >= define.HeroState_End {
+		return define.AuraAddResult_Null
+	}
+
+	switch step {
+	case define.AuraEffectStep_Check:
+		if state != define.HeroState_Dead && owner.HasImmunityAny(define.ImmunityType_Mechanic, 1<<state) {
+			return define.AuraAddResult_Immunity
+		}
+
+	case define.AuraEffectStep_Apply:
+		// 设置状态
+		if !owner.AddState(state, 1) {
+			return define.AuraAddResult_Immunity
+		}
+
+		if state == define.HeroState_Taunt && aura.opts.Caster != nil {
+			owner.TauntId = aura.opts.Caster.id
+		}
+
+	case define.AuraEffectStep_Remove:
+		// 去除状态
+		owner.DecState(state, 1)
+	}
 
 	return define.AuraAddResult_Success
 }
@@ -314,19 +326,19 @@ func AuraEffectState(aura *Buff, step define.EAuraEffectStep, index int32, param
 // 免疫变更
 //-------------------------------------------------------------------------------
 func AuraEffectImmunity(aura *Buff, step define.EAuraEffectStep, index int32, param1 any, param2 any) define.EAuraAddResult {
-	//EImmunityType eType		= (EImmunityType)m_pAuraEntry->nMiscType1[nIndex];
-	//DWORD dwUnitImmunity	= m_pAuraEntry->nMiscValue1[nIndex];
+	tp := define.EImmunityType(aura.opts.Entry.MiscType1[index])
+	if tp < define.ImmunityType_Begin || tp >= define.ImmunityType_End {
+		return define.AuraAddResult_Null
+	}
 
-	//switch (eStep)
-	//{
-	//case EAES_Apply:
-	//m_pOwner->AddImmunity(eType, dwUnitImmunity);
-	//break;
+	flag := uint32(aura.opts.Entry.MiscValue1[index])
+	switch step {
+	case define.AuraEffectStep_Apply:
+		aura.opts.Owner.AddImmunity(tp, flag)
 
-	//case EAES_Remove:
-	//m_pOwner->DecImmunity(eType, dwUnitImmunity);
-	//break;
-	//}
+	case define.AuraEffectStep_Remove:
+		aura.opts.Owner.DecImmunity(tp, flag)
+	}
 
 	return define.AuraAddResult_Success
 }
@@ -969,3 +981,15 @@ func AuraEffectModAttByEnemyAlive(aura *Buff, step define.EAuraEffectStep, index
 
 	return define.AuraAddResult_Success
 }
+
+//-------------------------------------------------------------------------------
+// 改变com条, 效果值为万分比, 正值提前负值延后
+//-------------------------------------------------------------------------------
+func AuraEffectModAtb(aura *Buff, step define.EAuraEffectStep, index int32, param1 any, param2 any) define.EAuraAddResult {
+	switch step {
+	case define.AuraEffectStep_Apply, define.AuraEffectStep_Effect:
+		aura.opts.Owner.AtbCtrl.Mod(decimal.New(int64(aura.CurPoint[index]), -4))
+	}
+
+	return define.AuraAddResult_Success
+}
@@ -11,9 +11,9 @@ import (
 
 var (
 	ErrSkillCdLimit = errors.New("skill cd limit")
+	ErrSkillInvalid = errors.New("invalid skill")
 
-	updateCdValue  int32 = 1 // 每次更新减少cd值
-	updateAtbValue int32 = 1 // 每次更新增加atb值
+	updateCdValue int32 = 1 // 每次行动减少cd值
 )
 
 type AuraTrigger struct {
@@ -171,6 +171,10 @@ func (c *CombatCtrl) checkTargetLimit(entry *auto.SkillBaseEntry, target *SceneE
 
 // 能否释放技能
 func (c *CombatCtrl) CanCast(skillEntry *auto.SkillBaseEntry, target *SceneEntity) error {
+	if skillEntry == nil {
+		return ErrSkillInvalid
+	}
+
 	// cd limit
 	if c.mapSkillCd[skillEntry.Id] > 0 {
 		return ErrSkillCdLimit
@@ -197,7 +201,7 @@ func (c *CombatCtrl) CastSkill(skillEntry *auto.SkillBaseEntry, target *SceneEnt
 
 	s := NewSkill()
 	s.Init(
-		s.scene,
+		c.scene,
 		WithSkilEntry(skillEntry),
 		WithSkillCaster(c.owner),
 		WithSkillTarget(target),
@@ -206,6 +210,24 @@ func (c *CombatCtrl) CastSkill(skillEntry *auto.SkillBaseEntry, target *SceneEnt
 
 	s.Cast()
 	c.AddSkillCd(skillEntry.Id, skillEntry.GeneralCD)
+	c.scene.OnSkillCast(c.owner, target, skillEntry)
+
+	return nil
+}
+
+// 选择可以施放的技能, 优先奥义技能
+func (c *CombatCtrl) SelectSkill(target *SceneEntity) *auto.SkillBaseEntry {
+	skills := []*auto.SkillBaseEntry{
+		c.owner.UltimateSkill,
+		c.owner.NormalSkill,
+		c.owner.GeneralSkill,
+	}
+
+	for _, entry := range skills {
+		if c.CanCast(entry, target) == nil {
+			return entry
+		}
+	}
 
 	return nil
 }
@@ -216,11 +238,10 @@ func (c *CombatCtrl) AddSkillCd(skillId int32, cd int32) {
 }
 
 func (c *CombatCtrl) Update() {
-	c.updateSkillCd()
 	c.updateBuff()
-	c.updateATB()
 }
 
+// 技能cd按行动次数减少
 func (c *CombatCtrl) updateSkillCd() {
 	for id := range c.mapSkillCd {
 		c.mapSkillCd[id] -= updateCdValue
@@ -247,12 +268,6 @@ func (c *CombatCtrl) updateBuff() {
 	}
 }
 
-func (c *CombatCtrl) updateATB() {
-	// c.AtbValue += int32(c.owner.GetAttManager().GetFinalAttValue(define.Att_AtbSpeed).IntPart()) * updateAtbValue
-
-	// todo trigger com finish
-}
-
 //-------------------------------------------------------------------------------
 // 技能结果触发
 //-------------------------------------------------------------------------------
@@ -614,6 +629,7 @@ func (c *CombatCtrl) AddAura(auraId uint32,
 	switch wrapResult {
 	case define.AuraWrapResult_Replace, define.AuraWrapResult_Add, define.AuraWrapResult_Wrap:
 		aura.CalcApplyEffect(true, true)
+		c.scene.OnBuffChanged(aura, true)
 	default:
 		ReleaseBuff(tempAura)
 		return define.AuraAddResult_Inferior
@@ -646,6 +662,7 @@ func (c *CombatCtrl) RemoveAura(aura *Buff, mode define.EAuraRemoveMode) bool {
 	// 转移到废弃列表
 	c.arrayAura[slotIndex] = nil
 	c.listDelAura.PushBack(aura)
+	c.scene.OnBuffChanged(aura, false)
 
 	// 发送同步消息
 	if define.AuraRemoveMode_Destroy&mode != 0 {
@@ -1219,6 +1236,27 @@ func (c *CombatCtrl) removeAuraByState(state define.EHeroState) {
 	}
 }
 
+//-------------------------------------------------------------------------------
+// 按控制类型删除aura
+//-------------------------------------------------------------------------------
+func (c *CombatCtrl) removeAuraByMechanic(mechanic uint32) {
+	for index := 0; index < define.Combat_MaxAura; index++ {
+		aura := c.arrayAura[index]
+		if aura == nil || (aura.GetRemoveMode()&define.AuraRemoveMode_Running) == 0 {
+			continue
+		}
+
+		auraEntry := aura.Opts().Entry
+		if auraEntry == nil {
+			continue
+		}
+
+		if auraEntry.MechanicFlags&mechanic != 0 {
+			c.RemoveAura(aura, define.AuraRemoveMode_Dispel)
+		}
+	}
+}
+
 //-------------------------------------------------------------------------------
 // 按施放者和ID删除aura
 //-------------------------------------------------------------------------------
@@ -1244,6 +1282,25 @@ func (c *CombatCtrl) removeAuraByCaster(caster *SceneEntity) {
 	}
 }
 
+//-------------------------------------------------------------------------------
+// 删除所有非被动aura
+//-------------------------------------------------------------------------------
+func (c *CombatCtrl) RemoveAllAura() {
+	for index := 0; index < define.Combat_MaxAura; index++ {
+		aura := c.arrayAura[index]
+		if aura == nil || (aura.GetRemoveMode()&define.AuraRemoveMode_Running) == 0 {
+			continue
+		}
+
+		auraEntry := aura.Opts().Entry
+		if auraEntry == nil || auraEntry.Passive {
+			continue
+		}
+
+		c.RemoveAura(aura, define.AuraRemoveMode_Cancel)
+	}
+}
+
 //-------------------------------------------------------------------------------
 // 是否有指定TypeID的aura
 //-------------------------------------------------------------------------------
@@ -1281,6 +1338,11 @@ func (c *CombatCtrl) DispelAura(dispelType uint32, num uint32) bool {
 			continue
 		}
 
+		// 免疫驱散
+		if c.owner.HasImmunityAny(define.ImmunityType_Dispel, aura.Opts().Entry.DispelFlags) {
+			continue
+		}
+
 		if aura.Opts().Entry.DispelFlags&dispelType != 0 {
 			aura.Disperse()
 			dispel = true
@@ -1,10 +1,7 @@
 package scene
 
-import "github.com/shopspring/decimal"
-
 type CombatCtrlOption func(*CombatCtrlOptions)
 type CombatCtrlOptions struct {
-	AtbValue decimal.Decimal // 行动条
 }
 
 func DefaultCombatCtrlOptions() *CombatCtrlOptions {
@@ -12,9 +9,3 @@ func DefaultCombatCtrlOptions() *CombatCtrlOptions {
 
 	return o
 }
-
-func WithCombatCtrlAtbValue(value decimal.Decimal) CombatCtrlOption {
-	return func(o *CombatCtrlOptions) {
-		o.AtbValue = value
-	}
-}
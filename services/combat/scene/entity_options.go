@@ -6,7 +6,6 @@ import (
 	"github.com/east-eden/server/internal/att"
 	"github.com/east-eden/server/utils"
 	"github.com/shopspring/decimal"
-	"github.com/willf/bitset"
 )
 
 type EntityOption func(*EntityOptions)
@@ -16,6 +15,7 @@ type EntityOptions struct {
 	Pos          *Position
 	InitAtbValue decimal.Decimal
 	AttManager   *att.AttManager
+	AttList      []float32 // 外部传入的最终属性
 	Scene        *Scene
 	SceneCamp    *SceneCamp
 
@@ -30,12 +30,13 @@ type EntityOptions struct {
 	PassiveSkills []*auto.SkillBaseEntry // 被动技能列表
 
 	State    *utils.CountableBitset
-	Immunity [define.ImmunityType_End]*bitset.BitSet
+	Immunity [define.ImmunityType_End]*utils.CountableBitset
 }
 
 func DefaultEntityOptions() *EntityOptions {
 	o := &EntityOptions{
 		MonsterId:    -1,
+		Pos:          &Position{},
 		InitAtbValue: decimal.NewFromInt32(0),
 		MonsterEntry: nil,
 		HeroEntry:    nil,
@@ -53,7 +54,7 @@ func DefaultEntityOptions() *EntityOptions {
 	}
 
 	for k := range o.Immunity {
-		o.Immunity[k] = bitset.New(uint(64))
+		o.Immunity[k] = utils.NewCountableBitset(Entity_ImmunityBits)
 	}
 
 	return o
@@ -84,6 +85,11 @@ func WithEntityHeroEntry(entry *auto.HeroEntry) EntityOption {
 func WithEntityMonsterEntry(entry *auto.MonsterEntry) EntityOption {
 	return func(o *EntityOptions) {
 		o.MonsterEntry = entry
+
+		o.GeneralSkill, _ = auto.GetSkillBaseEntry(entry.Skill1)
+		if len(entry.Skill2) > 0 {
+			o.NormalSkill, _ = auto.GetSkillBaseEntry(entry.Skill2[0])
+		}
 	}
 }
 
@@ -129,9 +135,7 @@ func WithEntityAttValue(tp int, value int32) EntityOption {
 
 func WithEntityAttList(attList []float32) EntityOption {
 	return func(o *EntityOptions) {
-		for tp := range attList {
-			o.AttManager.SetFinalAttValue(tp, decimal.NewFromFloat32(attList[tp]).Round(2))
-		}
+		o.AttList = attList
 	}
 }
 
@@ -1,19 +1,98 @@
 package scene
 
-import log "github.com/rs/zerolog/log"
+import (
+	"github.com/east-eden/server/define"
+	log "github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// 移动:
+// 行动时目标不在技能范围内则向目标移动, 每回合移动距离由移动速度决定,
+// 每次行动最多移动移动范围的距离
+
+var moveTolerance = decimal.New(1, -3) // 移动误差
 
 type MoveCtrl struct {
-	owner *SceneEntity // 拥有者
+	owner    *SceneEntity    // 拥有者
+	target   *SceneEntity    // 移动目标
+	distance decimal.Decimal // 到达目标的距离
+	moved    decimal.Decimal // 本次行动已移动距离
 }
 
 func NewMoveCtrl(owner *SceneEntity) *MoveCtrl {
 	c := &MoveCtrl{
 		owner: owner,
+		moved: decimal.Zero,
 	}
 
 	return c
 }
 
 func (c *MoveCtrl) Update() {
-	log.Info().Int64("owner_id", c.owner.id).Msg("MoveCtrl update")
+	log.Trace().Int64("owner_id", c.owner.id).Msg("MoveCtrl update")
+
+	if c.target == nil {
+		return
+	}
+
+	if c.IsArrived() {
+		c.Stop()
+		return
+	}
+
+	step := c.owner.GetAttManager().GetFinalAttValue(define.Att_MoveSpeed).Mul(atbRoundTime)
+	if left := c.GetMoveScope().Sub(c.moved); step.GreaterThan(left) {
+		step = left
+	}
+
+	// 移动到距离目标distance以内, 留出坐标精度误差
+	pos := c.owner.GetPosition()
+	targetPos := c.target.GetPosition()
+	step = decimal.Min(step, Distance(&pos.Pos, &targetPos.Pos).Sub(c.distance).Add(moveTolerance))
+	if step.LessThanOrEqual(decimal.Zero) {
+		return
+	}
+
+	pos.MoveTowards(&targetPos.Pos, step)
+	c.moved = c.moved.Add(step)
+}
+
+// 向目标移动, 直到距离目标distance以内
+func (c *MoveCtrl) MoveTo(target *SceneEntity, distance decimal.Decimal) {
+	c.target = target
+	c.distance = distance
+	c.owner.GetPosition().FaceTo(&target.GetPosition().Pos)
+}
+
+func (c *MoveCtrl) Stop() {
+	c.target = nil
+}
+
+// 行动结束后重置移动距离
+func (c *MoveCtrl) Reset() {
+	c.Stop()
+	c.moved = decimal.Zero
+}
+
+func (c *MoveCtrl) IsMoving() bool {
+	return c.target != nil
+}
+
+// 是否已到达目标范围内
+func (c *MoveCtrl) IsArrived() bool {
+	if c.target == nil {
+		return true
+	}
+
+	return IsInDistance(c.owner.GetPosition(), c.target.GetPosition(), c.distance)
+}
+
+// 本次行动是否还能移动
+func (c *MoveCtrl) CanMove() bool {
+	speed := c.owner.GetAttManager().GetFinalAttValue(define.Att_MoveSpeed)
+	return speed.IsPositive() && c.moved.LessThan(c.GetMoveScope())
+}
+
+func (c *MoveCtrl) GetMoveScope() decimal.Decimal {
+	return c.owner.GetAttManager().GetFinalAttValue(define.Att_MoveScope)
 }
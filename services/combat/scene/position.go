@@ -1,6 +1,13 @@
 package scene
 
-import "github.com/shopspring/decimal"
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+// 坐标精度, 移动后的坐标保留4位小数保证回放一致
+const positionPrecision int32 = 4
 
 type Pos struct {
 	X decimal.Decimal
@@ -8,14 +15,94 @@ type Pos struct {
 }
 
 // 坐标信息
+// Rotate为绕y轴旋转角度, 0度朝向z轴正方向, 90度朝向x轴正方向
 type Position struct {
 	Pos
 	Rotate decimal.Decimal
 }
 
-func IsInDistance(a, b *Position, distance int32) bool {
-	x2 := a.X.Sub(b.X).Mul(a.X)
-	z2 := a.Z.Sub(b.Z).Mul(a.Z)
-	d := decimal.NewFromInt32(distance)
-	return x2.Add(z2).LessThanOrEqual(d.Mul(d))
+func IsInDistance(a, b *Position, distance decimal.Decimal) bool {
+	x := a.X.Sub(b.X)
+	z := a.Z.Sub(b.Z)
+	return x.Mul(x).Add(z.Mul(z)).LessThanOrEqual(distance.Mul(distance))
+}
+
+// 两点间距离
+func Distance(a, b *Pos) decimal.Decimal {
+	x, z := b.sub(a)
+	return decimal.NewFromFloat(math.Hypot(x, z)).Round(positionPrecision)
+}
+
+// 判断b是否在以a为起点, 沿a朝向长length宽width的矩形内
+func IsInRectangle(a, b *Position, length, width decimal.Decimal) bool {
+	x, z := b.sub(&a.Pos)
+	fx, fz := a.forward()
+
+	// 投影到a的前方和右方
+	front := x*fx + z*fz
+	side := x*fz - z*fx
+
+	l, _ := length.Float64()
+	w, _ := width.Float64()
+	return front >= 0 && front <= l && math.Abs(side) <= w/2
+}
+
+// 判断b是否在以a为圆心, 沿a朝向半径radius夹角angle(角度)的扇形内
+func IsInFan(a, b *Position, radius, angle decimal.Decimal) bool {
+	if !IsInDistance(a, b, radius) {
+		return false
+	}
+
+	x, z := b.sub(&a.Pos)
+	if x == 0 && z == 0 {
+		return true
+	}
+
+	fx, fz := a.forward()
+	cos := (x*fx + z*fz) / math.Hypot(x, z)
+	half, _ := angle.Div(decimal.NewFromInt(2)).Float64()
+	return cos >= math.Cos(half*math.Pi/180)
+}
+
+// 朝向目标点
+func (p *Position) FaceTo(target *Pos) {
+	x, z := target.sub(&p.Pos)
+	if x == 0 && z == 0 {
+		return
+	}
+
+	p.Rotate = decimal.NewFromFloat(math.Atan2(x, z) * 180 / math.Pi).Round(positionPrecision)
+}
+
+// 向目标点移动step距离, 返回是否到达目标点
+func (p *Position) MoveTowards(target *Pos, step decimal.Decimal) bool {
+	p.FaceTo(target)
+
+	dist := Distance(&p.Pos, target)
+	if dist.LessThanOrEqual(step) {
+		p.X = target.X
+		p.Z = target.Z
+		return true
+	}
+
+	x, z := target.sub(&p.Pos)
+	d, _ := dist.Float64()
+	s, _ := step.Float64()
+	p.X = p.X.Add(decimal.NewFromFloat(x * s / d)).Round(positionPrecision)
+	p.Z = p.Z.Add(decimal.NewFromFloat(z * s / d)).Round(positionPrecision)
+	return false
+}
+
+// 朝向的单位向量
+func (p *Position) forward() (float64, float64) {
+	r, _ := p.Rotate.Float64()
+	r = r * math.Pi / 180
+	return math.Sin(r), math.Cos(r)
+}
+
+// p - o 的向量
+func (p *Pos) sub(o *Pos) (float64, float64) {
+	x, _ := p.X.Sub(o.X).Float64()
+	z, _ := p.Z.Sub(o.Z).Float64()
+	return x, z
 }
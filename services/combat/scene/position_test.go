@@ -0,0 +1,109 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func newTestPosition(x, z, rotate float64) *Position {
+	return &Position{
+		Pos: Pos{
+			X: decimal.NewFromFloat(x),
+			Z: decimal.NewFromFloat(z),
+		},
+		Rotate: decimal.NewFromFloat(rotate),
+	}
+}
+
+func TestIsInDistance(t *testing.T) {
+	a := newTestPosition(1, 1, 0)
+	cases := []struct {
+		b        *Position
+		distance float64
+		in       bool
+	}{
+		{b: newTestPosition(4, 5, 0), distance: 5, in: true},
+		{b: newTestPosition(4, 5, 0), distance: 4.9, in: false},
+		{b: newTestPosition(-2, -3, 0), distance: 5, in: true},
+		{b: newTestPosition(1, 1, 0), distance: 0, in: true},
+	}
+
+	for n, c := range cases {
+		if IsInDistance(a, c.b, decimal.NewFromFloat(c.distance)) != c.in {
+			t.Fatalf("case %d expect %v", n, c.in)
+		}
+	}
+
+	if d := Distance(&a.Pos, &cases[0].b.Pos); !d.Equal(decimal.NewFromInt(5)) {
+		t.Fatalf("distance %s, expect 5", d)
+	}
+}
+
+func TestIsInRectangle(t *testing.T) {
+	// 朝向x轴正方向, 长4宽2
+	a := newTestPosition(0, 0, 90)
+	length, width := decimal.NewFromInt(4), decimal.NewFromInt(2)
+
+	cases := []struct {
+		b  *Position
+		in bool
+	}{
+		{b: newTestPosition(2, 0, 0), in: true},
+		{b: newTestPosition(4, 1, 0), in: true},
+		{b: newTestPosition(4.1, 0, 0), in: false},
+		{b: newTestPosition(2, 1.1, 0), in: false},
+		{b: newTestPosition(-1, 0, 0), in: false},
+	}
+
+	for n, c := range cases {
+		if IsInRectangle(a, c.b, length, width) != c.in {
+			t.Fatalf("case %d expect %v", n, c.in)
+		}
+	}
+}
+
+func TestIsInFan(t *testing.T) {
+	// 朝向z轴正方向, 半径5夹角90度
+	a := newTestPosition(0, 0, 0)
+	radius, angle := decimal.NewFromInt(5), decimal.NewFromInt(90)
+
+	cases := []struct {
+		b  *Position
+		in bool
+	}{
+		{b: newTestPosition(0, 3, 0), in: true},
+		{b: newTestPosition(2, 2.5, 0), in: true},
+		{b: newTestPosition(3, 2, 0), in: false},
+		{b: newTestPosition(0, 5.1, 0), in: false},
+		{b: newTestPosition(0, -1, 0), in: false},
+	}
+
+	for n, c := range cases {
+		if IsInFan(a, c.b, radius, angle) != c.in {
+			t.Fatalf("case %d expect %v", n, c.in)
+		}
+	}
+}
+
+func TestMoveTowards(t *testing.T) {
+	p := newTestPosition(0, 0, 0)
+	target := &newTestPosition(3, 4, 0).Pos
+
+	if p.MoveTowards(target, decimal.NewFromInt(2)) {
+		t.Fatal("should not arrive")
+	}
+
+	if !p.X.Equal(decimal.NewFromFloat(1.2)) || !p.Z.Equal(decimal.NewFromFloat(1.6)) {
+		t.Fatalf("position (%s, %s), expect (1.2, 1.6)", p.X, p.Z)
+	}
+
+	// 朝向目标
+	if f, _ := p.Rotate.Float64(); f < 36.8 || f > 36.9 {
+		t.Fatalf("rotate %s, expect 36.87", p.Rotate)
+	}
+
+	if !p.MoveTowards(target, decimal.NewFromInt(5)) || !p.X.Equal(target.X) || !p.Z.Equal(target.Z) {
+		t.Fatalf("should arrive at target, position (%s, %s)", p.X, p.Z)
+	}
+}
@@ -22,8 +22,28 @@ import (
 
 var (
 	ErrSceneModelNotFound = errors.New("model not found")
+	sceneUpdateInterval   = time.Millisecond * 100 // 场景更新间隔
 )
 
+// 战斗结果
+type SceneResult struct {
+	Win        bool
+	Statistics *pbGlobal.CombatStatistics
+	Record     *pbGlobal.CombatRecord
+	Units      []*SceneUnitResult // 所有战斗单位结算, 按单位id排序
+}
+
+// 战斗单位结算
+type SceneUnitResult struct {
+	Id        int64
+	Camp      int32
+	TypeId    int32 // 英雄或怪物type_id
+	IsMonster bool
+	DmgDone   int64 // 造成的伤害
+	DmgRecv   int64 // 受到的伤害
+	Dead      bool
+}
+
 type Scene struct {
 	opts   *SceneOptions
 	tasker *task.Tasker
@@ -33,7 +53,11 @@ type Scene struct {
 	entityMap   *treemap.Map // 战斗unit列表
 	curRound    int32
 	maxRound    int32
-	result      chan bool
+	curWave     int32 // 当前波次索引
+	finished    bool
+	result      chan *SceneResult
+	statistics  *pbGlobal.CombatStatistics // 战斗统计
+	record      *pbGlobal.CombatRecord     // 战斗录像
 	rand        *random.FakeRandom[int]
 	camps       [define.Scene_Camp_End]*SceneCamp
 
@@ -44,15 +68,20 @@ type Scene struct {
 	sync.RWMutex
 }
 
-func (s *Scene) Init(sceneId int64, opts ...SceneOption) *Scene {
+// 初始化场景数据和战斗单位, 不包含tasker
+func (s *Scene) init(sceneId int64, opts ...SceneOption) {
 	s.id = sceneId
+	s.entityIdGen = 0
 	s.entityMap = treemap.NewWith(god_utils.Int64Comparator)
 	s.comFinishList = list.New()
 	s.spellList = list.New()
-	s.result = make(chan bool, 1)
+	s.curRound = 0
+	s.maxRound = define.Scene_MaxRound
+	s.curWave = 0
+	s.finished = false
+	s.result = make(chan *SceneResult, 1)
+	s.statistics = &pbGlobal.CombatStatistics{}
 	s.opts = DefaultSceneOptions()
-	s.rand = random.NewFakeRandom(int(time.Now().Unix()))
-	s.tasker = task.NewTasker()
 
 	for n := define.Scene_Camp_Begin; n < define.Scene_Camp_End; n++ {
 		s.camps[n] = NewSceneCamp(s, n)
@@ -62,9 +91,21 @@ func (s *Scene) Init(sceneId int64, opts ...SceneOption) *Scene {
 		o(s.opts)
 	}
 
-	// add attack unit list
-	for _, unitInfo := range s.opts.AttackEntityList {
-		err := s.AddEntityByPB(s.camps[define.Scene_Camp_Attack], unitInfo)
+	// 随机种子记录在录像中, 回放时使用相同种子
+	if s.opts.Seed == 0 {
+		s.opts.Seed = time.Now().UnixNano()
+	}
+	s.rand = random.NewFakeRandom(int(s.opts.Seed))
+	s.initRecord()
+
+	// add attack unit list, 有布阵位置时按位置站位, 否则按出场顺序
+	for idx, unitInfo := range s.opts.AttackEntityList {
+		layoutIdx := idx
+		if unitInfo.GetPos() > 0 {
+			layoutIdx = int(unitInfo.GetPos()) - 1
+		}
+
+		err := s.AddEntityByPB(s.camps[define.Scene_Camp_Attack], unitInfo, s.getLayoutPosition(layoutIdx)...)
 		utils.ErrPrint(err, "AddEntityByPB failed when Scene.Init", sceneId, s.opts.SceneEntry.Id, unitInfo.HeroTypeId)
 	}
 
@@ -74,31 +115,15 @@ func (s *Scene) Init(sceneId int64, opts ...SceneOption) *Scene {
 		_ = utils.ErrCheck(err, "AddEntityByPB failed when Scene.Init", sceneId, s.opts.SceneEntry.Id, unitInfo.HeroTypeId)
 	}
 
-	// 目前只有一波
-	battleWaveEntry := s.opts.BattleWaveEntries[0]
-	if battleWaveEntry != nil {
-		for idx := range battleWaveEntry.MonsterID {
-			// hero id invalid
-			if battleWaveEntry.MonsterID[idx] == -1 {
-				continue
-			}
-
-			monsterEntry, ok := auto.GetMonsterEntry(battleWaveEntry.MonsterID[idx])
-			if !ok {
-				continue
-			}
-
-			err := s.AddEntityByOptions(
-				s.camps[define.Scene_Camp_Defence],
-				WithEntityMonsterId(battleWaveEntry.MonsterID[idx]),
-				WithEntityMonsterEntry(monsterEntry),
-				WithEntityPosition(battleWaveEntry.PositionX[idx], battleWaveEntry.PositionZ[idx], battleWaveEntry.Rotation[idx]),
-				WithEntityInitAtbValue(battleWaveEntry.InitalCom[idx]),
-			)
-
-			_ = utils.ErrCheck(err, "AddEntityByOptions failed when Scene.Init", battleWaveEntry.MonsterID[idx])
-		}
+	// 第一波怪物
+	if len(s.opts.BattleWaveEntries) > 0 {
+		s.spawnWave(0)
 	}
+}
+
+func (s *Scene) Init(sceneId int64, opts ...SceneOption) *Scene {
+	s.init(sceneId, opts...)
+	s.tasker = task.NewTasker()
 
 	// tasker init
 	s.tasker.Init(
@@ -113,6 +138,8 @@ func (s *Scene) Init(sceneId int64, opts ...SceneOption) *Scene {
 		task.WithUpdateFn(func() {
 			s.onTaskUpdate()
 		}),
+
+		task.WithUpdateInterval(sceneUpdateInterval),
 	)
 
 	return s
@@ -133,8 +160,272 @@ func (s *Scene) onTaskStop() {
 }
 
 func (s *Scene) onTaskUpdate() {
+	// 服务器战斗不需要表现, 一次更新内模拟到战斗结束
+	s.simulate()
+
+	// tasker更新中不能直接停止
+	s.wg.Wrap(s.tasker.Stop)
+}
+
+// 不创建tasker, 在当前协程直接模拟一场战斗
+func Simulate(sceneId int64, opts ...SceneOption) *SceneResult {
+	s := NewScene()
+	s.init(sceneId, opts...)
+	s.onTaskStart()
+	s.simulate()
+	return <-s.result
+}
+
+// 模拟战斗直到结束
+func (s *Scene) simulate() {
+	for !s.finished {
+		s.updateRound()
+	}
+}
+
+// 更新一个回合
+func (s *Scene) updateRound() {
+	s.curRound++
 	s.updateEntities()
-	s.updateCamps()
+	s.updateComFinish()
+	s.updateSpells()
+	s.checkResult()
+}
+
+// 检查战斗是否结束
+func (s *Scene) checkResult() {
+	switch {
+	// 防守方全部死亡, 没有下一波时战斗胜利
+	case !s.camps[define.Scene_Camp_Defence].IsValid():
+		if !s.nextWave() {
+			s.finish(true)
+		}
+
+	// 进攻方全部死亡
+	case !s.camps[define.Scene_Camp_Attack].IsValid():
+		s.finish(false)
+
+	// 超时
+	case s.curRound >= s.maxRound:
+		s.finish(false)
+	}
+}
+
+func (s *Scene) finish(win bool) {
+	s.finished = true
+	s.statistics.Rounds = s.curRound
+	s.statistics.PassTime = s.curRound * define.Scene_RoundTime / 1000
+	s.endWave(win)
+	s.record.Win = win
+	s.record.Statistics = s.statistics
+	s.result <- &SceneResult{
+		Win:        win,
+		Statistics: s.statistics,
+		Record:     s.record,
+		Units:      s.genUnitResults(),
+	}
+
+	log.Info().
+		Int64("scene_id", s.GetId()).
+		Bool("win", win).
+		Interface("statistics", s.statistics).
+		Msg("scene combat finished")
+}
+
+func (s *Scene) genUnitResults() []*SceneUnitResult {
+	units := make([]*SceneUnitResult, 0, s.entityMap.Size())
+	it := s.entityMap.Iterator()
+	for it.Next() {
+		e := it.Value().(*SceneEntity)
+		unit := &SceneUnitResult{
+			Id:        e.id,
+			Camp:      e.GetCamp().camp,
+			TypeId:    e.HeroId,
+			IsMonster: e.MonsterEntry != nil,
+			DmgDone:   e.totalDmgDone,
+			DmgRecv:   e.totalDmgRecv,
+			Dead:      e.HasState(define.HeroState_Dead),
+		}
+
+		if unit.IsMonster {
+			unit.TypeId = e.MonsterId
+		}
+
+		units = append(units, unit)
+	}
+
+	return units
+}
+
+// 战斗单位死亡
+func (s *Scene) OnUnitDead(u *SceneEntity) {
+	s.addEvent(&pbGlobal.CombatEvent{
+		Type:     pbGlobal.CombatEventType_CombatEvent_Dead,
+		TargetId: u.id,
+	})
+
+	wave := s.getWaveStatistics()
+	if u.GetCamp().camp == define.Scene_Camp_Attack {
+		s.statistics.AttackDeadNum++
+		if wave != nil {
+			wave.AttackDeadNum++
+		}
+		return
+	}
+
+	s.statistics.DefenceDeadNum++
+	if wave != nil {
+		wave.DefenceDeadNum++
+	}
+
+	if u.MonsterEntry != nil {
+		s.statistics.KillOrder = append(s.statistics.KillOrder, u.MonsterId)
+	} else {
+		s.statistics.KillOrder = append(s.statistics.KillOrder, u.HeroId)
+	}
+}
+
+// 技能施放
+func (s *Scene) OnSkillCast(caster *SceneEntity, target *SceneEntity, entry *auto.SkillBaseEntry) {
+	s.addEvent(&pbGlobal.CombatEvent{
+		Type:     pbGlobal.CombatEventType_CombatEvent_SkillCast,
+		CasterId: caster.id,
+		TargetId: target.id,
+		SkillId:  entry.Id,
+	})
+
+	if caster.GetCamp().camp != define.Scene_Camp_Attack {
+		return
+	}
+
+	if caster.UltimateSkill != nil && caster.UltimateSkill.Id == entry.Id {
+		s.statistics.UltimateSkillNum++
+	}
+}
+
+// 技能被打断
+func (s *Scene) OnSkillInterrupted(caster *SceneEntity, target *SceneEntity) {
+	s.addEvent(&pbGlobal.CombatEvent{
+		Type:     pbGlobal.CombatEventType_CombatEvent_Interrupt,
+		CasterId: caster.id,
+		TargetId: target.id,
+	})
+
+	if caster.GetCamp().camp == define.Scene_Camp_Attack && target.GetCamp().camp != caster.GetCamp().camp {
+		s.statistics.InterruptNum++
+	}
+}
+
+// 行动开始
+func (s *Scene) OnActionStart(owner *SceneEntity, tp define.ECombatActionType, targetId int64) {
+	s.addEvent(&pbGlobal.CombatEvent{
+		Type:     pbGlobal.CombatEventType_CombatEvent_Action,
+		CasterId: owner.id,
+		TargetId: targetId,
+		Value:    int64(tp),
+	})
+}
+
+// 技能施放结束
+func (s *Scene) OnSkillCastEnd(caster *SceneEntity, entry *auto.SkillBaseEntry) {
+	s.addEvent(&pbGlobal.CombatEvent{
+		Type:     pbGlobal.CombatEventType_CombatEvent_SkillEnd,
+		CasterId: caster.id,
+		TargetId: -1,
+		SkillId:  entry.Id,
+		Value:    int64(caster.GetCamp().energy),
+	})
+}
+
+// 技能作用目标
+func (s *Scene) OnSkillHit(caster *SceneEntity, target *SceneEntity, dmgInfo *CalcDamageInfo) {
+	s.addEvent(&pbGlobal.CombatEvent{
+		Type:     pbGlobal.CombatEventType_CombatEvent_SkillHit,
+		CasterId: caster.id,
+		TargetId: target.id,
+		SkillId:  dmgInfo.SpellId,
+		Crit:     dmgInfo.Crit,
+		Miss:     !dmgInfo.Hit,
+	})
+}
+
+// 造成伤害或治疗
+func (s *Scene) OnDamage(caster *SceneEntity, target *SceneEntity, dmgInfo *CalcDamageInfo) {
+	e := &pbGlobal.CombatEvent{
+		Type:     pbGlobal.CombatEventType_CombatEvent_Damage,
+		CasterId: -1,
+		TargetId: target.id,
+		SkillId:  dmgInfo.SpellId,
+		Value:    dmgInfo.Damage,
+		Crit:     dmgInfo.Crit,
+	}
+
+	if dmgInfo.Type == define.DmgInfo_Heal {
+		e.Type = pbGlobal.CombatEventType_CombatEvent_Heal
+	}
+
+	if caster != nil {
+		e.CasterId = caster.id
+	}
+
+	s.addEvent(e)
+}
+
+// buff添加和移除
+func (s *Scene) OnBuffChanged(buff *Buff, add bool) {
+	e := &pbGlobal.CombatEvent{
+		Type:     pbGlobal.CombatEventType_CombatEvent_BuffAdd,
+		CasterId: -1,
+		TargetId: buff.opts.Owner.id,
+		BuffId:   int32(buff.opts.Entry.ID),
+	}
+
+	if !add {
+		e.Type = pbGlobal.CombatEventType_CombatEvent_BuffRemove
+	}
+
+	if buff.opts.Caster != nil {
+		e.CasterId = buff.opts.Caster.id
+	}
+
+	s.addEvent(e)
+}
+
+// buff生效
+func (s *Scene) OnBuffTick(buff *Buff, effIndex int32) {
+	e := &pbGlobal.CombatEvent{
+		Type:     pbGlobal.CombatEventType_CombatEvent_BuffTick,
+		CasterId: -1,
+		TargetId: buff.opts.Owner.id,
+		BuffId:   int32(buff.opts.Entry.ID),
+		Value:    int64(effIndex),
+	}
+
+	if buff.opts.Caster != nil {
+		e.CasterId = buff.opts.Caster.id
+	}
+
+	s.addEvent(e)
+}
+
+// 进入和脱离状态, 死亡状态由死亡事件记录
+func (s *Scene) OnStateChanged(owner *SceneEntity, state define.EHeroState, add bool) {
+	if state == define.HeroState_Dead {
+		return
+	}
+
+	e := &pbGlobal.CombatEvent{
+		Type:     pbGlobal.CombatEventType_CombatEvent_StateAdd,
+		CasterId: -1,
+		TargetId: owner.id,
+		Value:    int64(state),
+	}
+
+	if !add {
+		e.Type = pbGlobal.CombatEventType_CombatEvent_StateRemove
+	}
+
+	s.addEvent(e)
 }
 
 func (s *Scene) TaskRun(ctx context.Context) error {
@@ -179,6 +470,10 @@ func (s *Scene) findEnemyEntityByHead(camp int32) (*SceneEntity, bool) {
 	it := s.entityMap.Iterator()
 	for it.Next() {
 		e := it.Value().(*SceneEntity)
+		if e.HasState(define.HeroState_Dead) {
+			continue
+		}
+
 		if e.GetCamp().camp != camp {
 			return e, true
 		}
@@ -187,8 +482,14 @@ func (s *Scene) findEnemyEntityByHead(camp int32) (*SceneEntity, bool) {
 	return nil, false
 }
 
-func (s *Scene) GetResult() bool {
-	return <-s.result
+// 等待战斗结果
+func (s *Scene) GetResult(ctx context.Context) (*SceneResult, error) {
+	select {
+	case r := <-s.result:
+		return r, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 
 func (s *Scene) GetSceneCamp(camp int32) (*SceneCamp, bool) {
@@ -207,76 +508,6 @@ func (s *Scene) GetRand() *random.FakeRandom[int] {
 	return s.rand
 }
 
-func (s *Scene) updateCamps() {
-
-	// 是否攻击方先手
-	bAttackFirst := true
-
-	for ; s.curRound+1 <= s.maxRound; s.curRound++ {
-		bEnterNextRound := false
-
-		nActionRount := 0
-		for !bEnterNextRound {
-			nActionRount++
-
-			if bAttackFirst {
-				s.camps[int(define.Scene_Camp_Attack)].Update()
-
-				// 本轮攻击没有结束
-				if !s.camps[int(define.Scene_Camp_Attack)].IsLoopEnd() {
-					s.camps[int(define.Scene_Camp_Attack)].Attack(s.camps[int(define.Scene_Camp_Defence)])
-				}
-
-				s.camps[int(define.Scene_Camp_Defence)].Update()
-
-				// 本轮攻击没有结束
-				if !s.camps[int(define.Scene_Camp_Defence)].IsLoopEnd() {
-					s.camps[int(define.Scene_Camp_Defence)].Attack(s.camps[int(define.Scene_Camp_Attack)])
-				}
-			} else {
-				s.camps[int(define.Scene_Camp_Defence)].Update()
-
-				// 本轮攻击没有结束
-				if !s.camps[int(define.Scene_Camp_Defence)].IsLoopEnd() {
-					s.camps[int(define.Scene_Camp_Defence)].Attack(s.camps[int(define.Scene_Camp_Defence)])
-				}
-
-				s.camps[int(define.Scene_Camp_Attack)].Update()
-
-				// 本轮攻击没有结束
-				if !s.camps[int(define.Scene_Camp_Attack)].IsLoopEnd() {
-					s.camps[int(define.Scene_Camp_Attack)].Attack(s.camps[int(define.Scene_Camp_Defence)])
-				}
-			}
-
-			if s.camps[int(define.Scene_Camp_Attack)].IsLoopEnd() &&
-				s.camps[int(define.Scene_Camp_Defence)].IsLoopEnd() {
-				for i := nActionRount; i < Camp_Max_Unit; i++ {
-					s.camps[int(define.Scene_Camp_Defence)].Update()
-					s.camps[int(define.Scene_Camp_Attack)].Update()
-				}
-
-				nActionRount = 0
-				bEnterNextRound = true
-			}
-
-			// 释放符文技能
-			// compile comment
-			// s.UpdateRuneSpell(bAttackFirst);
-		}
-
-		// 重置攻击顺序
-		s.camps[int(define.Scene_Camp_Attack)].ResetLoopIndex()
-		s.camps[int(define.Scene_Camp_Defence)].ResetLoopIndex()
-
-		// 战斗结束
-		if !s.camps[int(define.Scene_Camp_Attack)].IsValid() ||
-			!s.camps[int(define.Scene_Camp_Defence)].IsValid() {
-			break
-		}
-	}
-}
-
 // 更新场景内技能
 func (s *Scene) updateSpells() {
 	var next *list.Element
@@ -293,6 +524,39 @@ func (s *Scene) updateSpells() {
 	}
 }
 
+// 行动队列: com条满的单位按进入队列的顺序行动, 队首单位行动结束前其他单位等待
+func (s *Scene) updateComFinish() {
+	for e := s.comFinishList.Front(); e != nil; e = s.comFinishList.Front() {
+		entity := e.Value.(*SceneEntity)
+		if entity.HasState(define.HeroState_Dead) || !entity.AtbCtrl.IsReady() {
+			s.comFinishList.Remove(e)
+			continue
+		}
+
+		entity.ActionCtrl.Update()
+
+		// 行动还未结束
+		if entity.AtbCtrl.IsReady() {
+			return
+		}
+	}
+}
+
+// com条满, 进入行动队列
+func (s *Scene) OnComFinish(e *SceneEntity) {
+	s.comFinishList.PushBack(e)
+}
+
+// com条被重置, 离开行动队列
+func (s *Scene) OnComReset(e *SceneEntity) {
+	for it := s.comFinishList.Front(); it != nil; it = it.Next() {
+		if it.Value.(*SceneEntity) == e {
+			s.comFinishList.Remove(it)
+			return
+		}
+	}
+}
+
 func (s *Scene) updateEntities() {
 	it := s.entityMap.Iterator()
 	for it.Next() {
@@ -300,7 +564,17 @@ func (s *Scene) updateEntities() {
 	}
 }
 
-func (s *Scene) AddEntityByPB(camp *SceneCamp, unitInfo *pbGlobal.EntityInfo) error {
+// 进攻方按布阵点站位
+func (s *Scene) getLayoutPosition(idx int) []EntityOption {
+	layout := s.opts.BattleLayoutEntry
+	if layout == nil || idx >= len(layout.PositionX) || idx >= len(layout.PositionZ) || idx >= len(layout.Rotation) {
+		return nil
+	}
+
+	return []EntityOption{WithEntityPosition(layout.PositionX[idx], layout.PositionZ[idx], layout.Rotation[idx])}
+}
+
+func (s *Scene) AddEntityByPB(camp *SceneCamp, unitInfo *pbGlobal.EntityInfo, opts ...EntityOption) error {
 	entry, ok := auto.GetHeroEntry(unitInfo.HeroTypeId)
 	if !ok {
 		return fmt.Errorf("GetUnitEntry failed: type_id<%d>", unitInfo.HeroTypeId)
@@ -311,35 +585,35 @@ func (s *Scene) AddEntityByPB(camp *SceneCamp, unitInfo *pbGlobal.EntityInfo) er
 		return fmt.Errorf("err:<%w>, model_id:<%d>", ErrSceneModelNotFound, entry.ModelID)
 	}
 
-	id := atomic.AddInt64(&s.entityIdGen, 1)
-	e, err := NewSceneEntity(
-		s,
-		id,
+	opts = append(opts,
 		WithEntityHeroId(unitInfo.HeroTypeId),
 		WithEntityAttList(unitInfo.AttValue),
 		WithEntityHeroEntry(entry),
 		WithEntityModelEntry(modelEntry),
 	)
 
-	if err != nil {
-		return err
-	}
+	_, err := s.AddEntityByOptions(camp, opts...)
 
-	s.entityMap.Put(id, e)
-
-	return nil
+	return err
 }
 
-func (s *Scene) AddEntityByOptions(camp *SceneCamp, opts ...EntityOption) error {
+func (s *Scene) AddEntityByOptions(camp *SceneCamp, opts ...EntityOption) (*SceneEntity, error) {
 	id := atomic.AddInt64(&s.entityIdGen, 1)
-	opts = append(opts, WithEntitySceneCamp(camp))
+	opts = append(opts, WithEntityScene(s), WithEntitySceneCamp(camp))
 	e, err := NewSceneEntity(s, id, opts...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	s.entityMap.Put(id, e)
-	return nil
+	camp.OnUnitEnter(e)
+
+	if camp.camp == define.Scene_Camp_Attack {
+		s.statistics.AttackUnitNum++
+	} else {
+		s.statistics.DefenceUnitNum++
+	}
+	return e, nil
 }
 
 func (s *Scene) ClearEntities() {
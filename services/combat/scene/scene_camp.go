@@ -64,10 +64,15 @@ func (c *SceneCamp) IsValid() bool {
 	return c.aliveUnitNum != 0
 }
 
+// 战斗单位加入
+func (c *SceneCamp) OnUnitEnter(u *SceneEntity) {
+	c.aliveUnitNum++
+}
+
 // 战斗单位死亡
 func (c *SceneCamp) OnUnitDead(u *SceneEntity) {
 	c.aliveUnitNum--
-	// c.scene.OnUnitDead(u)
+	c.scene.OnUnitDead(u)
 }
 
 // 战斗单位消亡
@@ -7,15 +7,15 @@ import (
 	"github.com/east-eden/server/utils"
 	log "github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
-	"github.com/willf/bitset"
 )
 
 const (
-	Unit_Energy_OnBeDamaged = 2 // 受伤害增加能量
-	Unit_Init_AuraNum       = 3 // 初始化buff数量
+	Unit_Energy_OnBeDamaged = 2  // 受伤害增加能量
+	Unit_Init_AuraNum       = 3  // 初始化buff数量
+	Entity_ImmunityBits     = 32 // 免疫掩码位数
 )
 
-var ()
+var dmgModLowerLimit = decimal.NewFromFloat(-0.7) // 伤害百分比加成下限
 
 type SceneEntity struct {
 	*EntityOptions
@@ -29,6 +29,9 @@ type SceneEntity struct {
 	CombatCtrl *CombatCtrl
 	ActionCtrl *ActionCtrl
 	MoveCtrl   *MoveCtrl
+	AtbCtrl    *AtbCtrl
+
+	dmgModAtt [define.DmgMod_End]decimal.Decimal // 伤害加成
 
 	// 伤害统计
 	totalDmgRecv int64 // 总共受到的伤害
@@ -40,31 +43,23 @@ type SceneEntity struct {
 func NewSceneEntity(scene *Scene, id int64, opts ...EntityOption) (*SceneEntity, error) {
 	e := &SceneEntity{
 		EntityOptions: DefaultEntityOptions(),
+		id:            id,
 	}
 
 	for _, o := range opts {
 		o(e.EntityOptions)
 	}
 
-	var attId int32 = -1
-	if e.HeroEntry != nil {
-		attId = e.HeroEntry.AttId
-	}
-	if e.MonsterEntry != nil {
-		attId = e.MonsterEntry.AttId
-	}
-
-	e.AttManager.SetBaseAttId(attId)
-	e.AttManager.CalcAtt()
+	e.InitAttribute()
 
 	// controller
 	e.ActionCtrl = NewActionCtrl(e)
 	e.MoveCtrl = NewMoveCtrl(e)
-	e.CombatCtrl = NewCombatCtrl(
-		scene,
-		e,
-		WithCombatCtrlAtbValue(e.InitAtbValue), // init atb value
-	)
+	e.AtbCtrl = NewAtbCtrl(e, WithAtbInitValue(e.InitAtbValue))
+	e.CombatCtrl = NewCombatCtrl(scene, e)
+
+	e.initState()
+	e.InitDmgModAtt()
 
 	return e, nil
 }
@@ -93,6 +88,24 @@ func (s *SceneEntity) GetPosition() *Position {
 	return s.Pos
 }
 
+// 模型半径
+func (s *SceneEntity) GetRadius() decimal.Decimal {
+	if s.ModelEntry == nil {
+		return decimal.Zero
+	}
+
+	return s.ModelEntry.Modelscope
+}
+
+// 对目标施放技能的距离, 包含双方模型半径
+func (s *SceneEntity) GetSkillRange(entry *auto.SkillBaseEntry, target *SceneEntity) decimal.Decimal {
+	return entry.Range.Add(s.GetRadius()).Add(target.GetRadius())
+}
+
+func (s *SceneEntity) IsInSkillRange(entry *auto.SkillBaseEntry, target *SceneEntity) bool {
+	return IsInDistance(s.GetPosition(), target.GetPosition(), s.GetSkillRange(entry, target))
+}
+
 func (s *SceneEntity) OnSceneStart() {
 	s.initSkill()
 }
@@ -103,8 +116,14 @@ func (s *SceneEntity) Update() {
 	}
 
 	s.CombatCtrl.Update()
+	s.AtbCtrl.Update()
 	s.MoveCtrl.Update()
-	s.ActionCtrl.Update()
+}
+
+// 行动结束, 失败时com条按配置重置
+func (s *SceneEntity) OnActionFinish(success bool) {
+	s.CombatCtrl.updateSkillCd()
+	s.AtbCtrl.OnActionFinish(success)
 }
 
 func (s *SceneEntity) HasState(e define.EHeroState) bool {
@@ -121,10 +140,61 @@ func (s *SceneEntity) GetState64() uint64 {
 }
 
 func (s *SceneEntity) HasImmunityAny(tp define.EImmunityType, flag uint32) bool {
-	compare := bitset.From([]uint64{uint64(flag)})
+	compare := utils.FromCountableBitset([]uint64{uint64(flag)}, []int16{})
 	return s.Immunity[tp].Intersection(compare).Any()
 }
 
+//-----------------------------------------------------------------------------
+// 免疫, flag为免疫掩码, 多个来源的同一免疫位按次数叠加
+//-----------------------------------------------------------------------------
+func (s *SceneEntity) AddImmunity(tp define.EImmunityType, flag uint32) {
+	for n := uint(0); n < Entity_ImmunityBits; n++ {
+		if flag&(1<<n) == 0 {
+			continue
+		}
+
+		new := !s.Immunity[tp].Test(n)
+		s.Immunity[tp].Set(n, 1)
+		if new {
+			s.AddToImmunity(tp, n)
+		}
+	}
+}
+
+func (s *SceneEntity) DecImmunity(tp define.EImmunityType, flag uint32) {
+	for n := uint(0); n < Entity_ImmunityBits; n++ {
+		if flag&(1<<n) == 0 {
+			continue
+		}
+
+		s.Immunity[tp].Clear(n, 1)
+	}
+}
+
+//-----------------------------------------------------------------------------
+// 伤害免疫, 免疫时伤害清零
+//-----------------------------------------------------------------------------
+func (s *SceneEntity) checkDamageImmunity(dmgInfo *CalcDamageInfo) bool {
+	immune := s.HasState(define.HeroState_UnBeat) ||
+		(s.HasState(define.HeroState_ImmunityGroupDmg) && dmgInfo.ProcEx&(1<<define.AuraEventEx_GroupDmg) != 0) ||
+		(dmgInfo.SchoolType > define.SchoolType_Null && s.HasImmunityAny(define.ImmunityType_Damage, 1<<dmgInfo.SchoolType))
+
+	if immune {
+		dmgInfo.Damage = 0
+		dmgInfo.ProcEx |= (1 << define.AuraEventEx_Immnne)
+	}
+
+	return immune
+}
+
+func (s *SceneEntity) GetDmgModAtt(tp define.EDmgModType) decimal.Decimal {
+	return s.dmgModAtt[tp]
+}
+
+func (s *SceneEntity) ModDmgModAtt(tp define.EDmgModType, value decimal.Decimal) {
+	s.dmgModAtt[tp] = s.dmgModAtt[tp].Add(value)
+}
+
 //-----------------------------------------------------------------------------
 // 进攻
 //-----------------------------------------------------------------------------
@@ -245,10 +315,11 @@ func (s *SceneEntity) DoneDamage(caster *SceneEntity, dmgInfo *CalcDamageInfo) {
 	switch dmgInfo.Type {
 	// 伤害
 	case define.DmgInfo_Damage:
-		if s.HasState(define.HeroState_UnBeat) || s.HasState(define.HeroState_ImmunityGroupDmg) && (dmgInfo.ProcEx&1<<define.AuraEventEx_GroupDmg != 0) {
-			dmgInfo.Damage = 0
-			dmgInfo.ProcEx |= (1 << define.AuraEventEx_Immnne)
-		} else if s.HasState(define.HeroState_UnDead) {
+		if s.checkDamageImmunity(dmgInfo) {
+			return
+		}
+
+		if s.HasState(define.HeroState_UnDead) {
 			if s.AttManager.GetFinalAttValue(define.Att_CurHP).IntPart() <= dmgInfo.Damage {
 				dmgInfo.Damage = s.AttManager.GetFinalAttValue(define.Att_CurHP).IntPart() - 1
 				s.AttManager.SetFinalAttValue(define.Att_CurHP, decimal.NewFromInt32(1))
@@ -303,6 +374,11 @@ func (s *SceneEntity) DoneDamage(caster *SceneEntity, dmgInfo *CalcDamageInfo) {
 // 进入状态
 //-----------------------------------------------------------------------------
 func (s *SceneEntity) AddToState(state define.EHeroState) {
+	// 进入控制状态时停止移动, 轮到行动时按行动失败处理
+	if actionFailMask&(1<<state) != 0 {
+		s.MoveCtrl.Stop()
+	}
+
 	s.CombatCtrl.TriggerByServentState(state, true)
 }
 
@@ -316,90 +392,74 @@ func (s *SceneEntity) EscFromState(state define.EHeroState) {
 //-----------------------------------------------------------------------------
 // 免疫
 //-----------------------------------------------------------------------------
-func (s *SceneEntity) AddToImmunity(immunityType define.EImmunityType, immunity int) {
+func (s *SceneEntity) AddToImmunity(immunityType define.EImmunityType, immunity uint) {
 	switch immunityType {
 	case define.ImmunityType_Mechanic:
 		// 删除指定类型的Aura
-		//s.CombatCtrl.RemoveAura(immunity)
+		s.CombatCtrl.removeAuraByMechanic(1 << immunity)
 	}
 }
 
 //-----------------------------------------------------------------------------
-// 初始化伤害加成
+// 初始化伤害加成, 英雄最终属性由game计算, 已包含装备和晶石加成
 //-----------------------------------------------------------------------------
 func (s *SceneEntity) InitDmgModAtt() {
-	// memcpy(m_nDmgModAtt, static_cast<EntityGroup*>(m_pFather)->GetDmgModAtt(), sizeof(m_nDmgModAtt));
-
-	// 	switch( m_pEntry->eClass )
-	// 	{
-	// 	case EHC_Tank:
-	// 		{
-	// 			m_nDmgModAtt[EDM_DamageDonePctPhysics] -= 2000;
-	// 			m_nDmgModAtt[EDM_DamageDonePctMagic] -= 2000;
-	// 			m_nDmgModAtt[EDM_DamageTakenPctPhysics] -= 3000;
-	// 			m_nDmgModAtt[EDM_DamageTakenPctMagic] -= 3000;
-	// 		}
-	// 		break;
-
-	// 	case EHC_Berserker:
-	// 		{
-	// 			m_nDmgModAtt[EDM_DamageDonePctPhysics] += 1000;
-	// 			m_nDmgModAtt[EDM_DamageDonePctMagic] += 1000;
-	// 		}
-	// 		break;
-
-	// 	case EHC_Assassin:
-	// 		{
-	// 			m_nDmgModAtt[EDM_DamageDonePctPhysics] += 2000;
-	// 			m_nDmgModAtt[EDM_DamageDonePctMagic] += 2000;
-	// 			m_nDmgModAtt[EDM_DamageTakenPctPhysics] += 2000;
-	// 			m_nDmgModAtt[EDM_DamageTakenPctMagic] += 2000;
-	// 		}
-	// 		break;
-
-	// 	case EHC_Elementer:
-	// 		{
-	// 			m_nDmgModAtt[EDM_DamageDonePctPhysics] += 1000;
-	// 			m_nDmgModAtt[EDM_DamageDonePctMagic] += 1000;
-	// 		}
-	// 		break;
-
-	// 	case EHC_Healer:
-	// 		{
-	// 			m_nDmgModAtt[EDM_DamageDonePctHeal] += 1000;
-	// 			m_nDmgModAtt[EDM_DamageTakenPctPhysics] += 1000;
-	// 			m_nDmgModAtt[EDM_DamageTakenPctMagic] += 1000;
-	// 		}
-	// 		break;
-
-	// 	default:
-	// 		break;
-	// 	}
+	for k := range s.dmgModAtt {
+		s.dmgModAtt[k] = decimal.Zero
+	}
+
+	s.dmgModAtt[define.DmgMod_DamageDone] = s.AttManager.GetFinalAttValue(define.Att_EnemyWoundInc)
+	s.dmgModAtt[define.DmgMod_DamageTaken] = s.AttManager.GetFinalAttValue(define.Att_SelfDmgDec).Neg()
 }
 
 //-----------------------------------------------------------------------------
 // 属性初始化
 //-----------------------------------------------------------------------------
-func (s *SceneEntity) InitAttribute(heroInfo *define.HeroInfo) {
-	// todo 读取静态表中的状态掩码
-	// s.State = bitset.From([]uint64{uint64(s.Entry.dwStateMask)})
+func (s *SceneEntity) InitAttribute() {
+	var attId int32 = -1
+	if s.HeroEntry != nil {
+		attId = s.HeroEntry.AttId
+	}
+	if s.MonsterEntry != nil {
+		attId = s.MonsterEntry.AttId
+	}
 
-	// todo 免疫
-	for n := 0; n < define.ImmunityType_End; n++ {
-		// s.Immunity[n] = bitset.From([]uint64{uint64(s.Entry.dwImmunity[n])})
+	s.AttManager.SetBaseAttId(attId)
+	s.AttManager.CalcAtt()
+
+	// 英雄属性由game计算好传入, 覆盖静态表属性
+	for tp := range s.AttList {
+		s.AttManager.SetFinalAttValue(tp, decimal.NewFromFloat32(s.AttList[tp]).Round(2))
 	}
+}
 
-	// todo AttEntry
-	// auto.GetAttEntry(s.Entry.BaseAttId)
-	heroEntry, ok := auto.GetHeroEntry(heroInfo.TypeId)
-	if !ok {
-		log.Warn().Int32("type_id", heroInfo.TypeId).Msg("cannot find hero entry")
-		return
+//-----------------------------------------------------------------------------
+// 读取静态表中的初始状态和免疫
+//-----------------------------------------------------------------------------
+func (s *SceneEntity) initState() {
+	var stateMask int32
+	var immunity []int32
+	switch {
+	case s.HeroEntry != nil:
+		stateMask, immunity = s.HeroEntry.StateMask, s.HeroEntry.Immunity
+	case s.MonsterEntry != nil:
+		stateMask, immunity = s.MonsterEntry.StateMask, s.MonsterEntry.Immunity
 	}
 
-	s.AttManager.SetBaseAttId(int32(heroEntry.AttId))
-	s.AttManager.CalcAtt()
-	s.AttManager.SetFinalAttValue(define.Att_CurHP, s.AttManager.GetFinalAttValue(define.Att_MaxHPBase))
+	// 死亡状态不能由静态表设置
+	for state := define.HeroState_Solid; state < define.HeroState_End; state++ {
+		if stateMask&(1<<state) != 0 {
+			s.AddState(state, 1)
+		}
+	}
+
+	for tp, flag := range immunity {
+		if tp >= define.ImmunityType_End {
+			break
+		}
+
+		s.AddImmunity(define.EImmunityType(tp), uint32(flag))
+	}
 }
 
 // 技能初始化
@@ -442,25 +502,25 @@ func (s *SceneEntity) SetNormalSpell(spellId uint32) {
 //-------------------------------------------------------------------------------
 // 状态
 //-------------------------------------------------------------------------------
-func (s *SceneEntity) AddState(state define.EHeroState, count int16) {
+func (s *SceneEntity) AddState(state define.EHeroState, count int16) bool {
+	// 状态免疫, 死亡状态不可免疫
+	if state != define.HeroState_Dead && s.HasImmunityAny(define.ImmunityType_Mechanic, 1<<state) {
+		return false
+	}
+
 	new := !s.HasState(state)
 
 	s.State.Set(uint(state), count)
 
-	// todo 进入新状态处理
+	// 进入新状态处理
 	if new {
-		// Scene* pScene = GetScene();
-		// if (VALID(pScene) && !pScene->IsOnlyRecord() )
-		// {
-		// 	CreateSceneProtoMsg(msg, MS_SetState,);
-		// 	*msg << (UINT32)GetLocation();
-		// 	*msg << (UINT32)eState;
-		// 	pScene->AddMsgList(msg);
-		// }
+		s.GetScene().OnStateChanged(s, state, true)
 
 		// 追加状态处理
 		s.AddToState(state)
 	}
+
+	return true
 }
 
 func (s *SceneEntity) DecState(state define.EHeroState, count int16) {
@@ -470,17 +530,9 @@ func (s *SceneEntity) DecState(state define.EHeroState, count int16) {
 
 	s.State.Clear(uint(state), count)
 
-	// todo 退出状态处理
+	// 计数归零时退出状态
 	if !s.HasState(state) {
-		// Scene* pScene = GetScene();
-		// if (VALID(pScene) && !pScene->IsOnlyRecord() )
-		// {
-		// 	CreateSceneProtoMsg(msg, MS_UnsetState, );
-		// 	*msg << (UINT32)GetLocation();
-		// 	*msg << (UINT32)eState;
-		// 	pScene->AddMsgList(msg);
-		// }
-
+		s.GetScene().OnStateChanged(s, state, false)
 		s.EscFromState(state)
 	}
 }
@@ -505,10 +557,3 @@ func (s *SceneEntity) Save2DB(pRecord any) {
 	// memcpy(pRecord->nAttModPct, m_AttRecord.ExportAttModPct(), sizeof(pRecord->nAttModPct));
 	// memcpy(pRecord->dwPassiveSpell, m_AttRecord.ExportPassiveSpell(), sizeof(pRecord->dwPassiveSpell));
 }
-
-//-----------------------------------------------------------------------------
-// todo 初始化伤害加成
-//-----------------------------------------------------------------------------
-func (s *SceneEntity) InitHeroDmgModAtt(info *define.HeroInfo, pos int32) {
-	// ZeroMemory(m_nHeroDmgModAtt,sizeof(m_nHeroDmgModAtt));
-}
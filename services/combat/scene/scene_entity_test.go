@@ -0,0 +1,259 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/east-eden/server/define"
+	"github.com/shopspring/decimal"
+	"github.com/willf/bitset"
+)
+
+func newTestStateAura(owner, caster *SceneEntity, state define.EHeroState) *Buff {
+	aura := &Buff{opts: DefaultBuffOptions()}
+	aura.opts.Owner = owner
+	aura.opts.Caster = caster
+	aura.opts.Entry = &define.AuraEntry{}
+	aura.opts.Entry.MiscValue1[0] = int32(state)
+	return aura
+}
+
+func TestEntityStateCount(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	_, attacker, _ := newAtbTestScene(t)
+
+	// 多个来源叠加的状态在全部移除后才退出
+	attacker.AddState(define.HeroState_Poison, 1)
+	attacker.AddState(define.HeroState_Poison, 1)
+	attacker.DecState(define.HeroState_Poison, 1)
+	if !attacker.HasState(define.HeroState_Poison) {
+		t.Fatal("poison state should remain after one dec")
+	}
+
+	attacker.DecState(define.HeroState_Poison, 1)
+	if attacker.HasState(define.HeroState_Poison) {
+		t.Fatal("poison state should be removed")
+	}
+}
+
+// 依赖所有者状态的aura, 状态改变时由CombatCtrl.TriggerByServentState删除
+func newTestStateLimitAura(owner *SceneEntity, state define.EHeroState, limit bool, slot int) *Buff {
+	aura := newTestStateAura(owner, owner, state)
+	aura.opts.SlotIndex = int8(slot)
+	aura.opts.Entry.OwnerStateCheckBitSet = bitset.New(uint(define.HeroState_End)).Set(uint(state))
+	aura.opts.Entry.OwnerStateLimitBitSet = bitset.New(uint(define.HeroState_End)).SetTo(uint(state), limit)
+	aura.AddRemoveMode(define.AuraRemoveMode_Running)
+	owner.CombatCtrl.arrayAura[slot] = aura
+	return aura
+}
+
+func TestEntityStateTable(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	cases := []struct {
+		state     define.EHeroState
+		immunable bool // 能否被状态免疫阻止
+	}{
+		{define.HeroState_Dead, false},
+		{define.HeroState_Solid, true},
+		{define.HeroState_Freeze, true},
+		{define.HeroState_Stun, true},
+		{define.HeroState_Fire, true},
+		{define.HeroState_Seal, true},
+		{define.HeroState_UnBeat, true},
+		{define.HeroState_UnDead, true},
+		{define.HeroState_Anger, true},
+		{define.HeroState_DoubleAttack, true},
+		{define.HeroState_Stealth, true},
+		{define.HeroState_Injury, true},
+		{define.HeroState_Poison, true},
+		{define.HeroState_Chaos, true},
+		{define.HeroState_AntiHidden, true},
+		{define.HeroState_ImmunityGroupDmg, true},
+		{define.HeroState_Paralyzed, true},
+		{define.HeroState_Taunt, true},
+	}
+
+	if len(cases) != int(define.HeroState_End-define.HeroState_Begin) {
+		t.Fatalf("%d state cases, expect one case for every HeroState", len(cases))
+	}
+
+	for _, c := range cases {
+		_, e, _ := newAtbTestScene(t)
+		e.State.ClearAll() // 清除静态表配置的初始状态
+
+		// 进入状态时删除, 脱离状态时删除
+		enterAura := newTestStateLimitAura(e, c.state, false, 0)
+		exitAura := newTestStateLimitAura(e, c.state, true, 1)
+
+		// 叠加计数
+		if !e.AddState(c.state, 1) || !e.AddState(c.state, 2) || e.State.Count(uint(c.state)) != 3 {
+			t.Fatalf("state %d count %d after add, expect 3", c.state, e.State.Count(uint(c.state)))
+		}
+
+		if !enterAura.IsRemoved() || exitAura.IsRemoved() {
+			t.Fatalf("state %d enter trigger: enter aura removed %v, exit aura removed %v", c.state, enterAura.IsRemoved(), exitAura.IsRemoved())
+		}
+
+		e.DecState(c.state, 2)
+		if !e.HasState(c.state) || e.State.Count(uint(c.state)) != 1 || exitAura.IsRemoved() {
+			t.Fatalf("state %d count %d after dec, expect 1 and still in state", c.state, e.State.Count(uint(c.state)))
+		}
+
+		e.DecState(c.state, 1)
+		if e.HasState(c.state) || e.State.Count(uint(c.state)) != 0 {
+			t.Fatalf("state %d count %d after remove, expect 0", c.state, e.State.Count(uint(c.state)))
+		}
+
+		if !exitAura.IsRemoved() {
+			t.Fatalf("state %d exit trigger: exit aura should be removed", c.state)
+		}
+
+		// 状态免疫
+		e.AddImmunity(define.ImmunityType_Mechanic, 1<<c.state)
+		added := e.AddState(c.state, 1)
+		if added == c.immunable || e.HasState(c.state) == c.immunable {
+			t.Fatalf("state %d added %v with immunity, expect immunable %v", c.state, added, c.immunable)
+		}
+	}
+}
+
+func TestEntityMechanicImmunity(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	_, attacker, defender := newAtbTestScene(t)
+
+	attacker.AddImmunity(define.ImmunityType_Mechanic, 1<<define.HeroState_Stun)
+	if attacker.AddState(define.HeroState_Stun, 1) || attacker.HasState(define.HeroState_Stun) {
+		t.Fatal("stun should be immune")
+	}
+
+	attacker.DecImmunity(define.ImmunityType_Mechanic, 1<<define.HeroState_Stun)
+	if !attacker.AddState(define.HeroState_Stun, 1) || !attacker.HasState(define.HeroState_Stun) {
+		t.Fatal("stun should be added after immunity removed")
+	}
+
+	// 控制状态打断移动
+	defender.MoveCtrl.MoveTo(attacker, decimal.NewFromInt(1))
+	defender.AddState(define.HeroState_Freeze, 1)
+	if defender.MoveCtrl.IsMoving() {
+		t.Fatal("frozen entity should stop moving")
+	}
+}
+
+func TestAuraEffectState(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	_, attacker, defender := newAtbTestScene(t)
+
+	aura := newTestStateAura(defender, attacker, define.HeroState_Taunt)
+	if res := AuraEffectState(aura, define.AuraEffectStep_Check, 0, nil, nil); res != define.AuraAddResult_Success {
+		t.Fatalf("check result %d, expect success", res)
+	}
+
+	AuraEffectState(aura, define.AuraEffectStep_Apply, 0, nil, nil)
+	if !defender.HasState(define.HeroState_Taunt) || defender.TauntId != attacker.id {
+		t.Fatalf("taunt state %v taunt id %d, expect taunted by %d", defender.HasState(define.HeroState_Taunt), defender.TauntId, attacker.id)
+	}
+
+	AuraEffectState(aura, define.AuraEffectStep_Remove, 0, nil, nil)
+	if defender.HasState(define.HeroState_Taunt) {
+		t.Fatal("taunt state should be removed")
+	}
+
+	// 免疫时检查失败
+	defender.AddImmunity(define.ImmunityType_Mechanic, 1<<define.HeroState_Taunt)
+	if res := AuraEffectState(aura, define.AuraEffectStep_Check, 0, nil, nil); res != define.AuraAddResult_Immunity {
+		t.Fatalf("check result %d, expect immunity", res)
+	}
+}
+
+func TestAuraEffectImmunity(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	_, attacker, _ := newAtbTestScene(t)
+
+	aura := newTestStateAura(attacker, attacker, define.HeroState_Seal)
+	aura.opts.Entry.MiscType1[0] = int32(define.ImmunityType_Mechanic)
+	aura.opts.Entry.MiscValue1[0] = 1 << define.HeroState_Seal
+
+	AuraEffectImmunity(aura, define.AuraEffectStep_Apply, 0, nil, nil)
+	if attacker.AddState(define.HeroState_Seal, 1) {
+		t.Fatal("seal should be immune")
+	}
+
+	AuraEffectImmunity(aura, define.AuraEffectStep_Remove, 0, nil, nil)
+	if !attacker.AddState(define.HeroState_Seal, 1) {
+		t.Fatal("seal should be added after immunity removed")
+	}
+}
+
+func TestEntityDamageImmunity(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	_, attacker, defender := newAtbTestScene(t)
+
+	hp := defender.AttManager.GetFinalAttValue(define.Att_CurHP)
+	defender.AddImmunity(define.ImmunityType_Damage, 1<<define.SchoolType_Physics)
+
+	dmgInfo := &CalcDamageInfo{Type: define.DmgInfo_Damage, SchoolType: define.SchoolType_Physics, Damage: 100}
+	defender.DoneDamage(attacker, dmgInfo)
+	if !defender.AttManager.GetFinalAttValue(define.Att_CurHP).Equal(hp) || dmgInfo.Damage != 0 {
+		t.Fatalf("immune hp %s damage %d, expect hp %s", defender.AttManager.GetFinalAttValue(define.Att_CurHP), dmgInfo.Damage, hp)
+	}
+
+	if dmgInfo.ProcEx&(1<<define.AuraEventEx_Immnne) == 0 {
+		t.Fatal("damage info should be flagged immune")
+	}
+
+	// 魔法伤害不免疫
+	dmgInfo = &CalcDamageInfo{Type: define.DmgInfo_Damage, SchoolType: define.SchoolType_Magic, Damage: 1}
+	defender.DoneDamage(attacker, dmgInfo)
+	if !defender.AttManager.GetFinalAttValue(define.Att_CurHP).Equal(hp.Sub(decimal.NewFromInt(1))) {
+		t.Fatalf("hp %s, expect magic damage taken", defender.AttManager.GetFinalAttValue(define.Att_CurHP))
+	}
+}
+
+func TestEntityInitState(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	_, attacker, _ := newAtbTestScene(t)
+
+	entry := *attacker.HeroEntry
+	entry.StateMask = 1<<define.HeroState_UnBeat | 1<<define.HeroState_Dead
+	entry.Immunity = []int32{0, 1 << define.HeroState_Stun}
+	attacker.HeroEntry = &entry
+	attacker.initState()
+
+	// 静态表不能设置死亡状态
+	if !attacker.HasState(define.HeroState_UnBeat) || attacker.HasState(define.HeroState_Dead) {
+		t.Fatalf("state %b, expect unbeat only", attacker.GetState64())
+	}
+
+	if attacker.AddState(define.HeroState_Stun, 1) {
+		t.Fatal("stun should be immune by entry")
+	}
+}
+
+func TestEntityDmgModAtt(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	_, attacker, _ := newAtbTestScene(t)
+
+	attacker.AttManager.SetFinalAttValue(define.Att_EnemyWoundInc, decimal.NewFromFloat(0.2))
+	attacker.AttManager.SetFinalAttValue(define.Att_SelfDmgDec, decimal.NewFromFloat(0.1))
+	attacker.InitDmgModAtt()
+
+	if !attacker.GetDmgModAtt(define.DmgMod_DamageDone).Equal(decimal.NewFromFloat(0.2)) {
+		t.Fatalf("damage done mod %s, expect 0.2", attacker.GetDmgModAtt(define.DmgMod_DamageDone))
+	}
+
+	if !attacker.GetDmgModAtt(define.DmgMod_DamageTaken).Equal(decimal.NewFromFloat(-0.1)) {
+		t.Fatalf("damage taken mod %s, expect -0.1", attacker.GetDmgModAtt(define.DmgMod_DamageTaken))
+	}
+
+	attacker.ModDmgModAtt(define.DmgMod_HealTaken, decimal.NewFromFloat(-0.5))
+	if !attacker.GetDmgModAtt(define.DmgMod_HealTaken).Equal(decimal.NewFromFloat(-0.5)) {
+		t.Fatalf("heal taken mod %s, expect -0.5", attacker.GetDmgModAtt(define.DmgMod_HealTaken))
+	}
+}
@@ -13,6 +13,10 @@ type SceneOptions struct {
 	DefenceEntityList []*pbGlobal.EntityInfo
 	SceneEntry        *auto.SceneEntry
 	BattleWaveEntries []*auto.BattleWaveEntry
+	BattleLayoutEntry *auto.BattleLayoutEntry // 进攻方布阵点
+	Seed              int64                   // 随机种子, 为0时按时间生成
+	WaveCarryHP       bool                    // 进攻方血量带入下一波
+	WaveCarryBuff     bool                    // 进攻方buff带入下一波
 }
 
 func DefaultSceneOptions() *SceneOptions {
@@ -23,6 +27,10 @@ func DefaultSceneOptions() *SceneOptions {
 		DefenceEntityList: make([]*pbGlobal.EntityInfo, 0, 10),
 		SceneEntry:        nil,
 		BattleWaveEntries: make([]*auto.BattleWaveEntry, 0, 3),
+		BattleLayoutEntry: nil,
+		Seed:              0,
+		WaveCarryHP:       true,
+		WaveCarryBuff:     true,
 	}
 }
 
@@ -61,3 +69,22 @@ func WithSceneBattleWaveEntries(e ...*auto.BattleWaveEntry) SceneOption {
 		o.BattleWaveEntries = append(o.BattleWaveEntries, e...)
 	}
 }
+
+func WithSceneBattleLayoutEntry(e *auto.BattleLayoutEntry) SceneOption {
+	return func(o *SceneOptions) {
+		o.BattleLayoutEntry = e
+	}
+}
+
+func WithSceneSeed(seed int64) SceneOption {
+	return func(o *SceneOptions) {
+		o.Seed = seed
+	}
+}
+
+func WithSceneWaveCarryOver(hp bool, buff bool) SceneOption {
+	return func(o *SceneOptions) {
+		o.WaveCarryHP = hp
+		o.WaveCarryBuff = buff
+	}
+}
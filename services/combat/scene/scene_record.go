@@ -0,0 +1,128 @@
+package scene
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel/auto"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"google.golang.org/protobuf/proto"
+)
+
+// 战斗录像:
+// 场景初始化时记录随机种子、双方初始单位、怪物波次和波次带入规则, 战斗过程中按回合记录行动、技能、伤害、治疗、buff、状态和波次事件.
+// 回放时使用相同的初始数据和种子重新模拟, 战斗结果和事件流应与录像完全一致
+
+var (
+	ErrRecordInvalidScene  = errors.New("invalid combat record scene")
+	ErrRecordInvalidWave   = errors.New("invalid combat record wave")
+	ErrRecordInvalidLayout = errors.New("invalid combat record layout")
+	ErrRecordMismatch      = errors.New("combat record mismatch")
+)
+
+func (s *Scene) initRecord() {
+	s.record = &pbGlobal.CombatRecord{
+		Seed:              s.opts.Seed,
+		AttackId:          s.opts.AttackId,
+		DefenceId:         s.opts.DefenceId,
+		AttackEntityList:  s.opts.AttackEntityList,
+		DefenceEntityList: s.opts.DefenceEntityList,
+		WaveIds:           make([]int32, 0, len(s.opts.BattleWaveEntries)),
+		Events:            make([]*pbGlobal.CombatEvent, 0, 128),
+		CreateTime:        time.Now().Unix(),
+		WaveCarryHP:       s.opts.WaveCarryHP,
+		WaveCarryBuff:     s.opts.WaveCarryBuff,
+	}
+
+	if s.opts.SceneEntry != nil {
+		s.record.SceneTypeId = s.opts.SceneEntry.Id
+	}
+
+	if s.opts.BattleLayoutEntry != nil {
+		s.record.LayoutId = s.opts.BattleLayoutEntry.Id
+	}
+
+	for _, entry := range s.opts.BattleWaveEntries {
+		if entry != nil {
+			s.record.WaveIds = append(s.record.WaveIds, entry.Id)
+		}
+	}
+}
+
+// 记录战斗事件
+func (s *Scene) addEvent(e *pbGlobal.CombatEvent) {
+	e.Round = s.curRound
+	e.Time = s.curRound * define.Scene_RoundTime
+	s.record.Events = append(s.record.Events, e)
+}
+
+// 根据录像重新模拟战斗
+func Replay(record *pbGlobal.CombatRecord) (*SceneResult, error) {
+	sceneEntry, ok := auto.GetSceneEntry(record.GetSceneTypeId())
+	if !ok {
+		return nil, fmt.Errorf("scene_type_id<%d>: %w", record.GetSceneTypeId(), ErrRecordInvalidScene)
+	}
+
+	battleWaveEntries := make([]*auto.BattleWaveEntry, 0, len(record.GetWaveIds()))
+	for _, id := range record.GetWaveIds() {
+		entry, ok := auto.GetBattleWaveEntry(id)
+		if !ok {
+			return nil, fmt.Errorf("wave_id<%d>: %w", id, ErrRecordInvalidWave)
+		}
+
+		battleWaveEntries = append(battleWaveEntries, entry)
+	}
+
+	var layoutEntry *auto.BattleLayoutEntry
+	if record.GetLayoutId() != 0 {
+		layoutEntry, ok = auto.GetBattleLayoutEntry(record.GetLayoutId())
+		if !ok {
+			return nil, fmt.Errorf("layout_id<%d>: %w", record.GetLayoutId(), ErrRecordInvalidLayout)
+		}
+	}
+
+	// 回放不需要tasker, 直接模拟到战斗结束
+	return Simulate(
+		record.GetId(),
+		WithSceneSeed(record.GetSeed()),
+		WithSceneAttackId(record.GetAttackId()),
+		WithSceneDefenceId(record.GetDefenceId()),
+		WithSceneAttackUnitList(record.GetAttackEntityList()),
+		WithSceneDefenceUnitList(record.GetDefenceEntityList()),
+		WithSceneEntry(sceneEntry),
+		WithSceneBattleWaveEntries(battleWaveEntries...),
+		WithSceneBattleLayoutEntry(layoutEntry),
+		WithSceneWaveCarryOver(record.GetWaveCarryHP(), record.GetWaveCarryBuff()),
+	), nil
+}
+
+// 校验录像, 重新模拟的结果和事件流需要与录像一致
+func VerifyRecord(record *pbGlobal.CombatRecord) error {
+	result, err := Replay(record)
+	if err != nil {
+		return err
+	}
+
+	if result.Win != record.GetWin() {
+		return fmt.Errorf("record_id<%d> win<%v> replay win<%v>: %w", record.GetId(), record.GetWin(), result.Win, ErrRecordMismatch)
+	}
+
+	if !proto.Equal(result.Statistics, record.GetStatistics()) {
+		return fmt.Errorf("record_id<%d> statistics<%v> replay statistics<%v>: %w", record.GetId(), record.GetStatistics(), result.Statistics, ErrRecordMismatch)
+	}
+
+	events := result.Record.GetEvents()
+	if len(events) != len(record.GetEvents()) {
+		return fmt.Errorf("record_id<%d> event num<%d> replay event num<%d>: %w", record.GetId(), len(record.GetEvents()), len(events), ErrRecordMismatch)
+	}
+
+	for n, e := range record.GetEvents() {
+		if !proto.Equal(e, events[n]) {
+			return fmt.Errorf("record_id<%d> event<%d> %v replay %v: %w", record.GetId(), n, e, events[n], ErrRecordMismatch)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package scene
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel"
+	"github.com/east-eden/server/excel/auto"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"google.golang.org/protobuf/proto"
+)
+
+func loadRecordTestEntries(t *testing.T) {
+	t.Helper()
+
+	dir := "../../../config/csv/"
+	if _, err := os.Stat(dir + "Stage.csv"); err != nil {
+		t.Skip("config/csv not found")
+	}
+
+	excel.ReadAllEntries(dir)
+}
+
+// 取第一个配置了波次的章节关卡, 不包含测试关卡
+func testStageEntry(t *testing.T) *auto.StageEntry {
+	t.Helper()
+
+	stageIds := make([]int32, 0, auto.GetStageSize())
+	for id := range auto.GetStageRows() {
+		stageIds = append(stageIds, id)
+	}
+	sort.Slice(stageIds, func(i, j int) bool { return stageIds[i] < stageIds[j] })
+
+	for _, id := range stageIds {
+		entry, _ := auto.GetStageEntry(id)
+		if entry.ChapterId > 0 && len(entry.WaveID) > 0 {
+			return entry
+		}
+	}
+
+	t.Fatal("stage with battle wave not found")
+	return nil
+}
+
+// 取前三个英雄作为进攻方
+func testAttackList() []*pbGlobal.EntityInfo {
+	heroIds := make([]int32, 0, auto.GetHeroSize())
+	for id := range auto.GetHeroRows() {
+		heroIds = append(heroIds, id)
+	}
+	sort.Slice(heroIds, func(i, j int) bool { return heroIds[i] < heroIds[j] })
+	if len(heroIds) > 3 {
+		heroIds = heroIds[:3]
+	}
+
+	attackList := make([]*pbGlobal.EntityInfo, 0, len(heroIds))
+	for _, id := range heroIds {
+		attackList = append(attackList, &pbGlobal.EntityInfo{HeroTypeId: id})
+	}
+
+	return attackList
+}
+
+// 生成一场关卡战斗的录像
+func genStageRecord(t *testing.T) *pbGlobal.CombatRecord {
+	t.Helper()
+
+	sceneEntry, ok := auto.GetSceneEntry(1)
+	if !ok {
+		t.Fatal("scene entry not found")
+	}
+
+	stageEntry := testStageEntry(t)
+	result, err := Replay(&pbGlobal.CombatRecord{
+		Id:               1,
+		Seed:             12345,
+		SceneTypeId:      sceneEntry.Id,
+		AttackId:         1,
+		DefenceId:        -1,
+		AttackEntityList: testAttackList(),
+		WaveIds:          stageEntry.WaveID,
+		LayoutId:         stageEntry.LayoutID,
+	})
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+
+	result.Record.Id = 1
+	return result.Record
+}
+
+func TestVerifyRecord(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	record := genStageRecord(t)
+	if len(record.GetEvents()) == 0 {
+		t.Fatal("combat record without events")
+	}
+
+	// 经过序列化的录像回放结果一致
+	data, err := proto.Marshal(record)
+	if err != nil {
+		t.Fatalf("marshal record failed: %v", err)
+	}
+
+	loaded := &pbGlobal.CombatRecord{}
+	if err := proto.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("unmarshal record failed: %v", err)
+	}
+
+	if err := VerifyRecord(loaded); err != nil {
+		t.Fatalf("verify record failed: %v", err)
+	}
+
+	// 篡改结果
+	tampered := proto.Clone(loaded).(*pbGlobal.CombatRecord)
+	tampered.Win = !tampered.Win
+	if err := VerifyRecord(tampered); !errors.Is(err, ErrRecordMismatch) {
+		t.Fatalf("tampered win should mismatch, got %v", err)
+	}
+
+	// 篡改事件
+	tampered = proto.Clone(loaded).(*pbGlobal.CombatRecord)
+	tampered.Events[len(tampered.Events)-1].Value++
+	if err := VerifyRecord(tampered); !errors.Is(err, ErrRecordMismatch) {
+		t.Fatalf("tampered event should mismatch, got %v", err)
+	}
+
+	// 无效场景
+	tampered = proto.Clone(loaded).(*pbGlobal.CombatRecord)
+	tampered.SceneTypeId = -1
+	if err := VerifyRecord(tampered); !errors.Is(err, ErrRecordInvalidScene) {
+		t.Fatalf("invalid scene should fail, got %v", err)
+	}
+
+	// 无效布阵点
+	tampered = proto.Clone(loaded).(*pbGlobal.CombatRecord)
+	tampered.LayoutId = -1
+	if err := VerifyRecord(tampered); !errors.Is(err, ErrRecordInvalidLayout) {
+		t.Fatalf("invalid layout should fail, got %v", err)
+	}
+}
+
+func TestCombatEventStream(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	record := genStageRecord(t)
+	counts := make(map[pbGlobal.CombatEventType]int)
+	var lastRound int32
+	for n, e := range record.GetEvents() {
+		if e.Time != e.Round*define.Scene_RoundTime {
+			t.Fatalf("event<%d> round %d time %d", n, e.Round, e.Time)
+		}
+
+		if e.Round < lastRound {
+			t.Fatalf("event<%d> round %d before last round %d", n, e.Round, lastRound)
+		}
+		lastRound = e.Round
+
+		// 未命中的目标不会暴击
+		if e.Type == pbGlobal.CombatEventType_CombatEvent_SkillHit && e.Miss && e.Crit {
+			t.Fatalf("event<%d> miss with crit", n)
+		}
+
+		counts[e.Type]++
+	}
+
+	if counts[pbGlobal.CombatEventType_CombatEvent_SkillCast] == 0 || counts[pbGlobal.CombatEventType_CombatEvent_SkillHit] == 0 {
+		t.Fatalf("skill events missing: %v", counts)
+	}
+
+	if counts[pbGlobal.CombatEventType_CombatEvent_SkillEnd] != counts[pbGlobal.CombatEventType_CombatEvent_SkillCast] {
+		t.Fatalf("skill cast %d skill end %d", counts[pbGlobal.CombatEventType_CombatEvent_SkillCast], counts[pbGlobal.CombatEventType_CombatEvent_SkillEnd])
+	}
+
+	// 每个波次都有开始和结束事件
+	waves := len(record.GetStatistics().GetWaves())
+	if counts[pbGlobal.CombatEventType_CombatEvent_WaveStart] != waves || counts[pbGlobal.CombatEventType_CombatEvent_WaveEnd] != waves {
+		t.Fatalf("waves %d wave start %d wave end %d", waves, counts[pbGlobal.CombatEventType_CombatEvent_WaveStart], counts[pbGlobal.CombatEventType_CombatEvent_WaveEnd])
+	}
+
+	// 死亡不记录为状态事件
+	for _, e := range record.GetEvents() {
+		if e.Type == pbGlobal.CombatEventType_CombatEvent_StateAdd && e.Value == int64(define.HeroState_Dead) {
+			t.Fatal("dead state should be recorded as dead event")
+		}
+	}
+}
+
+func TestStateChangedEvent(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	s, attacker, _ := newAtbTestScene(t)
+	attacker.AddState(define.HeroState_Stun, 1)
+	attacker.AddState(define.HeroState_Stun, 1)
+	attacker.DecState(define.HeroState_Stun, 1)
+	attacker.DecState(define.HeroState_Stun, 1)
+
+	var types []pbGlobal.CombatEventType
+	for _, e := range s.record.GetEvents() {
+		if e.TargetId == attacker.id && e.Value == int64(define.HeroState_Stun) {
+			types = append(types, e.Type)
+		}
+	}
+
+	if len(types) != 2 || types[0] != pbGlobal.CombatEventType_CombatEvent_StateAdd || types[1] != pbGlobal.CombatEventType_CombatEvent_StateRemove {
+		t.Fatalf("state events %v, expect add then remove", types)
+	}
+}
@@ -0,0 +1,140 @@
+package scene
+
+import (
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel/auto"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/east-eden/server/utils"
+	log "github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// 多波次战斗:
+// 防守方全部死亡后刷出下一波怪物, 所有波次清除后战斗胜利.
+// 进攻方的血量和buff是否带入下一波由场景参数决定
+
+// 当前波次统计
+func (s *Scene) getWaveStatistics() *pbGlobal.WaveStatistics {
+	if int(s.curWave) >= len(s.statistics.Waves) {
+		return nil
+	}
+
+	return s.statistics.Waves[s.curWave]
+}
+
+// 进入下一波, 没有下一波时返回false
+func (s *Scene) nextWave() bool {
+	if int(s.curWave)+1 >= len(s.opts.BattleWaveEntries) {
+		return false
+	}
+
+	s.endWave(true)
+	s.carryOver()
+	s.spawnWave(s.curWave + 1)
+	return true
+}
+
+// 结束当前波次
+func (s *Scene) endWave(cleared bool) {
+	wave := s.getWaveStatistics()
+	if wave == nil {
+		return
+	}
+
+	wave.Rounds = s.curRound - wave.StartRound
+	wave.Cleared = cleared
+
+	s.addEvent(&pbGlobal.CombatEvent{
+		Type:     pbGlobal.CombatEventType_CombatEvent_WaveEnd,
+		CasterId: -1,
+		TargetId: -1,
+		Value:    int64(s.curWave + 1),
+	})
+}
+
+// 刷出波次怪物
+func (s *Scene) spawnWave(idx int32) {
+	battleWaveEntry := s.opts.BattleWaveEntries[idx]
+	s.curWave = idx
+	s.statistics.WaveReached = idx + 1
+
+	wave := &pbGlobal.WaveStatistics{
+		StartRound: s.curRound,
+	}
+	s.statistics.Waves = append(s.statistics.Waves, wave)
+
+	s.addEvent(&pbGlobal.CombatEvent{
+		Type:     pbGlobal.CombatEventType_CombatEvent_WaveStart,
+		CasterId: -1,
+		TargetId: -1,
+		Value:    int64(idx + 1),
+	})
+
+	if battleWaveEntry != nil {
+		wave.WaveId = battleWaveEntry.Id
+		for n := range battleWaveEntry.MonsterID {
+			s.spawnWaveMonster(battleWaveEntry.MonsterID[n], battleWaveEntry.PositionX[n], battleWaveEntry.PositionZ[n], battleWaveEntry.Rotation[n], battleWaveEntry.InitalCom[n])
+		}
+	}
+
+	wave.DefenceUnitNum = s.camps[define.Scene_Camp_Defence].aliveUnitNum
+}
+
+func (s *Scene) spawnWaveMonster(monsterId int32, posX, posZ, rotate, initCom decimal.Decimal) {
+	// monster id invalid
+	if monsterId == -1 {
+		return
+	}
+
+	monsterEntry, ok := auto.GetMonsterEntry(monsterId)
+	if !ok {
+		return
+	}
+
+	modelEntry, ok := auto.GetModelEntry(monsterEntry.ModelID)
+	if !ok {
+		log.Error().Caller().Int32("monster_id", monsterId).Int32("model_id", monsterEntry.ModelID).Msg("can not find ModelEntry")
+		return
+	}
+
+	e, err := s.AddEntityByOptions(
+		s.camps[define.Scene_Camp_Defence],
+		WithEntityMonsterId(monsterId),
+		WithEntityMonsterEntry(monsterEntry),
+		WithEntityModelEntry(modelEntry),
+		WithEntityPosition(posX, posZ, rotate),
+		WithEntityInitAtbValue(initCom),
+	)
+
+	if !utils.ErrCheck(err, "AddEntityByOptions failed when Scene.spawnWaveMonster", s.GetId(), monsterId) {
+		return
+	}
+
+	// 战斗开始后刷出的单位需要单独初始化技能
+	if s.curRound > 0 {
+		e.OnSceneStart()
+	}
+}
+
+// 进攻方存活单位进入下一波前的处理
+func (s *Scene) carryOver() {
+	if s.opts.WaveCarryHP && s.opts.WaveCarryBuff {
+		return
+	}
+
+	it := s.entityMap.Iterator()
+	for it.Next() {
+		e := it.Value().(*SceneEntity)
+		if e.GetCamp().camp != define.Scene_Camp_Attack || e.HasState(define.HeroState_Dead) {
+			continue
+		}
+
+		if !s.opts.WaveCarryHP {
+			e.AttManager.SetFinalAttValue(define.Att_CurHP, e.AttManager.GetFinalAttValue(define.Att_MaxHP))
+		}
+
+		if !s.opts.WaveCarryBuff {
+			e.CombatCtrl.RemoveAllAura()
+		}
+	}
+}
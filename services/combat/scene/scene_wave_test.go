@@ -0,0 +1,191 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel/auto"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/shopspring/decimal"
+)
+
+func newWaveTestScene(t *testing.T, waveNum int, opts ...SceneOption) *Scene {
+	t.Helper()
+
+	sceneEntry, ok := auto.GetSceneEntry(1)
+	if !ok {
+		t.Fatal("scene entry not found")
+	}
+
+	stageEntry := testStageEntry(t)
+	waveEntry, ok := auto.GetBattleWaveEntry(stageEntry.WaveID[0])
+	if !ok {
+		t.Fatal("battle wave entry not found")
+	}
+
+	layoutEntry, ok := auto.GetBattleLayoutEntry(stageEntry.LayoutID)
+	if !ok {
+		t.Fatal("battle layout entry not found")
+	}
+
+	waves := make([]*auto.BattleWaveEntry, 0, waveNum)
+	for n := 0; n < waveNum; n++ {
+		waves = append(waves, waveEntry)
+	}
+
+	opts = append(opts,
+		WithSceneSeed(1),
+		WithSceneEntry(sceneEntry),
+		WithSceneAttackUnitList(testAttackList()),
+		WithSceneBattleWaveEntries(waves...),
+		WithSceneBattleLayoutEntry(layoutEntry),
+	)
+
+	s := NewScene()
+	s.init(1, opts...)
+	return s
+}
+
+func TestMultiWave(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	s := newWaveTestScene(t, 3)
+	if s.statistics.WaveReached != 1 || len(s.statistics.Waves) != 1 {
+		t.Fatalf("scene should start with first wave, statistics: %v", s.statistics)
+	}
+
+	firstWaveUnits := s.statistics.Waves[0].DefenceUnitNum
+	if firstWaveUnits == 0 {
+		t.Fatal("first wave without monsters")
+	}
+
+	s.onTaskStart()
+	s.simulate()
+	result := <-s.result
+
+	stats := result.Statistics
+	if !result.Win {
+		t.Fatalf("combat should win, statistics: %v", stats)
+	}
+
+	if stats.WaveReached != 3 || len(stats.Waves) != 3 {
+		t.Fatalf("combat should reach wave 3, statistics: %v", stats)
+	}
+
+	var rounds int32
+	for n, wave := range stats.Waves {
+		if !wave.Cleared || wave.DefenceUnitNum != firstWaveUnits || wave.DefenceDeadNum != firstWaveUnits {
+			t.Fatalf("wave %d statistics invalid: %v", n, wave)
+		}
+
+		if wave.StartRound != rounds {
+			t.Fatalf("wave %d start round %d, expect %d", n, wave.StartRound, rounds)
+		}
+		rounds += wave.Rounds
+	}
+
+	if rounds != stats.Rounds || stats.DefenceUnitNum != firstWaveUnits*3 {
+		t.Fatalf("combat statistics invalid: %v", stats)
+	}
+
+	var waveEvents int
+	for _, e := range result.Record.Events {
+		if e.Type == pbGlobal.CombatEventType_CombatEvent_WaveStart {
+			waveEvents++
+		}
+	}
+
+	if waveEvents != 3 {
+		t.Fatalf("wave start events %d, expect 3", waveEvents)
+	}
+}
+
+func TestWaveCarryOver(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	cases := []struct {
+		name  string
+		hp    bool
+		reset bool // 进入下一波后血量是否回满
+	}{
+		{name: "carry hp", hp: true, reset: false},
+		{name: "reset hp", hp: false, reset: true},
+	}
+
+	for _, c := range cases {
+		s := newWaveTestScene(t, 2, WithSceneWaveCarryOver(c.hp, true))
+
+		attackers := make([]*SceneEntity, 0, 3)
+		it := s.entityMap.Iterator()
+		for it.Next() {
+			e := it.Value().(*SceneEntity)
+			if e.GetCamp().camp == define.Scene_Camp_Attack {
+				e.AttManager.SetFinalAttValue(define.Att_CurHP, decimal.NewFromInt(1))
+				attackers = append(attackers, e)
+			}
+		}
+
+		if !s.nextWave() {
+			t.Fatalf("case <%s> should have next wave", c.name)
+		}
+
+		if s.nextWave() {
+			t.Fatalf("case <%s> should not have wave 3", c.name)
+		}
+
+		for _, e := range attackers {
+			curHP := e.AttManager.GetFinalAttValue(define.Att_CurHP)
+			maxHP := e.AttManager.GetFinalAttValue(define.Att_MaxHP)
+			if c.reset != curHP.Equal(maxHP) {
+				t.Fatalf("case <%s> entity<%d> hp %s max hp %s", c.name, e.id, curHP, maxHP)
+			}
+		}
+	}
+}
+
+func TestAttackLayoutPos(t *testing.T) {
+	loadRecordTestEntries(t)
+
+	sceneEntry, ok := auto.GetSceneEntry(1)
+	if !ok {
+		t.Fatal("scene entry not found")
+	}
+
+	layout := &auto.BattleLayoutEntry{}
+	for n := 0; n < 5; n++ {
+		layout.PositionX = append(layout.PositionX, decimal.NewFromInt(int64(-n)))
+		layout.PositionZ = append(layout.PositionZ, decimal.NewFromInt(int64(n)))
+		layout.Rotation = append(layout.Rotation, decimal.NewFromInt(90))
+	}
+
+	// 按布阵位置倒序站位, 最后一个单位没有位置时按出场顺序站位
+	attackList := testAttackList()
+	for n, unitInfo := range attackList[:len(attackList)-1] {
+		unitInfo.Pos = int32(5 - n)
+	}
+
+	s := NewScene()
+	s.init(1,
+		WithSceneSeed(1),
+		WithSceneEntry(sceneEntry),
+		WithSceneAttackUnitList(attackList),
+		WithSceneBattleLayoutEntry(layout),
+	)
+
+	for n, unitInfo := range attackList {
+		layoutIdx := n
+		if unitInfo.Pos > 0 {
+			layoutIdx = int(unitInfo.Pos) - 1
+		}
+
+		e, ok := s.GetEntity(int64(n + 1))
+		if !ok {
+			t.Fatalf("attack entity %d not found", n+1)
+		}
+
+		pos := e.GetPosition()
+		if !pos.X.Equal(layout.PositionX[layoutIdx]) || !pos.Z.Equal(layout.PositionZ[layoutIdx]) {
+			t.Fatalf("attack entity %d position (%s, %s), expect layout %d", n+1, pos.X, pos.Z, layoutIdx)
+		}
+	}
+}
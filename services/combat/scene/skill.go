@@ -110,6 +110,12 @@ func (s *Skill) checkTargetsValid() {
 	for e := s.listTargets.Front(); e != nil; e = next {
 		next = e.Next()
 
+		// 死亡单位不能作为目标
+		if e.Value.(*SceneEntity).HasState(define.HeroState_Dead) {
+			s.listTargets.Remove(e)
+			continue
+		}
+
 		// todo 目标种族检查
 		// if ((1 << target.opts.Entry.Race) & s.opts.Entry.TargetRace) == 0 {
 		// 	return false
@@ -227,6 +233,7 @@ func (s *Skill) doEffect(target *SceneEntity) {
 
 	// 计算技能结果
 	s.calSpellResult(target)
+	scene.OnSkillHit(s.opts.Caster, target, &s.damageInfo)
 
 	s.effectFlag = 0
 
@@ -334,23 +341,14 @@ func (s *Skill) sendCastEnd() {
 		return
 	}
 
-	// if scene.IsOnlyRecord() {
-	// 	return
-	// }
-
-	// todo send message
-	//CreateSceneProtoMsg(msg, MS_CastEnd,);
-	//*msg << (UINT32)m_pCaster->GetLocation();
-	//*msg << (UINT32)m_pEntry->dwID;
-	//*msg << (INT32)m_pCaster->GetAttController().GetAttValue(EHA_CurRage);
-	//*msg << (INT32)(static_cast<EntityGroup*>(m_pCaster->GetFather())->GetEnergy());
-	//m_pCaster->GetScene()->AddMsgList(msg);
+	scene.OnSkillCastEnd(s.opts.Caster, s.opts.Entry)
 }
 
 func (s *Skill) calSpellResult(target *SceneEntity) {
 	// 反击技能直接命中，不计算暴击和格挡
 	if s.opts.SpellType == define.SpellType_TriggerBeatBack {
 		s.damageInfo.ProcEx |= (1 << define.AuraEventEx_Normal_Hit)
+		s.damageInfo.Hit = true
 		return
 	}
 
@@ -405,9 +403,7 @@ func (s *Skill) calDamage(baseDamage int64, damageInfo *CalcDamageInfo, target *
 		return
 	}
 
-	dmgInc := s.opts.Caster.AttManager.GetFinalAttValue(define.Att_SelfDmgInc)
-	baseDamage = dmgInc.Mul(decimal.NewFromInt(baseDamage)).Round(0).IntPart()
-
+	// 自身伤害加成已在技能效果中计算
 	if s.opts.SpellType == define.SpellType_Rage {
 		dmgMod := int64(float64(s.ragePctMod) * float64(baseDamage))
 		baseDamage += dmgMod
@@ -442,7 +438,21 @@ func (s *Skill) calDamage(baseDamage int64, damageInfo *CalcDamageInfo, target *
 
 	//nBaseDamage += ((fPctDmgMod / 10000.0f) * (FLOAT)nBaseDamage);
 
-	if baseDamage < 1 {
+	// 伤害加成和减免
+	pctDmgMod := target.GetDmgModAtt(define.DmgMod_DamageTaken)
+	if s.opts.Caster != nil {
+		pctDmgMod = pctDmgMod.Add(s.opts.Caster.GetDmgModAtt(define.DmgMod_DamageDone))
+	}
+
+	// 判断百分比下限
+	if pctDmgMod.LessThan(dmgModLowerLimit) {
+		pctDmgMod = dmgModLowerLimit
+	}
+
+	baseDamage += pctDmgMod.Mul(decimal.NewFromInt(baseDamage)).IntPart()
+
+	damageInfo.Damage = baseDamage
+	if damageInfo.Damage < 1 {
 		damageInfo.Damage = 1
 	}
 
@@ -495,6 +505,18 @@ func (s *Skill) calHeal(baseHeal int64, damageInfo *CalcDamageInfo, target *Scen
 	//fDmgMod = fDmgMod * nBaseHeal;
 	//nBaseHeal += fDmgMod;
 
+	// 治疗加成和减免
+	pctHealMod := target.GetDmgModAtt(define.DmgMod_HealTaken)
+	if s.opts.Caster != nil {
+		pctHealMod = pctHealMod.Add(s.opts.Caster.GetDmgModAtt(define.DmgMod_HealDone))
+	}
+
+	if pctHealMod.LessThan(dmgModLowerLimit) {
+		pctHealMod = dmgModLowerLimit
+	}
+
+	baseHeal += pctHealMod.Mul(decimal.NewFromInt(baseHeal)).IntPart()
+
 	//if (DamageInfo.dwProcEx & EAEE_Critical_Hit)
 	//{
 	//INT nCrit = m_pCaster->GetAttController().GetAttValue(EHA_CritInc);
@@ -523,16 +545,20 @@ func (s *Skill) dealDamage(target *SceneEntity, baseDamage int64, damageInfo *Ca
 
 	s.calDamage(baseDamage, damageInfo, target)
 
+	// 免疫伤害
+	target.checkDamageImmunity(damageInfo)
+
 	// 触发双方的伤害接口
 	if s.opts.Caster != nil {
 		s.opts.Caster.OnDamage(target, damageInfo)
 	}
 
 	target.OnBeDamaged(s.opts.Caster, damageInfo)
+	s.GetScene().OnDamage(s.opts.Caster, target, damageInfo)
 }
 
 func (s *Skill) dealHeal(target *SceneEntity, baseHeal int64, damageInfo *CalcDamageInfo) {
-	if target != nil {
+	if target == nil {
 		return
 	}
 
@@ -558,12 +584,19 @@ func (s *Skill) dealHeal(target *SceneEntity, baseHeal int64, damageInfo *CalcDa
 	if maxHeal < damageInfo.Damage {
 		damageInfo.Damage = maxHeal
 	}
+
+	s.GetScene().OnDamage(s.opts.Caster, target, damageInfo)
 }
 
 //--------------------------------------------------------------------------------------------------
 // 效果是否可作用于目标
 //--------------------------------------------------------------------------------------------------
 func (s *Skill) checkEffectValid(effectEntry *auto.SkillEffectEntry, target *SceneEntity) bool {
+	// 免疫技能效果
+	if target.HasImmunityAny(define.ImmunityType_Effect, 1<<uint32(effectEntry.EffectType/100)) {
+		return false
+	}
+
 	// if s.opts.Entry.Effects[index] == define.SpellEffectType_Null {
 	// 	return false
 	// }
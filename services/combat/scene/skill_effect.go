@@ -82,7 +82,7 @@ func effectDamage(s *Skill, effectEntry *auto.SkillEffectEntry, target *SceneEnt
 	partB := decimal.NewFromInt32(1).Add(dmgInc)
 	partC := decimal.NewFromInt32(1).Sub(armorDec)
 	partD := decimal.NewFromInt32(1).Sub(dmgRes)
-	partE := s.opts.Caster.GetAttManager().GetFinalAttValue(define.Att_SelfDmgInc)
+	partE := decimal.NewFromInt32(1).Add(s.opts.Caster.GetAttManager().GetFinalAttValue(define.Att_SelfDmgInc))
 	partF := random.DecimalFake(globalConfig.DamageRange[0], globalConfig.DamageRange[1], s.GetScene().GetRand())
 	partG := func() decimal.Decimal {
 		critDamage := decimal.NewFromInt32(1)
@@ -95,6 +95,13 @@ func effectDamage(s *Skill, effectEntry *auto.SkillEffectEntry, target *SceneEnt
 	partH := realDamageBase
 
 	s.baseDamage = partA.Mul(partB).Mul(partC).Mul(partD).Mul(partE).Mul(partF).Mul(partG).Add(partH).Round(0).IntPart()
+
+	s.effectFlag |= 1 << uint32(effectEntry.EffectType/100)
+	s.damageInfo.Type = define.DmgInfo_Damage
+	s.damageInfo.SchoolType = define.SchoolType_Magic
+	if damageType == 0 {
+		s.damageInfo.SchoolType = define.SchoolType_Physics
+	}
 }
 
 // 201 治疗效果
@@ -103,6 +110,13 @@ func effectHeal(s *Skill, effectEntry *auto.SkillEffectEntry, target *SceneEntit
 
 // 301 打断效果
 func effectInterrupt(s *Skill, effectEntry *auto.SkillEffectEntry, target *SceneEntity) {
+	if target.HasState(define.HeroState_Dead) {
+		return
+	}
+
+	if target.ActionCtrl.Interrupt() {
+		s.GetScene().OnSkillInterrupted(s.opts.Caster, target)
+	}
 }
 
 // 401 聚集效果
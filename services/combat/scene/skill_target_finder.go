@@ -54,47 +54,50 @@ func registerTargetType() {
 
 	// 选定空间
 	targetTypeFn[define.SkillTargetType_SelectRound] = func(s *Skill) {
+		if s.opts.Target != nil {
+			s.opts.TargetPosition = s.opts.Target.GetPosition()
+		}
 	}
 
-	// 友军单体
+	// 友军单体, 以发起坐标为准
 	targetTypeFn[define.SkillTargetType_FriendlySingle] = func(s *Skill) {
-		s.listTargets.Init()
-		s.listTargets.PushBack(s.opts.Target)
 	}
 
-	// 敌军单体
+	// 敌军单体, 以发起坐标为准
 	targetTypeFn[define.SkillTargetType_EnemySingle] = func(s *Skill) {
-		s.listTargets.Init()
-		s.listTargets.PushBack(s.opts.Target)
 	}
 }
 
 func registerRangeType() {
+	// 单体
+	rangeTypeFn[define.SkillRangeType_Single] = func(s *Skill) {
+		s.listTargets.Init()
+		s.listTargets.PushBack(s.opts.Target)
+	}
+
 	// 圆形
 	rangeTypeFn[define.SkillRangeType_Circle] = func(s *Skill) {
-		skillStartPosition := s.opts.TargetPosition
-		radius := int32(s.opts.Entry.TargetLength.IntPart())
-
-		var next *list.Element
-		for e := s.listTargets.Front(); e != nil; e = next {
-			next = e.Next()
-			targetPosition := e.Value.(*SceneEntity).GetPosition()
-
-			// 判断距离是否在技能范围内
-			if !IsInDistance(skillStartPosition, targetPosition, radius) {
-				s.listTargets.Remove(e)
-			}
-		}
+		s.filterTargets(func(target *SceneEntity) bool {
+			return IsInDistance(s.opts.TargetPosition, target.GetPosition(), s.opts.Entry.TargetLength.Add(target.GetRadius()))
+		})
 	}
 
-	// 矩形
+	// 矩形, 沿发起点朝向延伸
 	rangeTypeFn[define.SkillRangeType_Rectangle] = func(s *Skill) {
-
+		s.filterTargets(func(target *SceneEntity) bool {
+			radius := target.GetRadius()
+			length := s.opts.Entry.TargetLength.Add(radius)
+			width := s.opts.Entry.TargetWide.Add(radius.Add(radius))
+			return IsInRectangle(s.opts.TargetPosition, target.GetPosition(), length, width)
+		})
 	}
 
-	// 扇形
+	// 扇形, 范围宽为扇形角度
 	rangeTypeFn[define.SkillRangeType_Fan] = func(s *Skill) {
-
+		s.filterTargets(func(target *SceneEntity) bool {
+			radius := s.opts.Entry.TargetLength.Add(target.GetRadius())
+			return IsInFan(s.opts.TargetPosition, target.GetPosition(), radius, s.opts.Entry.TargetWide)
+		})
 	}
 }
 
@@ -203,16 +206,35 @@ func (s *Skill) selectTargets() {
 	// }
 
 	// 通过发起类型获取技能开始时坐标
-	launchTypeFn[s.opts.Entry.SkillLaunch](s)
+	if fn, ok := launchTypeFn[s.opts.Entry.SkillLaunch]; ok {
+		fn(s)
+	}
 
 	// 通过目标类型筛选目标
-	targetTypeFn[s.opts.Entry.TargetType](s)
+	if fn, ok := targetTypeFn[s.opts.Entry.TargetType]; ok {
+		fn(s)
+	}
 
 	// 通过技能范围筛选目标
-	rangeTypeFn[s.opts.Entry.RangeType](s)
+	if fn, ok := rangeTypeFn[s.opts.Entry.RangeType]; ok {
+		fn(s)
+	}
 
 	// 通过作用对象筛选目标
-	scopeTypeFn[s.opts.Entry.Scope](s)
+	if fn, ok := scopeTypeFn[s.opts.Entry.Scope]; ok {
+		fn(s)
+	}
+}
+
+// 删除不满足条件的目标
+func (s *Skill) filterTargets(keep func(*SceneEntity) bool) {
+	var next *list.Element
+	for e := s.listTargets.Front(); e != nil; e = next {
+		next = e.Next()
+		if !keep(e.Value.(*SceneEntity)) {
+			s.listTargets.Remove(e)
+		}
+	}
 }
 
 // 选定目标
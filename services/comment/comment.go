@@ -104,9 +104,9 @@ func (m *Comment) Action(ctx *cli.Context) error {
 		})
 	}
 
-	m.ID = int16(ctx.Int("rank_id"))
+	m.ID = int16(ctx.Int("comment_id"))
 
-	store.NewStore(ctx)
+	store.NewStore(ctx, store.Node(m.ID))
 
 	// init snowflakes
 	m.initSnowflake()
@@ -133,6 +133,13 @@ func (m *Comment) Action(ctx *cli.Context) error {
 		m.manager.Exit(ctx)
 	})
 
+	// sensitive words hot reload
+	m.wg.Wrap(func() {
+		defer utils.CaptureException()
+		err := excel.WatchEntries(ctx.Context, "config/csv/", "SensitiveWord.csv")
+		_ = utils.ErrCheck(err, "excel.WatchEntries failed")
+	})
+
 	// gin server
 	m.wg.Wrap(func() {
 		defer utils.CaptureException()
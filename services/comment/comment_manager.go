@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"runtime/debug"
+	"strconv"
 	"sync"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/east-eden/server/store"
 	"github.com/east-eden/server/utils"
 	"github.com/east-eden/server/utils/cache"
+	"github.com/east-eden/server/utils/limiter"
 	"github.com/hellodudu/task"
 	log "github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v2"
@@ -23,12 +25,15 @@ var (
 	commentCleanupInterval       = 1 * time.Minute // cache cleanup interval
 	commentCacheExpire           = 1 * time.Hour   // cache缓存1小时
 	commentDefaultLoad     int64 = 10              // 默认加载前10条评论
+
+	ErrCommentTooFrequent = errors.New("comment publish too frequent")
 )
 
 type CommentManager struct {
 	r                 *Comment
 	cacheCommentDatas *cache.Cache
 	commentPool       sync.Pool
+	limiter           *limiter.Limiter // 玩家发表评论和回复限流
 	wg                utils.WaitGroupWrapper
 	mu                sync.Mutex
 }
@@ -37,6 +42,10 @@ func NewCommentManager(ctx *cli.Context, r *Comment) *CommentManager {
 	manager := &CommentManager{
 		r:                 r,
 		cacheCommentDatas: cache.New(commentCacheExpire, commentCleanupInterval),
+		limiter: limiter.New(limiter.Options{
+			Service: "comment",
+			Default: limiter.Budget{Rate: ctx.Float64("comment_publish_rate"), Burst: int64(ctx.Int("comment_publish_burst"))},
+		}),
 	}
 
 	// 排行榜池
@@ -54,6 +63,16 @@ func NewCommentManager(ctx *cli.Context, r *Comment) *CommentManager {
 		log.Fatal().Err(err).Msg("migrate collection comment failed")
 	}
 
+	store.GetStore().AddStoreInfo(define.StoreType_CommentMetadata, "comment_metadata", "_id")
+	if err := store.GetStore().MigrateDbTable("comment_metadata", "topic"); err != nil {
+		log.Fatal().Err(err).Msg("migrate collection comment_metadata failed")
+	}
+
+	store.GetStore().AddStoreInfo(define.StoreType_CommentThumbs, "comment_thumbs", "_id")
+	if err := store.GetStore().MigrateDbTable("comment_thumbs", "_id.player_id"); err != nil {
+		log.Fatal().Err(err).Msg("migrate collection comment_thumbs failed")
+	}
+
 	log.Info().Msg("CommentManager init ok ...")
 	return manager
 }
@@ -210,14 +229,23 @@ func (m *CommentManager) AddTask(ctx context.Context, topic define.CommentTopic,
 	return cd.AddTask(ctx, fn, cd)
 }
 
-func (m *CommentManager) QueryCommentTopic(ctx context.Context, topic define.CommentTopic) (metadatas []*define.CommentMetadata, err error) {
+// 玩家发表评论和回复限流
+func (m *CommentManager) allowPublish(publisherId int64) error {
+	err := m.limiter.Allow("player:"+strconv.FormatInt(publisherId, 10), "PublishComment")
+	if err != nil {
+		return ErrCommentTooFrequent
+	}
+	return nil
+}
+
+func (m *CommentManager) QueryCommentTopic(ctx context.Context, topic define.CommentTopic, moderator bool, viewerId int64) (metadatas []*define.CommentMetadata, err error) {
 	err = m.AddTask(
 		ctx,
 		topic,
 		func(c context.Context, p ...any) error {
 			var e error
 			ctd := p[0].(*CommentTopicData)
-			metadatas, e = ctd.GetCommentByRange(c, 0, commentDefaultLoad)
+			metadatas, e = ctd.GetCommentByRange(c, 0, commentDefaultLoad-1, moderator, viewerId)
 			return e
 		},
 	)
@@ -226,14 +254,14 @@ func (m *CommentManager) QueryCommentTopic(ctx context.Context, topic define.Com
 	return
 }
 
-func (m *CommentManager) QueryCommentTopicRange(ctx context.Context, topic define.CommentTopic, start, end int64) (metadatas []*define.CommentMetadata, err error) {
+func (m *CommentManager) QueryCommentTopicRange(ctx context.Context, topic define.CommentTopic, start, end int64, moderator bool, viewerId int64) (metadatas []*define.CommentMetadata, err error) {
 	err = m.AddTask(
 		ctx,
 		topic,
 		func(c context.Context, p ...any) error {
 			var e error
 			ctd := p[0].(*CommentTopicData)
-			metadatas, e = ctd.GetCommentByRange(c, start, end)
+			metadatas, e = ctd.GetCommentByRange(c, start, end, moderator, viewerId)
 			return e
 		},
 	)
@@ -242,16 +270,88 @@ func (m *CommentManager) QueryCommentTopicRange(ctx context.Context, topic defin
 	return
 }
 
-func (m *CommentManager) ModCommentThumbs(ctx context.Context, topic define.CommentTopic, commentId int64, modThumbs int32) error {
+func (m *CommentManager) PublishComment(ctx context.Context, topic define.CommentTopic, pm *define.PublisherMetadata) (metadata *define.CommentMetadata, err error) {
+	if err = m.allowPublish(pm.PublisherId); err != nil {
+		return
+	}
+
+	err = m.AddTask(
+		ctx,
+		topic,
+		func(c context.Context, p ...any) error {
+			var e error
+			ctd := p[0].(*CommentTopicData)
+			metadata, e = ctd.Publish(c, pm)
+			return e
+		},
+	)
+
+	_ = utils.ErrCheck(err, "AddTask failed when CommentManager.PublishComment", topic, pm.PublisherId)
+	return
+}
+
+func (m *CommentManager) ReplyComment(ctx context.Context, topic define.CommentTopic, commentId int64, pm *define.PublisherMetadata) (reply *define.ReplyerMetadata, err error) {
+	if err = m.allowPublish(pm.PublisherId); err != nil {
+		return
+	}
+
+	err = m.AddTask(
+		ctx,
+		topic,
+		func(c context.Context, p ...any) error {
+			var e error
+			ctd := p[0].(*CommentTopicData)
+			reply, e = ctd.Reply(c, commentId, pm)
+			return e
+		},
+	)
+
+	_ = utils.ErrCheck(err, "AddTask failed when CommentManager.ReplyComment", topic, commentId, pm.PublisherId)
+	return
+}
+
+func (m *CommentManager) DeleteComment(ctx context.Context, topic define.CommentTopic, commentId, replyId, operatorId int64, moderator bool) error {
 	err := m.AddTask(
 		ctx,
 		topic,
 		func(c context.Context, p ...any) error {
 			ctd := p[0].(*CommentTopicData)
-			return ctd.ModThumbs(ctx, commentId, modThumbs)
+			return ctd.Delete(c, commentId, replyId, operatorId, moderator)
 		},
 	)
 
-	_ = utils.ErrCheck(err, "AddTask failed when CommentManager.ModCommentThumbs", topic, commentId, modThumbs)
+	_ = utils.ErrCheck(err, "AddTask failed when CommentManager.DeleteComment", topic, commentId, replyId, operatorId, moderator)
 	return err
 }
+
+func (m *CommentManager) HideComment(ctx context.Context, topic define.CommentTopic, commentId, replyId int64, hide bool) (status int32, err error) {
+	err = m.AddTask(
+		ctx,
+		topic,
+		func(c context.Context, p ...any) error {
+			var e error
+			ctd := p[0].(*CommentTopicData)
+			status, e = ctd.Hide(c, commentId, replyId, hide)
+			return e
+		},
+	)
+
+	_ = utils.ErrCheck(err, "AddTask failed when CommentManager.HideComment", topic, commentId, replyId, hide)
+	return
+}
+
+func (m *CommentManager) ThumbsComment(ctx context.Context, topic define.CommentTopic, commentId, playerId int64, like bool) (thumbs int32, err error) {
+	err = m.AddTask(
+		ctx,
+		topic,
+		func(c context.Context, p ...any) error {
+			var e error
+			ctd := p[0].(*CommentTopicData)
+			thumbs, e = ctd.Thumbs(c, commentId, playerId, like)
+			return e
+		},
+	)
+
+	_ = utils.ErrCheck(err, "AddTask failed when CommentManager.ThumbsComment", topic, commentId, playerId, like)
+	return
+}
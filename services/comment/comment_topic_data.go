@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+	"unicode/utf8"
 
 	"github.com/east-eden/server/define"
 	"github.com/east-eden/server/store"
 	"github.com/east-eden/server/utils"
+	"github.com/east-eden/server/utils/bloom"
+	"github.com/east-eden/server/utils/sensitive"
 	"github.com/east-eden/server/utils/zset"
 	"github.com/hellodudu/task"
 	"github.com/rs/zerolog/log"
@@ -20,6 +23,11 @@ var (
 	ErrInvalidCommentStatus   = errors.New("invalid comment status")
 	ErrCommentNotExist        = errors.New("comment not exist")
 	ErrAddExistComment        = errors.New("add exist comment")
+	ErrCommentSensitive       = errors.New("comment content contains sensitive words")
+	ErrInvalidCommentContent  = errors.New("invalid comment content")
+	ErrCommentReplyFull       = errors.New("comment reply full")
+	ErrCommentReplyToNotExist = errors.New("comment reply to player not exist")
+	ErrCommentPermission      = errors.New("comment permission denied")
 
 	CommentDataTaskTimeout          = time.Hour       // 评论任务超时
 	CommentDataChannelResultTimeout = 5 * time.Second // 评论channel处理超时
@@ -30,7 +38,7 @@ type CommentTopicData struct {
 	define.CommentTopic `json:"_id" bson:"_id"` // 评论话题
 	LastSaveNodeId      int32                   `json:"last_save_node_id" bson:"last_save_node_id"`
 	NodeId              int16                   `json:"-" bson:"-"` // 当前节点id
-	zsets               *zset.SortedSet         `json:"-" bson:"-"` // 评论按赞排行
+	zsets               *zset.SortedSet         `json:"-" bson:"-"` // 评论按热度排行, 包括已删除和隐藏的评论
 	tasker              *task.Tasker            `json:"-" bson:"-"`
 	rpcHandler          *RpcHandler             `json:"-" bson:"-"`
 }
@@ -39,9 +47,38 @@ func NewCommentData() any {
 	return &CommentTopicData{}
 }
 
+// 检查发表内容长度
+func checkPublisherMetadata(pm *define.PublisherMetadata) error {
+	if pm == nil || pm.PublisherId <= 0 {
+		return ErrInvalidCommentMetadata
+	}
+
+	if n := utf8.RuneCountInString(pm.Content); n == 0 || n > define.Comment_ContentMaxLen {
+		return ErrInvalidCommentContent
+	}
+
+	return nil
+}
+
+// 过滤发表内容, 屏蔽字替换为*, 评论没有仅自己可见的处理, 命中拒绝或仅自己可见的屏蔽字时不允许发表
+func filterPublisherMetadata(pm *define.PublisherMetadata) error {
+	content, action, hit := sensitive.Filter(pm.Content)
+	if !hit {
+		return nil
+	}
+
+	if action != define.SensitiveAction_Mask {
+		return ErrCommentSensitive
+	}
+
+	pm.Content = content
+	return nil
+}
+
 func (c *CommentTopicData) Init(nodeId int16, rpcHandler *RpcHandler) {
 	c.LastSaveNodeId = -1
 	c.NodeId = nodeId
+	c.zsets = zset.New()
 	c.rpcHandler = rpcHandler
 }
 
@@ -75,7 +112,7 @@ func (c *CommentTopicData) Load(topic define.CommentTopic) error {
 	}
 
 	// 加载评论数据
-	res, err := store.GetStore().FindAll(context.Background(), define.StoreType_Rank, "topic", topic)
+	res, err := store.GetStore().FindAll(context.Background(), define.StoreType_CommentMetadata, "topic", topic)
 	if !utils.ErrCheck(err, "FindAll failed when CommentTopicData.Load", topic) {
 		return err
 	}
@@ -88,8 +125,12 @@ func (c *CommentTopicData) Load(topic define.CommentTopic) error {
 			continue
 		}
 
+		if metadata.PublisherMetadata == nil {
+			continue
+		}
+
 		c.zsets.Set(
-			float64(metadata.PublisherMetadata.Thumbs),
+			metadata.HotScore(),
 			metadata.CommentId,
 			int64(metadata.PublisherMetadata.Date),
 			metadata,
@@ -123,50 +164,428 @@ func (c *CommentTopicData) AddTask(ctx context.Context, fn task.TaskHandler, p .
 	return c.tasker.AddWait(ctx, fn, p...)
 }
 
-func (c *CommentTopicData) ModThumbs(ctx context.Context, commentId int64, modThumbs int32) error {
-	if commentId == -1 {
-		return ErrInvalidCommentMetadata
+// 保存评论数据
+func (c *CommentTopicData) saveMetadata(ctx context.Context, cm *define.CommentMetadata) error {
+	err := store.GetStore().UpdateOne(ctx, define.StoreType_CommentMetadata, cm.CommentId, cm)
+	if !utils.ErrCheck(err, "UpdateOne failed when CommentTopicData.saveMetadata", cm.CommentId) {
+		return err
 	}
 
+	c.saveLastNode()
+	return nil
+}
+
+// 获取评论, 非管理员只能获取正常状态的评论
+func (c *CommentTopicData) getMetadata(commentId int64, moderator bool) (*define.CommentMetadata, error) {
 	data, ok := c.zsets.GetData(commentId)
 	if !ok {
-		return ErrInvalidCommentMetadata
+		return nil, ErrCommentNotExist
 	}
 
-	cm := &define.CommentMetadata{}
-	*cm = *data.(*define.CommentMetadata)
-	cm.PublisherMetadata.Thumbs *= -1
-	cm.PublisherMetadata.Thumbs += modThumbs
+	cm := data.(*define.CommentMetadata)
+	if !moderator && cm.Status != define.CommentStatus_Normal {
+		return nil, ErrCommentNotExist
+	}
 
-	c.zsets.IncrBy(float64(cm.PublisherMetadata.Thumbs), cm.CommentId, int64(cm.PublisherMetadata.Date))
+	return cm, nil
+}
 
-	// save comment metadata
-	err := store.GetStore().UpdateOne(ctx, define.StoreType_Comment, cm.CommentId, cm)
-	_ = utils.ErrCheck(err, "UpdateOne failed when CommentTopicData.ModThumbs", cm)
+// 发表评论
+func (c *CommentTopicData) Publish(ctx context.Context, pm *define.PublisherMetadata) (*define.CommentMetadata, error) {
+	if err := checkPublisherMetadata(pm); err != nil {
+		return nil, err
+	}
 
-	c.saveLastNode()
-	return err
+	if err := filterPublisherMetadata(pm); err != nil {
+		return nil, err
+	}
+
+	id, err := utils.NextID(define.SnowFlake_Comment)
+	if !utils.ErrCheck(err, "NextID failed when CommentTopicData.Publish", c.CommentTopic) {
+		return nil, err
+	}
+
+	cm := &define.CommentMetadata{
+		CommentId:         id,
+		Topic:             c.CommentTopic,
+		PublisherMetadata: pm,
+		ReplyerMetadatas:  make([]*define.ReplyerMetadata, 0),
+		Status:            define.CommentStatus_Normal,
+	}
+
+	pm.ReplyToId = 0
+	pm.ReplyToName = ""
+	pm.Thumbs = 0
+	pm.Date = int32(time.Now().Unix())
+
+	c.zsets.Set(cm.HotScore(), cm.CommentId, int64(pm.Date), cm)
+	if err := c.saveMetadata(ctx, cm); err != nil {
+		return nil, err
+	}
+
+	return cm.Clone(false), nil
 }
 
-func (c *CommentTopicData) GetCommentById(ctx context.Context, commentId int64) (rank int64, metadata define.CommentMetadata, err error) {
-	zRank, _, data := c.zsets.GetRank(commentId, false)
-	rank = zRank
-	if data == nil {
-		err = ErrCommentNotExist
+// 回复评论, 回复保存在被回复的评论下, ReplyToId为0时回复评论发表者
+func (c *CommentTopicData) Reply(ctx context.Context, commentId int64, pm *define.PublisherMetadata) (*define.ReplyerMetadata, error) {
+	cm, err := c.getMetadata(commentId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cm.ReplyerMetadatas) >= define.Comment_ReplyMaxNum {
+		return nil, ErrCommentReplyFull
+	}
+
+	if err := checkPublisherMetadata(pm); err != nil {
+		return nil, err
+	}
+
+	// 只能回复评论发表者或者此条评论下的回复者
+	if pm.ReplyToId == 0 || pm.ReplyToId == cm.PublisherMetadata.PublisherId {
+		pm.ReplyToId = cm.PublisherMetadata.PublisherId
+		pm.ReplyToName = cm.PublisherMetadata.PublisherName
+	} else {
+		pm.ReplyToName = ""
+		for _, r := range cm.ReplyerMetadatas {
+			if r.Status == define.CommentStatus_Normal && r.PublisherId == pm.ReplyToId {
+				pm.ReplyToName = r.PublisherName
+				break
+			}
+		}
+
+		if pm.ReplyToName == "" {
+			return nil, ErrCommentReplyToNotExist
+		}
+	}
+
+	if err := filterPublisherMetadata(pm); err != nil {
+		return nil, err
+	}
+
+	id, err := utils.NextID(define.SnowFlake_Comment)
+	if !utils.ErrCheck(err, "NextID failed when CommentTopicData.Reply", c.CommentTopic, commentId) {
+		return nil, err
+	}
+
+	reply := &define.ReplyerMetadata{
+		CommentId:         id,
+		PublisherMetadata: *pm,
+		Status:            define.CommentStatus_Normal,
+	}
+	reply.Thumbs = 0
+	reply.Date = int32(time.Now().Unix())
+
+	cm.ReplyerMetadatas = append(cm.ReplyerMetadatas, reply)
+	if err := c.saveMetadata(ctx, cm); err != nil {
+		return nil, err
+	}
+
+	r := *reply
+	return &r, nil
+}
+
+// 删除评论或回复, 只标记删除状态, 管理员仍然可见. 非管理员只能删除自己发表的评论或回复
+func (c *CommentTopicData) Delete(ctx context.Context, commentId int64, replyId int64, operatorId int64, moderator bool) error {
+	cm, err := c.getMetadata(commentId, moderator)
+	if err != nil {
+		return err
+	}
+
+	// 删除评论
+	if replyId == 0 {
+		if !moderator && cm.PublisherMetadata.PublisherId != operatorId {
+			return ErrCommentPermission
+		}
+
+		cm.Status = define.CommentStatus_Deleted
+		return c.saveMetadata(ctx, cm)
+	}
+
+	// 删除回复
+	reply := cm.GetReply(replyId)
+	if reply == nil || (!moderator && reply.Status != define.CommentStatus_Normal) {
+		return ErrCommentNotExist
+	}
+
+	if !moderator && reply.PublisherId != operatorId {
+		return ErrCommentPermission
+	}
+
+	reply.Status = define.CommentStatus_Deleted
+	return c.saveMetadata(ctx, cm)
+}
+
+// 管理员隐藏或恢复评论和回复, 已删除的评论不能恢复
+func (c *CommentTopicData) Hide(ctx context.Context, commentId int64, replyId int64, hide bool) (int32, error) {
+	cm, err := c.getMetadata(commentId, true)
+	if err != nil {
+		return define.CommentStatus_Normal, err
+	}
+
+	status := &cm.Status
+	if replyId != 0 {
+		reply := cm.GetReply(replyId)
+		if reply == nil {
+			return define.CommentStatus_Normal, ErrCommentNotExist
+		}
+		status = &reply.Status
+	}
+
+	if *status == define.CommentStatus_Deleted {
+		return *status, ErrInvalidCommentStatus
+	}
+
+	if hide {
+		*status = define.CommentStatus_Hidden
+	} else {
+		*status = define.CommentStatus_Normal
+	}
+
+	return *status, c.saveMetadata(ctx, cm)
+}
+
+// 点赞玩家布隆过滤器
+func thumbsFilter(cm *define.CommentMetadata) *bloom.Filter {
+	words := make([]uint64, define.Comment_ThumbsFilterBits/64)
+	for n := 0; n < len(cm.ThumbsFilter) && n < len(words); n++ {
+		words[n] = uint64(cm.ThumbsFilter[n])
+	}
+	return bloom.From(words, define.Comment_ThumbsFilterHashes)
+}
+
+func saveThumbsFilter(cm *define.CommentMetadata, f *bloom.Filter) {
+	words := f.Words()
+	cm.ThumbsFilter = make([]int64, len(words))
+	for n := range words {
+		cm.ThumbsFilter[n] = int64(words[n])
+	}
+}
+
+// 最近点赞记录或布隆过滤器能确定玩家是否点过赞时, 不需要查询点赞记录
+func checkRecentThumbs(cm *define.CommentMetadata, playerId int64) (liked bool, ok bool) {
+	for n := len(cm.RecentThumbs) - 1; n >= 0; n-- {
+		if cm.RecentThumbs[n].PlayerId == playerId {
+			return cm.RecentThumbs[n].Liked, true
+		}
+	}
+
+	if !thumbsFilter(cm).Test(uint64(playerId)) {
+		return false, true
+	}
+
+	return false, false
+}
+
+// 玩家是否点过赞: 先查最近点赞记录, 布隆过滤器未命中时一定没有点过赞, 否则查询点赞记录
+func (c *CommentTopicData) isLiked(ctx context.Context, cm *define.CommentMetadata, playerId int64) (bool, error) {
+	if liked, ok := checkRecentThumbs(cm, playerId); ok {
+		return liked, nil
+	}
+
+	key := define.CommentThumbsKey{CommentId: cm.CommentId, PlayerId: playerId}
+	err := store.GetStore().FindOne(ctx, define.StoreType_CommentThumbs, key, &define.CommentThumbs{})
+	if errors.Is(err, store.ErrNoResult) {
+		return false, nil
+	}
+
+	if !utils.ErrCheck(err, "FindOne failed when CommentTopicData.isLiked", key) {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// 查询者点过赞的评论, 分页查询时不逐条查询点赞记录, 第一次需要时按player_id一次性加载
+type viewerThumbs struct {
+	viewerId int64
+	comments map[int64]struct{}
+}
+
+func (v *viewerThumbs) isLiked(ctx context.Context, cm *define.CommentMetadata) (bool, error) {
+	if liked, ok := checkRecentThumbs(cm, v.viewerId); ok {
+		return liked, nil
+	}
+
+	if v.comments == nil {
+		res, err := store.GetStore().FindAll(ctx, define.StoreType_CommentThumbs, "_id.player_id", v.viewerId)
+		if err != nil && !errors.Is(err, store.ErrNoResult) {
+			return false, err
+		}
+
+		v.comments = make(map[int64]struct{}, len(res))
+		for _, data := range res {
+			thumbs := &define.CommentThumbs{}
+			err := json.Unmarshal(data.([]byte), thumbs)
+			if !utils.ErrCheck(err, "json.Unmarshal failed when viewerThumbs.isLiked", v.viewerId) {
+				continue
+			}
+
+			v.comments[thumbs.CommentId] = struct{}{}
+		}
+	}
+
+	_, ok := v.comments[cm.CommentId]
+	return ok, nil
+}
+
+// 记录最近点赞, 每个玩家只保留最后一次操作
+func pushRecentThumbs(cm *define.CommentMetadata, playerId int64, liked bool, now int32) {
+	records := cm.RecentThumbs[:0]
+	for _, r := range cm.RecentThumbs {
+		if r.PlayerId != playerId {
+			records = append(records, r)
+		}
+	}
+
+	records = append(records, &define.CommentThumbsRecord{PlayerId: playerId, Liked: liked, Date: now})
+	if len(records) > define.Comment_ThumbsRecentNum {
+		records = records[len(records)-define.Comment_ThumbsRecentNum:]
+	}
+	cm.RecentThumbs = records
+}
+
+// 统计时间内点赞数异常增长时标记给管理员, 每个统计时间内只标记一次
+func (c *CommentTopicData) auditThumbsSpike(cm *define.CommentMetadata, now int32) {
+	if now-cm.SpikeFlagTime < define.Comment_ThumbsSpikeWindow {
+		return
+	}
+
+	var num int32
+	for _, r := range cm.RecentThumbs {
+		if r.Liked && now-r.Date < define.Comment_ThumbsSpikeWindow {
+			num++
+		}
+	}
+
+	if num < define.Comment_ThumbsSpikeNum {
 		return
 	}
 
-	metadata = *data.(*define.CommentMetadata)
-	metadata.PublisherMetadata.Thumbs *= -1
+	cm.SpikeFlagTime = now
+	cm.SpikeThumbs = num
+	log.Warn().
+		Caller().
+		Interface("topic", c.CommentTopic).
+		Int64("comment_id", cm.CommentId).
+		Int32("spike_thumbs", num).
+		Int32("thumbs", cm.PublisherMetadata.Thumbs).
+		Msg("comment thumbs spike flagged")
+}
+
+// 点赞或取消点赞, 重复操作不会改变点赞数
+func (c *CommentTopicData) Thumbs(ctx context.Context, commentId int64, playerId int64, like bool) (int32, error) {
+	if playerId <= 0 {
+		return 0, ErrInvalidCommentMetadata
+	}
+
+	cm, err := c.getMetadata(commentId, false)
+	if err != nil {
+		return 0, err
+	}
+
+	liked, err := c.isLiked(ctx, cm, playerId)
+	if err != nil {
+		return cm.PublisherMetadata.Thumbs, err
+	}
+
+	if liked == like {
+		return cm.PublisherMetadata.Thumbs, nil
+	}
+
+	now := int32(time.Now().Unix())
+	key := define.CommentThumbsKey{CommentId: cm.CommentId, PlayerId: playerId}
+	if like {
+		err = store.GetStore().UpdateOne(ctx, define.StoreType_CommentThumbs, key, &define.CommentThumbs{CommentThumbsKey: key, Date: now})
+		if !utils.ErrCheck(err, "UpdateOne failed when CommentTopicData.Thumbs", key) {
+			return cm.PublisherMetadata.Thumbs, err
+		}
+
+		f := thumbsFilter(cm)
+		f.Add(uint64(playerId))
+		saveThumbsFilter(cm, f)
+		cm.PublisherMetadata.Thumbs++
+	} else {
+		err = store.GetStore().DeleteOne(ctx, define.StoreType_CommentThumbs, key)
+		if !utils.ErrCheck(err, "DeleteOne failed when CommentTopicData.Thumbs", key) {
+			return cm.PublisherMetadata.Thumbs, err
+		}
+
+		if cm.PublisherMetadata.Thumbs > 0 {
+			cm.PublisherMetadata.Thumbs--
+		}
+	}
+
+	pushRecentThumbs(cm, playerId, like, now)
+	if like {
+		c.auditThumbsSpike(cm, now)
+	}
+
+	c.zsets.Set(cm.HotScore(), cm.CommentId, int64(cm.PublisherMetadata.Date), cm)
+	return cm.PublisherMetadata.Thumbs, c.saveMetadata(ctx, cm)
+}
+
+// 复制评论数据并填充查询者是否点过赞
+func (c *CommentTopicData) cloneForViewer(ctx context.Context, cm *define.CommentMetadata, moderator bool, viewer *viewerThumbs) *define.CommentMetadata {
+	clone := cm.Clone(moderator)
+	if viewer != nil {
+		liked, err := viewer.isLiked(ctx, cm)
+		utils.ErrPrint(err, "isLiked failed when CommentTopicData.cloneForViewer", cm.CommentId, viewer.viewerId)
+		clone.Liked = liked
+	}
+	return clone
+}
+
+func (c *CommentTopicData) GetCommentById(ctx context.Context, commentId int64, moderator bool, viewerId int64) (rank int64, metadata *define.CommentMetadata, err error) {
+	cm, err := c.getMetadata(commentId, moderator)
+	if err != nil {
+		return -1, nil, err
+	}
 
+	rank, _, _ = c.zsets.GetRank(commentId, true)
+	metadata = cm.Clone(moderator)
+	if viewerId > 0 {
+		liked, err := c.isLiked(ctx, cm, viewerId)
+		utils.ErrPrint(err, "isLiked failed when CommentTopicData.GetCommentById", cm.CommentId, viewerId)
+		metadata.Liked = liked
+	}
 	return rank, metadata, nil
 }
 
-func (c *CommentTopicData) GetCommentByRange(ctx context.Context, start, end int64) (metadatas []*define.CommentMetadata, err error) {
-	c.zsets.Range(start, end, func(score float64, key int64, data any) {
-		cm := *data.(*define.CommentMetadata)
-		cm.PublisherMetadata.Thumbs *= -1
-		metadatas = append(metadatas, &cm)
-	})
+// 按热度从高到低获取评论, end为-1时获取所有评论. 非管理员跳过已删除和隐藏的评论
+func (c *CommentTopicData) GetCommentByRange(ctx context.Context, start, end int64, moderator bool, viewerId int64) (metadatas []*define.CommentMetadata, err error) {
+	var viewer *viewerThumbs
+	if viewerId > 0 {
+		viewer = &viewerThumbs{viewerId: viewerId}
+	}
+
+	if moderator {
+		c.zsets.RevRange(start, end, func(score float64, key int64, data any) {
+			metadatas = append(metadatas, c.cloneForViewer(ctx, data.(*define.CommentMetadata), moderator, viewer))
+		})
+		return
+	}
+
+	var idx int64
+	for rank := int64(0); rank < c.zsets.Length(); rank++ {
+		if end >= 0 && idx > end {
+			break
+		}
+
+		_, _, data := c.zsets.GetDataByRank(rank, true)
+		if data == nil {
+			break
+		}
+
+		cm := data.(*define.CommentMetadata)
+		if cm.Status != define.CommentStatus_Normal {
+			continue
+		}
+
+		if idx >= start {
+			metadatas = append(metadatas, c.cloneForViewer(ctx, cm, moderator, viewer))
+		}
+		idx++
+	}
 	return
 }
@@ -0,0 +1,358 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/store"
+	"github.com/east-eden/server/utils/limiter"
+)
+
+var (
+	commentId      int16 = 401
+	commentManager *CommentManager
+)
+
+func init() {
+	ctx := store.NewTestMemStore("comment_test", commentId)
+	commentManager = NewCommentManager(ctx, &Comment{ID: commentId})
+}
+
+func newTestTopicData(t *testing.T, topic define.CommentTopic) *CommentTopicData {
+	cd := NewCommentData().(*CommentTopicData)
+	cd.Init(commentId, nil)
+	if err := cd.Load(topic); err != nil {
+		t.Fatalf("load topic %v failed: %v", topic, err)
+	}
+	return cd
+}
+
+func newTestPublisher(id int64, content string) *define.PublisherMetadata {
+	return &define.PublisherMetadata{
+		PublisherId:   id,
+		PublisherName: "player",
+		Content:       content,
+	}
+}
+
+func TestCommentPublishAndReload(t *testing.T) {
+	ctx := context.Background()
+	topic := define.CommentTopic{Type: define.TopicType_Hero, TypeId: 1}
+	cd := newTestTopicData(t, topic)
+
+	if _, err := cd.Publish(ctx, newTestPublisher(1, "")); !errors.Is(err, ErrInvalidCommentContent) {
+		t.Fatalf("publish empty content should fail, got %v", err)
+	}
+
+	first, err := cd.Publish(ctx, newTestPublisher(1, "first"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := cd.Publish(ctx, newTestPublisher(2, "second"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.CommentId == second.CommentId || !first.Topic.Equal(&topic) {
+		t.Fatalf("invalid published comment: %+v, %+v", first, second)
+	}
+
+	// 点赞数高的排在前面
+	for playerId := int64(1); playerId <= 2; playerId++ {
+		if _, err := cd.Thumbs(ctx, first.CommentId, playerId, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	metadatas, _ := cd.GetCommentByRange(ctx, 0, -1, false, 0)
+	if len(metadatas) != 2 || metadatas[0].CommentId != first.CommentId || metadatas[0].PublisherMetadata.Thumbs != 2 {
+		t.Fatalf("hot order mismatch: %+v", metadatas)
+	}
+
+	// 重新加载话题数据
+	store.GetStore().Flush()
+	reload := newTestTopicData(t, topic)
+	metadatas, _ = reload.GetCommentByRange(ctx, 0, -1, false, 0)
+	if len(metadatas) != 2 || metadatas[0].CommentId != first.CommentId || metadatas[1].CommentId != second.CommentId {
+		t.Fatalf("reload comments mismatch: %+v", metadatas)
+	}
+}
+
+func TestCommentReply(t *testing.T) {
+	ctx := context.Background()
+	cd := newTestTopicData(t, define.CommentTopic{Type: define.TopicType_Hero, TypeId: 2})
+
+	cm, err := cd.Publish(ctx, newTestPublisher(1, "comment"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 默认回复评论发表者
+	reply, err := cd.Reply(ctx, cm.CommentId, newTestPublisher(2, "reply"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reply.ReplyToId != 1 || reply.CommentId == cm.CommentId {
+		t.Fatalf("invalid reply: %+v", reply)
+	}
+
+	// 回复此条评论下的回复者
+	pm := newTestPublisher(3, "reply to 2")
+	pm.ReplyToId = 2
+	if _, err := cd.Reply(ctx, cm.CommentId, pm); err != nil {
+		t.Fatal(err)
+	}
+
+	pm = newTestPublisher(3, "reply to nobody")
+	pm.ReplyToId = 4
+	if _, err := cd.Reply(ctx, cm.CommentId, pm); !errors.Is(err, ErrCommentReplyToNotExist) {
+		t.Fatalf("reply to not exist player should fail, got %v", err)
+	}
+
+	_, metadata, err := cd.GetCommentById(ctx, cm.CommentId, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(metadata.ReplyerMetadatas) != 2 || metadata.ReplyerMetadatas[1].ReplyToId != 2 {
+		t.Fatalf("replies mismatch: %+v", metadata.ReplyerMetadatas)
+	}
+}
+
+func TestCommentDeleteAndHide(t *testing.T) {
+	ctx := context.Background()
+	cd := newTestTopicData(t, define.CommentTopic{Type: define.TopicType_Item, TypeId: 1})
+
+	cm, err := cd.Publish(ctx, newTestPublisher(1, "comment"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := cd.Reply(ctx, cm.CommentId, newTestPublisher(2, "reply"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 只能删除自己的回复
+	if err := cd.Delete(ctx, cm.CommentId, reply.CommentId, 1, false); !errors.Is(err, ErrCommentPermission) {
+		t.Fatalf("delete other's reply should fail, got %v", err)
+	}
+
+	if err := cd.Delete(ctx, cm.CommentId, reply.CommentId, 2, false); err != nil {
+		t.Fatal(err)
+	}
+
+	_, metadata, _ := cd.GetCommentById(ctx, cm.CommentId, false, 0)
+	if len(metadata.ReplyerMetadatas) != 0 {
+		t.Fatalf("deleted reply should be invisible: %+v", metadata.ReplyerMetadatas)
+	}
+
+	_, metadata, _ = cd.GetCommentById(ctx, cm.CommentId, true, 0)
+	if len(metadata.ReplyerMetadatas) != 1 || metadata.ReplyerMetadatas[0].Status != define.CommentStatus_Deleted {
+		t.Fatalf("moderator should see deleted reply: %+v", metadata.ReplyerMetadatas)
+	}
+
+	// 隐藏评论后仅管理员可见
+	status, err := cd.Hide(ctx, cm.CommentId, 0, true)
+	if err != nil || status != define.CommentStatus_Hidden {
+		t.Fatalf("hide comment failed: status %d, err %v", status, err)
+	}
+
+	if metadatas, _ := cd.GetCommentByRange(ctx, 0, -1, false, 0); len(metadatas) != 0 {
+		t.Fatalf("hidden comment should be invisible: %+v", metadatas)
+	}
+
+	if metadatas, _ := cd.GetCommentByRange(ctx, 0, -1, true, 0); len(metadatas) != 1 {
+		t.Fatalf("moderator should see hidden comment: %+v", metadatas)
+	}
+
+	if _, err := cd.Reply(ctx, cm.CommentId, newTestPublisher(2, "reply")); !errors.Is(err, ErrCommentNotExist) {
+		t.Fatalf("reply hidden comment should fail, got %v", err)
+	}
+
+	if _, err := cd.Hide(ctx, cm.CommentId, 0, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// 删除后不能恢复
+	if err := cd.Delete(ctx, cm.CommentId, 0, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cd.Hide(ctx, cm.CommentId, 0, false); !errors.Is(err, ErrInvalidCommentStatus) {
+		t.Fatalf("restore deleted comment should fail, got %v", err)
+	}
+}
+
+func TestCommentPublishLimit(t *testing.T) {
+	ctx := context.Background()
+	topic := define.CommentTopic{Type: define.TopicType_Item, TypeId: 2}
+
+	l := commentManager.limiter
+	defer func() { commentManager.limiter = l }()
+	commentManager.limiter = limiter.New(limiter.Options{
+		Service: "comment_test",
+		Default: limiter.Budget{Rate: 0.001, Burst: 1},
+	})
+
+	cm, err := commentManager.PublishComment(ctx, topic, newTestPublisher(1, "comment"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := commentManager.ReplyComment(ctx, topic, cm.CommentId, newTestPublisher(1, "reply")); !errors.Is(err, ErrCommentTooFrequent) {
+		t.Fatalf("reply should be limited, got %v", err)
+	}
+
+	// 其他玩家不受影响
+	if _, err := commentManager.ReplyComment(ctx, topic, cm.CommentId, newTestPublisher(2, "reply")); err != nil {
+		t.Fatal(err)
+	}
+
+	metadatas, err := commentManager.QueryCommentTopic(ctx, topic, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(metadatas) != 1 || len(metadatas[0].ReplyerMetadatas) != 1 {
+		t.Fatalf("query comments mismatch: %+v", metadatas)
+	}
+}
+
+func TestCommentThumbs(t *testing.T) {
+	ctx := context.Background()
+	topic := define.CommentTopic{Type: define.TopicType_Hero, TypeId: 3}
+	cd := newTestTopicData(t, topic)
+
+	cm, err := cd.Publish(ctx, newTestPublisher(1, "comment"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 重复点赞不会增加点赞数
+	for n := 0; n < 3; n++ {
+		thumbs, err := cd.Thumbs(ctx, cm.CommentId, 2, true)
+		if err != nil || thumbs != 1 {
+			t.Fatalf("thumbs %d should be 1, err %v", thumbs, err)
+		}
+	}
+
+	_, metadata, _ := cd.GetCommentById(ctx, cm.CommentId, false, 2)
+	if !metadata.Liked || metadata.PublisherMetadata.Thumbs != 1 {
+		t.Fatalf("player 2 should have liked: %+v", metadata)
+	}
+
+	_, metadata, _ = cd.GetCommentById(ctx, cm.CommentId, false, 3)
+	if metadata.Liked {
+		t.Fatal("player 3 should not have liked")
+	}
+
+	// 重复取消点赞不会减少点赞数
+	for n := 0; n < 2; n++ {
+		thumbs, err := cd.Thumbs(ctx, cm.CommentId, 2, false)
+		if err != nil || thumbs != 0 {
+			t.Fatalf("thumbs %d should be 0, err %v", thumbs, err)
+		}
+	}
+
+	if _, err := cd.Thumbs(ctx, cm.CommentId, 2, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// 最近点赞记录丢失后通过布隆过滤器和点赞记录判断
+	store.GetStore().Flush()
+	reload := newTestTopicData(t, topic)
+	data, _ := reload.zsets.GetData(cm.CommentId)
+	data.(*define.CommentMetadata).RecentThumbs = nil
+
+	liked, err := reload.isLiked(ctx, data.(*define.CommentMetadata), 2)
+	if err != nil || !liked {
+		t.Fatalf("player 2 should have liked after reload, err %v", err)
+	}
+
+	thumbs, err := reload.Thumbs(ctx, cm.CommentId, 2, true)
+	if err != nil || thumbs != 1 {
+		t.Fatalf("thumbs %d should be 1 after reload, err %v", thumbs, err)
+	}
+}
+
+func TestCommentThumbsByRange(t *testing.T) {
+	ctx := context.Background()
+	topic := define.CommentTopic{Type: define.TopicType_Hero, TypeId: 4}
+	cd := newTestTopicData(t, topic)
+
+	liked := make(map[int64]bool)
+	for n := int64(0); n < 4; n++ {
+		cm, err := cd.Publish(ctx, newTestPublisher(10+n, "comment"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n%2 == 0 {
+			if _, err := cd.Thumbs(ctx, cm.CommentId, 2, true); err != nil {
+				t.Fatal(err)
+			}
+			liked[cm.CommentId] = true
+		}
+	}
+
+	// 最近点赞记录丢失后, 分页查询一次加载查询者的所有点赞记录
+	store.GetStore().Flush()
+	reload := newTestTopicData(t, topic)
+	reload.zsets.RevRange(0, -1, func(score float64, key int64, data any) {
+		data.(*define.CommentMetadata).RecentThumbs = nil
+	})
+
+	for _, viewerId := range []int64{2, 3} {
+		metadatas, err := reload.GetCommentByRange(ctx, 0, -1, false, viewerId)
+		if err != nil || len(metadatas) != 4 {
+			t.Fatalf("get %d comments, err %v", len(metadatas), err)
+		}
+
+		for _, metadata := range metadatas {
+			if metadata.Liked != (viewerId == 2 && liked[metadata.CommentId]) {
+				t.Fatalf("player %d comment %d liked %v", viewerId, metadata.CommentId, metadata.Liked)
+			}
+		}
+	}
+}
+
+func TestCommentThumbsSpike(t *testing.T) {
+	ctx := context.Background()
+	cd := newTestTopicData(t, define.CommentTopic{Type: define.TopicType_Item, TypeId: 3})
+
+	cm, err := cd.Publish(ctx, newTestPublisher(1, "comment"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for playerId := int64(1); playerId < define.Comment_ThumbsSpikeNum; playerId++ {
+		if _, err := cd.Thumbs(ctx, cm.CommentId, playerId, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, metadata, _ := cd.GetCommentById(ctx, cm.CommentId, true, 0)
+	if metadata.SpikeFlagTime != 0 {
+		t.Fatalf("comment should not be flagged before spike: %+v", metadata)
+	}
+
+	if _, err := cd.Thumbs(ctx, cm.CommentId, define.Comment_ThumbsSpikeNum, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// 只有管理员能看到异常标记
+	_, metadata, _ = cd.GetCommentById(ctx, cm.CommentId, true, 0)
+	if metadata.SpikeFlagTime == 0 || metadata.SpikeThumbs != define.Comment_ThumbsSpikeNum {
+		t.Fatalf("comment should be flagged after spike: %+v", metadata)
+	}
+
+	_, metadata, _ = cd.GetCommentById(ctx, cm.CommentId, false, 0)
+	if metadata.SpikeFlagTime != 0 || metadata.SpikeThumbs != 0 {
+		t.Fatalf("spike flag should be invisible to players: %+v", metadata)
+	}
+}
@@ -91,7 +91,7 @@ func NewMicroService(ctx *cli.Context, m *Comment) *MicroService {
 	)
 
 	// set environment
-	os.Setenv("MICRO_SERVER_ID", ctx.String("rank_id"))
+	os.Setenv("MICRO_SERVER_ID", ctx.String("comment_id"))
 
 	if ctx.Bool("debug") {
 		os.Setenv("MICRO_REGISTRY", ctx.String("registry_debug"))
@@ -17,13 +17,31 @@ func NewFlags() []cli.Flag {
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "https_listen_addr", Usage: "https listen address"}),
 
 		// db
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "db_driver", Usage: "db driver: mongodb, mysql, sqlite3, memory", Value: "mongodb"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "db_dsn", Usage: "db data source name"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "database", Usage: "database name"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "redis_addr", Usage: "redis address"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "cache_backend", Usage: "cache backend: dummy, redis, sentinel, cluster, miniredis, redigo"}),
+		altsrc.NewStringSliceFlag(&cli.StringSliceFlag{Name: "redis_addrs", Usage: "redis sentinel or cluster addresses"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "redis_master_name", Usage: "redis sentinel master name"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "redis_password", Usage: "redis password"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "redis_db", Usage: "redis database index"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "redis_pool_size", Usage: "redis connection pool size"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "redis_min_idle_conns", Usage: "redis minimum idle connections"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "redis_dial_timeout", Usage: "redis dial timeout"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "redis_read_timeout", Usage: "redis read timeout"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "redis_write_timeout", Usage: "redis write timeout"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "redis_idle_timeout", Usage: "redis idle connection timeout"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "cache_expire", Usage: "cache key expire duration"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "store_journal_dir", Usage: "store write-behind journal directory, each node writes to a sub directory named by node id, journal is disabled if empty"}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{Name: "store_journal_fsync", Usage: "fsync every store journal record"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "store_flush_interval", Usage: "store write-behind flush interval"}),
 
 		// rate limit
 		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "rate_limit_interval", Usage: "rpc server rate limit interval"}),
 		altsrc.NewIntFlag(&cli.IntFlag{Name: "rate_limit_capacity", Usage: "rpc server rate limit capacity"}),
+		altsrc.NewFloat64Flag(&cli.Float64Flag{Name: "comment_publish_rate", Usage: "comment publish and reply tokens filled per second per player"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "comment_publish_burst", Usage: "comment publish and reply burst per player"}),
 
 		// cert
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "cert_path_debug", Usage: "debug tls cert_pem path"}),
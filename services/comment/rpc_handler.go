@@ -105,8 +105,8 @@ func (h *RpcHandler) QueryCommentTopic(
 ) error {
 	var topic define.CommentTopic
 	topic.FromPB(req.GetTopic())
-	metadatas, err := h.m.manager.QueryCommentTopic(ctx, topic)
-	if utils.ErrCheck(err, "QueryCommentTopic failed when RpcHandler.QueryCommentTopic") {
+	metadatas, err := h.m.manager.QueryCommentTopic(ctx, topic, req.GetModerator(), req.GetViewerId())
+	if !utils.ErrCheck(err, "QueryCommentTopic failed when RpcHandler.QueryCommentTopic", topic) {
 		return err
 	}
 
@@ -125,7 +125,10 @@ func (h *RpcHandler) QueryCommentTopicRange(
 ) error {
 	var topic define.CommentTopic
 	topic.FromPB(req.GetTopic())
-	metadatas, err := h.m.manager.QueryCommentTopicRange(ctx, topic, req.GetStart(), req.GetEnd())
+	metadatas, err := h.m.manager.QueryCommentTopicRange(ctx, topic, req.GetStart(), req.GetEnd(), req.GetModerator(), req.GetViewerId())
+	rsp.Topic = req.GetTopic()
+	rsp.Start = req.GetStart()
+	rsp.End = req.GetEnd()
 	rsp.Metadatas = make([]*pbGlobal.CommentMetadata, 0, len(metadatas))
 	for _, metadata := range metadatas {
 		rsp.Metadatas = append(rsp.Metadatas, metadata.ToPB())
@@ -133,16 +136,97 @@ func (h *RpcHandler) QueryCommentTopicRange(
 	return err
 }
 
-// 设置评论赞数
-func (h *RpcHandler) ModCommentThumbs(
+// 点赞或取消点赞
+func (h *RpcHandler) ThumbsComment(
 	ctx context.Context,
-	req *pbComment.ModCommentThumbsRq,
-	rsp *pbComment.ModCommentThumbsRs,
+	req *pbComment.ThumbsCommentRq,
+	rsp *pbComment.ThumbsCommentRs,
 ) error {
 	var topic define.CommentTopic
 	topic.FromPB(req.GetTopic())
-	err := h.m.manager.ModCommentThumbs(ctx, topic, req.GetCommentId(), req.GetModThumbs())
-	rsp.Error = err.Error()
+	thumbs, err := h.m.manager.ThumbsComment(ctx, topic, req.GetCommentId(), req.GetPlayerId(), req.GetLike())
+	if err != nil {
+		return err
+	}
+
+	rsp.CommentId = req.GetCommentId()
+	rsp.Thumbs = thumbs
+	rsp.Liked = req.GetLike()
+	return nil
+}
+
+// 发表评论
+func (h *RpcHandler) PublishComment(
+	ctx context.Context,
+	req *pbComment.PublishCommentRq,
+	rsp *pbComment.PublishCommentRs,
+) error {
+	var topic define.CommentTopic
+	topic.FromPB(req.GetTopic())
+	metadata, err := h.m.manager.PublishComment(ctx, topic, &define.PublisherMetadata{
+		PublisherId:   req.GetPublisher().GetPublisherId(),
+		PublisherName: req.GetPublisher().GetPublisherName(),
+		Content:       req.GetPublisher().GetContent(),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	rsp.Metadata = metadata.ToPB()
+	return nil
+}
+
+// 回复评论
+func (h *RpcHandler) ReplyComment(
+	ctx context.Context,
+	req *pbComment.ReplyCommentRq,
+	rsp *pbComment.ReplyCommentRs,
+) error {
+	var topic define.CommentTopic
+	topic.FromPB(req.GetTopic())
+	reply, err := h.m.manager.ReplyComment(ctx, topic, req.GetCommentId(), &define.PublisherMetadata{
+		PublisherId:   req.GetPublisher().GetPublisherId(),
+		PublisherName: req.GetPublisher().GetPublisherName(),
+		ReplyToId:     req.GetPublisher().GetReplyToId(),
+		Content:       req.GetPublisher().GetContent(),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	rsp.CommentId = req.GetCommentId()
+	rsp.Reply = reply.ToPB()
+	return nil
+}
+
+// 删除评论或回复
+func (h *RpcHandler) DeleteComment(
+	ctx context.Context,
+	req *pbComment.DeleteCommentRq,
+	rsp *pbComment.DeleteCommentRs,
+) error {
+	var topic define.CommentTopic
+	topic.FromPB(req.GetTopic())
+	err := h.m.manager.DeleteComment(ctx, topic, req.GetCommentId(), req.GetReplyId(), req.GetOperatorId(), req.GetModerator())
+	rsp.CommentId = req.GetCommentId()
+	rsp.ReplyId = req.GetReplyId()
+	return err
+}
+
+// 隐藏或恢复评论
+func (h *RpcHandler) HideComment(
+	ctx context.Context,
+	req *pbComment.HideCommentRq,
+	rsp *pbComment.HideCommentRs,
+) error {
+	var topic define.CommentTopic
+	topic.FromPB(req.GetTopic())
+	status, err := h.m.manager.HideComment(ctx, topic, req.GetCommentId(), req.GetReplyId(), req.GetHide())
+	rsp.CommentId = req.GetCommentId()
+	rsp.ReplyId = req.GetReplyId()
+	rsp.Status = status
 	return err
 }
 
@@ -155,6 +239,9 @@ func (h *RpcHandler) KickCommentTopicData(
 	var topic define.CommentTopic
 	topic.FromPB(req.GetTopic())
 	err := h.m.manager.KickCommentTopicData(topic, req.GetCommentTopicNodeId())
-	rsp.Error = err.Error()
+	rsp.Topic = req.GetTopic()
+	if err != nil {
+		rsp.Error = err.Error()
+	}
 	return err
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/east-eden/server/transport"
 	"github.com/east-eden/server/utils"
 	"github.com/east-eden/server/utils/cache"
+	"github.com/east-eden/server/utils/session"
 	"github.com/hellodudu/task"
 	log "github.com/rs/zerolog/log"
 	"google.golang.org/protobuf/proto"
@@ -32,6 +33,7 @@ var (
 	ErrAccountHasNoPlayer    = errors.New("account has no player")
 	ErrAccountNotFound       = errors.New("account not found")
 	ErrAccountTaskNotRunning = errors.New("account task not running")
+	ErrGameDraining          = errors.New("game node is draining")
 	ErrPlayerInfoNotFound    = errors.New("player info not found")
 	ErrPlayerLoadFailed      = errors.New("player load failed")
 )
@@ -49,6 +51,8 @@ type AccountManager struct {
 	wg utils.WaitGroupWrapper
 
 	accountConnectMax int
+	signer            *session.Signer
+	drain             *DrainProgress // 下线迁移进度
 
 	userPool       sync.Pool
 	playerPool     sync.Pool
@@ -66,11 +70,25 @@ func NewAccountManager(ctx *cli.Context, g *Game) *AccountManager {
 		cachePlayerInfos:  cache.New(PlayerInfoCacheExpire, CacheCleanupInterval),
 		mapSocks:          make(map[transport.Socket]int64),
 		accountConnectMax: ctx.Int("account_connect_max"),
+		drain:             &DrainProgress{},
+	}
+
+	// session token signer
+	var err error
+	am.signer, err = session.NewSigner(ctx.String("session_token_secret"), session.DefaultTokenExpire)
+	if err != nil {
+		log.Fatal().Err(err).Msg("game session token signer init failed")
 	}
 
 	// heart beat timeout
 	player.AccountTaskTimeout = ctx.Duration("heart_beat_timeout")
 
+	// replay buffer size
+	player.AccountReplayBufferSize = ctx.Int("account_replay_buffer_size")
+
+	// stage verify client
+	player.StageVerifyClient = ctx.Bool("stage_verify_client")
+
 	// user pool
 	am.userPool.New = NewUser
 
@@ -118,6 +136,8 @@ func NewAccountManager(ctx *cli.Context, g *Game) *AccountManager {
 	store.GetStore().AddStoreInfo(define.StoreType_Token, "player_token", "_id")
 	store.GetStore().AddStoreInfo(define.StoreType_Fragment, "player_fragment", "_id")
 	store.GetStore().AddStoreInfo(define.StoreType_Collection, "player_collection", "_id")
+	store.GetStore().AddStoreInfo(define.StoreType_ArenaDefence, "arena_defence", "_id")
+	store.GetStore().AddStoreInfo(define.StoreType_ArenaLog, "arena_log", "_id")
 
 	// migrate user table
 	if err := store.GetStore().MigrateDbTable("user", "account_id", "player_id"); err != nil {
@@ -159,6 +179,11 @@ func NewAccountManager(ctx *cli.Context, g *Game) *AccountManager {
 		log.Fatal().Err(err).Msg("migrate collection player_collection failed")
 	}
 
+	// migrate arena log table
+	if err := store.GetStore().MigrateDbTable("arena_log", "defence_id"); err != nil {
+		log.Fatal().Err(err).Msg("migrate collection arena_log failed")
+	}
+
 	log.Info().Msg("AccountManager init ok ...")
 	return am
 }
@@ -381,6 +406,7 @@ func (am *AccountManager) startAccountTask(ctx context.Context, sock transport.S
 		am.mapSocks[sock] = acct.GetId()
 		am.Unlock()
 		acct.SetSock(sock)
+		acct.ResetReplay()
 		start()
 	}
 	stopFn := func() {
@@ -425,11 +451,16 @@ func (am *AccountManager) startAccountTask(ctx context.Context, sock transport.S
 	})
 }
 
-func (am *AccountManager) Logon(ctx context.Context, userId int64, newSock transport.Socket) error {
+func (am *AccountManager) Logon(ctx context.Context, userId int64, sessionUserId string, caps uint32, newSock transport.Socket) error {
 	// if accountId == -1 {
 	// 	return errors.New("AccountManager.addAccount failed: account id invalid!")
 	// }
 
+	// 下线迁移中不再接受新的登陆
+	if am.IsDraining() {
+		return ErrGameDraining
+	}
+
 	user, err := am.getUser(userId)
 	if !utils.ErrCheck(err, "getUser failed when AccountManager.Logon", userId) {
 		return err
@@ -439,6 +470,8 @@ func (am *AccountManager) Logon(ctx context.Context, userId int64, newSock trans
 	if ok {
 		// cache exist
 		acct := c.(*player.Account)
+		acct.SetSessionUserId(sessionUserId)
+		acct.SetCapabilities(caps)
 		prevSock := acct.GetSock()
 
 		// connect with new socket
@@ -476,6 +509,8 @@ func (am *AccountManager) Logon(ctx context.Context, userId int64, newSock trans
 			return err
 		}
 
+		acct.SetSessionUserId(sessionUserId)
+		acct.SetCapabilities(caps)
 		am.cacheAccounts.Set(acct.GetId(), acct, AccountCacheExpire)
 
 		// account run
@@ -503,6 +538,73 @@ func (am *AccountManager) Logon(ctx context.Context, userId int64, newSock trans
 	return nil
 }
 
+// 断线重连: 账号task仍在运行时将新连接绑定到账号上, 补发断线期间的消息, 不需要重新加载玩家
+func (am *AccountManager) Resume(ctx context.Context, userId int64, lastSeq uint64, newSock transport.Socket) error {
+	// 下线迁移中的账号需要重新登陆到新节点
+	if am.IsDraining() {
+		return ErrGameDraining
+	}
+
+	user, err := am.getUser(userId)
+	if !utils.ErrCheck(err, "getUser failed when AccountManager.Resume", userId) {
+		return err
+	}
+
+	c, ok := am.cacheAccounts.Get(user.AccountID)
+	if !ok {
+		return ErrAccountNotFound
+	}
+
+	acct := c.(*player.Account)
+	if !acct.IsTaskRunning() {
+		return ErrAccountTaskNotRunning
+	}
+
+	return acct.AddWaitTask(ctx, func(c context.Context, p ...any) error {
+		acct := p[0].(*player.Account)
+		if !acct.CanResume(lastSeq) {
+			return player.ErrAccountReplayLost
+		}
+
+		// 替换连接
+		prevSock := acct.GetSock()
+		am.Lock()
+		delete(am.mapSocks, prevSock)
+		am.mapSocks[newSock] = acct.GetId()
+		am.Unlock()
+		acct.SetSock(newSock)
+
+		if prevSock != nil && prevSock != newSock {
+			prevSock.Close()
+		}
+
+		acct.ResetTimeout()
+
+		log.Info().
+			Caller().
+			Int64("account_id", acct.GetId()).
+			Uint64("last_seq", lastSeq).
+			Str("new_sock_remote", newSock.Remote()).
+			Msg("account resume with new socket")
+
+		return acct.Resume(lastSeq)
+	})
+}
+
+// verify session token signed by gate, token must be issued to this user and this game node
+func (am *AccountManager) VerifySessionToken(userId string, token string) error {
+	t, err := am.signer.Verify(token)
+	if err != nil {
+		return err
+	}
+
+	if t.UserId != userId || t.GameId != am.g.ID {
+		return session.ErrTokenMismatch
+	}
+
+	return nil
+}
+
 func (am *AccountManager) GetAccountIdBySock(sock transport.Socket) (int64, bool) {
 	am.RLock()
 	defer am.RUnlock()
@@ -553,6 +655,10 @@ func (am *AccountManager) AddAccountTask(ctx context.Context, acctId int64, fn t
 		return ErrAccountTaskNotRunning
 	}
 
+	if acct.IsMigrated() {
+		return player.ErrAccountMigrated
+	}
+
 	acct.AddTask(ctx, fn, p...)
 	return nil
 }
@@ -579,9 +685,13 @@ func (am *AccountManager) CreatePlayer(acct *player.Account, name string) (*play
 
 	p := am.playerPool.Get().(*player.Player)
 	p.Init(id)
+	if err := p.SetName(name); err != nil {
+		am.playerPool.Put(p)
+		return nil, err
+	}
+
 	p.AccountID = acct.Id
 	p.SetAccount(acct)
-	p.SetName(name)
 
 	// save handle
 	errHandle := func(f func() error) {
@@ -0,0 +1,174 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/east-eden/server/services/game/player"
+	"github.com/east-eden/server/store"
+	"github.com/east-eden/server/utils"
+	"github.com/east-eden/server/utils/cache"
+	log "github.com/rs/zerolog/log"
+)
+
+// game节点下线迁移:
+// 1. 不再接受新的登陆和断线重连
+// 2. 通知所有gate将本节点从一致性哈希中移除
+// 3. 在每个在线账号的task中保存玩家数据并写入数据库, 向客户端下发新节点地址和session token, 客户端直接重连到新节点
+// 4. 停止账号task并删除缓存, 迁移后本节点不再处理该账号的消息, 也不再保存数据
+
+var (
+	DrainAccountTimeout = 10 * time.Second // 单个账号迁移超时
+
+	ErrDrainStarted     = errors.New("game node drain already started")
+	ErrDrainFlushFailed = errors.New("game node drain flush account data failed")
+)
+
+type DrainProgress struct {
+	draining   int32
+	done       int32
+	total      int32
+	migrated   int32
+	failed     int32
+	startTime  int64
+	finishTime int64
+}
+
+type DrainStatus struct {
+	Draining   bool  `json:"draining"`
+	Done       bool  `json:"done"`
+	Total      int32 `json:"total"`
+	Migrated   int32 `json:"migrated"`
+	Failed     int32 `json:"failed"`
+	StartTime  int64 `json:"start_time"`
+	FinishTime int64 `json:"finish_time"`
+}
+
+func (d *DrainProgress) Status() DrainStatus {
+	return DrainStatus{
+		Draining:   atomic.LoadInt32(&d.draining) == 1,
+		Done:       atomic.LoadInt32(&d.done) == 1,
+		Total:      atomic.LoadInt32(&d.total),
+		Migrated:   atomic.LoadInt32(&d.migrated),
+		Failed:     atomic.LoadInt32(&d.failed),
+		StartTime:  atomic.LoadInt64(&d.startTime),
+		FinishTime: atomic.LoadInt64(&d.finishTime),
+	}
+}
+
+func (am *AccountManager) IsDraining() bool {
+	return atomic.LoadInt32(&am.drain.draining) == 1
+}
+
+func (am *AccountManager) GetDrainStatus() DrainStatus {
+	return am.drain.Status()
+}
+
+// Drain starts migrating all accounts to other game nodes, returns immediately and reports progress by GetDrainStatus
+func (am *AccountManager) Drain(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&am.drain.draining, 0, 1) {
+		return ErrDrainStarted
+	}
+	atomic.StoreInt64(&am.drain.startTime, time.Now().Unix())
+
+	// 通知所有gate移除本节点
+	err := am.g.pubSub.PubGameDrain(ctx)
+	utils.ErrPrint(err, "PubGameDrain failed when AccountManager.Drain", am.g.ID)
+
+	accts := make([]*player.Account, 0, am.cacheAccounts.ItemCount())
+	am.cacheAccounts.Range(func(v any) bool {
+		accts = append(accts, v.(*cache.Item).Object.(*player.Account))
+		return true
+	})
+	atomic.StoreInt32(&am.drain.total, int32(len(accts)))
+
+	log.Info().Int16("game_id", am.g.ID).Int("accounts", len(accts)).Msg("game node start draining")
+
+	am.wg.Wrap(func() {
+		defer utils.CaptureException()
+
+		for _, acct := range accts {
+			if err := am.migrateAccount(ctx, acct); err != nil {
+				atomic.AddInt32(&am.drain.failed, 1)
+				log.Warn().Err(err).Int64("account_id", acct.GetId()).Msg("migrate account failed")
+				continue
+			}
+
+			atomic.AddInt32(&am.drain.migrated, 1)
+		}
+
+		store.GetStore().Flush()
+		atomic.StoreInt64(&am.drain.finishTime, time.Now().Unix())
+		atomic.StoreInt32(&am.drain.done, 1)
+
+		log.Info().Interface("status", am.drain.Status()).Msg("game node draining finished")
+	})
+
+	return nil
+}
+
+func (am *AccountManager) migrateAccount(ctx context.Context, acct *player.Account) error {
+	// 离线账号只需要保存数据
+	if !acct.IsTaskRunning() {
+		if err := saveAccount(acct); err != nil {
+			return err
+		}
+
+		acct.SetMigrated()
+		am.cacheAccounts.Delete(acct.GetId())
+		return nil
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, DrainAccountTimeout)
+	defer cancel()
+
+	err := acct.AddWaitTask(subCtx, func(c context.Context, p ...any) error {
+		acct := p[0].(*player.Account)
+		if err := saveAccount(acct); err != nil {
+			return err
+		}
+
+		// 新节点会从数据库加载, 下发新节点地址之前数据必须已经落地
+		if stats := store.GetStore().Flush(); stats.Retry > 0 {
+			return fmt.Errorf("%w: %d writes are waiting for retry", ErrDrainFlushFailed, stats.Retry)
+		}
+
+		rs, err := am.g.rpcHandler.CallSelectGameNode(acct.GetSessionUserId())
+		if err != nil {
+			return fmt.Errorf("CallSelectGameNode failed: %w", err)
+		}
+
+		// 之后本节点不再处理该账号的消息, 也不再保存数据
+		acct.SetMigrated()
+		acct.SendProtoMessage(&pbGlobal.S2C_AccountRedirect{
+			GameId:        rs.GetGameId(),
+			PublicTcpAddr: rs.GetPublicTcpAddr(),
+			PublicWsAddr:  rs.GetPublicWsAddr(),
+			SessionToken:  rs.GetSessionToken(),
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// 停止账号task并删除缓存, 不能在账号task中调用
+	am.cacheAccounts.Delete(acct.GetId())
+	return nil
+}
+
+func saveAccount(acct *player.Account) error {
+	acct.SaveAccount()
+
+	if p := acct.GetPlayer(); p != nil {
+		return p.Save()
+	}
+
+	return nil
+}
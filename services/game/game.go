@@ -113,7 +113,7 @@ func (g *Game) Action(ctx *cli.Context) error {
 
 	g.ID = int16(ctx.Int("game_id"))
 
-	store.NewStore(ctx)
+	store.NewStore(ctx, store.Node(g.ID))
 
 	// init snowflakes
 	g.initSnowflake()
@@ -123,7 +123,7 @@ func (g *Game) Action(ctx *cli.Context) error {
 	g.mi = NewMicroService(ctx, g)
 	g.rpcHandler = NewRpcHandler(g)
 	g.pubSub = NewPubSub(g)
-	g.msgRegister = NewMsgRegister(g.am, g.rpcHandler, g.pubSub)
+	g.msgRegister = NewMsgRegister(g.am, g.rpcHandler, g.pubSub, NewMsgLimiter(ctx))
 	g.tcpSrv = NewTcpServer(ctx, g)
 	// g.gnetSrv = NewGNetServer(ctx, g)
 	g.wsSrv = NewWsServer(ctx, g)
@@ -151,6 +151,13 @@ func (g *Game) Action(ctx *cli.Context) error {
 		g.wsSrv.Exit()
 	})
 
+	// sensitive words hot reload
+	g.wg.Wrap(func() {
+		defer utils.CaptureException()
+		err := excel.WatchEntries(ctx.Context, "config/csv/", "SensitiveWord.csv")
+		_ = utils.ErrCheck(err, "excel.WatchEntries failed")
+	})
+
 	// gin server
 	g.wg.Wrap(func() {
 		defer utils.CaptureException()
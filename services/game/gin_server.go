@@ -30,10 +30,11 @@ var (
 )
 
 type GinServer struct {
-	g         *Game
-	router    *gin.Engine
-	tlsRouter *gin.Engine
-	wg        utils.WaitGroupWrapper
+	g           *Game
+	router      *gin.Engine
+	tlsRouter   *gin.Engine
+	adminRouter *gin.Engine
+	wg          utils.WaitGroupWrapper
 }
 
 // wrap http.HandlerFunc to gin.HandlerFunc
@@ -63,6 +64,26 @@ func (s *GinServer) setupHttpRouter() {
 	s.router.GET("/metrics", ginHandlerWrapper(promhttp.Handler().ServeHTTP))
 }
 
+// 管理接口只在内网地址监听, 不注册到对外的http服务上
+func (s *GinServer) setupAdminRouter() {
+	s.adminRouter.Use(gin.LoggerWithWriter(logger.Logger))
+
+	// drain game node
+	s.adminRouter.POST("/admin/drain", func(c *gin.Context) {
+		if err := s.g.am.Drain(context.Background()); err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		c.JSON(http.StatusOK, s.g.am.GetDrainStatus())
+	})
+
+	// drain progress
+	s.adminRouter.GET("/admin/drain", func(c *gin.Context) {
+		c.JSON(http.StatusOK, s.g.am.GetDrainStatus())
+	})
+}
+
 func (s *GinServer) setupHttpsRouter() {
 	s.tlsRouter.Use(limit.MaxAllowed(ginConcurrentRequestLimit))
 	s.tlsRouter.Use(gin.LoggerWithWriter(logger.Logger))
@@ -70,9 +91,10 @@ func (s *GinServer) setupHttpsRouter() {
 
 func NewGinServer(ctx *cli.Context, g *Game) *GinServer {
 	s := &GinServer{
-		g:         g,
-		router:    gin.Default(),
-		tlsRouter: gin.Default(),
+		g:           g,
+		router:      gin.Default(),
+		tlsRouter:   gin.Default(),
+		adminRouter: gin.New(),
 	}
 
 	gin.DebugPrintRouteFunc = func(httpMethod, absolutePath, handlerName string, nuHandlers int) {
@@ -81,6 +103,7 @@ func NewGinServer(ctx *cli.Context, g *Game) *GinServer {
 
 	s.setupHttpRouter()
 	s.setupHttpsRouter()
+	s.setupAdminRouter()
 	return s
 }
 
@@ -142,6 +165,25 @@ func (s *GinServer) Main(ctx *cli.Context) error {
 		}
 	}()
 
+	// listen admin, 未配置地址时不开启管理接口
+	if addr := ctx.String("admin_listen_addr"); addr != "" {
+		go func() {
+			defer utils.CaptureException()
+
+			server := &http.Server{
+				Addr:         addr,
+				Handler:      s.adminRouter,
+				ReadTimeout:  httpReadTimeout,
+				WriteTimeout: httpWriteTimeout,
+			}
+
+			if err := server.ListenAndServe(); err != nil {
+				log.Error().Err(err).Msg("gin server admin ListenAndServe return with error")
+				exitCh <- err
+			}
+		}()
+	}
+
 	return <-exitCh
 }
 
@@ -30,9 +30,6 @@ type GlobalController struct {
 	rpcCaller           iface.RpcCaller `bson:"-" json:"-"`
 
 	GameId int32 `bson:"_id" json:"_id"`
-
-	// 塔最佳通关记录
-	TowerBestRecord [define.Tower_Type_End][define.TowerMaxFloor]*TowerBestInfo `bson:"tower_best_record" json:"tower_best_record"`
 }
 
 func GetGlobalController() *GlobalController {
@@ -60,7 +57,6 @@ func (g *GlobalController) SetRpcCaller(c iface.RpcCaller) {
 }
 
 func (g *GlobalController) RegisterEvent() {
-	g.eventManager.Register(define.Event_Type_TowerPass, g.onEventTowerPass)
 }
 
 func (g *GlobalController) AddEvent(event *event.Event) {
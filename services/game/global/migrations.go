@@ -0,0 +1,19 @@
+package global
+
+import (
+	"github.com/east-eden/server/store"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// 全局数据结构版本迁移, 新增迁移时版本号在末尾递增, 已发布的迁移不能修改
+func init() {
+	store.RegisterMigration("global_mess",
+		&store.Migration{Version: 1, Name: "remove tower_best_record", Migrate: migrateRemoveTowerBestRecord},
+	)
+}
+
+// migrateRemoveTowerBestRecord removes per game node tower records, tower records are kept in rank service now
+func migrateRemoveTowerBestRecord(doc bson.M) error {
+	delete(doc, "tower_best_record")
+	return nil
+}
@@ -90,9 +90,27 @@ func (m *MsgRegister) handleAccountLogon(ctx context.Context, sock transport.Soc
 		return errors.New("handleAccountLogon failed: cannot assert value to message")
 	}
 
+	// re-verify session token
+	if err := m.am.VerifySessionToken(msg.GetUserId(), msg.GetSessionToken()); err != nil {
+		reply := &pbGlobal.HandshakeResp{
+			Code: pbGlobal.ErrorCode_Unauthorized,
+			Desc: err.Error(),
+		}
+		utils.ErrPrint(sock.Send(reply), "send HandshakeResp failed when handleAccountLogon", msg.GetUserId())
+		return fmt.Errorf("handleAccountLogon failed: %w", err)
+	}
+
 	// todo userid暂时为crc32
 	userId := crc32.ChecksumIEEE([]byte(msg.UserId))
-	err := m.am.Logon(ctx, int64(userId), sock)
+	err := m.am.Logon(ctx, int64(userId), msg.GetUserId(), msg.GetCapabilities(), sock)
+	if errors.Is(err, ErrGameDraining) {
+		reply := &pbGlobal.HandshakeResp{
+			Code: pbGlobal.ErrorCode_ServiceUnavailable,
+			Desc: err.Error(),
+		}
+		utils.ErrPrint(sock.Send(reply), "send HandshakeResp failed when handleAccountLogon", msg.GetUserId())
+	}
+
 	if err != nil {
 		return fmt.Errorf("handleAccountLogon failed: %w", err)
 	}
@@ -100,6 +118,33 @@ func (m *MsgRegister) handleAccountLogon(ctx context.Context, sock transport.Soc
 	return err
 }
 
+// 断线重连, 无法恢复时客户端需要重新登陆
+func (m *MsgRegister) handleAccountResume(ctx context.Context, sock transport.Socket, p proto.Message) error {
+	msg, ok := p.(*pbGlobal.C2S_AccountResume)
+	if !ok {
+		return errors.New("handleAccountResume failed: cannot assert value to message")
+	}
+
+	if err := m.am.VerifySessionToken(msg.GetUserId(), msg.GetSessionToken()); err != nil {
+		reply := &pbGlobal.HandshakeResp{
+			Code: pbGlobal.ErrorCode_Unauthorized,
+			Desc: err.Error(),
+		}
+		utils.ErrPrint(sock.Send(reply), "send HandshakeResp failed when handleAccountResume", msg.GetUserId())
+		return fmt.Errorf("handleAccountResume failed: %w", err)
+	}
+
+	userId := crc32.ChecksumIEEE([]byte(msg.UserId))
+	err := m.am.Resume(ctx, int64(userId), msg.GetLastSeq(), sock)
+	if err != nil {
+		reply := &pbGlobal.S2C_AccountResume{Resumed: false}
+		utils.ErrPrint(sock.Send(reply), "send S2C_AccountResume failed when handleAccountResume", msg.GetUserId())
+		return fmt.Errorf("handleAccountResume failed: %w", err)
+	}
+
+	return nil
+}
+
 func (m *MsgRegister) handleHeartBeat(ctx context.Context, sock transport.Socket, p proto.Message) error {
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(v float64) {
 		m.timeHistogram.WithLabelValues("handleHeartBeat").Observe(v)
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"context"
+	"errors"
+
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/east-eden/server/services/game/player"
+)
+
+func (m *MsgRegister) handleArenaInfo(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	_, ok := p[1].(*pbGlobal.C2S_ArenaInfo)
+	if !ok {
+		return errors.New("handleArenaInfo failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	return pl.ArenaManager.QueryInfo()
+}
+
+func (m *MsgRegister) handleArenaMatch(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	_, ok := p[1].(*pbGlobal.C2S_ArenaMatch)
+	if !ok {
+		return errors.New("handleArenaMatch failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	return pl.ArenaManager.Match()
+}
+
+func (m *MsgRegister) handleArenaChallenge(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	msg, ok := p[1].(*pbGlobal.C2S_ArenaChallenge)
+	if !ok {
+		return errors.New("handleArenaChallenge failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	return pl.ArenaManager.Challenge(msg.TargetId)
+}
+
+func (m *MsgRegister) handleArenaLogs(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	_, ok := p[1].(*pbGlobal.C2S_ArenaLogs)
+	if !ok {
+		return errors.New("handleArenaLogs failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	return pl.ArenaManager.QueryLogs()
+}
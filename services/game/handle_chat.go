@@ -0,0 +1,143 @@
+package game
+
+import (
+	"context"
+	"errors"
+
+	"github.com/east-eden/server/define"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	pbChat "github.com/east-eden/server/proto/server/chat"
+	"github.com/east-eden/server/services/game/player"
+	"github.com/east-eden/server/utils"
+)
+
+var (
+	ErrChatChannelNotAllowed = errors.New("chat channel not allowed")
+	ErrChatInvalidReceiver   = errors.New("invalid chat receiver")
+)
+
+// 玩家所在的聊天频道, 私聊频道为接收者的频道
+func (m *MsgRegister) chatChannel(channelType int32, receiverId int64) (define.ChatChannel, error) {
+	channel := define.ChatChannel{Type: channelType}
+	switch channelType {
+	case define.ChatChannel_World:
+		channel.TypeId = int64(m.am.g.ID)
+	case define.ChatChannel_CrossWorld, define.ChatChannel_System:
+	case define.ChatChannel_Private:
+		channel.TypeId = receiverId
+	default:
+		return channel, ErrChatChannelNotAllowed
+	}
+
+	return channel, nil
+}
+
+func (m *MsgRegister) handleChatSend(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	msg, ok := p[1].(*pbGlobal.C2S_ChatSend)
+	if !ok {
+		return errors.New("handleChatSend failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	// 系统广播只能由服务器发送
+	if msg.GetChannelType() == define.ChatChannel_System {
+		return ErrChatChannelNotAllowed
+	}
+
+	if msg.GetChannelType() == define.ChatChannel_Private && (msg.GetReceiverId() <= 0 || msg.GetReceiverId() == pl.ID) {
+		return ErrChatInvalidReceiver
+	}
+
+	channel, err := m.chatChannel(msg.GetChannelType(), msg.GetReceiverId())
+	if err != nil {
+		return err
+	}
+
+	_, err = acct.GetRpcCaller().CallSendChatMessage(&pbChat.SendChatMessageRq{
+		Message: &pbGlobal.ChatMessage{
+			Channel:     channel.ToPB(),
+			SenderId:    pl.ID,
+			SenderName:  pl.GetName(),
+			SenderLevel: pl.GetLevel(),
+			ReceiverId:  channel.TypeId,
+			Content:     msg.GetContent(),
+		},
+	})
+
+	utils.ErrPrint(err, "CallSendChatMessage failed when MsgRegister.handleChatSend", pl.ID, channel)
+	return err
+}
+
+func (m *MsgRegister) handleChatHistory(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	msg, ok := p[1].(*pbGlobal.C2S_ChatHistory)
+	if !ok {
+		return errors.New("handleChatHistory failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	// 私聊历史为自己收到的消息
+	channel, err := m.chatChannel(msg.GetChannelType(), pl.ID)
+	if err != nil {
+		return err
+	}
+
+	rs, err := acct.GetRpcCaller().CallQueryChatHistory(&pbChat.QueryChatHistoryRq{
+		Channel: channel.ToPB(),
+		LastSeq: msg.GetLastSeq(),
+	})
+
+	if !utils.ErrCheck(err, "CallQueryChatHistory failed when MsgRegister.handleChatHistory", pl.ID, channel) {
+		return err
+	}
+
+	pl.SendProtoMessage(&pbGlobal.S2C_ChatHistory{
+		Channel:  channel.ToPB(),
+		Messages: rs.GetMessages(),
+	})
+	return nil
+}
+
+func (m *MsgRegister) handleChatReport(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	msg, ok := p[1].(*pbGlobal.C2S_ChatReport)
+	if !ok {
+		return errors.New("handleChatReport failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	// 私聊只能举报自己收到的消息
+	channel, err := m.chatChannel(msg.GetChannelType(), pl.ID)
+	if err != nil {
+		return err
+	}
+
+	rs, err := acct.GetRpcCaller().CallReportChatMessage(&pbChat.ReportChatMessageRq{
+		Channel:    channel.ToPB(),
+		Seq:        msg.GetSeq(),
+		ReporterId: pl.ID,
+		Reason:     msg.GetReason(),
+	})
+
+	if !utils.ErrCheck(err, "CallReportChatMessage failed when MsgRegister.handleChatReport", pl.ID, channel, msg.GetSeq()) {
+		return err
+	}
+
+	pl.SendProtoMessage(&pbGlobal.S2C_ChatReport{
+		ReportId: rs.GetReportId(),
+	})
+	return nil
+}
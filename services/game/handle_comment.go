@@ -0,0 +1,231 @@
+package game
+
+import (
+	"context"
+	"errors"
+
+	"github.com/east-eden/server/define"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	pbComment "github.com/east-eden/server/proto/server/comment"
+	"github.com/east-eden/server/services/game/player"
+	"github.com/east-eden/server/utils"
+)
+
+var (
+	ErrInvalidCommentTopic = errors.New("invalid comment topic")
+	ErrInvalidCommentRange = errors.New("invalid comment range")
+)
+
+func commentTopic(topicType, topicTypeId int32) (define.CommentTopic, error) {
+	topic := define.CommentTopic{Type: topicType, TypeId: topicTypeId}
+	if !topic.Valid() {
+		return topic, ErrInvalidCommentTopic
+	}
+
+	return topic, nil
+}
+
+// 评论查询范围[start, end], end<0或超过单次查询上限时截断
+func commentRange(start, end int64) (int64, int64, error) {
+	if start < 0 || (end >= 0 && end < start) {
+		return start, end, ErrInvalidCommentRange
+	}
+
+	if end < 0 || end-start+1 > define.Comment_QueryMaxNum {
+		end = start + define.Comment_QueryMaxNum - 1
+	}
+
+	return start, end, nil
+}
+
+func (m *MsgRegister) handleCommentQuery(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	msg, ok := p[1].(*pbGlobal.C2S_CommentQuery)
+	if !ok {
+		return errors.New("handleCommentQuery failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	topic, err := commentTopic(msg.GetTopicType(), msg.GetTopicTypeId())
+	if err != nil {
+		return err
+	}
+
+	start, end, err := commentRange(msg.GetStart(), msg.GetEnd())
+	if err != nil {
+		return err
+	}
+
+	rs, err := acct.GetRpcCaller().CallQueryCommentTopicRange(&pbComment.QueryCommentTopicRangeRq{
+		Topic:    topic.ToPB(),
+		Start:    start,
+		End:      end,
+		ViewerId: pl.ID,
+	})
+
+	if !utils.ErrCheck(err, "CallQueryCommentTopicRange failed when MsgRegister.handleCommentQuery", pl.ID, topic) {
+		return err
+	}
+
+	pl.SendProtoMessage(&pbGlobal.S2C_CommentQuery{
+		Topic:     topic.ToPB(),
+		Start:     start,
+		End:       end,
+		Metadatas: rs.GetMetadatas(),
+	})
+	return nil
+}
+
+func (m *MsgRegister) handleCommentPublish(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	msg, ok := p[1].(*pbGlobal.C2S_CommentPublish)
+	if !ok {
+		return errors.New("handleCommentPublish failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	topic, err := commentTopic(msg.GetTopicType(), msg.GetTopicTypeId())
+	if err != nil {
+		return err
+	}
+
+	rs, err := acct.GetRpcCaller().CallPublishComment(&pbComment.PublishCommentRq{
+		Topic: topic.ToPB(),
+		Publisher: &pbGlobal.PublisherMetadata{
+			PublisherId:   pl.ID,
+			PublisherName: pl.GetName(),
+			Content:       msg.GetContent(),
+		},
+	})
+
+	if !utils.ErrCheck(err, "CallPublishComment failed when MsgRegister.handleCommentPublish", pl.ID, topic) {
+		return err
+	}
+
+	pl.SendProtoMessage(&pbGlobal.S2C_CommentPublish{
+		Metadata: rs.GetMetadata(),
+	})
+	return nil
+}
+
+func (m *MsgRegister) handleCommentReply(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	msg, ok := p[1].(*pbGlobal.C2S_CommentReply)
+	if !ok {
+		return errors.New("handleCommentReply failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	topic, err := commentTopic(msg.GetTopicType(), msg.GetTopicTypeId())
+	if err != nil {
+		return err
+	}
+
+	rs, err := acct.GetRpcCaller().CallReplyComment(&pbComment.ReplyCommentRq{
+		Topic:     topic.ToPB(),
+		CommentId: msg.GetCommentId(),
+		Publisher: &pbGlobal.PublisherMetadata{
+			PublisherId:   pl.ID,
+			PublisherName: pl.GetName(),
+			ReplyToId:     msg.GetReplyToId(),
+			Content:       msg.GetContent(),
+		},
+	})
+
+	if !utils.ErrCheck(err, "CallReplyComment failed when MsgRegister.handleCommentReply", pl.ID, topic, msg.GetCommentId()) {
+		return err
+	}
+
+	pl.SendProtoMessage(&pbGlobal.S2C_CommentReply{
+		Topic:     topic.ToPB(),
+		CommentId: rs.GetCommentId(),
+		Reply:     rs.GetReply(),
+	})
+	return nil
+}
+
+func (m *MsgRegister) handleCommentDelete(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	msg, ok := p[1].(*pbGlobal.C2S_CommentDelete)
+	if !ok {
+		return errors.New("handleCommentDelete failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	topic, err := commentTopic(msg.GetTopicType(), msg.GetTopicTypeId())
+	if err != nil {
+		return err
+	}
+
+	// 玩家只能删除自己发表的评论或回复
+	_, err = acct.GetRpcCaller().CallDeleteComment(&pbComment.DeleteCommentRq{
+		Topic:      topic.ToPB(),
+		CommentId:  msg.GetCommentId(),
+		ReplyId:    msg.GetReplyId(),
+		OperatorId: pl.ID,
+	})
+
+	if !utils.ErrCheck(err, "CallDeleteComment failed when MsgRegister.handleCommentDelete", pl.ID, topic, msg.GetCommentId(), msg.GetReplyId()) {
+		return err
+	}
+
+	pl.SendProtoMessage(&pbGlobal.S2C_CommentDelete{
+		Topic:     topic.ToPB(),
+		CommentId: msg.GetCommentId(),
+		ReplyId:   msg.GetReplyId(),
+	})
+	return nil
+}
+
+func (m *MsgRegister) handleCommentThumbs(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	msg, ok := p[1].(*pbGlobal.C2S_CommentThumbs)
+	if !ok {
+		return errors.New("handleCommentThumbs failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	topic, err := commentTopic(msg.GetTopicType(), msg.GetTopicTypeId())
+	if err != nil {
+		return err
+	}
+
+	rs, err := acct.GetRpcCaller().CallThumbsComment(&pbComment.ThumbsCommentRq{
+		Topic:     topic.ToPB(),
+		CommentId: msg.GetCommentId(),
+		PlayerId:  pl.ID,
+		Like:      msg.GetLike(),
+	})
+
+	if !utils.ErrCheck(err, "CallThumbsComment failed when MsgRegister.handleCommentThumbs", pl.ID, topic, msg.GetCommentId(), msg.GetLike()) {
+		return err
+	}
+
+	pl.SendProtoMessage(&pbGlobal.S2C_CommentThumbs{
+		Topic:     topic.ToPB(),
+		CommentId: rs.GetCommentId(),
+		Thumbs:    rs.GetThumbs(),
+		Liked:     rs.GetLiked(),
+	})
+	return nil
+}
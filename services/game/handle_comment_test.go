@@ -0,0 +1,38 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/east-eden/server/define"
+)
+
+func TestCommentRange(t *testing.T) {
+	maxNum := int64(define.Comment_QueryMaxNum)
+	cases := []struct {
+		start, end         int64
+		wantStart, wantEnd int64
+		err                error
+	}{
+		{0, 9, 0, 9, nil},
+		{10, 10, 10, 10, nil},
+		{0, -1, 0, maxNum - 1, nil},
+		{20, -1, 20, 20 + maxNum - 1, nil},
+		{0, maxNum * 10, 0, maxNum - 1, nil},
+		{5, 5 + maxNum - 1, 5, 5 + maxNum - 1, nil},
+		{-1, 9, 0, 0, ErrInvalidCommentRange},
+		{10, 5, 0, 0, ErrInvalidCommentRange},
+	}
+
+	for _, c := range cases {
+		start, end, err := commentRange(c.start, c.end)
+		if !errors.Is(err, c.err) {
+			t.Errorf("commentRange(%d, %d) error should be %v, got %v", c.start, c.end, c.err, err)
+			continue
+		}
+
+		if err == nil && (start != c.wantStart || end != c.wantEnd) {
+			t.Errorf("commentRange(%d, %d) should be [%d, %d], got [%d, %d]", c.start, c.end, c.wantStart, c.wantEnd, start, end)
+		}
+	}
+}
@@ -88,5 +88,5 @@ func (m *MsgRegister) handleSaveBattleArray(ctx context.Context, p ...any) error
 		return ErrPlayerNotFound
 	}
 
-	return pl.SaveBattleArray(msg.GetBattleHeroId())
+	return pl.SaveBattleArray(msg.GetType(), msg.GetName(), msg.GetHeroes())
 }
@@ -1 +1,33 @@
 package game
+
+import (
+	"context"
+	"errors"
+
+	pbGlobal "github.com/east-eden/server/proto/global"
+	pbCombat "github.com/east-eden/server/proto/server/combat"
+	"github.com/east-eden/server/services/game/player"
+	"github.com/east-eden/server/utils"
+)
+
+func (m *MsgRegister) handleQueryCombatRecord(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	msg, ok := p[1].(*pbGlobal.C2S_QueryCombatRecord)
+	if !ok {
+		return errors.New("handleQueryCombatRecord failed: recv message body error")
+	}
+
+	res, err := acct.GetRpcCaller().CallQueryCombatRecord(&pbCombat.QueryCombatRecordRq{
+		RecordId: msg.GetRecordId(),
+	})
+
+	if !utils.ErrCheck(err, "CallQueryCombatRecord failed when MsgRegister.handleQueryCombatRecord", acct.Id, msg.GetRecordId()) {
+		return err
+	}
+
+	reply := &pbGlobal.S2C_CombatRecord{
+		Record: res.GetRecord(),
+	}
+	acct.SendProtoMessage(reply)
+	return nil
+}
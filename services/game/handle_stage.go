@@ -20,6 +20,7 @@ func (m *MsgRegister) handleStageChallenge(ctx context.Context, p ...any) error
 		return ErrPlayerNotFound
 	}
 
+	// 客户端上报的结果只用于校验, 关卡结果由combat服务计算
 	return pl.ChapterStageManager.StageChallenge(msg.GetStageId(), msg.GetWin(), msg.GetAchieveCondition(), msg.GetStarCondition())
 }
 
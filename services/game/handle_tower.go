@@ -20,5 +20,20 @@ func (m *MsgRegister) handleTowerChallenge(ctx context.Context, p ...any) error
 		return ErrPlayerNotFound
 	}
 
-	return pl.TowerManager.Challenge(msg.TowerType, msg.TowerFloor, msg.BattleArray)
+	return pl.TowerManager.Challenge(msg.TowerType, msg.TowerFloor)
+}
+
+func (m *MsgRegister) handleQueryTowerRank(ctx context.Context, p ...any) error {
+	acct := p[0].(*player.Account)
+	msg, ok := p[1].(*pbGlobal.C2S_QueryTowerRank)
+	if !ok {
+		return errors.New("handleQueryTowerRank failed: recv message body error")
+	}
+
+	pl := acct.GetPlayer()
+	if pl == nil {
+		return ErrPlayerNotFound
+	}
+
+	return pl.TowerManager.QueryRank(msg.TowerType, msg.TowerFloor)
 }
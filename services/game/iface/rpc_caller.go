@@ -1,7 +1,9 @@
 package iface
 
 import (
+	pbChat "github.com/east-eden/server/proto/server/chat"
 	pbCombat "github.com/east-eden/server/proto/server/combat"
+	pbComment "github.com/east-eden/server/proto/server/comment"
 	pbMail "github.com/east-eden/server/proto/server/mail"
 	pbRank "github.com/east-eden/server/proto/server/rank"
 )
@@ -16,8 +18,27 @@ type RpcCaller interface {
 
 	// 战斗相关
 	CallStageCombat(*pbCombat.StageCombatRq) (*pbCombat.StageCombatRs, error)
+	CallTowerCombat(*pbCombat.TowerCombatRq) (*pbCombat.TowerCombatRs, error)
+	CallArenaCombat(*pbCombat.ArenaCombatRq) (*pbCombat.ArenaCombatRs, error)
+	CallQueryCombatRecord(*pbCombat.QueryCombatRecordRq) (*pbCombat.QueryCombatRecordRs, error)
 
 	// 排行相关
 	CallQueryRankByObjId(*pbRank.QueryRankByObjIdRq) (*pbRank.QueryRankByObjIdRs, error)
+	CallQueryRankByRange(*pbRank.QueryRankByRangeRq) (*pbRank.QueryRankByRangeRs, error)
+	CallQueryRankByScore(*pbRank.QueryRankByScoreRq) (*pbRank.QueryRankByScoreRs, error)
 	CallSetRankScore(*pbRank.SetRankScoreRq) (*pbRank.SetRankScoreRs, error)
+	CallIncrRankScore(*pbRank.IncrRankScoreRq) (*pbRank.IncrRankScoreRs, error)
+	CallQueryRankSnapshot(*pbRank.QueryRankSnapshotRq) (*pbRank.QueryRankSnapshotRs, error)
+
+	// 聊天相关
+	CallSendChatMessage(*pbChat.SendChatMessageRq) (*pbChat.SendChatMessageRs, error)
+	CallQueryChatHistory(*pbChat.QueryChatHistoryRq) (*pbChat.QueryChatHistoryRs, error)
+	CallReportChatMessage(*pbChat.ReportChatMessageRq) (*pbChat.ReportChatMessageRs, error)
+
+	// 评论相关
+	CallQueryCommentTopicRange(*pbComment.QueryCommentTopicRangeRq) (*pbComment.QueryCommentTopicRangeRs, error)
+	CallPublishComment(*pbComment.PublishCommentRq) (*pbComment.PublishCommentRs, error)
+	CallReplyComment(*pbComment.ReplyCommentRq) (*pbComment.ReplyCommentRs, error)
+	CallDeleteComment(*pbComment.DeleteCommentRq) (*pbComment.DeleteCommentRs, error)
+	CallThumbsComment(*pbComment.ThumbsCommentRq) (*pbComment.ThumbsCommentRs, error)
 }
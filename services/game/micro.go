@@ -5,6 +5,8 @@ import (
 	"crypto/tls"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	grpc_client "github.com/asim/go-micro/plugins/client/grpc/v3"
 	grpc_server "github.com/asim/go-micro/plugins/server/grpc/v3"
@@ -27,8 +29,9 @@ import (
 )
 
 type MicroService struct {
-	srv micro.Service
-	g   *Game
+	srv      micro.Service
+	g        *Game
+	bootTime string // 节点启动时间, 用于区分下线迁移中的节点和重新部署后的节点
 }
 
 func NewMicroService(c *cli.Context, g *Game) *MicroService {
@@ -37,6 +40,7 @@ func NewMicroService(c *cli.Context, g *Game) *MicroService {
 	metadata["gameId"] = c.String("game_id")
 	metadata["publicTcpAddr"] = fmt.Sprintf("%s%s", c.String("public_ip"), c.String("tcp_listen_addr"))
 	metadata["publicWsAddr"] = fmt.Sprintf("%s%s", c.String("public_ip"), c.String("websocket_listen_addr"))
+	metadata["bootTime"] = strconv.FormatInt(time.Now().Unix(), 10)
 
 	// cert
 	certPath := c.String("cert_path_release")
@@ -54,7 +58,7 @@ func NewMicroService(c *cli.Context, g *Game) *MicroService {
 	}
 	tlsConf.Certificates = []tls.Certificate{cert}
 
-	s := &MicroService{g: g}
+	s := &MicroService{g: g, bootTime: metadata["bootTime"]}
 	err = micro_logger.Init(micro_logger.WithOutput(logger.Logger))
 	utils.ErrPrint(err, "micro logger init failed")
 
@@ -3,14 +3,19 @@ package game
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 
 	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/east-eden/server/services/game/player"
 	"github.com/east-eden/server/transport"
 	"github.com/east-eden/server/transport/codec"
+	"github.com/east-eden/server/utils/limiter"
 	"github.com/hellodudu/task"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	log "github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -23,15 +28,32 @@ type MsgRegister struct {
 	rpcHandler    *RpcHandler
 	pubSub        *PubSub
 	r             transport.Register
+	limiter       *limiter.Limiter
 	timeHistogram *prometheus.HistogramVec
 }
 
-func NewMsgRegister(am *AccountManager, rpcHandler *RpcHandler, pubSub *PubSub) *MsgRegister {
+func NewMsgLimiter(ctx *cli.Context) *limiter.Limiter {
+	budgets, err := limiter.ParseBudgets(ctx.StringSlice("flood_msg_budgets"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("parse flood_msg_budgets failed")
+	}
+
+	return limiter.New(limiter.Options{
+		Service:      "game",
+		Default:      limiter.Budget{Rate: ctx.Float64("flood_msg_rate"), Burst: int64(ctx.Int("flood_msg_burst"))},
+		Budgets:      budgets,
+		BanThreshold: ctx.Int("flood_ban_threshold"),
+		BanDuration:  ctx.Duration("flood_ban_duration"),
+	})
+}
+
+func NewMsgRegister(am *AccountManager, rpcHandler *RpcHandler, pubSub *PubSub, l *limiter.Limiter) *MsgRegister {
 	m := &MsgRegister{
 		am:         am,
 		rpcHandler: rpcHandler,
 		pubSub:     pubSub,
 		r:          transport.NewTransportRegister(),
+		limiter:    l,
 		timeHistogram: promauto.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Namespace: "game",
@@ -55,6 +77,31 @@ func (msg *MC_AccountTest) GetName() string {
 	return "MC_AccountTest"
 }
 
+// 防刷: 已登陆的连接按账号限流, 未登陆的连接按socket地址限流, 超出预算的消息直接丢弃, 被封禁时断开连接
+func (m *MsgRegister) limit(mf transport.MessageFunc) transport.MessageFunc {
+	if m.limiter == nil {
+		return mf
+	}
+
+	return func(ctx context.Context, sock transport.Socket, msg proto.Message) error {
+		key := "sock:" + sock.Remote()
+		if accountId, ok := m.am.GetAccountIdBySock(sock); ok {
+			key = "account:" + strconv.FormatInt(accountId, 10)
+		}
+
+		err := m.limiter.Allow(key, string(msg.ProtoReflect().Descriptor().Name()))
+		if errors.Is(err, limiter.ErrThrottled) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("%w: %s", player.ErrAccountDisconnect, err.Error())
+		}
+
+		return mf(ctx, sock, msg)
+	}
+}
+
 func (m *MsgRegister) registerAllMessage() {
 	registerJsonFn := func(c codec.JsonCodec, mf transport.MessageFunc) {
 		err := m.r.RegisterJsonMessage(c, mf)
@@ -71,7 +118,7 @@ func (m *MsgRegister) registerAllMessage() {
 
 	// normal protobuf handler
 	registerPBHandler := func(p proto.Message, mf transport.MessageFunc) {
-		err := m.r.RegisterProtobufMessage(p, mf)
+		err := m.r.RegisterProtobufMessage(p, m.limit(mf))
 		if err != nil {
 			log.Fatal().
 				Err(err).
@@ -109,7 +156,7 @@ func (m *MsgRegister) registerAllMessage() {
 			)
 		}
 
-		err := m.r.RegisterProtobufMessage(p, mf)
+		err := m.r.RegisterProtobufMessage(p, m.limit(mf))
 		if err != nil {
 			log.Fatal().
 				Err(err).
@@ -124,6 +171,7 @@ func (m *MsgRegister) registerAllMessage() {
 	registerPBHandler(&pbGlobal.C2S_WaitResponseMessage{}, m.handleWaitResponseMessage)
 	registerPBHandler(&pbGlobal.C2S_Ping{}, m.handleAccountPing)
 	registerPBHandler(&pbGlobal.C2S_AccountLogon{}, m.handleAccountLogon)
+	registerPBHandler(&pbGlobal.C2S_AccountResume{}, m.handleAccountResume)
 	registerPBHandler(&pbGlobal.C2S_HeartBeat{}, m.handleHeartBeat)
 	registerPBHandler(&pbGlobal.C2S_AccountDisconnect{}, m.handleAccountDisconnect)
 
@@ -175,12 +223,32 @@ func (m *MsgRegister) registerAllMessage() {
 
 	// tower
 	registerPBAccountHandler(&pbGlobal.C2S_TowerChallenge{}, m.handleTowerChallenge)
+	registerPBAccountHandler(&pbGlobal.C2S_QueryTowerRank{}, m.handleQueryTowerRank)
+
+	// arena
+	registerPBAccountHandler(&pbGlobal.C2S_ArenaInfo{}, m.handleArenaInfo)
+	registerPBAccountHandler(&pbGlobal.C2S_ArenaMatch{}, m.handleArenaMatch)
+	registerPBAccountHandler(&pbGlobal.C2S_ArenaChallenge{}, m.handleArenaChallenge)
+	registerPBAccountHandler(&pbGlobal.C2S_ArenaLogs{}, m.handleArenaLogs)
 
 	// scene
+	registerPBAccountHandler(&pbGlobal.C2S_QueryCombatRecord{}, m.handleQueryCombatRecord)
 
 	// quest
 	registerPBAccountHandler(&pbGlobal.C2S_PlayerQuestReward{}, m.handlePlayerQuestReward)
 
 	// rank
 	registerPBAccountHandler(&pbGlobal.C2S_QueryRank{}, m.handleQueryRank)
+
+	// chat
+	registerPBAccountHandler(&pbGlobal.C2S_ChatSend{}, m.handleChatSend)
+	registerPBAccountHandler(&pbGlobal.C2S_ChatHistory{}, m.handleChatHistory)
+	registerPBAccountHandler(&pbGlobal.C2S_ChatReport{}, m.handleChatReport)
+
+	// comment
+	registerPBAccountHandler(&pbGlobal.C2S_CommentQuery{}, m.handleCommentQuery)
+	registerPBAccountHandler(&pbGlobal.C2S_CommentPublish{}, m.handleCommentPublish)
+	registerPBAccountHandler(&pbGlobal.C2S_CommentReply{}, m.handleCommentReply)
+	registerPBAccountHandler(&pbGlobal.C2S_CommentDelete{}, m.handleCommentDelete)
+	registerPBAccountHandler(&pbGlobal.C2S_CommentThumbs{}, m.handleCommentThumbs)
 }
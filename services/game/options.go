@@ -19,11 +19,19 @@ func NewFlags() []cli.Flag {
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "websocket_listen_addr", Usage: "websocket listen address"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "http_listen_addr", Usage: "http listen address"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "https_listen_addr", Usage: "https listen address"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "admin_listen_addr", Usage: "internal admin http listen address, admin api is disabled if empty"}),
 
 		// rate limit
 		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "rate_limit_interval", Usage: "rpc server rate limit interval"}),
 		altsrc.NewIntFlag(&cli.IntFlag{Name: "rate_limit_capacity", Usage: "rpc server rate limit capacity"}),
 
+		// flood protection
+		altsrc.NewFloat64Flag(&cli.Float64Flag{Name: "flood_msg_rate", Usage: "messages per second allowed per account"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "flood_msg_burst", Usage: "message burst allowed per account"}),
+		altsrc.NewStringSliceFlag(&cli.StringSliceFlag{Name: "flood_msg_budgets", Usage: "per message budgets in format MsgName=rate/burst"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "flood_ban_threshold", Usage: "violations before temporary ban"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "flood_ban_duration", Usage: "temporary ban duration"}),
+
 		// cert
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "cert_path_debug", Usage: "debug tls cert_pem path"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "key_path_debug", Usage: "debug tls server_key path"}),
@@ -31,9 +39,25 @@ func NewFlags() []cli.Flag {
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "key_path_release", Usage: "release tls server_key path"}),
 
 		// db
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "db_driver", Usage: "db driver: mongodb, mysql, sqlite3, memory", Value: "mongodb"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "db_dsn", Usage: "db data source name"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "database", Usage: "database name"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "redis_addr", Usage: "redis address"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "cache_backend", Usage: "cache backend: dummy, redis, sentinel, cluster, miniredis, redigo"}),
+		altsrc.NewStringSliceFlag(&cli.StringSliceFlag{Name: "redis_addrs", Usage: "redis sentinel or cluster addresses"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "redis_master_name", Usage: "redis sentinel master name"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "redis_password", Usage: "redis password"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "redis_db", Usage: "redis database index"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "redis_pool_size", Usage: "redis connection pool size"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "redis_min_idle_conns", Usage: "redis minimum idle connections"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "redis_dial_timeout", Usage: "redis dial timeout"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "redis_read_timeout", Usage: "redis read timeout"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "redis_write_timeout", Usage: "redis write timeout"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "redis_idle_timeout", Usage: "redis idle connection timeout"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "cache_expire", Usage: "cache key expire duration"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "store_journal_dir", Usage: "store write-behind journal directory, each node writes to a sub directory named by node id, journal is disabled if empty"}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{Name: "store_journal_fsync", Usage: "fsync every store journal record"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "store_flush_interval", Usage: "store write-behind flush interval"}),
 
 		// micro service
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "registry_debug", Usage: "micro service registry in debug mode"}),
@@ -46,6 +70,9 @@ func NewFlags() []cli.Flag {
 
 		// game
 		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "heart_beat_timeout", Usage: "account heart beat timeout"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "account_replay_buffer_size", Usage: "account replay buffer size in bytes for reconnecting"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "session_token_secret", Usage: "session token hmac secret, must be the same with gate", EnvVars: []string{"SESSION_TOKEN_SECRET"}}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{Name: "stage_verify_client", Usage: "verify client predicted stage result with combat service result"}),
 
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "config_file", Usage: "game config path"}),
 	}
@@ -3,6 +3,7 @@ package player
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"time"
 
 	"github.com/east-eden/server/define"
@@ -11,6 +12,7 @@ import (
 	"github.com/east-eden/server/store"
 	"github.com/east-eden/server/transport"
 	"github.com/east-eden/server/utils"
+	"github.com/east-eden/server/utils/ringbuffer"
 	"github.com/hellodudu/task"
 	log "github.com/rs/zerolog/log"
 	"google.golang.org/protobuf/proto"
@@ -20,8 +22,14 @@ var (
 	ErrAccountDisconnect       = errors.New("account disconnect") // handleSocket got this error will disconnect account
 	ErrAccountKicked           = errors.New("account kickoff")
 	ErrCreateMoreThanOnePlayer = errors.New("AccountManager.CreatePlayer failed: only can create one player") // only can create one player
+	ErrAccountReplayLost       = errors.New("account replay messages lost")                                   // 断线期间的消息已被覆盖, 无法恢复
+	ErrAccountMigrated         = errors.New("account migrated to other game node")                            // 下线迁移后本节点不再处理该账号
 	Account_MemExpire          = time.Hour * 2
 	AccountTaskTimeout         = time.Minute // 账号task超时
+	AccountReplayBufferSize    = 64 * 1024   // 断线重连补发消息缓存大小
+
+	// 服务器支持的会话功能
+	AccountSupportedCapabilities = uint32(pbGlobal.SessionCapability_SessionCapability_Sequenced)
 )
 
 // full account info
@@ -35,8 +43,13 @@ type Account struct {
 	PlayerIDs      []int64 `bson:"player_id" json:"player_id"`
 	LastLogoffTime int32   `bson:"last_logoff_time" json:"last_logoff_time"` // 账号上次下线时间
 
-	sock transport.Socket `bson:"-" json:"-"`
-	p    *Player          `bson:"-" json:"-"`
+	sock   transport.Socket      `bson:"-" json:"-"`
+	p      *Player               `bson:"-" json:"-"`
+	replay *ringbuffer.SeqBuffer `bson:"-" json:"-"` // 下发消息缓存, 断线重连时补发
+
+	sessionUserId string        `bson:"-" json:"-"` // session token中的user id
+	capabilities  atomic.Uint32 `bson:"-" json:"-"` // 登陆时协商的会话功能, 按位组合SessionCapability
+	migrated      atomic.Bool   `bson:"-" json:"-"` // 已迁移到其他game节点, 不再处理消息和保存数据
 
 	tasker    *task.Tasker    `bson:"-" json:"-"`
 	rpcCaller iface.RpcCaller `bson:"-" json:"-"`
@@ -56,6 +69,10 @@ func (a *Account) Init() {
 	a.PlayerIDs = make([]int64, 0, 5)
 	a.SetSock(nil)
 	a.SetPlayer(nil)
+	a.ResetReplay()
+	a.sessionUserId = ""
+	a.capabilities.Store(0)
+	a.migrated.Store(false)
 }
 
 func (a *Account) InitTask(startFn task.StartFn, stopFn task.StopFn) {
@@ -128,6 +145,37 @@ func (a *Account) SetSock(s transport.Socket) {
 	a.sock = s
 }
 
+func (a *Account) GetSessionUserId() string {
+	return a.sessionUserId
+}
+
+func (a *Account) SetSessionUserId(userId string) {
+	a.sessionUserId = userId
+}
+
+// 登陆时协商会话功能, 只启用服务器支持的部分
+func (a *Account) SetCapabilities(caps uint32) {
+	a.capabilities.Store(caps & AccountSupportedCapabilities)
+}
+
+func (a *Account) GetCapabilities() uint32 {
+	return a.capabilities.Load()
+}
+
+// 客户端支持带序号的消息时才缓存下发消息, 用于断线重连补发
+func (a *Account) IsSequenced() bool {
+	return a.GetCapabilities()&uint32(pbGlobal.SessionCapability_SessionCapability_Sequenced) != 0
+}
+
+func (a *Account) ResetReplay() {
+	if a.replay == nil {
+		a.replay = ringbuffer.NewSeqBuffer(AccountReplayBufferSize)
+		return
+	}
+
+	a.replay.Reset()
+}
+
 func (a *Account) GetPlayer() *Player {
 	return a.p
 }
@@ -151,7 +199,14 @@ func (a *Account) AddTask(ctx context.Context, fn task.TaskHandler, p ...any) {
 	param := make([]any, 0, len(p)+1)
 	param = append(param, a)
 	param = append(param, p...)
-	a.tasker.Add(ctx, fn, param...)
+	a.tasker.Add(ctx, func(c context.Context, p ...any) error {
+		// 迁移前已经进入队列的消息不再处理
+		if a.IsMigrated() {
+			return ErrAccountMigrated
+		}
+
+		return fn(c, p...)
+	}, param...)
 }
 
 func (a *Account) TaskRun(ctx context.Context) error {
@@ -162,6 +217,15 @@ func (a *Account) IsTaskRunning() bool {
 	return a.tasker.IsRunning()
 }
 
+// SetMigrated marks account migrated to other game node, it won't handle messages and save data anymore
+func (a *Account) SetMigrated() {
+	a.migrated.Store(true)
+}
+
+func (a *Account) IsMigrated() bool {
+	return a.migrated.Load()
+}
+
 func (a *Account) onTaskStart() {
 	if a.p != nil {
 		a.p.onTaskStart()
@@ -175,8 +239,10 @@ func (a *Account) onTaskStop() {
 		// Str("socket_remote", a.GetSock().Remote()).
 		Msg("account task stop...")
 
-	// 记录下线时间
-	a.saveLogoffTime()
+	// 记录下线时间, 已迁移的账号由新节点保存
+	if !a.IsMigrated() {
+		a.saveLogoffTime()
+	}
 
 	// 关闭socket
 	a.GetSock().Close()
@@ -184,6 +250,10 @@ func (a *Account) onTaskStop() {
 }
 
 func (a *Account) onTaskUpdate() {
+	if a.IsMigrated() {
+		return
+	}
+
 	if a.p != nil {
 		a.p.onTaskUpdate()
 	}
@@ -248,22 +318,82 @@ msg Example:
 	Body: protoBuf byte
 */
 func (a *Account) SendProtoMessage(p proto.Message) {
+	name := p.ProtoReflect().Descriptor().Name()
+	data, err := proto.Marshal(p)
+	if !utils.ErrCheck(err, "proto.Marshal failed when Account.SendProtoMessage", a.Id, name) {
+		return
+	}
+
+	// 客户端未声明支持带序号的消息时直接发送
+	if !a.IsSequenced() {
+		if a.GetSock() == nil {
+			return
+		}
+
+		err = a.GetSock().Send(p)
+		_ = utils.ErrCheck(err, "Account.SendProtoMessage failed", a.Id, name)
+		return
+	}
+
+	// 断线期间也需要缓存, 重连后补发
+	msg := &pbGlobal.S2C_SequencedMessage{
+		Seq:          a.replay.LastSeq() + 1,
+		InnerMsgCrc:  transport.MessageID(p),
+		InnerMsgData: data,
+	}
+	a.replay.Push(msg.Seq, msg.InnerMsgCrc, msg.InnerMsgData)
+
 	if a.GetSock() == nil {
 		return
 	}
 
-	name := p.ProtoReflect().Descriptor().Name()
-	err := a.GetSock().Send(p)
+	err = a.GetSock().Send(msg)
 	_ = utils.ErrCheck(err, "Account.SendProtoMessage failed", a.Id, name)
 }
 
+// 客户端最后收到的消息之后的所有消息是否仍在缓存中
+func (a *Account) CanResume(lastSeq uint64) bool {
+	return a.IsSequenced() && a.replay.Replayable(lastSeq)
+}
+
+// 断线重连: 补发客户端最后收到的消息之后的所有消息
+func (a *Account) Resume(lastSeq uint64) error {
+	records, ok := a.replay.Since(lastSeq)
+	if !ok {
+		return ErrAccountReplayLost
+	}
+
+	reply := &pbGlobal.S2C_AccountResume{
+		Resumed: true,
+		LastSeq: a.replay.LastSeq(),
+	}
+	if err := a.GetSock().Send(reply); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		msg := &pbGlobal.S2C_SequencedMessage{
+			Seq:          r.Seq,
+			InnerMsgCrc:  r.Id,
+			InnerMsgData: r.Data,
+		}
+
+		if err := a.GetSock().Send(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (a *Account) SendLogon() {
 	reply := &pbGlobal.S2C_AccountLogon{
-		UserId:      a.UserId,
-		AccountId:   a.Id,
-		PlayerId:    -1,
-		PlayerName:  "",
-		PlayerLevel: 0,
+		UserId:       a.UserId,
+		AccountId:    a.Id,
+		PlayerId:     -1,
+		PlayerName:   "",
+		PlayerLevel:  0,
+		Capabilities: a.GetCapabilities(),
 	}
 
 	if p := a.GetPlayer(); p != nil {
@@ -0,0 +1,52 @@
+package player
+
+import (
+	"testing"
+
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"github.com/east-eden/server/transport"
+	"google.golang.org/protobuf/proto"
+)
+
+type testSocket struct {
+	transport.Socket
+	sent []proto.Message
+}
+
+func (s *testSocket) Send(msg proto.Message) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func TestAccountCapabilities(t *testing.T) {
+	sock := &testSocket{}
+	acct := NewAccount().(*Account)
+	acct.Init()
+	acct.SetSock(sock)
+
+	// 未声明支持带序号消息的客户端直接收到原始消息, 不能断线重连
+	acct.SendProtoMessage(&pbGlobal.S2C_ServerTime{Timestamp: 1})
+	if _, ok := sock.sent[0].(*pbGlobal.S2C_ServerTime); !ok {
+		t.Fatalf("account without capabilities should send raw message, got %T", sock.sent[0])
+	}
+
+	if acct.CanResume(0) {
+		t.Fatal("account without sequenced capability should not resume")
+	}
+
+	// 只启用服务器支持的功能
+	acct.SetCapabilities(uint32(pbGlobal.SessionCapability_SessionCapability_Sequenced) | 1<<8)
+	if acct.GetCapabilities() != uint32(pbGlobal.SessionCapability_SessionCapability_Sequenced) {
+		t.Fatalf("unsupported capabilities should be dropped, got %d", acct.GetCapabilities())
+	}
+
+	acct.SendProtoMessage(&pbGlobal.S2C_ServerTime{Timestamp: 2})
+	msg, ok := sock.sent[1].(*pbGlobal.S2C_SequencedMessage)
+	if !ok || msg.GetSeq() != 1 || msg.GetInnerMsgCrc() != transport.MessageID(&pbGlobal.S2C_ServerTime{}) {
+		t.Fatalf("sequenced account should wrap message, got %v", sock.sent[1])
+	}
+
+	if !acct.CanResume(0) {
+		t.Fatal("sequenced account should resume from seq 0")
+	}
+}
@@ -0,0 +1,517 @@
+package player
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel/auto"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	pbCombat "github.com/east-eden/server/proto/server/combat"
+	pbRank "github.com/east-eden/server/proto/server/rank"
+	"github.com/east-eden/server/store"
+	"github.com/east-eden/server/utils"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrArenaNotOpen            = errors.New("arena season not open")
+	ErrArenaInvalidConfig      = errors.New("invalid arena config")
+	ErrArenaNoDefence          = errors.New("arena defence battle array not saved")
+	ErrArenaInvalidBattleArray = errors.New("invalid arena battle array")
+	ErrArenaInvalidTarget      = errors.New("invalid arena target")
+)
+
+// 竞技场: 积分保存在rank服务的赛季分榜中, 防守阵容快照和防守记录保存在公共表中
+type ArenaManager struct {
+	owner      *Player                                  `bson:"-" json:"-"`
+	opponents  map[int64]*pbGlobal.ArenaDefenceSnapshot `bson:"-" json:"-"`                                 // 当前匹配到的对手防守快照
+	Season     int32                                    `bson:"arena_season" json:"arena_season"`           // 当前所在赛季
+	SettleTime int64                                    `bson:"arena_settle_time" json:"arena_settle_time"` // 上次每日结算时间
+}
+
+func NewArenaManager(owner *Player) *ArenaManager {
+	m := &ArenaManager{
+		owner:     owner,
+		opponents: make(map[int64]*pbGlobal.ArenaDefenceSnapshot),
+	}
+
+	return m
+}
+
+// 与爬塔相同在每日5点结算, 离线期间的结算在上线时补发
+func (m *ArenaManager) start() {
+	lastSettleTime := time.Unix(m.SettleTime, 0)
+	days := CalcSettleDays(time.Now(), lastSettleTime)
+	if days > define.Arena_SettleMaxDays {
+		days = define.Arena_SettleMaxDays
+	}
+
+	if days > 0 {
+		m.settleDaily(days)
+	}
+
+	m.checkSeason()
+}
+
+// 小时改变
+func (m *ArenaManager) OnHourChange(curHour int) {
+	if curHour != define.Arena_SettleHour {
+		return
+	}
+
+	// 先结算旧赛季最后一天的排名, 再结算赛季
+	m.settleDaily(1)
+	m.checkSeason()
+}
+
+// 每日结算: 按每个结算时间点的排行快照发送排名奖励并补满挑战次数
+func (m *ArenaManager) settleDaily(days int) {
+	settleTime := define.ArenaDailySettleTime(time.Now().Unix())
+	for d := days - 1; d >= 0; d-- {
+		m.sendRankReward(define.ArenaReward_Type_Daily, m.Season, settleTime-int64(d)*86400)
+	}
+
+	if globalConfig, ok := auto.GetGlobalConfig(); ok {
+		times, _ := m.owner.TokenManager().GetToken(define.Token_Arena)
+		if times < globalConfig.ArenaDailyChallengeTimes {
+			err := m.owner.TokenManager().TokenSet(define.Token_Arena, globalConfig.ArenaDailyChallengeTimes)
+			utils.ErrPrint(err, "TokenSet failed when ArenaManager.settleDaily", m.owner.ID)
+		}
+	}
+
+	m.SettleTime = time.Now().Unix()
+
+	// save
+	fields := map[string]any{
+		"arena_settle_time": m.SettleTime,
+	}
+
+	err := store.GetStore().UpdateFields(context.Background(), define.StoreType_Player, m.owner.ID, fields)
+	utils.ErrPrint(err, "UpdateFields failed when ArenaManager.settleDaily", m.owner.ID, fields)
+}
+
+// 赛季切换: 发送上赛季排名奖励, 已设置防守阵容时自动加入新赛季
+func (m *ArenaManager) checkSeason() {
+	globalConfig, ok := auto.GetGlobalConfig()
+	if !ok {
+		return
+	}
+
+	season := define.ArenaSeason(time.Now().Unix(), int64(globalConfig.ArenaSeasonBeginTime), globalConfig.ArenaSeasonDays)
+	if season == m.Season {
+		return
+	}
+
+	seasonEndTime := define.ArenaSeasonEndTime(m.Season, int64(globalConfig.ArenaSeasonBeginTime), globalConfig.ArenaSeasonDays)
+	m.sendRankReward(define.ArenaReward_Type_Season, m.Season, seasonEndTime)
+
+	m.Season = season
+	m.opponents = make(map[int64]*pbGlobal.ArenaDefenceSnapshot)
+
+	// save
+	fields := map[string]any{
+		"arena_season": m.Season,
+	}
+
+	err := store.GetStore().UpdateFields(context.Background(), define.StoreType_Player, m.owner.ID, fields)
+	utils.ErrPrint(err, "UpdateFields failed when ArenaManager.checkSeason", m.owner.ID, fields)
+
+	if m.Season > 0 && m.owner.GetBattleArray(define.BattleArray_Type_ArenaDefence) != nil {
+		_, err = m.join()
+		utils.ErrPrint(err, "join failed when ArenaManager.checkSeason", m.owner.ID, m.Season)
+	}
+}
+
+// 排名对应的奖励, 排名从1开始
+func getArenaRewardEntry(rewardType int32, rank int32) *auto.ArenaRewardEntry {
+	for _, entry := range auto.GetArenaRewardRows() {
+		if entry.Type == rewardType && rank >= entry.RankMin && rank <= entry.RankMax {
+			return entry
+		}
+	}
+
+	return nil
+}
+
+// 按结算时间点的赛季排行快照发送奖励邮件
+func (m *ArenaManager) sendRankReward(rewardType int32, season int32, settleTime int64) {
+	if season <= 0 {
+		return
+	}
+
+	rsp, err := m.owner.acct.rpcCaller.CallQueryRankSnapshot(&pbRank.QueryRankSnapshotRq{
+		RankId:       define.ArenaRankId(season),
+		SnapshotTime: settleTime,
+		ObjId:        m.owner.ID,
+	})
+	if !utils.ErrCheck(err, "CallQueryRankSnapshot failed when ArenaManager.sendRankReward", m.owner.ID, season, settleTime) {
+		return
+	}
+
+	// 没有参与该赛季时不在排行中
+	if rsp.GetRankIndex() < 0 {
+		return
+	}
+
+	rank := rsp.GetRankIndex() + 1
+	entry := getArenaRewardEntry(rewardType, rank)
+	if entry == nil {
+		return
+	}
+
+	lootList := m.owner.CostLootManager().GenLootList(entry.RewardId)
+	attachments := &define.MailAttachments{
+		Attachments: m.owner.CostLootManager().PackLootList(lootList),
+	}
+
+	m.owner.MailController().SendArenaRewardMail(m.owner.ID, rewardType, rank, attachments)
+}
+
+// 加入当前赛季排行并返回当前积分, 已在排行中时积分不变
+func (m *ArenaManager) join() (*pbGlobal.RankMetadata, error) {
+	if m.Season <= 0 {
+		return nil, ErrArenaNotOpen
+	}
+
+	globalConfig, ok := auto.GetGlobalConfig()
+	if !ok {
+		return nil, ErrArenaInvalidConfig
+	}
+
+	rsp, err := m.owner.acct.rpcCaller.CallIncrRankScore(&pbRank.IncrRankScoreRq{
+		RankId: define.ArenaRankId(m.Season),
+		Metadata: &pbGlobal.RankMetadata{
+			ObjId:   m.owner.ID,
+			ObjName: m.owner.Name,
+			Score:   float64(globalConfig.ArenaInitScore),
+			Date:    time.Now().Unix(),
+		},
+		Delta: 0,
+	})
+	if !utils.ErrCheck(err, "CallIncrRankScore failed when ArenaManager.join", m.owner.ID, m.Season) {
+		return nil, err
+	}
+
+	return rsp.GetMetadata(), nil
+}
+
+// 防守布阵改变时保存快照, 被挑战时使用快照战斗
+func (m *ArenaManager) onDefenceChanged() {
+	battleArray := m.owner.GetBattleArray(define.BattleArray_Type_ArenaDefence)
+	if battleArray == nil {
+		err := store.GetStore().DeleteOne(context.Background(), define.StoreType_ArenaDefence, m.owner.ID)
+		utils.ErrPrint(err, "DeleteOne failed when ArenaManager.onDefenceChanged", m.owner.ID)
+		return
+	}
+
+	snapshot := &pbGlobal.ArenaDefenceSnapshot{
+		EntityList: m.owner.HeroManager().GenCombatEntityInfo(battleArray),
+	}
+
+	data, err := proto.Marshal(snapshot)
+	if !utils.ErrCheck(err, "proto.Marshal failed when ArenaManager.onDefenceChanged", m.owner.ID) {
+		return
+	}
+
+	defence := &define.ArenaDefence{
+		PlayerId:   m.owner.ID,
+		Name:       m.owner.Name,
+		Level:      m.owner.Level,
+		UpdateTime: time.Now().Unix(),
+		Data:       data,
+	}
+
+	err = store.GetStore().UpdateOne(context.Background(), define.StoreType_ArenaDefence, m.owner.ID, defence)
+	utils.ErrPrint(err, "UpdateOne failed when ArenaManager.onDefenceChanged", m.owner.ID)
+
+	if m.Season > 0 {
+		_, err = m.join()
+		utils.ErrPrint(err, "join failed when ArenaManager.onDefenceChanged", m.owner.ID, m.Season)
+	}
+}
+
+// 查询赛季信息
+func (m *ArenaManager) QueryInfo() error {
+	msg := &pbGlobal.S2C_ArenaInfo{
+		Season:    m.Season,
+		RankIndex: -1,
+	}
+
+	if globalConfig, ok := auto.GetGlobalConfig(); ok && m.Season > 0 {
+		msg.SeasonEndTime = define.ArenaSeasonEndTime(m.Season, int64(globalConfig.ArenaSeasonBeginTime), globalConfig.ArenaSeasonDays)
+	}
+
+	if m.Season > 0 {
+		rsp, err := m.owner.acct.rpcCaller.CallQueryRankByObjId(&pbRank.QueryRankByObjIdRq{
+			RankId: define.ArenaRankId(m.Season),
+			ObjId:  m.owner.ID,
+		})
+
+		// 没有加入赛季时不在排行中
+		if err == nil {
+			msg.Score = rsp.GetMetadata().GetScore()
+			msg.RankIndex = rsp.GetRankIndex()
+		}
+	}
+
+	m.owner.SendProtoMessage(msg)
+	return nil
+}
+
+// 在自己积分附近匹配对手, 候选对手不足时扩大积分区间
+func (m *ArenaManager) Match() error {
+	if m.owner.GetBattleArray(define.BattleArray_Type_ArenaDefence) == nil {
+		return ErrArenaNoDefence
+	}
+
+	globalConfig, ok := auto.GetGlobalConfig()
+	if !ok {
+		return ErrArenaInvalidConfig
+	}
+
+	self, err := m.join()
+	if err != nil {
+		return err
+	}
+
+	candidates := make([]*pbGlobal.RankMetadata, 0, define.Arena_MatchCandidateNum)
+	scoreRange := float64(globalConfig.ArenaMatchScoreRange)
+	for n := 0; n < define.Arena_MatchTimes; n++ {
+		rsp, err := m.owner.acct.rpcCaller.CallQueryRankByScore(&pbRank.QueryRankByScoreRq{
+			RankId: define.ArenaRankId(m.Season),
+			Min:    self.GetScore() - scoreRange,
+			Max:    self.GetScore() + scoreRange,
+			Limit:  define.Arena_MatchCandidateNum,
+		})
+		if !utils.ErrCheck(err, "CallQueryRankByScore failed when ArenaManager.Match", m.owner.ID, m.Season) {
+			return err
+		}
+
+		candidates = candidates[:0]
+		for _, metadata := range rsp.GetMetadatas() {
+			if metadata.GetObjId() != m.owner.ID {
+				candidates = append(candidates, metadata)
+			}
+		}
+
+		if len(candidates) >= define.Arena_MatchNum {
+			break
+		}
+
+		scoreRange *= 2
+	}
+
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	m.opponents = make(map[int64]*pbGlobal.ArenaDefenceSnapshot, define.Arena_MatchNum)
+	msg := &pbGlobal.S2C_ArenaMatch{
+		Opponents: make([]*pbGlobal.ArenaOpponent, 0, define.Arena_MatchNum),
+	}
+
+	for _, metadata := range candidates {
+		if len(m.opponents) >= define.Arena_MatchNum {
+			break
+		}
+
+		// 清除了防守布阵的玩家不参与匹配
+		defence := &define.ArenaDefence{}
+		err := store.GetStore().FindOne(context.Background(), define.StoreType_ArenaDefence, metadata.GetObjId(), defence)
+		if err != nil {
+			continue
+		}
+
+		snapshot := &pbGlobal.ArenaDefenceSnapshot{}
+		err = proto.Unmarshal(defence.Data, snapshot)
+		if !utils.ErrCheck(err, "proto.Unmarshal failed when ArenaManager.Match", m.owner.ID, defence.PlayerId) {
+			continue
+		}
+
+		m.opponents[defence.PlayerId] = snapshot
+
+		opponent := &pbGlobal.ArenaOpponent{
+			PlayerId:    defence.PlayerId,
+			Name:        defence.Name,
+			Level:       defence.Level,
+			Score:       metadata.GetScore(),
+			HeroTypeIds: make([]int32, 0, len(snapshot.GetEntityList())),
+		}
+
+		for _, entity := range snapshot.GetEntityList() {
+			opponent.HeroTypeIds = append(opponent.HeroTypeIds, entity.GetHeroTypeId())
+		}
+
+		msg.Opponents = append(msg.Opponents, opponent)
+	}
+
+	m.owner.SendProtoMessage(msg)
+	return nil
+}
+
+// 使用竞技场进攻布阵挑战匹配到的对手, 双方积分按elo计算
+func (m *ArenaManager) Challenge(targetId int64) error {
+	snapshot, ok := m.opponents[targetId]
+	if !ok {
+		return ErrArenaInvalidTarget
+	}
+
+	battleArray := m.owner.GetBattleArray(define.BattleArray_Type_ArenaAttack)
+	if battleArray == nil {
+		return ErrArenaInvalidBattleArray
+	}
+
+	if err := m.owner.TokenManager().CanCost(define.Token_Arena, 1); err != nil {
+		return err
+	}
+
+	globalConfig, ok := auto.GetGlobalConfig()
+	if !ok {
+		return ErrArenaInvalidConfig
+	}
+
+	self, err := m.join()
+	if err != nil {
+		return err
+	}
+
+	// 对手积分以挑战时为准
+	target, err := m.owner.acct.rpcCaller.CallQueryRankByObjId(&pbRank.QueryRankByObjIdRq{
+		RankId: define.ArenaRankId(m.Season),
+		ObjId:  targetId,
+	})
+	if !utils.ErrCheck(err, "CallQueryRankByObjId failed when ArenaManager.Challenge", m.owner.ID, targetId) {
+		return ErrArenaInvalidTarget
+	}
+
+	entityList := m.owner.HeroManager().GenCombatEntityInfo(battleArray)
+	if len(entityList) == 0 {
+		return ErrArenaInvalidBattleArray
+	}
+
+	// 战斗
+	rsp, err := m.owner.acct.rpcCaller.CallArenaCombat(&pbCombat.ArenaCombatRq{
+		AttackId:          m.owner.ID,
+		AttackEntityList:  entityList,
+		DefenceId:         targetId,
+		DefenceEntityList: snapshot.GetEntityList(),
+	})
+	if !utils.ErrCheck(err, "CallArenaCombat failed when ArenaManager.Challenge", m.owner.ID, targetId) {
+		return err
+	}
+
+	err = m.owner.TokenManager().DoCost(define.Token_Arena, 1)
+	utils.ErrPrint(err, "DoCost failed when ArenaManager.Challenge", m.owner.ID)
+
+	delete(m.opponents, targetId)
+
+	// 积分变化
+	now := time.Now().Unix()
+	delta := define.ArenaEloDelta(self.GetScore(), target.GetMetadata().GetScore(), globalConfig.ArenaEloK, rsp.GetWin())
+	attackRsp, err := m.owner.acct.rpcCaller.CallIncrRankScore(&pbRank.IncrRankScoreRq{
+		RankId: define.ArenaRankId(m.Season),
+		Metadata: &pbGlobal.RankMetadata{
+			ObjId:    m.owner.ID,
+			ObjName:  m.owner.Name,
+			Score:    self.GetScore(),
+			Date:     now,
+			RecordId: rsp.GetRecordId(),
+		},
+		Delta: delta,
+	})
+	utils.ErrPrint(err, "CallIncrRankScore failed when ArenaManager.Challenge", m.owner.ID, delta)
+
+	_, err = m.owner.acct.rpcCaller.CallIncrRankScore(&pbRank.IncrRankScoreRq{
+		RankId:   define.ArenaRankId(m.Season),
+		Metadata: target.GetMetadata(),
+		Delta:    -delta,
+	})
+	utils.ErrPrint(err, "CallIncrRankScore failed when ArenaManager.Challenge", targetId, -delta)
+
+	m.addDefenceLog(targetId, !rsp.GetWin(), -delta, rsp.GetRecordId(), now)
+
+	msg := &pbGlobal.S2C_ArenaChallenge{
+		TargetId:    targetId,
+		Win:         rsp.GetWin(),
+		ScoreChange: delta,
+		Score:       attackRsp.GetMetadata().GetScore(),
+		RecordId:    rsp.GetRecordId(),
+	}
+	m.owner.SendProtoMessage(msg)
+	return nil
+}
+
+// 给防守方添加防守记录
+func (m *ArenaManager) addDefenceLog(defenceId int64, win bool, scoreChange float64, recordId int64, t int64) {
+	id, err := utils.NextID(define.SnowFlake_ArenaLog)
+	if !utils.ErrCheck(err, "NextID failed when ArenaManager.addDefenceLog", m.owner.ID, defenceId) {
+		return
+	}
+
+	l := &define.ArenaLog{
+		Id:          id,
+		DefenceId:   defenceId,
+		AttackId:    m.owner.ID,
+		AttackName:  m.owner.Name,
+		Win:         win,
+		ScoreChange: scoreChange,
+		RecordId:    recordId,
+		Time:        t,
+	}
+
+	err = store.GetStore().UpdateOne(context.Background(), define.StoreType_ArenaLog, id, l)
+	utils.ErrPrint(err, "UpdateOne failed when ArenaManager.addDefenceLog", m.owner.ID, defenceId)
+}
+
+// 查询防守记录, 超出数量上限的旧记录在查询时删除
+func (m *ArenaManager) QueryLogs() error {
+	res, err := store.GetStore().FindAll(context.Background(), define.StoreType_ArenaLog, "defence_id", m.owner.ID)
+	if err != nil && !errors.Is(err, store.ErrNoResult) {
+		utils.ErrPrint(err, "FindAll failed when ArenaManager.QueryLogs", m.owner.ID)
+		return err
+	}
+
+	logs := make([]*define.ArenaLog, 0, len(res))
+	for _, v := range res {
+		l := &define.ArenaLog{}
+		err := json.Unmarshal(v.([]byte), l)
+		if !utils.ErrCheck(err, "json.Unmarshal failed when ArenaManager.QueryLogs", m.owner.ID) {
+			continue
+		}
+
+		logs = append(logs, l)
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].Time == logs[j].Time {
+			return logs[i].Id > logs[j].Id
+		}
+
+		return logs[i].Time > logs[j].Time
+	})
+
+	if len(logs) > define.Arena_LogMaxNum {
+		for _, l := range logs[define.Arena_LogMaxNum:] {
+			err := store.GetStore().DeleteOne(context.Background(), define.StoreType_ArenaLog, l.Id)
+			utils.ErrPrint(err, "DeleteOne failed when ArenaManager.QueryLogs", m.owner.ID, l.Id)
+		}
+
+		logs = logs[:define.Arena_LogMaxNum]
+	}
+
+	msg := &pbGlobal.S2C_ArenaLogs{
+		Logs: make([]*pbGlobal.ArenaLog, 0, len(logs)),
+	}
+
+	for _, l := range logs {
+		msg.Logs = append(msg.Logs, l.ToPB())
+	}
+
+	m.owner.SendProtoMessage(msg)
+	return nil
+}
@@ -0,0 +1,110 @@
+package player
+
+import (
+	"testing"
+	"time"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel"
+	"github.com/east-eden/server/excel/auto"
+)
+
+func TestArenaEloDelta(t *testing.T) {
+	cases := []struct {
+		attack  float64
+		defence float64
+		win     bool
+		expect  float64
+	}{
+		{attack: 1000, defence: 1000, win: true, expect: 16},
+		{attack: 1000, defence: 1000, win: false, expect: -16},
+		{attack: 1000, defence: 1400, win: true, expect: 29}, // 战胜强敌加分多
+		{attack: 1400, defence: 1000, win: true, expect: 3},  // 战胜弱敌加分少
+		{attack: 1400, defence: 1000, win: false, expect: -29},
+	}
+
+	for idx, c := range cases {
+		delta := define.ArenaEloDelta(c.attack, c.defence, 32, c.win)
+		if delta != c.expect {
+			t.Fatalf("case %d elo delta expect %v, got %v", idx, c.expect, delta)
+		}
+	}
+}
+
+func TestArenaSeason(t *testing.T) {
+	var begin int64 = 1609448400
+	if s := define.ArenaSeason(begin-1, begin, 14); s != 0 {
+		t.Fatalf("season before begin should be 0, got %d", s)
+	}
+
+	if s := define.ArenaSeason(begin, begin, 14); s != 1 {
+		t.Fatalf("first season expect 1, got %d", s)
+	}
+
+	end := define.ArenaSeasonEndTime(1, begin, 14)
+	if s := define.ArenaSeason(end-1, begin, 14); s != 1 {
+		t.Fatalf("season before end expect 1, got %d", s)
+	}
+
+	if s := define.ArenaSeason(end, begin, 14); s != 2 {
+		t.Fatalf("season after end expect 2, got %d", s)
+	}
+}
+
+func TestArenaSnapshotTime(t *testing.T) {
+	settle := time.Date(2021, 1, 2, define.Arena_SettleHour, 0, 0, 0, time.Local).Unix()
+	if st := define.ArenaDailySettleTime(settle - 1); st != settle-86400 {
+		t.Fatalf("settle time before settle hour should be yesterday, got %d", st)
+	}
+
+	if st := define.ArenaDailySettleTime(settle + 3600); st != settle {
+		t.Fatalf("settle time after settle hour should be today, got %d", st)
+	}
+
+	// 赛季结束后固定为赛季结束时间
+	begin := settle - 14*86400 + 3600
+	end := define.ArenaSeasonEndTime(1, begin, 14)
+	if st := define.ArenaSnapshotTime(settle+7200, 1, begin, 14); st != end {
+		t.Fatalf("snapshot time after season end expect %d, got %d", end, st)
+	}
+
+	if st := define.ArenaSnapshotTime(settle+1800, 1, begin, 14); st != settle {
+		t.Fatalf("snapshot time before season end expect %d, got %d", settle, st)
+	}
+}
+
+func TestArenaRewardEntry(t *testing.T) {
+	err := (&auto.ArenaRewardEntries{}).Load(&excel.ExcelFileRaw{
+		Filename: "ArenaReward.csv",
+		CellData: []excel.ExcelRowData{
+			{"Id": int32(1), "Type": define.ArenaReward_Type_Daily, "RankMin": int32(1), "RankMax": int32(1), "RewardId": int32(4)},
+			{"Id": int32(2), "Type": define.ArenaReward_Type_Daily, "RankMin": int32(2), "RankMax": int32(10), "RewardId": int32(3)},
+			{"Id": int32(101), "Type": define.ArenaReward_Type_Season, "RankMin": int32(1), "RankMax": int32(10), "RewardId": int32(7)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("load arena reward entries failed: %v", err)
+	}
+
+	cases := []struct {
+		tp     int32
+		rank   int32
+		expect int32
+	}{
+		{tp: define.ArenaReward_Type_Daily, rank: 1, expect: 1},
+		{tp: define.ArenaReward_Type_Daily, rank: 10, expect: 2},
+		{tp: define.ArenaReward_Type_Daily, rank: 11, expect: -1},
+		{tp: define.ArenaReward_Type_Season, rank: 5, expect: 101},
+	}
+
+	for idx, c := range cases {
+		var id int32 = -1
+		if entry := getArenaRewardEntry(c.tp, c.rank); entry != nil {
+			id = entry.Id
+		}
+
+		if id != c.expect {
+			t.Fatalf("case %d arena reward entry expect %d, got %d", idx, c.expect, id)
+		}
+	}
+}
@@ -9,14 +9,18 @@ import (
 	"github.com/east-eden/server/define"
 	"github.com/east-eden/server/excel/auto"
 	pbGlobal "github.com/east-eden/server/proto/global"
+	pbCombat "github.com/east-eden/server/proto/server/combat"
+	"github.com/east-eden/server/services/game/prom"
 	"github.com/east-eden/server/store"
 	"github.com/east-eden/server/utils"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cast"
 	"github.com/valyala/bytebufferpool"
 )
 
 var (
 	chapterStageUpdateInterval      = time.Second * 5 // 每5秒更新一次
+	StageVerifyClient               = false           // 校验客户端预测的关卡战斗结果
 	ErrInvalidRequest               = errors.New("invalid request")
 	ErrChapterNotFound              = errors.New("chapter not found")
 	ErrChapterRewardAlready         = errors.New("chapter reward received already")
@@ -26,6 +30,7 @@ var (
 	ErrStageChallengeTimesLimit     = errors.New("stage challenge times limit")
 	ErrStageChallengeStrengthLimit  = errors.New("stage challenge strength limit")
 	ErrStageChallengeSweepCostLimit = errors.New("stage sweep cost limit")
+	ErrStageBattleArrayEmpty        = errors.New("stage battle array empty")
 )
 
 func makeChapterKey(chapterId int32, fields ...string) string {
@@ -92,6 +97,13 @@ func (s *Stage) GenStagePB() *pbGlobal.Stage {
 	return pb
 }
 
+// 关卡战斗结果
+type StageResult struct {
+	Win        bool
+	Achieve    bool
+	Objectives [define.Stage_Objective_Num]bool
+}
+
 type ChapterStageManager struct {
 	owner      *Player            `bson:"-" json:"-"`
 	nextUpdate int64              `bson:"-" json:"-"`                       // 下次更新时间
@@ -132,7 +144,7 @@ func (m *ChapterStageManager) onDayChange() {
 	}
 }
 
-// 关卡挑战
+// 关卡挑战, 战斗结果以combat服务为准, win, achieve, objectives为客户端预测结果
 func (m *ChapterStageManager) StageChallenge(stageId int32, win bool, achieve bool, objectives []bool) error {
 	stageEntry, ok := auto.GetStageEntry(stageId)
 	if !ok {
@@ -163,8 +175,31 @@ func (m *ChapterStageManager) StageChallenge(stageId int32, win bool, achieve bo
 		return ErrStageChallengeStrengthLimit
 	}
 
+	// 布阵
+	entityList := m.owner.HeroManager().GenCombatEntityInfo(m.owner.GetBattleArray(define.BattleArray_Type_Stage))
+	if len(entityList) == 0 {
+		return ErrStageBattleArrayEmpty
+	}
+
+	// 战斗
+	rsp, err := m.owner.acct.rpcCaller.CallStageCombat(&pbCombat.StageCombatRq{
+		StageId:          stageId,
+		AttackId:         m.owner.ID,
+		AttackEntityList: entityList,
+	})
+	if !utils.ErrCheck(err, "CallStageCombat failed when ChapterStageManager.StageChallenge", m.owner.ID, stageId) {
+		return err
+	}
+
 	_ = m.owner.TokenManager().DoCost(define.Token_Strength, stageEntry.CostStrength)
 
+	result := m.CalcStageResult(stageEntry, rsp)
+	if StageVerifyClient {
+		m.verifyClientResult(stageId, result, win, achieve, objectives)
+	}
+
+	m.SendStageCombat(stageId, result, rsp)
+
 	// 通关处理
 	if !stageExist {
 		stage = &Stage{
@@ -175,7 +210,7 @@ func (m *ChapterStageManager) StageChallenge(stageId int32, win bool, achieve bo
 		}
 
 		// 首次通关奖励
-		if win {
+		if result.Win {
 			stage.Pass = true
 			err := m.owner.CostLootManager().GainLoot(stageEntry.FirstRewardLootId)
 			utils.ErrPrint(err, "Stage FirstPass GainLoot first reward failed when ChapterStageManager.StagePass", m.owner.ID, stageId)
@@ -183,14 +218,14 @@ func (m *ChapterStageManager) StageChallenge(stageId int32, win bool, achieve bo
 	}
 
 	// 通关奖励
-	if win {
+	if result.Win {
 		stage.Pass = true
 		err := m.owner.CostLootManager().GainLoot(stageEntry.RewardLootId)
 		utils.ErrPrint(err, "StagePass GainLoot failed when ChapterStageManager.StagePass", m.owner.ID, stageId)
 	}
 
 	// 成就达成
-	if achieve && !stage.Achieve {
+	if result.Achieve && !stage.Achieve {
 		stage.Achieve = true
 		err := m.owner.CostLootManager().GainLoot(stageEntry.AchieveLootId)
 		utils.ErrPrint(err, "Stage Achieve GainLoot failed when ChapterStageManager.StagePass", m.owner.ID, stageId)
@@ -198,8 +233,8 @@ func (m *ChapterStageManager) StageChallenge(stageId int32, win bool, achieve bo
 
 	// 更新关卡目标达成状况
 	var addStar int32
-	for k := range objectives {
-		if !objectives[k] {
+	for k := range result.Objectives {
+		if !result.Objectives[k] {
 			continue
 		}
 
@@ -233,8 +268,10 @@ func (m *ChapterStageManager) StageChallenge(stageId int32, win bool, achieve bo
 	}
 
 	fields := map[string]any{
-		makeChapterKey(chapter.Id): chapter,
-		makeStageKey(stage.Id):     stage,
+		makeStageKey(stage.Id): stage,
+	}
+	if chapter != nil {
+		fields[makeChapterKey(chapter.Id)] = chapter
 	}
 	err = store.GetStore().UpdateFields(context.Background(), define.StoreType_Player, m.owner.ID, fields)
 	utils.ErrPrint(err, "UpdateFields failed when ChapterStageManager.StagePass", m.owner.ID, fields)
@@ -243,6 +280,76 @@ func (m *ChapterStageManager) StageChallenge(stageId int32, win bool, achieve bo
 	return nil
 }
 
+// 根据战斗统计计算关卡结果
+func (m *ChapterStageManager) CalcStageResult(stageEntry *auto.StageEntry, rsp *pbCombat.StageCombatRs) *StageResult {
+	result := &StageResult{}
+	stats := rsp.GetStatistics()
+	if stats == nil {
+		return result
+	}
+
+	cm := m.owner.ConditionManager()
+
+	// 胜负条件, 没有配置时以战斗结果为准
+	result.Win = rsp.GetWin()
+	if stageEntry.WinCondition != -1 {
+		result.Win = cm.CheckCombatCondition(stageEntry.WinCondition, stats)
+	}
+
+	if stageEntry.LostCondition != -1 && cm.CheckCombatCondition(stageEntry.LostCondition, stats) {
+		result.Win = false
+	}
+
+	if !result.Win {
+		return result
+	}
+
+	// 成就条件
+	if stageEntry.AchieveConditionId != -1 {
+		result.Achieve = cm.CheckCombatCondition(stageEntry.AchieveConditionId, stats)
+	}
+
+	// 星级条件
+	for k, conditionId := range stageEntry.StarConditionIds {
+		if k >= define.Stage_Objective_Num {
+			break
+		}
+
+		if conditionId == -1 {
+			continue
+		}
+
+		result.Objectives[k] = cm.CheckCombatCondition(conditionId, stats)
+	}
+
+	return result
+}
+
+// 客户端预测结果与服务器不一致时记录, 供反作弊审查
+func (m *ChapterStageManager) verifyClientResult(stageId int32, result *StageResult, win bool, achieve bool, objectives []bool) bool {
+	match := result.Win == win && result.Achieve == achieve
+	for k := range result.Objectives {
+		clientObjective := k < len(objectives) && objectives[k]
+		if result.Objectives[k] != clientObjective {
+			match = false
+		}
+	}
+
+	if !match {
+		prom.OpsStageResultMismatchCounter.Inc()
+		log.Warn().
+			Int64("player_id", m.owner.ID).
+			Int32("stage_id", stageId).
+			Interface("server_result", result).
+			Bool("client_win", win).
+			Bool("client_achieve", achieve).
+			Bools("client_objectives", objectives).
+			Msg("stage result mismatch with client")
+	}
+
+	return match
+}
+
 // 领取章节奖励
 func (m *ChapterStageManager) ChapterReward(chapterId int32, index int32) error {
 	chapterEntry, ok := auto.GetChapterEntry(chapterId)
@@ -388,3 +495,15 @@ func (m *ChapterStageManager) SendStageUpdate(s *Stage) {
 	}
 	m.owner.SendProtoMessage(msg)
 }
+
+// 发送战斗结果和事件流, 客户端据此播放战斗
+func (m *ChapterStageManager) SendStageCombat(stageId int32, result *StageResult, rsp *pbCombat.StageCombatRs) {
+	msg := &pbGlobal.S2C_StageCombat{
+		StageId:    stageId,
+		Win:        result.Win,
+		Statistics: rsp.GetStatistics(),
+		RecordId:   rsp.GetRecordId(),
+		Events:     rsp.GetEvents(),
+	}
+	m.owner.SendProtoMessage(msg)
+}
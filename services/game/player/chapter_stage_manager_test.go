@@ -0,0 +1,179 @@
+package player
+
+import (
+	"os"
+	"testing"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel"
+	"github.com/east-eden/server/excel/auto"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	pbCombat "github.com/east-eden/server/proto/server/combat"
+	"github.com/google/go-cmp/cmp"
+)
+
+// 测试用的战斗条件
+var stageTestConditions = []*auto.ConditionEntry{
+	{Id: 1, Type: define.Condition_Type_Or, SubTypes: []int32{define.Condition_SubType_KillAllEnemy, -1}, SubValues: []int32{0, 0}},
+	{Id: 2, Type: define.Condition_Type_Or, SubTypes: []int32{define.Condition_SubType_OurUnitAllDead, -1}, SubValues: []int32{0, 0}},
+	{Id: 90001, Type: define.Condition_Type_And, SubTypes: []int32{define.Condition_SubType_OurUnitDeadLessThan, define.Condition_SubType_CombatPassTimeLessThan}, SubValues: []int32{2, 60}},
+	{Id: 90002, Type: define.Condition_Type_Or, SubTypes: []int32{define.Condition_SubType_KillEnemyTypeIdFirst, define.Condition_SubType_OurUnitCastUltimateSkill}, SubValues: []int32{3001, 3}},
+	{Id: 90003, Type: define.Condition_Type_Or, SubTypes: []int32{define.Condition_SubType_CombatRoundLessThan, -1}, SubValues: []int32{100, 0}},
+	{Id: 90004, Type: define.Condition_Type_Or, SubTypes: []int32{define.Condition_SubType_CombatWaveReached, -1}, SubValues: []int32{2, 0}},
+}
+
+func loadStageTestConditions(t *testing.T) {
+	t.Helper()
+
+	// 已读取配置时只追加测试条件
+	if _, err := os.Stat("config/csv/Condition.csv"); err == nil {
+		rows := auto.GetConditionRows()
+		for _, entry := range stageTestConditions[2:] {
+			rows[entry.Id] = entry
+		}
+
+		t.Cleanup(func() {
+			for _, entry := range stageTestConditions[2:] {
+				delete(rows, entry.Id)
+			}
+		})
+		return
+	}
+
+	cellData := make([]excel.ExcelRowData, 0, len(stageTestConditions))
+	for _, entry := range stageTestConditions {
+		cellData = append(cellData, excel.ExcelRowData{
+			"Id":        entry.Id,
+			"Type":      entry.Type,
+			"SubTypes":  entry.SubTypes,
+			"SubValues": entry.SubValues,
+		})
+	}
+
+	err := (&auto.ConditionEntries{}).Load(&excel.ExcelFileRaw{
+		Filename: "Condition.csv",
+		CellData: cellData,
+	})
+	if err != nil {
+		t.Fatalf("load condition entries failed: %v", err)
+	}
+}
+
+func TestCalcStageResult(t *testing.T) {
+	loadStageTestConditions(t)
+
+	owner := &Player{}
+	owner.Init(playerId)
+
+	stageEntry := &auto.StageEntry{
+		Id:                 1001001,
+		WinCondition:       1,
+		LostCondition:      2,
+		AchieveConditionId: 90002,
+		StarConditionIds:   []int32{1, 90001, -1},
+	}
+
+	cases := []struct {
+		name  string
+		rsp   *pbCombat.StageCombatRs
+		claim [define.Stage_Objective_Num]bool
+		want  StageResult
+	}{
+		{
+			name: "no statistics",
+			rsp:  &pbCombat.StageCombatRs{Win: true},
+			want: StageResult{},
+		},
+		{
+			name: "lose",
+			rsp: &pbCombat.StageCombatRs{
+				Win:        false,
+				Statistics: &pbGlobal.CombatStatistics{AttackUnitNum: 2, AttackDeadNum: 2, DefenceUnitNum: 3, DefenceDeadNum: 1, KillOrder: []int32{3001}},
+			},
+			want: StageResult{},
+		},
+		{
+			name: "win with one dead",
+			rsp: &pbCombat.StageCombatRs{
+				Win:        true,
+				Statistics: &pbGlobal.CombatStatistics{AttackUnitNum: 2, AttackDeadNum: 1, DefenceUnitNum: 2, DefenceDeadNum: 2, PassTime: 30, KillOrder: []int32{3002, 3001}},
+			},
+			want: StageResult{Win: true, Objectives: [define.Stage_Objective_Num]bool{true, true, false}},
+		},
+		{
+			name: "win slowly with achieve",
+			rsp: &pbCombat.StageCombatRs{
+				Win:        true,
+				Statistics: &pbGlobal.CombatStatistics{AttackUnitNum: 2, DefenceUnitNum: 2, DefenceDeadNum: 2, PassTime: 90, KillOrder: []int32{3001, 3002}},
+			},
+			want: StageResult{Win: true, Achieve: true, Objectives: [define.Stage_Objective_Num]bool{true, false, false}},
+		},
+	}
+
+	for _, c := range cases {
+		got := owner.ChapterStageManager.CalcStageResult(stageEntry, c.rsp)
+		if diff := cmp.Diff(c.want, *got); diff != "" {
+			t.Fatalf("case <%s> stage result mismatch: %s", c.name, diff)
+		}
+	}
+
+	// 没有战斗统计时战斗条件不满足
+	if owner.ConditionManager().CheckCondition(1) {
+		t.Fatal("combat condition should not pass without combat statistics")
+	}
+
+	// 客户端预测结果校验
+	result := &StageResult{Win: true, Objectives: [define.Stage_Objective_Num]bool{true, true, false}}
+	if !owner.ChapterStageManager.verifyClientResult(stageEntry.Id, result, true, false, []bool{true, true}) {
+		t.Fatal("client result should match server result")
+	}
+
+	if owner.ChapterStageManager.verifyClientResult(stageEntry.Id, result, true, false, []bool{true, true, true}) {
+		t.Fatal("client result with extra star should mismatch server result")
+	}
+}
+
+func TestCombatWaveCondition(t *testing.T) {
+	loadStageTestConditions(t)
+
+	owner := &Player{}
+	owner.Init(playerId)
+
+	cases := []struct {
+		name    string
+		stats   *pbGlobal.CombatStatistics
+		inRound bool
+		reached bool
+	}{
+		{
+			name:  "lose in first wave",
+			stats: &pbGlobal.CombatStatistics{Rounds: 50, WaveReached: 1, Waves: []*pbGlobal.WaveStatistics{{Rounds: 50}}},
+		},
+		{
+			name:    "lose in second wave",
+			stats:   &pbGlobal.CombatStatistics{Rounds: 80, WaveReached: 2, Waves: []*pbGlobal.WaveStatistics{{Rounds: 30, Cleared: true}, {StartRound: 30, Rounds: 50}}},
+			reached: true,
+		},
+		{
+			name:    "clear all waves slowly",
+			stats:   &pbGlobal.CombatStatistics{Rounds: 120, WaveReached: 2, Waves: []*pbGlobal.WaveStatistics{{Rounds: 60, Cleared: true}, {StartRound: 60, Rounds: 60, Cleared: true}}},
+			reached: true,
+		},
+		{
+			name:    "clear all waves in time",
+			stats:   &pbGlobal.CombatStatistics{Rounds: 90, WaveReached: 2, Waves: []*pbGlobal.WaveStatistics{{Rounds: 40, Cleared: true}, {StartRound: 40, Rounds: 50, Cleared: true}}},
+			inRound: true,
+			reached: true,
+		},
+	}
+
+	for _, c := range cases {
+		if got := owner.ConditionManager().CheckCombatCondition(90003, c.stats); got != c.inRound {
+			t.Fatalf("case <%s> round condition got %v, want %v", c.name, got, c.inRound)
+		}
+
+		if got := owner.ConditionManager().CheckCombatCondition(90004, c.stats); got != c.reached {
+			t.Fatalf("case <%s> wave condition got %v, want %v", c.name, got, c.reached)
+		}
+	}
+}
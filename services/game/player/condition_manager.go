@@ -5,6 +5,7 @@ import (
 
 	"github.com/east-eden/server/define"
 	"github.com/east-eden/server/excel/auto"
+	pbGlobal "github.com/east-eden/server/proto/global"
 )
 
 var (
@@ -15,8 +16,9 @@ type ConditionHandler func(value int32) bool
 type ConditionLimiter func() bool
 
 type ConditionManager struct {
-	owner    *Player                    `bson:"-" json:"-"`
-	handlers map[int32]ConditionHandler `bson:"-" json:"-"`
+	owner       *Player                    `bson:"-" json:"-"`
+	handlers    map[int32]ConditionHandler `bson:"-" json:"-"`
+	combatStats *pbGlobal.CombatStatistics `bson:"-" json:"-"` // 正在检查的战斗统计
 }
 
 func NewConditionManager(owner *Player) *ConditionManager {
@@ -38,6 +40,8 @@ func (m *ConditionManager) initHandlers() {
 	m.handlers[define.Condition_SubType_OurUnitCastUltimateSkill] = m.handleOurUnitCastUltimateSkill
 	m.handlers[define.Condition_SubType_CombatPassTimeLessThan] = m.handleCombatPassTimeLessThan
 	m.handlers[define.Condition_SubType_KillEnemyTypeIdFirst] = m.handleKillEnemyTypeIdFirst
+	m.handlers[define.Condition_SubType_CombatRoundLessThan] = m.handleCombatRoundLessThan
+	m.handlers[define.Condition_SubType_CombatWaveReached] = m.handleCombatWaveReached
 }
 
 // 检查条件是否满足
@@ -103,6 +107,16 @@ func (m *ConditionManager) CheckCondition(conditionId int32, limiters ...Conditi
 	return false
 }
 
+// 根据战斗统计检查条件是否满足
+func (m *ConditionManager) CheckCombatCondition(conditionId int32, stats *pbGlobal.CombatStatistics, limiters ...ConditionLimiter) bool {
+	m.combatStats = stats
+	defer func() {
+		m.combatStats = nil
+	}()
+
+	return m.CheckCondition(conditionId, limiters...)
+}
+
 // 队伍等级达到**级
 func (m *ConditionManager) handleTeamLevelAchieve(value int32) bool {
 	return m.owner.Level >= value
@@ -110,35 +124,82 @@ func (m *ConditionManager) handleTeamLevelAchieve(value int32) bool {
 
 // 击杀所有敌方单位
 func (m *ConditionManager) handleKillAllEnemy(value int32) bool {
-	return true
+	if m.combatStats == nil {
+		return false
+	}
+
+	return m.combatStats.DefenceUnitNum > 0 && m.combatStats.DefenceDeadNum >= m.combatStats.DefenceUnitNum
 }
 
 // 己方单位全部死亡
 func (m *ConditionManager) handleOurUnitAllDead(value int32) bool {
-	return true
+	if m.combatStats == nil {
+		return false
+	}
+
+	return m.combatStats.AttackDeadNum >= m.combatStats.AttackUnitNum
 }
 
 // 己方单位死亡人数小于*
 func (m *ConditionManager) handleOurUnitDeadLessThan(value int32) bool {
-	return true
+	if m.combatStats == nil {
+		return false
+	}
+
+	return m.combatStats.AttackDeadNum < value
 }
 
 // 成功打断*次敌方技能
 func (m *ConditionManager) handleInterruptEnemySkill(value int32) bool {
-	return true
+	if m.combatStats == nil {
+		return false
+	}
+
+	return m.combatStats.InterruptNum >= value
 }
 
 // 己方单位成功使用*次奥义技能
 func (m *ConditionManager) handleOurUnitCastUltimateSkill(value int32) bool {
-	return true
+	if m.combatStats == nil {
+		return false
+	}
+
+	return m.combatStats.UltimateSkillNum >= value
 }
 
 // 通关时间小于*秒
 func (m *ConditionManager) handleCombatPassTimeLessThan(value int32) bool {
-	return true
+	if m.combatStats == nil {
+		return false
+	}
+
+	return m.combatStats.PassTime < value
 }
 
 // 优先击杀id为*的敌方单位
 func (m *ConditionManager) handleKillEnemyTypeIdFirst(value int32) bool {
-	return true
+	if m.combatStats == nil || len(m.combatStats.KillOrder) == 0 {
+		return false
+	}
+
+	return m.combatStats.KillOrder[0] == value
+}
+
+// *回合内清除所有波次
+func (m *ConditionManager) handleCombatRoundLessThan(value int32) bool {
+	if m.combatStats == nil || len(m.combatStats.Waves) == 0 {
+		return false
+	}
+
+	lastWave := m.combatStats.Waves[len(m.combatStats.Waves)-1]
+	return lastWave.Cleared && m.combatStats.Rounds < value
+}
+
+// 到达第*波
+func (m *ConditionManager) handleCombatWaveReached(value int32) bool {
+	if m.combatStats == nil {
+		return false
+	}
+
+	return m.combatStats.WaveReached >= value
 }
@@ -904,19 +904,23 @@ func (m *HeroManager) GmPromoteChange(heroId int64, promote int32) error {
 	return err
 }
 
-func (m *HeroManager) GenCombatEntityInfo() []*pbGlobal.EntityInfo {
-	pbList := make([]*pbGlobal.EntityInfo, 0)
+// 根据布阵生成战斗单位信息
+// 按布阵位置生成战斗单位, 布阵为nil时返回空列表
+func (m *HeroManager) GenCombatEntityInfo(battleArray *define.BattleArray) []*pbGlobal.EntityInfo {
+	if battleArray == nil {
+		return []*pbGlobal.EntityInfo{}
+	}
 
-	// todo 暂时取头三个英雄
-	var n int32
-	for _, hero := range m.HeroList {
-		if n >= 3 {
-			break
+	pbList := make([]*pbGlobal.EntityInfo, 0, len(battleArray.Heroes))
+	for _, arrayHero := range battleArray.Heroes {
+		h := m.GetHero(arrayHero.HeroId)
+		if h == nil {
+			continue
 		}
 
-		pb := hero.GenEntityInfoPB()
-		pbList = append(pbList, pb)
-		n++
+		info := h.GenEntityInfoPB()
+		info.Pos = arrayHero.Pos
+		pbList = append(pbList, info)
 	}
 
 	return pbList
@@ -0,0 +1,64 @@
+package player
+
+import (
+	"context"
+	"testing"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel/auto"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHeroManagerLoadAll(t *testing.T) {
+	s := newTestStore(t)
+	loadTestEntries(t)
+
+	entry, ok := auto.GetHeroEntry(heroTypeId)
+	if !ok {
+		t.Fatalf("hero entry %d not found", heroTypeId)
+	}
+
+	owner := &Player{}
+	owner.Init(playerId)
+	h := owner.HeroManager().createEntryHero(entry)
+	if h == nil {
+		t.Fatal("createEntryHero failed")
+	}
+
+	h.Level = 10
+	if err := s.UpdateOne(context.Background(), define.StoreType_Hero, h.Id, h); err != nil {
+		t.Fatalf("UpdateOne hero failed: %v", err)
+	}
+
+	// 其他玩家的英雄不会被加载
+	other := &Player{}
+	other.Init(playerId + 1)
+	if oh := other.HeroManager().createEntryHero(entry); oh == nil || s.UpdateOne(context.Background(), define.StoreType_Hero, oh.Id, oh) != nil {
+		t.Fatal("save hero of other player failed")
+	}
+	s.Flush()
+
+	loaded := &Player{}
+	loaded.Init(playerId)
+	if err := loaded.HeroManager().LoadAll(); err != nil {
+		t.Fatalf("HeroManager.LoadAll failed: %v", err)
+	}
+
+	heroList := loaded.HeroManager().HeroList
+	if len(heroList) != 1 {
+		t.Fatalf("HeroManager.LoadAll should load 1 hero, got %d", len(heroList))
+	}
+
+	lh, ok := heroList[h.Id]
+	if !ok {
+		t.Fatalf("hero %d not loaded", h.Id)
+	}
+
+	if diff := cmp.Diff(h.GetOptions().HeroInfo, lh.GetOptions().HeroInfo); diff != "" {
+		t.Fatalf("loaded hero mismatch: %s", diff)
+	}
+
+	if lh.GetOptions().Entry != entry {
+		t.Fatal("loaded hero should be bound to hero entry")
+	}
+}
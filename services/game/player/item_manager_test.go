@@ -14,6 +14,7 @@ import (
 	"github.com/east-eden/server/logger"
 	pbGlobal "github.com/east-eden/server/proto/global"
 	"github.com/east-eden/server/store"
+	"github.com/east-eden/server/store/db"
 	"github.com/east-eden/server/utils"
 	json "github.com/json-iterator/go"
 	"github.com/msgpack/msgpack-go"
@@ -49,12 +50,10 @@ func initBenchmark() {
 	// read excel files
 	excel.ReadAllEntries("config/csv/")
 
+	// 使用内存数据库, 不依赖mongodb
 	set := flag.NewFlagSet("item_manager_test", flag.ContinueOnError)
-	set.String("redis_addr", "localhost:6379", "redis address")
-	set.String("db_dsn", "mongodb://localhost:27017", "mongodb address")
-	set.String("database", "game", "mongodb default database")
 	ctx := cli.NewContext(nil, set, nil)
-	store.NewStore(ctx)
+	store.NewStore(ctx, store.DB(db.NewMemDB()))
 
 	err := store.GetStore().MigrateDbTable("player_item", "owner_id", "item_list._id", "equip_list._id", "crystal_list._id")
 	utils.ErrPrint(err, "initBenchmark MigrateDbTable failed")
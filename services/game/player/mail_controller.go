@@ -1,6 +1,7 @@
 package player
 
 import (
+	"fmt"
 	"math/rand"
 	"time"
 
@@ -162,3 +163,29 @@ func (m *MailController) SendTowerSettleRewardMail(receiverId int64, attachments
 	newMail.FromPB(rsp.NewMail)
 	m.Mails[newMail.Id] = newMail
 }
+
+// 竞技场排名奖励
+func (m *MailController) SendArenaRewardMail(receiverId int64, rewardType int32, rank int32, attachments *define.MailAttachments) {
+	req := &pbMail.CreateMailRq{
+		ReceiverId:  receiverId,
+		Type:        pbGlobal.MailType_System,
+		SenderName:  "系统",
+		Title:       "竞技场每日排名奖励",
+		Content:     fmt.Sprintf("您在竞技场中排名第%d，这是每日排名奖励，请查收", rank),
+		Attachments: attachments.GenAttachmentsPB(),
+	}
+
+	if rewardType == define.ArenaReward_Type_Season {
+		req.Title = "竞技场赛季排名奖励"
+		req.Content = fmt.Sprintf("您在上赛季竞技场中排名第%d，这是赛季排名奖励，请查收", rank)
+	}
+
+	rsp, err := m.owner.acct.rpcCaller.CallCreateMail(req)
+	if !utils.ErrCheck(err, "CallCreateMail failed when MailController.SendArenaRewardMail", receiverId, rewardType, rank) {
+		return
+	}
+
+	newMail := &define.Mail{}
+	newMail.FromPB(rsp.NewMail)
+	m.Mails[newMail.Id] = newMail
+}
@@ -0,0 +1,95 @@
+package player
+
+import (
+	"fmt"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/store"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// 玩家数据结构版本迁移, 新增迁移时版本号在末尾递增, 已发布的迁移不能修改
+func init() {
+	store.RegisterMigration("player_token",
+		&store.Migration{Version: 1, Name: "pad tokens to Token_End", Migrate: migrateTokensPadding},
+	)
+
+	store.RegisterMigration("player",
+		&store.Migration{Version: 1, Name: "battle_array to battle_array_list", Migrate: migrateBattleArrayList},
+	)
+}
+
+// migrateTokensPadding pads tokens array to define.Token_End, null elements set by $set tokens.n are replaced with 0
+func migrateTokensPadding(doc bson.M) error {
+	var tokens bson.A
+	switch v := doc["tokens"].(type) {
+	case nil:
+	case bson.A:
+		tokens = v
+	default:
+		return fmt.Errorf("invalid tokens type %T", v)
+	}
+
+	if int32(len(tokens)) > define.Token_End {
+		return fmt.Errorf("tokens length %d exceeds Token_End %d", len(tokens), define.Token_End)
+	}
+
+	padded := make(bson.A, define.Token_End)
+	for n := range padded {
+		padded[n] = int32(0)
+		if n < len(tokens) && tokens[n] != nil {
+			padded[n] = tokens[n]
+		}
+	}
+
+	doc["tokens"] = padded
+	return nil
+}
+
+// migrateBattleArrayList converts old battle_array hero ids to stage battle array with positions in order, -1 placeholders are skipped
+func migrateBattleArrayList(doc bson.M) error {
+	var heroIds bson.A
+	switch v := doc["battle_array"].(type) {
+	case nil:
+	case bson.A:
+		heroIds = v
+	default:
+		return fmt.Errorf("invalid battle_array type %T", v)
+	}
+	delete(doc, "battle_array")
+
+	arrays, ok := doc["battle_array_list"].(bson.M)
+	if !ok {
+		arrays = bson.M{}
+	}
+
+	heroes := make(bson.A, 0, len(heroIds))
+	for n, id := range heroIds {
+		var heroId int64
+		switch v := id.(type) {
+		case int64:
+			heroId = v
+		case int32:
+			heroId = int64(v)
+		default:
+			return fmt.Errorf("invalid battle_array hero id type %T", id)
+		}
+
+		if heroId == -1 {
+			continue
+		}
+
+		heroes = append(heroes, bson.M{"hero_id": heroId, "pos": int32(n + 1)})
+	}
+
+	if len(heroes) > 0 {
+		arrays[fmt.Sprint(define.BattleArray_Type_Stage)] = bson.M{
+			"type":   define.BattleArray_Type_Stage,
+			"name":   "",
+			"heroes": heroes,
+		}
+	}
+
+	doc["battle_array_list"] = arrays
+	return nil
+}
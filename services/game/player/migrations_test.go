@@ -0,0 +1,67 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/store"
+	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigrateBattleArrayList(t *testing.T) {
+	doc := bson.M{
+		"_id":          int64(1),
+		"battle_array": bson.A{int64(101), int64(-1), int64(102)},
+	}
+
+	res, err := store.MigrateDocument("player", doc)
+	if err != nil {
+		t.Fatalf("migrate player document failed: %v", err)
+	}
+
+	if res == nil || len(res.Unset) != 1 || res.Unset[0] != "battle_array" {
+		t.Fatalf("migrate result invalid: %v", res)
+	}
+
+	expect := bson.M{
+		"0": bson.M{
+			"type": define.BattleArray_Type_Stage,
+			"name": "",
+			"heroes": bson.A{
+				bson.M{"hero_id": int64(101), "pos": int32(1)},
+				bson.M{"hero_id": int64(102), "pos": int32(3)},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expect, doc["battle_array_list"]); diff != "" {
+		t.Fatalf("battle_array_list mismatch: %s", diff)
+	}
+
+	// 迁移后的文档可以读取到布阵
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := &PlayerInfo{}
+	if err := bson.Unmarshal(data, info); err != nil {
+		t.Fatalf("unmarshal player info failed: %v", err)
+	}
+
+	array := info.GetBattleArray(define.BattleArray_Type_Stage)
+	if array == nil || len(array.Heroes) != 2 || array.Heroes[1].HeroId != 102 || array.Heroes[1].Pos != 3 {
+		t.Fatalf("migrated battle array invalid: %v", info.BattleArrays)
+	}
+
+	// 没有旧布阵
+	empty := bson.M{"_id": int64(2)}
+	if err := migrateBattleArrayList(empty); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(bson.M{}, empty["battle_array_list"]); diff != "" {
+		t.Fatalf("empty battle_array_list mismatch: %s", diff)
+	}
+}
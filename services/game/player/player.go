@@ -3,7 +3,10 @@ package player
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"time"
+	"unicode/utf8"
 
 	"github.com/east-eden/server/define"
 	"github.com/east-eden/server/excel/auto"
@@ -14,24 +17,31 @@ import (
 	"github.com/east-eden/server/services/game/quest"
 	"github.com/east-eden/server/store"
 	"github.com/east-eden/server/utils"
+	"github.com/east-eden/server/utils/sensitive"
 	log "github.com/rs/zerolog/log"
 	"google.golang.org/protobuf/proto"
 )
 
 var (
 	PlayerBattleArrayMaxHero = 8 // 布阵最多8个英雄
+
+	ErrBattleArrayInvalidType = errors.New("invalid battle array type")
+	ErrBattleArrayInvalidName = errors.New("battle array name too long")
+	ErrBattleArrayHeroLimit   = errors.New("battle array hero limit")
+	ErrBattleArrayInvalidPos  = errors.New("invalid battle array position")
+	ErrPlayerNameSensitive    = errors.New("player name contains sensitive words")
 )
 
 type PlayerInfo struct {
-	ID                 int64   `bson:"_id" json:"_id"`
-	AccountID          int64   `bson:"account_id" json:"account_id"`
-	Name               string  `bson:"name" json:"name"`
-	Exp                int32   `bson:"exp" json:"exp"`
-	Level              int32   `bson:"level" json:"level"`
-	VipExp             int32   `bson:"vip_exp" json:"vip_exp"`
-	VipLevel           int32   `bson:"vip_level" json:"vip_level"`
-	BuyStrengthenTimes int16   `bson:"buy_strengthen_times" json:"buy_strengthen_times"` // 购买体力次数
-	BattleArray        []int64 `bson:"battle_array" json:"battle_array"`                 // 布阵
+	ID                 int64                         `bson:"_id" json:"_id"`
+	AccountID          int64                         `bson:"account_id" json:"account_id"`
+	Name               string                        `bson:"name" json:"name"`
+	Exp                int32                         `bson:"exp" json:"exp"`
+	Level              int32                         `bson:"level" json:"level"`
+	VipExp             int32                         `bson:"vip_exp" json:"vip_exp"`
+	VipLevel           int32                         `bson:"vip_level" json:"vip_level"`
+	BuyStrengthenTimes int16                         `bson:"buy_strengthen_times" json:"buy_strengthen_times"` // 购买体力次数
+	BattleArrays       map[int32]*define.BattleArray `bson:"battle_array_list" json:"battle_array_list"`       // 各玩法布阵
 }
 
 func (p *PlayerInfo) ToPB() *pbGlobal.PlayerInfo {
@@ -42,10 +52,9 @@ func (p *PlayerInfo) ToPB() *pbGlobal.PlayerInfo {
 		Exp:                p.Exp,
 		Level:              p.Level,
 		BuyStrengthenTimes: int32(p.BuyStrengthenTimes),
-		BattleArray:        make([]int64, 0, len(p.BattleArray)),
+		BattleArrays:       p.genBattleArraysPB(),
 	}
 
-	pb.BattleArray = append(pb.BattleArray, p.BattleArray...)
 	return pb
 }
 
@@ -69,6 +78,7 @@ type Player struct {
 	QuestManager        *quest.QuestManager  `bson:"inline" json:",inline"`
 	GuideManager        *GuideManager        `bson:"inline" json:",inline"`
 	TowerManager        *TowerManager        `bson:"inline" json:",inline"`
+	ArenaManager        *ArenaManager        `bson:"inline" json:",inline"`
 
 	lastUpdateTime time.Time `bson:"-" json:"-"`
 	initComplete   bool      `bson:"-" json:"-"`
@@ -95,7 +105,41 @@ func (p *PlayerInfo) Init() {
 	p.VipExp = 0
 	p.VipLevel = 0
 	p.BuyStrengthenTimes = 0
-	p.BattleArray = make([]int64, 0, PlayerBattleArrayMaxHero)
+	p.BattleArrays = make(map[int32]*define.BattleArray)
+}
+
+// 按布阵类型排序
+func (p *PlayerInfo) genBattleArraysPB() []*pbGlobal.BattleArray {
+	pbArrays := make([]*pbGlobal.BattleArray, 0, len(p.BattleArrays))
+	for tp := define.BattleArray_Type_Begin; tp < define.BattleArray_Type_End; tp++ {
+		if array, ok := p.BattleArrays[tp]; ok {
+			pbArrays = append(pbArrays, genBattleArrayPB(array))
+		}
+	}
+
+	return pbArrays
+}
+
+func genBattleArrayPB(array *define.BattleArray) *pbGlobal.BattleArray {
+	pb := &pbGlobal.BattleArray{
+		Type:   array.Type,
+		Name:   array.Name,
+		Heroes: make([]*pbGlobal.BattleArrayHero, 0, len(array.Heroes)),
+	}
+
+	for _, h := range array.Heroes {
+		pb.Heroes = append(pb.Heroes, &pbGlobal.BattleArrayHero{
+			HeroId: h.HeroId,
+			Pos:    h.Pos,
+		})
+	}
+
+	return pb
+}
+
+// 获取布阵, 没有保存过时返回nil
+func (p *PlayerInfo) GetBattleArray(tp int32) *define.BattleArray {
+	return p.BattleArrays[tp]
 }
 
 func (p *PlayerInfo) GetId() int64 {
@@ -118,8 +162,14 @@ func (p *PlayerInfo) GetName() string {
 	return p.Name
 }
 
-func (p *PlayerInfo) SetName(name string) {
+// 名字不能包含屏蔽字
+func (p *PlayerInfo) SetName(name string) error {
+	if sensitive.Contains(name) {
+		return ErrPlayerNameSensitive
+	}
+
 	p.Name = name
+	return nil
 }
 
 func (p *PlayerInfo) GetExp() int32 {
@@ -138,10 +188,9 @@ func (p *PlayerInfo) GenInfoPB() *pbGlobal.PlayerInfo {
 		Exp:                p.Exp,
 		Level:              p.Level,
 		BuyStrengthenTimes: int32(p.BuyStrengthenTimes),
-		BattleArray:        make([]int64, 0, len(p.BattleArray)),
+		BattleArrays:       p.genBattleArraysPB(),
 	}
 
-	pb.BattleArray = append(pb.BattleArray, p.BattleArray...)
 	return pb
 }
 
@@ -151,6 +200,7 @@ func (p *Player) Init(playerId int64) {
 	p.Name = ""
 	p.Exp = 0
 	p.Level = 1
+	p.BattleArrays = make(map[int32]*define.BattleArray)
 	p.lastUpdateTime = time.Now()
 	p.initComplete = false
 
@@ -183,6 +233,7 @@ func (p *Player) Init(playerId int64) {
 	p.ChapterStageManager = NewChapterStageManager(p)
 	p.GuideManager = NewGuideManager(p)
 	p.TowerManager = NewTowerManager(p)
+	p.ArenaManager = NewArenaManager(p)
 
 	p.costLootManager = costloot.NewCostLootManager(p)
 	p.costLootManager.Init(
@@ -316,6 +367,7 @@ func (p *Player) AfterLoad() error {
 func (p *Player) onTaskStart() {
 	p.mailController.start()
 	p.TowerManager.start()
+	p.ArenaManager.start()
 }
 
 func (p *Player) onTaskUpdate() {
@@ -354,6 +406,7 @@ func (p *Player) onMinuteChange(curMinute int) {
 // 小时改变
 func (p *Player) onHourChange(curHour int) {
 	p.TowerManager.OnHourChange(curHour)
+	p.ArenaManager.OnHourChange(curHour)
 }
 
 // 跨天处理
@@ -378,6 +431,11 @@ func (p *Player) OnFirstLogon() {
 	_ = p.TokenManager().GainLoot(define.Token_Strength, 999)
 }
 
+// 立即保存玩家数据
+func (p *Player) Save() error {
+	return store.GetStore().UpdateOne(context.Background(), define.StoreType_Player, p.ID, p, true)
+}
+
 // 刷新体力购买次数
 func (p *Player) refreshBuyStrengthen() {
 	// 购买体力次数
@@ -526,38 +584,81 @@ func (p *Player) ChangeExp(add int32) {
 	}
 }
 
-func (p *Player) SaveBattleArray(battleHeroArray []int64) error {
-	if len(battleHeroArray) > PlayerBattleArrayMaxHero {
-		return errors.New("battle hero length invalid")
+func makeBattleArrayKey(tp int32) string {
+	return fmt.Sprintf("battle_array_list.%d", tp)
+}
+
+// 保存布阵, 英雄为空时清除该类型的布阵
+func (p *Player) SaveBattleArray(tp int32, name string, heroes []*pbGlobal.BattleArrayHero) error {
+	if !utils.Between(tp, define.BattleArray_Type_Begin, define.BattleArray_Type_End) {
+		return ErrBattleArrayInvalidType
+	}
+
+	if utf8.RuneCountInString(name) > define.BattleArray_MaxNameLength {
+		return ErrBattleArrayInvalidName
+	}
+
+	if len(heroes) > PlayerBattleArrayMaxHero {
+		return ErrBattleArrayHeroLimit
+	}
+
+	unrepeatedHeroId := make(map[int64]struct{}, len(heroes))
+	unrepeatedPos := make(map[int32]struct{}, len(heroes))
+	array := &define.BattleArray{
+		Type:   tp,
+		Name:   name,
+		Heroes: make([]*define.BattleArrayHero, 0, len(heroes)),
 	}
 
-	unrepeatedHeroId := make(map[int64]bool, PlayerBattleArrayMaxHero)
-	for _, heroId := range battleHeroArray {
-		if heroId == -1 {
-			continue
+	for _, h := range heroes {
+		if h.GetPos() < 1 || h.GetPos() > int32(PlayerBattleArrayMaxHero) {
+			return ErrBattleArrayInvalidPos
 		}
 
-		if _, ok := unrepeatedHeroId[heroId]; ok {
+		if _, ok := unrepeatedPos[h.GetPos()]; ok {
+			return ErrBattleArrayInvalidPos
+		}
+
+		if _, ok := unrepeatedHeroId[h.GetHeroId()]; ok {
 			return ErrHeroRepeatedId
 		}
 
-		if h := p.HeroManager().GetHero(heroId); h == nil {
+		if p.HeroManager().GetHero(h.GetHeroId()) == nil {
 			return ErrHeroNotFound
 		}
+
+		unrepeatedPos[h.GetPos()] = struct{}{}
+		unrepeatedHeroId[h.GetHeroId()] = struct{}{}
+		array.Heroes = append(array.Heroes, &define.BattleArrayHero{HeroId: h.GetHeroId(), Pos: h.GetPos()})
 	}
 
-	p.BattleArray = p.BattleArray[:0]
-	p.BattleArray = append(p.BattleArray, battleHeroArray...)
+	// 按位置排序
+	sort.Slice(array.Heroes, func(i, j int) bool {
+		return array.Heroes[i].Pos < array.Heroes[j].Pos
+	})
 
 	// save
-	fields := map[string]any{
-		"battle_array": p.BattleArray,
+	key := makeBattleArrayKey(tp)
+	if len(array.Heroes) == 0 {
+		delete(p.BattleArrays, tp)
+		err := store.GetStore().DeleteFields(context.Background(), define.StoreType_Player, p.ID, []string{key})
+		utils.ErrPrint(err, "DeleteFields failed when Player.SaveBattleArray", p.ID, key)
+	} else {
+		p.BattleArrays[tp] = array
+		fields := map[string]any{
+			key: array,
+		}
+		err := store.GetStore().UpdateFields(context.Background(), define.StoreType_Player, p.ID, fields)
+		utils.ErrPrint(err, "UpdateFields failed when Player.SaveBattleArray", p.ID, fields)
+	}
+
+	if tp == define.BattleArray_Type_ArenaDefence {
+		p.ArenaManager.onDefenceChanged()
 	}
-	err := store.GetStore().UpdateFields(context.Background(), define.StoreType_Player, p.ID, fields)
-	utils.ErrPrint(err, "UpdateFields failed when Player.SaveBattleArray", p.ID, fields)
 
 	msg := &pbGlobal.S2C_SaveBattleArray{
 		Success: true,
+		Array:   genBattleArrayPB(array),
 	}
 	p.SendProtoMessage(msg)
 	return nil
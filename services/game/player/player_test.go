@@ -2,22 +2,29 @@ package player
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"testing"
 
 	"github.com/east-eden/server/define"
 	"github.com/east-eden/server/excel"
 	"github.com/east-eden/server/excel/auto"
 	"github.com/east-eden/server/logger"
+	pbGlobal "github.com/east-eden/server/proto/global"
+	pbRank "github.com/east-eden/server/proto/server/rank"
 	"github.com/east-eden/server/services/game/hero"
+	"github.com/east-eden/server/services/game/iface"
 	"github.com/east-eden/server/services/game/item"
 	"github.com/east-eden/server/store"
+	"github.com/east-eden/server/store/db"
 	"github.com/east-eden/server/utils"
-	"github.com/golang/mock/gomock"
+	"github.com/east-eden/server/utils/sensitive"
+	"github.com/google/go-cmp/cmp"
+	"github.com/shopspring/decimal"
+	"github.com/urfave/cli/v2"
 )
 
 var (
-	mockStore *store.MockStore
-
 	gameId int16 = 201
 
 	accountId int64 = 1
@@ -69,36 +76,192 @@ var (
 	}
 )
 
-func TestPlayer(t *testing.T) {
-	// snow flake init
-	utils.InitMachineID(gameId, 0, func() {})
+// 使用内存数据库, 测试结束后恢复原来的store
+func newTestStore(t *testing.T) store.Store {
+	t.Helper()
+
+	prev := store.GetStore()
+	set := flag.NewFlagSet(t.Name(), flag.ContinueOnError)
+	s := store.NewStore(cli.NewContext(nil, set, nil), store.DB(db.NewMemDB()))
+	t.Cleanup(func() {
+		s.Exit()
+		store.SetStore(prev)
+	})
+
+	s.AddStoreInfo(define.StoreType_Player, "player", "_id")
+	s.AddStoreInfo(define.StoreType_Item, "player_item", "_id")
+	s.AddStoreInfo(define.StoreType_Hero, "player_hero", "_id")
+	s.AddStoreInfo(define.StoreType_Token, "player_token", "_id")
+
+	for _, tblName := range []string{"player_item", "player_hero", "player_token"} {
+		if err := s.MigrateDbTable(tblName, "owner_id"); err != nil {
+			t.Fatalf("migrate collection %s failed: %v", tblName, err)
+		}
+	}
+
+	return s
+}
+
+// 加载测试用的配置数据, 不依赖config/csv目录
+func loadTestEntries(t *testing.T) {
+	t.Helper()
 
-	// reload to project root path
-	if err := utils.RelocatePath("/server"); err != nil {
-		t.Fatalf("relocate path failed: %s", err.Error())
+	decimals := func(n int, v float64) []decimal.Decimal {
+		ret := make([]decimal.Decimal, n)
+		for k := range ret {
+			ret[k] = decimal.NewFromFloat(v)
+		}
+		return ret
 	}
 
-	// logger init
-	logger.InitLogger("player_test")
+	int32s := func(n int, v int32) []int32 {
+		ret := make([]int32, n)
+		for k := range ret {
+			ret[k] = v
+		}
+		return ret
+	}
+
+	// 突破消耗金币
+	var promoteCostId int32 = 100
+
+	tokenRows := make([]excel.ExcelRowData, 0, define.Token_End)
+	for tp := define.Token_Begin; tp < define.Token_End; tp++ {
+		tokenRows = append(tokenRows, excel.ExcelRowData{"Id": tp, "MaxHold": int32(999999999)})
+	}
 
-	excel.ReadAllEntries("config/csv/")
+	// 每级累计经验和突破限制
+	levelupRows := func(exps []int32, promoteLimits []int32) []excel.ExcelRowData {
+		rows := make([]excel.ExcelRowData, 0, len(exps))
+		for n := range exps {
+			rows = append(rows, excel.ExcelRowData{"Id": int32(n + 1), "Exp": exps[n], "PromoteLimit": promoteLimits[n]})
+		}
+		return rows
+	}
+
+	equipLevelupRows := make([]excel.ExcelRowData, 0, 3)
+	for n, promoteLimit := range []int32{0, 0, 1} {
+		equipLevelupRows = append(equipLevelupRows, excel.ExcelRowData{
+			"Id":           int32(n + 1),
+			"Exp":          int32s(int(define.Item_Quality_Num), int32(n*100)),
+			"PromoteLimit": promoteLimit,
+		})
+	}
+
+	// 晶石从0级开始
+	crystalLevelupRows := make([]excel.ExcelRowData, 0, 4)
+	for n := 0; n < 4; n++ {
+		crystalLevelupRows = append(crystalLevelupRows, excel.ExcelRowData{
+			"Id":  int32(n),
+			"Exp": int32s(int(define.Item_Quality_Num), int32(n*100)),
+		})
+	}
+
+	entries := []struct {
+		loader excel.EntryLoader
+		raw    *excel.ExcelFileRaw
+	}{
+		{&auto.ItemEntries{}, &excel.ExcelFileRaw{Filename: "Item.csv", CellData: []excel.ExcelRowData{
+			{"Id": equipTypeId, "Type": define.Item_TypeEquip, "Quality": define.Item_Quality_Green, "MaxStack": int32(1)},
+			{"Id": equipExpTypeId, "Type": define.Item_TypeItem, "SubType": define.Item_SubType_Item_EquipExp, "MaxStack": int32(99999), "PublicMisc": []int32{100}},
+			{"Id": crystalTypeId, "Type": define.Item_TypeCrystal, "Quality": define.Item_Quality_Green, "MaxStack": int32(1)},
+			{"Id": crystalExpTypeId, "Type": define.Item_TypeItem, "SubType": define.Item_SubType_Item_CrystalExp, "MaxStack": int32(99999), "PublicMisc": []int32{100}},
+			{"Id": heroExpTypeId, "Type": define.Item_TypeItem, "SubType": define.Item_SubType_Item_HeroExp, "MaxStack": int32(99999), "PublicMisc": []int32{100}},
+		}}},
+		{&auto.TokenEntries{}, &excel.ExcelFileRaw{Filename: "Token.csv", CellData: tokenRows}},
+		{&auto.GlobalConfigEntries{}, &excel.ExcelFileRaw{Filename: "GlobalConfig.csv", CellData: []excel.ExcelRowData{{
+			"Id":                             int32(1),
+			"MaterialContainerMax":           int32(100),
+			"EquipContainerMax":              int32(100),
+			"CrystalContainerMax":            int32(100),
+			"EquipPromoteLevelLimit":         int32s(define.Equip_Max_Promote_Times+1, 0),
+			"EquipPromoteIntensityRatio":     int32s(define.Equip_Max_Promote_Times+1, 1),
+			"EquipLevelQualityRatio":         decimals(int(define.Item_Quality_Num), 1),
+			"EquipSwallowExpLoss":            decimal.NewFromFloat(0.8),
+			"EquipExpItems":                  []int32{equipExpTypeId},
+			"EquipLevelupExpGoldRatio":       int32(1),
+			"HeroLevelupExpGoldRatio":        int32(1),
+			"HeroExpItems":                   []int32{heroExpTypeId},
+			"HeroPromoteIntensityRatio":      int32s(define.Hero_Max_Promote_Times+1, 1),
+			"HeroLevelQualityRatio":          decimals(int(define.Item_Quality_Num), 1),
+			"CrystalSwallowExpLoss":          decimal.NewFromFloat(0.8),
+			"CrystalLevelupExpGoldRatio":     int32(1),
+			"CrystalExpItems":                []int32{crystalExpTypeId},
+			"CrystalLevelupIntensityRatio":   int32(1),
+			"CrystalLevelupMainQualityRatio": decimals(int(define.Item_Quality_Num), 1),
+			"CrystalLevelupViceQualityRatio": decimals(int(define.Item_Quality_Num), 1),
+			"CrystalLevelupRandRatio":        []decimal.Decimal{decimal.NewFromFloat(0.9), decimal.NewFromFloat(1.1)},
+			"CrystalViceAttAddLevel":         []int32{3},
+			"CrystalLevelupQualityLimit":     int32s(int(define.Item_Quality_Num), 3),
+			"CrystalLevelupAssistantNumber":  int32(2),
+		}}}},
+		{&auto.CostLootEntries{}, &excel.ExcelFileRaw{Filename: "CostLoot.csv", CellData: []excel.ExcelRowData{{
+			"Id":        promoteCostId,
+			"LootKind":  int32(define.LootKind_Fixed),
+			"LootTimes": int32(1),
+			"Type":      []int32{define.CostLoot_Token},
+			"Misc":      []int32{define.Token_Gold},
+			"NumMin":    []int32{100},
+			"NumMax":    []int32{100},
+		}}}},
+		{&auto.PlayerLevelupEntries{}, &excel.ExcelFileRaw{Filename: "PlayerLevelup.csv", CellData: []excel.ExcelRowData{
+			{"Id": int32(1), "Exp": int32(0)},
+			{"Id": int32(2), "Exp": int32(100)},
+			{"Id": int32(3), "Exp": int32(200)},
+		}}},
+		{&auto.EquipEnchantEntries{}, &excel.ExcelFileRaw{Filename: "EquipEnchant.csv", CellData: []excel.ExcelRowData{
+			{"Id": equipTypeId, "PromoteCostId": int32s(define.Equip_Max_Promote_Times+1, promoteCostId)},
+		}}},
+		{&auto.EquipLevelupEntries{}, &excel.ExcelFileRaw{Filename: "EquipLevelup.csv", CellData: equipLevelupRows}},
+		{&auto.CrystalEntries{}, &excel.ExcelFileRaw{Filename: "Crystal.csv", CellData: []excel.ExcelRowData{
+			{"Id": crystalTypeId, "Pos": int32(0)},
+		}}},
+		{&auto.CrystalLevelupEntries{}, &excel.ExcelFileRaw{Filename: "CrystalLevelup.csv", CellData: crystalLevelupRows}},
+		{&auto.CrystalAttRepoEntries{}, &excel.ExcelFileRaw{Filename: "CrystalAttRepo.csv", CellData: []excel.ExcelRowData{
+			{"Id": int32(1), "Pos": int32(0), "Type": define.Crystal_AttTypeMain, "AttWeight": int32(1)},
+			{"Id": int32(2), "Type": define.Crystal_AttTypeVice, "AttWeight": int32(1)},
+			{"Id": int32(3), "Type": define.Crystal_AttTypeVice, "AttWeight": int32(1)},
+		}}},
+		{&auto.CrystalInitViceAttEntries{}, &excel.ExcelFileRaw{Filename: "CrystalInitViceAtt.csv", CellData: []excel.ExcelRowData{
+			{"Id": define.Item_Quality_Green, "AttNumWeight": []int32{1}},
+		}}},
+		{&auto.CrystalSkillEntries{}, &excel.ExcelFileRaw{Filename: "CrystalSkill.csv", CellData: []excel.ExcelRowData{
+			{"Id": int32(0), "SkillId": []int32{1}},
+		}}},
+		{&auto.HeroEntries{}, &excel.ExcelFileRaw{Filename: "Hero.csv", CellData: []excel.ExcelRowData{
+			{"Id": heroTypeId, "Quality": define.Item_Quality_Green},
+		}}},
+		{&auto.HeroLevelupEntries{}, &excel.ExcelFileRaw{Filename: "HeroLevelup.csv", CellData: levelupRows([]int32{0, 100, 100}, []int32{0, 0, 1})}},
+		{&auto.HeroEnchantEntries{}, &excel.ExcelFileRaw{Filename: "HeroEnchant.csv", CellData: []excel.ExcelRowData{
+			{"Id": heroTypeId, "PromoteCostId": int32s(define.Hero_Max_Promote_Times+1, promoteCostId)},
+		}}},
+		{&auto.HeroProfessionEntries{}, &excel.ExcelFileRaw{Filename: "HeroProfession.csv", CellData: []excel.ExcelRowData{
+			{"Id": int32(0), "AtkRatio": decimal.NewFromInt(1), "ArmorRatio": decimal.NewFromInt(1), "MaxHPRatio": decimal.NewFromInt(1)},
+		}}},
+	}
+
+	for _, e := range entries {
+		if err := e.loader.Load(e.raw); err != nil {
+			t.Fatalf("load %s failed: %v", e.raw.Filename, err)
+		}
 
-	mockCtl := gomock.NewController(t)
-	defer mockCtl.Finish()
+		if m, ok := e.loader.(excel.EntryManualLoader); ok {
+			if err := m.ManualLoad(e.raw); err != nil {
+				t.Fatalf("manual load %s failed: %v", e.raw.Filename, err)
+			}
+		}
+	}
+}
 
-	// msg := &pbGlobal.C2S_AccountLogon{
-	// 	UserId:      222,
-	// 	// AccountId:   352282886277175616,
-	// 	AccountId: -1,
-	// 	AccountName: "asdf",
-	// }
+func TestPlayer(t *testing.T) {
+	// snow flake init
+	utils.InitMachineID(gameId, 0, func() {})
 
-	// marshal := &codec.ProtoBufMarshaler{}
-	// data, _ := marshal.Marshal(msg)
-	// fmt.Println(data)
+	// logger init
+	logger.InitLogger("player_test")
 
-	// init
-	initMockStore(t, mockCtl)
+	newTestStore(t)
+	loadTestEntries(t)
 
 	// player test
 	playerTest(t)
@@ -117,23 +280,15 @@ func TestPlayer(t *testing.T) {
 
 	// remove all
 	removeTest(t)
-
-	// wait store execute finish
-	store.GetStore().Exit()
 }
 
-func initMockStore(t *testing.T, mockCtl *gomock.Controller) {
-	mockStore = store.NewMockStore(mockCtl)
-	store.SetStore(mockStore)
-
-	// expect
-	mockStore.EXPECT().InitCompleted().Return(true).AnyTimes()
-	mockStore.EXPECT().Exit().Return().AnyTimes()
+// 升级时更新排行榜, 测试中直接返回
+type testRpcCaller struct {
+	iface.RpcCaller
+}
 
-	mockStore.EXPECT().UpdateFields(context.Background(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-	mockStore.EXPECT().UpdateOne(context.Background(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-	mockStore.EXPECT().DeleteFields(context.Background(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
-	mockStore.EXPECT().DeleteOne(context.Background(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+func (c *testRpcCaller) CallSetRankScore(*pbRank.SetRankScoreRq) (*pbRank.SetRankScoreRs, error) {
+	return &pbRank.SetRankScoreRs{}, nil
 }
 
 func playerTest(t *testing.T) {
@@ -144,6 +299,7 @@ func playerTest(t *testing.T) {
 	acct.UserId = 1
 	acct.GameId = gameId
 	acct.Name = "test_account"
+	acct.SetRpcCaller(&testRpcCaller{})
 
 	// create new player
 	pl = NewPlayer().(*Player)
@@ -333,3 +489,103 @@ func tokenTest(t *testing.T) {
 		}
 	}
 }
+
+func TestSaveBattleArray(t *testing.T) {
+	s := newTestStore(t)
+	loadTestEntries(t)
+
+	entry, ok := auto.GetHeroEntry(heroTypeId)
+	if !ok {
+		t.Fatalf("hero entry %d not found", heroTypeId)
+	}
+
+	owner := &Player{}
+	owner.Init(playerId)
+	if err := s.UpdateOne(context.Background(), define.StoreType_Player, owner.ID, owner); err != nil {
+		t.Fatalf("UpdateOne player failed: %v", err)
+	}
+
+	h1 := owner.HeroManager().createEntryHero(entry)
+	h2 := owner.HeroManager().createEntryHero(entry)
+	if h1 == nil || h2 == nil {
+		t.Fatal("createEntryHero failed")
+	}
+
+	invalidCases := []struct {
+		tp     int32
+		heroes []*pbGlobal.BattleArrayHero
+		err    error
+	}{
+		{define.BattleArray_Type_End, nil, ErrBattleArrayInvalidType},
+		{define.BattleArray_Type_Stage, []*pbGlobal.BattleArrayHero{{HeroId: h1.Id, Pos: 0}}, ErrBattleArrayInvalidPos},
+		{define.BattleArray_Type_Stage, []*pbGlobal.BattleArrayHero{{HeroId: h1.Id, Pos: int32(PlayerBattleArrayMaxHero) + 1}}, ErrBattleArrayInvalidPos},
+		{define.BattleArray_Type_Stage, []*pbGlobal.BattleArrayHero{{HeroId: h1.Id, Pos: 1}, {HeroId: h2.Id, Pos: 1}}, ErrBattleArrayInvalidPos},
+		{define.BattleArray_Type_Stage, []*pbGlobal.BattleArrayHero{{HeroId: h1.Id, Pos: 1}, {HeroId: h1.Id, Pos: 2}}, ErrHeroRepeatedId},
+		{define.BattleArray_Type_Stage, []*pbGlobal.BattleArrayHero{{HeroId: h1.Id + h2.Id, Pos: 1}}, ErrHeroNotFound},
+	}
+
+	for n, c := range invalidCases {
+		if err := owner.SaveBattleArray(c.tp, "", c.heroes); !errors.Is(err, c.err) {
+			t.Fatalf("case %d save battle array error %v, expect %v", n, err, c.err)
+		}
+	}
+
+	tooMany := make([]*pbGlobal.BattleArrayHero, PlayerBattleArrayMaxHero+1)
+	if err := owner.SaveBattleArray(define.BattleArray_Type_Stage, "", tooMany); !errors.Is(err, ErrBattleArrayHeroLimit) {
+		t.Fatalf("save too many heroes error %v", err)
+	}
+
+	if len(owner.BattleArrays) != 0 {
+		t.Fatalf("invalid battle array should not be saved: %v", owner.BattleArrays)
+	}
+
+	// 布阵按位置排序, 战斗单位带上布阵位置
+	tp := define.TowerBattleArrayType(define.Tower_Type_General)
+	heroes := []*pbGlobal.BattleArrayHero{{HeroId: h1.Id, Pos: 5}, {HeroId: h2.Id, Pos: 2}}
+	if err := owner.SaveBattleArray(tp, "综合试炼", heroes); err != nil {
+		t.Fatalf("save battle array failed: %v", err)
+	}
+
+	entityList := owner.HeroManager().GenCombatEntityInfo(owner.GetBattleArray(tp))
+	if len(entityList) != 2 || entityList[0].Pos != 2 || entityList[1].Pos != 5 {
+		t.Fatalf("combat entity list invalid: %v", entityList)
+	}
+
+	if owner.GetBattleArray(define.BattleArray_Type_Stage) != nil {
+		t.Fatal("stage battle array should be empty")
+	}
+
+	s.Flush()
+	loaded := &Player{}
+	loaded.Init(playerId)
+	if err := s.FindOne(context.Background(), define.StoreType_Player, playerId, loaded); err != nil {
+		t.Fatalf("FindOne player failed: %v", err)
+	}
+
+	if diff := cmp.Diff(owner.BattleArrays, loaded.BattleArrays); diff != "" {
+		t.Fatalf("loaded battle arrays mismatch: %s", diff)
+	}
+
+	// 英雄为空时清除布阵
+	if err := owner.SaveBattleArray(tp, "", nil); err != nil {
+		t.Fatalf("clear battle array failed: %v", err)
+	}
+
+	if owner.GetBattleArray(tp) != nil || len(owner.HeroManager().GenCombatEntityInfo(owner.GetBattleArray(tp))) != 0 {
+		t.Fatal("battle array should be cleared")
+	}
+}
+
+func TestPlayerSetName(t *testing.T) {
+	sensitive.Reset([]*sensitive.Word{{Text: "gm", Action: define.SensitiveAction_Mask}})
+	defer sensitive.Reset(nil)
+
+	info := &PlayerInfo{}
+	if err := info.SetName("hero"); err != nil || info.Name != "hero" {
+		t.Fatalf("SetName failed: err=%v, name=%s", err, info.Name)
+	}
+
+	if err := info.SetName("I am GM"); !errors.Is(err, ErrPlayerNameSensitive) || info.Name != "hero" {
+		t.Fatalf("SetName with sensitive word should fail: err=%v, name=%s", err, info.Name)
+	}
+}
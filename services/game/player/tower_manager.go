@@ -9,10 +9,11 @@ import (
 	"github.com/east-eden/server/define"
 	"github.com/east-eden/server/excel/auto"
 	pbGlobal "github.com/east-eden/server/proto/global"
-	"github.com/east-eden/server/services/game/event"
-	"github.com/east-eden/server/services/game/global"
+	pbCombat "github.com/east-eden/server/proto/server/combat"
+	pbRank "github.com/east-eden/server/proto/server/rank"
 	"github.com/east-eden/server/store"
 	"github.com/east-eden/server/utils"
+	"github.com/rs/zerolog/log"
 )
 
 var (
@@ -123,38 +124,65 @@ func (m *TowerManager) settleReward(days int) {
 	utils.ErrPrint(err, "UpdateFields failed when TowerManager.settleReward", m.owner.ID, fields)
 }
 
-// 刷新记录处理
-func (m *TowerManager) refreshRecord(towerType int32, floor int32, battleArray []int64) {
-	seconds, err := global.GetGlobalController().GetTowerBestSeconds(towerType, floor)
-	if err != nil {
+// 刷新记录处理, 通关时间和回合数来自战斗服的战斗结果, 只有超越自己的记录时才会更新排行
+func (m *TowerManager) refreshRecord(towerType int32, floor int32, rsp *pbCombat.TowerCombatRs) {
+	stats := rsp.GetStatistics()
+
+	// 没有怪物或回合数为0的战斗结果不是有效的通关记录
+	if stats.GetRounds() <= 0 || stats.GetDefenceUnitNum() == 0 {
+		log.Warn().Caller().
+			Int64("player_id", m.owner.ID).
+			Int32("tower_type", towerType).
+			Int32("floor", floor).
+			Interface("statistics", stats).
+			Msg("invalid tower combat statistics")
 		return
 	}
 
-	// todo check record seconds
-	if seconds != -1 {
-		return
+	_, err := m.owner.acct.rpcCaller.CallSetRankScore(&pbRank.SetRankScoreRq{
+		RankId: define.TowerRankId(towerType, floor),
+		Metadata: &pbGlobal.RankMetadata{
+			ObjId:    m.owner.ID,
+			ObjName:  m.owner.Name,
+			Score:    define.TowerRankScore(stats.GetPassTime(), stats.GetRounds()),
+			Date:     time.Now().Unix(),
+			RecordId: rsp.GetRecordId(),
+		},
+	})
+
+	utils.ErrPrint(err, "CallSetRankScore failed when TowerManager.refreshRecord", m.owner.ID, towerType, floor)
+}
+
+// 查询某层的最快通关记录
+func (m *TowerManager) QueryRank(towerType int32, floor int32) error {
+	if !utils.Between(towerType, define.Tower_Type_Begin, define.Tower_Type_End) {
+		return ErrTowerInvalidType
 	}
 
-	e := &event.Event{
-		Type: define.Event_Type_TowerPass,
-		Miscs: []any{
-			towerType,
-			floor,
-			&global.TowerBestInfo{
-				PlayerId:    m.owner.ID,
-				PlayerName:  m.owner.Name,
-				Seconds:     10,
-				RecordId:    1001,
-				BattleArray: make([]int64, len(battleArray)),
-			},
-		},
+	if _, ok := auto.GetTowerEntry(towerType, floor); !ok {
+		return ErrTowerInvalidEntry
+	}
+
+	rsp, err := m.owner.acct.rpcCaller.CallQueryRankByRange(&pbRank.QueryRankByRangeRq{
+		RankId: define.TowerRankId(towerType, floor),
+		Start:  0,
+		End:    -1,
+	})
+	if !utils.ErrCheck(err, "CallQueryRankByRange failed when TowerManager.QueryRank", m.owner.ID, towerType, floor) {
+		return err
 	}
 
-	copy(e.Miscs[2].(*global.TowerBestInfo).BattleArray[:], battleArray[:])
-	global.GetGlobalController().AddEvent(e)
+	msg := &pbGlobal.S2C_QueryTowerRank{
+		TowerType:  towerType,
+		TowerFloor: floor,
+		Metadatas:  rsp.GetMetadatas(),
+	}
+	m.owner.SendProtoMessage(msg)
+	return nil
 }
 
-func (m *TowerManager) Challenge(towerType int32, floor int32, battleArray []int64) error {
+// 使用对应塔类型保存的布阵挑战
+func (m *TowerManager) Challenge(towerType int32, floor int32) error {
 	if !utils.Between(towerType, define.Tower_Type_Begin, define.Tower_Type_End) {
 		return ErrTowerInvalidType
 	}
@@ -163,12 +191,9 @@ func (m *TowerManager) Challenge(towerType int32, floor int32, battleArray []int
 		return ErrTowerInvalidFloor
 	}
 
-	// battle hero id repeated
-	battleMap := make(map[int64]struct{}, 8)
-	for _, heroId := range battleArray {
-		battleMap[heroId] = struct{}{}
-	}
-	if len(battleMap) != len(battleArray) {
+	// 布阵在保存时已检查过英雄和位置
+	battleArray := m.owner.GetBattleArray(define.TowerBattleArrayType(towerType))
+	if battleArray == nil {
 		return ErrTowerInvalidBattleArray
 	}
 
@@ -195,12 +220,8 @@ func (m *TowerManager) Challenge(towerType int32, floor int32, battleArray []int
 	case define.Tower_Type_General:
 		break
 	default:
-		for _, heroId := range battleArray {
-			if heroId == -1 {
-				continue
-			}
-
-			h := m.owner.HeroManager().GetHero(heroId)
+		for _, arrayHero := range battleArray.Heroes {
+			h := m.owner.HeroManager().GetHero(arrayHero.HeroId)
 			if h == nil {
 				continue
 			}
@@ -211,11 +232,32 @@ func (m *TowerManager) Challenge(towerType int32, floor int32, battleArray []int
 		}
 	}
 
+	entityList := m.owner.HeroManager().GenCombatEntityInfo(battleArray)
+	if len(entityList) == 0 {
+		return ErrTowerInvalidBattleArray
+	}
+
+	// 战斗
+	rsp, err := m.owner.acct.rpcCaller.CallTowerCombat(&pbCombat.TowerCombatRq{
+		TowerType:        towerType,
+		TowerFloor:       floor,
+		AttackId:         m.owner.ID,
+		AttackEntityList: entityList,
+	})
+	if !utils.ErrCheck(err, "CallTowerCombat failed when TowerManager.Challenge", m.owner.ID, towerType, floor) {
+		return err
+	}
+
+	// 挑战失败
+	if !rsp.GetWin() {
+		return nil
+	}
+
 	// pass
 	m.CurFloor[towerType]++
 
 	// first reward
-	err := m.owner.CostLootManager().GainLoot(towerEntry.FirstRewardId)
+	err = m.owner.CostLootManager().GainLoot(towerEntry.FirstRewardId)
 	utils.ErrPrint(err, "GainLoot failed when TowerManager.FloorPass", m.owner.ID, towerType, floor)
 
 	// save
@@ -228,7 +270,7 @@ func (m *TowerManager) Challenge(towerType int32, floor int32, battleArray []int
 
 	m.SendTowerUpdate(towerType)
 
-	m.refreshRecord(towerType, floor, battleArray)
+	m.refreshRecord(towerType, floor, rsp)
 
 	return err
 }
@@ -261,7 +303,7 @@ func (m *TowerManager) GmFloorPass(towerType int32, floor int32) error {
 	err = store.GetStore().UpdateFields(context.Background(), define.StoreType_Player, m.owner.ID, fields)
 	utils.ErrPrint(err, "UpdateFields failed when TowerManager.GmFloorPass", m.owner.ID, fields)
 
-	m.refreshRecord(towerType, floor, []int64{1, 2, 3})
+	// gm通关没有战斗结果, 不刷新排行
 	return err
 }
 
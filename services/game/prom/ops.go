@@ -25,4 +25,9 @@ var (
 		Name: "create_item_counter",
 		Help: "创建物品总数",
 	})
+
+	OpsStageResultMismatchCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stage_result_mismatch_counter",
+		Help: "客户端关卡战斗结果与服务器不一致总数",
+	})
 )
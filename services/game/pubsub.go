@@ -24,6 +24,7 @@ type PubSub struct {
 	pubStartGate       micro.Publisher
 	pubSyncPlayerInfo  micro.Publisher
 	pubMultiPublicTest micro.Publisher
+	pubGameDrain       micro.Publisher
 	g                  *Game
 	handler            *SubscriberHandler
 }
@@ -37,6 +38,7 @@ func NewPubSub(g *Game) *PubSub {
 	ps.pubStartGate = micro.NewEvent("game.StartGate", g.mi.srv.Client())
 	ps.pubSyncPlayerInfo = micro.NewEvent("game.SyncPlayerInfo", g.mi.srv.Client())
 	ps.pubMultiPublicTest = micro.NewEvent("multi_publish_test", g.mi.srv.Client())
+	ps.pubGameDrain = micro.NewEvent("game.Drain", g.mi.srv.Client())
 
 	// register subscriber
 	handler := NewSubscriberHandler(g)
@@ -48,6 +50,10 @@ func NewPubSub(g *Game) *PubSub {
 	err = micro.RegisterSubscriber("multi_publish_test", g.mi.srv.Server(), handler, server.SubscriberQueue("multi_publish_test"))
 	utils.ErrPrint(err, "register subscriber multi_public_test failed")
 
+	// 聊天消息需要所有game节点都收到
+	err = micro.RegisterSubscriber("chat.ChatMessage", g.mi.srv.Server(), handler.ProcessChatMessage)
+	utils.ErrPrint(err, "register subscriber chat.ChatMessage failed")
+
 	return ps
 }
 
@@ -84,6 +90,19 @@ func (ps *PubSub) PubSyncPlayerInfo(ctx context.Context, p *player.PlayerInfo) e
 	})
 }
 
+func (ps *PubSub) PubGameDrain(ctx context.Context) error {
+	nextId, err := utils.NextID(define.SnowFlake_Pubsub)
+	if !utils.ErrCheck(err, "NextID failed") {
+		return err
+	}
+
+	return ps.pubGameDrain.Publish(ctx, &pbPubSub.PubGameDrain{
+		MsgId:    nextId,
+		GameId:   int32(ps.g.ID),
+		BootTime: ps.g.mi.bootTime,
+	})
+}
+
 func (ps *PubSub) PubMultiPublishTest(ctx context.Context, pb *pbPubSub.MultiPublishTest) error {
 	return ps.pubMultiPublicTest.Publish(ctx, pb)
 }
@@ -127,3 +146,31 @@ func (s *SubscriberHandler) ProcessMultiPublishTest(ctx context.Context, event *
 	log.Info().Interface("event", event).Send()
 	return nil
 }
+
+// 推送聊天消息给本节点的在线玩家
+func (s *SubscriberHandler) ProcessChatMessage(ctx context.Context, event *pbPubSub.PubChatMessage) error {
+	if s.isDunplicateMsg(event.MsgId) {
+		return nil
+	}
+
+	if event.GetGameId() != 0 && event.GetGameId() != int32(s.g.ID) {
+		return nil
+	}
+
+	msg := &pbGlobal.S2C_ChatMessage{Message: event.GetMessage()}
+	if len(event.GetReceiverIds()) == 0 {
+		s.g.am.Broadcast(msg)
+		return nil
+	}
+
+	for _, receiverId := range event.GetReceiverIds() {
+		// 玩家不在本节点时忽略
+		_ = s.g.am.AddPlayerTask(context.Background(), receiverId, func(c context.Context, p ...any) error {
+			acct := p[0].(*player.Account)
+			acct.SendProtoMessage(msg)
+			return nil
+		})
+	}
+
+	return nil
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/asim/go-micro/v3/client"
 	pbGlobal "github.com/east-eden/server/proto/global"
+	pbChat "github.com/east-eden/server/proto/server/chat"
 	pbCombat "github.com/east-eden/server/proto/server/combat"
+	pbComment "github.com/east-eden/server/proto/server/comment"
 	pbGame "github.com/east-eden/server/proto/server/game"
 	pbGate "github.com/east-eden/server/proto/server/gate"
 	pbMail "github.com/east-eden/server/proto/server/mail"
@@ -25,12 +27,14 @@ var (
 )
 
 type RpcHandler struct {
-	g         *Game
-	gateSrv   pbGate.GateService
-	gameSrv   pbGame.GameService
-	combatSrv pbCombat.CombatService
-	mailSrv   pbMail.MailService
-	rankSrv   pbRank.RankService
+	g          *Game
+	gateSrv    pbGate.GateService
+	gameSrv    pbGame.GameService
+	combatSrv  pbCombat.CombatService
+	mailSrv    pbMail.MailService
+	rankSrv    pbRank.RankService
+	chatSrv    pbChat.ChatService
+	commentSrv pbComment.CommentService
 }
 
 func NewRpcHandler(g *Game) *RpcHandler {
@@ -60,6 +64,16 @@ func NewRpcHandler(g *Game) *RpcHandler {
 			"rank",
 			g.mi.srv.Client(),
 		),
+
+		chatSrv: pbChat.NewChatService(
+			"chat",
+			g.mi.srv.Client(),
+		),
+
+		commentSrv: pbComment.NewCommentService(
+			"comment",
+			g.mi.srv.Client(),
+		),
 	}
 
 	err := pbGame.RegisterGameServiceHandler(g.mi.srv.Server(), h)
@@ -122,6 +136,18 @@ func (h *RpcHandler) CallSyncPlayerInfo(userId int64, info *player.PlayerInfo) (
 	)
 }
 
+// 下线迁移时为玩家选择新的game节点
+func (h *RpcHandler) CallSelectGameNode(userId string) (*pbGate.SelectGameNodeReply, error) {
+	req := &pbGate.SelectGameNodeRequest{
+		UserId:        userId,
+		ExcludeGameId: int32(h.g.ID),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.gateSrv.SelectGameNode(ctx, req)
+}
+
 // 踢account下线
 func (h *RpcHandler) CallKickAccountOffline(accountId int64, gameId int32) (*pbGame.KickAccountOfflineRs, error) {
 	if accountId == -1 {
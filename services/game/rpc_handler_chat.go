@@ -0,0 +1,55 @@
+package game
+
+import (
+	"context"
+
+	"github.com/east-eden/server/define"
+	pbChat "github.com/east-eden/server/proto/server/chat"
+)
+
+/////////////////////////////////////////////
+// rpc call
+/////////////////////////////////////////////
+
+// 发送聊天消息
+func (h *RpcHandler) CallSendChatMessage(req *pbChat.SendChatMessageRq) (*pbChat.SendChatMessageRs, error) {
+	var channel define.ChatChannel
+	channel.FromPB(req.GetMessage().GetChannel())
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.chatSrv.SendChatMessage(
+		ctx,
+		req,
+		h.consistentHashCallOption(channel.Key()),
+	)
+}
+
+// 查询频道历史消息
+func (h *RpcHandler) CallQueryChatHistory(req *pbChat.QueryChatHistoryRq) (*pbChat.QueryChatHistoryRs, error) {
+	var channel define.ChatChannel
+	channel.FromPB(req.GetChannel())
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.chatSrv.QueryChatHistory(
+		ctx,
+		req,
+		h.consistentHashCallOption(channel.Key()),
+		h.retries(3),
+	)
+}
+
+// 举报聊天消息
+func (h *RpcHandler) CallReportChatMessage(req *pbChat.ReportChatMessageRq) (*pbChat.ReportChatMessageRs, error) {
+	var channel define.ChatChannel
+	channel.FromPB(req.GetChannel())
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.chatSrv.ReportChatMessage(
+		ctx,
+		req,
+		h.consistentHashCallOption(channel.Key()),
+	)
+}
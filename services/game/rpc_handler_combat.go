@@ -21,3 +21,36 @@ func (h *RpcHandler) CallStageCombat(req *pbCombat.StageCombatRq) (*pbCombat.Sta
 		h.consistentHashCallOption(cast.ToString(req.GetAttackId())),
 	)
 }
+
+// 爬塔战斗
+func (h *RpcHandler) CallTowerCombat(req *pbCombat.TowerCombatRq) (*pbCombat.TowerCombatRs, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.combatSrv.TowerCombat(
+		ctx,
+		req,
+		h.consistentHashCallOption(cast.ToString(req.GetAttackId())),
+	)
+}
+
+// 竞技场战斗
+func (h *RpcHandler) CallArenaCombat(req *pbCombat.ArenaCombatRq) (*pbCombat.ArenaCombatRs, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.combatSrv.ArenaCombat(
+		ctx,
+		req,
+		h.consistentHashCallOption(cast.ToString(req.GetAttackId())),
+	)
+}
+
+// 查询战斗录像
+func (h *RpcHandler) CallQueryCombatRecord(req *pbCombat.QueryCombatRecordRq) (*pbCombat.QueryCombatRecordRs, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.combatSrv.QueryCombatRecord(
+		ctx,
+		req,
+		h.consistentHashCallOption(cast.ToString(req.GetRecordId())),
+	)
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"context"
+
+	"github.com/east-eden/server/define"
+	pbComment "github.com/east-eden/server/proto/server/comment"
+)
+
+/////////////////////////////////////////////
+// rpc call
+/////////////////////////////////////////////
+
+// 按热度区间查询评论
+func (h *RpcHandler) CallQueryCommentTopicRange(req *pbComment.QueryCommentTopicRangeRq) (*pbComment.QueryCommentTopicRangeRs, error) {
+	var topic define.CommentTopic
+	topic.FromPB(req.GetTopic())
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.commentSrv.QueryCommentTopicRange(
+		ctx,
+		req,
+		h.consistentHashCallOption(topic.Key()),
+		h.retries(3),
+	)
+}
+
+// 发表评论
+func (h *RpcHandler) CallPublishComment(req *pbComment.PublishCommentRq) (*pbComment.PublishCommentRs, error) {
+	var topic define.CommentTopic
+	topic.FromPB(req.GetTopic())
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.commentSrv.PublishComment(
+		ctx,
+		req,
+		h.consistentHashCallOption(topic.Key()),
+	)
+}
+
+// 回复评论
+func (h *RpcHandler) CallReplyComment(req *pbComment.ReplyCommentRq) (*pbComment.ReplyCommentRs, error) {
+	var topic define.CommentTopic
+	topic.FromPB(req.GetTopic())
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.commentSrv.ReplyComment(
+		ctx,
+		req,
+		h.consistentHashCallOption(topic.Key()),
+	)
+}
+
+// 删除评论或回复
+func (h *RpcHandler) CallDeleteComment(req *pbComment.DeleteCommentRq) (*pbComment.DeleteCommentRs, error) {
+	var topic define.CommentTopic
+	topic.FromPB(req.GetTopic())
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.commentSrv.DeleteComment(
+		ctx,
+		req,
+		h.consistentHashCallOption(topic.Key()),
+		h.retries(3),
+	)
+}
+
+// 点赞或取消点赞评论, 重复操作不影响点赞数, 可以重试
+func (h *RpcHandler) CallThumbsComment(req *pbComment.ThumbsCommentRq) (*pbComment.ThumbsCommentRs, error) {
+	var topic define.CommentTopic
+	topic.FromPB(req.GetTopic())
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.commentSrv.ThumbsComment(
+		ctx,
+		req,
+		h.consistentHashCallOption(topic.Key()),
+		h.retries(3),
+	)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/east-eden/server/define"
 	"github.com/east-eden/server/excel/auto"
 	pbRank "github.com/east-eden/server/proto/server/rank"
 	"github.com/spf13/cast"
@@ -19,7 +20,7 @@ var (
 
 // 请求排行
 func (h *RpcHandler) CallQueryRankByObjId(req *pbRank.QueryRankByObjIdRq) (*pbRank.QueryRankByObjIdRs, error) {
-	rankEntry, ok := auto.GetRankEntry(req.GetRankId())
+	rankEntry, ok := auto.GetRankEntry(define.RankEntryId(req.GetRankId()))
 	if !ok {
 		return nil, ErrRpcInvalidRankId
 	}
@@ -41,9 +42,57 @@ func (h *RpcHandler) CallQueryRankByObjId(req *pbRank.QueryRankByObjIdRq) (*pbRa
 	)
 }
 
+// 请求排行范围
+func (h *RpcHandler) CallQueryRankByRange(req *pbRank.QueryRankByRangeRq) (*pbRank.QueryRankByRangeRs, error) {
+	rankEntry, ok := auto.GetRankEntry(define.RankEntryId(req.GetRankId()))
+	if !ok {
+		return nil, ErrRpcInvalidRankId
+	}
+
+	var consistentKey string
+	if rankEntry.Local {
+		consistentKey = cast.ToString(h.g.ID)
+	} else {
+		consistentKey = cast.ToString(req.GetRankId())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.rankSrv.QueryRankByRange(
+		ctx,
+		req,
+		h.consistentHashCallOption(consistentKey),
+		h.retries(3),
+	)
+}
+
+// 按分数区间请求排行
+func (h *RpcHandler) CallQueryRankByScore(req *pbRank.QueryRankByScoreRq) (*pbRank.QueryRankByScoreRs, error) {
+	rankEntry, ok := auto.GetRankEntry(define.RankEntryId(req.GetRankId()))
+	if !ok {
+		return nil, ErrRpcInvalidRankId
+	}
+
+	var consistentKey string
+	if rankEntry.Local {
+		consistentKey = cast.ToString(h.g.ID)
+	} else {
+		consistentKey = cast.ToString(req.GetRankId())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.rankSrv.QueryRankByScore(
+		ctx,
+		req,
+		h.consistentHashCallOption(consistentKey),
+		h.retries(3),
+	)
+}
+
 // 设置排行积分
 func (h *RpcHandler) CallSetRankScore(req *pbRank.SetRankScoreRq) (*pbRank.SetRankScoreRs, error) {
-	rankEntry, ok := auto.GetRankEntry(req.GetRankId())
+	rankEntry, ok := auto.GetRankEntry(define.RankEntryId(req.GetRankId()))
 	if !ok {
 		return nil, ErrRpcInvalidRankId
 	}
@@ -65,6 +114,54 @@ func (h *RpcHandler) CallSetRankScore(req *pbRank.SetRankScoreRq) (*pbRank.SetRa
 	)
 }
 
+// 增减排行积分
+func (h *RpcHandler) CallIncrRankScore(req *pbRank.IncrRankScoreRq) (*pbRank.IncrRankScoreRs, error) {
+	rankEntry, ok := auto.GetRankEntry(define.RankEntryId(req.GetRankId()))
+	if !ok {
+		return nil, ErrRpcInvalidRankId
+	}
+
+	var consistentKey string
+	if rankEntry.Local {
+		consistentKey = cast.ToString(h.g.ID)
+	} else {
+		consistentKey = cast.ToString(req.GetRankId())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	// 增量修改不是幂等的, 超时重试可能重复加减积分, 不使用重试
+	return h.rankSrv.IncrRankScore(
+		ctx,
+		req,
+		h.consistentHashCallOption(consistentKey),
+	)
+}
+
+// 查询结算时间点的排行快照
+func (h *RpcHandler) CallQueryRankSnapshot(req *pbRank.QueryRankSnapshotRq) (*pbRank.QueryRankSnapshotRs, error) {
+	rankEntry, ok := auto.GetRankEntry(define.RankEntryId(req.GetRankId()))
+	if !ok {
+		return nil, ErrRpcInvalidRankId
+	}
+
+	var consistentKey string
+	if rankEntry.Local {
+		consistentKey = cast.ToString(h.g.ID)
+	} else {
+		consistentKey = cast.ToString(req.GetRankId())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRpcTimeout)
+	defer cancel()
+	return h.rankSrv.QueryRankSnapshot(
+		ctx,
+		req,
+		h.consistentHashCallOption(consistentKey),
+		h.retries(3),
+	)
+}
+
 /////////////////////////////////////////////
 // rpc receive
 /////////////////////////////////////////////
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
-	"hash/crc32"
 	"os"
 	"strings"
 	"sync"
@@ -97,6 +96,14 @@ func (s *GNetServer) OnOpened(c gnet.Conn) (out []byte, action gnet.Action) {
 		codec:  &codec.ProtoBufMarshaler{},
 	}
 
+	// session layer handshake is done when the first frame reacted
+	var err error
+	sock.session, err = transport.NewSession(s.tr.Options().Session, false)
+	if !utils.ErrCheck(err, "NewSession failed when GNetServer.OnOpened") {
+		return nil, gnet.Close
+	}
+	sock.handshaked.Store(sock.session == nil)
+
 	sock.ctx, sock.cancel = context.WithCancel(context.Background())
 
 	s.Lock()
@@ -134,6 +141,28 @@ func (s *GNetServer) Tick() (delay time.Duration, action gnet.Action) {
 // Call c.Read() or c.ReadN(n) within the parameter:c to read incoming data from client.
 // Parameter:out is the return value which is going to be sent back to the client.
 func (s *GNetServer) React(frame []byte, c gnet.Conn) (out []byte, action gnet.Action) {
+	s.RLock()
+	defer s.RUnlock()
+	sock, ok := s.conns[c]
+	if !ok {
+		return nil, gnet.Close
+	}
+
+	// first frame is session hello, reply with our hello
+	if !sock.handshaked.Load() {
+		sock.handshaked.Store(true)
+		err := sock.session.Handshake(func(hello []byte) ([]byte, error) {
+			out = hello
+			return frame, nil
+		})
+
+		if !utils.ErrCheck(err, "session handshake failed when GNetServer.React", sock.Remote()) {
+			return nil, gnet.Close
+		}
+
+		return
+	}
+
 	if len(frame) < 4 {
 		return
 	}
@@ -144,17 +173,16 @@ func (s *GNetServer) React(frame []byte, c gnet.Conn) (out []byte, action gnet.A
 		return nil, gnet.Close
 	}
 
-	message, err := s.codec.Unmarshal(frame[4:], h.RType)
+	body, err := sock.session.Open(nameCrc, frame[4:])
 	if err != nil {
 		return nil, gnet.Close
 	}
 
-	s.RLock()
-	defer s.RUnlock()
-	sock, ok := s.conns[c]
-	if !ok {
+	message, err := s.codec.Unmarshal(body, h.RType)
+	if err != nil {
 		return nil, gnet.Close
 	}
+
 	// err = s.pool.Submit(func() {
 	err = h.Fn(sock.ctx, sock, message.(proto.Message))
 	// })
@@ -175,36 +203,46 @@ func (s *GNetServer) Exit() {
 
 type gnetTransportSocket struct {
 	gnet.Conn
-	codec  codec.Marshaler
-	closed atomic.Bool
-	ctx    context.Context
-	cancel context.CancelFunc
+	codec      codec.Marshaler
+	closed     atomic.Bool
+	ctx        context.Context
+	cancel     context.CancelFunc
+	session    *transport.Session
+	handshaked atomic.Bool
 }
 
 func (s *gnetTransportSocket) Recv(transport.Register) (proto.Message, *transport.MessageHandler, error) {
 	return nil, nil, nil
 }
 
+func (s *gnetTransportSocket) RecvFrame() (uint32, []byte, error) {
+	return 0, nil, nil
+}
+
 func (s *gnetTransportSocket) Send(m proto.Message) error {
 	body, err := s.codec.Marshal(m)
 	if err != nil {
 		return err
 	}
 
-	// Message Header:
-	// 4 bytes message size, size = 4 bytes name crc + proto binary size
-	// 4 bytes message name crc32 id,
-	// Message Body:
-	var bodySize uint32 = uint32(4 + len(body))
-	name := m.ProtoReflect().Descriptor().Name()
-	var nameCrc uint32 = crc32.ChecksumIEEE([]byte(name))
-	buffer := new(bytes.Buffer)
-
-	_ = binary.Write(buffer, binary.LittleEndian, bodySize)
-	_ = binary.Write(buffer, binary.LittleEndian, uint32(nameCrc))
-	_, _ = buffer.Write(body)
+	return s.SendFrame(transport.MessageID(m), body)
+}
 
-	return s.AsyncWrite(buffer.Bytes())
+func (s *gnetTransportSocket) SendFrame(nameCrc uint32, body []byte) error {
+	return s.session.Send(nameCrc, body, func(body []byte) error {
+		// Message Header:
+		// 4 bytes message size, size = 4 bytes name crc + proto binary size
+		// 4 bytes message id, crc32 of message name or explicit id,
+		// Message Body:
+		var bodySize uint32 = uint32(4 + len(body))
+		buffer := new(bytes.Buffer)
+
+		_ = binary.Write(buffer, binary.LittleEndian, bodySize)
+		_ = binary.Write(buffer, binary.LittleEndian, nameCrc)
+		_, _ = buffer.Write(body)
+
+		return s.AsyncWrite(buffer.Bytes())
+	})
 }
 
 func (s *gnetTransportSocket) Close() {
@@ -5,6 +5,7 @@ import (
 	"hash/crc32"
 	"sync"
 
+	"github.com/asim/go-micro/v3/registry"
 	"github.com/east-eden/server/define"
 	"github.com/east-eden/server/store"
 	"github.com/east-eden/server/utils"
@@ -26,6 +27,7 @@ type GameSelector struct {
 	userPool      sync.Pool
 	userCache     *lru.Cache
 	gameMetadatas map[int16]Metadata // all game's metadata
+	draining      map[int16]string   // draining game id -> boot time
 
 	wg utils.WaitGroupWrapper
 	g  *Gate
@@ -40,6 +42,7 @@ func NewGameSelector(c *cli.Context, g *Gate) *GameSelector {
 		g:             g,
 		userCache:     lru.New(maxUserLruCache),
 		gameMetadatas: make(map[int16]Metadata),
+		draining:      make(map[int16]string),
 		consistent:    consistent.New(),
 	}
 
@@ -126,7 +129,32 @@ func (gs *GameSelector) loadUserInfo(userId int64) (*UserInfo, error) {
 	return user, nil
 }
 
-func (gs *GameSelector) SelectGame(userID string) (*UserInfo, Metadata) {
+// game node in draining will be removed from consistent hash until redeployed
+func (gs *GameSelector) SetDraining(gameId int16, bootTime string) {
+	gs.Lock()
+	defer gs.Unlock()
+
+	gs.draining[gameId] = bootTime
+	log.Info().Int16("game_id", gameId).Str("boot_time", bootTime).Msg("game node draining")
+}
+
+func (gs *GameSelector) excludeDraining(excludes ...int16) func(*registry.Node) bool {
+	return func(node *registry.Node) bool {
+		gameId := cast.ToInt16(node.Metadata["gameId"])
+		for _, id := range excludes {
+			if id == gameId {
+				return true
+			}
+		}
+
+		gs.RLock()
+		defer gs.RUnlock()
+		bootTime, ok := gs.draining[gameId]
+		return ok && bootTime == node.Metadata["bootTime"]
+	}
+}
+
+func (gs *GameSelector) SelectGame(userID string, excludes ...int16) (*UserInfo, Metadata) {
 	// todo userId 暂时为userID(string)的crc32
 	userId := crc32.ChecksumIEEE([]byte(userID))
 	userInfo, errUser := gs.loadUserInfo(int64(userId))
@@ -135,7 +163,10 @@ func (gs *GameSelector) SelectGame(userID string) (*UserInfo, Metadata) {
 	}
 
 	// every time select calls, consistent hash will be refreshed
-	next, err := gs.g.mi.srv.Client().Options().Selector.Select("game", utils.ConsistentHashSelector(gs.consistent, cast.ToString(userId)))
+	next, err := gs.g.mi.srv.Client().Options().Selector.Select(
+		"game",
+		utils.ConsistentHashSelector(gs.consistent, cast.ToString(userId), gs.excludeDraining(excludes...)),
+	)
 	if !utils.ErrCheck(err, "select game failed") {
 		return nil, Metadata{}
 	}
@@ -149,6 +180,24 @@ func (gs *GameSelector) SelectGame(userID string) (*UserInfo, Metadata) {
 	return userInfo, node.Metadata
 }
 
+// get game node's metadata by game id
+func (gs *GameSelector) GetGameMetadata(gameId int16) (Metadata, bool) {
+	srvs, err := gs.g.mi.srv.Client().Options().Registry.GetService("game")
+	if !utils.ErrCheck(err, "GetService failed when GameSelector.GetGameMetadata", gameId) {
+		return nil, false
+	}
+
+	for _, srv := range srvs {
+		for _, node := range srv.Nodes {
+			if node.Metadata["gameId"] == cast.ToString(gameId) {
+				return node.Metadata, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
 func (gs *GameSelector) Main(ctx context.Context) error {
 	exitCh := make(chan error)
 	var once sync.Once
@@ -14,6 +14,7 @@ import (
 	"github.com/east-eden/server/logger"
 	"github.com/east-eden/server/store"
 	"github.com/east-eden/server/utils"
+	"github.com/east-eden/server/utils/session"
 	"github.com/rs/zerolog"
 	log "github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v2"
@@ -27,12 +28,13 @@ type Gate struct {
 	sync.RWMutex       `bson:"-" json:"-"`
 	wg                 utils.WaitGroupWrapper `bson:"-" json:"-"`
 
-	tg         *TransferGate `bson:"-" json:"-"`
-	gin        *GinServer    `bson:"-" json:"-"`
-	mi         *MicroService `bson:"-" json:"-"`
-	gs         *GameSelector `bson:"-" json:"-"`
-	rpcHandler *RpcHandler   `bson:"-" json:"-"`
-	pubSub     *PubSub       `bson:"-" json:"-"`
+	tg         *TransferGate   `bson:"-" json:"-"`
+	gin        *GinServer      `bson:"-" json:"-"`
+	mi         *MicroService   `bson:"-" json:"-"`
+	gs         *GameSelector   `bson:"-" json:"-"`
+	rpcHandler *RpcHandler     `bson:"-" json:"-"`
+	pubSub     *PubSub         `bson:"-" json:"-"`
+	signer     *session.Signer `bson:"-" json:"-"`
 }
 
 func New() *Gate {
@@ -106,11 +108,16 @@ func (g *Gate) Action(ctx *cli.Context) error {
 
 	g.ID = int16(ctx.Int("gate_id"))
 
-	store.NewStore(ctx)
+	store.NewStore(ctx, store.Node(g.ID))
 
 	// init snowflakes
 	g.initSnowflake()
 
+	g.signer, err = session.NewSigner(ctx.String("session_token_secret"), ctx.Duration("session_token_expire"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("gate session token signer init failed")
+	}
+
 	g.tg = NewTransferGate(ctx, g)
 	g.gin = NewGinServer(ctx, g)
 	g.mi = NewMicroService(ctx, g)
@@ -123,6 +123,18 @@ func (s *GinServer) setupHttpRouter() {
 		}
 
 		if user, metadata := s.g.gs.SelectGame(req.UserID); user != nil {
+			// sign session token with selected game node
+			token, err := s.g.signer.Sign(req.UserID, cast.ToInt16(metadata["gameId"]))
+			if err != nil {
+				log.Error().
+					Err(err).
+					Str("user_id", req.UserID).
+					Msg("select_game_addr sign session token failed")
+
+				c.String(http.StatusInternalServerError, "sign session token failed")
+				return
+			}
+
 			h := gin.H{
 				"userId":        req.UserID,
 				"userName":      user.PlayerName,
@@ -130,6 +142,7 @@ func (s *GinServer) setupHttpRouter() {
 				"gameId":        metadata["gameId"],
 				"publicTcpAddr": metadata["publicTcpAddr"],
 				"publicWsAddr":  metadata["publicWsAddr"],
+				"sessionToken":  token,
 			}
 			c.JSON(http.StatusOK, h)
 
@@ -13,19 +13,55 @@ func NewFlags() []cli.Flag {
 		altsrc.NewIntFlag(&cli.IntFlag{Name: "gate_id", Usage: "gate server unique id(0-1024)"}),
 
 		// db
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "db_driver", Usage: "db driver: mongodb, mysql, sqlite3, memory", Value: "mongodb"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "db_dsn", Usage: "db data source name"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "database", Usage: "database name"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "redis_addr", Usage: "redis address"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "cache_backend", Usage: "cache backend: dummy, redis, sentinel, cluster, miniredis, redigo"}),
+		altsrc.NewStringSliceFlag(&cli.StringSliceFlag{Name: "redis_addrs", Usage: "redis sentinel or cluster addresses"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "redis_master_name", Usage: "redis sentinel master name"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "redis_password", Usage: "redis password"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "redis_db", Usage: "redis database index"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "redis_pool_size", Usage: "redis connection pool size"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "redis_min_idle_conns", Usage: "redis minimum idle connections"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "redis_dial_timeout", Usage: "redis dial timeout"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "redis_read_timeout", Usage: "redis read timeout"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "redis_write_timeout", Usage: "redis write timeout"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "redis_idle_timeout", Usage: "redis idle connection timeout"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "cache_expire", Usage: "cache key expire duration"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "store_journal_dir", Usage: "store write-behind journal directory, each node writes to a sub directory named by node id, journal is disabled if empty"}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{Name: "store_journal_fsync", Usage: "fsync every store journal record"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "store_flush_interval", Usage: "store write-behind flush interval"}),
 
 		// id and address
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "https_listen_addr", Usage: "https listen address"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "http_listen_addr", Usage: "http listen address"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "tcp_listen_addr", Usage: "tcp listen address"}),
 
+		// session
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "session_token_secret", Usage: "session token hmac secret, must be the same with game", EnvVars: []string{"SESSION_TOKEN_SECRET"}}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "session_token_expire", Usage: "session token expire duration"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "client_version_min", Usage: "minimum client version allowed to handshake"}),
+
+		// transport session layer
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "transport_cipher", Usage: "client transport cipher: none, aes-gcm, chacha20"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "transport_compress", Usage: "client transport compress: none, snappy, zstd"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "transport_compress_threshold", Usage: "compress message body greater than threshold"}),
+
 		// rate limit
 		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "rate_limit_interval", Usage: "rpc server rate limit interval"}),
 		altsrc.NewIntFlag(&cli.IntFlag{Name: "rate_limit_capacity", Usage: "rpc server rate limit capacity"}),
 
+		// flood protection
+		altsrc.NewFloat64Flag(&cli.Float64Flag{Name: "flood_msg_rate", Usage: "messages per second allowed per user"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "flood_msg_burst", Usage: "message burst allowed per user"}),
+		altsrc.NewStringSliceFlag(&cli.StringSliceFlag{Name: "flood_msg_budgets", Usage: "per message budgets of user in format MsgName=rate/burst"}),
+		altsrc.NewFloat64Flag(&cli.Float64Flag{Name: "flood_ip_msg_rate", Usage: "messages per second allowed per ip"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "flood_ip_msg_burst", Usage: "message burst allowed per ip"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "flood_conn_per_ip", Usage: "max concurrent connections per ip"}),
+		altsrc.NewIntFlag(&cli.IntFlag{Name: "flood_ban_threshold", Usage: "violations before temporary ban"}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{Name: "flood_ban_duration", Usage: "temporary ban duration"}),
+
 		// cert
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "cert_path_debug", Usage: "debug tls cert_pem path"}),
 		altsrc.NewStringFlag(&cli.StringFlag{Name: "key_path_debug", Usage: "debug tls server_key path"}),
@@ -43,6 +43,10 @@ func NewPubSub(g *Gate) *PubSub {
 	err = micro.RegisterSubscriber("game.SyncPlayerInfo", g.mi.srv.Server(), handler.ProcessSyncPlayerInfo, server.SubscriberQueue("game.SyncPlayerInfo"))
 	utils.ErrPrint(err, "subscriber game.SyncPlayerInfo failed")
 
+	// every gate should remove draining game node, so subscribe without queue
+	err = micro.RegisterSubscriber("game.Drain", g.mi.srv.Server(), handler.ProcessGameDrain)
+	utils.ErrPrint(err, "subscriber game.Drain failed")
+
 	err = micro.RegisterSubscriber("multi_publish_test", g.mi.srv.Server(), handler.ProcessMultiPublishTest, server.SubscriberQueue("multi_publish_test"))
 	utils.ErrPrint(err, "subscriber multi_publish_test failed")
 
@@ -104,6 +108,16 @@ func (s *SubscriberHandler) ProcessSyncPlayerInfo(ctx context.Context, event *pb
 	return nil
 }
 
+func (s *SubscriberHandler) ProcessGameDrain(ctx context.Context, event *pbPubSub.PubGameDrain) error {
+	if s.isDunplicateMsg(event.MsgId) {
+		return nil
+	}
+
+	log.Info().Interface("event", event).Msg("process PubGameDrain")
+	s.g.gs.SetDraining(int16(event.GetGameId()), event.GetBootTime())
+	return nil
+}
+
 func (s *SubscriberHandler) ProcessMultiPublishTest(ctx context.Context, event *pbPubSub.MultiPublishTest) error {
 	if s.isDunplicateMsg(event.MsgId) {
 		return nil
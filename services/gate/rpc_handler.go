@@ -2,6 +2,7 @@ package gate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -56,6 +57,30 @@ func (h *RpcHandler) GetGateStatus(ctx context.Context, req *pbGate.GateEmptyMes
 	return nil
 }
 
+// select a new game node for user when game node draining
+func (h *RpcHandler) SelectGameNode(ctx context.Context, req *pbGate.SelectGameNodeRequest, rsp *pbGate.SelectGameNodeReply) error {
+	user, metadata := h.g.gs.SelectGame(req.GetUserId(), int16(req.GetExcludeGameId()))
+	if user == nil || len(metadata) == 0 {
+		return fmt.Errorf("RpcHandler.SelectGameNode failed: %w", ErrGameNodeNotFound)
+	}
+
+	gameId := cast.ToInt16(metadata["gameId"])
+	if gameId == int16(req.GetExcludeGameId()) {
+		return errors.New("RpcHandler.SelectGameNode failed: no other game node available")
+	}
+
+	token, err := h.g.signer.Sign(req.GetUserId(), gameId)
+	if err != nil {
+		return fmt.Errorf("RpcHandler.SelectGameNode failed: %w", err)
+	}
+
+	rsp.GameId = int32(gameId)
+	rsp.PublicTcpAddr = metadata["publicTcpAddr"]
+	rsp.PublicWsAddr = metadata["publicWsAddr"]
+	rsp.SessionToken = token
+	return nil
+}
+
 func (h *RpcHandler) SyncPlayerInfo(ctx context.Context, req *pbGate.SyncPlayerInfoRequest, rsp *pbGate.SyncPlayerInfoReply) error {
 	tm := time.Now()
 	defer func() {
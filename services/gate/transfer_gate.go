@@ -3,7 +3,8 @@ package gate
 import (
 	"context"
 	"errors"
-	"io"
+	"fmt"
+	"net"
 	"os"
 	"runtime/debug"
 	"sync"
@@ -12,6 +13,8 @@ import (
 	pbGlobal "github.com/east-eden/server/proto/global"
 	"github.com/east-eden/server/transport"
 	"github.com/east-eden/server/utils"
+	"github.com/east-eden/server/utils/limiter"
+	"github.com/east-eden/server/utils/session"
 	"github.com/panjf2000/ants/v2"
 	log "github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v2"
@@ -20,15 +23,22 @@ import (
 
 var (
 	TcpRecvInternal = 100 * time.Millisecond
+
+	ErrClientVersionTooLow = errors.New("client version too low")
+	ErrManifestMismatch    = errors.New("protocol manifest mismatch")
+	ErrGameNodeNotFound    = errors.New("game node not found")
 )
 
 type TransferGate struct {
-	gate    *Gate
-	wg      sync.WaitGroup
-	pool    *ants.Pool
-	Options *TransferGateOptions
-	front   transport.Transport // transport with client
-	reg     transport.Register
+	gate        *Gate
+	wg          sync.WaitGroup
+	pool        *ants.Pool
+	Options     *TransferGateOptions
+	front       transport.Transport // transport with client
+	reg         transport.Register
+	userLimiter *limiter.Limiter
+	ipLimiter   *limiter.Limiter
+	names       map[uint32]string // message id -> message name
 }
 
 func NewTransferGate(ctx *cli.Context, gate *Gate) *TransferGate {
@@ -36,9 +46,51 @@ func NewTransferGate(ctx *cli.Context, gate *Gate) *TransferGate {
 	tg := &TransferGate{
 		gate: gate,
 		reg:  transport.NewTransportRegister(),
+		Options: &TransferGateOptions{
+			ClientVersionMin: ctx.String("client_version_min"),
+		},
 	}
 
 	var err error
+	tg.Options.Session.Cipher, err = transport.ParseCipherType(ctx.String("transport_cipher"))
+	if !utils.ErrCheck(err, "ParseCipherType failed when NewTransferGate", ctx.String("transport_cipher")) {
+		return nil
+	}
+
+	tg.Options.Session.Compress, err = transport.ParseCompressType(ctx.String("transport_compress"))
+	if !utils.ErrCheck(err, "ParseCompressType failed when NewTransferGate", ctx.String("transport_compress")) {
+		return nil
+	}
+	tg.Options.Session.CompressThreshold = ctx.Int("transport_compress_threshold")
+
+	// flood protection
+	tg.Options.UserLimiter = limiter.Options{
+		Service:      "gate_user",
+		Default:      limiter.Budget{Rate: ctx.Float64("flood_msg_rate"), Burst: int64(ctx.Int("flood_msg_burst"))},
+		BanThreshold: ctx.Int("flood_ban_threshold"),
+		BanDuration:  ctx.Duration("flood_ban_duration"),
+	}
+
+	tg.Options.UserLimiter.Budgets, err = limiter.ParseBudgets(ctx.StringSlice("flood_msg_budgets"))
+	if !utils.ErrCheck(err, "ParseBudgets failed when NewTransferGate", ctx.StringSlice("flood_msg_budgets")) {
+		return nil
+	}
+
+	tg.Options.IpLimiter = limiter.Options{
+		Service:      "gate_ip",
+		Default:      limiter.Budget{Rate: ctx.Float64("flood_ip_msg_rate"), Burst: int64(ctx.Int("flood_ip_msg_burst"))},
+		ConnPerKey:   ctx.Int("flood_conn_per_ip"),
+		BanThreshold: ctx.Int("flood_ban_threshold"),
+		BanDuration:  ctx.Duration("flood_ban_duration"),
+	}
+
+	tg.userLimiter = limiter.New(tg.Options.UserLimiter)
+	tg.ipLimiter = limiter.New(tg.Options.IpLimiter)
+	tg.names = make(map[uint32]string, len(pbGlobal.Manifest))
+	for name, id := range pbGlobal.Manifest {
+		tg.names[id] = name
+	}
+
 	tg.pool, err = ants.NewPool(maxClient, ants.WithExpiryDuration(10*time.Second))
 	if !utils.ErrCheck(err, "NewPool failed when NewTransferGate", maxClient) {
 		return nil
@@ -66,6 +118,8 @@ func (tg *TransferGate) serve(ctx *cli.Context) error {
 	tg.front = transport.NewTransport("tcp")
 	tg.front.Init(
 		transport.Timeout(transport.DefaultServeTimeout),
+		transport.Encrypt(tg.Options.Session.Cipher),
+		transport.Compress(tg.Options.Session.Compress, tg.Options.Session.CompressThreshold),
 	)
 
 	go func() {
@@ -101,6 +155,14 @@ func (tg *TransferGate) Exit() {
 }
 
 func (tg *TransferGate) HandleSocket(ctx context.Context, frontSock transport.Socket) {
+	// concurrent connections per ip
+	ip := remoteIp(frontSock)
+	if err := tg.ipLimiter.Connect(ip); err != nil {
+		log.Warn().Err(err).Str("remote", frontSock.Remote()).Msg("TransferGate.HandleSocket connection rejected")
+		frontSock.Close()
+		return
+	}
+
 	subCtx, cancel := context.WithCancel(ctx)
 	tg.wg.Add(1)
 	err := tg.pool.Submit(func() {
@@ -111,6 +173,7 @@ func (tg *TransferGate) HandleSocket(ctx context.Context, frontSock transport.So
 			}
 
 			frontSock.Close()
+			tg.ipLimiter.Disconnect(ip)
 			cancel()
 			tg.wg.Done()
 		}()
@@ -121,59 +184,141 @@ func (tg *TransferGate) HandleSocket(ctx context.Context, frontSock transport.So
 			return
 		}
 
-		// validation
+		if err := tg.ipLimiter.Allow(ip, string(msg.ProtoReflect().Descriptor().Name())); err != nil {
+			log.Warn().Err(err).Str("remote", frontSock.Remote()).Msg("TransferGate.handleSocket handshake throttled")
+			return
+		}
+
+		// validation and transfer
 		if err := h.Fn(subCtx, frontSock, msg); err != nil {
 			log.Warn().
 				Caller().
 				Err(err).
 				Str("msg", string(msg.ProtoReflect().Descriptor().Name())).
+				Str("remote", frontSock.Remote()).
 				Msg("TransferGate.handleSocket callback error")
 			return
 		}
+	})
 
-		handshake, ok := msg.(*pbGlobal.Handshake)
-		if !ok {
-			log.Warn().Caller().Msg("assert to Handshake failed")
-			return
-		}
+	if err != nil {
+		tg.ipLimiter.Disconnect(ip)
+	}
+	utils.ErrPrint(err, "Submit failed when handleSocket")
+}
 
-		_, metadata := tg.gate.gs.SelectGame(handshake.UserId)
-		backend := transport.NewTransport("tcp")
-		backend.Init()
-		backendSock, err := backend.Dial(metadata["publicTcpAddr"])
-		if !utils.ErrCheck(err, "Dial failed when TransferGate.HandleSocket") {
-			return
-		}
+// reply typed error to client before closing socket
+func (tg *TransferGate) rejectHandshake(sock transport.Socket, code pbGlobal.ErrorCode, err error) error {
+	reply := &pbGlobal.HandshakeResp{
+		Code: code,
+		Desc: err.Error(),
+	}
 
-		// begin transfer
-		go func() {
-			_, err := io.Copy(backendSock, frontSock)
-			if err != nil {
-				frontSock.Close()
-				backendSock.Close()
-			}
-		}()
+	utils.ErrPrint(sock.Send(reply), "send HandshakeResp failed when TransferGate.rejectHandshake", sock.Remote())
+	return fmt.Errorf("handshake rejected with code<%s>: %w", code, err)
+}
+
+func (tg *TransferGate) handleHandshake(ctx context.Context, frontSock transport.Socket, p proto.Message) error {
+	handshake, ok := p.(*pbGlobal.Handshake)
+	if !ok {
+		return errors.New("handleHandshake failed: cannot assert value to message")
+	}
 
-		_, err = io.Copy(frontSock, backendSock)
+	// check client version
+	if session.CompareVersion(handshake.GetClientVer(), tg.Options.ClientVersionMin) < 0 {
+		return tg.rejectHandshake(frontSock, pbGlobal.ErrorCode_UpgradeRequired, ErrClientVersionTooLow)
+	}
+
+	// check protocol manifest
+	if handshake.GetManifestVersion() != pbGlobal.ManifestVersion {
+		return tg.rejectHandshake(frontSock, pbGlobal.ErrorCode_UpgradeRequired, ErrManifestMismatch)
+	}
+
+	// validation
+	token, err := tg.gate.signer.Verify(handshake.GetSessionToken())
+	if err != nil {
+		return tg.rejectHandshake(frontSock, pbGlobal.ErrorCode_Unauthorized, err)
+	}
+
+	if token.UserId != handshake.GetUserId() {
+		return tg.rejectHandshake(frontSock, pbGlobal.ErrorCode_Forbidden, session.ErrTokenMismatch)
+	}
+
+	// transfer to the game node signed in token
+	metadata, ok := tg.gate.gs.GetGameMetadata(token.GameId)
+	if !ok {
+		return tg.rejectHandshake(frontSock, pbGlobal.ErrorCode_ServiceUnavailable, ErrGameNodeNotFound)
+	}
+
+	backend := transport.NewTransport("tcp")
+	backend.Init()
+	backendSock, err := backend.Dial(metadata["publicTcpAddr"])
+	if err != nil {
+		return tg.rejectHandshake(frontSock, pbGlobal.ErrorCode_ServiceUnavailable, err)
+	}
+
+	// begin transfer, frames are decrypted from front and relayed to backend in plaintext
+	go func() {
+		err := relay(backendSock, frontSock, tg.allowFrame(frontSock, handshake.GetUserId()))
 		if err != nil {
 			frontSock.Close()
 			backendSock.Close()
 		}
+	}()
 
-	})
+	err = relay(frontSock, backendSock, nil)
+	if err != nil {
+		frontSock.Close()
+		backendSock.Close()
+	}
 
-	utils.ErrPrint(err, "Submit failed when handleSocket")
+	return nil
 }
 
-func (tg *TransferGate) handleHandshake(ctx context.Context, sock transport.Socket, p proto.Message) error {
-	_, ok := p.(*pbGlobal.Handshake)
-	if !ok {
-		return errors.New("handleHandshake failed: cannot assert value to message")
+// relay frames from src to dst until error occurs, frames throttled by allow will be dropped
+func relay(dst, src transport.Socket, allow func(nameCrc uint32) error) error {
+	for {
+		nameCrc, body, err := src.RecvFrame()
+		if err != nil {
+			return err
+		}
+
+		if allow != nil {
+			if err := allow(nameCrc); errors.Is(err, limiter.ErrThrottled) {
+				continue
+			} else if err != nil {
+				return err
+			}
+		}
+
+		if err := dst.SendFrame(nameCrc, body); err != nil {
+			return err
+		}
 	}
+}
 
-	// todo check client version
+// client frames are limited by both ip and user id
+func (tg *TransferGate) allowFrame(frontSock transport.Socket, userId string) func(nameCrc uint32) error {
+	ip := remoteIp(frontSock)
+	return func(nameCrc uint32) error {
+		name, ok := tg.names[nameCrc]
+		if !ok {
+			name = "unknown"
+		}
 
-	// todo validation
+		if err := tg.ipLimiter.Allow(ip, name); err != nil {
+			return err
+		}
 
-	return nil
+		return tg.userLimiter.Allow(userId, name)
+	}
+}
+
+func remoteIp(sock transport.Socket) string {
+	host, _, err := net.SplitHostPort(sock.Remote())
+	if err != nil {
+		return sock.Remote()
+	}
+
+	return host
 }
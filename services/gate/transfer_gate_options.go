@@ -1,4 +1,13 @@
 package gate
 
+import (
+	"github.com/east-eden/server/transport"
+	"github.com/east-eden/server/utils/limiter"
+)
+
 type TransferGateOptions struct {
+	ClientVersionMin string                   // minimum client version allowed to handshake
+	Session          transport.SessionOptions // session layer with client
+	UserLimiter      limiter.Options          // flood protection per user
+	IpLimiter        limiter.Options          // flood protection per ip
 }
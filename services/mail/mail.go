@@ -106,7 +106,7 @@ func (m *Mail) Action(ctx *cli.Context) error {
 
 	m.ID = int16(ctx.Int("mail_id"))
 
-	store.NewStore(ctx)
+	store.NewStore(ctx, store.Node(m.ID))
 
 	// init snowflakes
 	m.initSnowflake()
@@ -2,16 +2,13 @@ package mail
 
 import (
 	"context"
-	"flag"
-	"log"
 	"testing"
 	"time"
 
 	"github.com/east-eden/server/define"
-	"github.com/east-eden/server/excel"
-	"github.com/east-eden/server/logger"
 	"github.com/east-eden/server/store"
 	"github.com/east-eden/server/utils"
+	"github.com/google/go-cmp/cmp"
 	"github.com/urfave/cli/v2"
 )
 
@@ -24,26 +21,7 @@ var (
 )
 
 func init() {
-	// snow flake init
-	utils.InitMachineID(gameId, 0, func() {})
-
-	// reload to project root path
-	if err := utils.RelocatePath("/server"); err != nil {
-		log.Fatalf("relocate path failed: %s", err.Error())
-	}
-
-	// logger init
-	logger.InitLogger("mail_test")
-
-	// read excel files
-	excel.ReadAllEntries("config/csv/")
-
-	set := flag.NewFlagSet("mail_test", flag.ContinueOnError)
-	set.String("db_dsn", "mongodb://localhost:27017", "mongodb address")
-	set.String("database", "game", "mongodb default database")
-	ctx = cli.NewContext(nil, set, nil)
-	store.NewStore(ctx)
-
+	ctx = store.NewTestMemStore("mail_test", gameId)
 	mailManager = NewMailManager(ctx, &Mail{})
 }
 
@@ -89,3 +67,57 @@ func TestAddMail(t *testing.T) {
 // 	}
 
 // }
+
+func TestMailBoxLoad(t *testing.T) {
+	ctx := context.Background()
+	var ownerId int64 = 10001
+
+	b := NewMailBox().(*MailBox)
+	b.Init(gameId, nil)
+	if err := b.Load(ownerId); err != nil {
+		t.Fatalf("MailBox.Load failed: %v", err)
+	}
+
+	mailIds := make([]int64, 0, 3)
+	for n := 0; n < 3; n++ {
+		newMail := &define.Mail{}
+		newMail.Init()
+		newMail.Id, _ = utils.NextID(define.SnowFlake_Mail)
+		newMail.OwnerId = ownerId
+		newMail.Type = define.Mail_Type_System
+		newMail.Title = "测试标题"
+		newMail.Attachments = []*define.LootData{{LootType: define.CostLoot_Item, LootMisc: 1, LootNum: 2}}
+		if err := b.AddMail(ctx, newMail); err != nil {
+			t.Fatalf("AddMail failed: %v", err)
+		}
+		mailIds = append(mailIds, newMail.Id)
+	}
+
+	if err := b.ReadMail(ctx, mailIds[0]); err != nil {
+		t.Fatalf("ReadMail failed: %v", err)
+	}
+
+	if err := b.DelMail(ctx, mailIds[2]); err != nil {
+		t.Fatalf("DelMail failed: %v", err)
+	}
+	store.GetStore().Flush()
+
+	// 重新加载邮箱
+	loaded := NewMailBox().(*MailBox)
+	loaded.Init(gameId, nil)
+	if err := loaded.Load(ownerId); err != nil {
+		t.Fatalf("MailBox.Load failed: %v", err)
+	}
+
+	if diff := cmp.Diff(b.Mails, loaded.Mails); diff != "" {
+		t.Fatalf("loaded mails mismatch: %s", diff)
+	}
+
+	if loaded.Mails[mailIds[0]].Status != define.Mail_Status_Readed {
+		t.Fatalf("mail %d should be readed, got status %d", mailIds[0], loaded.Mails[mailIds[0]].Status)
+	}
+
+	if loaded.LastSaveNodeId != int32(gameId) {
+		t.Fatalf("last save node should be %d, got %d", gameId, loaded.LastSaveNodeId)
+	}
+}
@@ -106,7 +106,7 @@ func (m *Rank) Action(ctx *cli.Context) error {
 
 	m.ID = int16(ctx.Int("rank_id"))
 
-	store.NewStore(ctx)
+	store.NewStore(ctx, store.Node(m.ID))
 
 	// init snowflakes
 	m.initSnowflake()
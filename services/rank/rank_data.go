@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/east-eden/server/define"
@@ -16,11 +17,12 @@ import (
 )
 
 var (
-	ErrInvalidRank         = errors.New("invalid rank")
-	ErrInvalidRankMetadata = errors.New("invalid rank metadata")
-	ErrInvalidRankStatus   = errors.New("invalid rank status")
-	ErrRankNotExist        = errors.New("rank not exist")
-	ErrAddExistRank        = errors.New("add exist rank")
+	ErrInvalidRank          = errors.New("invalid rank")
+	ErrInvalidRankMetadata  = errors.New("invalid rank metadata")
+	ErrInvalidRankStatus    = errors.New("invalid rank status")
+	ErrRankNotExist         = errors.New("rank not exist")
+	ErrAddExistRank         = errors.New("add exist rank")
+	ErrRankSnapshotNotExist = errors.New("rank snapshot not exist")
 
 	RankDataTaskTimeout          = time.Hour       // 邮箱任务超时
 	RankDataChannelResultTimeout = 5 * time.Second // 邮箱channel处理超时
@@ -28,13 +30,14 @@ var (
 
 // 排行榜数据
 type RankData struct {
-	RankId         int32           `json:"_id" bson:"_id"`
-	LastSaveNodeId int32           `json:"last_save_node_id" bson:"last_save_node_id"`
-	NodeId         int16           `json:"-" bson:"-"` // 当前节点id
-	zsets          *zset.SortedSet `json:"-" bson:"-"` // 排行zset
-	tasker         *task.Tasker    `json:"-" bson:"-"`
-	rpcHandler     *RpcHandler     `json:"-" bson:"-"`
-	entry          *auto.RankEntry `json:"-" bson:"-"`
+	RankId         int32                  `json:"_id" bson:"_id"`
+	LastSaveNodeId int32                  `json:"last_save_node_id" bson:"last_save_node_id"`
+	NodeId         int16                  `json:"-" bson:"-"` // 当前节点id
+	zsets          *zset.SortedSet        `json:"-" bson:"-"` // 排行zset
+	tasker         *task.Tasker           `json:"-" bson:"-"`
+	rpcHandler     *RpcHandler            `json:"-" bson:"-"`
+	entry          *auto.RankEntry        `json:"-" bson:"-"`
+	snapshots      []*define.RankSnapshot `json:"-" bson:"-"` // 按时间排序的排行快照
 }
 
 func NewRankData() any {
@@ -47,6 +50,7 @@ func (r *RankData) Init(nodeId int16, rpcHandler *RpcHandler) {
 	r.NodeId = nodeId
 	r.zsets = zset.New()
 	r.rpcHandler = rpcHandler
+	r.snapshots = nil
 }
 
 func (r *RankData) InitTask() {
@@ -64,7 +68,7 @@ func (r *RankData) IsTaskRunning() bool {
 
 func (r *RankData) Load(rankId int32) error {
 	var ok bool
-	r.entry, ok = auto.GetRankEntry(rankId)
+	r.entry, ok = auto.GetRankEntry(define.RankEntryId(rankId))
 	if !ok {
 		return ErrInvalidRank
 	}
@@ -102,6 +106,39 @@ func (r *RankData) Load(rankId int32) error {
 		r.zsets.Set(metadata.Score, metadata.ObjId, metadata.Date, metadata)
 	}
 
+	return r.loadSnapshots()
+}
+
+// 加载排行快照
+func (r *RankData) loadSnapshots() error {
+	if r.snapshotTime(time.Now().Unix()) == 0 {
+		return nil
+	}
+
+	res, err := store.GetStore().FindAll(context.Background(), define.StoreType_RankSnapshot, "_id.rank_id", r.RankId)
+	if errors.Is(err, store.ErrNoResult) {
+		return nil
+	}
+
+	if !utils.ErrCheck(err, "FindAll failed when RankData.loadSnapshots", r.RankId) {
+		return err
+	}
+
+	for _, v := range res {
+		vv := v.([]byte)
+		snapshot := &define.RankSnapshot{}
+		err := json.Unmarshal(vv, snapshot)
+		if !utils.ErrCheck(err, "json.Unmarshal failed when RankData.loadSnapshots", vv) {
+			continue
+		}
+
+		r.snapshots = append(r.snapshots, snapshot)
+	}
+
+	sort.Slice(r.snapshots, func(i, j int) bool {
+		return r.snapshots[i].SnapshotTime < r.snapshots[j].SnapshotTime
+	})
+
 	return nil
 }
 
@@ -137,6 +174,9 @@ func (r *RankData) SetScore(ctx context.Context, rankMetadata *define.RankMetada
 		return ErrInvalidRankMetadata
 	}
 
+	// 排行变化之前生成快照
+	r.checkSnapshot(ctx, time.Now().Unix())
+
 	rr := &define.RankMetadata{}
 	*rr = *rankMetadata
 
@@ -144,16 +184,40 @@ func (r *RankData) SetScore(ctx context.Context, rankMetadata *define.RankMetada
 		rr.Score *= -1
 	}
 
+	// 只保留最好成绩, zset中分数小的排在前面
+	if r.entry.KeepBest {
+		if data, ok := r.zsets.GetData(rr.ObjId); ok && data.(*define.RankMetadata).Score <= rr.Score {
+			return nil
+		}
+	}
+
 	r.zsets.Set(rr.Score, rr.ObjId, rr.Date, rr)
 
 	// save rank metadata
 	err := store.GetStore().UpdateOne(ctx, define.StoreType_Rank, rr.RankKey, rr)
 	_ = utils.ErrCheck(err, "UpdateOne failed when RankData.SetScore", rr)
 
+	r.trim(ctx)
 	r.saveLastNode()
 	return err
 }
 
+// 删除超出排行榜最大数量的数据
+func (r *RankData) trim(ctx context.Context) {
+	if r.entry.MaxSize <= 0 {
+		return
+	}
+
+	for r.zsets.Length() > int64(r.entry.MaxSize) {
+		objId, _, _ := r.zsets.GetDataByRank(r.zsets.Length()-1, false)
+		r.zsets.Delete(objId)
+
+		key := define.RankKey{ObjId: objId, RankId: r.RankId}
+		err := store.GetStore().DeleteOne(ctx, define.StoreType_Rank, key)
+		_ = utils.ErrCheck(err, "DeleteOne failed when RankData.trim", key)
+	}
+}
+
 func (r *RankData) GetRankByObjId(ctx context.Context, objId int64) (rank int64, metadata define.RankMetadata, err error) {
 	zRank, _, data := r.zsets.GetRank(objId, false)
 	rank = zRank
@@ -180,3 +244,127 @@ func (r *RankData) GetRankByRange(ctx context.Context, start, end int64) (metada
 	})
 	return
 }
+
+// 按分数区间查询, 排行顺序返回, limit <= 0时返回区间内所有数据
+func (r *RankData) GetRankByScore(ctx context.Context, min, max float64, limit int64) (metadatas []define.RankMetadata, err error) {
+	if r.entry.Desc {
+		min, max = -max, -min
+	}
+
+	r.zsets.RangeByScore(min, max, limit, func(score float64, key int64, data any) {
+		rr := *data.(*define.RankMetadata)
+		if r.entry.Desc {
+			rr.Score *= -1
+		}
+		metadatas = append(metadatas, rr)
+	})
+	return
+}
+
+// 增减排行积分, 不在排行榜中时以rankMetadata.Score为初始积分
+func (r *RankData) IncrScore(ctx context.Context, rankMetadata *define.RankMetadata, delta float64) (metadata define.RankMetadata, err error) {
+	if rankMetadata == nil {
+		err = ErrInvalidRankMetadata
+		return
+	}
+
+	// 排行变化之前生成快照
+	r.checkSnapshot(ctx, time.Now().Unix())
+
+	rr := &define.RankMetadata{}
+	*rr = *rankMetadata
+
+	if r.entry.Desc {
+		rr.Score *= -1
+		delta *= -1
+	}
+
+	if data, ok := r.zsets.GetData(rr.ObjId); ok {
+		rr.Score = data.(*define.RankMetadata).Score
+	}
+	rr.Score += delta
+
+	r.zsets.Set(rr.Score, rr.ObjId, rr.Date, rr)
+
+	// save rank metadata
+	err = store.GetStore().UpdateOne(ctx, define.StoreType_Rank, rr.RankKey, rr)
+	_ = utils.ErrCheck(err, "UpdateOne failed when RankData.IncrScore", rr)
+
+	r.trim(ctx)
+	r.saveLastNode()
+
+	metadata = *rr
+	if r.entry.Desc {
+		metadata.Score *= -1
+	}
+	return
+}
+
+// 排行快照时间点, 返回0表示该排行榜不需要快照
+func (r *RankData) snapshotTime(now int64) int64 {
+	if define.RankEntryId(r.RankId) != define.RankId_Arena {
+		return 0
+	}
+
+	globalConfig, ok := auto.GetGlobalConfig()
+	if !ok {
+		return 0
+	}
+
+	season := r.RankId % define.RankSubIdScale
+	return define.ArenaSnapshotTime(now, season, int64(globalConfig.ArenaSeasonBeginTime), globalConfig.ArenaSeasonDays)
+}
+
+// 过了结算时间点后在排行第一次变化之前生成快照, 快照即为结算时间点的排行
+func (r *RankData) checkSnapshot(ctx context.Context, now int64) {
+	t := r.snapshotTime(now)
+	if t == 0 {
+		return
+	}
+
+	if n := len(r.snapshots); n > 0 && r.snapshots[n-1].SnapshotTime >= t {
+		return
+	}
+
+	snapshot := &define.RankSnapshot{
+		RankSnapshotKey: define.RankSnapshotKey{RankId: r.RankId, SnapshotTime: t},
+		ObjIds:          make([]int64, 0, r.zsets.Length()),
+	}
+	r.zsets.Range(0, -1, func(score float64, key int64, data any) {
+		snapshot.ObjIds = append(snapshot.ObjIds, key)
+	})
+
+	err := store.GetStore().UpdateOne(ctx, define.StoreType_RankSnapshot, snapshot.RankSnapshotKey, snapshot, true)
+	_ = utils.ErrCheck(err, "UpdateOne failed when RankData.checkSnapshot", r.RankId, t)
+	r.snapshots = append(r.snapshots, snapshot)
+
+	// 删除过期快照, 最近一次快照一直保留
+	expire := now - define.RankSnapshotKeepDays*86400
+	for len(r.snapshots) > 1 && r.snapshots[0].SnapshotTime < expire {
+		err := store.GetStore().DeleteOne(ctx, define.StoreType_RankSnapshot, r.snapshots[0].RankSnapshotKey)
+		_ = utils.ErrCheck(err, "DeleteOne failed when RankData.checkSnapshot", r.snapshots[0].RankSnapshotKey)
+		r.snapshots = r.snapshots[1:]
+	}
+}
+
+// 查询结算时间点的排名, 结算时间点之后排行没有变化时快照时间晚于结算时间点
+func (r *RankData) GetSnapshotRank(ctx context.Context, t int64, objId int64) (snapshotTime int64, rank int32, err error) {
+	r.checkSnapshot(ctx, time.Now().Unix())
+
+	// 赛季结束后的结算时间点都对应赛季结束时的快照
+	if st := r.snapshotTime(t); st > 0 && st < t {
+		t = st
+	}
+
+	idx := sort.Search(len(r.snapshots), func(i int) bool {
+		return r.snapshots[i].SnapshotTime >= t
+	})
+
+	if idx >= len(r.snapshots) {
+		err = ErrRankSnapshotNotExist
+		return
+	}
+
+	snapshot := r.snapshots[idx]
+	return snapshot.SnapshotTime, snapshot.GetRank(objId), nil
+}
@@ -0,0 +1,348 @@
+package rank
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/east-eden/server/define"
+	"github.com/east-eden/server/excel"
+	"github.com/east-eden/server/excel/auto"
+	"github.com/east-eden/server/store"
+	"github.com/east-eden/server/store/db"
+	"github.com/google/go-cmp/cmp"
+	"github.com/urfave/cli/v2"
+)
+
+// 使用内存数据库, 不依赖mongodb和配置文件
+func initMemStore(t *testing.T) {
+	t.Helper()
+
+	set := flag.NewFlagSet("rank_test", flag.ContinueOnError)
+	store.NewStore(cli.NewContext(nil, set, nil), store.DB(db.NewMemDB()))
+	store.GetStore().AddStoreInfo(define.StoreType_Rank, "rank", "_id")
+	if err := store.GetStore().MigrateDbTable("rank"); err != nil {
+		t.Fatalf("migrate collection rank failed: %v", err)
+	}
+
+	store.GetStore().AddStoreInfo(define.StoreType_RankSnapshot, "rank_snapshot", "_id")
+	if err := store.GetStore().MigrateDbTable("rank_snapshot"); err != nil {
+		t.Fatalf("migrate collection rank_snapshot failed: %v", err)
+	}
+
+	err := (&auto.RankEntries{}).Load(&excel.ExcelFileRaw{
+		Filename: "Rank.csv",
+		CellData: []excel.ExcelRowData{{"Id": int32(1), "Desc": true}},
+	})
+	if err != nil {
+		t.Fatalf("load rank entries failed: %v", err)
+	}
+
+	// 竞技场赛季配置为空时不生成排行快照
+	if err := (&auto.GlobalConfigEntries{}).Load(&excel.ExcelFileRaw{Filename: "GlobalConfig.csv"}); err != nil {
+		t.Fatalf("load global config entries failed: %v", err)
+	}
+}
+
+func TestRankDataLoad(t *testing.T) {
+	initMemStore(t)
+	defer store.GetStore().Exit()
+
+	r := &RankData{}
+	r.Init(1, nil)
+	if err := r.Load(2); err != ErrInvalidRank {
+		t.Fatalf("load rank not in Rank.csv should return ErrInvalidRank, got %v", err)
+	}
+
+	// 新建排行榜
+	if err := r.Load(1); err != nil {
+		t.Fatalf("RankData.Load failed: %v", err)
+	}
+
+	ctx := context.Background()
+	metadatas := []*define.RankMetadata{
+		{RankKey: define.RankKey{ObjId: 101, RankId: 1}, ObjName: "a", Score: 10, Date: 1},
+		{RankKey: define.RankKey{ObjId: 102, RankId: 1}, ObjName: "b", Score: 30, Date: 2},
+		{RankKey: define.RankKey{ObjId: 103, RankId: 1}, ObjName: "c", Score: 20, Date: 3},
+	}
+	for _, md := range metadatas {
+		if err := r.SetScore(ctx, md); err != nil {
+			t.Fatalf("SetScore failed: %v", err)
+		}
+	}
+	store.GetStore().Flush()
+
+	// 重新加载排行榜数据
+	loaded := &RankData{}
+	loaded.Init(2, nil)
+	if err := loaded.Load(1); err != nil {
+		t.Fatalf("RankData.Load failed: %v", err)
+	}
+
+	got, err := loaded.GetRankByRange(ctx, 0, 2)
+	if err != nil {
+		t.Fatalf("GetRankByRange failed: %v", err)
+	}
+
+	want := []define.RankMetadata{*metadatas[1], *metadatas[2], *metadatas[0]}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("loaded rank mismatch: %s", diff)
+	}
+
+	if loaded.LastSaveNodeId != 1 {
+		t.Fatalf("last save node should be 1, got %d", loaded.LastSaveNodeId)
+	}
+}
+
+func TestTowerRankData(t *testing.T) {
+	initMemStore(t)
+	defer store.GetStore().Exit()
+
+	err := (&auto.RankEntries{}).Load(&excel.ExcelFileRaw{
+		Filename: "Rank.csv",
+		CellData: []excel.ExcelRowData{{"Id": int32(define.RankId_Tower), "MaxSize": int32(2), "KeepBest": true}},
+	})
+	if err != nil {
+		t.Fatalf("load rank entries failed: %v", err)
+	}
+
+	// 每层塔为一个分榜
+	rankId := define.TowerRankId(define.Tower_Type_Nature, 3)
+	if define.RankEntryId(rankId) != define.RankId_Tower || rankId == define.TowerRankId(define.Tower_Type_Nature, 4) {
+		t.Fatalf("invalid tower rank id %d", rankId)
+	}
+
+	r := &RankData{}
+	r.Init(1, nil)
+	if err := r.Load(rankId); err != nil {
+		t.Fatalf("RankData.Load failed: %v", err)
+	}
+
+	newMetadata := func(objId int64, passTime, rounds int32, recordId int64) *define.RankMetadata {
+		return &define.RankMetadata{
+			RankKey:  define.RankKey{ObjId: objId, RankId: rankId},
+			ObjName:  "p",
+			Score:    define.TowerRankScore(passTime, rounds),
+			Date:     int64(recordId),
+			RecordId: recordId,
+		}
+	}
+
+	ctx := context.Background()
+	metadatas := []*define.RankMetadata{
+		newMetadata(101, 30, 10, 1),
+		newMetadata(102, 20, 8, 2),
+		newMetadata(101, 40, 12, 3), // 没有超越自己的记录
+		newMetadata(103, 20, 7, 4),  // 时间相同回合数更少
+	}
+	for _, md := range metadatas {
+		if err := r.SetScore(ctx, md); err != nil {
+			t.Fatalf("SetScore failed: %v", err)
+		}
+	}
+	store.GetStore().Flush()
+
+	// 只保留最快的2条记录
+	want := []define.RankMetadata{*metadatas[3], *metadatas[1]}
+	got, err := r.GetRankByRange(ctx, 0, -1)
+	if err != nil {
+		t.Fatalf("GetRankByRange failed: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("tower rank mismatch: %s", diff)
+	}
+
+	loaded := &RankData{}
+	loaded.Init(2, nil)
+	if err := loaded.Load(rankId); err != nil {
+		t.Fatalf("RankData.Load failed: %v", err)
+	}
+
+	got, _ = loaded.GetRankByRange(ctx, 0, -1)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("loaded tower rank mismatch: %s", diff)
+	}
+}
+
+func TestArenaRankData(t *testing.T) {
+	initMemStore(t)
+	defer store.GetStore().Exit()
+
+	err := (&auto.RankEntries{}).Load(&excel.ExcelFileRaw{
+		Filename: "Rank.csv",
+		CellData: []excel.ExcelRowData{{"Id": int32(define.RankId_Arena), "Desc": true}},
+	})
+	if err != nil {
+		t.Fatalf("load rank entries failed: %v", err)
+	}
+
+	rankId := define.ArenaRankId(1)
+	r := &RankData{}
+	r.Init(1, nil)
+	if err := r.Load(rankId); err != nil {
+		t.Fatalf("RankData.Load failed: %v", err)
+	}
+
+	ctx := context.Background()
+	newMetadata := func(objId int64) *define.RankMetadata {
+		return &define.RankMetadata{
+			RankKey: define.RankKey{ObjId: objId, RankId: rankId},
+			ObjName: "p",
+			Score:   1000,
+			Date:    objId,
+		}
+	}
+
+	// 不在排行中时以初始分数加入
+	for objId := int64(101); objId <= 104; objId++ {
+		md, err := r.IncrScore(ctx, newMetadata(objId), 0)
+		if err != nil || md.Score != 1000 {
+			t.Fatalf("IncrScore join failed: %v, score %v", err, md.Score)
+		}
+	}
+
+	if md, _ := r.IncrScore(ctx, newMetadata(101), 200); md.Score != 1200 {
+		t.Fatalf("IncrScore expect 1200, got %v", md.Score)
+	}
+
+	if md, _ := r.IncrScore(ctx, newMetadata(102), -300); md.Score != 700 {
+		t.Fatalf("IncrScore expect 700, got %v", md.Score)
+	}
+
+	// 再次加入时积分不变
+	if md, _ := r.IncrScore(ctx, newMetadata(101), 0); md.Score != 1200 {
+		t.Fatalf("IncrScore rejoin expect 1200, got %v", md.Score)
+	}
+
+	getIds := func(metadatas []define.RankMetadata) []int64 {
+		ids := make([]int64, 0, len(metadatas))
+		for _, md := range metadatas {
+			ids = append(ids, md.ObjId)
+		}
+		return ids
+	}
+
+	got, err := r.GetRankByScore(ctx, 900, 1300, 0)
+	if err != nil {
+		t.Fatalf("GetRankByScore failed: %v", err)
+	}
+
+	if diff := cmp.Diff([]int64{101, 103, 104}, getIds(got)); diff != "" {
+		t.Fatalf("GetRankByScore mismatch: %s", diff)
+	}
+
+	if got[0].Score != 1200 {
+		t.Fatalf("GetRankByScore score expect 1200, got %v", got[0].Score)
+	}
+
+	got, _ = r.GetRankByScore(ctx, 0, 1100, 2)
+	if diff := cmp.Diff([]int64{103, 104}, getIds(got)); diff != "" {
+		t.Fatalf("GetRankByScore with limit mismatch: %s", diff)
+	}
+
+	store.GetStore().Flush()
+
+	loaded := &RankData{}
+	loaded.Init(2, nil)
+	if err := loaded.Load(rankId); err != nil {
+		t.Fatalf("RankData.Load failed: %v", err)
+	}
+
+	got, _ = loaded.GetRankByRange(ctx, 0, -1)
+	if diff := cmp.Diff([]int64{101, 103, 104, 102}, getIds(got)); diff != "" {
+		t.Fatalf("loaded arena rank mismatch: %s", diff)
+	}
+}
+
+func TestArenaRankSnapshot(t *testing.T) {
+	initMemStore(t)
+	defer store.GetStore().Exit()
+
+	err := (&auto.RankEntries{}).Load(&excel.ExcelFileRaw{
+		Filename: "Rank.csv",
+		CellData: []excel.ExcelRowData{{"Id": int32(define.RankId_Arena), "Desc": true}},
+	})
+	if err != nil {
+		t.Fatalf("load rank entries failed: %v", err)
+	}
+
+	// 第一赛季3天前开始, 持续14天
+	now := time.Now().Unix()
+	begin := now - 3*86400
+	err = (&auto.GlobalConfigEntries{}).Load(&excel.ExcelFileRaw{
+		Filename: "GlobalConfig.csv",
+		CellData: []excel.ExcelRowData{{"Id": int32(1), "ArenaSeasonBeginTime": int32(begin), "ArenaSeasonDays": int32(14)}},
+	})
+	if err != nil {
+		t.Fatalf("load global config entries failed: %v", err)
+	}
+
+	rankId := define.ArenaRankId(1)
+	r := &RankData{}
+	r.Init(1, nil)
+	if err := r.Load(rankId); err != nil {
+		t.Fatalf("RankData.Load failed: %v", err)
+	}
+
+	ctx := context.Background()
+	incr := func(objId int64, delta float64) {
+		md := &define.RankMetadata{RankKey: define.RankKey{ObjId: objId, RankId: rankId}, Score: 1000, Date: objId}
+		if _, err := r.IncrScore(ctx, md, delta); err != nil {
+			t.Fatalf("IncrScore failed: %v", err)
+		}
+	}
+
+	// 今日结算时间点的快照在第一次变化之前生成, 排行为空
+	today := define.ArenaDailySettleTime(now)
+	for objId := int64(101); objId <= 103; objId++ {
+		incr(objId, 0)
+	}
+	incr(101, 200)
+
+	if _, rank, err := r.GetSnapshotRank(ctx, today, 101); err != nil || rank != -1 {
+		t.Fatalf("today snapshot should be empty, got rank %d, %v", rank, err)
+	}
+
+	// 明日结算时间点生成快照后, 之后的变化不影响奖励排名
+	tomorrow := today + 86400
+	r.checkSnapshot(ctx, tomorrow+60)
+	incr(103, 500)
+
+	cases := map[int64]int32{101: 0, 102: 1, 103: 2}
+	for objId, want := range cases {
+		if st, rank, err := r.GetSnapshotRank(ctx, tomorrow, objId); err != nil || st != tomorrow || rank != want {
+			t.Fatalf("snapshot rank of %d expect %d, got %d at %d, %v", objId, want, rank, st, err)
+		}
+	}
+
+	// 重新加载后快照不变
+	store.GetStore().Flush()
+	loaded := &RankData{}
+	loaded.Init(2, nil)
+	if err := loaded.Load(rankId); err != nil {
+		t.Fatalf("RankData.Load failed: %v", err)
+	}
+
+	if _, rank, _ := loaded.GetSnapshotRank(ctx, tomorrow, 103); rank != 2 {
+		t.Fatalf("loaded snapshot rank of 103 expect 2, got %d", rank)
+	}
+
+	// 赛季结束前没有赛季快照, 结束后的结算时间点都对应赛季结束时的快照
+	end := define.ArenaSeasonEndTime(1, begin, 14)
+	if _, _, err := loaded.GetSnapshotRank(ctx, end+86400, 103); !errors.Is(err, ErrRankSnapshotNotExist) {
+		t.Fatalf("season snapshot should not exist before season end, got %v", err)
+	}
+
+	loaded.checkSnapshot(ctx, end+60)
+	if st, rank, err := loaded.GetSnapshotRank(ctx, end+86400, 103); err != nil || st != end || rank != 0 {
+		t.Fatalf("season snapshot rank of 103 expect 0, got %d at %d, %v", rank, st, err)
+	}
+
+	// 过期的每日快照被删除
+	if len(loaded.snapshots) != 1 {
+		t.Fatalf("expired snapshots should be removed, got %d", len(loaded.snapshots))
+	}
+}
@@ -53,6 +53,11 @@ func NewRankManager(ctx *cli.Context, r *Rank) *RankManager {
 		log.Fatal().Err(err).Msg("migrate collection rank failed")
 	}
 
+	store.GetStore().AddStoreInfo(define.StoreType_RankSnapshot, "rank_snapshot", "_id")
+	if err := store.GetStore().MigrateDbTable("rank_snapshot"); err != nil {
+		log.Fatal().Err(err).Msg("migrate collection rank_snapshot failed")
+	}
+
 	log.Info().Msg("RankManager init ok ...")
 	return manager
 }
@@ -239,6 +244,22 @@ func (m *RankManager) QueryRankByRange(ctx context.Context, rankId int32, start,
 	return
 }
 
+func (m *RankManager) QueryRankByScore(ctx context.Context, rankId int32, min, max float64, limit int64) (raws []define.RankMetadata, err error) {
+	err = m.AddTask(
+		ctx,
+		rankId,
+		func(c context.Context, p ...any) error {
+			var e error
+			rankData := p[0].(*RankData)
+			raws, e = rankData.GetRankByScore(c, min, max, limit)
+			return e
+		},
+	)
+
+	_ = utils.ErrCheck(err, "AddTask failed when RankManager.QueryRankByScore", rankId, min, max)
+	return
+}
+
 // 设置排行积分
 func (m *RankManager) SetRankScore(ctx context.Context, rankId int32, metadata *define.RankMetadata) error {
 	return m.AddTask(ctx, rankId, func(c context.Context, p ...any) error {
@@ -246,3 +267,27 @@ func (m *RankManager) SetRankScore(ctx context.Context, rankId int32, metadata *
 		return rankData.SetScore(ctx, metadata)
 	})
 }
+
+// 增减排行积分
+func (m *RankManager) IncrRankScore(ctx context.Context, rankId int32, metadata *define.RankMetadata, delta float64) (raw define.RankMetadata, err error) {
+	err = m.AddTask(ctx, rankId, func(c context.Context, p ...any) error {
+		var e error
+		rankData := p[0].(*RankData)
+		raw, e = rankData.IncrScore(ctx, metadata, delta)
+		return e
+	})
+	return
+}
+
+// 查询结算时间点的排行快照
+func (m *RankManager) QueryRankSnapshot(ctx context.Context, rankId int32, t int64, objId int64) (snapshotTime int64, rank int32, err error) {
+	err = m.AddTask(ctx, rankId, func(c context.Context, p ...any) error {
+		var e error
+		rankData := p[0].(*RankData)
+		snapshotTime, rank, e = rankData.GetSnapshotRank(c, t, objId)
+		return e
+	})
+
+	_ = utils.ErrCheck(err, "AddTask failed when RankManager.QueryRankSnapshot", rankId, t, objId)
+	return
+}
@@ -129,6 +129,21 @@ func (h *RpcHandler) QueryRankByRange(
 	return err
 }
 
+// 按分数区间查询排行
+func (h *RpcHandler) QueryRankByScore(
+	ctx context.Context,
+	req *pbRank.QueryRankByScoreRq,
+	rsp *pbRank.QueryRankByScoreRs,
+) error {
+	rsp.RankId = req.GetRankId()
+	metadatas, err := h.m.manager.QueryRankByScore(ctx, req.GetRankId(), req.GetMin(), req.GetMax(), req.GetLimit())
+	rsp.Metadatas = make([]*pbGlobal.RankMetadata, 0, len(metadatas))
+	for _, metadata := range metadatas {
+		rsp.Metadatas = append(rsp.Metadatas, metadata.ToPB())
+	}
+	return err
+}
+
 // 踢出邮件cache
 func (h *RpcHandler) KickRankData(
 	ctx context.Context,
@@ -153,3 +168,42 @@ func (h *RpcHandler) SetRankScore(
 	metadata.FromPB(req.GetMetadata())
 	return h.m.manager.SetRankScore(ctx, req.GetRankId(), metadata)
 }
+
+// 增减排行积分
+func (h *RpcHandler) IncrRankScore(
+	ctx context.Context,
+	req *pbRank.IncrRankScoreRq,
+	rsp *pbRank.IncrRankScoreRs,
+) error {
+	metadata := &define.RankMetadata{
+		RankKey: define.RankKey{
+			ObjId:  req.GetMetadata().ObjId,
+			RankId: req.GetRankId(),
+		},
+	}
+	metadata.FromPB(req.GetMetadata())
+
+	raw, err := h.m.manager.IncrRankScore(ctx, req.GetRankId(), metadata, req.GetDelta())
+	if utils.ErrCheck(err, "IncrRankScore failed when RpcHandler.IncrRankScore", req.GetRankId(), req.GetDelta()) {
+		rsp.Metadata = raw.ToPB()
+	}
+	return err
+}
+
+// 查询结算时间点的排行快照
+func (h *RpcHandler) QueryRankSnapshot(
+	ctx context.Context,
+	req *pbRank.QueryRankSnapshotRq,
+	rsp *pbRank.QueryRankSnapshotRs,
+) error {
+	rsp.RankId = req.GetRankId()
+	rsp.ObjId = req.GetObjId()
+	rsp.RankIndex = -1
+
+	snapshotTime, rank, err := h.m.manager.QueryRankSnapshot(ctx, req.GetRankId(), req.GetSnapshotTime(), req.GetObjId())
+	if utils.ErrCheck(err, "QueryRankSnapshot failed when RpcHandler.QueryRankSnapshot", req.GetRankId(), req.GetSnapshotTime()) {
+		rsp.SnapshotTime = snapshotTime
+		rsp.RankIndex = rank
+	}
+	return err
+}
@@ -2,8 +2,11 @@ package cache
 
 import (
 	"errors"
+	"os"
+	"strings"
 	"time"
 
+	log "github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v2"
 )
 
@@ -15,6 +18,16 @@ var (
 	ExpireTime = 24 * time.Hour // 过期时间24小时
 )
 
+// cache backends
+const (
+	Backend_Dummy     = "dummy"     // 不使用缓存
+	Backend_Redis     = "redis"     // go-redis单节点
+	Backend_Sentinel  = "sentinel"  // go-redis哨兵模式
+	Backend_Cluster   = "cluster"   // go-redis集群模式
+	Backend_MiniRedis = "miniredis" // 内嵌miniredis, 测试使用
+	Backend_Redigo    = "redigo"    // redigo + rejson, redis需要加载RedisJSON模块
+)
+
 type Cache interface {
 	SaveObject(prefix string, k interface{}, x interface{}) error
 	SaveHashObject(prefix string, k interface{}, field interface{}, x interface{}) error
@@ -26,6 +39,80 @@ type Cache interface {
 	Exit() error
 }
 
+type Options struct {
+	Backend      string
+	Addrs        []string // 单节点只使用第一个地址, 哨兵模式为sentinel地址, 集群模式为种子节点地址
+	MasterName   string   // 哨兵模式master名字
+	Password     string
+	DB           int
+	PoolSize     int
+	MinIdleConns int
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+}
+
+func (o *Options) Addr() string {
+	if len(o.Addrs) == 0 {
+		return ""
+	}
+
+	return o.Addrs[0]
+}
+
+func NewOptions(ctx *cli.Context) *Options {
+	opts := &Options{
+		Backend:      strings.ToLower(ctx.String("cache_backend")),
+		Addrs:        ctx.StringSlice("redis_addrs"),
+		MasterName:   ctx.String("redis_master_name"),
+		Password:     ctx.String("redis_password"),
+		DB:           ctx.Int("redis_db"),
+		PoolSize:     ctx.Int("redis_pool_size"),
+		MinIdleConns: ctx.Int("redis_min_idle_conns"),
+		DialTimeout:  ctx.Duration("redis_dial_timeout"),
+		ReadTimeout:  ctx.Duration("redis_read_timeout"),
+		WriteTimeout: ctx.Duration("redis_write_timeout"),
+		IdleTimeout:  ctx.Duration("redis_idle_timeout"),
+	}
+
+	if opts.Backend == "" {
+		opts.Backend = Backend_Dummy
+	}
+
+	// 环境变量REDIS_ADDR优先
+	if redisAddr, ok := os.LookupEnv("REDIS_ADDR"); ok {
+		opts.Addrs = strings.Split(redisAddr, ",")
+	} else if len(opts.Addrs) == 0 && ctx.String("redis_addr") != "" {
+		opts.Addrs = []string{ctx.String("redis_addr")}
+	}
+
+	if expire := ctx.Duration("cache_expire"); expire > 0 {
+		ExpireTime = expire
+	}
+
+	return opts
+}
+
 func NewCache(ctx *cli.Context) Cache {
-	return NewDummyRedis(ctx)
+	opts := NewOptions(ctx)
+	log.Info().
+		Str("backend", opts.Backend).
+		Strs("addrs", opts.Addrs).
+		Dur("expire", ExpireTime).
+		Msg("new cache")
+
+	switch opts.Backend {
+	case Backend_Redis, Backend_Sentinel, Backend_Cluster:
+		return NewGoRedis(opts)
+	case Backend_MiniRedis:
+		return NewMiniRedis(opts)
+	case Backend_Redigo:
+		return NewRedigo(opts)
+	case Backend_Dummy:
+		return NewDummyRedis(opts)
+	default:
+		log.Warn().Str("backend", opts.Backend).Msg("unknown cache backend, use dummy instead")
+		return NewDummyRedis(opts)
+	}
 }
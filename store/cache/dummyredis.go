@@ -1,20 +1,10 @@
 package cache
 
-import (
-	"os"
-
-	"github.com/urfave/cli/v2"
-)
-
+// DummyRedis does nothing, all loads return no result
 type DummyRedis struct {
 }
 
-func NewDummyRedis(ctx *cli.Context) *DummyRedis {
-	_, ok := os.LookupEnv("REDIS_ADDR")
-	if !ok {
-		_ = ctx.String("redis_addr")
-	}
-
+func NewDummyRedis(opts *Options) *DummyRedis {
 	r := &DummyRedis{}
 
 	return r
@@ -4,32 +4,127 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/east-eden/server/utils"
 	"github.com/go-redis/redis"
 	json "github.com/json-iterator/go"
-	"github.com/urfave/cli/v2"
+	log "github.com/rs/zerolog/log"
 )
 
+type poolStater interface {
+	PoolStats() *redis.PoolStats
+}
+
+// GoRedis supports redis single node, sentinel and cluster
 type GoRedis struct {
-	redisCli *redis.Client
+	backend  string
+	redisCli redis.UniversalClient
+	done     chan struct{}
+	utils.WaitGroupWrapper
 }
 
-func NewGoRedis(ctx *cli.Context) *GoRedis {
-	redisAddr, ok := os.LookupEnv("REDIS_ADDR")
-	if !ok {
-		redisAddr = ctx.String("redis_addr")
+func newGoRedisClient(opts *Options) redis.UniversalClient {
+	switch opts.Backend {
+	case Backend_Sentinel:
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    opts.MasterName,
+			SentinelAddrs: opts.Addrs,
+			Password:      opts.Password,
+			DB:            opts.DB,
+			DialTimeout:   opts.DialTimeout,
+			ReadTimeout:   opts.ReadTimeout,
+			WriteTimeout:  opts.WriteTimeout,
+			PoolSize:      opts.PoolSize,
+			MinIdleConns:  opts.MinIdleConns,
+			IdleTimeout:   opts.IdleTimeout,
+		})
+
+	case Backend_Cluster:
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        opts.Addrs,
+			Password:     opts.Password,
+			DialTimeout:  opts.DialTimeout,
+			ReadTimeout:  opts.ReadTimeout,
+			WriteTimeout: opts.WriteTimeout,
+			PoolSize:     opts.PoolSize,
+			MinIdleConns: opts.MinIdleConns,
+			IdleTimeout:  opts.IdleTimeout,
+		})
+
+	default:
+		return redis.NewClient(&redis.Options{
+			Addr:         opts.Addr(),
+			Password:     opts.Password,
+			DB:           opts.DB,
+			DialTimeout:  opts.DialTimeout,
+			ReadTimeout:  opts.ReadTimeout,
+			WriteTimeout: opts.WriteTimeout,
+			PoolSize:     opts.PoolSize,
+			MinIdleConns: opts.MinIdleConns,
+			IdleTimeout:  opts.IdleTimeout,
+		})
 	}
+}
+
+func NewGoRedis(opts *Options) *GoRedis {
+	return newGoRedis(opts.Backend, opts)
+}
 
+// backend is used as metrics label
+func newGoRedis(backend string, opts *Options) *GoRedis {
 	r := &GoRedis{
-		redisCli: redis.NewClient(&redis.Options{Addr: redisAddr}),
+		backend:  backend,
+		redisCli: newGoRedisClient(opts),
+		done:     make(chan struct{}),
+	}
+
+	// 连接失败不影响启动, 缓存读取失败时会直接读取数据库
+	if err := r.healthCheck(); err != nil {
+		log.Warn().Err(err).Str("backend", r.backend).Strs("addrs", opts.Addrs).Msg("goredis ping failed")
 	}
 
+	r.Wrap(r.run)
 	return r
 }
 
-func (r *GoRedis) SaveObject(prefix string, k any, x any) error {
+func (r *GoRedis) healthCheck() error {
+	err := r.redisCli.Ping().Err()
+	setUp(r.backend, err == nil)
+
+	if ps, ok := r.redisCli.(poolStater); ok {
+		stats := ps.PoolStats()
+		setPoolStat(r.backend, "hits", float64(stats.Hits))
+		setPoolStat(r.backend, "misses", float64(stats.Misses))
+		setPoolStat(r.backend, "timeouts", float64(stats.Timeouts))
+		setPoolStat(r.backend, "total_conns", float64(stats.TotalConns))
+		setPoolStat(r.backend, "idle_conns", float64(stats.IdleConns))
+		setPoolStat(r.backend, "stale_conns", float64(stats.StaleConns))
+	}
+
+	return err
+}
+
+func (r *GoRedis) run() {
+	defer utils.CaptureException()
+
+	ticker := time.NewTicker(HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			err := r.healthCheck()
+			utils.ErrPrint(err, "goredis health check failed", r.backend)
+		}
+	}
+}
+
+func (r *GoRedis) SaveObject(prefix string, k any, x any) (err error) {
+	defer func() { observe(r.backend, "save_object", err) }()
+
 	key := fmt.Sprintf("%s:%v", prefix, k)
 	data, err := json.Marshal(x)
 	if !utils.ErrCheck(err, "json marshal failed when goredis SaveObject", key, x) {
@@ -42,33 +137,53 @@ func (r *GoRedis) SaveObject(prefix string, k any, x any) error {
 	return err
 }
 
-func (r *GoRedis) SaveHashObject(prefix string, k any, field any, x any) error {
+func (r *GoRedis) SaveHashObject(prefix string, k any, field any, x any) (err error) {
+	defer func() { observe(r.backend, "save_hash_object", err) }()
+
 	key := fmt.Sprintf("%s:%v", prefix, k)
-	data, err := json.Marshal(x)
-	if !utils.ErrCheck(err, "json marshal failed when goredis SaveObject", key, x) {
+	data, err := marshalHashValue(x)
+	if !utils.ErrCheck(err, "json marshal failed when goredis SaveHashObject", key, x) {
 		return err
 	}
 
 	f := fmt.Sprintf("%v", field)
 	_, err = r.redisCli.HSet(key, f, data).Result()
-	utils.ErrPrint(err, "goredis hset failed", key)
+	if !utils.ErrCheck(err, "goredis hset failed", key) {
+		return err
+	}
 
+	// update expire
+	_, err = r.redisCli.Expire(key, ExpireTime).Result()
 	return err
 }
 
-func (r *GoRedis) SaveHashAll(prefix string, k any, fields map[string]any) error {
+func (r *GoRedis) SaveHashAll(prefix string, k any, fields map[string]any) (err error) {
+	defer func() { observe(r.backend, "save_hash_all", err) }()
+
 	key := fmt.Sprintf("%s:%v", prefix, k)
+	values := make(map[string]any, len(fields))
+	for f, v := range fields {
+		data, err := marshalHashValue(v)
+		if !utils.ErrCheck(err, "json marshal failed when goredis SaveHashAll", key, f) {
+			return err
+		}
 
-	_, err := r.redisCli.HMSet(key, fields).Result()
-	utils.ErrPrint(err, "goredis hmset failed", key, fields)
+		values[f] = data
+	}
 
-	// update expire
-	r.redisCli.Expire(key, ExpireTime)
+	_, err = r.redisCli.HMSet(key, values).Result()
+	if !utils.ErrCheck(err, "goredis hmset failed", key, fields) {
+		return err
+	}
 
+	// update expire
+	_, err = r.redisCli.Expire(key, ExpireTime).Result()
 	return err
 }
 
-func (r *GoRedis) LoadObject(prefix string, k any, x any) error {
+func (r *GoRedis) LoadObject(prefix string, k any, x any) (err error) {
+	defer func() { observeLoad(r.backend, "load_object", err) }()
+
 	key := fmt.Sprintf("%s:%v", prefix, k)
 
 	data, err := r.redisCli.Get(key).Bytes()
@@ -92,12 +207,14 @@ func (r *GoRedis) LoadObject(prefix string, k any, x any) error {
 	return err
 }
 
-func (r *GoRedis) LoadHashAll(prefix string, keyValue any) (any, error) {
+func (r *GoRedis) LoadHashAll(prefix string, keyValue any) (_ any, err error) {
+	defer func() { observeLoad(r.backend, "load_hash_all", err) }()
+
 	key := fmt.Sprintf("%s:%v", prefix, keyValue)
 
 	m, err := r.redisCli.HGetAll(key).Result()
 	if err != nil && !errors.Is(err, redis.Nil) {
-		return nil, ErrNoResult
+		return nil, err
 	}
 
 	if len(m) == 0 {
@@ -114,23 +231,39 @@ func (r *GoRedis) LoadHashAll(prefix string, keyValue any) (any, error) {
 	return result, err
 }
 
-func (r *GoRedis) DeleteObject(prefix string, k any) error {
+func (r *GoRedis) DeleteObject(prefix string, k any) (err error) {
+	defer func() { observe(r.backend, "delete_object", err) }()
+
 	key := fmt.Sprintf("%s:%v", prefix, k)
-	_, err := r.redisCli.Del(key).Result()
+	_, err = r.redisCli.Del(key).Result()
 	utils.ErrPrint(err, "redis delete object failed", k)
 
 	return err
 }
 
-func (r *GoRedis) DeleteHashObject(prefix string, k any, field any) error {
+func (r *GoRedis) DeleteHashObject(prefix string, k any, field any) (err error) {
+	defer func() { observe(r.backend, "delete_hash_object", err) }()
+
 	key := fmt.Sprintf("%s:%v", prefix, k)
 	f := fmt.Sprintf("%v", field)
-	_, err := r.redisCli.HDel(key, f).Result()
-	utils.ErrPrint(err, "redis delete object failed", k)
+	_, err = r.redisCli.HDel(key, f).Result()
+	utils.ErrPrint(err, "redis delete hash object failed", k, f)
 
 	return err
 }
 
 func (r *GoRedis) Exit() error {
+	close(r.done)
+	r.Wait()
+	setUp(r.backend, false)
 	return r.redisCli.Close()
 }
+
+// hash field value is saved in json, except raw bytes
+func marshalHashValue(v any) ([]byte, error) {
+	if data, ok := v.([]byte); ok {
+		return data, nil
+	}
+
+	return json.Marshal(v)
+}
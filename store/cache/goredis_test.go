@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"errors"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/urfave/cli/v2"
+)
+
+type testObject struct {
+	Id      int64  `json:"_id"`
+	OwnerId int64  `json:"owner_id"`
+	Name    string `json:"name"`
+	Level   int32  `json:"level"`
+}
+
+func newTestContext(backend string) *cli.Context {
+	set := flag.NewFlagSet("cache_test", flag.ContinueOnError)
+	set.String("cache_backend", backend, "cache backend")
+	set.String("redis_addr", "", "redis address")
+	return cli.NewContext(nil, set, nil)
+}
+
+func TestNewCache(t *testing.T) {
+	cases := map[string]func(Cache) bool{
+		"":             func(c Cache) bool { _, ok := c.(*DummyRedis); return ok },
+		Backend_Dummy:  func(c Cache) bool { _, ok := c.(*DummyRedis); return ok },
+		"unknown":      func(c Cache) bool { _, ok := c.(*DummyRedis); return ok },
+		"MiniRedis":    func(c Cache) bool { _, ok := c.(*MiniRedis); return ok },
+		Backend_Redis:  func(c Cache) bool { _, ok := c.(*GoRedis); return ok },
+		Backend_Redigo: func(c Cache) bool { _, ok := c.(*Redigo); return ok },
+	}
+
+	for backend, check := range cases {
+		c := NewCache(newTestContext(backend))
+		if !check(c) {
+			t.Errorf("NewCache backend<%s> returns unexpected type %T", backend, c)
+		}
+		_ = c.Exit()
+	}
+}
+
+func TestMiniRedisObject(t *testing.T) {
+	r := NewMiniRedis(&Options{})
+	defer r.Exit()
+
+	obj := &testObject{Id: 1001, OwnerId: 1, Name: "hero", Level: 10}
+	if err := r.SaveObject("test_obj", obj.Id, obj); err != nil {
+		t.Fatalf("SaveObject failed: %v", err)
+	}
+
+	var loaded testObject
+	if err := r.LoadObject("test_obj", obj.Id, &loaded); err != nil {
+		t.Fatalf("LoadObject failed: %v", err)
+	}
+
+	if diff := cmp.Diff(*obj, loaded); diff != "" {
+		t.Fatalf("LoadObject mismatch: %s", diff)
+	}
+
+	if ttl := r.Server().TTL("test_obj:1001"); ttl != ExpireTime {
+		t.Fatalf("object ttl should be %v, got %v", ExpireTime, ttl)
+	}
+
+	if err := r.DeleteObject("test_obj", obj.Id); err != nil {
+		t.Fatalf("DeleteObject failed: %v", err)
+	}
+
+	if err := r.LoadObject("test_obj", obj.Id, &loaded); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("LoadObject after delete should return ErrObjectNotFound, got %v", err)
+	}
+}
+
+func TestMiniRedisExpire(t *testing.T) {
+	r := NewMiniRedis(&Options{})
+	defer r.Exit()
+
+	obj := &testObject{Id: 1002}
+	if err := r.SaveObject("test_obj", obj.Id, obj); err != nil {
+		t.Fatalf("SaveObject failed: %v", err)
+	}
+
+	r.Server().FastForward(ExpireTime + time.Second)
+
+	var loaded testObject
+	if err := r.LoadObject("test_obj", obj.Id, &loaded); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("LoadObject after expired should return ErrObjectNotFound, got %v", err)
+	}
+}
+
+func TestMiniRedisHash(t *testing.T) {
+	r := NewMiniRedis(&Options{})
+	defer r.Exit()
+
+	if _, err := r.LoadHashAll("test_hash", 1); !errors.Is(err, ErrNoResult) {
+		t.Fatalf("LoadHashAll on empty key should return ErrNoResult, got %v", err)
+	}
+
+	obj := &testObject{Id: 2001, OwnerId: 1, Level: 3}
+	if err := r.SaveHashObject("test_hash", obj.OwnerId, obj.Id, obj); err != nil {
+		t.Fatalf("SaveHashObject failed: %v", err)
+	}
+
+	err := r.SaveHashAll("test_hash", obj.OwnerId, map[string]any{
+		"2002": &testObject{Id: 2002, OwnerId: 1},
+		"raw":  []byte("raw"),
+	})
+	if err != nil {
+		t.Fatalf("SaveHashAll failed: %v", err)
+	}
+
+	if ttl := r.Server().TTL("test_hash:1"); ttl != ExpireTime {
+		t.Fatalf("hash ttl should be %v, got %v", ExpireTime, ttl)
+	}
+
+	if err := r.DeleteHashObject("test_hash", obj.OwnerId, "2002"); err != nil {
+		t.Fatalf("DeleteHashObject failed: %v", err)
+	}
+
+	res, err := r.LoadHashAll("test_hash", obj.OwnerId)
+	if err != nil {
+		t.Fatalf("LoadHashAll failed: %v", err)
+	}
+
+	want := map[string]any{
+		"2001": []byte(`{"_id":2001,"owner_id":1,"name":"","level":3}`),
+		"raw":  []byte("raw"),
+	}
+	if diff := cmp.Diff(want, res); diff != "" {
+		t.Fatalf("LoadHashAll mismatch: %s", diff)
+	}
+}
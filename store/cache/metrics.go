@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	HealthCheckInterval = 10 * time.Second // 缓存健康检查和连接池状态上报间隔
+
+	requestCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "cache",
+			Name:      "requests_total",
+			Help:      "缓存请求数量, result为hit, miss, ok或error",
+		},
+		[]string{"backend", "op", "result"},
+	)
+
+	upGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "cache",
+			Name:      "up",
+			Help:      "缓存是否可用, 1可用0不可用",
+		},
+		[]string{"backend"},
+	)
+
+	poolGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "cache",
+			Name:      "pool_stats",
+			Help:      "缓存连接池状态",
+		},
+		[]string{"backend", "stat"},
+	)
+)
+
+// observe counts request result by error
+func observe(backend, op string, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+
+	requestCounter.WithLabelValues(backend, op, result).Inc()
+}
+
+// observeLoad counts load result, not found is treated as miss
+func observeLoad(backend, op string, err error) {
+	result := "hit"
+	switch err {
+	case nil:
+	case ErrObjectNotFound, ErrNoResult:
+		result = "miss"
+	default:
+		result = "error"
+	}
+
+	requestCounter.WithLabelValues(backend, op, result).Inc()
+}
+
+func setUp(backend string, up bool) {
+	v := 0.0
+	if up {
+		v = 1.0
+	}
+
+	upGauge.WithLabelValues(backend).Set(v)
+}
+
+func setPoolStat(backend, stat string, v float64) {
+	poolGauge.WithLabelValues(backend, stat).Set(v)
+}
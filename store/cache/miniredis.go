@@ -1,139 +1,48 @@
 package cache
 
 import (
-	"bytes"
-	"errors"
-	"fmt"
-	"log"
-	"os"
-
 	"github.com/alicebob/miniredis"
-	"github.com/east-eden/server/utils"
-	"github.com/go-redis/redis"
-	json "github.com/json-iterator/go"
-	"github.com/nitishm/go-rejson"
-	"github.com/nitishm/go-rejson/rjs"
-	"github.com/urfave/cli/v2"
+	log "github.com/rs/zerolog/log"
 )
 
+// MiniRedis starts an embedded miniredis server and accesses it by go-redis, used in tests
 type MiniRedis struct {
+	*GoRedis
 	redisServer *miniredis.Miniredis
-	redisCli    *redis.Client
-	handler     *rejson.Handler
-	utils.WaitGroupWrapper
 }
 
-func NewMiniRedis(ctx *cli.Context) *MiniRedis {
-	redisAddr, ok := os.LookupEnv("REDIS_ADDR")
-	if !ok {
-		redisAddr = ctx.String("redis_addr")
-	}
-
+func NewMiniRedis(opts *Options) *MiniRedis {
 	r := &MiniRedis{
-		redisCli: redis.NewClient(&redis.Options{Addr: redisAddr}),
-		handler:  rejson.NewReJSONHandler(),
-	}
-
-	r.handler.SetGoRedisClient(r.redisCli)
-
-	r.redisServer = miniredis.NewMiniRedis()
-	if err := r.redisServer.StartAddr(redisAddr); err != nil {
-		log.Fatal(err)
-	}
-
-	return r
-}
-
-func (r *MiniRedis) SaveObject(prefix string, k any, x any) error {
-	key := fmt.Sprintf("%s:%v", prefix, k)
-	if _, err := r.handler.JSONSet(key, ".", x); err != nil {
-		return fmt.Errorf("Redis.SaveObject failed: %w", err)
-	}
-
-	// update expire
-	r.redisCli.Expire(key, ExpireTime)
-
-	return nil
-}
-
-func (r *MiniRedis) SaveFields(prefix string, k any, fields map[string]any) error {
-	key := fmt.Sprintf("%s:%v", prefix, k)
-	for path, val := range fields {
-		if _, err := r.handler.JSONSet(key, "."+path, val); err != nil {
-			return fmt.Errorf("Redis.SaveFields path<%s> failed: %w", path, err)
-		}
-	}
-
-	// update expire
-	r.redisCli.Expire(key, ExpireTime)
-
-	return nil
-}
-
-func (r *MiniRedis) LoadObject(prefix string, k any, x any) error {
-	key := fmt.Sprintf("%s:%v", prefix, k)
-
-	res, err := r.handler.JSONGet(key, ".", rjs.GETOptionNOESCAPE)
-	if errors.Is(err, redis.Nil) {
-		return ErrObjectNotFound
+		redisServer: miniredis.NewMiniRedis(),
 	}
 
-	if err != nil {
-		return err
-	}
-
-	// empty result
-	if res == nil {
-		return ErrObjectNotFound
+	// 未指定地址时随机监听本地端口
+	var err error
+	if addr := opts.Addr(); addr != "" {
+		err = r.redisServer.StartAddr(addr)
+	} else {
+		err = r.redisServer.Start()
 	}
 
-	decoder := json.NewDecoder(bytes.NewBuffer(res.([]byte)))
-	decoder.UseNumber()
-	err = decoder.Decode(x)
-	// err = json.Unmarshal(res.([]byte), x)
 	if err != nil {
-		return err
+		log.Fatal().Err(err).Str("addr", opts.Addr()).Msg("start miniredis failed")
 	}
 
-	// update expire
-	r.redisCli.Expire(key, ExpireTime)
+	clientOpts := *opts
+	clientOpts.Backend = Backend_Redis
+	clientOpts.Addrs = []string{r.redisServer.Addr()}
+	r.GoRedis = newGoRedis(Backend_MiniRedis, &clientOpts)
 
-	return nil
-}
-
-func (r *MiniRedis) DeleteObject(prefix string, k any) error {
-	key := fmt.Sprintf("%s:%v", prefix, k)
-	_, err := r.handler.JSONDel(key, ".")
-	utils.ErrPrint(err, "redis delete object failed", k)
-
-	// delete object index
-	// if x.GetStoreIndex() == -1 {
-	// 	return nil
-	// }
-
-	// zremKey := fmt.Sprintf("%s_index:%v", prefix, x.GetStoreIndex())
-	// err := r.redisCli.ZRem(zremKey, key).Err()
-	// if err != nil {
-	// 	return fmt.Errorf("GoRedis.DeleteObject index failed: %w", err)
-	// }
-
-	return err
+	return r
 }
 
-func (r *MiniRedis) DeleteFields(prefix string, k any, fieldsName []string) error {
-	key := fmt.Sprintf("%s:%v", prefix, k)
-	for _, path := range fieldsName {
-		if _, err := r.handler.JSONDel(key, "."+path); err != nil {
-			return fmt.Errorf("Redis.SaveFields path<%s> failed: %w", path, err)
-		}
-	}
-
-	return nil
+// Server returns the embedded miniredis server
+func (r *MiniRedis) Server() *miniredis.Miniredis {
+	return r.redisServer
 }
 
 func (r *MiniRedis) Exit() error {
-	r.Wait()
-	err := r.redisCli.Close()
+	err := r.GoRedis.Exit()
 	r.redisServer.Close()
 	return err
 }
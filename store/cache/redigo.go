@@ -2,7 +2,6 @@ package cache
 
 import (
 	"fmt"
-	"os"
 	"sync"
 	"time"
 
@@ -12,7 +11,6 @@ import (
 	"github.com/nitishm/go-rejson"
 	"github.com/nitishm/go-rejson/rjs"
 	log "github.com/rs/zerolog/log"
-	"github.com/urfave/cli/v2"
 )
 
 var (
@@ -22,36 +20,53 @@ var (
 )
 
 type Redigo struct {
-	addr string
-	pool *redis.Pool
+	addr     string
+	password string
+	db       int
+	pool     *redis.Pool
+	done     chan struct{}
 	utils.WaitGroupWrapper
 	mapRejsonHandler map[redis.Conn]*rejson.Handler
 	sync.RWMutex
 }
 
-func NewRedigo(ctx *cli.Context) *Redigo {
-	redisAddr, ok := os.LookupEnv("REDIS_ADDR")
-	if !ok {
-		redisAddr = ctx.String("redis_addr")
+func NewRedigo(opts *Options) *Redigo {
+	maxActive := opts.PoolSize
+	if maxActive <= 0 {
+		maxActive = 5000
+	}
+
+	idleTimeout := opts.IdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = time.Second * 300
 	}
 
 	r := &Redigo{
-		addr: redisAddr,
+		addr:     opts.Addr(),
+		password: opts.Password,
+		db:       opts.DB,
 		pool: &redis.Pool{
 			Wait:        true,
 			MaxIdle:     500,
-			MaxActive:   5000,
-			IdleTimeout: time.Second * 300,
+			MaxActive:   maxActive,
+			IdleTimeout: idleTimeout,
 		},
 		mapRejsonHandler: make(map[redis.Conn]*rejson.Handler),
+		done:             make(chan struct{}),
+	}
+
+	if opts.MinIdleConns > r.pool.MaxIdle {
+		r.pool.MaxIdle = opts.MinIdleConns
 	}
 
 	r.pool.Dial = func() (redis.Conn, error) {
-		c, err := redis.DialTimeout("tcp", r.addr, RedisConnectTimeout, RedisReadTimeout, RedisWriteTimeout)
-		if err != nil {
-			panic(err.Error())
-		}
-		return c, err
+		return redis.Dial("tcp", r.addr,
+			redis.DialConnectTimeout(RedisConnectTimeout),
+			redis.DialReadTimeout(RedisReadTimeout),
+			redis.DialWriteTimeout(RedisWriteTimeout),
+			redis.DialPassword(r.password),
+			redis.DialDatabase(r.db),
+		)
 	}
 
 	r.pool.TestOnBorrow = func(c redis.Conn, t time.Time) error {
@@ -63,9 +78,45 @@ func NewRedigo(ctx *cli.Context) *Redigo {
 		return err
 	}
 
+	if err := r.healthCheck(); err != nil {
+		log.Warn().Err(err).Str("addr", r.addr).Msg("redigo ping failed")
+	}
+
+	r.Wrap(r.run)
 	return r
 }
 
+func (r *Redigo) healthCheck() error {
+	con := r.pool.Get()
+	defer con.Close()
+
+	_, err := con.Do("PING")
+	setUp(Backend_Redigo, err == nil)
+
+	stats := r.pool.Stats()
+	setPoolStat(Backend_Redigo, "active_conns", float64(stats.ActiveCount))
+	setPoolStat(Backend_Redigo, "idle_conns", float64(stats.IdleCount))
+
+	return err
+}
+
+func (r *Redigo) run() {
+	defer utils.CaptureException()
+
+	ticker := time.NewTicker(HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			err := r.healthCheck()
+			utils.ErrPrint(err, "redigo health check failed")
+		}
+	}
+}
+
 // get rejson's handler by redigo.Conn, if not existing, create one.
 func (r *Redigo) getRejsonHandler() (redis.Conn, *rejson.Handler) {
 	r.Lock()
@@ -283,7 +334,95 @@ func (r *Redigo) DeleteFields(prefix string, k any, fieldsName []string) error {
 	return nil
 }
 
+func (r *Redigo) SaveHashObject(prefix string, k any, field any, x any) (err error) {
+	defer func() { observe(Backend_Redigo, "save_hash_object", err) }()
+
+	con := r.pool.Get()
+	defer con.Close()
+
+	key := fmt.Sprintf("%s:%v", prefix, k)
+	data, err := marshalHashValue(x)
+	if err != nil {
+		return fmt.Errorf("Redigo.SaveHashObject failed: %w", err)
+	}
+
+	if _, err := con.Do("HSET", key, fmt.Sprintf("%v", field), data); err != nil {
+		return fmt.Errorf("Redigo.SaveHashObject failed: %w", err)
+	}
+
+	// update expire
+	_, err = con.Do("EXPIRE", key, ExpireTime/time.Second)
+	return err
+}
+
+func (r *Redigo) SaveHashAll(prefix string, k any, fields map[string]any) (err error) {
+	defer func() { observe(Backend_Redigo, "save_hash_all", err) }()
+
+	con := r.pool.Get()
+	defer con.Close()
+
+	key := fmt.Sprintf("%s:%v", prefix, k)
+	args := redis.Args{}.Add(key)
+	for f, v := range fields {
+		data, err := marshalHashValue(v)
+		if err != nil {
+			return fmt.Errorf("Redigo.SaveHashAll field<%s> failed: %w", f, err)
+		}
+
+		args = args.Add(f, data)
+	}
+
+	if _, err := con.Do("HMSET", args...); err != nil {
+		return fmt.Errorf("Redigo.SaveHashAll failed: %w", err)
+	}
+
+	// update expire
+	_, err = con.Do("EXPIRE", key, ExpireTime/time.Second)
+	return err
+}
+
+func (r *Redigo) LoadHashAll(prefix string, keyValue any) (_ any, err error) {
+	defer func() { observeLoad(Backend_Redigo, "load_hash_all", err) }()
+
+	con := r.pool.Get()
+	defer con.Close()
+
+	key := fmt.Sprintf("%s:%v", prefix, keyValue)
+	values, err := redis.ByteSlices(con.Do("HGETALL", key))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) == 0 {
+		return nil, ErrNoResult
+	}
+
+	result := make(map[string]any, len(values)/2)
+	for n := 0; n+1 < len(values); n += 2 {
+		result[string(values[n])] = values[n+1]
+	}
+
+	// update expire
+	_, err = con.Do("EXPIRE", key, ExpireTime/time.Second)
+	return result, err
+}
+
+func (r *Redigo) DeleteHashObject(prefix string, k any, field any) (err error) {
+	defer func() { observe(Backend_Redigo, "delete_hash_object", err) }()
+
+	con := r.pool.Get()
+	defer con.Close()
+
+	key := fmt.Sprintf("%s:%v", prefix, k)
+	_, err = con.Do("HDEL", key, fmt.Sprintf("%v", field))
+	utils.ErrPrint(err, "redigo delete hash object failed", k, field)
+
+	return err
+}
+
 func (r *Redigo) Exit() error {
+	close(r.done)
 	r.Wait()
+	setUp(Backend_Redigo, false)
 	return r.pool.Close()
 }
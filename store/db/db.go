@@ -3,9 +3,11 @@ package db
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/urfave/cli/v2"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -27,10 +29,18 @@ type DB interface {
 	UpdateOne(ctx context.Context, colName string, filter any, update any, opts ...*options.UpdateOptions) error
 	DeleteOne(ctx context.Context, colName string, filter any) error
 	BulkWrite(ctx context.Context, colName string, model any) error
+	BulkWriteModels(ctx context.Context, colName string, models []mongo.WriteModel) error
 	Flush()
 	Exit()
 }
 
 func NewDB(ctx *cli.Context) DB {
-	return NewMongoDB(ctx)
+	switch strings.ToLower(ctx.String("db_driver")) {
+	case Driver_MySQL, Driver_SQLite:
+		return NewSqlDB(ctx)
+	case Driver_Memory:
+		return NewMemDB()
+	default:
+		return NewMongoDB(ctx)
+	}
 }
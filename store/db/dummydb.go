@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -48,6 +49,10 @@ func (m *DummyDB) BulkWrite(ctx context.Context, colName string, model any) erro
 	return nil
 }
 
+func (m *DummyDB) BulkWriteModels(ctx context.Context, colName string, models []mongo.WriteModel) error {
+	return nil
+}
+
 func (m *DummyDB) Flush() {
 
 }
@@ -0,0 +1,354 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 内存数据库, 用于不依赖mongodb的单元测试:
+// 文档以bson保存, 过滤条件和更新操作与SqlDB相同, 只支持等值匹配和$set, $unset, $push, $pull.
+// BulkWrite同步写入, 测试中写入后可以立即读取
+
+const Driver_Memory = "memory"
+
+type memTable struct {
+	indexes []string
+	docs    map[string]bson.Raw // _id -> document
+}
+
+func (t *memTable) sortedIds() []string {
+	ids := make([]string, 0, len(t.docs))
+	for id := range t.docs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+type MemDB struct {
+	tables map[string]*memTable
+	sync.RWMutex
+}
+
+func NewMemDB() DB {
+	return &MemDB{
+		tables: make(map[string]*memTable),
+	}
+}
+
+// getTable returns table of collection, create it if not exist. it should be called with lock
+func (m *MemDB) getTable(name string) *memTable {
+	t, ok := m.tables[name]
+	if !ok {
+		t = &memTable{docs: make(map[string]bson.Raw)}
+		m.tables[name] = t
+	}
+	return t
+}
+
+// migrate collection
+func (m *MemDB) MigrateTable(name string, indexNames ...string) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if _, ok := m.tables[name]; ok {
+		return fmt.Errorf("duplicate collection %s", name)
+	}
+
+	m.getTable(name).indexes = indexNames
+	return nil
+}
+
+// Indexes returns index names of collection
+func (m *MemDB) Indexes(name string) []string {
+	m.RLock()
+	defer m.RUnlock()
+
+	if t, ok := m.tables[name]; ok {
+		return append([]string(nil), t.indexes...)
+	}
+	return nil
+}
+
+func matchConds(doc bson.M, conds []sqlCond) bool {
+	for _, c := range conds {
+		v, ok := getValue(doc, strings.Split(c.path, "."))
+		if !ok {
+			if c.value != nil {
+				return false
+			}
+			continue
+		}
+
+		if !valueEqual(v, c.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// find returns ids and documents matched filter in _id order, at most limit documents when limit > 0
+func (m *MemDB) find(t *memTable, filter any, limit int) ([]string, []bson.M, error) {
+	conds, err := parseFilter(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 按_id查询
+	candidates := t.sortedIds()
+	for _, c := range conds {
+		if c.path == "_id" {
+			id, err := idText(c.value)
+			if err != nil {
+				return nil, nil, err
+			}
+			candidates = []string{id}
+			break
+		}
+	}
+
+	var ids []string
+	var docs []bson.M
+	for _, id := range candidates {
+		raw, ok := t.docs[id]
+		if !ok {
+			continue
+		}
+
+		var doc bson.M
+		if err := bson.Unmarshal(raw, &doc); err != nil {
+			return nil, nil, err
+		}
+
+		if !matchConds(doc, conds) {
+			continue
+		}
+
+		ids = append(ids, id)
+		docs = append(docs, doc)
+		if limit > 0 && len(docs) >= limit {
+			break
+		}
+	}
+
+	return ids, docs, nil
+}
+
+func (m *MemDB) FindOne(ctx context.Context, colName string, filter any, result any) error {
+	m.Lock()
+	defer m.Unlock()
+
+	t := m.getTable(colName)
+	ids, _, err := m.find(t, filter, 1)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		return ErrNoResult
+	}
+
+	return bson.Unmarshal(t.docs[ids[0]], result)
+}
+
+func (m *MemDB) Find(ctx context.Context, colName string, filter any) (map[string]any, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	ids, docs, err := m.find(m.getTable(colName), filter, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any, len(docs))
+	for n, v := range docs {
+		data, err := json.Marshal(map[string]any(v))
+		if err != nil {
+			return nil, err
+		}
+
+		result[ids[n]] = data
+	}
+
+	return result, nil
+}
+
+func (m *MemDB) insert(t *memTable, insert any) error {
+	id, d, err := insertDocument(insert)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := t.docs[id]; ok {
+		return fmt.Errorf("insert _id %s: %w", id, ErrSqlDuplicateKey)
+	}
+
+	raw, err := bson.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	t.docs[id] = raw
+	return nil
+}
+
+func (m *MemDB) InsertOne(ctx context.Context, colName string, insert any) error {
+	return m.InsertMany(ctx, colName, []any{insert})
+}
+
+func (m *MemDB) InsertMany(ctx context.Context, colName string, inserts []any) error {
+	m.Lock()
+	defer m.Unlock()
+
+	t := m.getTable(colName)
+	for _, insert := range inserts {
+		if err := m.insert(t, insert); err != nil {
+			return fmt.Errorf("MemDB.InsertMany failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (m *MemDB) update(t *memTable, filter any, update any, upsert bool) error {
+	ids, docs, err := m.find(t, filter, 1)
+	if err != nil {
+		return err
+	}
+
+	var id string
+	var doc bson.M
+	if len(docs) > 0 {
+		id, doc = ids[0], docs[0]
+	} else {
+		if !upsert {
+			return nil
+		}
+
+		conds, err := parseFilter(filter)
+		if err != nil {
+			return err
+		}
+
+		if doc, err = upsertDocument(conds); err != nil {
+			return err
+		}
+
+		if id, err = idText(doc["_id"]); err != nil {
+			return err
+		}
+	}
+
+	if err := applyUpdate(doc, update); err != nil {
+		return err
+	}
+
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	t.docs[id] = raw
+	return nil
+}
+
+func (m *MemDB) UpdateOne(ctx context.Context, colName string, filter any, update any, opts ...*options.UpdateOptions) error {
+	upsert := false
+	for _, opt := range opts {
+		if opt != nil && opt.Upsert != nil {
+			upsert = *opt.Upsert
+		}
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	if err := m.update(m.getTable(colName), filter, update, upsert); err != nil {
+		return fmt.Errorf("MemDB.UpdateOne failed: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MemDB) delete(t *memTable, filter any) error {
+	ids, _, err := m.find(t, filter, 1)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		delete(t.docs, id)
+	}
+	return nil
+}
+
+func (m *MemDB) DeleteOne(ctx context.Context, colName string, filter any) error {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.delete(m.getTable(colName), filter)
+}
+
+func (m *MemDB) BulkWrite(ctx context.Context, colName string, model any) error {
+	wm, ok := model.(mongo.WriteModel)
+	if !ok {
+		return ErrBulkWriteInvalidType
+	}
+
+	return m.BulkWriteModels(ctx, colName, []mongo.WriteModel{wm})
+}
+
+// BulkWriteModels writes models in order, it stops at the first rejected model and returns mongo.BulkWriteException
+func (m *MemDB) BulkWriteModels(ctx context.Context, colName string, models []mongo.WriteModel) error {
+	m.Lock()
+	defer m.Unlock()
+
+	t := m.getTable(colName)
+	for idx, model := range models {
+		var err error
+		switch wm := model.(type) {
+		case *mongo.InsertOneModel:
+			err = m.insert(t, wm.Document)
+		case *mongo.UpdateOneModel:
+			err = m.update(t, wm.Filter, wm.Update, wm.Upsert != nil && *wm.Upsert)
+		case *mongo.DeleteOneModel:
+			err = m.delete(t, wm.Filter)
+		default:
+			err = fmt.Errorf("bulk write model %T: %w", model, ErrSqlUnsupported)
+		}
+
+		if err == nil {
+			continue
+		}
+
+		code := 0
+		if errors.Is(err, ErrSqlDuplicateKey) {
+			code = duplicateKeyCode
+		}
+
+		return mongo.BulkWriteException{
+			WriteErrors: []mongo.BulkWriteError{{
+				WriteError: mongo.WriteError{Index: idx, Code: code, Message: err.Error()},
+				Request:    model,
+			}},
+		}
+	}
+
+	return nil
+}
+
+func (m *MemDB) Flush() {
+
+}
+
+func (m *MemDB) Exit() {
+}
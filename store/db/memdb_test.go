@@ -0,0 +1,248 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type memTestKey struct {
+	ObjId  int64 `bson:"obj_id" json:"obj_id"`
+	RankId int32 `bson:"rank_id" json:"rank_id"`
+}
+
+type memTestMetadata struct {
+	Key   memTestKey `bson:"_id" json:"_id"`
+	Score float64    `bson:"score" json:"score"`
+}
+
+func TestMemDBFindAndUpdate(t *testing.T) {
+	m := NewMemDB()
+	defer m.Exit()
+
+	if err := m.MigrateTable("player", "owner_id"); err != nil {
+		t.Fatalf("MigrateTable failed: %v", err)
+	}
+
+	if err := m.MigrateTable("player"); err == nil {
+		t.Fatal("MigrateTable duplicate collection should fail")
+	}
+
+	ctx := context.Background()
+	players := []any{
+		&sqlTestPlayer{Id: 1, OwnerId: 100, Name: "a", Level: 1},
+		&sqlTestPlayer{Id: 2, OwnerId: 100, Name: "b", Level: 2},
+		&sqlTestPlayer{Id: 3, OwnerId: 200, Name: "c", Level: 3},
+	}
+	if err := m.InsertMany(ctx, "player", players); err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+
+	if err := m.InsertOne(ctx, "player", players[0]); !errors.Is(err, ErrSqlDuplicateKey) {
+		t.Fatalf("InsertOne duplicate _id should return ErrSqlDuplicateKey, got %v", err)
+	}
+
+	var p sqlTestPlayer
+	if err := m.FindOne(ctx, "player", bson.M{"_id": int64(4)}, &p); !errors.Is(err, ErrNoResult) {
+		t.Fatalf("FindOne not exist should return ErrNoResult, got %v", err)
+	}
+
+	// find by index key
+	res, err := m.Find(ctx, "player", bson.D{{Key: "owner_id", Value: int64(100)}})
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+
+	names := make(map[string]string)
+	for k, v := range res {
+		var p sqlTestPlayer
+		if err := json.Unmarshal(v.([]byte), &p); err != nil {
+			t.Fatalf("json.Unmarshal failed: %v", err)
+		}
+		names[k] = p.Name
+	}
+
+	if diff := cmp.Diff(map[string]string{"1": "a", "2": "b"}, names); diff != "" {
+		t.Fatalf("Find by owner_id mismatch: %s", diff)
+	}
+
+	// $set and $unset
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "level", Value: int32(10)}, {Key: "heroes.1", Value: int32(5)}}},
+		{Key: "$unset", Value: bson.D{{Key: "name", Value: ""}}},
+	}
+	if err := m.UpdateOne(ctx, "player", bson.D{{Key: "_id", Value: int64(1)}}, update); err != nil {
+		t.Fatalf("UpdateOne failed: %v", err)
+	}
+
+	want := &sqlTestPlayer{Id: 1, OwnerId: 100, Level: 10, Heroes: map[string]int32{"1": 5}}
+	if err := m.FindOne(ctx, "player", bson.M{"_id": int64(1)}, &p); err != nil {
+		t.Fatalf("FindOne failed: %v", err)
+	}
+	if diff := cmp.Diff(want, &p); diff != "" {
+		t.Fatalf("UpdateOne result mismatch: %s", diff)
+	}
+
+	// upsert creates document from filter
+	set := bson.D{{Key: "$set", Value: bson.D{{Key: "level", Value: int32(4)}}}}
+	if err := m.UpdateOne(ctx, "player", bson.D{{Key: "_id", Value: int64(4)}}, set, options.Update().SetUpsert(false)); err != nil {
+		t.Fatalf("UpdateOne without upsert failed: %v", err)
+	}
+	if err := m.FindOne(ctx, "player", bson.M{"_id": int64(4)}, &p); !errors.Is(err, ErrNoResult) {
+		t.Fatalf("UpdateOne without upsert should not create document, got %v", err)
+	}
+
+	if err := m.UpdateOne(ctx, "player", bson.D{{Key: "_id", Value: int64(4)}}, set, options.Update().SetUpsert(true)); err != nil {
+		t.Fatalf("UpdateOne upsert failed: %v", err)
+	}
+	if err := m.FindOne(ctx, "player", bson.D{{Key: "_id", Value: int64(4)}, {Key: "level", Value: int32(4)}}, &p); err != nil {
+		t.Fatalf("UpdateOne upsert should create document, got %v", err)
+	}
+
+	if err := m.DeleteOne(ctx, "player", bson.D{{Key: "_id", Value: int64(4)}}); err != nil {
+		t.Fatalf("DeleteOne failed: %v", err)
+	}
+	if err := m.FindOne(ctx, "player", bson.M{"_id": int64(4)}, &p); !errors.Is(err, ErrNoResult) {
+		t.Fatalf("deleted document should not be found, got %v", err)
+	}
+}
+
+func TestMemDBDocumentId(t *testing.T) {
+	m := NewMemDB()
+	ctx := context.Background()
+
+	// 复合主键, 和排行榜元数据一样以整个对象upsert
+	for n := int64(1); n <= 3; n++ {
+		md := &memTestMetadata{Key: memTestKey{ObjId: n, RankId: 1}, Score: float64(n * 10)}
+		filter := bson.D{{Key: "_id", Value: md.Key}}
+		update := bson.D{{Key: "$set", Value: md}}
+		if err := m.UpdateOne(ctx, "rank", filter, update, options.Update().SetUpsert(true)); err != nil {
+			t.Fatalf("UpdateOne upsert failed: %v", err)
+		}
+	}
+
+	md := &memTestMetadata{Key: memTestKey{ObjId: 2, RankId: 1}, Score: 99}
+	update := bson.D{{Key: "$set", Value: md}}
+	if err := m.UpdateOne(ctx, "rank", bson.D{{Key: "_id", Value: md.Key}}, update, options.Update().SetUpsert(true)); err != nil {
+		t.Fatalf("UpdateOne existing document failed: %v", err)
+	}
+
+	res, err := m.Find(ctx, "rank", bson.D{{Key: "_id.rank_id", Value: int32(1)}})
+	if err != nil || len(res) != 3 {
+		t.Fatalf("Find by _id.rank_id should return 3 documents, got %d %v", len(res), err)
+	}
+
+	var got memTestMetadata
+	if err := m.FindOne(ctx, "rank", bson.M{"_id": memTestKey{ObjId: 2, RankId: 1}}, &got); err != nil {
+		t.Fatalf("FindOne by document _id failed: %v", err)
+	}
+	if diff := cmp.Diff(*md, got); diff != "" {
+		t.Fatalf("FindOne by document _id mismatch: %s", diff)
+	}
+}
+
+func TestMemDBPushEachSlice(t *testing.T) {
+	type history struct {
+		Id      int64   `bson:"_id" json:"_id"`
+		LastSeq int64   `bson:"last_seq" json:"last_seq"`
+		Seqs    []int64 `bson:"seqs" json:"seqs"`
+	}
+
+	m := NewMemDB()
+	defer m.Exit()
+
+	ctx := context.Background()
+	filter := bson.D{{Key: "_id", Value: int64(1)}}
+	for n := int64(1); n <= 5; n++ {
+		update := bson.D{
+			{Key: "$set", Value: bson.D{{Key: "last_seq", Value: n}}},
+			{Key: "$push", Value: bson.D{{Key: "seqs", Value: bson.D{
+				{Key: "$each", Value: bson.A{n}},
+				{Key: "$slice", Value: int32(-3)},
+			}}}},
+		}
+		if err := m.UpdateOne(ctx, "history", filter, update, options.Update().SetUpsert(true)); err != nil {
+			t.Fatalf("UpdateOne $push $each $slice failed: %v", err)
+		}
+	}
+
+	var got history
+	if err := m.FindOne(ctx, "history", filter, &got); err != nil {
+		t.Fatalf("FindOne failed: %v", err)
+	}
+	if diff := cmp.Diff(history{Id: 1, LastSeq: 5, Seqs: []int64{3, 4, 5}}, got); diff != "" {
+		t.Fatalf("$push $slice should keep last 3 elements: %s", diff)
+	}
+
+	// 正数$slice保留前n个
+	update := bson.D{{Key: "$push", Value: bson.D{{Key: "seqs", Value: bson.D{
+		{Key: "$each", Value: bson.A{int64(6), int64(7)}},
+		{Key: "$slice", Value: int32(2)},
+	}}}}}
+	if err := m.UpdateOne(ctx, "history", filter, update); err != nil {
+		t.Fatalf("UpdateOne $push positive $slice failed: %v", err)
+	}
+	if err := m.FindOne(ctx, "history", filter, &got); err != nil {
+		t.Fatalf("FindOne failed: %v", err)
+	}
+	if diff := cmp.Diff([]int64{3, 4}, got.Seqs); diff != "" {
+		t.Fatalf("$push positive $slice should keep first 2 elements: %s", diff)
+	}
+
+	update = bson.D{{Key: "$push", Value: bson.D{{Key: "seqs", Value: bson.D{
+		{Key: "$each", Value: bson.A{int64(8)}},
+		{Key: "$sort", Value: int32(1)},
+	}}}}}
+	if err := m.UpdateOne(ctx, "history", filter, update); !errors.Is(err, ErrSqlUnsupported) {
+		t.Fatalf("$push with unsupported modifier should return ErrSqlUnsupported, got %v", err)
+	}
+}
+
+func TestMemDBBulkWriteModels(t *testing.T) {
+	m := NewMemDB()
+	ctx := context.Background()
+
+	models := []mongo.WriteModel{
+		mongo.NewInsertOneModel().SetDocument(&sqlTestPlayer{Id: 1, Name: "a"}),
+		mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": int64(2)}).SetUpdate(bson.M{"$set": bson.M{"name": "b"}}).SetUpsert(true),
+		mongo.NewDeleteOneModel().SetFilter(bson.M{"_id": int64(1)}),
+		mongo.NewInsertOneModel().SetDocument(&sqlTestPlayer{Id: 2, Name: "dup"}),
+		mongo.NewInsertOneModel().SetDocument(&sqlTestPlayer{Id: 3, Name: "c"}),
+	}
+
+	err := m.BulkWriteModels(ctx, "player", models)
+	var bwe mongo.BulkWriteException
+	if !errors.As(err, &bwe) || len(bwe.WriteErrors) != 1 {
+		t.Fatalf("BulkWriteModels should return BulkWriteException, got %v", err)
+	}
+
+	if we := bwe.WriteErrors[0]; we.Index != 3 || we.Code != duplicateKeyCode {
+		t.Fatalf("BulkWriteModels should stop at duplicate insert, got index %d code %d", we.Index, we.Code)
+	}
+
+	// BulkWrite is synchronous, documents can be read immediately
+	if err := m.BulkWrite(ctx, "player", mongo.NewInsertOneModel().SetDocument(&sqlTestPlayer{Id: 4, Name: "d"})); err != nil {
+		t.Fatalf("BulkWrite failed: %v", err)
+	}
+
+	res, err := m.Find(ctx, "player", nil)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+
+	ids := make([]string, 0, len(res))
+	for k := range res {
+		ids = append(ids, k)
+	}
+	sort.Strings(ids)
+	if diff := cmp.Diff([]string{"2", "4"}, ids); diff != "" {
+		t.Fatalf("documents after bulk write mismatch: %s", diff)
+	}
+}
@@ -340,6 +340,21 @@ func (m *MongoDB) BulkWrite(ctx context.Context, colName string, model any) erro
 	return coll.Write(wm)
 }
 
+// BulkWriteModels writes models in order synchronously, it stops at the first failed model
+func (m *MongoDB) BulkWriteModels(ctx context.Context, colName string, models []mongo.WriteModel) error {
+	coll := m.GetCollection(colName)
+	if coll == nil {
+		return ErrCollectionNotFound
+	}
+
+	// timeout control
+	subCtx, cancel := utils.WithTimeoutContext(ctx, DatabaseBulkWriteTimeout)
+	defer cancel()
+
+	_, err := coll.Collection.BulkWrite(subCtx, models, options.BulkWrite().SetOrdered(true))
+	return err
+}
+
 func (m *MongoDB) Flush() {
 	m.Lock()
 	defer m.Unlock()
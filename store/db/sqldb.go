@@ -0,0 +1,778 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/east-eden/server/utils"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/hellodudu/channelwriter"
+	_ "github.com/mattn/go-sqlite3"
+	log "github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 关系数据库实现:
+// 每个collection对应一张表(id, doc), doc以json保存整个文档,
+// MigrateTable传入的索引字段生成虚拟列c_<index>并建立索引.
+// 过滤条件只支持等值匹配, 更新操作在事务中读出文档修改后写回
+
+const (
+	Driver_MongoDB = "mongodb"
+	Driver_MySQL   = "mysql"
+	Driver_SQLite  = "sqlite3"
+
+	duplicateKeyCode = 11000 // 与mongodb的duplicate key错误码一致
+)
+
+var (
+	ErrSqlDuplicateKey = errors.New("sql db duplicate key")
+
+	invalidColumnChar = regexp.MustCompile(`[^A-Za-z0-9_]`)
+)
+
+type sqlDialect struct {
+	docType    string // 文档列类型
+	idType     string // 主键及索引列类型
+	generated  string // 生成列类型
+	lockSuffix string // 事务中锁定读取的行
+	upsert     string // 按主键插入或覆盖文档
+	extract    func(path string) string
+	boolText   func(b bool) string
+}
+
+var dialects = map[string]*sqlDialect{
+	Driver_MySQL: {
+		docType:    "JSON",
+		idType:     "VARCHAR(191)",
+		generated:  "STORED",
+		lockSuffix: " FOR UPDATE",
+		upsert:     "INSERT INTO `%s` (id, doc) VALUES (?, ?) ON DUPLICATE KEY UPDATE doc = VALUES(doc)",
+		extract: func(path string) string {
+			return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(doc, '%s'))", jsonPath(path))
+		},
+		boolText: strconv.FormatBool,
+	},
+
+	Driver_SQLite: {
+		docType:    "TEXT",
+		idType:     "TEXT",
+		generated:  "VIRTUAL",
+		lockSuffix: "",
+		upsert:     "INSERT INTO `%s` (id, doc) VALUES (?, ?) ON CONFLICT(id) DO UPDATE SET doc = excluded.doc",
+		extract: func(path string) string {
+			return fmt.Sprintf("CAST(json_extract(doc, '%s') AS TEXT)", jsonPath(path))
+		},
+		boolText: func(b bool) string {
+			if b {
+				return "1"
+			}
+			return "0"
+		},
+	},
+}
+
+// jsonPath converts document path a.b.c to json path $."a"."b"."c"
+func jsonPath(path string) string {
+	var b strings.Builder
+	b.WriteString("$")
+	for _, p := range strings.Split(path, ".") {
+		b.WriteString(`."`)
+		b.WriteString(p)
+		b.WriteString(`"`)
+	}
+	return b.String()
+}
+
+func indexColumn(indexName string) string {
+	return "c_" + invalidColumnChar.ReplaceAllString(indexName, "_")
+}
+
+type SqlTable struct {
+	name    string
+	indexes map[string]string // index name -> generated column
+	db      *SqlDB
+	*channelwriter.ChannelWriter
+}
+
+func newSqlTable(db *SqlDB, name string) *SqlTable {
+	t := &SqlTable{
+		name:    name,
+		indexes: make(map[string]string),
+		db:      db,
+	}
+
+	t.ChannelWriter = channelwriter.NewChannelWriter(
+		channelwriter.WithLogger(log.Logger),
+		channelwriter.WithFlushHandler(t.flush),
+	)
+
+	return t
+}
+
+func (t *SqlTable) Write(p any) error {
+	model, ok := p.(mongo.WriteModel)
+	if !ok {
+		return ErrBulkWriteInvalidType
+	}
+
+	t.ChannelWriter.Write(model)
+	return nil
+}
+
+func (t *SqlTable) flush(datas []any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DatabaseBulkWriteTimeout)
+	defer cancel()
+
+	models := make([]mongo.WriteModel, 0, len(datas))
+	for _, data := range datas {
+		model, ok := data.(mongo.WriteModel)
+		if !ok {
+			return ErrBulkWriteInvalidType
+		}
+
+		models = append(models, model)
+	}
+
+	err := t.db.BulkWriteModels(ctx, t.name, models)
+	_ = utils.ErrCheck(err, "BulkWrite failed when SqlTable.Flush", t.name, len(models))
+	return err
+}
+
+func (t *SqlTable) Exit() {
+	t.Stop()
+}
+
+type SqlDB struct {
+	driver    string
+	dialect   *sqlDialect
+	db        *sql.DB
+	mapTables map[string]*SqlTable
+	sync.RWMutex
+}
+
+func NewSqlDB(ctx *cli.Context) DB {
+	driver := strings.ToLower(ctx.String("db_driver"))
+	dsn, ok := os.LookupEnv("DB_DSN")
+	if !ok {
+		dsn = ctx.String("db_dsn")
+	}
+
+	s, err := OpenSqlDB(driver, dsn)
+	if err != nil {
+		log.Fatal().
+			Str("driver", driver).
+			Str("dsn", dsn).
+			Err(err).
+			Msg("new sql db failed")
+		return nil
+	}
+
+	return s
+}
+
+// OpenSqlDB connects to mysql or sqlite3 database with dsn
+func OpenSqlDB(driver string, dsn string) (*SqlDB, error) {
+	dialect, ok := dialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("invalid sql driver %s", driver)
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("OpenSqlDB failed: %w", err)
+	}
+
+	// sqlite只允许单个写入连接, 事务之间串行执行; 内存数据库每个连接都是独立的库
+	if driver == Driver_SQLite {
+		db.SetMaxOpenConns(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DatabaseLoadTimeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("OpenSqlDB ping failed: %w", err)
+	}
+
+	return &SqlDB{
+		driver:    driver,
+		dialect:   dialect,
+		db:        db,
+		mapTables: make(map[string]*SqlTable),
+	}, nil
+}
+
+func (s *SqlDB) getTable(name string) *SqlTable {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.mapTables[name]
+}
+
+// GetTable returns table of collection, create it if not exist
+func (s *SqlDB) GetTable(name string) (*SqlTable, error) {
+	if t := s.getTable(name); t != nil {
+		return t, nil
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if t, ok := s.mapTables[name]; ok {
+		return t, nil
+	}
+
+	t := newSqlTable(s, name)
+	if err := s.createTable(t); err != nil {
+		t.Exit()
+		return nil, err
+	}
+
+	s.mapTables[name] = t
+	return t, nil
+}
+
+func (s *SqlDB) createTable(t *SqlTable) error {
+	if invalidColumnChar.MatchString(t.name) {
+		return fmt.Errorf("invalid table name %s: %w", t.name, ErrSqlUnsupported)
+	}
+
+	stmt := fmt.Sprintf("CREATE TABLE IF NOT EXISTS `%s` (id %s NOT NULL PRIMARY KEY, doc %s NOT NULL)",
+		t.name, s.dialect.idType, s.dialect.docType)
+	if _, err := s.db.Exec(stmt); err != nil {
+		return fmt.Errorf("create table %s failed: %w", t.name, err)
+	}
+
+	return nil
+}
+
+// addIndex adds generated column and index, existing column or index is ignored
+func (s *SqlDB) addIndex(t *SqlTable, indexName string) error {
+	if err := checkPath(indexName); err != nil {
+		return err
+	}
+
+	col := indexColumn(indexName)
+	stmt := fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN `%s` %s GENERATED ALWAYS AS (%s) %s",
+		t.name, col, s.dialect.idType, s.dialect.extract(indexName), s.dialect.generated)
+	if _, err := s.db.Exec(stmt); err != nil && !isDuplicateSchema(err) {
+		return fmt.Errorf("add column %s.%s failed: %w", t.name, col, err)
+	}
+
+	// mysql的TEXT类型列不能直接建立索引, 这里的列类型都已指定长度
+	stmt = fmt.Sprintf("CREATE INDEX `idx_%s_%s` ON `%s` (`%s`)", t.name, col, t.name, col)
+	if _, err := s.db.Exec(stmt); err != nil && !isDuplicateSchema(err) {
+		return fmt.Errorf("create index %s.%s failed: %w", t.name, col, err)
+	}
+
+	t.indexes[indexName] = col
+	return nil
+}
+
+func isDuplicateSchema(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "duplicate column") ||
+		strings.Contains(msg, "duplicate key name") ||
+		strings.Contains(msg, "already exists")
+}
+
+func isDuplicateKey(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "unique constraint failed") ||
+		strings.Contains(msg, "duplicate entry")
+}
+
+// migrate collection
+func (s *SqlDB) MigrateTable(name string, indexNames ...string) error {
+	if t := s.getTable(name); t != nil {
+		return fmt.Errorf("duplicate collection %s", name)
+	}
+
+	t, err := s.GetTable(name)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	for _, indexName := range indexNames {
+		if err := s.addIndex(t, indexName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// where builds where clause of equality conditions
+func (s *SqlDB) where(t *SqlTable, conds []sqlCond) (string, []any, error) {
+	if len(conds) == 0 {
+		return "", nil, nil
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	clauses := make([]string, 0, len(conds))
+	args := make([]any, 0, len(conds))
+	for _, c := range conds {
+		var expr string
+		if c.path == "_id" {
+			expr = "id"
+		} else if col, ok := t.indexes[c.path]; ok {
+			expr = "`" + col + "`"
+		} else {
+			expr = s.dialect.extract(c.path)
+		}
+
+		if c.value == nil {
+			clauses = append(clauses, expr+" IS NULL")
+			continue
+		}
+
+		text, err := s.condText(c)
+		if err != nil {
+			return "", nil, err
+		}
+
+		clauses = append(clauses, expr+" = ?")
+		args = append(args, text)
+	}
+
+	return " WHERE " + strings.Join(clauses, " AND "), args, nil
+}
+
+func (s *SqlDB) condText(c sqlCond) (string, error) {
+	if c.path == "_id" {
+		return idText(c.value)
+	}
+
+	switch v := c.value.(type) {
+	case bool:
+		return s.dialect.boolText(v), nil
+	case bson.M, bson.A:
+		return "", fmt.Errorf("filter on document or array field %s: %w", c.path, ErrSqlUnsupported)
+	default:
+		return idText(v)
+	}
+}
+
+func marshalDoc(doc any) (string, error) {
+	data, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return "", fmt.Errorf("marshal document failed: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func (s *SqlDB) FindOne(ctx context.Context, colName string, filter any, result any) error {
+	t, err := s.GetTable(colName)
+	if err != nil {
+		return err
+	}
+
+	conds, err := parseFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	where, args, err := s.where(t, conds)
+	if err != nil {
+		return err
+	}
+
+	// timeout control
+	subCtx, cancel := utils.WithTimeoutContext(ctx, DatabaseLoadTimeout)
+	defer cancel()
+
+	var doc string
+	err = s.db.QueryRowContext(subCtx, fmt.Sprintf("SELECT doc FROM `%s`%s LIMIT 1", colName, where), args...).Scan(&doc)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNoResult
+	}
+
+	if err != nil {
+		return fmt.Errorf("SqlDB.FindOne failed: %w", err)
+	}
+
+	err = bson.UnmarshalExtJSON([]byte(doc), false, result)
+	utils.ErrPrint(err, "Decode failed when SqlDB.FindOne", colName, filter)
+	return nil
+}
+
+func (s *SqlDB) Find(ctx context.Context, colName string, filter any) (map[string]any, error) {
+	t, err := s.GetTable(colName)
+	if err != nil {
+		return nil, err
+	}
+
+	conds, err := parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args, err := s.where(t, conds)
+	if err != nil {
+		return nil, err
+	}
+
+	// timeout control
+	subCtx, cancel := utils.WithTimeoutContext(ctx, DatabaseLoadTimeout)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(subCtx, fmt.Sprintf("SELECT doc FROM `%s`%s", colName, where), args...)
+	if err != nil {
+		return nil, fmt.Errorf("SqlDB.Find failed: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]any)
+	for rows.Next() {
+		var doc string
+		if err := rows.Scan(&doc); err != nil {
+			return nil, err
+		}
+
+		var v bson.M
+		if err := bson.UnmarshalExtJSON([]byte(doc), false, &v); err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(map[string]any(v))
+		if err != nil {
+			return nil, err
+		}
+
+		result[fmt.Sprintf("%d", v["_id"])] = data
+	}
+
+	return result, rows.Err()
+}
+
+// insertDocument returns document's primary key and document with _id generated if not exist
+func insertDocument(insert any) (string, bson.D, error) {
+	d, err := toBsonD(insert)
+	if err != nil {
+		return "", nil, fmt.Errorf("marshal insert failed: %w", err)
+	}
+
+	var idValue any
+	for _, e := range d {
+		if e.Key == "_id" {
+			idValue = e.Value
+			break
+		}
+	}
+
+	if idValue == nil {
+		idValue = primitive.NewObjectID()
+		d = append(bson.D{{Key: "_id", Value: idValue}}, d...)
+	}
+
+	id, err := idText(idValue)
+	return id, d, err
+}
+
+func (s *SqlDB) insert(ctx context.Context, tx *sql.Tx, colName string, insert any) error {
+	id, d, err := insertDocument(insert)
+	if err != nil {
+		return err
+	}
+
+	doc, err := marshalDoc(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO `%s` (id, doc) VALUES (?, ?)", colName), id, doc)
+	if err != nil && isDuplicateKey(err) {
+		return fmt.Errorf("insert _id %s: %w", id, ErrSqlDuplicateKey)
+	}
+
+	return err
+}
+
+func (s *SqlDB) InsertOne(ctx context.Context, colName string, insert any) error {
+	return s.InsertMany(ctx, colName, []any{insert})
+}
+
+func (s *SqlDB) InsertMany(ctx context.Context, colName string, inserts []any) error {
+	if _, err := s.GetTable(colName); err != nil {
+		return err
+	}
+
+	// timeout control
+	subCtx, cancel := utils.WithTimeoutContext(ctx, DatabaseWriteTimeout)
+	defer cancel()
+
+	err := s.withTx(subCtx, func(tx *sql.Tx) error {
+		for _, insert := range inserts {
+			if err := s.insert(subCtx, tx, colName, insert); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("SqlDB.InsertMany failed: %w", err)
+	}
+
+	return nil
+}
+
+// update applies update operators to the first matched document in transaction
+func (s *SqlDB) update(ctx context.Context, tx *sql.Tx, t *SqlTable, filter any, update any, upsert bool) error {
+	conds, err := parseFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	where, args, err := s.where(t, conds)
+	if err != nil {
+		return err
+	}
+
+	var id, data string
+	err = tx.QueryRowContext(ctx, fmt.Sprintf("SELECT id, doc FROM `%s`%s LIMIT 1%s", t.name, where, s.dialect.lockSuffix), args...).Scan(&id, &data)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	var doc bson.M
+	found := err == nil
+	if found {
+		if err := bson.UnmarshalExtJSON([]byte(data), false, &doc); err != nil {
+			return fmt.Errorf("decode document %s failed: %w", id, err)
+		}
+	} else {
+		if !upsert {
+			return nil
+		}
+
+		if doc, err = upsertDocument(conds); err != nil {
+			return err
+		}
+
+		if id, err = idText(doc["_id"]); err != nil {
+			return err
+		}
+	}
+
+	if err := applyUpdate(doc, update); err != nil {
+		return err
+	}
+
+	if data, err = marshalDoc(doc); err != nil {
+		return err
+	}
+
+	if found {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("UPDATE `%s` SET doc = ? WHERE id = ?", t.name), data, id)
+	} else {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(s.dialect.upsert, t.name), id, data)
+	}
+
+	return err
+}
+
+func (s *SqlDB) UpdateOne(ctx context.Context, colName string, filter any, update any, opts ...*options.UpdateOptions) error {
+	t, err := s.GetTable(colName)
+	if err != nil {
+		return err
+	}
+
+	upsert := false
+	for _, opt := range opts {
+		if opt != nil && opt.Upsert != nil {
+			upsert = *opt.Upsert
+		}
+	}
+
+	// timeout control
+	subCtx, cancel := utils.WithTimeoutContext(ctx, DatabaseWriteTimeout)
+	defer cancel()
+
+	err = s.withTx(subCtx, func(tx *sql.Tx) error {
+		return s.update(subCtx, tx, t, filter, update, upsert)
+	})
+
+	if err != nil {
+		return fmt.Errorf("SqlDB.UpdateOne failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SqlDB) delete(ctx context.Context, tx *sql.Tx, t *SqlTable, filter any) error {
+	conds, err := parseFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	where, args, err := s.where(t, conds)
+	if err != nil {
+		return err
+	}
+
+	var id string
+	err = tx.QueryRowContext(ctx, fmt.Sprintf("SELECT id FROM `%s`%s LIMIT 1%s", t.name, where, s.dialect.lockSuffix), args...).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM `%s` WHERE id = ?", t.name), id)
+	return err
+}
+
+func (s *SqlDB) DeleteOne(ctx context.Context, colName string, filter any) error {
+	t, err := s.GetTable(colName)
+	if err != nil {
+		return err
+	}
+
+	// timeout control
+	subCtx, cancel := utils.WithTimeoutContext(ctx, DatabaseWriteTimeout)
+	defer cancel()
+
+	return s.withTx(subCtx, func(tx *sql.Tx) error {
+		return s.delete(subCtx, tx, t, filter)
+	})
+}
+
+func (s *SqlDB) BulkWrite(ctx context.Context, colName string, model any) error {
+	t, err := s.GetTable(colName)
+	if err != nil {
+		return err
+	}
+
+	wm, ok := model.(mongo.WriteModel)
+	if !ok {
+		return ErrBulkWriteInvalidType
+	}
+
+	return t.Write(wm)
+}
+
+// BulkWriteModels writes models in order in a transaction.
+// when a model is rejected, models before it are committed and mongo.BulkWriteException is returned with its index
+func (s *SqlDB) BulkWriteModels(ctx context.Context, colName string, models []mongo.WriteModel) error {
+	t, err := s.GetTable(colName)
+	if err != nil {
+		return err
+	}
+
+	// timeout control
+	subCtx, cancel := utils.WithTimeoutContext(ctx, DatabaseBulkWriteTimeout)
+	defer cancel()
+
+	var bwe *mongo.BulkWriteException
+	err = s.withTx(subCtx, func(tx *sql.Tx) error {
+		for idx, model := range models {
+			var err error
+			switch m := model.(type) {
+			case *mongo.InsertOneModel:
+				err = s.insert(subCtx, tx, colName, m.Document)
+			case *mongo.UpdateOneModel:
+				err = s.update(subCtx, tx, t, m.Filter, m.Update, m.Upsert != nil && *m.Upsert)
+			case *mongo.DeleteOneModel:
+				err = s.delete(subCtx, tx, t, m.Filter)
+			default:
+				err = fmt.Errorf("bulk write model %T: %w", model, ErrSqlUnsupported)
+			}
+
+			if err == nil {
+				continue
+			}
+
+			// 文档或更新本身不合法, 提交之前的写入并返回出错的位置
+			if errors.Is(err, ErrSqlUnsupported) || errors.Is(err, ErrSqlInvalidUpdate) || errors.Is(err, ErrSqlDuplicateKey) {
+				code := 0
+				if errors.Is(err, ErrSqlDuplicateKey) {
+					code = duplicateKeyCode
+				}
+
+				bwe = &mongo.BulkWriteException{
+					WriteErrors: []mongo.BulkWriteError{{
+						WriteError: mongo.WriteError{Index: idx, Code: code, Message: err.Error()},
+						Request:    model,
+					}},
+				}
+				return nil
+			}
+
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("SqlDB.BulkWriteModels failed: %w", err)
+	}
+
+	if bwe != nil {
+		return *bwe
+	}
+
+	return nil
+}
+
+// withTx runs fn in a transaction, it commits when fn returns nil
+func (s *SqlDB) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *SqlDB) Flush() {
+	s.Lock()
+	defer s.Unlock()
+	for _, t := range s.mapTables {
+		t.Flush()
+	}
+}
+
+func (s *SqlDB) Exit() {
+	var wg sync.WaitGroup
+	s.Lock()
+	for _, t := range s.mapTables {
+		tbl := t
+		wg.Add(1)
+		go func() {
+			tbl.Exit()
+			wg.Done()
+		}()
+	}
+	s.Unlock()
+
+	wg.Wait()
+	err := s.db.Close()
+	utils.ErrPrint(err, "sql db close failed")
+}
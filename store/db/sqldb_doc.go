@@ -0,0 +1,487 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 关系数据库中文档以json保存, 这里在内存中完成bson过滤条件和更新操作的转换
+
+var (
+	ErrSqlUnsupported   = errors.New("sql db unsupported operation")
+	ErrSqlInvalidUpdate = errors.New("sql db invalid update")
+)
+
+// sqlCond is an equality condition on a document path
+type sqlCond struct {
+	path  string
+	value any
+}
+
+// parseFilter translates bson filter into equality conditions, operators like $in or $gt are not supported
+func parseFilter(filter any) ([]sqlCond, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	d, err := toBsonD(filter)
+	if err != nil {
+		return nil, fmt.Errorf("parse filter failed: %w", err)
+	}
+
+	conds := make([]sqlCond, 0, len(d))
+	for _, e := range d {
+		if strings.HasPrefix(e.Key, "$") {
+			return nil, fmt.Errorf("filter operator %s: %w", e.Key, ErrSqlUnsupported)
+		}
+
+		if err := checkPath(e.Key); err != nil {
+			return nil, err
+		}
+
+		if sub, ok := e.Value.(bson.D); ok && len(sub) > 0 && strings.HasPrefix(sub[0].Key, "$") {
+			return nil, fmt.Errorf("filter operator %s on %s: %w", sub[0].Key, e.Key, ErrSqlUnsupported)
+		}
+
+		v, err := normalizeValue(e.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		conds = append(conds, sqlCond{path: e.Key, value: v})
+	}
+
+	return conds, nil
+}
+
+// checkPath rejects document paths which can't be embedded into json path or column name
+func checkPath(path string) error {
+	if len(path) == 0 || strings.ContainsAny(path, "\"'`\\$") {
+		return fmt.Errorf("invalid field path <%s>: %w", path, ErrSqlUnsupported)
+	}
+
+	return nil
+}
+
+func toBsonD(x any) (bson.D, error) {
+	if d, ok := x.(bson.D); ok {
+		return d, nil
+	}
+
+	data, err := bson.Marshal(x)
+	if err != nil {
+		return nil, err
+	}
+
+	var d bson.D
+	err = bson.Unmarshal(data, &d)
+	return d, err
+}
+
+// normalizeValue converts value into the same types which are decoded from stored document
+func normalizeValue(v any) (any, error) {
+	data, err := bson.Marshal(bson.D{{Key: "v", Value: v}})
+	if err != nil {
+		return nil, err
+	}
+
+	var m bson.M
+	if err := bson.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return m["v"], nil
+}
+
+// idText returns the primary key column value of document _id
+func idText(v any) (string, error) {
+	switch id := v.(type) {
+	case string:
+		return id, nil
+	case int32:
+		return strconv.FormatInt(int64(id), 10), nil
+	case int64:
+		return strconv.FormatInt(id, 10), nil
+	case int:
+		return strconv.Itoa(id), nil
+	case float64:
+		if id == math.Trunc(id) && math.Abs(id) < 1<<53 {
+			return strconv.FormatInt(int64(id), 10), nil
+		}
+		return strconv.FormatFloat(id, 'g', -1, 64), nil
+	case primitive.ObjectID:
+		return id.Hex(), nil
+	case bson.M, bson.D:
+		// 复合主键, 字段按名字排序后保存为json
+		data, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: sortedDocument(id)}}, false, false)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	default:
+		return "", fmt.Errorf("_id type %T: %w", v, ErrSqlUnsupported)
+	}
+}
+
+// sortedDocument converts document into bson.D sorted by key recursively
+func sortedDocument(v any) any {
+	var m bson.M
+	switch d := v.(type) {
+	case bson.M:
+		m = d
+	case bson.D:
+		m = d.Map()
+	case bson.A:
+		a := make(bson.A, 0, len(d))
+		for _, e := range d {
+			a = append(a, sortedDocument(e))
+		}
+		return a
+	default:
+		return v
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	d := make(bson.D, 0, len(keys))
+	for _, k := range keys {
+		d = append(d, bson.E{Key: k, Value: sortedDocument(m[k])})
+	}
+	return d
+}
+
+// getValue returns value at path of document
+func getValue(c any, parts []string) (any, bool) {
+	if len(parts) == 0 {
+		return c, true
+	}
+
+	switch v := c.(type) {
+	case bson.M:
+		child, ok := v[parts[0]]
+		if !ok {
+			return nil, false
+		}
+		return getValue(child, parts[1:])
+
+	case bson.A:
+		idx, err := strconv.Atoi(parts[0])
+		if err != nil || idx < 0 || idx >= len(v) {
+			return nil, false
+		}
+		return getValue(v[idx], parts[1:])
+
+	default:
+		return nil, false
+	}
+}
+
+// setValue sets value at path of container c, missing documents on the path are created
+func setValue(c any, parts []string, value any) (any, error) {
+	if len(parts) == 0 {
+		return value, nil
+	}
+
+	switch v := c.(type) {
+	case nil:
+		child, err := setValue(nil, parts[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		return bson.M{parts[0]: child}, nil
+
+	case bson.M:
+		child, err := setValue(v[parts[0]], parts[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		v[parts[0]] = child
+		return v, nil
+
+	case bson.A:
+		idx, err := strconv.Atoi(parts[0])
+		if err != nil || idx < 0 {
+			return nil, fmt.Errorf("cannot set field <%s> in array: %w", parts[0], ErrSqlInvalidUpdate)
+		}
+
+		for len(v) <= idx {
+			v = append(v, nil)
+		}
+
+		child, err := setValue(v[idx], parts[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		v[idx] = child
+		return v, nil
+
+	default:
+		return nil, fmt.Errorf("cannot set field <%s> in non-document value: %w", parts[0], ErrSqlInvalidUpdate)
+	}
+}
+
+// unsetValue removes path from container c, array element is set to null like mongodb does
+func unsetValue(c any, parts []string) {
+	switch v := c.(type) {
+	case bson.M:
+		if len(parts) == 1 {
+			delete(v, parts[0])
+			return
+		}
+		unsetValue(v[parts[0]], parts[1:])
+
+	case bson.A:
+		idx, err := strconv.Atoi(parts[0])
+		if err != nil || idx < 0 || idx >= len(v) {
+			return
+		}
+
+		if len(parts) == 1 {
+			v[idx] = nil
+			return
+		}
+		unsetValue(v[idx], parts[1:])
+	}
+}
+
+// valueEqual compares normalized values, numbers of different types are compared by value
+func valueEqual(a, b any) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+
+	switch av := a.(type) {
+	case bson.M:
+		bv, ok := b.(bson.M)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for k, v := range av {
+			if w, ok := bv[k]; !ok || !valueEqual(v, w) {
+				return false
+			}
+		}
+		return true
+
+	case bson.A:
+		bv, ok := b.(bson.A)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for n := range av {
+			if !valueEqual(av[n], bv[n]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// match checks whether element matches $pull condition
+func match(elem any, cond any) bool {
+	condM, ok := cond.(bson.M)
+	if !ok {
+		return valueEqual(elem, cond)
+	}
+
+	elemM, ok := elem.(bson.M)
+	if !ok {
+		return false
+	}
+
+	for k, v := range condM {
+		if strings.HasPrefix(k, "$") {
+			return false
+		}
+
+		ev, ok := getValue(elemM, strings.Split(k, "."))
+		if !ok || !valueEqual(ev, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applyUpdate applies update operators $set, $unset, $push($each, $slice) and $pull to document
+func applyUpdate(doc bson.M, update any) error {
+	ops, err := toBsonD(update)
+	if err != nil {
+		return fmt.Errorf("parse update failed: %w", err)
+	}
+
+	if len(ops) == 0 {
+		return fmt.Errorf("empty update: %w", ErrSqlInvalidUpdate)
+	}
+
+	for _, op := range ops {
+		fields, err := toBsonD(op.Value)
+		if err != nil {
+			return fmt.Errorf("update operator <%s> with invalid fields: %w", op.Key, ErrSqlInvalidUpdate)
+		}
+
+		for _, f := range fields {
+			if err := checkPath(f.Key); err != nil {
+				return err
+			}
+
+			value, err := normalizeValue(f.Value)
+			if err != nil {
+				return err
+			}
+
+			// 和mongodb一样, 允许$set相同的_id
+			parts := strings.Split(f.Key, ".")
+			if parts[0] == "_id" && (op.Key != "$set" || len(parts) > 1 || !valueEqual(doc["_id"], value)) {
+				return fmt.Errorf("update operator <%s> on _id: %w", op.Key, ErrSqlInvalidUpdate)
+			}
+
+			switch op.Key {
+			case "$set":
+				if _, err := setValue(doc, parts, value); err != nil {
+					return err
+				}
+
+			case "$unset":
+				unsetValue(doc, parts)
+
+			case "$push":
+				arr, _ := getValue(doc, parts)
+				if arr == nil {
+					arr = bson.A{}
+				}
+
+				a, ok := arr.(bson.A)
+				if !ok {
+					return fmt.Errorf("$push to non-array field <%s>: %w", f.Key, ErrSqlInvalidUpdate)
+				}
+
+				elems, err := pushElements(f.Key, a, value)
+				if err != nil {
+					return err
+				}
+
+				if _, err := setValue(doc, parts, elems); err != nil {
+					return err
+				}
+
+			case "$pull":
+				arr, ok := getValue(doc, parts)
+				if !ok {
+					continue
+				}
+
+				a, ok := arr.(bson.A)
+				if !ok {
+					return fmt.Errorf("$pull from non-array field <%s>: %w", f.Key, ErrSqlInvalidUpdate)
+				}
+
+				kept := make(bson.A, 0, len(a))
+				for _, elem := range a {
+					if !match(elem, value) {
+						kept = append(kept, elem)
+					}
+				}
+
+				if _, err := setValue(doc, parts, kept); err != nil {
+					return err
+				}
+
+			default:
+				return fmt.Errorf("update operator <%s>: %w", op.Key, ErrSqlUnsupported)
+			}
+		}
+	}
+
+	return nil
+}
+
+// pushElements appends $push value to array, supports modifiers $each and $slice
+func pushElements(key string, a bson.A, value any) (bson.A, error) {
+	m, ok := value.(bson.M)
+	if !ok {
+		return append(a, value), nil
+	}
+
+	each, hasEach := m["$each"]
+	if !hasEach {
+		return append(a, value), nil
+	}
+
+	elems, ok := each.(bson.A)
+	if !ok {
+		return nil, fmt.Errorf("$push $each with non-array value on <%s>: %w", key, ErrSqlInvalidUpdate)
+	}
+
+	a = append(a, elems...)
+	for k, v := range m {
+		switch k {
+		case "$each":
+		case "$slice":
+			f, ok := toFloat(v)
+			if !ok || f != math.Trunc(f) {
+				return nil, fmt.Errorf("$push $slice with non-integer value on <%s>: %w", key, ErrSqlInvalidUpdate)
+			}
+
+			// 正数保留前n个, 负数保留后n个
+			n := int64(f)
+			switch {
+			case n >= 0 && int64(len(a)) > n:
+				a = a[:n]
+			case n < 0 && int64(len(a)) > -n:
+				a = a[int64(len(a))+n:]
+			}
+
+		default:
+			return nil, fmt.Errorf("$push modifier <%s>: %w", k, ErrSqlUnsupported)
+		}
+	}
+
+	return a, nil
+}
+
+// upsertDocument builds a new document from filter's equality conditions
+func upsertDocument(conds []sqlCond) (bson.M, error) {
+	doc := bson.M{}
+	for _, c := range conds {
+		if _, err := setValue(doc, strings.Split(c.path, "."), c.value); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := doc["_id"]; !ok {
+		doc["_id"] = primitive.NewObjectID()
+	}
+
+	return doc, nil
+}
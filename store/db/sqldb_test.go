@@ -0,0 +1,228 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type sqlTestItem struct {
+	Id    int64 `bson:"_id"`
+	Count int32 `bson:"count"`
+}
+
+type sqlTestPlayer struct {
+	Id      int64            `bson:"_id" json:"_id"`
+	OwnerId int64            `bson:"owner_id" json:"owner_id"`
+	Name    string           `bson:"name" json:"name"`
+	Level   int32            `bson:"level" json:"level"`
+	Heroes  map[string]int32 `bson:"heroes,omitempty" json:"heroes,omitempty"`
+	Items   []*sqlTestItem   `bson:"items,omitempty" json:"-"`
+	Extra   map[string]any   `bson:"extra,omitempty" json:"-"`
+}
+
+func newTestSqlDB(t *testing.T) *SqlDB {
+	t.Helper()
+
+	s, err := OpenSqlDB(Driver_SQLite, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("OpenSqlDB failed: %v", err)
+	}
+
+	if err := s.MigrateTable("player", "owner_id"); err != nil {
+		t.Fatalf("MigrateTable failed: %v", err)
+	}
+
+	return s
+}
+
+func loadPlayer(t *testing.T, s *SqlDB, id int64) *sqlTestPlayer {
+	t.Helper()
+
+	var p sqlTestPlayer
+	if err := s.FindOne(context.Background(), "player", bson.D{{Key: "_id", Value: id}}, &p); err != nil {
+		t.Fatalf("FindOne %d failed: %v", id, err)
+	}
+	return &p
+}
+
+func TestSqlDBFind(t *testing.T) {
+	s := newTestSqlDB(t)
+	defer s.Exit()
+
+	ctx := context.Background()
+	players := []any{
+		&sqlTestPlayer{Id: 1, OwnerId: 100, Name: "a", Level: 1},
+		&sqlTestPlayer{Id: 2, OwnerId: 100, Name: "b", Level: 2},
+		&sqlTestPlayer{Id: 3, OwnerId: 200, Name: "c", Level: 3},
+	}
+	if err := s.InsertMany(ctx, "player", players); err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+
+	if err := s.InsertOne(ctx, "player", players[0]); !errors.Is(err, ErrSqlDuplicateKey) {
+		t.Fatalf("InsertOne duplicate _id should return ErrSqlDuplicateKey, got %v", err)
+	}
+
+	if diff := cmp.Diff(players[1], loadPlayer(t, s, 2)); diff != "" {
+		t.Fatalf("FindOne mismatch: %s", diff)
+	}
+
+	var p sqlTestPlayer
+	if err := s.FindOne(ctx, "player", bson.M{"_id": int64(4)}, &p); !errors.Is(err, ErrNoResult) {
+		t.Fatalf("FindOne not exist should return ErrNoResult, got %v", err)
+	}
+
+	// owner_id is queried by generated column
+	res, err := s.Find(ctx, "player", bson.D{{Key: "owner_id", Value: int64(100)}})
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+
+	found := make(map[string]*sqlTestPlayer)
+	for k, v := range res {
+		var p sqlTestPlayer
+		if err := json.Unmarshal(v.([]byte), &p); err != nil {
+			t.Fatalf("unmarshal Find result failed: %v", err)
+		}
+		found[k] = &p
+	}
+
+	want := map[string]*sqlTestPlayer{"1": players[0].(*sqlTestPlayer), "2": players[1].(*sqlTestPlayer)}
+	if diff := cmp.Diff(want, found); diff != "" {
+		t.Fatalf("Find mismatch: %s", diff)
+	}
+
+	// filter on field without index
+	res, err = s.Find(ctx, "player", bson.D{{Key: "name", Value: "c"}})
+	if err != nil || len(res) != 1 {
+		t.Fatalf("Find by name should return 1 document, got %v %v", res, err)
+	}
+
+	if _, err := s.Find(ctx, "player", bson.M{"level": bson.M{"$gt": 1}}); !errors.Is(err, ErrSqlUnsupported) {
+		t.Fatalf("Find with operator should return ErrSqlUnsupported, got %v", err)
+	}
+}
+
+func TestSqlDBUpdate(t *testing.T) {
+	s := newTestSqlDB(t)
+	defer s.Exit()
+
+	ctx := context.Background()
+	filter := bson.D{{Key: "_id", Value: int64(1)}}
+
+	// without upsert nothing changed
+	if err := s.UpdateOne(ctx, "player", filter, bson.M{"$set": bson.M{"level": 1}}); err != nil {
+		t.Fatalf("UpdateOne failed: %v", err)
+	}
+
+	var p sqlTestPlayer
+	if err := s.FindOne(ctx, "player", filter, &p); !errors.Is(err, ErrNoResult) {
+		t.Fatalf("UpdateOne without upsert should not insert, got %v", err)
+	}
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "owner_id", Value: int64(100)},
+			{Key: "name", Value: "a"},
+			{Key: "heroes.10", Value: 1},
+			{Key: "extra", Value: bson.M{"k": "v"}},
+		}},
+	}
+	if err := s.UpdateOne(ctx, "player", filter, update, options.Update().SetUpsert(true)); err != nil {
+		t.Fatalf("UpdateOne upsert failed: %v", err)
+	}
+
+	updates := []any{
+		bson.M{"$set": bson.M{"heroes.11": 2, "level": 5}},
+		bson.M{"$unset": bson.M{"heroes.10": 1, "extra": 1}},
+		bson.M{"$push": bson.M{"items": &sqlTestItem{Id: 1, Count: 1}}},
+		bson.M{"$push": bson.M{"items": &sqlTestItem{Id: 2, Count: 2}}},
+		bson.M{"$set": bson.M{"items.1.count": 3}},
+		bson.M{"$pull": bson.M{"items": bson.M{"_id": int64(1)}}},
+	}
+	for _, u := range updates {
+		if err := s.UpdateOne(ctx, "player", filter, u); err != nil {
+			t.Fatalf("UpdateOne %v failed: %v", u, err)
+		}
+	}
+
+	want := &sqlTestPlayer{
+		Id:      1,
+		OwnerId: 100,
+		Name:    "a",
+		Level:   5,
+		Heroes:  map[string]int32{"11": 2},
+		Items:   []*sqlTestItem{{Id: 2, Count: 3}},
+	}
+	if diff := cmp.Diff(want, loadPlayer(t, s, 1)); diff != "" {
+		t.Fatalf("updated document mismatch: %s", diff)
+	}
+
+	// generated column follows document update
+	res, err := s.Find(ctx, "player", bson.D{{Key: "owner_id", Value: int64(100)}})
+	if err != nil || len(res) != 1 {
+		t.Fatalf("Find by updated owner_id should return 1 document, got %v %v", res, err)
+	}
+
+	if err := s.UpdateOne(ctx, "player", filter, bson.M{"$set": bson.M{"name.first": "a"}}); !errors.Is(err, ErrSqlInvalidUpdate) {
+		t.Fatalf("set field in non-document should return ErrSqlInvalidUpdate, got %v", err)
+	}
+
+	if err := s.DeleteOne(ctx, "player", filter); err != nil {
+		t.Fatalf("DeleteOne failed: %v", err)
+	}
+
+	if err := s.FindOne(ctx, "player", filter, &p); !errors.Is(err, ErrNoResult) {
+		t.Fatalf("FindOne after delete should return ErrNoResult, got %v", err)
+	}
+}
+
+func TestSqlDBBulkWriteModels(t *testing.T) {
+	s := newTestSqlDB(t)
+	defer s.Exit()
+
+	models := []mongo.WriteModel{
+		mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: int64(1)}}).
+			SetUpdate(bson.M{"$set": bson.M{"level": 1}}).
+			SetUpsert(true),
+		mongo.NewInsertOneModel().SetDocument(&sqlTestPlayer{Id: 2, Level: 2}),
+		mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: int64(1)}}).
+			SetUpdate(bson.M{"$inc": bson.M{"level": 1}}),
+		mongo.NewDeleteOneModel().SetFilter(bson.D{{Key: "_id", Value: int64(2)}}),
+	}
+
+	err := s.BulkWriteModels(context.Background(), "player", models)
+
+	var bwe mongo.BulkWriteException
+	if !errors.As(err, &bwe) || len(bwe.WriteErrors) != 1 || bwe.WriteErrors[0].Index != 2 {
+		t.Fatalf("BulkWriteModels should be rejected at index 2, got %v", err)
+	}
+
+	// models before the rejected one are committed
+	if p := loadPlayer(t, s, 1); p.Level != 1 {
+		t.Fatalf("player 1 level should be 1, got %d", p.Level)
+	}
+
+	if p := loadPlayer(t, s, 2); p.Level != 2 {
+		t.Fatalf("player 2 level should be 2, got %d", p.Level)
+	}
+
+	if err := s.BulkWriteModels(context.Background(), "player", models[3:]); err != nil {
+		t.Fatalf("BulkWriteModels failed: %v", err)
+	}
+
+	var p sqlTestPlayer
+	if err := s.FindOne(context.Background(), "player", bson.D{{Key: "_id", Value: int64(2)}}, &p); !errors.Is(err, ErrNoResult) {
+		t.Fatalf("player 2 should be deleted, got %v", err)
+	}
+}
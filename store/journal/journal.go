@@ -0,0 +1,261 @@
+package journal
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/rs/zerolog/log"
+)
+
+// 追加写日志:
+// 记录按顺序写入当前段文件, 每条记录格式为[4字节长度][4字节crc32][数据],
+// Rotate封存当前段并打开新的段, 封存段中的数据落地后通过Remove删除.
+// 进程崩溃时最后一条记录可能只写入一部分, 重放时会丢弃不完整的记录
+
+var (
+	ErrClosed    = errors.New("journal closed")
+	ErrCorrupted = errors.New("journal record corrupted")
+
+	MaxRecordSize = 16 * 1024 * 1024 // 单条记录最大16M
+
+	segmentSuffix = ".journal"
+	headerSize    = 8
+)
+
+type Journal struct {
+	dir   string
+	fsync bool // 每条记录写入后是否fsync, 关闭时只保证进程崩溃不丢数据
+	seq   uint64
+	f     *os.File
+	sync.Mutex
+}
+
+// Open opens a new segment after all existing segments in dir
+func Open(dir string, fsync bool) (*Journal, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("journal.Open failed: %w", err)
+	}
+
+	j := &Journal{dir: dir, fsync: fsync}
+
+	segs, err := j.Segments()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(segs) > 0 {
+		j.seq = segs[len(segs)-1]
+	}
+
+	if err := j.openSegment(j.seq + 1); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+func (j *Journal) segmentPath(seq uint64) string {
+	return filepath.Join(j.dir, fmt.Sprintf("%016d%s", seq, segmentSuffix))
+}
+
+func (j *Journal) openSegment(seq uint64) error {
+	f, err := os.OpenFile(j.segmentPath(seq), os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("journal open segment %d failed: %w", seq, err)
+	}
+
+	j.f = f
+	j.seq = seq
+	return nil
+}
+
+// Seq returns current writing segment's sequence
+func (j *Journal) Seq() uint64 {
+	j.Lock()
+	defer j.Unlock()
+	return j.seq
+}
+
+// Segments returns all segments' sequence in ascending order, including current writing segment
+func (j *Journal) Segments() ([]uint64, error) {
+	entries, err := os.ReadDir(j.dir)
+	if err != nil {
+		return nil, fmt.Errorf("journal read dir failed: %w", err)
+	}
+
+	segs := make([]uint64, 0, len(entries))
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		segs = append(segs, seq)
+	}
+
+	sort.Slice(segs, func(i, j int) bool { return segs[i] < segs[j] })
+	return segs, nil
+}
+
+// Append writes a record to current segment, it returns after the record is written to os
+func (j *Journal) Append(data []byte) error {
+	if len(data) > MaxRecordSize {
+		return fmt.Errorf("journal record size %d exceeds limit: %w", len(data), ErrCorrupted)
+	}
+
+	j.Lock()
+	defer j.Unlock()
+
+	if j.f == nil {
+		return ErrClosed
+	}
+
+	buf := make([]byte, headerSize+len(data))
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(data)))
+	binary.LittleEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(data))
+	copy(buf[headerSize:], data)
+
+	if _, err := j.f.Write(buf); err != nil {
+		return fmt.Errorf("journal append failed: %w", err)
+	}
+
+	if j.fsync {
+		return j.f.Sync()
+	}
+
+	return nil
+}
+
+// Rotate seals current segment and opens a new one, returns the sealed segment's sequence
+func (j *Journal) Rotate() (uint64, error) {
+	j.Lock()
+	defer j.Unlock()
+
+	if j.f == nil {
+		return 0, ErrClosed
+	}
+
+	sealed := j.seq
+	if err := j.closeSegment(); err != nil {
+		return 0, err
+	}
+
+	return sealed, j.openSegment(sealed + 1)
+}
+
+func (j *Journal) closeSegment() error {
+	err := j.f.Sync()
+	if errClose := j.f.Close(); err == nil {
+		err = errClose
+	}
+
+	j.f = nil
+	return err
+}
+
+// Remove deletes sealed segments whose sequence is not greater than upTo
+func (j *Journal) Remove(upTo uint64) error {
+	segs, err := j.Segments()
+	if err != nil {
+		return err
+	}
+
+	cur := j.Seq()
+	for _, seq := range segs {
+		if seq > upTo || seq >= cur {
+			break
+		}
+
+		if err := os.Remove(j.segmentPath(seq)); err != nil {
+			return fmt.Errorf("journal remove segment %d failed: %w", seq, err)
+		}
+	}
+
+	return nil
+}
+
+// Replay reads records of sealed segments whose sequence is not greater than upTo in order
+func (j *Journal) Replay(upTo uint64, fn func(data []byte) error) error {
+	segs, err := j.Segments()
+	if err != nil {
+		return err
+	}
+
+	cur := j.Seq()
+	for _, seq := range segs {
+		if seq > upTo || seq >= cur {
+			break
+		}
+
+		if err := j.replaySegment(seq, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (j *Journal) replaySegment(seq uint64, fn func(data []byte) error) error {
+	f, err := os.Open(j.segmentPath(seq))
+	if err != nil {
+		return fmt.Errorf("journal open segment %d failed: %w", seq, err)
+	}
+	defer f.Close()
+
+	header := make([]byte, headerSize)
+	for {
+		if _, err := io.ReadFull(f, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			// 进程崩溃导致的不完整记录
+			log.Warn().Err(err).Uint64("segment", seq).Msg("journal segment ends with partial header")
+			return nil
+		}
+
+		size := binary.LittleEndian.Uint32(header[0:4])
+		if int(size) > MaxRecordSize {
+			return fmt.Errorf("journal segment %d record size %d: %w", seq, size, ErrCorrupted)
+		}
+
+		data := make([]byte, size)
+		if _, err := io.ReadFull(f, data); err != nil {
+			log.Warn().Err(err).Uint64("segment", seq).Msg("journal segment ends with partial record")
+			return nil
+		}
+
+		if crc32.ChecksumIEEE(data) != binary.LittleEndian.Uint32(header[4:8]) {
+			return fmt.Errorf("journal segment %d crc mismatch: %w", seq, ErrCorrupted)
+		}
+
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+}
+
+func (j *Journal) Close() error {
+	j.Lock()
+	defer j.Unlock()
+
+	if j.f == nil {
+		return nil
+	}
+
+	return j.closeSegment()
+}
@@ -0,0 +1,497 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/east-eden/server/store/db"
+	log "github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 文档结构版本迁移:
+// 每个表注册按版本号递增的迁移函数, 文档中的SchemaVersionField记录当前版本, 没有版本号的文档视为版本0.
+// FindOne从数据库读取时按需迁移并写回, 迁移前的文档保存在SnapshotTable中用于回滚.
+// cmd/store_migrate可以离线批量迁移和回滚
+
+const (
+	SchemaVersionField = "_schema"
+	SnapshotTable      = "schema_snapshot"
+)
+
+var (
+	ErrMigrationVersion = errors.New("invalid migration version")
+
+	migrations = make(map[string][]*Migration)
+	migrateMu  sync.RWMutex
+)
+
+// MigrateFunc upgrades document from previous version in place
+type MigrateFunc func(doc bson.M) error
+
+type Migration struct {
+	Version int32  // 迁移后的版本, 从1开始连续递增
+	Name    string // 迁移说明
+	Migrate MigrateFunc
+}
+
+// RegisterMigration registers migrations of table, it should be called in init functions
+func RegisterMigration(tblName string, ms ...*Migration) {
+	migrateMu.Lock()
+	defer migrateMu.Unlock()
+
+	list := append(migrations[tblName], ms...)
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+
+	for n, m := range list {
+		if m.Version != int32(n+1) {
+			panic(fmt.Errorf("table %s migration<%s> version %d: %w", tblName, m.Name, m.Version, ErrMigrationVersion))
+		}
+	}
+
+	migrations[tblName] = list
+}
+
+// SchemaVersion returns the latest schema version of table
+func SchemaVersion(tblName string) int32 {
+	migrateMu.RLock()
+	defer migrateMu.RUnlock()
+	return int32(len(migrations[tblName]))
+}
+
+// MigrationTables returns all tables which have migrations
+func MigrationTables() []string {
+	migrateMu.RLock()
+	defer migrateMu.RUnlock()
+
+	tables := make([]string, 0, len(migrations))
+	for tbl := range migrations {
+		tables = append(tables, tbl)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// Migrations returns registered migrations of table in version order
+func Migrations(tblName string) []*Migration {
+	migrateMu.RLock()
+	defer migrateMu.RUnlock()
+	return append([]*Migration(nil), migrations[tblName]...)
+}
+
+func docVersion(doc bson.M) int32 {
+	switch v := doc[SchemaVersionField].(type) {
+	case int32:
+		return v
+	case int64:
+		return int32(v)
+	case float64:
+		return int32(v)
+	default:
+		return 0
+	}
+}
+
+// MigrateResult describes changes of a migrated document
+type MigrateResult struct {
+	Table    string
+	Id       any
+	From     int32
+	To       int32
+	Set      bson.D   // 变化的顶层字段, 包含版本号
+	Unset    []string // 删除的顶层字段
+	Snapshot bson.M   // 迁移前的文档
+}
+
+// MigrateDocument upgrades document to the latest version in place, returns nil if it is up to date
+func MigrateDocument(tblName string, doc bson.M) (*MigrateResult, error) {
+	from := docVersion(doc)
+	list := Migrations(tblName)
+	if int(from) >= len(list) {
+		return nil, nil
+	}
+
+	snapshot, err := copyDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range list[from:] {
+		if err := m.Migrate(doc); err != nil {
+			return nil, fmt.Errorf("table %s migrate document %v to version %d<%s> failed: %w", tblName, doc["_id"], m.Version, m.Name, err)
+		}
+	}
+
+	to := int32(len(list))
+	doc[SchemaVersionField] = to
+
+	res := &MigrateResult{
+		Table:    tblName,
+		Id:       snapshot["_id"],
+		From:     from,
+		To:       to,
+		Snapshot: snapshot,
+	}
+
+	keys := make([]string, 0, len(doc))
+	for k := range doc {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if k == "_id" {
+			continue
+		}
+
+		if old, ok := snapshot[k]; !ok || !reflect.DeepEqual(old, doc[k]) {
+			res.Set = append(res.Set, bson.E{Key: k, Value: doc[k]})
+		}
+	}
+
+	for k := range snapshot {
+		if _, ok := doc[k]; !ok {
+			res.Unset = append(res.Unset, k)
+		}
+	}
+	sort.Strings(res.Unset)
+
+	return res, nil
+}
+
+// copyDocument deep copies document, values are normalized to types decoded from database
+func copyDocument(doc any) (bson.M, error) {
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("copy document failed: %w", err)
+	}
+
+	var m bson.M
+	err = bson.Unmarshal(data, &m)
+	return m, err
+}
+
+func (r *MigrateResult) String() string {
+	fields := make([]string, 0, len(r.Set))
+	for _, e := range r.Set {
+		fields = append(fields, e.Key)
+	}
+
+	return fmt.Sprintf("table=%s _id=%v version=%d->%d set=%v unset=%v", r.Table, r.Id, r.From, r.To, fields, r.Unset)
+}
+
+func (r *MigrateResult) snapshotId() string {
+	return fmt.Sprintf("%s|%v|%d", r.Table, r.Id, r.From)
+}
+
+func (r *MigrateResult) snapshotFields() bson.D {
+	return bson.D{
+		{Key: "table", Value: r.Table},
+		{Key: "doc_id", Value: r.Id},
+		{Key: "from", Value: r.From},
+		{Key: "to", Value: r.To},
+		{Key: "doc", Value: r.Snapshot},
+		{Key: "time", Value: time.Now().Unix()},
+	}
+}
+
+func (r *MigrateResult) update() bson.D {
+	update := bson.D{}
+	if len(r.Set) > 0 {
+		update = append(update, bson.E{Key: "$set", Value: r.Set})
+	}
+
+	if len(r.Unset) > 0 {
+		unset := make(bson.D, 0, len(r.Unset))
+		for _, k := range r.Unset {
+			unset = append(unset, bson.E{Key: k, Value: 1})
+		}
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
+	return update
+}
+
+// migrateOnLoad upgrades document loaded from database and writes it back with write behind queue
+func (s *defStore) migrateOnLoad(ctx context.Context, info *StoreInfo, filter bson.D, x any) error {
+	var doc bson.M
+	if err := s.db.FindOne(ctx, info.tblName, filter, &doc); err != nil {
+		return err
+	}
+
+	if v := docVersion(doc); v > SchemaVersion(info.tblName) {
+		log.Warn().
+			Str("table", info.tblName).
+			Interface("_id", doc["_id"]).
+			Int32("version", v).
+			Msg("document schema version is newer than server")
+	}
+
+	res, err := MigrateDocument(info.tblName, doc)
+	if err != nil {
+		return err
+	}
+
+	if res != nil {
+		if err := s.writeMigration(res); err != nil {
+			return err
+		}
+
+		log.Info().Msgf("migrate document on load: %s", res)
+	}
+
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return bson.Unmarshal(data, x)
+}
+
+func (s *defStore) writeMigration(res *MigrateResult) error {
+	snap, err := newWriteOp(SnapshotTable, bson.D{{Key: "_id", Value: res.snapshotId()}})
+	if err == nil {
+		snap.Set, err = bson.Marshal(res.snapshotFields())
+	}
+
+	if err != nil {
+		return fmt.Errorf("Store writeMigration failed: %w", err)
+	}
+
+	snap.Upsert = true
+	if err := s.write(snap); err != nil {
+		return err
+	}
+
+	op, err := newWriteOp(res.Table, bson.D{{Key: "_id", Value: res.Id}})
+	if err == nil {
+		op.Set, err = bson.Marshal(res.Set)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Store writeMigration failed: %w", err)
+	}
+
+	op.Unset = res.Unset
+	return s.write(op)
+}
+
+// stampVersion appends schema version to the whole object saved by UpdateOne
+func stampVersion(tblName string, raw bson.Raw) (bson.Raw, error) {
+	ver := SchemaVersion(tblName)
+	if ver == 0 {
+		return raw, nil
+	}
+
+	if _, err := raw.LookupErr(SchemaVersionField); err == nil {
+		return raw, nil
+	}
+
+	d := bson.D{}
+	if err := bson.Unmarshal(raw, &d); err != nil {
+		return nil, err
+	}
+
+	return bson.Marshal(append(d, bson.E{Key: SchemaVersionField, Value: ver}))
+}
+
+// cacheName separates cached objects of different schema versions
+func cacheName(info *StoreInfo) string {
+	if ver := SchemaVersion(info.tblName); ver > 0 {
+		return fmt.Sprintf("%s_v%d", info.tblName, ver)
+	}
+
+	return info.tblName
+}
+
+// Migrator migrates or rollbacks all documents of a table offline
+type Migrator struct {
+	DB     db.DB
+	DryRun bool
+	Output func(format string, args ...any)
+}
+
+func (m *Migrator) printf(format string, args ...any) {
+	if m.Output != nil {
+		m.Output(format, args...)
+	}
+}
+
+// loadAll returns all documents of table, documents are reloaded by _id to keep value types
+func (m *Migrator) loadAll(ctx context.Context, tblName string, filter bson.D) ([]bson.M, error) {
+	res, err := m.DB.Find(ctx, tblName, filter)
+	if err != nil && !errors.Is(err, db.ErrNoResult) {
+		return nil, err
+	}
+
+	docs := make([]bson.M, 0, len(res))
+	for _, v := range res {
+		data, ok := v.([]byte)
+		if !ok {
+			continue
+		}
+
+		var head struct {
+			Id any `json:"_id"`
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&head); err != nil {
+			return nil, err
+		}
+
+		id := head.Id
+		if n, ok := id.(json.Number); ok {
+			if id, err = n.Int64(); err != nil {
+				return nil, fmt.Errorf("table %s invalid _id %s: %w", tblName, n, err)
+			}
+		}
+
+		var doc bson.M
+		if err := m.DB.FindOne(ctx, tblName, bson.D{{Key: "_id", Value: id}}, &doc); err != nil {
+			return nil, fmt.Errorf("table %s reload document %v failed: %w", tblName, id, err)
+		}
+
+		docs = append(docs, doc)
+	}
+
+	sort.Slice(docs, func(i, j int) bool {
+		return fmt.Sprint(docs[i]["_id"]) < fmt.Sprint(docs[j]["_id"])
+	})
+	return docs, nil
+}
+
+// Migrate upgrades all documents of table to the latest version, returns number of migrated documents
+func (m *Migrator) Migrate(ctx context.Context, tblName string) (int, error) {
+	docs, err := m.loadAll(ctx, tblName, bson.D{})
+	if err != nil {
+		return 0, err
+	}
+
+	upsert := options.Update().SetUpsert(true)
+
+	var count int
+	for _, doc := range docs {
+		res, err := MigrateDocument(tblName, doc)
+		if err != nil {
+			return count, err
+		}
+
+		if res == nil {
+			continue
+		}
+
+		count++
+		m.printf("%s\n", res)
+		if m.DryRun {
+			continue
+		}
+
+		if err := m.DB.UpdateOne(ctx, SnapshotTable, bson.D{{Key: "_id", Value: res.snapshotId()}}, bson.D{{Key: "$set", Value: res.snapshotFields()}}, upsert); err != nil {
+			return count, fmt.Errorf("save snapshot failed: %w", err)
+		}
+
+		if err := m.DB.UpdateOne(ctx, tblName, bson.D{{Key: "_id", Value: res.Id}}, res.update()); err != nil {
+			return count, fmt.Errorf("update document failed: %w", err)
+		}
+	}
+
+	return count, nil
+}
+
+// Rollback restores documents of table from snapshots taken when migrating from version not less than to.
+// for each document the earliest snapshot is restored, and all these snapshots are removed.
+func (m *Migrator) Rollback(ctx context.Context, tblName string, to int32) (int, error) {
+	snaps, err := m.loadAll(ctx, SnapshotTable, bson.D{{Key: "table", Value: tblName}})
+	if err != nil {
+		return 0, err
+	}
+
+	type restore struct {
+		snap   bson.M
+		remove []any
+	}
+
+	restores := make(map[string]*restore)
+	keys := make([]string, 0, len(snaps))
+	for _, snap := range snaps {
+		if docVersionOf(snap["from"]) < to {
+			continue
+		}
+
+		key := fmt.Sprint(snap["doc_id"])
+		r, ok := restores[key]
+		if !ok {
+			r = &restore{snap: snap}
+			restores[key] = r
+			keys = append(keys, key)
+		} else if docVersionOf(snap["from"]) < docVersionOf(r.snap["from"]) {
+			r.snap = snap
+		}
+		r.remove = append(r.remove, snap["_id"])
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		r := restores[key]
+		docId := r.snap["doc_id"]
+		old, _ := r.snap["doc"].(bson.M)
+
+		var cur bson.M
+		err := m.DB.FindOne(ctx, tblName, bson.D{{Key: "_id", Value: docId}}, &cur)
+		if err != nil && !errors.Is(err, db.ErrNoResult) {
+			return 0, err
+		}
+
+		res := &MigrateResult{Table: tblName, Id: docId, From: docVersion(cur), To: docVersion(old)}
+		fields := make([]string, 0, len(old))
+		for k := range old {
+			fields = append(fields, k)
+		}
+		sort.Strings(fields)
+
+		for _, k := range fields {
+			if k != "_id" {
+				res.Set = append(res.Set, bson.E{Key: k, Value: old[k]})
+			}
+		}
+
+		for k := range cur {
+			if _, ok := old[k]; !ok {
+				res.Unset = append(res.Unset, k)
+			}
+		}
+		sort.Strings(res.Unset)
+
+		m.printf("rollback %s\n", res)
+		if m.DryRun {
+			continue
+		}
+
+		if update := res.update(); len(update) > 0 {
+			err := m.DB.UpdateOne(ctx, tblName, bson.D{{Key: "_id", Value: docId}}, update, options.Update().SetUpsert(true))
+			if err != nil {
+				return 0, fmt.Errorf("restore document failed: %w", err)
+			}
+		}
+
+		for _, id := range r.remove {
+			if err := m.DB.DeleteOne(ctx, SnapshotTable, bson.D{{Key: "_id", Value: id}}); err != nil {
+				return 0, fmt.Errorf("remove snapshot failed: %w", err)
+			}
+		}
+	}
+
+	return len(keys), nil
+}
+
+func docVersionOf(v any) int32 {
+	return docVersion(bson.M{SchemaVersionField: v})
+}
@@ -0,0 +1,185 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/east-eden/server/store/db"
+	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const migrateTestTable = "migrate_player"
+
+type migratePlayer struct {
+	Id     int64   `bson:"_id" json:"_id"`
+	Name   string  `bson:"name" json:"name"`
+	Level  int32   `bson:"level" json:"level"`
+	Tokens []int32 `bson:"tokens" json:"tokens"`
+}
+
+func init() {
+	RegisterMigration(migrateTestTable,
+		&Migration{Version: 2, Name: "pad tokens", Migrate: func(doc bson.M) error {
+			tokens, _ := doc["tokens"].(bson.A)
+			for len(tokens) < 3 {
+				tokens = append(tokens, int32(0))
+			}
+			doc["tokens"] = tokens
+			return nil
+		}},
+		&Migration{Version: 1, Name: "rename lv to level", Migrate: func(doc bson.M) error {
+			if lv, ok := doc["lv"]; ok {
+				doc["level"] = lv
+				delete(doc, "lv")
+			}
+			return nil
+		}},
+	)
+}
+
+func newMigrateTestStore(t *testing.T) (*defStore, *db.SqlDB) {
+	t.Helper()
+
+	sdb, err := db.OpenSqlDB(db.Driver_SQLite, filepath.Join(t.TempDir(), "migrate.db"))
+	if err != nil {
+		t.Fatalf("OpenSqlDB failed: %v", err)
+	}
+
+	s := newTestStore(t, sdb, "")
+	s.AddStoreInfo(2, migrateTestTable, "_id")
+	return s, sdb
+}
+
+func loadDoc(t *testing.T, d db.DB, tbl string, id any) bson.M {
+	t.Helper()
+
+	var doc bson.M
+	if err := d.FindOne(context.Background(), tbl, bson.D{{Key: "_id", Value: id}}, &doc); err != nil {
+		t.Fatalf("FindOne %s %v failed: %v", tbl, id, err)
+	}
+	return doc
+}
+
+func TestRegisterMigrationVersion(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !errors.Is(r.(error), ErrMigrationVersion) {
+			t.Fatalf("register discontinuous version should panic with ErrMigrationVersion, got %v", r)
+		}
+	}()
+
+	RegisterMigration("migrate_invalid", &Migration{Version: 2, Name: "skip version 1"})
+}
+
+func TestMigrateOnLoad(t *testing.T) {
+	s, sdb := newMigrateTestStore(t)
+	defer s.wb.Exit()
+	defer sdb.Exit()
+
+	ctx := context.Background()
+	old := bson.D{{Key: "_id", Value: int64(1)}, {Key: "name", Value: "a"}, {Key: "lv", Value: int32(5)}, {Key: "tokens", Value: bson.A{int32(7)}}}
+	if err := sdb.InsertOne(ctx, migrateTestTable, old); err != nil {
+		t.Fatalf("InsertOne failed: %v", err)
+	}
+
+	var p migratePlayer
+	if err := s.FindOne(ctx, 2, int64(1), &p); err != nil {
+		t.Fatalf("FindOne failed: %v", err)
+	}
+
+	want := migratePlayer{Id: 1, Name: "a", Level: 5, Tokens: []int32{7, 0, 0}}
+	if diff := cmp.Diff(want, p); diff != "" {
+		t.Fatalf("migrated object mismatch: %s", diff)
+	}
+
+	// migrated document is written back with write behind
+	s.Flush()
+	doc := loadDoc(t, sdb, migrateTestTable, int64(1))
+	if _, ok := doc["lv"]; ok || docVersion(doc) != 2 {
+		t.Fatalf("document should be migrated to version 2 without lv, got %v", doc)
+	}
+
+	snap := loadDoc(t, sdb, SnapshotTable, fmt.Sprintf("%s|1|0", migrateTestTable))
+	if snapDoc, _ := snap["doc"].(bson.M); snapDoc["lv"] != int32(5) {
+		t.Fatalf("snapshot should keep the document before migration, got %v", snap)
+	}
+
+	// whole object saved by UpdateOne is stamped with the latest version
+	p2 := &migratePlayer{Id: 2, Name: "b", Tokens: []int32{0, 0, 0}}
+	if err := s.UpdateOne(ctx, 2, p2.Id, p2, true); err != nil {
+		t.Fatalf("UpdateOne failed: %v", err)
+	}
+
+	if doc := loadDoc(t, sdb, migrateTestTable, int64(2)); docVersion(doc) != 2 {
+		t.Fatalf("document saved by UpdateOne should have version 2, got %v", doc)
+	}
+}
+
+func TestMigratorMigrateAndRollback(t *testing.T) {
+	s, sdb := newMigrateTestStore(t)
+	defer s.wb.Exit()
+	defer sdb.Exit()
+
+	ctx := context.Background()
+	olds := []any{
+		bson.D{{Key: "_id", Value: int64(1)}, {Key: "lv", Value: int32(1)}},
+		bson.D{{Key: "_id", Value: int64(2)}, {Key: "level", Value: int32(2)}, {Key: "tokens", Value: bson.A{int32(1), int32(2), int32(3)}}, {Key: SchemaVersionField, Value: int32(1)}},
+		bson.D{{Key: "_id", Value: int64(3)}, {Key: "level", Value: int32(3)}, {Key: "tokens", Value: bson.A{int32(0), int32(0), int32(0)}}, {Key: SchemaVersionField, Value: int32(2)}},
+	}
+	if err := sdb.InsertMany(ctx, migrateTestTable, olds); err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+
+	var output []string
+	m := &Migrator{DB: sdb, DryRun: true, Output: func(format string, args ...any) {
+		output = append(output, fmt.Sprintf(format, args...))
+	}}
+
+	n, err := m.Migrate(ctx, migrateTestTable)
+	if err != nil || n != 2 {
+		t.Fatalf("dry run should migrate 2 documents, got %d %v", n, err)
+	}
+
+	want := []string{
+		"table=migrate_player _id=1 version=0->2 set=[_schema level tokens] unset=[lv]\n",
+		"table=migrate_player _id=2 version=1->2 set=[_schema] unset=[]\n",
+	}
+	if diff := cmp.Diff(want, output); diff != "" {
+		t.Fatalf("dry run output mismatch: %s", diff)
+	}
+
+	if doc := loadDoc(t, sdb, migrateTestTable, int64(1)); docVersion(doc) != 0 {
+		t.Fatalf("dry run should not write database, got %v", doc)
+	}
+
+	m.DryRun = false
+	if n, err := m.Migrate(ctx, migrateTestTable); err != nil || n != 2 {
+		t.Fatalf("Migrate should migrate 2 documents, got %d %v", n, err)
+	}
+
+	if n, err := m.Migrate(ctx, migrateTestTable); err != nil || n != 0 {
+		t.Fatalf("migrated documents should be up to date, got %d %v", n, err)
+	}
+
+	if n, err := m.Rollback(ctx, migrateTestTable, 0); err != nil || n != 2 {
+		t.Fatalf("Rollback should restore 2 documents, got %d %v", n, err)
+	}
+
+	// 关系数据库中小整数读出为int32
+	wantDoc := bson.M{"_id": int32(1), "lv": int32(1)}
+	if diff := cmp.Diff(wantDoc, loadDoc(t, sdb, migrateTestTable, int64(1))); diff != "" {
+		t.Fatalf("rollback document mismatch: %s", diff)
+	}
+
+	if doc := loadDoc(t, sdb, migrateTestTable, int64(2)); docVersion(doc) != 1 {
+		t.Fatalf("document 2 should be restored to version 1, got %v", doc)
+	}
+
+	// snapshots are removed after rollback
+	if n, err := m.Rollback(ctx, migrateTestTable, 0); err != nil || n != 0 {
+		t.Fatalf("Rollback again should restore nothing, got %d %v", n, err)
+	}
+}
@@ -169,9 +169,11 @@ func (mr *MockStoreMockRecorder) FindOne(ctx, storeType, key, x any) *gomock.Cal
 }
 
 // Flush mocks base method.
-func (m *MockStore) Flush() {
+func (m *MockStore) Flush() WriteBehindStats {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Flush")
+	ret := m.ctrl.Call(m, "Flush")
+	ret0, _ := ret[0].(WriteBehindStats)
+	return ret0
 }
 
 // Flush indicates an expected call of Flush.
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"strconv"
+
+	"github.com/east-eden/server/store/cache"
+	"github.com/east-eden/server/store/db"
+)
+
+type Option func(*Options)
+
+// store options, cache and database are created from cli flags if not set
+type Options struct {
+	DB    db.DB
+	Cache cache.Cache
+	Node  string // 节点id, 同一服务的多个节点共用配置时日志目录按节点区分
+}
+
+func DB(d db.DB) Option {
+	return func(o *Options) {
+		o.DB = d
+	}
+}
+
+func Cache(c cache.Cache) Option {
+	return func(o *Options) {
+		o.Cache = c
+	}
+}
+
+// 日志写入store_journal_dir下以节点id命名的子目录
+func Node(id int16) Option {
+	return func(o *Options) {
+		o.Node = strconv.Itoa(int(id))
+	}
+}
@@ -4,16 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/east-eden/server/store/cache"
 	"github.com/east-eden/server/store/db"
+	"github.com/east-eden/server/utils"
 	_ "github.com/go-sql-driver/mysql"
 	log "github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v2"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // store find no result
@@ -33,7 +34,7 @@ type StoreInfo struct {
 type Store interface {
 	InitCompleted() bool
 	GetDB() db.DB
-	Flush()
+	Flush() WriteBehindStats
 	Exit()
 	AddStoreInfo(tp int, tblName, keyName string)
 	MigrateDbTable(tblName string, indexNames ...string) error
@@ -61,15 +62,16 @@ type Store interface {
 type defStore struct {
 	cache    cache.Cache
 	db       db.DB
+	wb       *WriteBehind
 	once     sync.Once
 	done     bool
 	infoList map[int]*StoreInfo
 	sync.Mutex
 }
 
-func NewStore(ctx *cli.Context) Store {
+func NewStore(ctx *cli.Context, opts ...Option) Store {
 	s := &defStore{}
-	s.init(ctx)
+	s.init(ctx, opts...)
 	gs = s
 	return gs
 }
@@ -82,10 +84,33 @@ func SetStore(s Store) {
 	gs = s
 }
 
-func (s *defStore) init(ctx *cli.Context) {
+func (s *defStore) init(ctx *cli.Context, opts ...Option) {
 	s.once.Do(func() {
-		s.cache = cache.NewCache(ctx)
-		s.db = db.NewDB(ctx)
+		o := &Options{}
+		for _, opt := range opts {
+			opt(o)
+		}
+
+		s.cache = o.Cache
+		if s.cache == nil {
+			s.cache = cache.NewCache(ctx)
+		}
+
+		s.db = o.DB
+		if s.db == nil {
+			s.db = db.NewDB(ctx)
+		}
+
+		journalDir := ctx.String("store_journal_dir")
+		if journalDir != "" && o.Node != "" {
+			journalDir = filepath.Join(journalDir, o.Node)
+		}
+
+		s.wb = NewWriteBehind(s.db, &WriteBehindOptions{
+			JournalDir:    journalDir,
+			JournalFsync:  ctx.Bool("store_journal_fsync"),
+			FlushInterval: ctx.Duration("store_flush_interval"),
+		})
 		s.done = true
 		s.infoList = make(map[int]*StoreInfo)
 	})
@@ -99,11 +124,17 @@ func (s *defStore) GetDB() db.DB {
 	return s.db
 }
 
-func (s *defStore) Flush() {
+// Flush writes all pending writes into database, returns the backlog after flush
+func (s *defStore) Flush() WriteBehindStats {
+	stats, err := s.wb.flush()
+	utils.ErrPrint(err, "write behind flush failed when Store.Flush")
+
 	s.db.Flush()
+	return stats
 }
 
 func (s *defStore) Exit() {
+	s.wb.Exit()
 	s.cache.Exit()
 	s.db.Exit()
 	log.Info().Msg("store exit...")
@@ -125,6 +156,12 @@ func (s *defStore) MigrateDbTable(tblName string, indexNames ...string) error {
 	return s.db.MigrateTable(tblName, indexNames...)
 }
 
+// invalidateCache deletes cached object after partial update, the next FindOne will flush pending writes of it and reload it from database
+func (s *defStore) invalidateCache(info *StoreInfo, k any) {
+	err := s.cache.DeleteObject(cacheName(info), k)
+	utils.ErrPrint(err, "cache delete object failed when Store.invalidateCache", info.tblName, k)
+}
+
 // FindOne loads object from cache at first, if didn't hit, it will search from database. it neither search nor save with memory.
 func (s *defStore) FindOne(ctx context.Context, storeType int, key any, x any) error {
 	if !s.InitCompleted() {
@@ -137,17 +174,36 @@ func (s *defStore) FindOne(ctx context.Context, storeType int, key any, x any) e
 	}
 
 	// search in cache, if hit, store it in memory
-	err := s.cache.LoadObject(info.tblName, key, x)
+	err := s.cache.LoadObject(cacheName(info), key, x)
 	if err == nil {
 		return nil
 	}
 
+	// 部分更新会删除缓存, 读取数据库之前先把该文档未落地的更新写入数据库
+	if s.wb.dirty(info.tblName, makeFilter(key)) {
+		errFlush := s.wb.flushDoc(docKey(info.tblName, makeFilter(key)))
+		utils.ErrPrint(errFlush, "write behind flush failed when Store.FindOne", info.tblName, key)
+	}
+
 	// search in database, if hit, store it in both memory and cache
 	filter := bson.D{}
 	filter = append(filter, bson.E{Key: info.keyName, Value: key})
-	err = s.db.FindOne(ctx, info.tblName, filter, x)
+	if SchemaVersion(info.tblName) > 0 {
+		err = s.migrateOnLoad(ctx, info, filter, x)
+	} else {
+		err = s.db.FindOne(ctx, info.tblName, filter, x)
+	}
+
 	if err == nil {
-		return s.cache.SaveObject(info.tblName, key, x)
+		// 数据库中的数据不是最新时不能缓存, 否则缓存过期前都会读到旧数据
+		if s.wb.dirty(info.tblName, makeFilter(key)) {
+			return nil
+		}
+
+		// 缓存不可用时不影响读取
+		errCache := s.cache.SaveObject(cacheName(info), key, x)
+		utils.ErrPrint(errCache, "cache save object failed when Store.FindOne", info.tblName, key)
+		return nil
 	}
 
 	if errors.Is(err, db.ErrNoResult) {
@@ -202,36 +258,25 @@ func (s *defStore) UpdateOne(ctx context.Context, storeType int, k any, x any, i
 	}
 
 	// save into cache
-	errCache := s.cache.SaveObject(info.tblName, k, x)
+	errCache := s.cache.SaveObject(cacheName(info), k, x)
 
-	// filter
-	filter := bson.D{}
-	switch v := k.(type) {
-	case map[string]any:
-		for key, value := range v {
-			filter = append(filter, bson.E{Key: key, Value: value})
-		}
-	default:
-		filter = append(filter, bson.E{Key: "_id", Value: k})
+	// save into database
+	op, err := newWriteOp(info.tblName, makeFilter(k))
+	if err == nil {
+		op.Set, err = bson.Marshal(x)
 	}
 
-	// update
-	update := bson.D{}
-	update = append(update, bson.E{Key: "$set", Value: x})
-	op := options.Update().SetUpsert(true)
+	if err == nil {
+		op.Set, err = stampVersion(info.tblName, op.Set)
+	}
 
-	// save into database
-	var errDb error
-	if len(immediately) > 0 && immediately[0] {
-		errDb = s.db.UpdateOne(ctx, info.tblName, filter, update, op)
-	} else {
-		model := mongo.NewUpdateOneModel()
-		model.SetFilter(filter)
-		model.SetUpdate(update)
-		model.SetUpsert(true)
-		errDb = s.db.BulkWrite(ctx, info.tblName, model)
+	if err != nil {
+		return fmt.Errorf("Store UpdateOne failed: %w", err)
 	}
 
+	op.Upsert = true
+	errDb := s.write(op, immediately...)
+
 	if errCache != nil {
 		return errCache
 	}
@@ -250,46 +295,21 @@ func (s *defStore) UpdateFields(ctx context.Context, storeType int, k any, field
 		return fmt.Errorf("Store SaveFields: invalid store type %d", storeType)
 	}
 
-	// save into cache
-	// errCache := s.cache.SaveObject(info.tblName, k, x)
-
-	// filter
-	filter := bson.D{}
-	switch v := k.(type) {
-	case map[string]any:
-		for key, value := range v {
-			filter = append(filter, bson.E{Key: key, Value: value})
-		}
-	default:
-		filter = append(filter, bson.E{Key: "_id", Value: k})
-	}
-
-	// update
-	update := bson.D{}
-	updateValues := bson.D{}
-	for updateKey, updateValue := range fields {
-		updateValues = append(updateValues, bson.E{Key: updateKey, Value: updateValue})
-	}
-	update = append(update, bson.E{Key: "$set", Value: updateValues})
-	op := options.Update().SetUpsert(true)
+	// partial update, invalidate cached object
+	s.invalidateCache(info, k)
 
 	// save into database
-	var errDb error
-	if len(immediately) > 0 && immediately[0] {
-		errDb = s.db.UpdateOne(ctx, info.tblName, filter, update, op)
-	} else {
-		model := mongo.NewUpdateOneModel()
-		model.SetFilter(filter)
-		model.SetUpdate(update)
-		model.SetUpsert(true)
-		errDb = s.db.BulkWrite(ctx, info.tblName, model)
+	op, err := newWriteOp(info.tblName, makeFilter(k))
+	if err == nil {
+		op.Set, err = bson.Marshal(makeSetFields(fields))
 	}
 
-	// if errCache != nil {
-	// 	return errCache
-	// }
+	if err != nil {
+		return fmt.Errorf("Store UpdateFields failed: %w", err)
+	}
 
-	return errDb
+	op.Upsert = true
+	return s.write(op, immediately...)
 }
 
 // DeleteOne delete object cache and database with async call. it won't delete from memory
@@ -304,29 +324,17 @@ func (s *defStore) DeleteOne(ctx context.Context, storeType int, k any, immediat
 	}
 
 	// delete from cache
-	errCache := s.cache.DeleteObject(info.tblName, k)
-
-	// filter
-	filter := bson.D{}
-	switch v := k.(type) {
-	case map[string]any:
-		for key, value := range v {
-			filter = append(filter, bson.E{Key: key, Value: value})
-		}
-	default:
-		filter = append(filter, bson.E{Key: "_id", Value: k})
-	}
+	errCache := s.cache.DeleteObject(cacheName(info), k)
 
 	// delete from database
-	var errDb error
-	if len(immediately) > 0 && immediately[0] {
-		errDb = s.db.DeleteOne(ctx, info.tblName, filter)
-	} else {
-		model := mongo.NewDeleteOneModel()
-		model.SetFilter(filter)
-		errDb = s.db.BulkWrite(ctx, info.tblName, model)
+	op, err := newWriteOp(info.tblName, makeFilter(k))
+	if err != nil {
+		return fmt.Errorf("Store DeleteOne failed: %w", err)
 	}
 
+	op.Delete = true
+	errDb := s.write(op, immediately...)
+
 	if errCache != nil {
 		return errCache
 	}
@@ -344,37 +352,17 @@ func (s *defStore) DeleteFields(ctx context.Context, storeType int, k any, field
 		return fmt.Errorf("Store DeleteFields: invalid store type %d", storeType)
 	}
 
-	// filter
-	filter := bson.D{}
-	switch v := k.(type) {
-	case map[string]any:
-		for key, value := range v {
-			filter = append(filter, bson.E{Key: key, Value: value})
-		}
-	default:
-		filter = append(filter, bson.E{Key: "_id", Value: k})
-	}
-
-	// update
-	update := bson.D{}
-	updateValues := bson.D{}
-	for _, keyName := range fields {
-		updateValues = append(updateValues, bson.E{Key: keyName, Value: 1})
-	}
-	update = append(update, bson.E{Key: "$unset", Value: updateValues})
+	// partial update, invalidate cached object
+	s.invalidateCache(info, k)
 
 	// delete fields from database
-	var errDb error
-	if len(immediately) > 0 && immediately[0] {
-		errDb = s.db.UpdateOne(ctx, info.tblName, filter, update)
-	} else {
-		model := mongo.NewUpdateOneModel()
-		model.SetFilter(filter)
-		model.SetUpdate(update)
-		errDb = s.db.BulkWrite(ctx, info.tblName, model)
+	op, err := newWriteOp(info.tblName, makeFilter(k))
+	if err != nil {
+		return fmt.Errorf("Store DeleteFields failed: %w", err)
 	}
 
-	return errDb
+	op.Unset = fields
+	return s.write(op, immediately...)
 }
 
 // push element to array
@@ -388,14 +376,79 @@ func (s *defStore) PushArray(ctx context.Context, storeType int, k any, arrayNam
 		return fmt.Errorf("Store PushArray: invalid store type %d", storeType)
 	}
 
-	// delete fields from database
-	filter := bson.D{}
-	filter = append(filter, bson.E{Key: "_id", Value: k})
-	update := bson.M{"$push": bson.M{arrayName: x}}
-	// op := options.Update().SetUpsert(true)
-	errDb := s.db.UpdateOne(ctx, info.tblName, filter, update)
+	s.invalidateCache(info, k)
 
-	return errDb
+	// $push不是幂等的, 日志重放或者重试时会重复添加元素, 先$pull相同的元素再$push, 数组元素需要唯一
+	pulls, err := pullPushed(arrayName, x)
+	if err != nil {
+		return fmt.Errorf("Store PushArray failed: %w", err)
+	}
+
+	for _, update := range pulls {
+		op, err := newWriteOp(info.tblName, bson.D{{Key: "_id", Value: k}})
+		if err != nil {
+			return fmt.Errorf("Store PushArray failed: %w", err)
+		}
+
+		op.Update = update
+		if err := s.write(op); err != nil {
+			return err
+		}
+	}
+
+	op, err := newWriteOp(info.tblName, bson.D{{Key: "_id", Value: k}})
+	if err == nil {
+		op.Update, err = bson.Marshal(bson.M{"$push": bson.M{arrayName: x}})
+	}
+
+	if err != nil {
+		return fmt.Errorf("Store PushArray failed: %w", err)
+	}
+
+	return s.write(op, true)
+}
+
+// pullPushed returns $pull updates which remove elements pushed by x, x is an element or $push modifiers with $each.
+// elements with _id are pulled by _id, because they may be updated by UpdateArray after pushed
+func pullPushed(arrayName string, x any) ([]bson.Raw, error) {
+	raw, err := bson.Marshal(bson.M{"x": x})
+	if err != nil {
+		return nil, err
+	}
+
+	v := bson.Raw(raw).Lookup("x")
+	elems := []bson.RawValue{v}
+	if doc, ok := v.DocumentOK(); ok {
+		if each, err := doc.LookupErr("$each"); err == nil {
+			arr, ok := each.ArrayOK()
+			if !ok {
+				return nil, errors.New("$each should be an array")
+			}
+
+			if elems, err = arr.Values(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	updates := make([]bson.Raw, 0, len(elems))
+	for _, e := range elems {
+		var cond any = e
+		if doc, ok := e.DocumentOK(); ok {
+			if id, err := doc.LookupErr("_id"); err == nil {
+				cond = bson.D{{Key: "_id", Value: id}}
+			}
+		}
+
+		update, err := bson.Marshal(bson.M{"$pull": bson.M{arrayName: cond}})
+		if err != nil {
+			return nil, err
+		}
+
+		updates = append(updates, update)
+	}
+
+	return updates, nil
 }
 
 // pull element from array
@@ -409,13 +462,18 @@ func (s *defStore) PullArray(ctx context.Context, storeType int, k any, arrayNam
 		return fmt.Errorf("Store PullArray: invalid store type %d", storeType)
 	}
 
-	// delete fields from database
-	filter := bson.D{}
-	filter = append(filter, bson.E{Key: "_id", Value: k})
-	update := bson.M{"$pull": bson.M{arrayName: bson.M{"_id": xKey}}}
-	errDb := s.db.UpdateOne(ctx, info.tblName, filter, update)
+	s.invalidateCache(info, k)
 
-	return errDb
+	op, err := newWriteOp(info.tblName, bson.D{{Key: "_id", Value: k}})
+	if err == nil {
+		op.Update, err = bson.Marshal(bson.M{"$pull": bson.M{arrayName: bson.M{"_id": xKey}}})
+	}
+
+	if err != nil {
+		return fmt.Errorf("Store PullArray failed: %w", err)
+	}
+
+	return s.write(op, true)
 }
 
 // update element in array
@@ -429,20 +487,23 @@ func (s *defStore) UpdateArray(ctx context.Context, storeType int, k any, arrayN
 		return fmt.Errorf("Store UpdateArray: invalid store type %d", storeType)
 	}
 
+	s.invalidateCache(info, k)
+
 	// save into database
 	filter := bson.D{}
 	filter = append(filter, bson.E{Key: "_id", Value: k})
 	filter = append(filter, bson.E{Key: fmt.Sprintf("%s._id", arrayName), Value: xKey})
-	update := bson.D{}
-	updateValues := bson.D{}
-	for updateKey, updateValue := range fields {
-		updateValues = append(updateValues, bson.E{Key: updateKey, Value: updateValue})
+	op, err := newWriteOp(info.tblName, filter)
+	if err == nil {
+		op.Set, err = bson.Marshal(makeSetFields(fields))
 	}
-	update = append(update, bson.E{Key: "$set", Value: updateValues})
-	op := options.Update().SetUpsert(true)
-	errDb := s.db.UpdateOne(ctx, info.tblName, filter, update, op)
 
-	return errDb
+	if err != nil {
+		return fmt.Errorf("Store UpdateArray failed: %w", err)
+	}
+
+	op.Upsert = true
+	return s.write(op, true)
 }
 
 // SaveHashObjectFields save fields to cache and database with async call. it won't save to memory
@@ -457,19 +518,20 @@ func (s *defStore) SaveHashObjectFields(storeType int, k any, field any, x any,
 	}
 
 	// save into cache
-	errCache := s.cache.SaveHashObject(info.tblName, k, field, x)
+	errCache := s.cache.SaveHashObject(cacheName(info), k, field, x)
 
 	// save into database
-	filter := bson.D{}
-	filter = append(filter, bson.E{Key: "_id", Value: k})
-	update := bson.D{}
-	updateValues := bson.D{}
-	for updateKey, updateValue := range fields {
-		updateValues = append(updateValues, bson.E{Key: updateKey, Value: updateValue})
+	op, err := newWriteOp(info.tblName, bson.D{{Key: "_id", Value: k}})
+	if err == nil {
+		op.Set, err = bson.Marshal(makeSetFields(fields))
 	}
-	update = append(update, bson.E{Key: "$set", Value: updateValues})
-	op := options.Update().SetUpsert(true)
-	errDb := s.db.UpdateOne(context.Background(), info.tblName, filter, update, op)
+
+	if err != nil {
+		return fmt.Errorf("Store SaveHashObjectFields failed: %w", err)
+	}
+
+	op.Upsert = true
+	errDb := s.write(op, true)
 
 	if errCache != nil {
 		return errCache
@@ -489,15 +551,20 @@ func (s *defStore) SaveHashObject(storeType int, k any, field any, x any) error
 	}
 
 	// save into cache
-	errCache := s.cache.SaveHashObject(info.tblName, k, field, x)
+	errCache := s.cache.SaveHashObject(cacheName(info), k, field, x)
 
 	// save into database
-	filter := bson.D{}
-	filter = append(filter, bson.E{Key: "_id", Value: field})
-	update := bson.D{}
-	update = append(update, bson.E{Key: "$set", Value: x})
-	op := options.Update().SetUpsert(true)
-	errDb := s.db.UpdateOne(context.Background(), info.tblName, filter, update, op)
+	op, err := newWriteOp(info.tblName, bson.D{{Key: "_id", Value: field}})
+	if err == nil {
+		op.Set, err = bson.Marshal(x)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Store SaveHashObject failed: %w", err)
+	}
+
+	op.Upsert = true
+	errDb := s.write(op, true)
 
 	if errCache != nil {
 		return errCache
@@ -517,19 +584,17 @@ func (s *defStore) DeleteObjectFields(storeType int, k any, x any, fields []stri
 	}
 
 	// delete fields from cache
-	errCache := s.cache.SaveObject(info.tblName, k, x)
+	errCache := s.cache.SaveObject(cacheName(info), k, x)
 
 	// delete fields from database
-	filter := bson.D{}
-	filter = append(filter, bson.E{Key: "_id", Value: k})
-	update := bson.D{}
-	updateValues := bson.D{}
-	for _, keyName := range fields {
-		updateValues = append(updateValues, bson.E{Key: keyName, Value: 1})
+	op, err := newWriteOp(info.tblName, bson.D{{Key: "_id", Value: k}})
+	if err != nil {
+		return fmt.Errorf("Store DeleteObjectFields failed: %w", err)
 	}
-	update = append(update, bson.E{Key: "$unset", Value: updateValues})
-	op := options.Update().SetUpsert(true)
-	errDb := s.db.UpdateOne(context.Background(), info.tblName, filter, update, op)
+
+	op.Unset = fields
+	op.Upsert = true
+	errDb := s.write(op, true)
 
 	if errCache != nil {
 		return errCache
@@ -550,12 +615,16 @@ func (s *defStore) DeleteHashObject(storeType int, k any, field any) error {
 	}
 
 	// delete from cache
-	errCache := s.cache.DeleteHashObject(info.tblName, k, field)
+	errCache := s.cache.DeleteHashObject(cacheName(info), k, field)
 
 	// delete from database
-	filter := bson.D{}
-	filter = append(filter, bson.E{Key: "_id", Value: field})
-	errDb := s.db.DeleteOne(context.Background(), info.tblName, filter)
+	op, err := newWriteOp(info.tblName, bson.D{{Key: "_id", Value: field}})
+	if err != nil {
+		return fmt.Errorf("Store DeleteHashObject failed: %w", err)
+	}
+
+	op.Delete = true
+	errDb := s.write(op, true)
 
 	if errCache != nil {
 		return errCache
@@ -575,19 +644,17 @@ func (s *defStore) DeleteHashObjectFields(storeType int, k any, field any, x any
 	}
 
 	// delete fields from cache
-	errCache := s.cache.SaveHashObject(info.tblName, k, field, x)
+	errCache := s.cache.SaveHashObject(cacheName(info), k, field, x)
 
 	// delete fields from database
-	filter := bson.D{}
-	filter = append(filter, bson.E{Key: "_id", Value: k})
-	update := bson.D{}
-	updateValues := bson.D{}
-	for _, keyName := range fields {
-		updateValues = append(updateValues, bson.E{Key: keyName, Value: 1})
+	op, err := newWriteOp(info.tblName, bson.D{{Key: "_id", Value: k}})
+	if err != nil {
+		return fmt.Errorf("Store DeleteHashObjectFields failed: %w", err)
 	}
-	update = append(update, bson.E{Key: "$unset", Value: updateValues})
-	op := options.Update().SetUpsert(true)
-	errDb := s.db.UpdateOne(context.Background(), info.tblName, filter, update, op)
+
+	op.Unset = fields
+	op.Upsert = true
+	errDb := s.write(op, true)
 
 	if errCache != nil {
 		return errCache
@@ -595,3 +662,53 @@ func (s *defStore) DeleteHashObjectFields(storeType int, k any, field any, x any
 
 	return errDb
 }
+
+// write appends op to write-behind queue, it flushes queue synchronously when immediately
+func (s *defStore) write(op *writeOp, immediately ...bool) error {
+	if err := s.wb.Write(op); err != nil {
+		return fmt.Errorf("Store write failed: %w", err)
+	}
+
+	// 只写入这个文档的更新, 其他文档的更新继续合并
+	if len(immediately) > 0 && immediately[0] {
+		return s.wb.flushDoc(op.docKey())
+	}
+
+	return nil
+}
+
+// makeFilter builds filter by key, map keys are sorted so the same key always has the same filter
+func makeFilter(k any) bson.D {
+	filter := bson.D{}
+	switch v := k.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			filter = append(filter, bson.E{Key: key, Value: v[key]})
+		}
+	default:
+		filter = append(filter, bson.E{Key: "_id", Value: k})
+	}
+
+	return filter
+}
+
+func makeSetFields(fields map[string]any) bson.D {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := make(bson.D, 0, len(fields))
+	for _, key := range keys {
+		values = append(values, bson.E{Key: key, Value: fields[key]})
+	}
+
+	return values
+}
@@ -0,0 +1,25 @@
+package store
+
+import (
+	"flag"
+
+	"github.com/east-eden/server/logger"
+	"github.com/east-eden/server/store/db"
+	"github.com/east-eden/server/utils"
+	"github.com/urfave/cli/v2"
+)
+
+// NewTestMemStore init snow flake, logger and global store for unit tests of services.
+// 使用内存数据库, 不依赖mongodb, 返回的cli.Context用于创建各服务的manager
+func NewTestMemStore(name string, machineId int16) *cli.Context {
+	// snow flake init
+	utils.InitMachineID(machineId, 0, func() {})
+
+	// logger init
+	logger.InitLogger(name)
+
+	set := flag.NewFlagSet(name, flag.ContinueOnError)
+	ctx := cli.NewContext(nil, set, nil)
+	NewStore(ctx, DB(db.NewMemDB()))
+	return ctx
+}
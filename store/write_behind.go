@@ -0,0 +1,702 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/east-eden/server/store/db"
+	"github.com/east-eden/server/store/journal"
+	"github.com/east-eden/server/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// write-behind:
+// 所有写操作先追加到本地日志再返回, 然后在内存中按文档合并, 定时批量写入数据库.
+// 同一文档的多次字段更新会合并成一次$set/$unset, 删除操作会覆盖之前的所有更新.
+// 数据全部落地后删除对应的日志段, 进程重启时重放未删除的日志段
+
+var (
+	WriteBehindFlushThreshold = 10000 // 待写入的文档数达到阈值时立即写入
+
+	queueDepthGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "store",
+		Subsystem: "write_behind",
+		Name:      "queue_depth",
+		Help:      "等待写入数据库的文档更新数量",
+	})
+
+	lagGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "store",
+		Subsystem: "write_behind",
+		Name:      "lag_seconds",
+		Help:      "最早一条未写入数据库的更新距今的时间",
+	})
+
+	coalescedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "store",
+		Subsystem: "write_behind",
+		Name:      "coalesced_total",
+		Help:      "被合并的更新数量",
+	})
+
+	droppedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "store",
+		Subsystem: "write_behind",
+		Name:      "dropped_total",
+		Help:      "数据库拒绝写入而丢弃的更新数量",
+	})
+
+	flushCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "store",
+		Subsystem: "write_behind",
+		Name:      "flush_total",
+		Help:      "批量写入次数",
+	}, []string{"result"})
+
+	flushHistogram = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "store",
+		Subsystem: "write_behind",
+		Name:      "flush_duration_seconds",
+		Help:      "批量写入耗时",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 8),
+	})
+)
+
+type WriteBehindOptions struct {
+	JournalDir    string        // 日志目录, 为空时不写日志
+	JournalFsync  bool          // 每条日志写入后fsync
+	FlushInterval time.Duration // 批量写入间隔
+}
+
+type WriteBehindStats struct {
+	Depth int           `json:"depth"` // 等待写入的文档更新数量
+	Retry int           `json:"retry"` // 写入失败等待重试的文档更新数量, 包含在Depth中
+	Lag   time.Duration `json:"lag"`   // 最早一条未写入的更新距今的时间
+}
+
+// writeOp is a single write request, it is saved in journal with bson
+type writeOp struct {
+	Coll   string   `bson:"coll"`
+	Filter bson.Raw `bson:"filter"`
+	Set    bson.Raw `bson:"set,omitempty"`
+	Unset  []string `bson:"unset,omitempty"`
+	Update bson.Raw `bson:"update,omitempty"` // 无法合并的更新, 如$push, $pull
+	Delete bool     `bson:"delete,omitempty"`
+	Upsert bool     `bson:"upsert,omitempty"`
+	Time   int64    `bson:"time"`
+}
+
+func newWriteOp(coll string, filter bson.D) (*writeOp, error) {
+	raw, err := bson.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &writeOp{Coll: coll, Filter: raw, Time: time.Now().UnixNano()}, nil
+}
+
+// document key used to coalesce writes, use _id if exists
+func (op *writeOp) docKey() string {
+	if id, err := op.Filter.LookupErr("_id"); err == nil {
+		return op.Coll + "|" + string(rune(id.Type)) + string(id.Value)
+	}
+
+	return op.Coll + "|" + string(op.Filter)
+}
+
+// docKey returns key of the document found by filter, returns empty string if filter is invalid
+func docKey(coll string, filter bson.D) string {
+	op, err := newWriteOp(coll, filter)
+	if err != nil {
+		return ""
+	}
+
+	return op.docKey()
+}
+
+// deleting by _id only overrides all writes before
+func (op *writeOp) deleteById() bool {
+	if !op.Delete {
+		return false
+	}
+
+	elems, err := op.Filter.Elements()
+	return err == nil && len(elems) == 1 && elems[0].Key() == "_id"
+}
+
+type docWrite struct {
+	coll   string
+	filter bson.Raw
+	set    bson.D // values are bson.RawValue
+	unset  []string
+	update bson.Raw
+	delete bool
+	upsert bool
+}
+
+func newDocWrite(op *writeOp) *docWrite {
+	w := &docWrite{
+		coll:   op.Coll,
+		filter: op.Filter,
+		update: op.Update,
+		delete: op.Delete,
+		upsert: op.Upsert,
+	}
+
+	w.apply(op)
+	return w
+}
+
+func (w *docWrite) apply(op *writeOp) {
+	elems, _ := op.Set.Elements()
+	for _, e := range elems {
+		w.set = append(w.set, bson.E{Key: e.Key(), Value: e.Value()})
+	}
+
+	w.unset = append(w.unset, op.Unset...)
+	w.upsert = w.upsert || op.Upsert
+}
+
+func opPaths(op *writeOp) []string {
+	elems, _ := op.Set.Elements()
+	paths := make([]string, 0, len(elems)+len(op.Unset))
+	for _, e := range elems {
+		paths = append(paths, e.Key())
+	}
+
+	return append(paths, op.Unset...)
+}
+
+// overridden returns true if path q is the same or a child of path p
+func overridden(q, p string) bool {
+	return q == p || strings.HasPrefix(q, p+".")
+}
+
+// merge tries to coalesce op into this write
+func (w *docWrite) merge(op *writeOp) bool {
+	if w.delete || w.update != nil || op.Delete || op.Update != nil {
+		return false
+	}
+
+	if string(w.filter) != string(op.Filter) {
+		return false
+	}
+
+	paths := opPaths(op)
+
+	// 更新已有字段的子字段时无法合并
+	for _, p := range paths {
+		for _, e := range w.set {
+			if strings.HasPrefix(p, e.Key+".") {
+				return false
+			}
+		}
+
+		for _, q := range w.unset {
+			if strings.HasPrefix(p, q+".") {
+				return false
+			}
+		}
+	}
+
+	// remove overridden fields
+	isOverridden := func(q string) bool {
+		for _, p := range paths {
+			if overridden(q, p) {
+				return true
+			}
+		}
+		return false
+	}
+
+	set := w.set[:0]
+	for _, e := range w.set {
+		if !isOverridden(e.Key) {
+			set = append(set, e)
+		}
+	}
+	w.set = set
+
+	unset := w.unset[:0]
+	for _, q := range w.unset {
+		if !isOverridden(q) {
+			unset = append(unset, q)
+		}
+	}
+	w.unset = unset
+
+	w.apply(op)
+	return true
+}
+
+func (w *docWrite) model() mongo.WriteModel {
+	if w.delete {
+		return mongo.NewDeleteOneModel().SetFilter(w.filter)
+	}
+
+	var update any
+	if w.update != nil {
+		update = w.update
+	} else {
+		d := bson.D{}
+		if len(w.set) > 0 {
+			d = append(d, bson.E{Key: "$set", Value: w.set})
+		}
+
+		if len(w.unset) > 0 {
+			fields := make(bson.D, 0, len(w.unset))
+			for _, q := range w.unset {
+				fields = append(fields, bson.E{Key: q, Value: 1})
+			}
+			d = append(d, bson.E{Key: "$unset", Value: fields})
+		}
+
+		if len(d) == 0 {
+			return nil
+		}
+
+		update = d
+	}
+
+	return mongo.NewUpdateOneModel().SetFilter(w.filter).SetUpdate(update).SetUpsert(w.upsert)
+}
+
+// batch holds pending writes in order of documents
+type batch struct {
+	docs   map[string][]*docWrite
+	keys   []string
+	ops    int
+	writes int
+	oldest int64
+}
+
+func newBatch() *batch {
+	return &batch{docs: make(map[string][]*docWrite)}
+}
+
+func (b *batch) add(op *writeOp) {
+	key := op.docKey()
+	writes, ok := b.docs[key]
+	if !ok {
+		b.keys = append(b.keys, key)
+	}
+
+	switch {
+	case op.deleteById():
+		coalescedCounter.Add(float64(len(writes)))
+		b.writes -= len(writes)
+		writes = append(writes[:0], newDocWrite(op))
+		b.writes++
+
+	case len(writes) > 0 && writes[len(writes)-1].merge(op):
+		coalescedCounter.Inc()
+
+	default:
+		writes = append(writes, newDocWrite(op))
+		b.writes++
+	}
+
+	b.docs[key] = writes
+	b.ops++
+	if b.oldest == 0 || op.Time < b.oldest {
+		b.oldest = op.Time
+	}
+}
+
+func (b *batch) models() map[string][]mongo.WriteModel {
+	models := make(map[string][]mongo.WriteModel)
+	for _, key := range b.keys {
+		for _, w := range b.docs[key] {
+			if m := w.model(); m != nil {
+				models[w.coll] = append(models[w.coll], m)
+			}
+		}
+	}
+
+	return models
+}
+
+// take moves writes of the document into a new batch, oldest time of the new batch is not exact
+func (b *batch) take(key string) *batch {
+	t := newBatch()
+	writes, ok := b.docs[key]
+	if !ok {
+		return t
+	}
+
+	delete(b.docs, key)
+	for i, k := range b.keys {
+		if k == key {
+			b.keys = append(b.keys[:i], b.keys[i+1:]...)
+			break
+		}
+	}
+
+	b.writes -= len(writes)
+	t.docs[key] = writes
+	t.keys = append(t.keys, key)
+	t.writes = len(writes)
+	t.oldest = b.oldest
+	if b.writes == 0 {
+		b.oldest = 0
+	}
+
+	return t
+}
+
+type WriteBehind struct {
+	db       db.DB
+	journal  *journal.Journal
+	interval time.Duration
+
+	mu       sync.Mutex
+	pending  *batch
+	flushing *batch // 正在写入数据库的批次
+
+	flushMu     sync.Mutex
+	retry       map[string][]mongo.WriteModel
+	retryDepth  int64
+	retryOldest int64
+	sealed      uint64 // 已封存但数据还未全部写入数据库的日志段
+
+	notify chan struct{}
+	done   chan struct{}
+	utils.WaitGroupWrapper
+}
+
+func NewWriteBehind(d db.DB, opts *WriteBehindOptions) *WriteBehind {
+	w := &WriteBehind{
+		db:       d,
+		interval: opts.FlushInterval,
+		pending:  newBatch(),
+		retry:    make(map[string][]mongo.WriteModel),
+		notify:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+
+	if w.interval <= 0 {
+		w.interval = db.BulkWriteFlushLatency
+	}
+
+	if opts.JournalDir != "" {
+		w.openJournal(opts.JournalDir, opts.JournalFsync)
+	}
+
+	w.Wrap(w.run)
+	return w
+}
+
+// open journal and replay unflushed writes of last process
+func (w *WriteBehind) openJournal(dir string, fsync bool) {
+	j, err := journal.Open(dir, fsync)
+	if err != nil {
+		log.Fatal().Err(err).Str("dir", dir).Msg("open store journal failed")
+	}
+
+	w.journal = j
+	prev := j.Seq() - 1
+	err = j.Replay(prev, func(data []byte) error {
+		op := &writeOp{}
+		if err := bson.Unmarshal(data, op); err != nil {
+			return err
+		}
+
+		w.pending.add(op)
+		return nil
+	})
+
+	if err != nil {
+		log.Fatal().Err(err).Str("dir", dir).Msg("replay store journal failed")
+	}
+
+	if w.pending.ops == 0 {
+		utils.ErrPrint(j.Remove(prev), "remove empty journal segments failed", dir)
+		return
+	}
+
+	log.Info().Str("dir", dir).Int("ops", w.pending.ops).Int("writes", w.pending.writes).Msg("replay store journal")
+
+	w.sealed = prev
+	_, err = w.flush()
+	utils.ErrPrint(err, "flush replayed journal failed, it will be retried later", dir)
+}
+
+// Write appends op to journal and pending batch
+func (w *WriteBehind) Write(op *writeOp) error {
+	var data []byte
+	if w.journal != nil {
+		var err error
+		if data, err = bson.Marshal(op); err != nil {
+			return err
+		}
+	}
+
+	w.mu.Lock()
+	if w.journal != nil {
+		if err := w.journal.Append(data); err != nil {
+			w.mu.Unlock()
+			return err
+		}
+	}
+
+	w.pending.add(op)
+	writes := w.pending.writes
+	w.mu.Unlock()
+
+	if writes >= WriteBehindFlushThreshold {
+		select {
+		case w.notify <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (w *WriteBehind) run() {
+	defer utils.CaptureException()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+		case <-w.notify:
+		}
+
+		_, err := w.flush()
+		utils.ErrPrint(err, "write behind flush failed")
+	}
+}
+
+// flush writes retrying and pending writes into database, returns stats after flush and the last error
+func (w *WriteBehind) flush() (WriteBehindStats, error) {
+	w.flushMu.Lock()
+	defer w.flushMu.Unlock()
+
+	return w.flushLocked()
+}
+
+func (w *WriteBehind) flushLocked() (WriteBehindStats, error) {
+	start := time.Now()
+
+	w.mu.Lock()
+	b := w.pending
+	w.pending = newBatch()
+	w.flushing = b
+	if w.journal != nil && b.ops > 0 {
+		seq, err := w.journal.Rotate()
+		if utils.ErrCheck(err, "journal rotate failed when WriteBehind.flush") {
+			w.sealed = seq
+		}
+	}
+	w.mu.Unlock()
+
+	// 先写入上次失败的更新
+	models := w.retry
+	for coll, ms := range b.models() {
+		models[coll] = append(models[coll], ms...)
+	}
+
+	oldest := atomic.LoadInt64(&w.retryOldest)
+	if b.oldest > 0 && (oldest == 0 || b.oldest < oldest) {
+		oldest = b.oldest
+	}
+
+	var lastErr error
+	depth := 0
+	w.retry = make(map[string][]mongo.WriteModel)
+	for coll, ms := range models {
+		remain, err := w.writeModels(coll, ms)
+		if err != nil {
+			lastErr = err
+		}
+
+		if len(remain) > 0 {
+			w.retry[coll] = remain
+			depth += len(remain)
+		}
+	}
+	atomic.StoreInt64(&w.retryDepth, int64(depth))
+
+	w.mu.Lock()
+	w.flushing = nil
+	w.mu.Unlock()
+
+	if depth == 0 {
+		oldest = 0
+		if w.journal != nil && w.sealed > 0 {
+			utils.ErrPrint(w.journal.Remove(w.sealed), "journal remove failed when WriteBehind.flush", w.sealed)
+		}
+	}
+
+	atomic.StoreInt64(&w.retryOldest, oldest)
+
+	if len(models) > 0 {
+		result := "ok"
+		if lastErr != nil {
+			result = "error"
+		}
+		flushCounter.WithLabelValues(result).Inc()
+		flushHistogram.Observe(time.Since(start).Seconds())
+	}
+
+	return w.Stats(), lastErr
+}
+
+// flushDoc writes pending writes of one document into database, writes of other documents are still coalesced until next flush.
+// 日志段中的这些更新在下次flush全部落地后才删除, 重放时会再次写入, 所以更新需要是幂等的
+func (w *WriteBehind) flushDoc(key string) error {
+	w.flushMu.Lock()
+	defer w.flushMu.Unlock()
+
+	// 重试队列中的更新无法按文档区分, 为了保证写入顺序整体flush
+	if atomic.LoadInt64(&w.retryDepth) > 0 {
+		_, err := w.flushLocked()
+		return err
+	}
+
+	start := time.Now()
+
+	w.mu.Lock()
+	b := w.pending.take(key)
+	w.flushing = b
+	w.mu.Unlock()
+
+	models := b.models()
+
+	var lastErr error
+	depth := 0
+	for coll, ms := range models {
+		remain, err := w.writeModels(coll, ms)
+		if err != nil {
+			lastErr = err
+		}
+
+		if len(remain) > 0 {
+			w.retry[coll] = remain
+			depth += len(remain)
+		}
+	}
+
+	if depth > 0 {
+		atomic.StoreInt64(&w.retryDepth, int64(depth))
+		atomic.StoreInt64(&w.retryOldest, b.oldest)
+	}
+
+	w.mu.Lock()
+	w.flushing = nil
+	w.mu.Unlock()
+
+	if len(models) > 0 {
+		result := "ok"
+		if lastErr != nil {
+			result = "error"
+		}
+		flushCounter.WithLabelValues(result).Inc()
+		flushHistogram.Observe(time.Since(start).Seconds())
+	}
+
+	return lastErr
+}
+
+// writeModels returns models which should be retried later
+func (w *WriteBehind) writeModels(coll string, models []mongo.WriteModel) ([]mongo.WriteModel, error) {
+	var lastErr error
+	for len(models) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), db.DatabaseBulkWriteTimeout)
+		err := w.db.BulkWriteModels(ctx, coll, models)
+		cancel()
+
+		if err == nil {
+			return nil, lastErr
+		}
+
+		// 网络或者超时错误, 下次重试
+		var bwe mongo.BulkWriteException
+		if !errors.As(err, &bwe) || len(bwe.WriteErrors) == 0 {
+			return models, err
+		}
+
+		// 数据库拒绝的更新无法重试, 丢弃后继续写入后面的更新
+		idx := bwe.WriteErrors[0].Index
+		if idx < 0 || idx >= len(models) {
+			return models, err
+		}
+
+		log.Error().
+			Err(err).
+			Str("coll", coll).
+			Interface("model", models[idx]).
+			Msg("write behind drop rejected model")
+
+		droppedCounter.Inc()
+		lastErr = err
+		models = models[idx+1:]
+	}
+
+	return nil, lastErr
+}
+
+// dirty returns true if the document has writes which are not in database yet
+func (w *WriteBehind) dirty(coll string, filter bson.D) bool {
+	key := docKey(coll, filter)
+	if key == "" {
+		return true
+	}
+
+	w.mu.Lock()
+	_, pending := w.pending.docs[key]
+	flushing := w.flushing != nil && w.flushing.docs[key] != nil
+	w.mu.Unlock()
+
+	// 重试队列中的更新无法按文档区分, 有重试时都认为未落地
+	return pending || flushing || atomic.LoadInt64(&w.retryDepth) > 0
+}
+
+func (w *WriteBehind) Stats() WriteBehindStats {
+	w.mu.Lock()
+	depth := w.pending.writes
+	oldest := w.pending.oldest
+	w.mu.Unlock()
+
+	retry := int(atomic.LoadInt64(&w.retryDepth))
+	depth += retry
+	if ro := atomic.LoadInt64(&w.retryOldest); ro > 0 && (oldest == 0 || ro < oldest) {
+		oldest = ro
+	}
+
+	stats := WriteBehindStats{Depth: depth, Retry: retry}
+	if oldest > 0 {
+		stats.Lag = time.Since(time.Unix(0, oldest))
+	}
+
+	queueDepthGauge.Set(float64(stats.Depth))
+	lagGauge.Set(stats.Lag.Seconds())
+	return stats
+}
+
+func (w *WriteBehind) Exit() {
+	close(w.done)
+	w.Wait()
+
+	stats, err := w.flush()
+	if err != nil || stats.Depth > 0 {
+		log.Error().Err(err).Interface("stats", stats).Msg("write behind exit with unflushed writes, they will be replayed at next startup")
+	}
+
+	if w.journal != nil {
+		utils.ErrPrint(w.journal.Close(), "journal close failed")
+	}
+}
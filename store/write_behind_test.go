@@ -0,0 +1,333 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/east-eden/server/store/cache"
+	"github.com/east-eden/server/store/db"
+	"github.com/google/go-cmp/cmp"
+	"github.com/urfave/cli/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var errFakeNetwork = errors.New("fake network error")
+
+// fakeDB records bulk written models in extended json
+type fakeDB struct {
+	*db.DummyDB
+	fail    bool
+	written map[string][]string
+	sync.Mutex
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{
+		DummyDB: db.NewDummyDB().(*db.DummyDB),
+		written: make(map[string][]string),
+	}
+}
+
+func (f *fakeDB) BulkWriteModels(ctx context.Context, colName string, models []mongo.WriteModel) error {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.fail {
+		return errFakeNetwork
+	}
+
+	for _, m := range models {
+		var doc bson.D
+		switch v := m.(type) {
+		case *mongo.UpdateOneModel:
+			doc = bson.D{{Key: "filter", Value: v.Filter}, {Key: "update", Value: v.Update}, {Key: "upsert", Value: *v.Upsert}}
+		case *mongo.DeleteOneModel:
+			doc = bson.D{{Key: "filter", Value: v.Filter}, {Key: "delete", Value: true}}
+		}
+
+		data, _ := bson.MarshalExtJSON(doc, false, false)
+		f.written[colName] = append(f.written[colName], string(data))
+	}
+
+	return nil
+}
+
+func (f *fakeDB) models(colName string) []string {
+	f.Lock()
+	defer f.Unlock()
+	return f.written[colName]
+}
+
+type testPlayer struct {
+	Id    int64  `bson:"_id"`
+	Name  string `bson:"name"`
+	Level int32  `bson:"level"`
+}
+
+func newTestStore(t *testing.T, d db.DB, journalDir string) *defStore {
+	t.Helper()
+
+	s := &defStore{
+		cache:    cache.NewDummyRedis(&cache.Options{}),
+		db:       d,
+		done:     true,
+		infoList: make(map[int]*StoreInfo),
+		wb:       NewWriteBehind(d, &WriteBehindOptions{JournalDir: journalDir, FlushInterval: time.Hour}),
+	}
+	s.AddStoreInfo(1, "player", "_id")
+	return s
+}
+
+func TestWriteBehindCoalesce(t *testing.T) {
+	fdb := newFakeDB()
+	s := newTestStore(t, fdb, "")
+	defer s.wb.Exit()
+
+	ctx := context.Background()
+	_ = s.UpdateFields(ctx, 1, int64(1), map[string]any{"level": 2})
+	_ = s.UpdateFields(ctx, 1, int64(1), map[string]any{"level": 3, "name": "a"})
+	_ = s.UpdateFields(ctx, 1, int64(1), map[string]any{"hero.1.level": 5})
+	_ = s.DeleteFields(ctx, 1, int64(1), []string{"name"})
+
+	// child of a pending field can't be coalesced
+	_ = s.UpdateFields(ctx, 1, int64(1), map[string]any{"hero": bson.M{"1": bson.M{"level": 6}}})
+	_ = s.UpdateFields(ctx, 1, int64(1), map[string]any{"hero.1.exp": 10})
+
+	_ = s.UpdateFields(ctx, 1, int64(2), map[string]any{"level": 1})
+	_ = s.DeleteOne(ctx, 1, int64(2))
+
+	if stats := s.wb.Stats(); stats.Depth != 3 {
+		t.Fatalf("write behind depth should be 3, got %d", stats.Depth)
+	}
+
+	stats := s.Flush()
+	if stats.Depth != 0 || stats.Lag != 0 {
+		t.Fatalf("write behind should be empty after flush, got %+v", stats)
+	}
+
+	want := []string{
+		`{"filter":{"_id":1},"update":{"$set":{"level":3,"hero":{"1":{"level":6}}},"$unset":{"name":1}},"upsert":true}`,
+		`{"filter":{"_id":1},"update":{"$set":{"hero.1.exp":10}},"upsert":true}`,
+		`{"filter":{"_id":2},"delete":true}`,
+	}
+	if diff := cmp.Diff(want, fdb.models("player")); diff != "" {
+		t.Fatalf("written models mismatch: %s", diff)
+	}
+}
+
+func TestWriteBehindFlushDoc(t *testing.T) {
+	fdb := newFakeDB()
+	s := newTestStore(t, fdb, "")
+	defer s.wb.Exit()
+
+	ctx := context.Background()
+	_ = s.UpdateFields(ctx, 1, int64(1), map[string]any{"level": 2})
+	_ = s.UpdateFields(ctx, 1, int64(2), map[string]any{"level": 3})
+
+	// 立即写入只写入该文档的更新, 其他文档继续合并
+	_ = s.PullArray(ctx, 1, int64(1), "mail_list", int64(10))
+
+	want := []string{
+		`{"filter":{"_id":1},"update":{"$set":{"level":2}},"upsert":true}`,
+		`{"filter":{"_id":1},"update":{"$pull":{"mail_list":{"_id":10}}},"upsert":false}`,
+	}
+	if diff := cmp.Diff(want, fdb.models("player")); diff != "" {
+		t.Fatalf("written models mismatch: %s", diff)
+	}
+
+	if stats := s.wb.Stats(); stats.Depth != 1 {
+		t.Fatalf("write behind depth should be 1, got %d", stats.Depth)
+	}
+
+	_ = s.UpdateFields(ctx, 1, int64(2), map[string]any{"name": "b"})
+	s.Flush()
+
+	want = append(want, `{"filter":{"_id":2},"update":{"$set":{"level":3,"name":"b"}},"upsert":true}`)
+	if diff := cmp.Diff(want, fdb.models("player")); diff != "" {
+		t.Fatalf("written models mismatch: %s", diff)
+	}
+}
+
+func TestWriteBehindJournalReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	// database unavailable, writes are kept in journal
+	fdb := newFakeDB()
+	fdb.fail = true
+	s := newTestStore(t, fdb, dir)
+
+	p := &testPlayer{Id: 1, Name: "a", Level: 1}
+	_ = s.UpdateOne(context.Background(), 1, p.Id, p)
+	_ = s.UpdateFields(context.Background(), 1, p.Id, map[string]any{"level": 2})
+
+	if _, err := s.wb.flush(); !errors.Is(err, errFakeNetwork) {
+		t.Fatalf("flush should fail with network error, got %v", err)
+	}
+
+	if stats := s.wb.Stats(); stats.Depth != 1 || stats.Retry != 1 || stats.Lag <= 0 {
+		t.Fatalf("failed writes should be retried, got %+v", stats)
+	}
+
+	// crash without exit, restart with database available
+	fdb2 := newFakeDB()
+	s2 := newTestStore(t, fdb2, dir)
+	defer s2.wb.Exit()
+
+	want := []string{
+		`{"filter":{"_id":1},"update":{"$set":{"_id":1,"name":"a","level":2}},"upsert":true}`,
+	}
+	if diff := cmp.Diff(want, fdb2.models("player")); diff != "" {
+		t.Fatalf("replayed models mismatch: %s", diff)
+	}
+
+	segs, _ := s2.wb.journal.Segments()
+	if len(segs) != 1 {
+		t.Fatalf("flushed journal segments should be removed, got %v", segs)
+	}
+}
+
+func TestWriteBehindReplayArrayOps(t *testing.T) {
+	dir := t.TempDir()
+	mdb := db.NewMemDB()
+	s := newTestStore(t, mdb, dir)
+
+	ctx := context.Background()
+	_ = s.UpdateOne(ctx, 1, int64(1), &testPlayer{Id: 1, Name: "a", Level: 1})
+	_ = s.PushArray(ctx, 1, int64(1), "mail_list", bson.M{"_id": int64(10), "status": 0})
+	_ = s.PushArray(ctx, 1, int64(1), "mail_list", bson.M{"_id": int64(11), "status": 0})
+	_ = s.PushArray(ctx, 1, int64(1), "mail_list", bson.M{"_id": int64(12), "status": 0})
+	_ = s.PullArray(ctx, 1, int64(1), "mail_list", int64(11))
+	for seq := 1; seq <= 3; seq++ {
+		_ = s.PushArray(ctx, 1, int64(1), "history", bson.M{
+			"$each":  []bson.M{{"seq": seq}},
+			"$slice": -2,
+		})
+	}
+
+	// 数据已经写入数据库, 日志段还未删除时崩溃, 重启后重放的数组更新不能重复添加元素
+	s2 := newTestStore(t, mdb, dir)
+	defer s2.wb.Exit()
+
+	doc := loadDoc(t, mdb, "player", int64(1))
+	want := bson.M{
+		"_id":   int64(1),
+		"name":  "a",
+		"level": int32(1),
+		"mail_list": bson.A{
+			bson.M{"_id": int64(10), "status": int32(0)},
+			bson.M{"_id": int64(12), "status": int32(0)},
+		},
+		"history": bson.A{bson.M{"seq": int32(2)}, bson.M{"seq": int32(3)}},
+	}
+	if diff := cmp.Diff(want, doc); diff != "" {
+		t.Fatalf("replayed document mismatch: %s", diff)
+	}
+}
+
+func TestWriteBehindJournalNodeDir(t *testing.T) {
+	prev := GetStore()
+	defer SetStore(prev)
+
+	// 同一服务的两个节点使用相同配置
+	dir := t.TempDir()
+	set := flag.NewFlagSet("write_behind_test", flag.ContinueOnError)
+	set.String("store_journal_dir", dir, "")
+	ctx := cli.NewContext(nil, set, nil)
+
+	s1 := NewStore(ctx, DB(newFakeDB()), Node(1)).(*defStore)
+	defer s1.Exit()
+	s2 := NewStore(ctx, DB(newFakeDB()), Node(2)).(*defStore)
+	defer s2.Exit()
+
+	if s1.wb.journal == nil || s2.wb.journal == nil {
+		t.Fatal("journal should be opened")
+	}
+
+	for _, node := range []string{"1", "2"} {
+		entries, err := os.ReadDir(filepath.Join(dir, node))
+		if err != nil || len(entries) != 1 {
+			t.Fatalf("node %s journal dir entries %v, err %v", node, entries, err)
+		}
+	}
+}
+
+func TestWriteBehindFindOneAfterPartialUpdate(t *testing.T) {
+	s := newTestStore(t, db.NewMemDB(), "")
+	s.cache = cache.NewMiniRedis(&cache.Options{})
+	defer s.wb.Exit()
+
+	ctx := context.Background()
+	p := &testPlayer{Id: 1, Name: "a", Level: 1}
+	_ = s.UpdateOne(ctx, 1, p.Id, p)
+	s.Flush()
+
+	// 部分更新还未落地时读取, 不能把数据库中的旧数据缓存下来
+	_ = s.UpdateFields(ctx, 1, p.Id, map[string]any{"level": 2})
+
+	for n := 0; n < 2; n++ {
+		var loaded testPlayer
+		if err := s.FindOne(ctx, 1, p.Id, &loaded); err != nil {
+			t.Fatalf("FindOne failed: %v", err)
+		}
+
+		if loaded.Level != 2 {
+			t.Fatalf("FindOne should load partial update, got level %d", loaded.Level)
+		}
+	}
+
+	if stats := s.wb.Stats(); stats.Depth != 0 {
+		t.Fatalf("pending writes of the document should be flushed, got %+v", stats)
+	}
+}
+
+// failingDB fails bulk writes when fail is set
+type failingDB struct {
+	db.DB
+	fail bool
+}
+
+func (f *failingDB) BulkWriteModels(ctx context.Context, colName string, models []mongo.WriteModel) error {
+	if f.fail {
+		return errFakeNetwork
+	}
+
+	return f.DB.BulkWriteModels(ctx, colName, models)
+}
+
+func TestWriteBehindFindOneDirtySkipCache(t *testing.T) {
+	fdb := &failingDB{DB: db.NewMemDB()}
+	s := newTestStore(t, fdb, "")
+	s.cache = cache.NewMiniRedis(&cache.Options{})
+	defer s.wb.Exit()
+
+	ctx := context.Background()
+	p := &testPlayer{Id: 1, Name: "a", Level: 1}
+	_ = s.UpdateOne(ctx, 1, p.Id, p)
+	s.Flush()
+
+	// 数据库不可用, 更新进入重试队列, 读到的旧数据不能缓存
+	fdb.fail = true
+	_ = s.UpdateFields(ctx, 1, p.Id, map[string]any{"level": 2})
+
+	var loaded testPlayer
+	if err := s.FindOne(ctx, 1, p.Id, &loaded); err != nil {
+		t.Fatalf("FindOne failed: %v", err)
+	}
+
+	if err := s.cache.LoadObject(cacheName(s.infoList[1]), p.Id, &testPlayer{}); err == nil {
+		t.Fatal("dirty document should not be cached")
+	}
+
+	// 数据库恢复后读取会先写入重试队列中的更新
+	fdb.fail = false
+	if err := s.FindOne(ctx, 1, p.Id, &loaded); err != nil || loaded.Level != 2 {
+		t.Fatalf("FindOne should load retried update, got %+v, %v", loaded, err)
+	}
+}
@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strings"
+	"sync"
+
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var (
+	msgIdCache sync.Map // protoreflect.FullName -> uint32
+)
+
+// MessageID returns message id used in transport header
+func MessageID(m proto.Message) uint32 {
+	return DescriptorID(m.ProtoReflect().Descriptor())
+}
+
+// DescriptorID returns explicit id set by option (MsgId) if existed, otherwise crc32 of message name
+func DescriptorID(md protoreflect.MessageDescriptor) uint32 {
+	if id, ok := msgIdCache.Load(md.FullName()); ok {
+		return id.(uint32)
+	}
+
+	id := crc32.ChecksumIEEE([]byte(md.Name()))
+	if opts, ok := md.Options().(*descriptorpb.MessageOptions); ok && opts != nil {
+		if explicit := proto.GetExtension(opts, pbGlobal.E_MsgId).(uint32); explicit != 0 {
+			id = explicit
+		}
+	}
+
+	msgIdCache.Store(md.FullName(), id)
+	return id
+}
+
+// MessageIDs returns ids of all top level messages in packages registered,
+// returns error if any two messages have the same id
+func MessageIDs(pkgs ...protoreflect.FullName) (map[protoreflect.FullName]uint32, error) {
+	ids := make(map[protoreflect.FullName]uint32)
+	owners := make(map[uint32]protoreflect.FullName)
+
+	var err error
+	for _, pkg := range pkgs {
+		protoregistry.GlobalFiles.RangeFilesByPackage(pkg, func(fd protoreflect.FileDescriptor) bool {
+			msgs := fd.Messages()
+			for n := 0; n < msgs.Len(); n++ {
+				md := msgs.Get(n)
+				id := DescriptorID(md)
+				if owner, ok := owners[id]; ok {
+					err = fmt.Errorf("message id<%d> collision between %s and %s", id, owner, md.FullName())
+					return false
+				}
+
+				owners[id] = md.FullName()
+				ids[md.FullName()] = id
+			}
+			return true
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+// ManifestVersion returns crc32 of manifest sorted by message name
+func ManifestVersion(manifest map[string]uint32) uint32 {
+	names := make([]string, 0, len(manifest))
+	for name := range manifest {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s=%d\n", name, manifest[name])
+	}
+
+	return crc32.ChecksumIEEE([]byte(b.String()))
+}
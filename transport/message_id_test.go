@@ -0,0 +1,92 @@
+package transport
+
+import (
+	"hash/crc32"
+	"testing"
+
+	pbGlobal "github.com/east-eden/server/proto/global"
+	_ "github.com/east-eden/server/proto/server/chat"
+	_ "github.com/east-eden/server/proto/server/combat"
+	_ "github.com/east-eden/server/proto/server/comment"
+	_ "github.com/east-eden/server/proto/server/game"
+	_ "github.com/east-eden/server/proto/server/gate"
+	_ "github.com/east-eden/server/proto/server/mail"
+	_ "github.com/east-eden/server/proto/server/pubsub"
+	_ "github.com/east-eden/server/proto/server/rank"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// manifest should be regenerated by cmd/proto_manifest when any message changed
+func TestManifest(t *testing.T) {
+	ids, err := MessageIDs("proto", "chat", "combat", "comment", "game", "gate", "mail", "pubsub", "rank")
+	if err != nil {
+		t.Fatalf("message ids collision: %v", err)
+	}
+
+	var count int
+	for fullName, id := range ids {
+		if fullName.Parent() != "proto" {
+			continue
+		}
+
+		count++
+		if pbGlobal.Manifest[string(fullName.Name())] != id {
+			t.Fatalf("message<%s> id<%d> not matched with manifest, run make proto_manifest_gen", fullName, id)
+		}
+	}
+
+	if count != len(pbGlobal.Manifest) {
+		t.Fatalf("manifest size<%d> not matched with messages<%d>, run make proto_manifest_gen", len(pbGlobal.Manifest), count)
+	}
+
+	if ManifestVersion(pbGlobal.Manifest) != pbGlobal.ManifestVersion {
+		t.Fatalf("manifest version not matched, run make proto_manifest_gen")
+	}
+}
+
+func TestMessageID(t *testing.T) {
+	if id := MessageID(&pbGlobal.C2S_Ping{}); id != crc32.ChecksumIEEE([]byte("C2S_Ping")) {
+		t.Fatalf("message id should be crc32 of name by default, got %d", id)
+	}
+
+	// message with explicit id option
+	opts := &descriptorpb.MessageOptions{}
+	proto.SetExtension(opts, pbGlobal.E_MsgId, uint32(1001))
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("message_id_test.proto"),
+		Package: proto.String("message_id_test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Explicit"), Options: opts},
+			{Name: proto.String("Implicit")},
+		},
+	}
+
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatalf("new file descriptor failed: %v", err)
+	}
+
+	var md protoreflect.MessageDescriptor = fd.Messages().ByName("Explicit")
+	if id := DescriptorID(md); id != 1001 {
+		t.Fatalf("explicit message id should be 1001, got %d", id)
+	}
+
+	md = fd.Messages().ByName("Implicit")
+	if id := DescriptorID(md); id != crc32.ChecksumIEEE([]byte("Implicit")) {
+		t.Fatalf("implicit message id should be crc32 of name, got %d", id)
+	}
+}
+
+func TestManifestVersion(t *testing.T) {
+	m := map[string]uint32{"A": 1, "B": 2}
+	v := ManifestVersion(m)
+
+	m["B"] = 3
+	if ManifestVersion(m) == v {
+		t.Fatalf("manifest version should change when message id changed")
+	}
+}
@@ -24,6 +24,9 @@ type Options struct {
 	TLSConfig *tls.Config
 	// Timeout sets the timeout for Send/Recv
 	Timeout time.Duration
+	// Session enables per-packet encryption and compression,
+	// both sides of the connection must be set with the same options
+	Session *SessionOptions
 	// Other options for implementations of the interface
 	// can be stored in a context
 	Context context.Context
@@ -99,6 +102,27 @@ func TLSConfig(t *tls.Config) Option {
 	}
 }
 
+// Encrypt message bodies with cipher negotiated by x25519 key exchange
+func Encrypt(tp CipherType) Option {
+	return func(o *Options) {
+		if o.Session == nil {
+			o.Session = &SessionOptions{CompressThreshold: DefaultCompressThreshold}
+		}
+		o.Session.Cipher = tp
+	}
+}
+
+// Compress message bodies whose size is greater than threshold
+func Compress(tp CompressType, threshold int) Option {
+	return func(o *Options) {
+		if o.Session == nil {
+			o.Session = &SessionOptions{CompressThreshold: DefaultCompressThreshold}
+		}
+		o.Session.Compress = tp
+		o.Session.CompressThreshold = threshold
+	}
+}
+
 // Indicates whether this is a streaming connection
 func WithStream() DialOption {
 	return func(o *DialOptions) {
@@ -26,7 +26,7 @@ type defaultTransportRegister struct {
 
 func (t *defaultTransportRegister) RegisterProtobufMessage(p proto.Message, f MessageFunc) error {
 	protoName := p.ProtoReflect().Descriptor().Name()
-	id := crc32.ChecksumIEEE([]byte(protoName))
+	id := MessageID(p)
 	if _, ok := t.msgHandler[id]; ok {
 		return fmt.Errorf("register protobuf message name existed:%s", protoName)
 	}
@@ -0,0 +1,376 @@
+package transport
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// 会话层:
+// 连接建立后双方交换hello包(cipher + compress + x25519公钥), 协商出收发两个方向的密钥,
+// 之后每个消息包的body先按阈值压缩, 再加密, 包头的长度和name crc保持明文
+
+type CipherType int8
+type CompressType int8
+
+const (
+	CipherNone     CipherType = iota // 不加密
+	CipherAesGcm                     // AES-256-GCM
+	CipherChacha20                   // ChaCha20-Poly1305
+)
+
+const (
+	CompressNone   CompressType = iota // 不压缩
+	CompressSnappy                     // snappy
+	CompressZstd                       // zstd
+)
+
+const (
+	sessionHelloSize     = 2 + curve25519.PointSize // 1 byte cipher + 1 byte compress + public key
+	sessionFlagCompress  = 0x01                     // body flag: compressed
+	sessionKeySize       = 32
+	sessionNonceSize     = 12
+	sessionKeyInfoClient = "east-eden session client to server"
+	sessionKeyInfoServer = "east-eden session server to client"
+)
+
+var (
+	DefaultCompressThreshold = 512 // body大于512字节才压缩
+
+	ErrSessionHelloInvalid = errors.New("session hello invalid")
+	ErrSessionMismatch     = errors.New("session cipher or compress type mismatch")
+	ErrSessionDecrypt      = errors.New("session decrypt failed")
+	ErrSessionDecompress   = errors.New("session decompress failed")
+
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+)
+
+func ParseCipherType(s string) (CipherType, error) {
+	switch s {
+	case "", "none":
+		return CipherNone, nil
+	case "aes-gcm":
+		return CipherAesGcm, nil
+	case "chacha20":
+		return CipherChacha20, nil
+	default:
+		return CipherNone, fmt.Errorf("unknown cipher type: %s", s)
+	}
+}
+
+func ParseCompressType(s string) (CompressType, error) {
+	switch s {
+	case "", "none":
+		return CompressNone, nil
+	case "snappy":
+		return CompressSnappy, nil
+	case "zstd":
+		return CompressZstd, nil
+	default:
+		return CompressNone, fmt.Errorf("unknown compress type: %s", s)
+	}
+}
+
+type SessionOptions struct {
+	Cipher            CipherType
+	Compress          CompressType
+	CompressThreshold int // body size greater than threshold will be compressed
+}
+
+// session layer is disabled when neither cipher nor compress is set
+func (o *SessionOptions) Enabled() bool {
+	return o != nil && (o.Cipher != CipherNone || o.Compress != CompressNone)
+}
+
+// Session wraps message bodies of one connection, all methods are safe on nil session
+type Session struct {
+	opts     SessionOptions
+	isClient bool
+	priv     []byte
+	pub      []byte
+
+	once         sync.Once
+	handshakeErr error
+
+	sendMu   sync.Mutex
+	sendAead cipher.AEAD
+	sendSeq  uint64
+
+	recvMu   sync.Mutex
+	recvAead cipher.AEAD
+	recvSeq  uint64
+}
+
+// NewSession returns nil if session layer is disabled in options
+func NewSession(opts *SessionOptions, isClient bool) (*Session, error) {
+	if !opts.Enabled() {
+		return nil, nil
+	}
+
+	s := &Session{
+		opts:     *opts,
+		isClient: isClient,
+		priv:     make([]byte, curve25519.ScalarSize),
+	}
+
+	if _, err := io.ReadFull(rand.Reader, s.priv); err != nil {
+		return nil, err
+	}
+
+	var err error
+	s.pub, err = curve25519.X25519(s.priv, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Hello returns hello packet with 4 bytes length header
+func (s *Session) Hello() []byte {
+	hello := make([]byte, 4+sessionHelloSize)
+	binary.LittleEndian.PutUint32(hello[:4], sessionHelloSize)
+	hello[4] = byte(s.opts.Cipher)
+	hello[5] = byte(s.opts.Compress)
+	copy(hello[6:], s.pub)
+	return hello
+}
+
+// Establish derives send and recv keys from peer's hello body
+func (s *Session) Establish(peer []byte) error {
+	if len(peer) != sessionHelloSize {
+		return ErrSessionHelloInvalid
+	}
+
+	if CipherType(peer[0]) != s.opts.Cipher || CompressType(peer[1]) != s.opts.Compress {
+		return ErrSessionMismatch
+	}
+
+	if s.opts.Cipher == CipherNone {
+		return nil
+	}
+
+	shared, err := curve25519.X25519(s.priv, peer[2:])
+	if err != nil {
+		return fmt.Errorf("Session.Establish failed: %w", err)
+	}
+
+	clientAead, err := s.newAead(shared, sessionKeyInfoClient)
+	if err != nil {
+		return err
+	}
+
+	serverAead, err := s.newAead(shared, sessionKeyInfoServer)
+	if err != nil {
+		return err
+	}
+
+	if s.isClient {
+		s.sendAead, s.recvAead = clientAead, serverAead
+	} else {
+		s.sendAead, s.recvAead = serverAead, clientAead
+	}
+
+	return nil
+}
+
+func (s *Session) newAead(shared []byte, info string) (cipher.AEAD, error) {
+	key := make([]byte, sessionKeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, nil, []byte(info)), key); err != nil {
+		return nil, err
+	}
+
+	switch s.opts.Cipher {
+	case CipherAesGcm:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+
+	case CipherChacha20:
+		return chacha20poly1305.New(key)
+
+	default:
+		return nil, fmt.Errorf("unknown cipher type: %d", s.opts.Cipher)
+	}
+}
+
+// Handshake exchanges hello packets only once, fn sends our hello and returns peer's hello body
+func (s *Session) Handshake(fn func(hello []byte) ([]byte, error)) error {
+	if s == nil {
+		return nil
+	}
+
+	s.once.Do(func() {
+		peer, err := fn(s.Hello())
+		if err != nil {
+			s.handshakeErr = fmt.Errorf("Session.Handshake failed: %w", err)
+			return
+		}
+
+		s.handshakeErr = s.Establish(peer)
+	})
+
+	return s.handshakeErr
+}
+
+// Send seals body and calls fn under send lock, so that nonce sequence is the same as wire order
+func (s *Session) Send(nameCrc uint32, body []byte, fn func(body []byte) error) error {
+	if s == nil {
+		return fn(body)
+	}
+
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	data, err := s.seal(nameCrc, body)
+	if err != nil {
+		return err
+	}
+
+	return fn(data)
+}
+
+func (s *Session) seal(nameCrc uint32, body []byte) ([]byte, error) {
+	var flag byte
+	if s.opts.Compress != CompressNone && len(body) > s.opts.CompressThreshold {
+		if compressed := compress(s.opts.Compress, body); len(compressed) < len(body) {
+			flag |= sessionFlagCompress
+			body = compressed
+		}
+	}
+
+	data := make([]byte, 0, 1+len(body)+16)
+	data = append(data, flag)
+	data = append(data, body...)
+
+	if s.sendAead == nil {
+		return data, nil
+	}
+
+	nonce, ad := sessionNonce(s.sendSeq), sessionAd(nameCrc)
+	s.sendSeq++
+	return s.sendAead.Seal(data[:0], nonce, data, ad), nil
+}
+
+// Open decrypts and decompresses body received
+func (s *Session) Open(nameCrc uint32, data []byte) ([]byte, error) {
+	if s == nil {
+		return data, nil
+	}
+
+	s.recvMu.Lock()
+	defer s.recvMu.Unlock()
+
+	if s.recvAead != nil {
+		nonce, ad := sessionNonce(s.recvSeq), sessionAd(nameCrc)
+		s.recvSeq++
+
+		var err error
+		data, err = s.recvAead.Open(data[:0], nonce, data, ad)
+		if err != nil {
+			return nil, ErrSessionDecrypt
+		}
+	}
+
+	if len(data) < 1 {
+		return nil, ErrSessionDecrypt
+	}
+
+	if data[0]&sessionFlagCompress == 0 {
+		return data[1:], nil
+	}
+
+	return decompress(s.opts.Compress, data[1:])
+}
+
+func sessionNonce(seq uint64) []byte {
+	nonce := make([]byte, sessionNonceSize)
+	binary.LittleEndian.PutUint64(nonce[4:], seq)
+	return nonce
+}
+
+func sessionAd(nameCrc uint32) []byte {
+	ad := make([]byte, 4)
+	binary.LittleEndian.PutUint32(ad, nameCrc)
+	return ad
+}
+
+// readSessionHello reads peer's hello packet from stream
+func readSessionHello(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	if binary.LittleEndian.Uint32(header[:]) != sessionHelloSize {
+		return nil, ErrSessionHelloInvalid
+	}
+
+	peer := make([]byte, sessionHelloSize)
+	if _, err := io.ReadFull(r, peer); err != nil {
+		return nil, err
+	}
+
+	return peer, nil
+}
+
+func initZstd() {
+	zstdOnce.Do(func() {
+		zstdEncoder, _ = zstd.NewWriter(nil)
+		zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(uint64(TcpPacketMaxSize)))
+	})
+}
+
+func compress(tp CompressType, body []byte) []byte {
+	switch tp {
+	case CompressSnappy:
+		return snappy.Encode(nil, body)
+	case CompressZstd:
+		initZstd()
+		return zstdEncoder.EncodeAll(body, nil)
+	default:
+		return body
+	}
+}
+
+func decompress(tp CompressType, data []byte) ([]byte, error) {
+	switch tp {
+	case CompressSnappy:
+		if n, err := snappy.DecodedLen(data); err != nil || n > int(TcpPacketMaxSize) {
+			return nil, ErrSessionDecompress
+		}
+
+		body, err := snappy.Decode(nil, data)
+		if err != nil {
+			return nil, ErrSessionDecompress
+		}
+		return body, nil
+
+	case CompressZstd:
+		initZstd()
+		body, err := zstdDecoder.DecodeAll(data, nil)
+		if err != nil || len(body) > int(TcpPacketMaxSize) {
+			return nil, ErrSessionDecompress
+		}
+		return body, nil
+
+	default:
+		return nil, ErrSessionDecompress
+	}
+}
@@ -0,0 +1,178 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	pbGlobal "github.com/east-eden/server/proto/global"
+	"google.golang.org/protobuf/proto"
+)
+
+func newSessionPair(t *testing.T, opts *SessionOptions) (*Session, *Session) {
+	cli, err := NewSession(opts, true)
+	if err != nil {
+		t.Fatalf("new client session failed: %v", err)
+	}
+
+	srv, err := NewSession(opts, false)
+	if err != nil {
+		t.Fatalf("new server session failed: %v", err)
+	}
+
+	if err := cli.Establish(srv.Hello()[4:]); err != nil {
+		t.Fatalf("client establish failed: %v", err)
+	}
+
+	if err := srv.Establish(cli.Hello()[4:]); err != nil {
+		t.Fatalf("server establish failed: %v", err)
+	}
+
+	return cli, srv
+}
+
+func TestSessionSealOpen(t *testing.T) {
+	ciphers := []CipherType{CipherNone, CipherAesGcm, CipherChacha20}
+	compresses := []CompressType{CompressNone, CompressSnappy, CompressZstd}
+	bodies := [][]byte{
+		[]byte("short body"),
+		bytes.Repeat([]byte("long body to be compressed "), 100),
+	}
+
+	for _, cipher := range ciphers {
+		for _, compress := range compresses {
+			opts := &SessionOptions{Cipher: cipher, Compress: compress, CompressThreshold: DefaultCompressThreshold}
+			if !opts.Enabled() {
+				if s, _ := NewSession(opts, true); s != nil {
+					t.Fatalf("session should be nil when disabled")
+				}
+				continue
+			}
+
+			cli, srv := newSessionPair(t, opts)
+			for _, body := range bodies {
+				var sealed []byte
+				_ = cli.Send(1, body, func(data []byte) error {
+					sealed = append([]byte{}, data...)
+					return nil
+				})
+
+				opened, err := srv.Open(1, sealed)
+				if err != nil {
+					t.Fatalf("cipher<%d> compress<%d> open failed: %v", cipher, compress, err)
+				}
+
+				if !bytes.Equal(opened, body) {
+					t.Fatalf("cipher<%d> compress<%d> open result mismatch", cipher, compress)
+				}
+			}
+		}
+	}
+}
+
+func TestSessionTampered(t *testing.T) {
+	cli, srv := newSessionPair(t, &SessionOptions{Cipher: CipherAesGcm})
+
+	var sealed []byte
+	_ = cli.Send(1, []byte("body"), func(data []byte) error {
+		sealed = append([]byte{}, data...)
+		return nil
+	})
+
+	// name crc is authenticated
+	if _, err := srv.Open(2, sealed); !errors.Is(err, ErrSessionDecrypt) {
+		t.Fatalf("open with another name crc should fail, got %v", err)
+	}
+
+	// mismatch options
+	other, _ := NewSession(&SessionOptions{Cipher: CipherChacha20}, false)
+	if err := other.Establish(cli.Hello()[4:]); !errors.Is(err, ErrSessionMismatch) {
+		t.Fatalf("establish with mismatch options should fail, got %v", err)
+	}
+}
+
+func TestTransportTcpSession(t *testing.T) {
+	reg := NewTransportRegister()
+	_ = reg.RegisterProtobufMessage(&pbGlobal.C2S_AccountLogon{}, func(ctx context.Context, sock Socket, p proto.Message) error {
+		msg := p.(*pbGlobal.C2S_AccountLogon)
+		return sock.Send(&pbGlobal.S2C_AccountLogon{PlayerName: msg.AccountName})
+	})
+	_ = reg.RegisterProtobufMessage(&pbGlobal.S2C_AccountLogon{}, func(context.Context, Socket, proto.Message) error {
+		return nil
+	})
+
+	opts := []Option{
+		Timeout(DefaultDialTimeout),
+		Encrypt(CipherChacha20),
+		Compress(CompressZstd, 64),
+	}
+
+	trSrv := NewTransport("tcp")
+	trSrv.Init(opts...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = trSrv.ListenAndServe(ctx, ":7031", &sessionServer{reg: reg})
+	}()
+
+	time.Sleep(time.Millisecond * 500)
+
+	trCli := NewTransport("tcp")
+	trCli.Init(opts...)
+	sock, err := trCli.Dial("127.0.0.1:7031")
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer sock.Close()
+
+	name := strings.Repeat("test_name", 20)
+	if err := sock.Send(&pbGlobal.C2S_AccountLogon{UserId: "1", AccountName: name}); err != nil {
+		t.Fatalf("send failed: %v", err)
+	}
+
+	msg, _, err := sock.Recv(reg)
+	if err != nil {
+		t.Fatalf("recv failed: %v", err)
+	}
+
+	if msg.(*pbGlobal.S2C_AccountLogon).PlayerName != name {
+		t.Fatalf("recv message mismatch: %v", msg)
+	}
+
+	// plaintext client cannot talk to session server
+	trPlain := NewTransport("tcp")
+	trPlain.Init(Timeout(time.Second))
+	plain, err := trPlain.Dial("127.0.0.1:7031")
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer plain.Close()
+
+	_ = plain.Send(&pbGlobal.C2S_AccountLogon{UserId: "1", AccountName: name})
+	if _, _, err := plain.Recv(reg); err == nil {
+		t.Fatalf("plaintext client should not receive reply")
+	}
+}
+
+type sessionServer struct {
+	reg Register
+}
+
+func (s *sessionServer) HandleSocket(ctx context.Context, sock Socket) {
+	go func() {
+		defer sock.Close()
+
+		for {
+			msg, h, err := sock.Recv(s.reg)
+			if err != nil {
+				return
+			}
+
+			_ = h.Fn(ctx, sock, msg)
+		}
+	}()
+}
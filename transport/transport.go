@@ -12,9 +12,10 @@ import (
 )
 
 var (
-	ErrTransportPacketTooLong          = errors.New("transport send packet too long")
-	ErrTransportReadSizeTooLong        = errors.New("transport recv msg length > 1MB")
-	TcpPacketMaxSize            uint32 = 1024 * 1024 // 单个tcp包数据上限
+	ErrTransportPacketTooLong           = errors.New("transport send packet too long")
+	ErrTransportReadSizeTooLong         = errors.New("transport recv msg length > 1MB")
+	ErrTransportReadSizeTooShort        = errors.New("transport recv msg length < 4")
+	TcpPacketMaxSize             uint32 = 1024 * 1024 // 单个tcp包数据上限
 )
 
 const (
@@ -55,6 +56,11 @@ type MessageHandler struct {
 type Socket interface {
 	Recv(Register) (proto.Message, *MessageHandler, error)
 	Send(proto.Message) error
+
+	// frame with name crc and body bytes, decrypted and decompressed by session layer
+	RecvFrame() (uint32, []byte, error)
+	SendFrame(uint32, []byte) error
+
 	Close()
 	IsClosed() bool
 	Local() string
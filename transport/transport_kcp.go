@@ -8,7 +8,6 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"hash/crc32"
 	"io"
 	"net"
 	"time"
@@ -80,14 +79,28 @@ func (t *kcpTransport) Dial(addr string, opts ...DialOption) (Socket, error) {
 		return nil, err
 	}
 
-	return &kcpTransportSocket{
+	sock := &kcpTransportSocket{
 		conn:    conn,
 		writer:  writer.NewBinaryWriter(bufio.NewWriterSize(conn, writer.DefaultBinaryWriterSize), -1),
 		reader:  bufio.NewReader(conn),
 		codecs:  []codec.Marshaler{&codec.ProtoBufMarshaler{}, &codec.JsonMarshaler{}},
 		timeout: t.opts.Timeout,
 		closed:  *atomic.NewBool(false),
-	}, nil
+	}
+
+	// session layer handshake
+	if sock.session, err = NewSession(t.opts.Session, true); err != nil {
+		sock.Close()
+		return nil, err
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(dopts.Timeout))
+	if err := sock.session.Handshake(sock.exchangeHello); err != nil {
+		sock.Close()
+		return nil, err
+	}
+
+	return sock, nil
 }
 
 func (t *kcpTransport) ListenAndServe(ctx context.Context, addr string, server TransportServer, opts ...ListenOption) error {
@@ -152,6 +165,7 @@ func (t *kcpTransport) Listen(addr string, opts ...ListenOption) (Listener, erro
 	ls := &kcpTransportListener{
 		opts:     options,
 		timeout:  t.opts.Timeout,
+		session:  t.opts.Session,
 		listener: l,
 	}
 
@@ -164,6 +178,7 @@ type kcpTransportListener struct {
 	opts     *ListenOptions
 	listener net.Listener
 	timeout  time.Duration
+	session  *SessionOptions
 	// sockPool sync.Pool
 }
 
@@ -214,6 +229,13 @@ func (t *kcpTransportListener) Accept(ctx context.Context, server TransportServe
 		sock.timeout = t.timeout
 		sock.closed.Store(false)
 
+		// session layer handshake is done lazily in socket's first Recv or Send
+		if sock.session, err = NewSession(t.session, false); err != nil {
+			fmt.Printf("kcp: NewSession error: %v\n", err)
+			sock.Close()
+			continue
+		}
+
 		server.HandleSocket(ctx, sock)
 	}
 }
@@ -225,6 +247,7 @@ type kcpTransportSocket struct {
 	codecs  []codec.Marshaler
 	timeout time.Duration
 	closed  atomic.Bool
+	session *Session
 }
 
 func (t *kcpTransportSocket) Local() string {
@@ -254,24 +277,48 @@ func (t *kcpTransportSocket) PbMarshaler() codec.Marshaler {
 }
 
 func (t *kcpTransportSocket) Recv(r Register) (proto.Message, *MessageHandler, error) {
+	nameCrc, bodyData, err := t.RecvFrame()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// get register handler
+	h, err := r.GetHandler(nameCrc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kcpTransportSocket.Recv failed: %w", err)
+	}
+
+	message, err := t.codecs[0].Unmarshal(bodyData, h.RType)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kcpTransportSocket.Recv unmarshal message body failed: %w", err)
+	}
+
+	return message.(proto.Message), h, err
+}
+
+func (t *kcpTransportSocket) RecvFrame() (uint32, []byte, error) {
 	if t.IsClosed() {
-		return nil, nil, errors.New("tcpTransportSocket.Recv failed: socket closed")
+		return 0, nil, errors.New("kcpTransportSocket.RecvFrame failed: socket closed")
 	}
 
 	// set timeout if its greater than 0
 	if t.timeout > time.Duration(0) {
 		if err := t.conn.SetDeadline(time.Now().Add(t.timeout)); err != nil {
-			return nil, nil, err
+			return 0, nil, err
 		}
 	}
 
+	if err := t.session.Handshake(t.exchangeHello); err != nil {
+		return 0, nil, fmt.Errorf("kcpTransportSocket.RecvFrame failed: %w", err)
+	}
+
 	// Message Header:
 	// 4 bytes message size, size = 4 bytes name crc + proto binary size
-	// 4 bytes message name crc32 id,
+	// 4 bytes message id, crc32 of message name or explicit id,
 	// Message Body:
 	var header [8]byte
 	if _, err := io.ReadFull(t.reader, header[:]); err != nil {
-		return nil, nil, fmt.Errorf("tcpTransportSocket.Recv header failed: %w", err)
+		return 0, nil, fmt.Errorf("kcpTransportSocket.RecvFrame header failed: %w", err)
 	}
 
 	var msgLen uint32
@@ -280,30 +327,37 @@ func (t *kcpTransportSocket) Recv(r Register) (proto.Message, *MessageHandler, e
 	nameCrc = binary.LittleEndian.Uint32(header[4:8])
 
 	if msgLen > TcpPacketMaxSize {
-		return nil, nil, ErrTransportReadSizeTooLong
+		return 0, nil, ErrTransportReadSizeTooLong
+	}
+
+	if msgLen < 4 {
+		return 0, nil, ErrTransportReadSizeTooShort
 	}
 
 	// read body bytes
 	bodyData := make([]byte, msgLen-4)
 	if _, err := io.ReadFull(t.reader, bodyData); err != nil {
-		return nil, nil, fmt.Errorf("tcpTransportSocket.Recv body failed: %w", err)
+		return 0, nil, fmt.Errorf("kcpTransportSocket.RecvFrame body failed: %w", err)
 	}
 
-	// get register handler
-	h, err := r.GetHandler(nameCrc)
+	bodyData, err := t.session.Open(nameCrc, bodyData)
 	if err != nil {
-		return nil, nil, fmt.Errorf("tcpTransportSocket.Recv failed: %w", err)
+		return 0, nil, fmt.Errorf("kcpTransportSocket.RecvFrame failed: %w", err)
 	}
 
-	message, err := t.codecs[0].Unmarshal(bodyData, h.RType)
+	return nameCrc, bodyData, nil
+}
+
+func (t *kcpTransportSocket) Send(m proto.Message) error {
+	body, err := t.codecs[0].Marshal(m)
 	if err != nil {
-		return nil, nil, fmt.Errorf("tcpTransportSocket.Recv unmarshal message body failed: %w", err)
+		return err
 	}
 
-	return message.(proto.Message), h, err
+	return t.SendFrame(MessageID(m), body)
 }
 
-func (t *kcpTransportSocket) Send(m proto.Message) error {
+func (t *kcpTransportSocket) SendFrame(nameCrc uint32, body []byte) error {
 	// set timeout if its greater than 0
 	if t.timeout > time.Duration(0) {
 		if err := t.conn.SetDeadline(time.Now().Add(t.timeout)); err != nil {
@@ -311,28 +365,36 @@ func (t *kcpTransportSocket) Send(m proto.Message) error {
 		}
 	}
 
-	body, err := t.codecs[0].Marshal(m)
-	if err != nil {
+	if err := t.session.Handshake(t.exchangeHello); err != nil {
+		return fmt.Errorf("kcpTransportSocket.SendFrame failed: %w", err)
+	}
+
+	return t.session.Send(nameCrc, body, func(body []byte) error {
+		// Message Header:
+		// 4 bytes message size, size = 4 bytes name crc + proto binary size
+		// 4 bytes message id, crc32 of message name or explicit id,
+		// Message Body:
+		var bodySize uint32 = uint32(4 + len(body))
+		buffer := bytebufferpool.Get()
+		defer bytebufferpool.Put(buffer)
+
+		_ = binary.Write(buffer, binary.LittleEndian, bodySize)
+		_ = binary.Write(buffer, binary.LittleEndian, nameCrc)
+		_, _ = buffer.Write(body)
+
+		// todo add a writer buffer, cache bytes which didn't sended, then try resend
+		_, err := t.writer.Write(buffer.Bytes())
 		return err
+	})
+}
+
+// send our hello directly to conn and read peer's hello
+func (t *kcpTransportSocket) exchangeHello(hello []byte) ([]byte, error) {
+	if _, err := t.conn.Write(hello); err != nil {
+		return nil, err
 	}
 
-	// Message Header:
-	// 4 bytes message size, size = 4 bytes name crc + proto binary size
-	// 4 bytes message name crc32 id,
-	// Message Body:
-	var bodySize uint32 = uint32(4 + len(body))
-	name := m.ProtoReflect().Descriptor().Name()
-	var nameCrc uint32 = crc32.ChecksumIEEE([]byte(name))
-	buffer := bytebufferpool.Get()
-	defer bytebufferpool.Put(buffer)
-
-	_ = binary.Write(buffer, binary.LittleEndian, bodySize)
-	_ = binary.Write(buffer, binary.LittleEndian, uint32(nameCrc))
-	_, _ = buffer.Write(body)
-
-	// todo add a writer buffer, cache bytes which didn't sended, then try resend
-	_, err = t.writer.Write(buffer.Bytes())
-	return err
+	return readSessionHello(t.reader)
 }
 
 // io.Writer
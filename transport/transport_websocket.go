@@ -2,11 +2,11 @@
 package transport
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"hash/crc32"
 	"net/http"
 	"time"
 
@@ -63,11 +63,25 @@ func (t *wsTransport) Dial(addr string, opts ...DialOption) (Socket, error) {
 		return nil, err
 	}
 
-	return &wsTransportSocket{
+	sock := &wsTransportSocket{
 		conn:    conn,
 		codecs:  []codec.Marshaler{&codec.ProtoBufMarshaler{}, &codec.JsonMarshaler{}},
 		timeout: t.opts.Timeout,
-	}, nil
+	}
+
+	// session layer handshake
+	if sock.session, err = NewSession(t.opts.Session, true); err != nil {
+		sock.Close()
+		return nil, err
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(dopts.Timeout))
+	if err := sock.session.Handshake(sock.exchangeHello); err != nil {
+		sock.Close()
+		return nil, err
+	}
+
+	return sock, nil
 }
 
 func (t *wsTransport) ListenAndServe(ctx context.Context, addr string, handler TransportServer, opts ...ListenOption) error {
@@ -75,6 +89,7 @@ func (t *wsTransport) ListenAndServe(ctx context.Context, addr string, handler T
 		ctx:     ctx,
 		srv:     handler,
 		timeout: t.opts.Timeout,
+		session: t.opts.Session,
 	}
 
 	// wsHandler.sockPool.New = newWsTransportSocket
@@ -92,6 +107,7 @@ type wsServeHandler struct {
 	ctx     context.Context
 	srv     TransportServer
 	timeout time.Duration
+	session *SessionOptions
 	// sockPool sync.Pool
 }
 
@@ -108,6 +124,14 @@ func (h *wsServeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	sock.conn = conn
 	sock.closed.Store(false)
 
+	// session layer handshake is done lazily in socket's first Recv or Send
+	session, err := NewSession(h.session, false)
+	if err != nil {
+		sock.Close()
+		return
+	}
+	sock.session = session
+
 	// handle in workerpool
 	h.srv.HandleSocket(h.ctx, sock)
 }
@@ -117,6 +141,7 @@ type wsTransportSocket struct {
 	codecs  []codec.Marshaler
 	timeout time.Duration
 	closed  atomic.Bool
+	session *Session
 }
 
 func (t *wsTransportSocket) Close() {
@@ -141,8 +166,28 @@ func (t *wsTransportSocket) PbMarshaler() codec.Marshaler {
 }
 
 func (t *wsTransportSocket) Recv(r Register) (proto.Message, *MessageHandler, error) {
+	nameCrc, bodyData, err := t.RecvFrame()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// get register handler
+	h, err := r.GetHandler(nameCrc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("wsTransportSocket.Recv failed: %w", err)
+	}
+
+	message, err := t.codecs[0].Unmarshal(bodyData, h.RType)
+	if err != nil {
+		return nil, nil, fmt.Errorf("wsTransportSocket.Recv unmarshal message body failed: %w", err)
+	}
+
+	return message.(proto.Message), h, err
+}
+
+func (t *wsTransportSocket) RecvFrame() (uint32, []byte, error) {
 	if t.IsClosed() {
-		return nil, nil, errors.New("wsTransportSocket.Recv failed: socket closed")
+		return 0, nil, errors.New("wsTransportSocket.RecvFrame failed: socket closed")
 	}
 
 	// set timeout if its greater than 0
@@ -150,44 +195,40 @@ func (t *wsTransportSocket) Recv(r Register) (proto.Message, *MessageHandler, er
 		_ = t.conn.SetReadDeadline(time.Now().Add(t.timeout))
 	}
 
+	if err := t.session.Handshake(t.exchangeHello); err != nil {
+		return 0, nil, fmt.Errorf("wsTransportSocket.RecvFrame failed: %w", err)
+	}
+
 	// Message Header:
 	// 4 bytes message size, size = 4 bytes name crc + proto binary size
-	// 4 bytes message name crc32 id,
+	// 4 bytes message id, crc32 of message name or explicit id,
 	// Message Body:
 
 	_, data, err := t.conn.ReadMessage()
 	if err != nil {
-		return nil, nil, fmt.Errorf("wsTransportSocket.Recv read message error:%v", err)
+		return 0, nil, fmt.Errorf("wsTransportSocket.RecvFrame read message error:%v", err)
+	}
+
+	if len(data) < 8 {
+		return 0, nil, ErrTransportReadSizeTooShort
 	}
 
 	msgLen := binary.LittleEndian.Uint32(data[:4])
 	nameCrc := binary.LittleEndian.Uint32(data[4:8])
 
 	if msgLen > TcpPacketMaxSize {
-		return nil, nil, ErrTransportReadSizeTooLong
+		return 0, nil, ErrTransportReadSizeTooLong
 	}
 
-	// get register handler
-	h, err := r.GetHandler(nameCrc)
+	bodyData, err := t.session.Open(nameCrc, data[8:])
 	if err != nil {
-		return nil, nil, fmt.Errorf("wsTransportSocket.Recv failed: %w", err)
+		return 0, nil, fmt.Errorf("wsTransportSocket.RecvFrame failed: %w", err)
 	}
 
-	bodyData := data[8:]
-	message, err := t.codecs[0].Unmarshal(bodyData, h.RType)
-	if err != nil {
-		return nil, nil, fmt.Errorf("wsTransportSocket.Recv unmarshal message body failed: %w", err)
-	}
-
-	return message.(proto.Message), h, err
+	return nameCrc, bodyData, nil
 }
 
 func (t *wsTransportSocket) Send(m proto.Message) error {
-	// set timeout if its greater than 0
-	if t.timeout > time.Duration(0) {
-		_ = t.conn.SetWriteDeadline(time.Now().Add(t.timeout))
-	}
-
 	// if m.Type < BodyBegin || m.Type >= BodyEnd {
 	// 	return fmt.Errorf("wsTransportSocket.Send marshal type<%d> error", m.Type)
 	// }
@@ -197,25 +238,48 @@ func (t *wsTransportSocket) Send(m proto.Message) error {
 		return err
 	}
 
-	// Message Header:
-	// 4 bytes message size, size = 4 bytes name crc + proto binary size
-	// 4 bytes message name crc32 id,
-	// Message Body:
-	var bodySize uint32 = uint32(4 + len(body))
-	name := m.ProtoReflect().Descriptor().Name()
-	var nameCrc uint32 = crc32.ChecksumIEEE([]byte(name))
-	buffer := bytebufferpool.Get()
-	defer bytebufferpool.Put(buffer)
+	return t.SendFrame(MessageID(m), body)
+}
+
+func (t *wsTransportSocket) SendFrame(nameCrc uint32, body []byte) error {
+	// set timeout if its greater than 0
+	if t.timeout > time.Duration(0) {
+		_ = t.conn.SetWriteDeadline(time.Now().Add(t.timeout))
+	}
 
-	_ = binary.Write(buffer, binary.LittleEndian, bodySize)
-	_ = binary.Write(buffer, binary.LittleEndian, uint32(nameCrc))
-	_, _ = buffer.Write(body)
+	if err := t.session.Handshake(t.exchangeHello); err != nil {
+		return fmt.Errorf("wsTransportSocket.SendFrame failed: %w", err)
+	}
 
-	if err := t.conn.WriteMessage(websocket.BinaryMessage, buffer.Bytes()); err != nil {
-		return err
+	return t.session.Send(nameCrc, body, func(body []byte) error {
+		// Message Header:
+		// 4 bytes message size, size = 4 bytes name crc + proto binary size
+		// 4 bytes message id, crc32 of message name or explicit id,
+		// Message Body:
+		var bodySize uint32 = uint32(4 + len(body))
+		buffer := bytebufferpool.Get()
+		defer bytebufferpool.Put(buffer)
+
+		_ = binary.Write(buffer, binary.LittleEndian, bodySize)
+		_ = binary.Write(buffer, binary.LittleEndian, nameCrc)
+		_, _ = buffer.Write(body)
+
+		return t.conn.WriteMessage(websocket.BinaryMessage, buffer.Bytes())
+	})
+}
+
+// hello packet is sent in a single binary message
+func (t *wsTransportSocket) exchangeHello(hello []byte) ([]byte, error) {
+	if err := t.conn.WriteMessage(websocket.BinaryMessage, hello); err != nil {
+		return nil, err
+	}
+
+	_, data, err := t.conn.ReadMessage()
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return readSessionHello(bytes.NewReader(data))
 }
 
 func (t *wsTransportSocket) Write(body []byte) (int, error) {
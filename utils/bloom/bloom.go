@@ -0,0 +1,84 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+
+	"github.com/bits-and-blooms/bitset"
+)
+
+// 布隆过滤器: Test返回false时key一定不存在, 返回true时key可能存在, 不支持删除
+type Filter struct {
+	bits *bitset.BitSet
+	m    uint // 位数
+	k    uint // 哈希函数个数
+}
+
+// New creates a filter with m bits and k hash functions, m is rounded up to a multiple of 64
+func New(m, k uint) *Filter {
+	return From(make([]uint64, (m+63)/64), k)
+}
+
+// From creates a filter from words returned by Filter.Words, words are used without copy
+func From(words []uint64, k uint) *Filter {
+	if k == 0 {
+		k = 1
+	}
+
+	return &Filter{
+		bits: bitset.From(words),
+		m:    uint(len(words)) * 64,
+		k:    k,
+	}
+}
+
+// 双重哈希计算第i个位置
+func (f *Filter) location(h uint64, i uint) uint {
+	h1 := uint32(h)
+	h2 := uint32(h >> 32)
+	return uint(h1+uint32(i)*h2) % f.m
+}
+
+func hashKey(key uint64) uint64 {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], key)
+
+	h := fnv.New64a()
+	_, _ = h.Write(buf[:])
+	return h.Sum64()
+}
+
+func (f *Filter) Add(key uint64) *Filter {
+	if f.m == 0 {
+		return f
+	}
+
+	h := hashKey(key)
+	for i := uint(0); i < f.k; i++ {
+		f.bits.Set(f.location(h, i))
+	}
+	return f
+}
+
+func (f *Filter) Test(key uint64) bool {
+	if f.m == 0 {
+		return false
+	}
+
+	h := hashKey(key)
+	for i := uint(0); i < f.k; i++ {
+		if !f.bits.Test(f.location(h, i)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Filter) Words() []uint64 {
+	return f.bits.Bytes()
+}
+
+func (f *Filter) ClearAll() *Filter {
+	f.bits.ClearAll()
+	return f
+}
@@ -0,0 +1,50 @@
+package bloom
+
+import "testing"
+
+func TestFilter(t *testing.T) {
+	f := New(8192, 4)
+	for key := uint64(1); key <= 500; key++ {
+		f.Add(key)
+	}
+
+	// 已添加的key一定存在
+	for key := uint64(1); key <= 500; key++ {
+		if !f.Test(key) {
+			t.Fatalf("key %d should exist", key)
+		}
+	}
+
+	// 误判率应该很低
+	falsePositive := 0
+	for key := uint64(10000); key < 20000; key++ {
+		if f.Test(key) {
+			falsePositive++
+		}
+	}
+
+	if falsePositive > 200 {
+		t.Fatalf("false positive %d of 10000 too high", falsePositive)
+	}
+
+	// 从words恢复
+	r := From(append([]uint64(nil), f.Words()...), 4)
+	for key := uint64(1); key <= 500; key++ {
+		if !r.Test(key) {
+			t.Fatalf("restored key %d should exist", key)
+		}
+	}
+
+	r.ClearAll()
+	if r.Test(1) {
+		t.Fatal("key 1 should not exist after clear")
+	}
+}
+
+func TestEmptyFilter(t *testing.T) {
+	f := From(nil, 4)
+	f.Add(1)
+	if f.Test(1) {
+		t.Fatal("empty filter should never hit")
+	}
+}
@@ -30,7 +30,7 @@ func (b *CountableBitset) Bytes() []uint64 {
 }
 
 func (b *CountableBitset) Test(i uint) bool {
-	if i > b.state.Len() {
+	if i >= b.state.Len() {
 		panic(fmt.Sprintf("CountableBitset: index<%d> out of range", i))
 	}
 
@@ -48,7 +48,7 @@ func (b *CountableBitset) Intersection(compare *CountableBitset) (result *Counta
 }
 
 func (b *CountableBitset) Set(i uint, count int16) *CountableBitset {
-	if i > b.state.Len() {
+	if i >= b.state.Len() {
 		panic(fmt.Sprintf("CountableBitset: index<%d> out of range", i))
 	}
 
@@ -58,15 +58,29 @@ func (b *CountableBitset) Set(i uint, count int16) *CountableBitset {
 }
 
 func (b *CountableBitset) Clear(i uint, count int16) *CountableBitset {
-	if i > b.state.Len() {
+	if i >= b.state.Len() {
 		panic(fmt.Sprintf("CountableBitset: index<%d> out of range", i))
 	}
 
-	b.state.Clear(i)
+	// 计数归零才清除
 	b.count[i] -= count
+	if b.count[i] <= 0 {
+		b.count[i] = 0
+		b.state.Clear(i)
+	}
+
 	return b
 }
 
+// 当前计数
+func (b *CountableBitset) Count(i uint) int16 {
+	if i >= uint(len(b.count)) {
+		return 0
+	}
+
+	return b.count[i]
+}
+
 func (b *CountableBitset) ClearAll() *CountableBitset {
 	b.state.ClearAll()
 	for k := range b.count {
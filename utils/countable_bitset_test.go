@@ -0,0 +1,37 @@
+package utils
+
+import "testing"
+
+func TestCountableBitset(t *testing.T) {
+	b := NewCountableBitset(8)
+
+	b.Set(3, 1)
+	b.Set(3, 1)
+	if !b.Test(3) || b.Count(3) != 2 {
+		t.Fatalf("bit 3 count %d, expect set with count 2", b.Count(3))
+	}
+
+	// 计数未归零时保持
+	b.Clear(3, 1)
+	if !b.Test(3) || b.Count(3) != 1 {
+		t.Fatalf("bit 3 count %d, expect set with count 1", b.Count(3))
+	}
+
+	b.Clear(3, 1)
+	if b.Test(3) || b.Count(3) != 0 {
+		t.Fatalf("bit 3 count %d, expect cleared", b.Count(3))
+	}
+
+	// 多次清除不会产生负计数
+	b.Clear(3, 1)
+	b.Set(3, 1)
+	if !b.Test(3) || b.Count(3) != 1 {
+		t.Fatalf("bit 3 count %d, expect set with count 1", b.Count(3))
+	}
+
+	b.Set(5, 2)
+	b.ClearAll()
+	if b.Any() || b.Count(3) != 0 || b.Count(5) != 0 {
+		t.Fatal("ClearAll should reset all bits and counts")
+	}
+}
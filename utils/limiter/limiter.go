@@ -0,0 +1,285 @@
+package limiter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/ratelimit"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// 防刷限流:
+// 每个key(ip或者user id)有一个总的令牌桶, 配置了单独预算的消息再额外检查key+消息名的令牌桶,
+// 超出预算的消息会被丢弃并记录一次违规, 短时间内违规次数达到阈值后key会被临时封禁
+
+var (
+	ErrThrottled      = errors.New("message throttled")
+	ErrBanned         = errors.New("key banned")
+	ErrTooManyConnect = errors.New("too many connections")
+
+	DefaultSweepInterval = time.Minute // 清理闲置令牌桶的间隔
+
+	throttledCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "flood",
+			Name:      "throttled_total",
+			Help:      "超出限流预算被丢弃的消息数量",
+		},
+		[]string{"service", "msg"},
+	)
+
+	droppedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "flood",
+			Name:      "dropped_total",
+			Help:      "被封禁或者连接数超限而丢弃的消息和连接数量",
+		},
+		[]string{"service", "reason"},
+	)
+)
+
+// Budget is a token bucket setting
+type Budget struct {
+	Rate  float64 // tokens filled per second
+	Burst int64   // bucket capacity
+}
+
+func (b Budget) Enabled() bool {
+	return b.Rate > 0 && b.Burst > 0
+}
+
+// ParseBudget parses budget in format "rate/burst", e.g. "0.5/3"
+func ParseBudget(s string) (Budget, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return Budget{}, fmt.Errorf("invalid budget format: %s", s)
+	}
+
+	rate, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return Budget{}, fmt.Errorf("invalid budget rate: %s", s)
+	}
+
+	burst, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+	if err != nil {
+		return Budget{}, fmt.Errorf("invalid budget burst: %s", s)
+	}
+
+	return Budget{Rate: rate, Burst: burst}, nil
+}
+
+// ParseBudgets parses message budgets in format "MsgName=rate/burst"
+func ParseBudgets(ss []string) (map[string]Budget, error) {
+	budgets := make(map[string]Budget, len(ss))
+	for _, s := range ss {
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid message budget format: %s", s)
+		}
+
+		b, err := ParseBudget(kv[1])
+		if err != nil {
+			return nil, err
+		}
+
+		budgets[strings.TrimSpace(kv[0])] = b
+	}
+
+	return budgets, nil
+}
+
+type Options struct {
+	Service      string            // prometheus label
+	Default      Budget            // budget of all messages per key
+	Budgets      map[string]Budget // budget per message name
+	ConnPerKey   int               // max concurrent connections per key, 0 means unlimited
+	BanThreshold int               // violations before ban, 0 means never ban
+	BanDuration  time.Duration     // ban duration, violations are also counted in this window
+}
+
+type bucket struct {
+	*ratelimit.Bucket
+	lastSeen time.Time
+}
+
+type violation struct {
+	count int
+	since time.Time
+}
+
+// Limiter is safe for concurrent use, all methods are no-op on nil limiter
+type Limiter struct {
+	opts Options
+
+	mu         sync.Mutex
+	buckets    map[string]*bucket
+	violations map[string]*violation
+	bans       map[string]time.Time
+	conns      map[string]int
+	lastSweep  time.Time
+}
+
+func New(opts Options) *Limiter {
+	return &Limiter{
+		opts:       opts,
+		buckets:    make(map[string]*bucket),
+		violations: make(map[string]*violation),
+		bans:       make(map[string]time.Time),
+		conns:      make(map[string]int),
+		lastSweep:  time.Now(),
+	}
+}
+
+// Allow takes one token for message from key, returns ErrThrottled if budget exceeded,
+// returns ErrBanned if key is banned
+func (l *Limiter) Allow(key string, msgName string) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	if l.banned(key, now) {
+		droppedCounter.WithLabelValues(l.opts.Service, "banned").Inc()
+		return ErrBanned
+	}
+
+	allowed := l.take(key, l.opts.Default, now)
+	if b, ok := l.opts.Budgets[msgName]; ok && allowed {
+		allowed = l.take(key+"|"+msgName, b, now)
+	}
+
+	if allowed {
+		return nil
+	}
+
+	throttledCounter.WithLabelValues(l.opts.Service, msgName).Inc()
+	if l.violate(key, now) {
+		return ErrBanned
+	}
+
+	return ErrThrottled
+}
+
+// Connect adds a connection to key, returns error if key is banned or has too many connections
+func (l *Limiter) Connect(key string) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.banned(key, time.Now()) {
+		droppedCounter.WithLabelValues(l.opts.Service, "banned").Inc()
+		return ErrBanned
+	}
+
+	if l.opts.ConnPerKey > 0 && l.conns[key] >= l.opts.ConnPerKey {
+		droppedCounter.WithLabelValues(l.opts.Service, "connections").Inc()
+		return ErrTooManyConnect
+	}
+
+	l.conns[key]++
+	return nil
+}
+
+// Disconnect removes a connection from key
+func (l *Limiter) Disconnect(key string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conns[key]--; l.conns[key] <= 0 {
+		delete(l.conns, key)
+	}
+}
+
+func (l *Limiter) take(key string, budget Budget, now time.Time) bool {
+	if !budget.Enabled() {
+		return true
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{Bucket: ratelimit.NewBucketWithRate(budget.Rate, budget.Burst)}
+		l.buckets[key] = b
+	}
+
+	b.lastSeen = now
+	return b.TakeAvailable(1) == 1
+}
+
+func (l *Limiter) banned(key string, now time.Time) bool {
+	until, ok := l.bans[key]
+	if !ok {
+		return false
+	}
+
+	if now.Before(until) {
+		return true
+	}
+
+	delete(l.bans, key)
+	return false
+}
+
+// violate records a violation and returns true if key got banned
+func (l *Limiter) violate(key string, now time.Time) bool {
+	if l.opts.BanThreshold <= 0 {
+		return false
+	}
+
+	v, ok := l.violations[key]
+	if !ok || now.Sub(v.since) > l.opts.BanDuration {
+		v = &violation{since: now}
+		l.violations[key] = v
+	}
+
+	v.count++
+	if v.count < l.opts.BanThreshold {
+		return false
+	}
+
+	delete(l.violations, key)
+	l.bans[key] = now.Add(l.opts.BanDuration)
+	return true
+}
+
+// sweep removes idle buckets and expired violations
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < DefaultSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > DefaultSweepInterval {
+			delete(l.buckets, key)
+		}
+	}
+
+	for key, v := range l.violations {
+		if now.Sub(v.since) > l.opts.BanDuration {
+			delete(l.violations, key)
+		}
+	}
+
+	for key, until := range l.bans {
+		if !now.Before(until) {
+			delete(l.bans, key)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package limiter
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseBudgets(t *testing.T) {
+	budgets, err := ParseBudgets([]string{"C2S_GmCmd=0.5/3", "C2S_HeartBeat = 2/10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b := budgets["C2S_GmCmd"]; b.Rate != 0.5 || b.Burst != 3 {
+		t.Fatalf("C2S_GmCmd budget mismatch: %+v", b)
+	}
+
+	if b := budgets["C2S_HeartBeat"]; b.Rate != 2 || b.Burst != 10 {
+		t.Fatalf("C2S_HeartBeat budget mismatch: %+v", b)
+	}
+
+	if _, err := ParseBudgets([]string{"C2S_GmCmd"}); err == nil {
+		t.Fatal("parse invalid budget should fail")
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	l := New(Options{
+		Service: "test",
+		Default: Budget{Rate: 0.001, Burst: 5},
+		Budgets: map[string]Budget{"C2S_GmCmd": {Rate: 0.001, Burst: 1}},
+	})
+
+	if err := l.Allow("user:1", "C2S_GmCmd"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Allow("user:1", "C2S_GmCmd"); !errors.Is(err, ErrThrottled) {
+		t.Fatalf("second C2S_GmCmd should be throttled, got %v", err)
+	}
+
+	// default budget has 3 tokens left
+	for n := 0; n < 3; n++ {
+		if err := l.Allow("user:1", "C2S_HeartBeat"); err != nil {
+			t.Fatalf("C2S_HeartBeat %d should be allowed, got %v", n, err)
+		}
+	}
+
+	if err := l.Allow("user:1", "C2S_HeartBeat"); !errors.Is(err, ErrThrottled) {
+		t.Fatalf("C2S_HeartBeat should be throttled after default budget exceeded, got %v", err)
+	}
+
+	// other keys are not affected
+	if err := l.Allow("user:2", "C2S_GmCmd"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLimiterBan(t *testing.T) {
+	l := New(Options{
+		Service:      "test",
+		Default:      Budget{Rate: 0.001, Burst: 1},
+		BanThreshold: 2,
+		BanDuration:  50 * time.Millisecond,
+	})
+
+	if err := l.Allow("ip:127.0.0.1", "C2S_Ping"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Allow("ip:127.0.0.1", "C2S_Ping"); !errors.Is(err, ErrThrottled) {
+		t.Fatalf("should be throttled, got %v", err)
+	}
+
+	if err := l.Allow("ip:127.0.0.1", "C2S_Ping"); !errors.Is(err, ErrBanned) {
+		t.Fatalf("should be banned after 2 violations, got %v", err)
+	}
+
+	if err := l.Connect("ip:127.0.0.1"); !errors.Is(err, ErrBanned) {
+		t.Fatalf("banned key should not connect, got %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if err := l.Connect("ip:127.0.0.1"); err != nil {
+		t.Fatalf("ban should expire, got %v", err)
+	}
+}
+
+func TestLimiterConnect(t *testing.T) {
+	l := New(Options{Service: "test", ConnPerKey: 2})
+
+	for n := 0; n < 2; n++ {
+		if err := l.Connect("ip:127.0.0.1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := l.Connect("ip:127.0.0.1"); !errors.Is(err, ErrTooManyConnect) {
+		t.Fatalf("third connection should be rejected, got %v", err)
+	}
+
+	l.Disconnect("ip:127.0.0.1")
+	if err := l.Connect("ip:127.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// nil limiter allows everything
+	var nl *Limiter
+	if err := nl.Allow("ip:127.0.0.1", "C2S_Ping"); err != nil {
+		t.Fatal(err)
+	}
+}
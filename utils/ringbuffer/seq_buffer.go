@@ -0,0 +1,164 @@
+package ringbuffer
+
+import (
+	"encoding/binary"
+)
+
+// record header: 8 bytes seq + 4 bytes id + 4 bytes data length
+const seqRecordHeaderSize = 16
+
+// SeqRecord is a sequence-numbered message stored in SeqBuffer
+type SeqRecord struct {
+	Seq  uint64
+	Id   uint32
+	Data []byte
+}
+
+// SeqBuffer keeps the latest sequence-numbered records within maxSize bytes,
+// the oldest records will be discarded when buffer is full
+type SeqBuffer struct {
+	rb      *RingBuffer
+	maxSize int
+	first   uint64 // seq of the oldest record in buffer
+	last    uint64 // seq of the latest record pushed
+	count   int
+}
+
+func NewSeqBuffer(maxSize int) *SeqBuffer {
+	return &SeqBuffer{
+		rb:      New(0),
+		maxSize: maxSize,
+	}
+}
+
+// Push appends record to buffer, seq must be increasing
+func (b *SeqBuffer) Push(seq uint64, id uint32, data []byte) {
+	b.last = seq
+
+	need := seqRecordHeaderSize + len(data)
+	if need > b.maxSize {
+		b.discardAll()
+		return
+	}
+
+	// discard the oldest records until there is enough space
+	for b.count > 0 && b.rb.Length()+need > b.maxSize {
+		b.discardFirst()
+	}
+
+	var header [seqRecordHeaderSize]byte
+	binary.LittleEndian.PutUint64(header[:8], seq)
+	binary.LittleEndian.PutUint32(header[8:12], id)
+	binary.LittleEndian.PutUint32(header[12:16], uint32(len(data)))
+	_, _ = b.rb.Write(header[:])
+	_, _ = b.rb.Write(data)
+
+	if b.count == 0 {
+		b.first = seq
+	}
+	b.count++
+}
+
+// Replayable reports whether all records after seq are still in buffer
+func (b *SeqBuffer) Replayable(seq uint64) bool {
+	if seq == b.last {
+		return true
+	}
+
+	return seq < b.last && b.count > 0 && seq+1 >= b.first
+}
+
+// Since returns all records whose seq is greater than the given seq,
+// returns false if any of them has been discarded or seq was never pushed
+func (b *SeqBuffer) Since(seq uint64) ([]*SeqRecord, bool) {
+	if !b.Replayable(seq) {
+		return nil, false
+	}
+
+	if seq == b.last {
+		return nil, true
+	}
+
+	records := make([]*SeqRecord, 0, b.last-seq)
+	for _, r := range b.readAll() {
+		if r.Seq > seq {
+			records = append(records, r)
+		}
+	}
+
+	return records, true
+}
+
+// All returns all records still in buffer
+func (b *SeqBuffer) All() []*SeqRecord {
+	if b.count == 0 {
+		return nil
+	}
+
+	return b.readAll()
+}
+
+// Len returns the number of records in buffer
+func (b *SeqBuffer) Len() int {
+	return b.count
+}
+
+// LastSeq returns seq of the latest record pushed
+func (b *SeqBuffer) LastSeq() uint64 {
+	return b.last
+}
+
+// Reset discards all records and resets seq
+func (b *SeqBuffer) Reset() {
+	b.discardAll()
+	b.first = 0
+	b.last = 0
+}
+
+func (b *SeqBuffer) readAll() []*SeqRecord {
+	head, tail := b.rb.LazyReadAll()
+	data := make([]byte, 0, len(head)+len(tail))
+	data = append(data, head...)
+	data = append(data, tail...)
+
+	records := make([]*SeqRecord, 0, b.count)
+	for len(data) >= seqRecordHeaderSize {
+		size := int(binary.LittleEndian.Uint32(data[12:16]))
+		records = append(records, &SeqRecord{
+			Seq:  binary.LittleEndian.Uint64(data[:8]),
+			Id:   binary.LittleEndian.Uint32(data[8:12]),
+			Data: data[seqRecordHeaderSize : seqRecordHeaderSize+size],
+		})
+		data = data[seqRecordHeaderSize+size:]
+	}
+
+	return records
+}
+
+func (b *SeqBuffer) discardFirst() {
+	head, tail := b.rb.LazyRead(seqRecordHeaderSize)
+	header := make([]byte, 0, seqRecordHeaderSize)
+	header = append(header, head...)
+	header = append(header, tail...)
+
+	size := int(binary.LittleEndian.Uint32(header[12:16]))
+	b.rb.Shift(seqRecordHeaderSize + size)
+	b.count--
+
+	if b.count == 0 {
+		return
+	}
+
+	head, tail = b.rb.LazyRead(8)
+	header = header[:0]
+	header = append(header, head...)
+	header = append(header, tail...)
+	b.first = binary.LittleEndian.Uint64(header)
+}
+
+func (b *SeqBuffer) discardAll() {
+	if b.count > 0 {
+		b.rb.Shift(b.rb.Length())
+	}
+	b.count = 0
+}
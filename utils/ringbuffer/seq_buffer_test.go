@@ -0,0 +1,70 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSeqBuffer(t *testing.T) {
+	b := NewSeqBuffer(100)
+	for seq := uint64(1); seq <= 3; seq++ {
+		b.Push(seq, uint32(seq), bytes.Repeat([]byte{byte(seq)}, 10))
+	}
+
+	records, ok := b.Since(1)
+	if !ok || len(records) != 2 {
+		t.Fatalf("Since(1) failed: ok=%v, len=%d", ok, len(records))
+	}
+
+	for n, r := range records {
+		seq := uint64(n + 2)
+		if r.Seq != seq || r.Id != uint32(seq) || !bytes.Equal(r.Data, bytes.Repeat([]byte{byte(seq)}, 10)) {
+			t.Fatalf("record %d mismatch: %+v", n, r)
+		}
+	}
+
+	if records, ok := b.Since(3); !ok || len(records) != 0 {
+		t.Fatalf("Since(3) should return nothing: ok=%v, len=%d", ok, len(records))
+	}
+
+	if _, ok := b.Since(4); ok {
+		t.Fatal("Since(4) should fail before seq 4 pushed")
+	}
+
+	// 26 bytes per record, the oldest records will be discarded
+	for seq := uint64(4); seq <= 10; seq++ {
+		b.Push(seq, uint32(seq), bytes.Repeat([]byte{byte(seq)}, 10))
+	}
+
+	if b.Len() != 3 {
+		t.Fatalf("buffer should keep 3 records, got %d", b.Len())
+	}
+
+	if _, ok := b.Since(6); ok || b.Replayable(6) {
+		t.Fatal("Since(6) should fail after records discarded")
+	}
+
+	records, ok = b.Since(7)
+	if !ok || len(records) != 3 || records[0].Seq != 8 || records[2].Seq != 10 {
+		t.Fatalf("Since(7) failed: ok=%v, records=%v", ok, records)
+	}
+
+	if all := b.All(); len(all) != 3 || all[0].Seq != 8 || all[2].Seq != 10 {
+		t.Fatalf("All failed: records=%v", all)
+	}
+
+	// record larger than buffer
+	b.Push(11, 11, make([]byte, 200))
+	if _, ok := b.Since(10); ok {
+		t.Fatal("Since(10) should fail after oversized record")
+	}
+
+	b.Reset()
+	if records, ok := b.Since(0); !ok || len(records) != 0 {
+		t.Fatalf("Since(0) after reset failed: ok=%v, len=%d", ok, len(records))
+	}
+
+	if all := b.All(); len(all) != 0 {
+		t.Fatalf("All after reset should return nothing, got %d", len(all))
+	}
+}
@@ -35,14 +35,21 @@ func SpecificIDSelector(nodeId string) selector.SelectOption {
 	})
 }
 
-// select node by consistent hash
-func ConsistentHashSelector(cons *consistent.Consistent, id string) selector.SelectOption {
+// select node by consistent hash, nodes matched by excludes will be removed from hash ring
+func ConsistentHashSelector(cons *consistent.Consistent, id string, excludes ...func(*registry.Node) bool) selector.SelectOption {
 
 	return selector.WithStrategy(func(srvs []*registry.Service) selector.Next {
 		nodes := make(map[string]*registry.Node)
 		var names []string
 		for _, service := range srvs {
+		nextNode:
 			for _, node := range service.Nodes {
+				for _, exclude := range excludes {
+					if exclude(node) {
+						continue nextNode
+					}
+				}
+
 				names = append(names, node.Id)
 				nodes[node.Id] = node
 			}
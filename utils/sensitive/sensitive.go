@@ -0,0 +1,203 @@
+package sensitive
+
+import (
+	"sync/atomic"
+	"unicode"
+)
+
+// 屏蔽字
+type Word struct {
+	Text   string
+	Action int32
+}
+
+// 命中的屏蔽字, Start和End为rune下标, 区间左闭右开
+type Hit struct {
+	Start int
+	End   int
+	Word  *Word
+}
+
+type node struct {
+	children map[rune]int32
+	fail     int32
+	output   int32 // 以此节点结尾的屏蔽字下标, -1表示没有
+	link     int32 // 沿fail链最近的有输出的节点, -1表示没有
+}
+
+// Aho-Corasick自动机, 匹配时不区分大小写
+type Matcher struct {
+	nodes []*node
+	words []*Word
+}
+
+func newNode() *node {
+	return &node{
+		children: make(map[rune]int32),
+		output:   -1,
+		link:     -1,
+	}
+}
+
+func normalize(r rune) rune {
+	return unicode.ToLower(r)
+}
+
+func NewMatcher(words []*Word) *Matcher {
+	m := &Matcher{
+		nodes: []*node{newNode()},
+		words: make([]*Word, 0, len(words)),
+	}
+
+	// 建立trie
+	for _, w := range words {
+		if len(w.Text) == 0 {
+			continue
+		}
+
+		cur := int32(0)
+		for _, r := range w.Text {
+			r = normalize(r)
+			next, ok := m.nodes[cur].children[r]
+			if !ok {
+				next = int32(len(m.nodes))
+				m.nodes = append(m.nodes, newNode())
+				m.nodes[cur].children[r] = next
+			}
+			cur = next
+		}
+
+		// 重复的屏蔽字取最严重的处理方式
+		if out := m.nodes[cur].output; out != -1 {
+			if w.Action > m.words[out].Action {
+				m.words[out].Action = w.Action
+			}
+			continue
+		}
+
+		m.nodes[cur].output = int32(len(m.words))
+		m.words = append(m.words, &Word{Text: w.Text, Action: w.Action})
+	}
+
+	// 广度优先建立fail指针
+	queue := make([]int32, 0, len(m.nodes))
+	for _, child := range m.nodes[0].children {
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for r, child := range m.nodes[cur].children {
+			fail := m.nodes[cur].fail
+			for {
+				if next, ok := m.nodes[fail].children[r]; ok && next != child {
+					m.nodes[child].fail = next
+					break
+				}
+
+				if fail == 0 {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+
+			f := m.nodes[child].fail
+			if m.nodes[f].output != -1 {
+				m.nodes[child].link = f
+			} else {
+				m.nodes[child].link = m.nodes[f].link
+			}
+
+			queue = append(queue, child)
+		}
+	}
+
+	return m
+}
+
+// 返回所有命中的屏蔽字
+func (m *Matcher) Match(text string) []*Hit {
+	var hits []*Hit
+
+	cur := int32(0)
+	pos := 0
+	for _, r := range text {
+		r = normalize(r)
+		pos++
+
+		for {
+			if next, ok := m.nodes[cur].children[r]; ok {
+				cur = next
+				break
+			}
+
+			if cur == 0 {
+				break
+			}
+			cur = m.nodes[cur].fail
+		}
+
+		for n := cur; n != -1; n = m.nodes[n].link {
+			out := m.nodes[n].output
+			if out == -1 {
+				continue
+			}
+
+			w := m.words[out]
+			hits = append(hits, &Hit{
+				Start: pos - len([]rune(w.Text)),
+				End:   pos,
+				Word:  w,
+			})
+		}
+	}
+
+	return hits
+}
+
+// 过滤文本, 命中的屏蔽字替换为*, 返回替换后的文本和最严重的处理方式
+func (m *Matcher) Filter(text string) (string, int32, bool) {
+	hits := m.Match(text)
+	if len(hits) == 0 {
+		return text, 0, false
+	}
+
+	runes := []rune(text)
+	action := hits[0].Word.Action
+	for _, h := range hits {
+		for n := h.Start; n < h.End; n++ {
+			runes[n] = '*'
+		}
+
+		if h.Word.Action > action {
+			action = h.Word.Action
+		}
+	}
+
+	return string(runes), action, true
+}
+
+var defaultMatcher atomic.Pointer[Matcher]
+
+// 重新加载屏蔽字, 正在进行的过滤不受影响
+func Reset(words []*Word) {
+	defaultMatcher.Store(NewMatcher(words))
+}
+
+// 使用全局屏蔽字过滤文本, 屏蔽字未加载时不做处理
+func Filter(text string) (string, int32, bool) {
+	m := defaultMatcher.Load()
+	if m == nil {
+		return text, 0, false
+	}
+
+	return m.Filter(text)
+}
+
+// 文本是否包含屏蔽字
+func Contains(text string) bool {
+	_, _, hit := Filter(text)
+	return hit
+}
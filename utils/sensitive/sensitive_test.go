@@ -0,0 +1,54 @@
+package sensitive
+
+import (
+	"testing"
+)
+
+func TestMatcher(t *testing.T) {
+	m := NewMatcher([]*Word{
+		{Text: "he", Action: 0},
+		{Text: "she", Action: 1},
+		{Text: "his", Action: 0},
+		{Text: "hers", Action: 2},
+		{Text: "屏蔽", Action: 0},
+		{Text: "屏蔽", Action: 2},
+	})
+
+	hits := m.Match("uSHErs")
+	if len(hits) != 3 {
+		t.Fatalf("uSHErs should hit she, he, hers, got %d", len(hits))
+	}
+
+	cases := []struct {
+		text   string
+		result string
+		action int32
+		hit    bool
+	}{
+		{"hello", "**llo", 0, true},
+		{"ushers", "u*****", 2, true},
+		{"this is", "t*** is", 0, true},
+		{"不要屏蔽我", "不要**我", 2, true},
+		{"clean text", "clean text", 0, false},
+		{"", "", 0, false},
+	}
+
+	for _, c := range cases {
+		result, action, hit := m.Filter(c.text)
+		if result != c.result || action != c.action || hit != c.hit {
+			t.Errorf("Filter(%q) = (%q, %d, %v), want (%q, %d, %v)", c.text, result, action, hit, c.result, c.action, c.hit)
+		}
+	}
+}
+
+func TestReset(t *testing.T) {
+	Reset(nil)
+	if Contains("anything") {
+		t.Fatal("empty matcher should not hit")
+	}
+
+	Reset([]*Word{{Text: "bad", Action: 1}})
+	if result, action, hit := Filter("not BAD"); !hit || action != 1 || result != "not ***" {
+		t.Fatalf("Filter after reset failed: %q, %d, %v", result, action, hit)
+	}
+}
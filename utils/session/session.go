@@ -0,0 +1,131 @@
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	json "github.com/json-iterator/go"
+)
+
+var (
+	DefaultTokenExpire = 10 * time.Minute // session token expires in 10 minutes by default
+
+	ErrTokenMalformed = errors.New("session token malformed")
+	ErrTokenSignature = errors.New("session token signature invalid")
+	ErrTokenExpired   = errors.New("session token expired")
+	ErrTokenMismatch  = errors.New("session token mismatch")
+	ErrEmptySecret    = errors.New("session token secret is empty")
+)
+
+// Token is issued by gate's select_game_addr, and presented in Handshake and C2S_AccountLogon
+type Token struct {
+	UserId   string `json:"userId"`
+	GameId   int16  `json:"gameId"`
+	ExpireAt int64  `json:"expireAt"`
+}
+
+// Signer signs and verifies session tokens with HMAC-SHA256,
+// gate and game must be configured with the same secret
+type Signer struct {
+	secret []byte
+	expire time.Duration
+}
+
+// NewSigner returns error if secret is empty, anyone could forge tokens with an empty secret
+func NewSigner(secret string, expire time.Duration) (*Signer, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+
+	if expire <= 0 {
+		expire = DefaultTokenExpire
+	}
+
+	return &Signer{
+		secret: []byte(secret),
+		expire: expire,
+	}, nil
+}
+
+func (s *Signer) mac(payload string) string {
+	h := hmac.New(sha256.New, s.secret)
+	_, _ = h.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// Sign returns token string in the form of base64(payload).base64(signature)
+func (s *Signer) Sign(userId string, gameId int16) (string, error) {
+	t := &Token{
+		UserId:   userId,
+		GameId:   gameId,
+		ExpireAt: time.Now().Add(s.expire).Unix(),
+	}
+
+	data, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + s.mac(payload), nil
+}
+
+// Verify checks token's signature and expiry
+func (s *Signer) Verify(token string) (*Token, error) {
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrTokenMalformed
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(s.mac(payload))) {
+		return nil, ErrTokenSignature
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	t := &Token{}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	if time.Now().Unix() > t.ExpireAt {
+		return nil, ErrTokenExpired
+	}
+
+	return t, nil
+}
+
+// CompareVersion compares dotted version strings like "1.2.10",
+// returns -1 if a < b, 0 if a == b, 1 if a > b
+func CompareVersion(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for n := 0; n < len(as) || n < len(bs); n++ {
+		var x, y int
+		if n < len(as) {
+			x, _ = strconv.Atoi(as[n])
+		}
+
+		if n < len(bs) {
+			y, _ = strconv.Atoi(bs[n])
+		}
+
+		if x < y {
+			return -1
+		}
+
+		if x > y {
+			return 1
+		}
+	}
+
+	return 0
+}
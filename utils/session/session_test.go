@@ -0,0 +1,77 @@
+package session
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSignVerify(t *testing.T) {
+	s, _ := NewSigner("secret", time.Minute)
+	token, err := s.Sign("user_1", 201)
+	if err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+
+	tk, err := s.Verify(token)
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+
+	if tk.UserId != "user_1" || tk.GameId != 201 {
+		t.Fatalf("verify result invalid: %+v", tk)
+	}
+
+	// signed by another secret
+	another, _ := NewSigner("another", time.Minute)
+	if _, err := another.Verify(token); !errors.Is(err, ErrTokenSignature) {
+		t.Fatalf("verify with another secret should fail, got %v", err)
+	}
+
+	// tampered payload
+	if _, err := s.Verify("x" + token); !errors.Is(err, ErrTokenSignature) {
+		t.Fatalf("verify tampered token should fail, got %v", err)
+	}
+
+	if _, err := s.Verify("malformed"); !errors.Is(err, ErrTokenMalformed) {
+		t.Fatalf("verify malformed token should fail, got %v", err)
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	s, _ := NewSigner("secret", time.Minute)
+	s.expire = -time.Minute
+	token, err := s.Sign("user_1", 201)
+	if err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+
+	if _, err := s.Verify(token); !errors.Is(err, ErrTokenExpired) {
+		t.Fatalf("verify expired token should fail, got %v", err)
+	}
+}
+
+func TestCompareVersion(t *testing.T) {
+	cases := []struct {
+		a, b string
+		ret  int
+	}{
+		{"0.0.1", "0.0.1", 0},
+		{"0.0.1", "0.0.2", -1},
+		{"0.1", "0.0.9", 1},
+		{"1.2.10", "1.2.9", 1},
+		{"1.2", "1.2.0", 0},
+	}
+
+	for _, c := range cases {
+		if ret := CompareVersion(c.a, c.b); ret != c.ret {
+			t.Errorf("CompareVersion(%s, %s) = %d, want %d", c.a, c.b, ret, c.ret)
+		}
+	}
+}
+
+func TestEmptySecret(t *testing.T) {
+	if _, err := NewSigner("", time.Minute); !errors.Is(err, ErrEmptySecret) {
+		t.Fatalf("new signer with empty secret should fail, got %v", err)
+	}
+}
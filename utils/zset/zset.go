@@ -624,6 +624,23 @@ func (z *SortedSet) RevRange(start, end int64, f func(float64, int64, any)) {
 	z.commonRange(start, end, true, f)
 }
 
+// RangeByScore implements ZRANGEBYSCORE with LIMIT, limit <= 0 means no limit
+func (z *SortedSet) RangeByScore(min, max float64, limit int64, f func(float64, int64, any)) {
+	ran := &zrangespec{min: min, max: max}
+	for node := z.zsl.zslFirstInRange(ran); node != nil; node = node.level[0].forward {
+		if !zslValueLteMax(node.score, ran) {
+			break
+		}
+
+		k := node.objID
+		f(node.score, k, z.dict[k].attachment)
+
+		if limit--; limit == 0 {
+			break
+		}
+	}
+}
+
 func (z *SortedSet) commonRange(start, end int64, reverse bool, f func(float64, int64, any)) {
 	l := z.zsl.length
 	if start < 0 {
@@ -27,3 +27,34 @@ func TestRankZSet(t *testing.T) {
 	rank, score, data = s.GetRank(3, true)
 	fmt.Println("player3:", rank, score, data)
 }
+
+func TestRangeByScore(t *testing.T) {
+	s := New()
+	for n := int64(1); n <= 5; n++ {
+		s.Set(float64(n*10), n, 1000+n, n)
+	}
+
+	var keys []int64
+	s.RangeByScore(20, 40, 0, func(score float64, key int64, data any) {
+		keys = append(keys, key)
+	})
+	if len(keys) != 3 || keys[0] != 2 || keys[2] != 4 {
+		t.Fatalf("RangeByScore[20, 40] got %v", keys)
+	}
+
+	keys = keys[:0]
+	s.RangeByScore(0, 100, 2, func(score float64, key int64, data any) {
+		keys = append(keys, key)
+	})
+	if len(keys) != 2 || keys[0] != 1 {
+		t.Fatalf("RangeByScore with limit got %v", keys)
+	}
+
+	keys = keys[:0]
+	s.RangeByScore(60, 100, 0, func(score float64, key int64, data any) {
+		keys = append(keys, key)
+	})
+	if len(keys) != 0 {
+		t.Fatalf("RangeByScore out of range got %v", keys)
+	}
+}